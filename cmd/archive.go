@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	cmsfs "github.com/rgonek/confluence-markdown-sync/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+// archivedDirName is the space subdirectory that archive parks files in.
+// Push, validate and diff skip it like a space config ignore pattern.
+const archivedDirName = "archived"
+
+// archiveRemote defines the Confluence API methods used by archive and unarchive.
+type archiveRemote interface {
+	ArchivePages(ctx context.Context, pageIDs []string) (confluence.ArchiveResult, error)
+	WaitForArchiveTask(ctx context.Context, taskID string, opts confluence.ArchiveTaskWaitOptions) (confluence.ArchiveTaskStatus, error)
+	UnarchivePage(ctx context.Context, pageID string) (confluence.Page, error)
+}
+
+var newArchiveRemote = func(cfg *config.Config) (archiveRemote, error) {
+	return newConfluenceClientFromConfig(cfg)
+}
+
+// archiveTarget is the page an archive or unarchive command acts on. relPath
+// is empty when only a page ID is known locally.
+type archiveTarget struct {
+	spaceDir string
+	relPath  string
+	pageID   string
+}
+
+func newArchiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive FILE|PAGE_ID",
+		Short: "Archive a page in Confluence without deleting its local file",
+		Long: `archive archives one tracked page in Confluence, stops tracking it locally and moves the
+file into the space's archived/ directory, which pull, push, validate and diff skip. The page
+ID is read from the file's frontmatter, or the page is looked up by ID in the workspace state.
+The file is moved rather than left in place because it would still carry the archived page's
+id. Use 'conf unarchive' to restore the page.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArchive(cmd, args[0])
+		},
+	}
+	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve archiving")
+	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when confirmation is required")
+	return cmd
+}
+
+func newUnarchiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unarchive FILE|PAGE_ID",
+		Short: "Restore an archived page and track its local file again",
+		Long: `unarchive restores an archived page to the current pages of its space. A file under the
+space's archived/ directory is moved back to its original path and tracked again; run
+'conf pull' afterwards to refresh its content.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnarchive(cmd, args[0])
+		},
+	}
+	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve restoring")
+	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when confirmation is required")
+	return cmd
+}
+
+func runArchive(cmd *cobra.Command, raw string) (runErr error) {
+	if err := ensureWorkspaceSyncReady("archive"); err != nil {
+		return err
+	}
+	out := ensureSynchronizedCmdOutput(cmd)
+	ctx := getCommandContext(cmd)
+
+	lock, err := acquireWorkspaceLock("archive")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := lock.Release(); runErr == nil && releaseErr != nil {
+			runErr = releaseErr
+		}
+	}()
+
+	target, err := resolveArchiveTarget(raw, false)
+	if err != nil {
+		return err
+	}
+	if target.relPath == "" {
+		return fmt.Errorf("page %s is not tracked in this workspace; pass its Markdown file instead", target.pageID)
+	}
+	description := fmt.Sprintf("The local file is moved to %s/%s.", archivedDirName, target.relPath)
+	if err := confirmArchiveAction(cmd.InOrStdin(), out, "archive", fmt.Sprintf("Archive page %s (%s) in Confluence?", target.pageID, target.relPath), description); err != nil {
+		return err
+	}
+
+	remote, err := openArchiveRemote(target.spaceDir)
+	if err != nil {
+		return err
+	}
+	defer closeRemoteIfPossible(remote)
+
+	result, err := remote.ArchivePages(ctx, []string{target.pageID})
+	switch {
+	case errors.Is(err, confluence.ErrArchived):
+		_, _ = fmt.Fprintf(out, "page %s was already archived\n", target.pageID)
+	case err != nil:
+		return fmt.Errorf("archive page %s: %w", target.pageID, err)
+	case strings.TrimSpace(result.TaskID) != "":
+		if _, err := remote.WaitForArchiveTask(ctx, result.TaskID, confluence.ArchiveTaskWaitOptions{}); err != nil {
+			return fmt.Errorf("wait for archive of page %s: %w", target.pageID, err)
+		}
+	}
+
+	state, err := cmsfs.LoadState(target.spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+	delete(state.PagePathIndex, target.relPath)
+	delete(state.PageETags, target.pageID)
+
+	movedTo := filepath.ToSlash(filepath.Join(archivedDirName, filepath.FromSlash(target.relPath)))
+	if err := moveMarkdownWithSidecar(target.spaceDir, target.relPath, movedTo); err != nil {
+		return err
+	}
+	if err := cmsfs.SaveState(target.spaceDir, state); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "archived page %s (%s)\n", target.pageID, target.relPath)
+	_, _ = fmt.Fprintf(out, "moved %s to %s\n", target.relPath, movedTo)
+	return nil
+}
+
+func runUnarchive(cmd *cobra.Command, raw string) (runErr error) {
+	if err := ensureWorkspaceSyncReady("unarchive"); err != nil {
+		return err
+	}
+	out := ensureSynchronizedCmdOutput(cmd)
+	ctx := getCommandContext(cmd)
+
+	lock, err := acquireWorkspaceLock("unarchive")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := lock.Release(); runErr == nil && releaseErr != nil {
+			runErr = releaseErr
+		}
+	}()
+
+	target, err := resolveArchiveTarget(raw, true)
+	if err != nil {
+		return err
+	}
+
+	restoredRelPath := strings.TrimPrefix(target.relPath, archivedDirName+"/")
+	description := "Run 'conf pull' afterwards to download it."
+	if target.relPath != "" {
+		description = fmt.Sprintf("%s is tracked again as %s.", target.relPath, restoredRelPath)
+		if _, err := os.Stat(filepath.Join(target.spaceDir, filepath.FromSlash(restoredRelPath))); restoredRelPath != target.relPath && err == nil {
+			return fmt.Errorf("cannot restore %s: %s already exists", target.relPath, restoredRelPath)
+		}
+	}
+	if err := confirmArchiveAction(cmd.InOrStdin(), out, "unarchive", fmt.Sprintf("Restore archived page %s?", target.pageID), description); err != nil {
+		return err
+	}
+
+	remote, err := openArchiveRemote(target.spaceDir)
+	if err != nil {
+		return err
+	}
+	defer closeRemoteIfPossible(remote)
+
+	page, err := remote.UnarchivePage(ctx, target.pageID)
+	if err != nil {
+		return fmt.Errorf("unarchive page %s: %w", target.pageID, err)
+	}
+	_, _ = fmt.Fprintf(out, "restored page %s (version %d)\n", target.pageID, page.Version)
+	if target.relPath == "" {
+		_, _ = fmt.Fprintln(out, "no local file found; run 'conf pull' to download it")
+		return nil
+	}
+
+	if restoredRelPath != target.relPath {
+		if err := moveMarkdownWithSidecar(target.spaceDir, target.relPath, restoredRelPath); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "moved %s to %s\n", target.relPath, restoredRelPath)
+	}
+
+	absPath := filepath.Join(target.spaceDir, filepath.FromSlash(restoredRelPath))
+	doc, err := cmsfs.ReadMarkdownDocument(absPath)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", restoredRelPath, err)
+	}
+	if page.Version > 0 {
+		doc.Frontmatter.Version = page.Version
+	}
+	if err := cmsfs.WriteMarkdownDocument(absPath, doc); err != nil {
+		return fmt.Errorf("write %s: %w", restoredRelPath, err)
+	}
+
+	state, err := cmsfs.LoadState(target.spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+	state.PagePathIndex[restoredRelPath] = target.pageID
+	if err := cmsfs.SaveState(target.spaceDir, state); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	_, _ = fmt.Fprintln(out, "run 'conf pull' to refresh its content")
+	return nil
+}
+
+// resolveArchiveTarget resolves a Markdown file or a page ID. A page ID is
+// looked up in every space state of the workspace and, when searchArchived is
+// set, among the files parked under archived/ directories.
+func resolveArchiveTarget(raw string, searchArchived bool) (archiveTarget, error) {
+	raw = strings.TrimSpace(raw)
+	target := config.ParseTarget(raw)
+	if target.IsFile() {
+		initialCtx, err := resolveInitialPullContext(target)
+		if err != nil {
+			return archiveTarget{}, err
+		}
+		absPath, err := filepath.Abs(raw)
+		if err != nil {
+			return archiveTarget{}, err
+		}
+		relPath, err := filepath.Rel(initialCtx.spaceDir, absPath)
+		if err != nil {
+			return archiveTarget{}, err
+		}
+		return archiveTarget{spaceDir: initialCtx.spaceDir, relPath: normalizeRepoRelPath(relPath), pageID: initialCtx.targetPageID}, nil
+	}
+
+	root, err := gitRepoRoot()
+	if err != nil {
+		return archiveTarget{}, err
+	}
+	states, err := cmsfs.FindAllStateFiles(root)
+	if err != nil {
+		return archiveTarget{}, fmt.Errorf("load workspace state: %w", err)
+	}
+	for spaceDir, state := range states {
+		for relPath, pageID := range state.PagePathIndex {
+			if strings.TrimSpace(pageID) == raw {
+				return archiveTarget{spaceDir: spaceDir, relPath: normalizeRepoRelPath(relPath), pageID: raw}, nil
+			}
+		}
+	}
+	if searchArchived {
+		for spaceDir := range states {
+			if relPath := findArchivedMarkdownByPageID(spaceDir, raw); relPath != "" {
+				return archiveTarget{spaceDir: spaceDir, relPath: relPath, pageID: raw}, nil
+			}
+		}
+		return archiveTarget{spaceDir: root, pageID: raw}, nil
+	}
+	return archiveTarget{pageID: raw}, nil
+}
+
+// findArchivedMarkdownByPageID returns the space-relative path of the file
+// under archived/ whose frontmatter id is pageID, or "".
+func findArchivedMarkdownByPageID(spaceDir, pageID string) string {
+	found := ""
+	_ = filepath.WalkDir(filepath.Join(spaceDir, archivedDirName), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || found != "" {
+			return nil
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") || cmsfs.IsPageSidecar(path) {
+			return nil
+		}
+		if fm, fmErr := cmsfs.ReadFrontmatter(path); fmErr == nil && strings.TrimSpace(fm.ID) == pageID {
+			if relPath, relErr := filepath.Rel(spaceDir, path); relErr == nil {
+				found = normalizeRepoRelPath(relPath)
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// moveMarkdownWithSidecar moves a Markdown file, and its comments and history
+// sidecars when present, between two space-relative paths.
+func moveMarkdownWithSidecar(spaceDir, fromRelPath, toRelPath string) error {
+	fromAbs := filepath.Join(spaceDir, filepath.FromSlash(fromRelPath))
+	toAbs := filepath.Join(spaceDir, filepath.FromSlash(toRelPath))
+	if err := os.MkdirAll(filepath.Dir(toAbs), 0o750); err != nil {
+		return fmt.Errorf("prepare directory for %s: %w", toRelPath, err)
+	}
+	if err := os.Rename(fromAbs, toAbs); err != nil {
+		return fmt.Errorf("move %s to %s: %w", fromRelPath, toRelPath, err)
+	}
+	toSidecars := cmsfs.PageSidecarPaths(toAbs)
+	for i, fromSidecar := range cmsfs.PageSidecarPaths(fromAbs) {
+		if err := os.Rename(fromSidecar, toSidecars[i]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("move sidecar of %s: %w", fromRelPath, err)
+		}
+	}
+	// Drop directories the move left empty; os.Remove fails on the first
+	// non-empty one, which ends the walk.
+	for dir := filepath.Dir(fromAbs); dir != filepath.Clean(spaceDir) && strings.HasPrefix(dir, spaceDir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func openArchiveRemote(spaceDir string) (archiveRemote, error) {
+	envPath := findEnvPath(spaceDir)
+	cfg, err := config.Load(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	remote, err := newArchiveRemote(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create Confluence client: %w", err)
+	}
+	return remote, nil
+}
+
+func confirmArchiveAction(in io.Reader, out io.Writer, action, title, description string) error {
+	if flagYes {
+		return nil
+	}
+	if flagNonInteractive {
+		return fmt.Errorf("%s requires confirmation; rerun with --yes", action)
+	}
+
+	if outputSupportsProgress(out) {
+		var confirm bool
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(title).
+					Description(description).
+					Value(&confirm),
+			),
+		).WithOutput(out)
+		if err := form.Run(); err != nil {
+			return err
+		}
+		if !confirm {
+			return fmt.Errorf("%s cancelled", action)
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(out, "%s [y/N]: ", title); err != nil {
+		return fmt.Errorf("write prompt: %w", err)
+	}
+	choice, err := readPromptLine(in)
+	if err != nil {
+		return err
+	}
+	choice = strings.ToLower(strings.TrimSpace(choice))
+	if choice != "y" && choice != "yes" {
+		return fmt.Errorf("%s cancelled", action)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+type fakeArchiveRemote struct {
+	archived   []string
+	waited     []string
+	unarchived []string
+}
+
+func (f *fakeArchiveRemote) ArchivePages(_ context.Context, pageIDs []string) (confluence.ArchiveResult, error) {
+	f.archived = append(f.archived, pageIDs...)
+	return confluence.ArchiveResult{TaskID: "task-1"}, nil
+}
+
+func (f *fakeArchiveRemote) WaitForArchiveTask(_ context.Context, taskID string, _ confluence.ArchiveTaskWaitOptions) (confluence.ArchiveTaskStatus, error) {
+	f.waited = append(f.waited, taskID)
+	return confluence.ArchiveTaskStatus{TaskID: taskID, State: confluence.ArchiveTaskStateSucceeded}, nil
+}
+
+func (f *fakeArchiveRemote) UnarchivePage(_ context.Context, pageID string) (confluence.Page, error) {
+	f.unarchived = append(f.unarchived, pageID)
+	return confluence.Page{ID: pageID, Status: "current", Version: 4}, nil
+}
+
+func setupArchiveWorkspace(t *testing.T) (string, *fakeArchiveRemote) {
+	t.Helper()
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Old.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Old", ID: "7", Version: 3},
+		Body:        "retired guide\n",
+	})
+	state := fs.NewSpaceState()
+	state.SpaceKey = "ENG"
+	state.PagePathIndex = map[string]string{"Guides/Old.md": "7"}
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "baseline")
+
+	fake := &fakeArchiveRemote{}
+	oldFactory := newArchiveRemote
+	newArchiveRemote = func(_ *config.Config) (archiveRemote, error) { return fake, nil }
+	t.Cleanup(func() { newArchiveRemote = oldFactory })
+	return spaceDir, fake
+}
+
+func TestRunArchive_MovesFileAndUnarchiveRestoresIt(t *testing.T) {
+	runParallelCommandTest(t)
+	spaceDir, fake := setupArchiveWorkspace(t)
+
+	// Command constructors reset the flag variables to their defaults.
+	cmd := newArchiveCmd()
+	setAutomationFlags(t, true, true)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runArchive(cmd, filepath.Join("ENG", "Guides", "Old.md")); err != nil {
+		t.Fatalf("runArchive() error: %v\n%s", err, out.String())
+	}
+	if len(fake.archived) != 1 || fake.archived[0] != "7" || len(fake.waited) != 1 {
+		t.Fatalf("archived=%v waited=%v", fake.archived, fake.waited)
+	}
+	archivedPath := filepath.Join(spaceDir, "archived", "Guides", "Old.md")
+	if _, err := os.Stat(archivedPath); err != nil {
+		t.Fatalf("expected file under archived/: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Guides")); !os.IsNotExist(err) {
+		t.Fatalf("expected emptied Guides/ directory to be removed, stat err = %v", err)
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if len(state.PagePathIndex) != 0 {
+		t.Fatalf("archived page still tracked: %v", state.PagePathIndex)
+	}
+
+	// Unarchive by page ID finds the parked file through its frontmatter.
+	cmd = newUnarchiveCmd()
+	setAutomationFlags(t, true, true)
+	out.Reset()
+	cmd.SetOut(out)
+	if err := runUnarchive(cmd, "7"); err != nil {
+		t.Fatalf("runUnarchive() error: %v\n%s", err, out.String())
+	}
+	if len(fake.unarchived) != 1 || fake.unarchived[0] != "7" {
+		t.Fatalf("unarchived = %v", fake.unarchived)
+	}
+	restoredPath := filepath.Join(spaceDir, "Guides", "Old.md")
+	doc, err := fs.ReadMarkdownDocument(restoredPath)
+	if err != nil {
+		t.Fatalf("read restored file: %v", err)
+	}
+	if doc.Frontmatter.Version != 4 || doc.Body != "retired guide\n" {
+		t.Fatalf("restored doc = %+v", doc)
+	}
+	state, err = fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if got := state.PagePathIndex["Guides/Old.md"]; got != "7" {
+		t.Fatalf("restored page not tracked: %v", state.PagePathIndex)
+	}
+	if !strings.Contains(out.String(), "conf pull") {
+		t.Fatalf("expected pull hint, got %q", out.String())
+	}
+}
+
+func TestRunArchive_RequiresConfirmationInNonInteractiveMode(t *testing.T) {
+	runParallelCommandTest(t)
+	spaceDir, fake := setupArchiveWorkspace(t)
+
+	cmd := newArchiveCmd()
+	setAutomationFlags(t, false, true)
+	cmd.SetOut(&bytes.Buffer{})
+	err := runArchive(cmd, filepath.Join("ENG", "Guides", "Old.md"))
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("runArchive() error = %v, want confirmation error", err)
+	}
+	if len(fake.archived) != 0 {
+		t.Fatalf("page archived without confirmation: %v", fake.archived)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Guides", "Old.md")); err != nil {
+		t.Fatalf("file should be untouched: %v", err)
+	}
+}
+
+func TestLoadSpaceConfig_IgnoresArchivedDirectory(t *testing.T) {
+	spaceCfg, err := loadSpaceConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadSpaceConfig() error: %v", err)
+	}
+	if !matchesSpaceIgnore(spaceCfg.Ignore, "archived/Guides/Old.md") || matchesSpaceIgnore(spaceCfg.Ignore, "Guides/Old.md") {
+		t.Fatalf("ignore = %v", spaceCfg.Ignore)
+	}
+}
@@ -11,10 +11,35 @@ import (
 	"github.com/charmbracelet/huh"
 )
 
-const safetyConfirmationThreshold = 10
+// defaultMaxImpact is the --max-impact applied when the flag is unset, so
+// runs affecting more than 10 files need confirmation.
+const defaultMaxImpact = 11
+
+// flagMaxImpact sets the safety threshold for pull and push: runs affecting
+// fewer than this many files proceed without confirmation. Zero keeps
+// defaultMaxImpact.
+var flagMaxImpact int
+
+func validateMaxImpactFlag() error {
+	if flagMaxImpact < 0 {
+		return errors.New("--max-impact must be zero or positive")
+	}
+	return nil
+}
+
+// exceedsSafetyImpact reports whether changedCount needs confirmation under
+// --max-impact, or defaultMaxImpact when the flag is unset.
+func exceedsSafetyImpact(changedCount int) bool {
+	maxImpact := flagMaxImpact
+	if maxImpact == 0 {
+		maxImpact = defaultMaxImpact
+	}
+	return changedCount >= maxImpact
+}
 
 func requireSafetyConfirmation(in io.Reader, out io.Writer, action string, changedCount int, hasDeletes bool) error {
-	if changedCount <= safetyConfirmationThreshold && !hasDeletes {
+	exceedsImpact := exceedsSafetyImpact(changedCount)
+	if !exceedsImpact && !hasDeletes {
 		return nil
 	}
 
@@ -23,7 +48,7 @@ func requireSafetyConfirmation(in io.Reader, out io.Writer, action string, chang
 	}
 
 	reasonParts := make([]string, 0, 2)
-	if changedCount > safetyConfirmationThreshold {
+	if exceedsImpact {
 		reasonParts = append(reasonParts, fmt.Sprintf("%d files", changedCount))
 	}
 	if hasDeletes {
@@ -34,15 +34,18 @@ func TestRequireSafetyConfirmation(t *testing.T) {
 	// Backup flags
 	oldYes := flagYes
 	oldNonInteractive := flagNonInteractive
+	oldMaxImpact := flagMaxImpact
 	defer func() {
 		flagYes = oldYes
 		flagNonInteractive = oldNonInteractive
+		flagMaxImpact = oldMaxImpact
 	}()
 
 	tests := []struct {
 		name         string
 		yes          bool
 		nonInt       bool
+		maxImpact    int
 		changedCount int
 		hasDeletes   bool
 		input        string
@@ -82,6 +85,52 @@ func TestRequireSafetyConfirmation(t *testing.T) {
 			changedCount: 11,
 			wantErr:      false,
 		},
+		{
+			name:         "below --max-impact passes without --yes",
+			nonInt:       true,
+			maxImpact:    50,
+			changedCount: 49,
+			wantErr:      false,
+		},
+		{
+			name:         "at --max-impact non-interactive fails",
+			nonInt:       true,
+			maxImpact:    3,
+			changedCount: 3,
+			wantErr:      true,
+			errMatch:     "requires confirmation (3 files)",
+		},
+		{
+			name:         "one below --max-impact passes without --yes",
+			nonInt:       true,
+			maxImpact:    3,
+			changedCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "--max-impact 11 matches the default at 10 files",
+			nonInt:       true,
+			maxImpact:    11,
+			changedCount: 10,
+			wantErr:      false,
+		},
+		{
+			name:         "--max-impact 11 matches the default at 11 files",
+			nonInt:       true,
+			maxImpact:    11,
+			changedCount: 11,
+			wantErr:      true,
+			errMatch:     "requires confirmation (11 files)",
+		},
+		{
+			name:         "deletes stay gated below --max-impact",
+			nonInt:       true,
+			maxImpact:    50,
+			changedCount: 1,
+			hasDeletes:   true,
+			wantErr:      true,
+			errMatch:     "requires confirmation (delete operations",
+		},
 		{
 			name:         "interactive accept",
 			changedCount: 11,
@@ -101,6 +150,7 @@ func TestRequireSafetyConfirmation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			flagYes = tt.yes
 			flagNonInteractive = tt.nonInt
+			flagMaxImpact = tt.maxImpact
 
 			in := strings.NewReader(tt.input)
 			out := new(bytes.Buffer)
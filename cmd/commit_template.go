@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagPushCommitTemplate and flagPullCommitTemplate override the
+// push.commit_template and pull.commit_template keys of .cms-space.yaml.
+var (
+	flagPushCommitTemplate string
+	flagPullCommitTemplate string
+)
+
+// pushCommitTemplateData is what a push commit template can reference, once
+// per published page.
+type pushCommitTemplateData struct {
+	PageTitle string
+	PageID    string
+	Version   int
+	SpaceKey  string
+	URL       string
+	Path      string
+}
+
+// pullCommitTemplateData is what a pull commit template can reference.
+type pullCommitTemplateData struct {
+	SpaceKey     string
+	Version      int // highest page version seen by the pull
+	UpdatedPages int
+	DeletedPages int
+}
+
+// resolvePushCommitTemplate prefers --commit-template, then the space config.
+// A nil template keeps the built-in message.
+func resolvePushCommitTemplate(spaceCfg config.SpaceConfig) (*template.Template, error) {
+	sample := pushCommitTemplateData{PageTitle: "Page", PageID: "1", Version: 1, SpaceKey: "SPACE", URL: "https://example.atlassian.net/wiki/x/1", Path: "Page.md"}
+	if strings.TrimSpace(flagPushCommitTemplate) != "" {
+		return parseCommitTemplate("--commit-template", flagPushCommitTemplate, sample)
+	}
+	return parseCommitTemplate(config.SpaceConfigFileName+" push.commit_template", spaceCfg.PushCommitTemplate, sample)
+}
+
+// resolvePullCommitTemplate prefers --commit-template, then the space config.
+// A nil template keeps the built-in message.
+func resolvePullCommitTemplate(spaceCfg config.SpaceConfig) (*template.Template, error) {
+	sample := pullCommitTemplateData{SpaceKey: "SPACE", Version: 1, UpdatedPages: 1}
+	if strings.TrimSpace(flagPullCommitTemplate) != "" {
+		return parseCommitTemplate("--commit-template", flagPullCommitTemplate, sample)
+	}
+	return parseCommitTemplate(config.SpaceConfigFileName+" pull.commit_template", spaceCfg.PullCommitTemplate, sample)
+}
+
+// parseCommitTemplate parses a text/template commit message and renders it
+// once with sample data, so a typo in a field name fails the command before
+// anything is synced rather than when the commit is made.
+func parseCommitTemplate(source, text string, sample any) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("commit").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid commit template: %w", source, err)
+	}
+	if _, _, err := renderCommitTemplate(tmpl, sample); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return tmpl, nil
+}
+
+// renderCommitTemplate splits the rendered message into its first line, the
+// subject, and the rest, the body.
+func renderCommitTemplate(tmpl *template.Template, data any) (string, string, error) {
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", "", fmt.Errorf("render commit template: %w", err)
+	}
+	message := strings.TrimSpace(strings.ReplaceAll(rendered.String(), "\r\n", "\n"))
+	subject, body, _ := strings.Cut(message, "\n")
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return "", "", errors.New("commit template renders an empty subject line")
+	}
+	return subject, strings.TrimSpace(body), nil
+}
+
+// pushCommitMessage returns the subject and body of the commit for one
+// published page. The Confluence trailers always end the body, whatever the
+// template renders. The page is already published when this runs, so a
+// template that fails to render falls back to the built-in message.
+func pushCommitMessage(tmpl *template.Template, commitPlan syncflow.PushCommitPlan) (string, string) {
+	subject := fmt.Sprintf("Sync %q to Confluence (v%d)", commitPlan.PageTitle, commitPlan.Version)
+	body := fmt.Sprintf("Page ID: %s\nURL: %s", commitPlan.PageID, commitPlan.URL)
+	if tmpl != nil {
+		renderedSubject, renderedBody, err := renderCommitTemplate(tmpl, pushCommitTemplateData{
+			PageTitle: commitPlan.PageTitle,
+			PageID:    commitPlan.PageID,
+			Version:   commitPlan.Version,
+			SpaceKey:  commitPlan.SpaceKey,
+			URL:       commitPlan.URL,
+			Path:      commitPlan.Path,
+		})
+		if err != nil {
+			slog.Warn("push_commit_template_failed", "page_id", commitPlan.PageID, "error", err)
+		} else {
+			subject, body = renderedSubject, renderedBody
+		}
+	}
+	if body == "" {
+		return subject, pushCommitTrailers(commitPlan)
+	}
+	return subject, body + "\n\n" + pushCommitTrailers(commitPlan)
+}
+
+// pullCommitMessage returns the message of the pull commit.
+func pullCommitMessage(tmpl *template.Template, data pullCommitTemplateData) string {
+	message := fmt.Sprintf("Sync from Confluence: [%s] (v%d)", data.SpaceKey, data.Version)
+	if tmpl == nil {
+		return message
+	}
+	subject, body, err := renderCommitTemplate(tmpl, data)
+	if err != nil {
+		slog.Warn("pull_commit_template_failed", "space_key", data.SpaceKey, "error", err)
+		return message
+	}
+	if body == "" {
+		return subject
+	}
+	return subject + "\n\n" + body
+}
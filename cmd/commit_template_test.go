@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPush_CommitTemplateFromSpaceConfigKeepsTrailers(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	spaceCfg := "push:\n  commit_template: |-\n    docs({{.SpaceKey}}): update {{.PageTitle}} to v{{.Version}}\n\n    Refs: DOC-1 ({{.Path}})\n"
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte(spaceCfg), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T10:00:00Z"},
+		Body:        "Updated local content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+	headBefore := strings.TrimSpace(runGitForTest(t, repo, "rev-parse", "HEAD"))
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+
+	syncCommits := strings.Fields(runGitForTest(t, repo, "rev-list", "--no-merges", headBefore+"..HEAD"))
+	if len(syncCommits) != 1 {
+		t.Fatalf("expected one sync commit, got %d", len(syncCommits))
+	}
+	syncCommit := syncCommits[0]
+	message := runGitForTest(t, repo, "log", "-1", "--pretty=%B", syncCommit)
+	if !strings.HasPrefix(message, "docs(ENG): update Root to v2\n\nRefs: DOC-1 (root.md)\n\n") {
+		t.Fatalf("commit message does not follow the template:\n%s", message)
+	}
+	trailers := runGitForTest(t, repo, "log", "-1", "--format=%(trailers:key=Confluence-Page-ID,valueonly)", syncCommit)
+	if strings.TrimSpace(trailers) != "1" {
+		t.Fatalf("Confluence-Page-ID trailer = %q, want 1:\n%s", trailers, message)
+	}
+}
+
+func TestRunPush_InvalidCommitTemplateFailsBeforePushing(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T10:00:00Z"},
+		Body:        "Updated local content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldTemplate := flagPushCommitTemplate
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	flagPushCommitTemplate = "docs: {{.Title}}"
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		flagPushCommitTemplate = oldTemplate
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false)
+	if err == nil || !strings.Contains(err.Error(), "--commit-template") || !strings.Contains(err.Error(), "Title") {
+		t.Fatalf("runPush() error = %v, want a --commit-template error naming the field", err)
+	}
+	if len(fake.updateCalls) != 0 {
+		t.Fatalf("update calls = %d, want none before the template is valid", len(fake.updateCalls))
+	}
+}
+
+func TestRunPull_CommitTemplateFlagOverridesSpaceConfig(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T08:00:00Z"},
+		Body:        "old body\n",
+	})
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("pull:\n  commit_template: \"from config\"\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, simpleADF("new body"))},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newPullRemote
+	previousForce := flagPullForce
+	oldTemplate := flagPullCommitTemplate
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	flagPullForce = true
+	flagPullCommitTemplate = "chore(docs): sync {{.SpaceKey}} ({{.UpdatedPages}} page(s), v{{.Version}})"
+	t.Cleanup(func() {
+		newPullRemote = oldFactory
+		flagPullForce = previousForce
+		flagPullCommitTemplate = oldTemplate
+	})
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runPull() error: %v", err)
+	}
+
+	subject := strings.TrimSpace(runGitForTest(t, repo, "log", "-1", "--format=%s"))
+	if subject != "chore(docs): sync ENG (1 page(s), v2)" {
+		t.Fatalf("pull commit subject = %q", subject)
+	}
+}
+
+func TestPushCommitMessage_FallsBackWhenTemplateRendersEmptySubject(t *testing.T) {
+	tmpl, err := parseCommitTemplate("--commit-template", "{{if eq .PageID \"1\"}}docs: {{.PageTitle}}{{end}}", pushCommitTemplateData{PageID: "1"})
+	if err != nil {
+		t.Fatalf("parseCommitTemplate() error: %v", err)
+	}
+
+	subject, body := pushCommitMessage(tmpl, syncflow.PushCommitPlan{PageID: "2", PageTitle: "Other", Version: 3, SpaceKey: "ENG"})
+	if subject != `Sync "Other" to Confluence (v3)` {
+		t.Fatalf("subject = %q, want the built-in subject", subject)
+	}
+	if !strings.HasSuffix(body, "Confluence-Page-ID: 2\nConfluence-Version: 3\nConfluence-Space-Key: ENG\nConfluence-URL: ") {
+		t.Fatalf("body should end with the trailers:\n%s", body)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"log/slog"
 	"strings"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
@@ -8,15 +9,27 @@ import (
 )
 
 func newConfluenceClientFromConfig(cfg *config.Config) (*confluence.Client, error) {
+	if flagInsecure {
+		slog.Warn("TLS certificate verification is disabled (--insecure); connections can be intercepted, prefer ATLASSIAN_CA_BUNDLE")
+	}
+	apiVersion := strings.TrimSpace(flagConfluenceAPIVersion)
+	if apiVersion == "" {
+		apiVersion = cfg.APIVersion
+	}
 	return confluence.NewClient(confluence.ClientConfig{
-		BaseURL:          cfg.Domain,
-		Email:            cfg.Email,
-		APIToken:         cfg.APIToken,
-		UserAgent:        buildUserAgent(Version),
-		RateLimitRPS:     flagRateLimitRPS,
-		RetryMaxAttempts: flagRetryMaxAttempts,
-		RetryBaseDelay:   flagRetryBaseDelay,
-		RetryMaxDelay:    flagRetryMaxDelay,
+		BaseURL:            cfg.Domain,
+		Email:              cfg.Email,
+		APIToken:           cfg.APIToken,
+		AuthMode:           confluence.AuthMode(cfg.AuthMode),
+		UserAgent:          buildUserAgent(Version),
+		RateLimitRPS:       flagRateLimitRPS,
+		RetryMaxAttempts:   flagRetryMaxAttempts,
+		RetryBaseDelay:     flagRetryBaseDelay,
+		RetryMaxDelay:      flagRetryMaxDelay,
+		HTTPTimeout:        cfg.HTTPTimeout,
+		CABundle:           cfg.CABundle,
+		InsecureSkipVerify: flagInsecure,
+		APIVersion:         confluence.APIVersion(apiVersion),
 	})
 }
 
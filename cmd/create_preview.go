@@ -46,6 +46,11 @@ func buildLocalCreatePreview(
 		title = strings.TrimSuffix(filepath.Base(relPath), filepath.Ext(relPath))
 	}
 
+	filenameMode, err := loadSpaceFilenameMode(spaceDir)
+	if err != nil {
+		return localCreatePreview{}, err
+	}
+
 	pageIndex, err := syncflow.BuildPageIndex(spaceDir)
 	if err != nil {
 		return localCreatePreview{}, err
@@ -82,7 +87,7 @@ func buildLocalCreatePreview(
 		RelPath:             relPath,
 		Title:               title,
 		ResolvedParent:      resolvePreviewParent(relPath, doc.Frontmatter.ConfluenceParentPageID, pageIndex),
-		CanonicalTargetPath: canonicalCreatePreviewPath(relPath, title),
+		CanonicalTargetPath: canonicalCreatePreviewPath(relPath, title, filenameMode),
 		AttachmentUploads:   referencedAssets,
 		ADFBytes:            len(reverseResult.ADF),
 		ADFTopLevelNodes:    adfTopLevelNodeCount(reverseResult.ADF),
@@ -131,9 +136,9 @@ func previewIndexPathForDir(dirPath string) string {
 	return normalizeRepoRelPath(filepath.ToSlash(filepath.Join(dirPath, dirBase+".md")))
 }
 
-func canonicalCreatePreviewPath(relPath, title string) string {
+func canonicalCreatePreviewPath(relPath, title string, mode fs.FilenameMode) string {
 	dirPath := normalizeRepoRelPath(filepath.Dir(relPath))
-	fileName := fs.SanitizeMarkdownFilename(title)
+	fileName := fs.SanitizeMarkdownFilenameWithMode(title, mode)
 	if dirPath == "" || dirPath == "." {
 		return fileName
 	}
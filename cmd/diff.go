@@ -17,17 +17,33 @@ import (
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var newDiffRemote = func(cfg *config.Config) (syncflow.PullRemote, error) {
 	return newConfluenceClientFromConfig(cfg)
 }
 
+// flagDiffConcurrency bounds how many remote pages a space diff fetches and
+// converts at once.
+var flagDiffConcurrency = defaultDiffConcurrency
+
+const defaultDiffConcurrency = 4
+
 type diffContext struct {
 	spaceKey     string
 	spaceDir     string
 	targetPageID string
 	targetFile   string
+
+	// stripTitleHeading and propertyKeys mirror the space config so the
+	// remote snapshot matches what pull would write.
+	stripTitleHeading bool
+	propertyKeys      []string
+
+	// localPathByID maps tracked page IDs to their local Markdown file, whose
+	// frontmatter supplies the values pull keeps from the local copy.
+	localPathByID map[string]string
 }
 
 func newDiffCmd() *cobra.Command {
@@ -37,6 +53,10 @@ func newDiffCmd() *cobra.Command {
 		Long: `Diff fetches remote Confluence content, converts it to Markdown,
 and shows a diff against local files using git diff --no-index.
 
+With --base local-baseline, diff instead compares local files with the
+Markdown committed at the space's last sync tag, entirely offline, to show
+what was edited since the last pull or push.
+
 TARGET can be a SPACE_KEY (e.g. "MYSPACE") or a path to a .md file.
 If omitted, the space is inferred from the current directory name.`,
 		Args: cobra.MaximumNArgs(1),
@@ -48,6 +68,10 @@ If omitted, the space is inferred from the current directory name.`,
 			return runDiff(cmd, config.ParseTarget(raw))
 		},
 	}
+	cmd.Flags().BoolVar(&flagDiffChangedOnly, "changed-only", false, "Only compare Markdown files changed locally since the last sync (space targets only)")
+	cmd.Flags().StringVar(&flagDiffBase, "base", diffBaseRemote, "What to compare local files with: remote (live Confluence content) or local-baseline (the last sync tag, offline)")
+	cmd.Flags().IntVar(&flagDiffConcurrency, "concurrency", defaultDiffConcurrency, "Maximum number of remote pages fetched and converted in parallel (space targets only)")
+	addCommandTimeoutFlag(cmd)
 	addReportJSONFlag(cmd)
 	return cmd
 }
@@ -85,10 +109,24 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		)
 	}()
 
-	ctx := getCommandContext(cmd)
+	ctx, cancel, err := withCommandTimeout(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	defer func() { runErr = explainCommandInterruption(ctx, "diff", runErr) }()
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	if flagDiffConcurrency < 1 {
+		return errors.New("--concurrency must be a positive number of workers")
+	}
+	if err := validateDiffBase(flagDiffBase); err != nil {
+		return err
+	}
+	if flagDiffBase == diffBaseLocalBaseline && flagDiffChangedOnly {
+		return errors.New("--changed-only cannot be combined with --base local-baseline, which already shows only local changes")
+	}
 	if err := ensureWorkspaceSyncReady("diff"); err != nil {
 		return err
 	}
@@ -97,6 +135,26 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		return err
 	}
 
+	if flagDiffBase == diffBaseLocalBaseline {
+		if !dirExists(initialCtx.spaceDir) {
+			return fmt.Errorf("space directory not found: %s", initialCtx.spaceDir)
+		}
+		diffCtx := diffContext{spaceKey: initialCtx.spaceKey, spaceDir: initialCtx.spaceDir}
+		if target.IsFile() {
+			if absPath, err := filepath.Abs(target.Value); err == nil {
+				diffCtx.targetFile = absPath
+			}
+		}
+		telemetrySpaceKey = diffCtx.spaceKey
+		report.Target.SpaceKey = diffCtx.spaceKey
+		report.Target.SpaceDir = diffCtx.spaceDir
+		report.Target.File = diffCtx.targetFile
+		result, err := runDiffLocalBaseline(out, diffCtx)
+		report.MutatedFiles = append(report.MutatedFiles, result.ChangedFiles...)
+		report.NoChanges = len(result.ChangedFiles) == 0
+		return err
+	}
+
 	envPath := findEnvPath(initialCtx.spaceDir)
 	cfg, err := config.Load(envPath)
 	if err != nil {
@@ -119,10 +177,18 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		spaceDir = filepath.Join(filepath.Dir(initialCtx.spaceDir), fs.SanitizeSpaceDirName(space.Name, space.Key))
 	}
 
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return err
+	}
+
 	diffCtx := diffContext{
 		spaceKey:     space.Key,
 		spaceDir:     spaceDir,
 		targetPageID: initialCtx.targetPageID,
+
+		stripTitleHeading: spaceCfg.StripTitleHeading,
+		propertyKeys:      spaceCfg.PullProperties,
 	}
 	telemetrySpaceKey = diffCtx.spaceKey
 	report.Target.SpaceKey = diffCtx.spaceKey
@@ -139,6 +205,10 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 	if err != nil {
 		return fmt.Errorf("load state: %w", err)
 	}
+	diffCtx.localPathByID = make(map[string]string, len(state.PagePathIndex))
+	for relPath, pageID := range state.PagePathIndex {
+		diffCtx.localPathByID[pageID] = filepath.Join(diffCtx.spaceDir, filepath.FromSlash(relPath))
+	}
 
 	if target.IsFile() {
 		absPath, err := filepath.Abs(target.Value)
@@ -169,6 +239,24 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		}
 	}
 
+	var changedScope *diffChangedScope
+	if flagDiffChangedOnly {
+		if target.IsFile() {
+			return errors.New("--changed-only is only supported for space targets")
+		}
+		scope, err := collectDiffChangedScope(diffCtx.spaceKey, diffCtx.spaceDir, state)
+		if err != nil {
+			return fmt.Errorf("collect local changes: %w", err)
+		}
+		if len(scope.relPaths) == 0 {
+			_, _ = fmt.Fprintln(out, "diff completed: no local markdown changes since last sync (no-op)")
+			report.NoChanges = true
+			return nil
+		}
+		_, _ = fmt.Fprintf(out, "comparing %d locally changed file(s) with Confluence\n", len(scope.relPaths))
+		changedScope = &scope
+	}
+
 	pages, err := listAllDiffPages(ctx, remote, confluence.PageListOptions{
 		SpaceID:  space.ID,
 		SpaceKey: space.Key,
@@ -197,7 +285,11 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		}
 	}
 
-	pagePathByIDAbs, pagePathByIDRel := syncflow.PlanPagePaths(diffCtx.spaceDir, state.PagePathIndex, pages, folderByID)
+	pathLayout, err := loadSpacePagePathLayout(diffCtx.spaceDir)
+	if err != nil {
+		return err
+	}
+	pagePathByIDAbs, pagePathByIDRel := syncflow.PlanPagePaths(diffCtx.spaceDir, state.PagePathIndex, pages, folderByID, pathLayout)
 	pathMoves := syncflow.PlannedPagePathMoves(state.PagePathIndex, pagePathByIDRel)
 	attachmentPathByID := buildDiffAttachmentPathByID(diffCtx.spaceDir, state.AttachmentIndex)
 	globalPageIndex, err := buildWorkspaceGlobalPageIndex(diffCtx.spaceDir)
@@ -217,6 +309,7 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		result, err := runDiffFileMode(ctx, out, remote, diffCtx, pagePathByIDAbs, pathMoves, attachmentPathByID, globalPageIndex, tmpRoot)
 		report.Diagnostics = append(report.Diagnostics, reportDiagnosticsFromPull(result.Diagnostics, diffCtx.spaceDir)...)
 		report.MutatedFiles = append(report.MutatedFiles, result.ChangedFiles...)
+		report.NoChanges = len(result.ChangedFiles) == 0
 		return err
 	}
 
@@ -232,12 +325,95 @@ func runDiff(cmd *cobra.Command, target config.Target) (runErr error) {
 		attachmentPathByID,
 		globalPageIndex,
 		tmpRoot,
+		changedScope,
+		flagDiffConcurrency,
 	)
 	report.Diagnostics = append(report.Diagnostics, reportDiagnosticsFromPull(result.Diagnostics, diffCtx.spaceDir)...)
 	report.MutatedFiles = append(report.MutatedFiles, result.ChangedFiles...)
+	report.NoChanges = len(result.ChangedFiles) == 0
 	return err
 }
 
+// diffRenderedPage is what one diff worker produced for a remote page.
+type diffRenderedPage struct {
+	diagnostics []syncflow.PullDiagnostic
+	metadata    *diffMetadataSummary
+}
+
+// renderDiffRemotePage fetches one remote page, converts it to Markdown and
+// writes it into the remote snapshot. Pages that were deleted or archived
+// since listing are skipped.
+func renderDiffRemotePage(
+	ctx context.Context,
+	remote syncflow.PullRemote,
+	diffCtx diffContext,
+	pageID string,
+	pagePathByIDAbs map[string]string,
+	pagePathByIDRel map[string]string,
+	attachmentPathByID map[string]string,
+	globalPageIndex syncflow.GlobalPageIndex,
+	remoteSnapshot string,
+) (diffRenderedPage, error) {
+	if err := ctx.Err(); err != nil {
+		return diffRenderedPage{}, err
+	}
+	page, err := remote.GetPage(ctx, pageID)
+	if err != nil {
+		if errors.Is(err, confluence.ErrNotFound) || errors.Is(err, confluence.ErrArchived) {
+			return diffRenderedPage{}, nil
+		}
+		return diffRenderedPage{}, fmt.Errorf("fetch page %s: %w", pageID, err)
+	}
+
+	sourcePath, ok := pagePathByIDAbs[page.ID]
+	if !ok {
+		return diffRenderedPage{}, fmt.Errorf("planned path missing for page %s", page.ID)
+	}
+
+	relPath, ok := pagePathByIDRel[page.ID]
+	if !ok {
+		return diffRenderedPage{}, fmt.Errorf("planned relative path missing for page %s", page.ID)
+	}
+
+	localFM := readDiffLocalFrontmatter(diffCtx, page.ID)
+	page, metadataDiags := hydrateDiffPageMetadata(ctx, remote, page, relPath, diffCtx.propertyKeys, localFM)
+	rendered, pageDiags, err := renderDiffMarkdown(
+		ctx,
+		page,
+		diffCtx.spaceKey,
+		diffCtx.spaceDir,
+		sourcePath,
+		relPath,
+		pagePathByIDAbs,
+		attachmentPathByID,
+		globalPageIndex,
+		diffCtx.stripTitleHeading,
+		localFM,
+	)
+	if err != nil {
+		return diffRenderedPage{}, err
+	}
+	result := diffRenderedPage{diagnostics: append(metadataDiags, pageDiags...)}
+
+	dstPath := filepath.Join(remoteSnapshot, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
+		return result, fmt.Errorf("prepare remote snapshot path: %w", err)
+	}
+	if err := os.WriteFile(dstPath, rendered, 0o600); err != nil {
+		return result, fmt.Errorf("write remote snapshot file: %w", err)
+	}
+
+	localRaw, err := os.ReadFile(sourcePath) //nolint:gosec // planned path is scoped under the current workspace
+	if err == nil {
+		localRaw, err = normalizeDiffMarkdown(localRaw)
+	}
+	if err == nil {
+		summary := summarizeMetadataDrift(relPath, localRaw, rendered)
+		result.metadata = &summary
+	}
+	return result, nil
+}
+
 func resolveInitialDiffContext(target config.Target) (initialPullContext, error) {
 	if !target.IsFile() {
 		return resolveInitialPullContext(target)
@@ -336,7 +512,8 @@ func runDiffFileMode(
 		return result, fmt.Errorf("fetch page %s: %w", diffCtx.targetPageID, err)
 	}
 
-	page, metadataDiags := hydrateDiffPageMetadata(ctx, remote, page, relPath)
+	localFM := readDiffLocalFrontmatter(diffCtx, page.ID)
+	page, metadataDiags := hydrateDiffPageMetadata(ctx, remote, page, relPath, diffCtx.propertyKeys, localFM)
 	renderSourcePath := diffCtx.targetFile
 	if plannedSourcePath, ok := pagePathByIDAbs[page.ID]; ok && strings.TrimSpace(plannedSourcePath) != "" {
 		renderSourcePath = plannedSourcePath
@@ -351,6 +528,8 @@ func runDiffFileMode(
 		pagePathByIDAbs,
 		attachmentPathByID,
 		globalPageIndex,
+		diffCtx.stripTitleHeading,
+		localFM,
 	)
 	if err != nil {
 		return result, err
@@ -413,6 +592,8 @@ func runDiffSpaceMode(
 	attachmentPathByID map[string]string,
 	globalPageIndex syncflow.GlobalPageIndex,
 	tmpRoot string,
+	changedScope *diffChangedScope,
+	concurrency int,
 ) (diffCommandResult, error) {
 	result := diffCommandResult{
 		SpaceKey:     diffCtx.spaceKey,
@@ -433,9 +614,19 @@ func runDiffSpaceMode(
 	if err := copyLocalMarkdownSnapshot(diffCtx.spaceDir, localSnapshot); err != nil {
 		return result, err
 	}
+	if changedScope != nil {
+		if err := removeSnapshotFiles(localSnapshot, func(relPath string) bool {
+			return !changedScope.keepSnapshotPath(relPath, pagePathByIDRel)
+		}); err != nil {
+			return result, fmt.Errorf("prepare local snapshot: %w", err)
+		}
+	}
 
 	pageIDs := make([]string, 0, len(pages))
 	for _, page := range pages {
+		if !changedScope.includesPage(page.ID) {
+			continue
+		}
 		pageIDs = append(pageIDs, page.ID)
 	}
 	sort.Strings(pageIDs)
@@ -444,58 +635,29 @@ func runDiffSpaceMode(
 	for _, move := range pathMoves {
 		diagnostics = append(diagnostics, syncflow.PagePathMoveDiagnostic(move))
 	}
-	metadataSummaries := make([]diffMetadataSummary, 0, len(pageIDs))
-	for _, pageID := range pageIDs {
-		page, err := remote.GetPage(ctx, pageID)
-		if err != nil {
-			if errors.Is(err, confluence.ErrNotFound) || errors.Is(err, confluence.ErrArchived) {
-				continue
-			}
-			return result, fmt.Errorf("fetch page %s: %w", pageID, err)
-		}
-
-		sourcePath, ok := pagePathByIDAbs[page.ID]
-		if !ok {
-			return result, fmt.Errorf("planned path missing for page %s", page.ID)
-		}
-
-		relPath, ok := pagePathByIDRel[page.ID]
-		if !ok {
-			return result, fmt.Errorf("planned relative path missing for page %s", page.ID)
-		}
-
-		page, metadataDiags := hydrateDiffPageMetadata(ctx, remote, page, relPath)
-		rendered, pageDiags, err := renderDiffMarkdown(
-			ctx,
-			page,
-			diffCtx.spaceKey,
-			diffCtx.spaceDir,
-			sourcePath,
-			relPath,
-			pagePathByIDAbs,
-			attachmentPathByID,
-			globalPageIndex,
-		)
-		if err != nil {
-			return result, err
-		}
-		pageDiags = append(metadataDiags, pageDiags...)
-		diagnostics = append(diagnostics, pageDiags...)
-
-		dstPath := filepath.Join(remoteSnapshot, filepath.FromSlash(relPath))
-		if err := os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
-			return result, fmt.Errorf("prepare remote snapshot path: %w", err)
-		}
-		if err := os.WriteFile(dstPath, rendered, 0o600); err != nil {
-			return result, fmt.Errorf("write remote snapshot file: %w", err)
-		}
+	// Pages are fetched, converted and written to the remote snapshot by a
+	// bounded pool of workers. Each worker fills only its own slot, so the
+	// diagnostics and metadata summaries are reported in page ID order no
+	// matter which page finishes first.
+	rendered := make([]diffRenderedPage, len(pageIDs))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(concurrency, 1))
+	for i, pageID := range pageIDs {
+		g.Go(func() error {
+			page, err := renderDiffRemotePage(gCtx, remote, diffCtx, pageID, pagePathByIDAbs, pagePathByIDRel, attachmentPathByID, globalPageIndex, remoteSnapshot)
+			rendered[i] = page
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return result, err
+	}
 
-		localRaw, err := os.ReadFile(sourcePath) //nolint:gosec // planned path is scoped under the current workspace
-		if err == nil {
-			localRaw, err = normalizeDiffMarkdown(localRaw)
-		}
-		if err == nil {
-			metadataSummaries = append(metadataSummaries, summarizeMetadataDrift(relPath, localRaw, rendered))
+	metadataSummaries := make([]diffMetadataSummary, 0, len(pageIDs))
+	for _, page := range rendered {
+		diagnostics = append(diagnostics, page.diagnostics...)
+		if page.metadata != nil {
+			metadataSummaries = append(metadataSummaries, *page.metadata)
 		}
 	}
 
@@ -508,6 +670,9 @@ func runDiffSpaceMode(
 		return result, err
 	}
 	result.Diagnostics = append(result.Diagnostics, diagnostics...)
+	if err := removeSpaceIgnoredSnapshotFiles(diffCtx.spaceDir, localSnapshot, remoteSnapshot); err != nil {
+		return result, err
+	}
 	changed, err := renderNoIndexDiff(out, localSnapshot, remoteSnapshot)
 	if changed {
 		changedFiles, changedFilesErr := collectChangedSnapshotFiles(localSnapshot, remoteSnapshot)
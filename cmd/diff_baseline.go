@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	"github.com/rgonek/confluence-markdown-sync/internal/git"
+)
+
+const (
+	// diffBaseRemote compares local files with live Confluence content.
+	diffBaseRemote = "remote"
+	// diffBaseLocalBaseline compares local files with the Markdown committed
+	// at the last sync tag of the space, without contacting Confluence.
+	diffBaseLocalBaseline = "local-baseline"
+)
+
+var flagDiffBase = diffBaseRemote
+
+func validateDiffBase(base string) error {
+	switch base {
+	case diffBaseRemote, diffBaseLocalBaseline:
+		return nil
+	default:
+		return fmt.Errorf("invalid --base %q (want %s or %s)", base, diffBaseRemote, diffBaseLocalBaseline)
+	}
+}
+
+// runDiffLocalBaseline diffs the working tree against the last sync tag of
+// the space (see gitPushBaselineRef). Both sides are normalized like a
+// remote diff, so only edits made since the last pull or push show up.
+func runDiffLocalBaseline(out io.Writer, diffCtx diffContext) (diffCommandResult, error) {
+	result := diffCommandResult{
+		SpaceKey:     diffCtx.spaceKey,
+		SpaceDir:     diffCtx.spaceDir,
+		TargetFile:   diffCtx.targetFile,
+		ChangedFiles: []string{},
+	}
+
+	client, err := git.NewClient()
+	if err != nil {
+		return result, err
+	}
+	baselineRef, err := gitPushBaselineRef(client, diffCtx.spaceKey)
+	if err != nil {
+		return result, fmt.Errorf("resolve last sync baseline: %w", err)
+	}
+	spaceScopePath, err := gitScopePathFromPath(diffCtx.spaceDir)
+	if err != nil {
+		return result, err
+	}
+
+	tmpRoot, err := os.MkdirTemp("", "conf-diff-*")
+	if err != nil {
+		return result, fmt.Errorf("create diff workspace: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpRoot)
+	}()
+	baselineSnapshot := filepath.Join(tmpRoot, "baseline")
+	localSnapshot := filepath.Join(tmpRoot, "local")
+	for _, dir := range []string{baselineSnapshot, localSnapshot} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return result, fmt.Errorf("prepare diff snapshot: %w", err)
+		}
+	}
+
+	if err := copyBaselineMarkdownSnapshot(client, baselineRef, spaceScopePath, baselineSnapshot); err != nil {
+		return result, err
+	}
+	if err := copyLocalMarkdownSnapshot(diffCtx.spaceDir, localSnapshot); err != nil {
+		return result, err
+	}
+	if err := removeSpaceIgnoredSnapshotFiles(diffCtx.spaceDir, baselineSnapshot, localSnapshot); err != nil {
+		return result, err
+	}
+	if diffCtx.targetFile != "" {
+		targetRelPath := diffDisplayRelPath(diffCtx.spaceDir, diffCtx.targetFile)
+		for _, dir := range []string{baselineSnapshot, localSnapshot} {
+			if err := removeSnapshotFiles(dir, func(relPath string) bool { return relPath != targetRelPath }); err != nil {
+				return result, fmt.Errorf("prepare diff snapshot: %w", err)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "comparing local files with last sync %s (offline)\n", baselineRef)
+	changed, err := renderNoIndexDiff(out, baselineSnapshot, localSnapshot)
+	if changed {
+		changedFiles, changedFilesErr := collectChangedSnapshotFiles(baselineSnapshot, localSnapshot)
+		if changedFilesErr != nil {
+			return result, changedFilesErr
+		}
+		result.ChangedFiles = append(result.ChangedFiles, changedFiles...)
+	}
+	return result, err
+}
+
+// copyBaselineMarkdownSnapshot writes the Markdown pages committed at ref
+// under scopePath into snapshotDir, applying the same filters and
+// normalization as copyLocalMarkdownSnapshot.
+func copyBaselineMarkdownSnapshot(client *git.Client, ref, scopePath, snapshotDir string) error {
+	archive, err := client.Run("archive", "--format=tar", ref, "--", scopePath)
+	if err != nil {
+		// The space directory did not exist at the baseline: every local
+		// page is new.
+		if strings.Contains(err.Error(), "did not match any files") {
+			return nil
+		}
+		return fmt.Errorf("read baseline %s: %w", ref, err)
+	}
+
+	prefix := ""
+	if scopePath != "." {
+		prefix = strings.TrimSuffix(scopePath, "/") + "/"
+	}
+	reader := tar.NewReader(strings.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read baseline %s: %w", ref, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		relPath, ok := strings.CutPrefix(header.Name, prefix)
+		if !ok || !isBaselineSnapshotPage(relPath) {
+			continue
+		}
+
+		raw, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("read baseline %s: %w", header.Name, err)
+		}
+		raw, err = normalizeDiffMarkdown(raw)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(snapshotDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dstPath, raw, 0o600); err != nil {
+			return err
+		}
+	}
+}
+
+// isBaselineSnapshotPage mirrors the walk filter of copyLocalMarkdownSnapshot
+// for a space-relative slash path.
+func isBaselineSnapshotPage(relPath string) bool {
+	if path.Ext(relPath) != ".md" || fs.IsPageSidecar(relPath) {
+		return false
+	}
+	segments := strings.Split(relPath, "/")
+	for _, dir := range segments[:len(segments)-1] {
+		if dir == "assets" || strings.HasPrefix(dir, ".") {
+			return false
+		}
+	}
+	return true
+}
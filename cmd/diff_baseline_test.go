@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunDiff_LocalBaselineComparesWithLastSyncOffline(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+
+	for _, page := range []struct{ id, title string }{{"1", "Alpha"}, {"2", "Beta"}} {
+		writeMarkdown(t, filepath.Join(spaceDir, page.title+".md"), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: page.title, ID: page.id, Version: 2},
+			Body:        page.title + " body\n",
+		})
+	}
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:        "ENG",
+		PagePathIndex:   map[string]string{"Alpha.md": "1", "Beta.md": "2"},
+		AttachmentIndex: map[string]string{},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "pull")
+	runGitForTest(t, repo, "tag", "confluence-sync/pull/ENG/20260201T120000Z")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Alpha.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Alpha", ID: "1", Version: 2},
+		Body:        "Alpha edited locally\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Gamma.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Gamma"},
+		Body:        "Gamma draft\n",
+	})
+
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) {
+		return nil, errors.New("local-baseline diff must not contact Confluence")
+	}
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := newDiffCmd()
+	if err := cmd.Flags().Set("base", diffBaseLocalBaseline); err != nil {
+		t.Fatalf("set --base: %v", err)
+	}
+	t.Cleanup(func() { flagDiffBase = diffBaseRemote })
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runDiff() error: %v\n%s", err, out.String())
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "last sync confluence-sync/pull/ENG/20260201T120000Z") {
+		t.Fatalf("expected baseline tag in output, got:\n%s", got)
+	}
+	for _, want := range []string{"-Alpha body", "+Alpha edited locally", "+Gamma draft"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in diff, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Beta") {
+		t.Fatalf("unchanged Beta.md should not be in the diff, got:\n%s", got)
+	}
+
+	out.Reset()
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeFile, Value: filepath.Join("ENG", "Beta.md")}); err != nil {
+		t.Fatalf("runDiff() file error: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "diff completed with no differences") {
+		t.Fatalf("expected no differences for unchanged Beta.md, got:\n%s", out.String())
+	}
+}
+
+func TestRunDiff_RejectsUnknownBase(t *testing.T) {
+	runParallelCommandTest(t)
+	oldBase := flagDiffBase
+	flagDiffBase = "pull-tag"
+	t.Cleanup(func() { flagDiffBase = oldBase })
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"})
+	if err == nil || !strings.Contains(err.Error(), "--base") {
+		t.Fatalf("expected --base validation error, got: %v", err)
+	}
+}
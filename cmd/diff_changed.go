@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	"github.com/rgonek/confluence-markdown-sync/internal/git"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagDiffChangedOnly limits a space diff to Markdown files changed locally
+// since the last sync.
+var flagDiffChangedOnly bool
+
+// diffChangedScope is the part of a space compared by `diff --changed-only`.
+type diffChangedScope struct {
+	relPaths map[string]struct{} // space-relative Markdown paths kept in both snapshots
+	pageIDs  map[string]struct{} // remote pages fetched and converted
+}
+
+// collectDiffChangedScope finds the Markdown files changed since the last
+// pull/push sync tag, using the same baseline and change detection as push,
+// and maps them to their remote page IDs.
+func collectDiffChangedScope(spaceKey, spaceDir string, state fs.SpaceState) (diffChangedScope, error) {
+	scope := diffChangedScope{relPaths: map[string]struct{}{}, pageIDs: map[string]struct{}{}}
+
+	client, err := git.NewClient()
+	if err != nil {
+		return scope, err
+	}
+	baselineRef, err := gitPushBaselineRef(client, spaceKey)
+	if err != nil {
+		return scope, err
+	}
+	spaceScopePath, err := gitScopePathFromPath(spaceDir)
+	if err != nil {
+		return scope, err
+	}
+	changes, err := collectSyncPushChanges(client, baselineRef, spaceScopePath, spaceScopePath)
+	if err != nil {
+		return scope, err
+	}
+
+	for _, change := range changes {
+		relPath := normalizeRepoRelPath(change.Path)
+		if relPath == "" {
+			continue
+		}
+		scope.relPaths[relPath] = struct{}{}
+
+		pageID := strings.TrimSpace(state.PagePathIndex[relPath])
+		if change.Type != syncflow.PushChangeDelete {
+			if fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath))); err == nil && strings.TrimSpace(fm.ID) != "" {
+				pageID = strings.TrimSpace(fm.ID)
+			}
+		}
+		if pageID != "" {
+			scope.pageIDs[pageID] = struct{}{}
+		}
+	}
+	return scope, nil
+}
+
+func (s *diffChangedScope) includesPage(pageID string) bool {
+	if s == nil {
+		return true
+	}
+	_, ok := s.pageIDs[pageID]
+	return ok
+}
+
+// keepSnapshotPath reports whether a snapshot file belongs to the scope,
+// either as a changed local path or as the planned path of a changed page.
+func (s *diffChangedScope) keepSnapshotPath(relPath string, pagePathByIDRel map[string]string) bool {
+	if s == nil {
+		return true
+	}
+	if _, ok := s.relPaths[relPath]; ok {
+		return true
+	}
+	for pageID := range s.pageIDs {
+		if normalizeRepoRelPath(pagePathByIDRel[pageID]) == relPath {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunDiff_SpaceModeRendersPagesConcurrentlyInStableOrder(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	spaceDir := filepath.Join(repo, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	titles := map[string]string{"1": "Alpha", "2": "Bravo", "3": "Charlie", "4": "Delta"}
+	pathIndex := map[string]string{}
+	pages := make([]confluence.Page, 0, len(titles))
+	pagesByID := map[string]confluence.Page{}
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		relPath := titles[id] + ".md"
+		pathIndex[relPath] = id
+		writeMarkdown(t, filepath.Join(spaceDir, relPath), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: titles[id], ID: id, Version: 1},
+			Body:        "old body\n",
+		})
+		pages = append(pages, confluence.Page{ID: id, SpaceID: "space-1", Title: titles[id], Version: 2, LastModified: modified})
+		pagesByID[id] = confluence.Page{
+			ID:           id,
+			SpaceID:      "space-1",
+			Title:        titles[id],
+			Version:      2,
+			LastModified: modified,
+			BodyADF:      rawJSON(t, diffUnresolvedADF()),
+		}
+	}
+	if err := fs.SaveState(spaceDir, fs.SpaceState{PagePathIndex: pathIndex, AttachmentIndex: map[string]string{}}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	delays := map[string]time.Duration{"1": 40 * time.Millisecond, "2": 30 * time.Millisecond, "3": 20 * time.Millisecond, "4": 10 * time.Millisecond}
+	var inFlight, highest atomic.Int32
+	fake := &cmdFakePullRemote{
+		space:       confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages:       pages,
+		pagesByID:   pagesByID,
+		attachments: map[string][]byte{},
+	}
+	fake.getPageFunc = func(pageID string) (confluence.Page, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := highest.Load()
+			if current <= seen || highest.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		// Earlier pages finish last so the output order cannot follow
+		// completion order.
+		time.Sleep(delays[pageID])
+		return pagesByID[pageID], nil
+	}
+
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+	oldConcurrency := flagDiffConcurrency
+	flagDiffConcurrency = 2
+	t.Cleanup(func() { flagDiffConcurrency = oldConcurrency })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runDiff() error: %v", err)
+	}
+
+	if got := highest.Load(); got > 2 {
+		t.Fatalf("expected at most 2 pages in flight, got %d", got)
+	}
+	got := out.String()
+	last := -1
+	for _, title := range []string{"Alpha", "Bravo", "Charlie", "Delta"} {
+		idx := strings.Index(got, "warning: "+title+".md [unresolved_reference]")
+		if idx < 0 {
+			t.Fatalf("expected unresolved warning for %s, got:\n%s", title, got)
+		}
+		if idx < last {
+			t.Fatalf("expected diagnostics in page order, got:\n%s", got)
+		}
+		last = idx
+	}
+}
+
+func TestRunDiff_RejectsNonPositiveConcurrency(t *testing.T) {
+	runParallelCommandTest(t)
+	oldConcurrency := flagDiffConcurrency
+	flagDiffConcurrency = 0
+	t.Cleanup(func() { flagDiffConcurrency = oldConcurrency })
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"})
+	if err == nil || !strings.Contains(err.Error(), "--concurrency") {
+		t.Fatalf("expected --concurrency validation error, got: %v", err)
+	}
+}
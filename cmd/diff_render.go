@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
 	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
@@ -21,6 +22,8 @@ func hydrateDiffPageMetadata(
 	remote syncflow.PullRemote,
 	page confluence.Page,
 	relPath string,
+	propertyKeys []string,
+	localFM fs.Frontmatter,
 ) (confluence.Page, []syncflow.PullDiagnostic) {
 	diagnostics := make([]syncflow.PullDiagnostic, 0, 2)
 
@@ -46,9 +49,49 @@ func hydrateDiffPageMetadata(
 		page.Labels = labels
 	}
 
+	restrictions, err := remote.GetRestrictions(ctx, page.ID)
+	if err != nil {
+		diagnostics = append(diagnostics, syncflow.PullDiagnostic{
+			Path:    filepath.ToSlash(relPath),
+			Code:    "RESTRICTIONS_FETCH_FAILED",
+			Message: fmt.Sprintf("fetch restrictions for page %s: %v", page.ID, err),
+		})
+	} else {
+		page.Restrictions = restrictions
+	}
+
+	localProperties, _ := localFM.Properties()
+	properties, err := syncflow.FetchPullProperties(ctx, remote, page.ID, propertyKeys, localProperties)
+	if err != nil {
+		diagnostics = append(diagnostics, syncflow.PullDiagnostic{
+			Path:    filepath.ToSlash(relPath),
+			Code:    "PROPERTIES_FETCH_FAILED",
+			Message: fmt.Sprintf("fetch content properties for page %s: %v", page.ID, err),
+		})
+	} else {
+		page.Properties = properties
+	}
+
 	return page, diagnostics
 }
 
+// readDiffLocalFrontmatter returns the frontmatter of the local file tracked
+// for pageID, or the zero value when there is none.
+func readDiffLocalFrontmatter(diffCtx diffContext, pageID string) fs.Frontmatter {
+	localPath := diffCtx.localPathByID[pageID]
+	if pageID == diffCtx.targetPageID && diffCtx.targetFile != "" {
+		localPath = diffCtx.targetFile
+	}
+	if localPath == "" {
+		return fs.Frontmatter{}
+	}
+	doc, err := fs.ReadMarkdownDocument(localPath)
+	if err != nil {
+		return fs.Frontmatter{}
+	}
+	return doc.Frontmatter
+}
+
 func renderDiffMarkdown(
 	ctx context.Context,
 	page confluence.Page,
@@ -59,9 +102,11 @@ func renderDiffMarkdown(
 	pagePathByIDAbs map[string]string,
 	attachmentPathByID map[string]string,
 	globalIndex syncflow.GlobalPageIndex,
+	stripTitleHeading bool,
+	localFM fs.Frontmatter,
 ) ([]byte, []syncflow.PullDiagnostic, error) {
 	linkNotices := make([]syncflow.ForwardLinkNotice, 0, 1)
-	forward, err := converter.Forward(ctx, page.BodyADF, converter.ForwardConfig{
+	forward, err := syncflow.ForwardPageBody(ctx, page, converter.ForwardConfig{
 		LinkHook: syncflow.NewForwardLinkHookWithGlobalIndex(
 			sourcePath,
 			spaceDir,
@@ -72,7 +117,9 @@ func renderDiffMarkdown(
 				linkNotices = append(linkNotices, notice)
 			},
 		),
-		MediaHook: syncflow.NewForwardMediaHook(sourcePath, attachmentPathByID),
+		MediaHook:      syncflow.NewForwardMediaHook(sourcePath, attachmentPathByID),
+		StripLeadingH1: stripTitleHeading,
+		Title:          page.Title,
 	}, sourcePath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("convert page %s: %w", page.ID, err)
@@ -86,9 +133,24 @@ func renderDiffMarkdown(
 			State:   page.Status,
 			Status:  page.ContentStatus,
 			Labels:  page.Labels,
+
+			Restrictions: syncflow.FrontmatterRestrictions(page.Restrictions),
 		},
 		Body: forward.Markdown,
 	}
+	// Parent pins are local intent the remote page does not record.
+	doc.Frontmatter.ParentID = localFM.ParentID
+	doc.Frontmatter.ParentPath = localFM.ParentPath
+	// Like pull, keep the local properties when none were fetched.
+	if page.Properties != nil {
+		properties, err := syncflow.FrontmatterPropertyValues(page.Properties)
+		if err != nil {
+			return nil, nil, fmt.Errorf("content properties of page %s: %w", page.ID, err)
+		}
+		doc.Frontmatter.SetProperties(properties)
+	} else if properties, err := localFM.Properties(); err == nil {
+		doc.Frontmatter.SetProperties(properties)
+	}
 
 	rendered, err := fs.FormatMarkdownDocument(doc)
 	if err != nil {
@@ -125,7 +187,7 @@ func copyLocalMarkdownSnapshot(spaceDir, snapshotDir string) error {
 			}
 			return nil
 		}
-		if filepath.Ext(path) != ".md" {
+		if filepath.Ext(path) != ".md" || fs.IsPageSidecar(path) {
 			return nil
 		}
 
@@ -170,6 +232,7 @@ func normalizeDiffMarkdown(raw []byte) ([]byte, error) {
 	doc.Frontmatter.CreatedAt = ""
 	doc.Frontmatter.UpdatedBy = ""
 	doc.Frontmatter.UpdatedAt = ""
+	doc.Frontmatter.URL = ""
 
 	normalized, err := fs.FormatMarkdownDocument(doc)
 	if err != nil {
@@ -296,3 +359,41 @@ func formatDiffLabels(labels []string) string {
 	}
 	return "[" + strings.Join(labels, ", ") + "]"
 }
+
+// removeSpaceIgnoredSnapshotFiles drops files matching the space config ignore
+// patterns from both diff snapshots so they never show up as changes.
+func removeSpaceIgnoredSnapshotFiles(spaceDir string, snapshotDirs ...string) error {
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return err
+	}
+	if len(spaceCfg.Ignore) == 0 {
+		return nil
+	}
+	for _, snapshotDir := range snapshotDirs {
+		if err := removeSnapshotFiles(snapshotDir, func(relPath string) bool {
+			return matchesSpaceIgnore(spaceCfg.Ignore, relPath)
+		}); err != nil {
+			return fmt.Errorf("apply %s ignore patterns: %w", config.SpaceConfigFileName, err)
+		}
+	}
+	return nil
+}
+
+// removeSnapshotFiles deletes snapshot files whose slash-separated relative
+// path satisfies drop.
+func removeSnapshotFiles(snapshotDir string, drop func(relPath string) bool) error {
+	return filepath.WalkDir(snapshotDir, func(path string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil || d.IsDir() {
+			return walkErr
+		}
+		relPath, err := filepath.Rel(snapshotDir, path)
+		if err != nil {
+			return err
+		}
+		if drop(filepath.ToSlash(relPath)) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
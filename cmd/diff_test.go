@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -940,3 +941,200 @@ func diffUnresolvedADF() map[string]any {
 		},
 	}
 }
+
+func TestRunDiff_ChangedOnlyFetchesLocallyChangedPages(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+
+	lastModified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	for _, page := range []struct{ id, title string }{{"1", "Alpha"}, {"2", "Beta"}} {
+		writeMarkdown(t, filepath.Join(spaceDir, page.title+".md"), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: page.title, ID: page.id, Version: 2, ConfluenceLastModified: "2026-02-01T11:00:00Z"},
+			Body:        page.title + " body\n",
+		})
+	}
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:        "ENG",
+		PagePathIndex:   map[string]string{"Alpha.md": "1", "Beta.md": "2"},
+		AttachmentIndex: map[string]string{},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "pull")
+	runGitForTest(t, repo, "tag", "confluence-sync/pull/ENG/20260201T120000Z")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Alpha.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Alpha", ID: "1", Version: 2, ConfluenceLastModified: "2026-02-01T11:00:00Z"},
+		Body:        "Alpha edited locally\n",
+	})
+
+	fetched := []string{}
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Alpha", Version: 2, LastModified: lastModified},
+			{ID: "2", SpaceID: "space-1", Title: "Beta", Version: 3, LastModified: lastModified},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Alpha", Version: 2, LastModified: lastModified, BodyADF: rawJSON(t, simpleADF("Alpha body"))},
+			"2": {ID: "2", SpaceID: "space-1", Title: "Beta", Version: 3, LastModified: lastModified, BodyADF: rawJSON(t, simpleADF("Beta edited remotely"))},
+		},
+		attachments: map[string][]byte{},
+	}
+	fake.getPageFunc = func(pageID string) (confluence.Page, error) {
+		fetched = append(fetched, pageID)
+		page, ok := fake.pagesByID[pageID]
+		if !ok {
+			return confluence.Page{}, confluence.ErrNotFound
+		}
+		return page, nil
+	}
+
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+
+	oldChangedOnly := flagDiffChangedOnly
+	flagDiffChangedOnly = true
+	t.Cleanup(func() { flagDiffChangedOnly = oldChangedOnly })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runDiff() error: %v\n%s", err, out.String())
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "comparing 1 locally changed file(s)") {
+		t.Fatalf("expected changed-only scope summary, got:\n%s", got)
+	}
+	if !strings.Contains(got, "Alpha edited locally") {
+		t.Fatalf("expected diff for locally changed Alpha.md, got:\n%s", got)
+	}
+	if strings.Contains(got, "Beta") {
+		t.Fatalf("unchanged Beta.md should not be compared, got:\n%s", got)
+	}
+	if strings.Join(fetched, ",") != "1" {
+		t.Fatalf("fetched pages = %v, want only the changed page 1", fetched)
+	}
+}
+
+func TestRunDiff_RendersTrackedContentPropertiesLikePull(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	spaceDir := filepath.Join(repo, "ENG")
+	localFile := filepath.Join(spaceDir, "root.md")
+	writeMarkdown(t, localFile, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                2,
+			ConfluenceLastModified: "2026-02-01T11:00:00Z",
+			Extra: map[string]any{
+				fs.PropertiesKey: map[string]any{"owner": "alice", "editor": "v2"},
+			},
+		},
+		Body: "same body\n",
+	})
+
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, simpleADF("same body"))},
+		},
+		propertiesByPage: map[string][]confluence.ContentProperty{
+			"1": {
+				{Key: "owner", Value: json.RawMessage(`"alice"`)},
+				{Key: "editor", Value: json.RawMessage(`"v3"`)},
+				{Key: "untracked", Value: json.RawMessage(`true`)},
+			},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeFile, Value: localFile}); err != nil {
+		t.Fatalf("runDiff() error: %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "-    editor: v2") || !strings.Contains(got, "+    editor: v3") {
+		t.Fatalf("diff should show the changed property value:\n%s", got)
+	}
+	for _, unexpected := range []string{"-properties:", "-    owner", "+    owner", "untracked"} {
+		if strings.Contains(got, unexpected) {
+			t.Fatalf("diff should only show the changed property, found %q:\n%s", unexpected, got)
+		}
+	}
+}
+
+func TestRunDiff_SpaceModeKeepsLocalParentPins(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                2,
+			ConfluenceLastModified: "2026-02-01T11:00:00Z",
+			ParentID:               "42",
+			ParentPath:             "Archive/Index.md",
+		},
+		Body: "same body\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		PagePathIndex:   map[string]string{"Root.md": "1"},
+		AttachmentIndex: map[string]string{},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, simpleADF("same body"))},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runDiff() error: %v", err)
+	}
+
+	if got := out.String(); strings.Contains(got, "parent_id") || strings.Contains(got, "parent_path") {
+		t.Fatalf("diff should not report the local parent pins as changes:\n%s", got)
+	}
+}
@@ -38,8 +38,12 @@ func newDoctorCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "doctor [TARGET]",
-		Short: "Check local sync state consistency",
-		Long: `doctor inspects the local workspace for consistency issues between
+		Short: "Diagnose setup problems and check local sync state consistency",
+		Long: `doctor first prints a setup checklist: git is installed and the workspace is a
+repository, .env and the three credentials are present, the domain is an HTTPS
+URL, the API token authenticates, and .confluence-state.json parses.
+
+It then inspects the local workspace for consistency issues between
 .confluence-state.json, the actual Markdown files on disk, and the git index.
 
 TARGET follows the standard rule:
@@ -58,6 +62,7 @@ Use --repair to automatically fix detected issues.`,
 	}
 
 	cmd.Flags().BoolVar(&repair, "repair", false, "Automatically repair detected issues where possible")
+	cmd.Flags().BoolVar(&flagDoctorOffline, "offline", false, "Skip the Confluence authentication check")
 
 	return cmd
 }
@@ -66,6 +71,11 @@ func runDoctor(cmd *cobra.Command, target config.Target, repair bool) error {
 	out := ensureSynchronizedCmdOutput(cmd)
 
 	initialCtx, err := resolveInitialPullContext(target)
+	setupDir := initialCtx.spaceDir
+	if setupDir == "" {
+		setupDir, _ = os.Getwd()
+	}
+	printDoctorSetupChecks(out, runDoctorSetupChecks(getCommandContext(cmd), setupDir))
 	if err != nil {
 		return err
 	}
@@ -422,7 +432,7 @@ func listDoctorMarkdownPaths(spaceDir string) ([]string, error) {
 			}
 			return nil
 		}
-		if !strings.EqualFold(filepath.Ext(d.Name()), ".md") {
+		if !strings.EqualFold(filepath.Ext(d.Name()), ".md") || fs.IsPageSidecar(d.Name()) {
 			return nil
 		}
 		rel, relErr := filepath.Rel(spaceDir, path)
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// flagDoctorOffline skips the Confluence authentication probe.
+var flagDoctorOffline bool
+
+const doctorAuthProbeTimeout = 15 * time.Second
+
+// doctorAuthProbe looks up the account the credentials authenticate as, so
+// doctor can tell credential problems apart from connectivity problems and
+// name the account even when bearer auth has no email configured.
+var doctorAuthProbe = func(ctx context.Context, cfg *config.Config) (confluence.User, error) {
+	return whoamiCurrentUser(ctx, cfg)
+}
+
+// doctorSetupCheck is one line of the doctor setup checklist.
+type doctorSetupCheck struct {
+	Name   string
+	Status string // pass, fail, or skip
+	Detail string
+	Hint   string
+}
+
+// runDoctorSetupChecks verifies the prerequisites every sync command relies
+// on: git, the repository, credentials, the domain, authentication, and the
+// space state file. Later checks are skipped when an earlier one they depend
+// on fails.
+func runDoctorSetupChecks(ctx context.Context, spaceDir string) []doctorSetupCheck {
+	checks := make([]doctorSetupCheck, 0, 7)
+	pass := func(name, detail string) {
+		checks = append(checks, doctorSetupCheck{Name: name, Status: "pass", Detail: detail})
+	}
+	fail := func(name, detail, hint string) {
+		checks = append(checks, doctorSetupCheck{Name: name, Status: "fail", Detail: detail, Hint: hint})
+	}
+	skip := func(name, detail string) {
+		checks = append(checks, doctorSetupCheck{Name: name, Status: "skip", Detail: detail})
+	}
+
+	if gitPath, err := exec.LookPath("git"); err != nil {
+		fail("git installed", "git was not found on PATH", "install git and make sure it is on PATH")
+		skip("git repository", "git is not installed")
+	} else {
+		pass("git installed", gitPath)
+		if root, err := gitRepoRoot(); err != nil {
+			fail("git repository", "the current directory is not inside a git repository", "run `conf init` (or `git init`) in your workspace root")
+		} else {
+			pass("git repository", root)
+		}
+	}
+
+	envPath := findEnvPath(spaceDir)
+	cfg, err := config.Load(envPath)
+	switch _, statErr := os.Stat(envPath); {
+	case statErr == nil:
+		pass(".env file", envPath)
+	case err == nil:
+		skip(".env file", "not found; credentials come from the environment")
+	default:
+		fail(".env file", "no .env found in "+filepath.Dir(envPath)+" or its parents", "run `conf init` to create one, or export ATLASSIAN_DOMAIN, ATLASSIAN_EMAIL and ATLASSIAN_API_TOKEN")
+	}
+
+	if err != nil {
+		detail := err.Error()
+		if errors.Is(err, config.ErrMissingConfig) {
+			detail = "missing " + strings.TrimSpace(strings.TrimPrefix(err.Error(), config.ErrMissingConfig.Error()+":"))
+		}
+		fail("credentials", detail, "set the missing values in .env or the environment")
+		skip("domain", "credentials are incomplete")
+		skip("authentication", "credentials are incomplete")
+	} else {
+		credentialsDetail := "domain, email and API token are set"
+		if confluence.ResolveAuthMode(confluence.AuthMode(cfg.AuthMode), cfg.Email) == confluence.AuthModeBearer {
+			credentialsDetail = "domain and API token are set (bearer auth)"
+		}
+		pass("credentials", credentialsDetail)
+		if detail, ok := validateDoctorDomain(cfg.Domain); !ok {
+			fail("domain", detail, "set ATLASSIAN_DOMAIN to your site URL, e.g. https://your-site.atlassian.net")
+			skip("authentication", "domain is invalid")
+		} else {
+			pass("domain", cfg.Domain)
+			if flagDoctorOffline {
+				skip("authentication", "--offline")
+			} else {
+				probeCtx, cancel := context.WithTimeout(ctx, doctorAuthProbeTimeout)
+				user, probeErr := doctorAuthProbe(probeCtx, cfg)
+				cancel()
+				if probeErr != nil {
+					fail("authentication", probeErr.Error(), doctorAuthHint(probeErr))
+				} else {
+					pass("authentication", "signed in as "+accountLabel(user))
+				}
+			}
+		}
+	}
+
+	statePath := fs.StatePath(spaceDir)
+	if !fs.HasState(spaceDir) {
+		skip("state file", "no "+fs.StateFileName+" in "+spaceDir+"; run `conf pull` to create it")
+	} else if _, err := fs.LoadState(spaceDir); err != nil {
+		fail("state file", err.Error(), "run `conf pull` to rebuild it, or `conf doctor --repair` for index issues")
+	} else {
+		pass("state file", statePath)
+	}
+
+	return checks
+}
+
+func validateDoctorDomain(domain string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(domain))
+	if err != nil {
+		return fmt.Sprintf("%q is not a valid URL: %v", domain, err), false
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Sprintf("%q must use https://", domain), false
+	}
+	if parsed.Host == "" {
+		return fmt.Sprintf("%q has no host name", domain), false
+	}
+	return "", true
+}
+
+func doctorAuthHint(err error) string {
+	var apiErr *confluence.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized:
+			return "token rejected — regenerate it at https://id.atlassian.com/manage-profile/security/api-tokens and check ATLASSIAN_EMAIL matches its owner"
+		case http.StatusForbidden:
+			return "the token is valid but the account lacks Confluence access — check its product access and space permissions"
+		case http.StatusNotFound:
+			return "no Confluence site at this domain — check ATLASSIAN_DOMAIN"
+		}
+		return "Confluence returned an unexpected error; rerun with --verbose for details"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timed out reaching Confluence — check network access and proxy settings"
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "the host name does not resolve — check ATLASSIAN_DOMAIN for typos"
+	}
+	return "could not reach Confluence — check ATLASSIAN_DOMAIN, proxy settings, and ATLASSIAN_CA_BUNDLE for TLS errors"
+}
+
+func printDoctorSetupChecks(out io.Writer, checks []doctorSetupCheck) {
+	_, _ = fmt.Fprintln(out, "Setup:")
+	for _, check := range checks {
+		line := fmt.Sprintf("  [%s] %s", check.Status, check.Name)
+		if check.Detail != "" {
+			line += ": " + check.Detail
+		}
+		_, _ = fmt.Fprintln(out, line)
+		if check.Hint != "" {
+			_, _ = fmt.Fprintf(out, "         hint: %s\n", check.Hint)
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+}
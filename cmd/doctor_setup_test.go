@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func stubDoctorAuthProbe(t *testing.T, err error) {
+	t.Helper()
+	stubDoctorAuthProbeUser(t, confluence.User{AccountID: "acc-1", DisplayName: "Test User", Email: "user@example.com"}, err)
+}
+
+func stubDoctorAuthProbeUser(t *testing.T, user confluence.User, err error) {
+	t.Helper()
+	old := doctorAuthProbe
+	doctorAuthProbe = func(context.Context, *config.Config) (confluence.User, error) { return user, err }
+	t.Cleanup(func() { doctorAuthProbe = old })
+}
+
+func setDoctorCredentialEnv(t *testing.T, domain string) {
+	t.Helper()
+	for _, key := range []string{"CONFLUENCE_URL", "CONFLUENCE_EMAIL", "CONFLUENCE_API_TOKEN"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("ATLASSIAN_DOMAIN", domain)
+	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "token-123")
+}
+
+func newDoctorSetupWorkspace(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "TEST")
+	state := fs.NewSpaceState()
+	state.SpaceKey = "TEST"
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".env"), []byte("# credentials come from the test environment\n"), 0o600); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	return spaceDir
+}
+
+func TestRunDoctor_SetupChecklistPasses(t *testing.T) {
+	runParallelCommandTest(t)
+
+	spaceDir := newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "https://example.atlassian.net")
+	stubDoctorAuthProbe(t, nil)
+
+	out := new(bytes.Buffer)
+	cmd := newDoctorCmd()
+	cmd.SetOut(out)
+	if err := runDoctor(cmd, config.Target{Value: spaceDir, Mode: config.TargetModeSpace}, false); err != nil {
+		t.Fatalf("runDoctor() error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"[pass] git installed",
+		"[pass] git repository",
+		"[pass] .env file",
+		"[pass] credentials",
+		"[pass] domain: https://example.atlassian.net",
+		"[pass] authentication: signed in as Test User <user@example.com>",
+		"[pass] state file",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("doctor output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "[fail]") {
+		t.Fatalf("doctor output has failing checks:\n%s", got)
+	}
+}
+
+func TestRunDoctor_SetupChecklistExplainsRejectedToken(t *testing.T) {
+	runParallelCommandTest(t)
+
+	spaceDir := newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "https://example.atlassian.net")
+	stubDoctorAuthProbe(t, &confluence.APIError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"})
+
+	out := new(bytes.Buffer)
+	cmd := newDoctorCmd()
+	cmd.SetOut(out)
+	if err := runDoctor(cmd, config.Target{Value: spaceDir, Mode: config.TargetModeSpace}, false); err != nil {
+		t.Fatalf("runDoctor() error: %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "[fail] authentication") || !strings.Contains(got, "token rejected — regenerate it at https://id.atlassian.com") {
+		t.Fatalf("doctor output does not explain the rejected token:\n%s", got)
+	}
+}
+
+func TestRunDoctorSetupChecks_RejectsNonHTTPSDomain(t *testing.T) {
+	runParallelCommandTest(t)
+
+	spaceDir := newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "http://example.atlassian.net")
+	stubDoctorAuthProbe(t, nil)
+
+	checks := runDoctorSetupChecks(context.Background(), spaceDir)
+	statusByName := map[string]string{}
+	for _, check := range checks {
+		statusByName[check.Name] = check.Status
+	}
+	if statusByName["domain"] != "fail" || statusByName["authentication"] != "skip" {
+		t.Fatalf("checks = %+v, want failing domain and skipped authentication", checks)
+	}
+}
+
+func TestRunDoctorSetupChecks_BearerAuthNamesSignedInAccount(t *testing.T) {
+	runParallelCommandTest(t)
+
+	spaceDir := newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "https://example.atlassian.net")
+	t.Setenv("ATLASSIAN_EMAIL", "")
+	stubDoctorAuthProbeUser(t, confluence.User{AccountID: "acc-1", DisplayName: "Build Bot"}, nil)
+
+	for _, check := range runDoctorSetupChecks(context.Background(), spaceDir) {
+		if check.Name != "authentication" {
+			continue
+		}
+		if check.Status != "pass" || check.Detail != "signed in as Build Bot" {
+			t.Fatalf("authentication check = %+v, want the account from the probe", check)
+		}
+		return
+	}
+	t.Fatal("authentication check missing")
+}
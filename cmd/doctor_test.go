@@ -64,6 +64,7 @@ func TestRunDoctor_ReportsStructuralAndSemanticIssues(t *testing.T) {
 		t.Fatalf("write unreadable: %v", err)
 	}
 
+	stubDoctorAuthProbe(t, nil)
 	out := new(bytes.Buffer)
 	cmd := newDoctorCmd()
 	cmd.SetOut(out)
@@ -132,6 +133,7 @@ func TestRunDoctor_RepairRemovesOnlySafeIssues(t *testing.T) {
 	syncBranch := "sync/TEST/20260305T211238Z"
 	runGitForTest(t, repo, "branch", syncBranch, "main")
 
+	stubDoctorAuthProbe(t, nil)
 	out := new(bytes.Buffer)
 	cmd := newDoctorCmd()
 	cmd.SetOut(out)
@@ -205,6 +207,7 @@ func TestRunDoctor_RepairScopesStaleSyncBranchesToTargetSpace(t *testing.T) {
 	runGitForTest(t, repo, "branch", testBranch, "main")
 	runGitForTest(t, repo, "branch", otherBranch, "main")
 
+	stubDoctorAuthProbe(t, nil)
 	out := new(bytes.Buffer)
 	cmd := newDoctorCmd()
 	cmd.SetOut(out)
@@ -83,6 +83,42 @@ func (d *dryRunPushRemote) RemoveLabel(ctx context.Context, pageID string, label
 	return nil
 }
 
+func (d *dryRunPushRemote) GetRestrictions(ctx context.Context, pageID string) (confluence.PageRestrictions, error) {
+	if strings.HasPrefix(pageID, "dry-run-") {
+		return confluence.PageRestrictions{}, nil
+	}
+	return d.inner.GetRestrictions(ctx, pageID)
+}
+
+func (d *dryRunPushRemote) SetRestrictions(ctx context.Context, pageID string, restrictions confluence.PageRestrictions) error {
+	if len(restrictions.Read) == 0 && len(restrictions.Update) == 0 {
+		d.printf("[DRY-RUN] DELETE RESTRICTIONS (DELETE %s/wiki/rest/api/content/%s/restriction)\n\n", d.domain, pageID)
+		return nil
+	}
+	d.printf("[DRY-RUN] SET RESTRICTIONS (PUT %s/wiki/rest/api/content/%s/restriction)\n", d.domain, pageID)
+	d.printf("  Read: %v\n", restrictions.Read)
+	d.printf("  Update: %v\n\n", restrictions.Update)
+	return nil
+}
+
+func (d *dryRunPushRemote) GetContentProperties(ctx context.Context, pageID string) ([]confluence.ContentProperty, error) {
+	if strings.HasPrefix(pageID, "dry-run-") {
+		return nil, nil
+	}
+	return d.inner.GetContentProperties(ctx, pageID)
+}
+
+func (d *dryRunPushRemote) SetContentProperty(ctx context.Context, pageID string, property confluence.ContentProperty) (confluence.ContentProperty, error) {
+	if property.ID == "" {
+		d.printf("[DRY-RUN] CREATE CONTENT PROPERTY (POST %s/wiki/api/v2/pages/%s/properties)\n", d.domain, pageID)
+	} else {
+		d.printf("[DRY-RUN] UPDATE CONTENT PROPERTY (PUT %s/wiki/api/v2/pages/%s/properties/%s)\n", d.domain, pageID, property.ID)
+	}
+	d.printf("  Key: %s\n", property.Key)
+	d.printf("  Value: %s\n\n", property.Value)
+	return property, nil
+}
+
 func (d *dryRunPushRemote) CreatePage(ctx context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
 	pageID := d.nextSyntheticPageID()
 	d.printf("[DRY-RUN] CREATE PAGE (POST %s/wiki/api/v2/pages)\n", d.domain)
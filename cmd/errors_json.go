@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// Machine-readable error codes written by --json-errors. Scripts branch on
+// these, so existing values must not change.
+const (
+	errorCodeConflict         = "CONFLICT"
+	errorCodeAuthFailed       = "AUTH_FAILED"
+	errorCodePermissionDenied = "PERMISSION_DENIED"
+	errorCodeNotFound         = "NOT_FOUND"
+	errorCodeRateLimited      = "RATE_LIMITED"
+	errorCodeRemoteError      = "REMOTE_ERROR"
+	errorCodeNetwork          = "NETWORK_ERROR"
+	errorCodeTimeout          = "TIMEOUT"
+	errorCodeInterrupted      = "INTERRUPTED"
+	errorCodeV2Unavailable    = "V2_API_UNAVAILABLE"
+	errorCodeFolderFallback   = "FOLDER_PAGE_FALLBACK_REQUIRED"
+	errorCodeUsage            = "USAGE_ERROR"
+	errorCodeUnknown          = "ERROR"
+)
+
+var flagJSONErrors bool
+
+// jsonErrorsInArgs is set by ExecuteContext when the raw arguments ask for
+// JSON errors, so a flag that fails to parse, before flagJSONErrors is set
+// and PersistentPreRunE runs, is still reported as JSON.
+var jsonErrorsInArgs bool
+
+// usageError is a flag that cobra could not parse.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+
+func (e *usageError) Unwrap() error { return e.err }
+
+// commandError is the JSON document --json-errors writes to stderr.
+type commandError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Path       string `json:"path,omitempty"`
+	PageID     string `json:"page_id,omitempty"`
+}
+
+// jsonErrorsEnabled reports whether failures are written as JSON: with
+// --json-errors, or when push already prints JSON via --output json.
+func jsonErrorsEnabled() bool {
+	return flagJSONErrors || jsonErrorsInArgs || flagPushOutput == pushOutputJSON
+}
+
+// argsRequestJSONErrors reports whether the raw command-line arguments
+// enable JSON errors: --json-errors, or push with --output json.
+func argsRequestJSONErrors(args []string) bool {
+	command := ""
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		switch {
+		case arg == "--json-errors":
+			return true
+		case strings.HasPrefix(arg, "--json-errors="):
+			if enabled, err := strconv.ParseBool(strings.TrimPrefix(arg, "--json-errors=")); err == nil && enabled {
+				return true
+			}
+		case command == "" && !strings.HasPrefix(arg, "-"):
+			command = arg
+		case command == "push" && arg == "--output=json":
+			return true
+		case command == "push" && arg == "--output" && i+1 < len(args) && args[i+1] == pushOutputJSON:
+			return true
+		}
+	}
+	return false
+}
+
+// prepareJSONErrors silences cobra's own error and usage output on root when
+// args ask for JSON errors, and marks flag parse failures as usage errors.
+func prepareJSONErrors(root *cobra.Command, args []string) {
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	if !argsRequestJSONErrors(args) {
+		return
+	}
+	jsonErrorsInArgs = true
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+}
+
+// silenceCobraErrorsForJSON stops cobra from printing its own "Error:" line
+// and usage, so stderr only carries the JSON document.
+func silenceCobraErrorsForJSON(cmd *cobra.Command) {
+	if jsonErrorsEnabled() {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+}
+
+// WriteError reports a failed command on w: one JSON line with a code when
+// JSON errors are enabled, the plain message otherwise.
+func WriteError(w io.Writer, err error) {
+	if err == nil {
+		return
+	}
+	if !jsonErrorsEnabled() {
+		_, _ = fmt.Fprintln(w, err)
+		return
+	}
+	raw, marshalErr := json.Marshal(classifyCommandError(err))
+	if marshalErr != nil {
+		_, _ = fmt.Fprintln(w, err)
+		return
+	}
+	_, _ = fmt.Fprintln(w, string(raw))
+}
+
+// classifyCommandError maps the typed errors returned by commands to a
+// stable code. Unrecognised errors get errorCodeUnknown.
+func classifyCommandError(err error) commandError {
+	out := commandError{Code: errorCodeUnknown, Message: err.Error()}
+
+	var conflictErr *syncflow.PushConflictError
+	var fallbackErr *syncflow.FolderPageFallbackRequiredError
+	var interruptedErr *commandInterruptedError
+	var flagErr *usageError
+	var apiErr *confluence.APIError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &flagErr):
+		out.Code = errorCodeUsage
+	case errors.As(err, &conflictErr):
+		out.Code = errorCodeConflict
+		out.Path = conflictErr.Path
+		out.PageID = conflictErr.PageID
+	case errors.As(err, &fallbackErr):
+		out.Code = errorCodeFolderFallback
+		out.Path = fallbackErr.Path
+	case errors.As(err, &interruptedErr):
+		out.Code = errorCodeInterrupted
+		if interruptedErr.timedOut {
+			out.Code = errorCodeTimeout
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		out.Code = errorCodeTimeout
+	case errors.Is(err, context.Canceled):
+		out.Code = errorCodeInterrupted
+	case errors.Is(err, confluence.ErrV2Unavailable):
+		out.Code = errorCodeV2Unavailable
+	case errors.As(err, &apiErr):
+		out.HTTPStatus = apiErr.StatusCode
+		out.Code = apiErrorCode(apiErr.StatusCode)
+	case errors.Is(err, confluence.ErrNotFound):
+		out.Code = errorCodeNotFound
+	case errors.As(err, &netErr):
+		out.Code = errorCodeNetwork
+		if netErr.Timeout() {
+			out.Code = errorCodeTimeout
+		}
+	}
+	return out
+}
+
+func apiErrorCode(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return errorCodeAuthFailed
+	case http.StatusForbidden:
+		return errorCodePermissionDenied
+	case http.StatusNotFound:
+		return errorCodeNotFound
+	case http.StatusConflict:
+		return errorCodeConflict
+	case http.StatusTooManyRequests:
+		return errorCodeRateLimited
+	default:
+		return errorCodeRemoteError
+	}
+}
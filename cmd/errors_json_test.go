@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestClassifyCommandError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		status int
+	}{
+		{
+			name: "push conflict",
+			err:  fmt.Errorf("push failed: %w", &syncflow.PushConflictError{Path: "a.md", PageID: "1", LocalVersion: 1, RemoteVersion: 2, Policy: "cancel"}),
+			code: errorCodeConflict,
+		},
+		{name: "unauthorized", err: &confluence.APIError{StatusCode: 401, Method: "GET", URL: "/x"}, code: errorCodeAuthFailed, status: 401},
+		{name: "forbidden", err: &confluence.APIError{StatusCode: 403, Method: "GET", URL: "/x"}, code: errorCodePermissionDenied, status: 403},
+		{name: "rate limited", err: &confluence.APIError{StatusCode: 429, Method: "GET", URL: "/x"}, code: errorCodeRateLimited, status: 429},
+		{name: "server error", err: &confluence.APIError{StatusCode: 502, Method: "GET", URL: "/x"}, code: errorCodeRemoteError, status: 502},
+		{name: "not found sentinel", err: fmt.Errorf("get page: %w", confluence.ErrNotFound), code: errorCodeNotFound},
+		{name: "interrupted", err: &commandInterruptedError{reason: "interrupted", err: context.Canceled}, code: errorCodeInterrupted},
+		{name: "timeout", err: &commandInterruptedError{reason: "pull aborted", timedOut: true, err: errors.New("request failed")}, code: errorCodeTimeout},
+		{name: "deadline", err: fmt.Errorf("pull: %w", context.DeadlineExceeded), code: errorCodeTimeout},
+		{name: "v2 unavailable", err: fmt.Errorf("list folders: %w", confluence.ErrV2Unavailable), code: errorCodeV2Unavailable},
+		{name: "plain", err: errors.New("something else"), code: errorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyCommandError(tt.err)
+			if got.Code != tt.code || got.HTTPStatus != tt.status {
+				t.Fatalf("classifyCommandError() = %+v, want code %s status %d", got, tt.code, tt.status)
+			}
+			if got.Message != tt.err.Error() {
+				t.Fatalf("message = %q, want %q", got.Message, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestWriteError_JSONMode(t *testing.T) {
+	oldJSON, oldOutput := flagJSONErrors, flagPushOutput
+	t.Cleanup(func() {
+		flagJSONErrors, flagPushOutput = oldJSON, oldOutput
+	})
+	conflict := &syncflow.PushConflictError{Path: "Guides/Setup.md", PageID: "42", LocalVersion: 3, RemoteVersion: 5, Policy: "cancel"}
+
+	flagJSONErrors, flagPushOutput = false, pushOutputText
+	out := &bytes.Buffer{}
+	WriteError(out, conflict)
+	if strings.HasPrefix(out.String(), "{") {
+		t.Fatalf("plain mode wrote JSON: %q", out.String())
+	}
+
+	for _, enable := range []func(){
+		func() { flagJSONErrors = true },
+		func() { flagPushOutput = pushOutputJSON },
+	} {
+		flagJSONErrors, flagPushOutput = false, pushOutputText
+		enable()
+		out.Reset()
+		WriteError(out, conflict)
+		var got commandError
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatalf("decode %q: %v", out.String(), err)
+		}
+		if got.Code != errorCodeConflict || got.Path != "Guides/Setup.md" || got.PageID != "42" || got.Message == "" {
+			t.Fatalf("json error = %+v", got)
+		}
+	}
+}
+
+func TestArgsRequestJSONErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"pull", "--json-errors"}, want: true},
+		{args: []string{"--json-errors=true", "pull"}, want: true},
+		{args: []string{"pull", "--json-errors=false"}, want: false},
+		{args: []string{"push", "--dry-run", "--output", "json"}, want: true},
+		{args: []string{"push", "--output=json"}, want: true},
+		{args: []string{"manifest", "--output", "json"}, want: false},
+		{args: []string{"pull", "--", "--json-errors"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := argsRequestJSONErrors(tt.args); got != tt.want {
+			t.Errorf("argsRequestJSONErrors(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestPrepareJSONErrors_ReportsFlagParseErrorsAsJSON(t *testing.T) {
+	oldJSON, oldInArgs, oldOutput := flagJSONErrors, jsonErrorsInArgs, flagPushOutput
+	t.Cleanup(func() {
+		flagJSONErrors, jsonErrorsInArgs, flagPushOutput = oldJSON, oldInArgs, oldOutput
+	})
+	flagJSONErrors, jsonErrorsInArgs, flagPushOutput = false, false, pushOutputText
+
+	root := &cobra.Command{Use: "conf", RunE: func(*cobra.Command, []string) error { return nil }}
+	root.PersistentFlags().BoolVar(new(bool), "json-errors", false, "")
+	// The bad flag comes first, so --json-errors itself is never parsed.
+	args := []string{"--no-such-flag", "--json-errors"}
+	prepareJSONErrors(root, args)
+	root.SetArgs(args)
+	cobraOut := &bytes.Buffer{}
+	root.SetOut(cobraOut)
+	root.SetErr(cobraOut)
+
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("expected a flag parse error")
+	}
+	if cobraOut.Len() != 0 {
+		t.Fatalf("cobra printed prose alongside the JSON error: %q", cobraOut.String())
+	}
+
+	out := &bytes.Buffer{}
+	WriteError(out, err)
+	var got commandError
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode %q: %v", out.String(), err)
+	}
+	if got.Code != errorCodeUsage || !strings.Contains(got.Message, "no-such-flag") {
+		t.Fatalf("json error = %+v", got)
+	}
+}
@@ -87,6 +87,7 @@ Space/
 - **Cross-space links**: Relative links to pages in sibling space directories are resolved at push time.
 - **Attachments**: Images and files stored in ` + "`assets/`" + ` are uploaded as Confluence page attachments.
 - **PlantUML**: Rendered round-trip support via the ` + "`plantumlcloud`" + ` Confluence macro.
+- **Table of Contents**: Write ` + "`[[TOC]]`" + ` on its own line for a Confluence Table of Contents macro; pulled TOC macros keep their parameters in an ` + "`adf-extension`" + ` wrapper.
 - **Mermaid**: Preserved as fenced code blocks; pushed as ADF ` + "`codeBlock`" + ` (not rendered as a Confluence diagram). ` + "`validate`" + ` warns with ` + "`MERMAID_PRESERVED_AS_CODEBLOCK`" + `.
 - **Hierarchy**: Pages with children use the ` + "`ParentPage/ParentPage.md`" + ` convention; moves are surfaced as ` + "`PAGE_PATH_MOVED`" + ` diagnostics.
 
@@ -354,6 +355,9 @@ func writeDotEnvFile(cfg config.Config) error {
 		fmt.Sprintf("ATLASSIAN_EMAIL=%s", strings.TrimSpace(cfg.Email)),
 		fmt.Sprintf("ATLASSIAN_API_TOKEN=%s", strings.TrimSpace(cfg.APIToken)),
 	}
+	if authMode := strings.TrimSpace(cfg.AuthMode); authMode != "" {
+		lines = append(lines, fmt.Sprintf("ATLASSIAN_AUTH_MODE=%s", authMode))
+	}
 
 	return os.WriteFile(".env", []byte(strings.Join(lines, "\n")+"\n"), 0o600) //nolint:gosec // Writing static filename
 }
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagListDepth int
+	flagListJSON  bool
+)
+
+// listTreeNode is one page or folder in the remote page tree.
+type listTreeNode struct {
+	Type     string          `json:"type"`
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Version  int             `json:"version,omitempty"`
+	Children []*listTreeNode `json:"children,omitempty"`
+}
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [SPACE_KEY]",
+		Short: "Print the remote page tree of a space",
+		Long: `list prints the remote page hierarchy of a space without pulling it.
+
+Pages and folders are shown as an indented tree with page IDs and versions.
+Without SPACE_KEY the space of the current directory is used.
+
+Examples:
+  conf list ENG
+  conf list ENG --depth 2
+  conf list ENG --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw string
+			if len(args) > 0 {
+				raw = args[0]
+			}
+			return runList(cmd, config.ParseTarget(raw))
+		},
+	}
+	cmd.Flags().IntVar(&flagListDepth, "depth", 0, "Maximum nesting depth to print (0 = unlimited)")
+	cmd.Flags().BoolVar(&flagListJSON, "json", false, "Print the tree as JSON")
+	return cmd
+}
+
+func runList(cmd *cobra.Command, target config.Target) error {
+	if target.IsFile() {
+		return errors.New("list takes a SPACE_KEY or space directory, not a Markdown file")
+	}
+	if flagListDepth < 0 {
+		return errors.New("--depth must be zero or positive")
+	}
+
+	out := ensureSynchronizedCmdOutput(cmd)
+	ctx := getCommandContext(cmd)
+
+	initialCtx, err := resolveInitialPullContext(target)
+	if err != nil {
+		return err
+	}
+	spaceKey := strings.TrimSpace(initialCtx.spaceKey)
+	if spaceKey == "" {
+		return fmt.Errorf("unable to resolve space key for %s", initialCtx.spaceDir)
+	}
+
+	envPath := findEnvPath(initialCtx.spaceDir)
+	cfg, err := config.Load(envPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	remote, err := newPullRemote(cfg)
+	if err != nil {
+		return fmt.Errorf("create Confluence client: %w", err)
+	}
+	defer closeRemoteIfPossible(remote)
+
+	roots, pageCount, diagnostics, err := buildRemotePageTree(ctx, remote, spaceKey)
+	if err != nil {
+		return err
+	}
+	for _, diag := range diagnostics {
+		if err := writeSyncDiagnostic(cmd.ErrOrStderr(), diag); err != nil {
+			return err
+		}
+	}
+
+	truncateListTree(roots, flagListDepth)
+	if flagListJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(roots)
+	}
+
+	_, _ = fmt.Fprintf(out, "Space: %s (%d page(s))\n", spaceKey, pageCount)
+	printListTree(out, roots, 0)
+	return nil
+}
+
+// buildRemotePageTree lists the current pages of spaceKey and arranges them
+// and their folders by parent. Nodes whose parent is not part of the space
+// listing become roots.
+func buildRemotePageTree(ctx context.Context, remote syncflow.PullRemote, spaceKey string) ([]*listTreeNode, int, []syncflow.PullDiagnostic, error) {
+	space, err := remote.GetSpace(ctx, spaceKey)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("fetch space %s: %w", spaceKey, err)
+	}
+
+	pages, err := listAllDiffPages(ctx, remote, confluence.PageListOptions{
+		SpaceID:  space.ID,
+		SpaceKey: space.Key,
+		Status:   "current",
+		Limit:    100,
+	})
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("list pages: %w", err)
+	}
+
+	folderByID, diagnostics, err := syncflow.ResolveFolderHierarchy(ctx, remote, pages)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("resolve folder hierarchy: %w", err)
+	}
+
+	nodes := make(map[string]*listTreeNode, len(pages)+len(folderByID))
+	parentByID := make(map[string]string, len(pages)+len(folderByID))
+	for _, folder := range folderByID {
+		id := strings.TrimSpace(folder.ID)
+		nodes[id] = &listTreeNode{Type: "folder", ID: id, Title: folder.Title}
+		parentByID[id] = strings.TrimSpace(folder.ParentID)
+	}
+	for _, page := range pages {
+		id := strings.TrimSpace(page.ID)
+		nodes[id] = &listTreeNode{Type: "page", ID: id, Title: page.Title, Version: page.Version}
+		parentByID[id] = strings.TrimSpace(page.ParentPageID)
+	}
+
+	roots := make([]*listTreeNode, 0)
+	for id, node := range nodes {
+		parent, ok := nodes[parentByID[id]]
+		if !ok || parent == node {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	sortListTree(roots)
+	return roots, len(pages), diagnostics, nil
+}
+
+func sortListTree(nodes []*listTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		left := strings.ToLower(nodes[i].Title)
+		right := strings.ToLower(nodes[j].Title)
+		if left != right {
+			return left < right
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	for _, node := range nodes {
+		sortListTree(node.Children)
+	}
+}
+
+// truncateListTree drops children below maxDepth levels; zero keeps all.
+func truncateListTree(nodes []*listTreeNode, maxDepth int) {
+	if maxDepth <= 0 {
+		return
+	}
+	for _, node := range nodes {
+		if maxDepth == 1 {
+			node.Children = nil
+			continue
+		}
+		truncateListTree(node.Children, maxDepth-1)
+	}
+}
+
+func printListTree(out io.Writer, nodes []*listTreeNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, node := range nodes {
+		if node.Type == "folder" {
+			_, _ = fmt.Fprintf(out, "%s%s/ (folder %s)\n", indent, node.Title, node.ID)
+		} else {
+			_, _ = fmt.Fprintf(out, "%s%s (id %s, v%d)\n", indent, node.Title, node.ID, node.Version)
+		}
+		printListTree(out, node.Children, depth+1)
+	}
+}
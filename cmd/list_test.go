@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+func setupListRemote(t *testing.T) {
+	t.Helper()
+
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Home", Version: 4},
+			{ID: "2", SpaceID: "space-1", Title: "Setup", ParentPageID: "1", ParentType: "page", Version: 2},
+			{ID: "3", SpaceID: "space-1", Title: "Runbook", ParentPageID: "folder-1", ParentType: "folder", Version: 1},
+		},
+		folderByID: map[string]confluence.Folder{
+			"folder-1": {ID: "folder-1", Title: "Ops", ParentID: "1", ParentType: "page"},
+		},
+	}
+
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	oldDepth, oldJSON := flagListDepth, flagListJSON
+	t.Cleanup(func() {
+		flagListDepth = oldDepth
+		flagListJSON = oldJSON
+	})
+}
+
+func TestRunList_PrintsIndentedTreeWithFolders(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupEnv(t)
+	chdirRepo(t, repo)
+	setupListRemote(t)
+
+	cmd := newListCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runList(cmd, config.Target{Value: "ENG", Mode: config.TargetModeSpace}); err != nil {
+		t.Fatalf("runList() error: %v", err)
+	}
+
+	want := "Space: ENG (3 page(s))\n" +
+		"Home (id 1, v4)\n" +
+		"  Ops/ (folder folder-1)\n" +
+		"    Runbook (id 3, v1)\n" +
+		"  Setup (id 2, v2)\n"
+	if out.String() != want {
+		t.Fatalf("list output mismatch\n got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRunList_JSONRespectsDepth(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupEnv(t)
+	chdirRepo(t, repo)
+	setupListRemote(t)
+
+	cmd := newListCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	flagListJSON = true
+	flagListDepth = 2
+
+	if err := runList(cmd, config.Target{Value: "ENG", Mode: config.TargetModeSpace}); err != nil {
+		t.Fatalf("runList() error: %v", err)
+	}
+
+	var roots []listTreeNode
+	if err := json.Unmarshal(out.Bytes(), &roots); err != nil {
+		t.Fatalf("decode JSON output: %v\n%s", err, out.String())
+	}
+	if len(roots) != 1 || roots[0].ID != "1" || len(roots[0].Children) != 2 {
+		t.Fatalf("unexpected tree: %s", out.String())
+	}
+	folder := roots[0].Children[0]
+	if folder.Type != "folder" || folder.Title != "Ops" {
+		t.Fatalf("first child = %+v, want Ops folder", folder)
+	}
+	if len(folder.Children) != 0 {
+		t.Fatalf("--depth 2 should drop the folder's children, got %+v", folder.Children)
+	}
+	if strings.Contains(out.String(), "Runbook") {
+		t.Fatalf("expected Runbook to be cut by --depth, got:\n%s", out.String())
+	}
+}
@@ -2,12 +2,38 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"strings"
 	"sync/atomic"
 	"time"
 )
 
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogHandler builds the slog handler used for diagnostic logs on stderr.
+// Warnings and errors are always shown; --verbose lowers the level to debug,
+// which includes Confluence HTTP request/response traces.
+func newLogHandler(w io.Writer, format string, verbose bool) (slog.Handler, error) {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", logFormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case logFormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q (expected %s or %s)", format, logFormatText, logFormatJSON)
+	}
+}
+
 var runIDNowUTC = func() time.Time {
 	return time.Now().UTC()
 }
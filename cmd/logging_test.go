@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLogHandler_JSONFormatEmitsStructuredRecords(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	handler, err := newLogHandler(buf, "json", false)
+	if err != nil {
+		t.Fatalf("newLogHandler() error: %v", err)
+	}
+	logger := slog.New(handler)
+	logger.Warn("push_validation_skipped", "reason", "skip_validate_flag")
+
+	var record map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &record); err != nil {
+		t.Fatalf("expected JSON log record, got %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "push_validation_skipped" || record["reason"] != "skip_validate_flag" {
+		t.Fatalf("unexpected JSON log record: %#v", record)
+	}
+}
+
+func TestNewLogHandler_VerboseEnablesDebugLevel(t *testing.T) {
+	t.Parallel()
+
+	quiet, err := newLogHandler(&bytes.Buffer{}, "text", false)
+	if err != nil {
+		t.Fatalf("newLogHandler() error: %v", err)
+	}
+	if quiet.Enabled(context.Background(), slog.LevelInfo) {
+		t.Fatal("default handler should suppress info-level diagnostics")
+	}
+
+	verbose, err := newLogHandler(&bytes.Buffer{}, "", true)
+	if err != nil {
+		t.Fatalf("newLogHandler() error: %v", err)
+	}
+	if !verbose.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("verbose handler should enable debug-level diagnostics")
+	}
+}
+
+func TestNewLogHandler_RejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := newLogHandler(&bytes.Buffer{}, "xml", false)
+	if err == nil || !strings.Contains(err.Error(), "invalid --log-format") {
+		t.Fatalf("expected invalid --log-format error, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var flagManifestOutput string
+
+// syncManifest is the document written by `conf manifest`. Unlike
+// .confluence-state.json it is meant to be committed and read by other
+// tools, so it holds no sync bookkeeping and no timestamps.
+type syncManifest struct {
+	SpaceKey    string                   `json:"space_key"`
+	Pages       []syncManifestPage       `json:"pages"`
+	Attachments []syncManifestAttachment `json:"attachments"`
+}
+
+type syncManifestPage struct {
+	Path     string `json:"path"`
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	Version  int    `json:"version,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type syncManifestAttachment struct {
+	Path string `json:"path"`
+	ID   string `json:"id"`
+}
+
+func newManifestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest [SPACE_KEY]",
+		Short: "Write a JSON manifest of the synced pages and attachments",
+		Long: `manifest maps every tracked Markdown file to its Confluence page ID,
+version, title, parent and URL, and every tracked attachment file to its
+attachment ID. It reads .confluence-state.json and each file's frontmatter;
+Confluence is not contacted.
+
+The manifest is meant to be committed and consumed by other tools such as
+search indexers or link checkers. Paths are relative to the space directory.
+Without --output the manifest is printed to stdout.
+
+Examples:
+  conf manifest ENG --output ENG/manifest.json
+  conf manifest`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw string
+			if len(args) > 0 {
+				raw = args[0]
+			}
+			return runManifest(cmd, config.ParseTarget(raw))
+		},
+	}
+	cmd.Flags().StringVarP(&flagManifestOutput, "output", "o", "", "Write the manifest to this file instead of stdout")
+	return cmd
+}
+
+func runManifest(cmd *cobra.Command, target config.Target) error {
+	if target.IsFile() {
+		return errors.New("manifest takes a SPACE_KEY or space directory, not a Markdown file")
+	}
+
+	initialCtx, err := resolveInitialPullContext(target)
+	if err != nil {
+		return err
+	}
+	spaceDir := initialCtx.spaceDir
+	if !dirExists(spaceDir) {
+		return fmt.Errorf("space directory not found: %s", spaceDir)
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	domain := ""
+	if cfg, err := config.Load(findEnvPath(spaceDir)); err == nil {
+		domain = cfg.Domain
+	}
+	manifest := buildSyncManifest(spaceDir, state, domain, func(relPath string, err error) {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: %v\n", relPath, err)
+	})
+	if manifest.SpaceKey == "" {
+		manifest.SpaceKey = strings.TrimSpace(initialCtx.spaceKey)
+	}
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	raw = append(raw, '\n')
+
+	if strings.TrimSpace(flagManifestOutput) == "" {
+		_, err := ensureSynchronizedCmdOutput(cmd).Write(raw)
+		return err
+	}
+	outputPath := flagManifestOutput
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("create manifest directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, raw, 0o644); err != nil { //nolint:gosec // the manifest is meant to be committed and shared
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote manifest of %d page(s) and %d attachment(s) to %s\n", len(manifest.Pages), len(manifest.Attachments), outputPath)
+	return nil
+}
+
+// buildSyncManifest lists the pages and attachments tracked in state, in
+// path order. Page titles, versions, parents and URLs come from frontmatter;
+// a page whose file cannot be read keeps its state entry and is reported
+// through warn. URLs fall back to the page-ID link form when domain is set
+// and the frontmatter has no url.
+func buildSyncManifest(spaceDir string, state fs.SpaceState, domain string, warn func(relPath string, err error)) syncManifest {
+	manifest := syncManifest{
+		SpaceKey:    strings.TrimSpace(state.SpaceKey),
+		Pages:       []syncManifestPage{},
+		Attachments: []syncManifestAttachment{},
+	}
+
+	pageIDByPath := make(syncflow.PageIndex, len(state.PagePathIndex))
+	for relPath, pageID := range state.PagePathIndex {
+		if pageID = strings.TrimSpace(pageID); pageID != "" {
+			pageIDByPath[normalizeRepoRelPath(relPath)] = pageID
+		}
+	}
+	folderIDByPath := make(map[string]string, len(state.FolderPathIndex))
+	for relPath, folderID := range state.FolderPathIndex {
+		folderIDByPath[normalizeRepoRelPath(relPath)] = strings.TrimSpace(folderID)
+	}
+
+	for relPath, pageID := range pageIDByPath {
+		page := syncManifestPage{Path: relPath, ID: pageID}
+		fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath)))
+		if err != nil {
+			warn(relPath, err)
+		} else {
+			page.Title = strings.TrimSpace(fm.Title)
+			page.Version = fm.Version
+			page.URL = strings.TrimSpace(fm.URL)
+		}
+		fm.ID = pageID
+		page.ParentID = syncflow.LocalParentID(relPath, fm, pageIDByPath, folderIDByPath)
+		if page.URL == "" && domain != "" {
+			page.URL = strings.TrimRight(domain, "/") + "/wiki/pages/viewpage.action?pageId=" + pageID
+		}
+		manifest.Pages = append(manifest.Pages, page)
+	}
+	sort.Slice(manifest.Pages, func(i, j int) bool { return manifest.Pages[i].Path < manifest.Pages[j].Path })
+
+	for relPath, attachmentID := range state.AttachmentIndex {
+		manifest.Attachments = append(manifest.Attachments, syncManifestAttachment{
+			Path: normalizeRepoRelPath(relPath),
+			ID:   strings.TrimSpace(attachmentID),
+		})
+	}
+	sort.Slice(manifest.Attachments, func(i, j int) bool { return manifest.Attachments[i].Path < manifest.Attachments[j].Path })
+	return manifest
+}
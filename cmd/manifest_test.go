@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestRunManifest_WritesPagesAndAttachments(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Guides.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Guides", ID: "10", Version: 3, URL: "https://example.atlassian.net/wiki/spaces/ENG/pages/10/Guides"},
+		Body:        "guides\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Setup.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Setup", ID: "11", Version: 1},
+		Body:        "setup\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Pinned.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Pinned", ID: "12", Version: 2, ParentPath: "Guides/Setup.md"},
+		Body:        "pinned\n",
+	})
+	state := fs.NewSpaceState()
+	state.SpaceKey = "ENG"
+	state.PagePathIndex = map[string]string{"Guides/Guides.md": "10", "Guides/Setup.md": "11", "Pinned.md": "12"}
+	state.AttachmentIndex = map[string]string{"assets/11/diagram.png": "att-1"}
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	cmd := newManifestCmd()
+	if err := cmd.Flags().Set("output", filepath.Join("ENG", "manifest.json")); err != nil {
+		t.Fatalf("set --output: %v", err)
+	}
+	t.Cleanup(func() { flagManifestOutput = "" })
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runManifest(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runManifest() error: %v", err)
+	}
+	if !strings.Contains(out.String(), "3 page(s) and 1 attachment(s)") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "manifest.json")) //nolint:gosec // test path
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var got syncManifest
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	want := syncManifest{
+		SpaceKey: "ENG",
+		Pages: []syncManifestPage{
+			{Path: "Guides/Guides.md", ID: "10", Title: "Guides", Version: 3, URL: "https://example.atlassian.net/wiki/spaces/ENG/pages/10/Guides"},
+			{Path: "Guides/Setup.md", ID: "11", Title: "Setup", Version: 1, ParentID: "10", URL: "https://example.atlassian.net/wiki/pages/viewpage.action?pageId=11"},
+			{Path: "Pinned.md", ID: "12", Title: "Pinned", Version: 2, ParentID: "11", URL: "https://example.atlassian.net/wiki/pages/viewpage.action?pageId=12"},
+		},
+		Attachments: []syncManifestAttachment{{Path: "assets/11/diagram.png", ID: "att-1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("manifest = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestBuildSyncManifest_KeepsPagesWithUnreadableFiles(t *testing.T) {
+	t.Parallel()
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Gone.md": "7"}
+
+	var warned []string
+	manifest := buildSyncManifest(t.TempDir(), state, "", func(relPath string, _ error) {
+		warned = append(warned, relPath)
+	})
+	if len(manifest.Pages) != 1 || manifest.Pages[0].ID != "7" || manifest.Pages[0].Title != "" {
+		t.Fatalf("pages = %+v, want the state entry without frontmatter fields", manifest.Pages)
+	}
+	if !reflect.DeepEqual(warned, []string{"Gone.md"}) {
+		t.Fatalf("warned = %v, want [Gone.md]", warned)
+	}
+}
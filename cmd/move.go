@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func newMoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move SRC.md DEST.md",
+		Short: "Move a page and its children to another path in the same space",
+		Long: `move relocates a Markdown page inside its space, together with its comments and history
+sidecars and the directory holding its child pages. Relative links in the space that point at
+the moved files, and the links inside them, are rewritten, and .confluence-state.json follows
+the new paths so the move is not pushed as a delete.
+
+The next 'conf push' re-parents the page under the page or folder of its new directory. A
+page with children keeps the directory layout: moving Guides/Guides.md to Handbook/Guides.md
+places it at Handbook/Guides/Guides.md. Nothing is sent to Confluence by this command.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMove(cmd, args[0], args[1])
+		},
+	}
+	return cmd
+}
+
+func runMove(cmd *cobra.Command, src, dest string) (runErr error) {
+	if err := ensureWorkspaceSyncReady("move"); err != nil {
+		return err
+	}
+	out := ensureSynchronizedCmdOutput(cmd)
+
+	lock, err := acquireWorkspaceLock("move")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := lock.Release(); runErr == nil && releaseErr != nil {
+			runErr = releaseErr
+		}
+	}()
+
+	srcAbs, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	destAbs, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	spaceDir := findSpaceDirFromFile(srcAbs, "")
+	if !fs.HasState(spaceDir) {
+		return fmt.Errorf("%s is not inside a pulled space directory", src)
+	}
+	srcRel, err := filepath.Rel(spaceDir, srcAbs)
+	if err != nil {
+		return err
+	}
+	destRel, err := filepath.Rel(spaceDir, destAbs)
+	if err != nil {
+		return err
+	}
+
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+	result, err := syncflow.MovePage(spaceDir, &state, srcRel, destRel)
+	if err != nil {
+		return err
+	}
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "moved %s to %s\n", result.From, result.To)
+	if result.FromDir != "" {
+		_, _ = fmt.Fprintf(out, "moved %s/ to %s/\n", result.FromDir, result.ToDir)
+	}
+	if result.LinksRewritten > 0 {
+		_, _ = fmt.Fprintf(out, "rewrote %d link(s) in %s\n", result.LinksRewritten, strings.Join(result.RewrittenFiles, ", "))
+	}
+	if result.DroppedParentPin {
+		_, _ = fmt.Fprintf(out, "removed the parent_id/parent_path pin from %s so its new directory decides its parent\n", result.To)
+	}
+	switch {
+	case !strings.Contains(result.To, "/"):
+		_, _ = fmt.Fprintln(out, "the page is now at the space root; 'conf push' keeps its current Confluence parent unless you set parent_id")
+	case result.ParentID != "":
+		_, _ = fmt.Fprintf(out, "run 'conf push' to re-parent the page in Confluence (parent: %s)\n", result.ParentID)
+	default:
+		_, _ = fmt.Fprintln(out, "run 'conf push' to re-parent the page in Confluence")
+	}
+	return nil
+}
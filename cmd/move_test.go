@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestRunMove_MovesPageAndUpdatesState(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "Setup.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Setup", ID: "11", Version: 2},
+		Body:        "setup\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Guides.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Guides", ID: "10", Version: 1},
+		Body:        "Start with [setup](../Setup.md).\n",
+	})
+	state := fs.NewSpaceState()
+	state.SpaceKey = "ENG"
+	state.PagePathIndex = map[string]string{"Setup.md": "11", "Guides/Guides.md": "10"}
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "baseline")
+
+	cmd := newMoveCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runMove(cmd, filepath.Join("ENG", "Setup.md"), filepath.Join("ENG", "Guides", "Setup.md")); err != nil {
+		t.Fatalf("runMove() error: %v\n%s", err, out.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(spaceDir, "Guides", "Setup.md")); err != nil {
+		t.Fatalf("moved file missing: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Guides", "Guides.md")) //nolint:gosec // test temp dir
+	if err != nil {
+		t.Fatalf("read Guides.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "[setup](Setup.md)") {
+		t.Fatalf("link not rewritten:\n%s", raw)
+	}
+	saved, err := fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if saved.PagePathIndex["Guides/Setup.md"] != "11" {
+		t.Fatalf("state index = %+v, want Guides/Setup.md tracked", saved.PagePathIndex)
+	}
+	if !strings.Contains(out.String(), "(parent: 10)") {
+		t.Fatalf("output = %q, want the new parent", out.String())
+	}
+}
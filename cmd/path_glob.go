@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// validateRelPathGlobs rejects malformed glob patterns before any work starts.
+func validateRelPathGlobs(flagName string, patterns []string) error {
+	for _, pattern := range patterns {
+		trimmed := strings.TrimSpace(pattern)
+		if trimmed == "" {
+			return fmt.Errorf("invalid %s pattern: empty value", flagName)
+		}
+		for _, segment := range strings.Split(trimmed, "/") {
+			if segment == "**" {
+				continue
+			}
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid %s pattern %q: %w", flagName, pattern, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import "testing"
+
+func TestValidateRelPathGlobs_RejectsMalformedPatterns(t *testing.T) {
+	t.Parallel()
+
+	if err := validateRelPathGlobs("--only", []string{"Guides/**", "*.md"}); err != nil {
+		t.Fatalf("unexpected error for valid patterns: %v", err)
+	}
+	if err := validateRelPathGlobs("--only", []string{"Guides/[abc"}); err == nil {
+		t.Fatal("expected malformed pattern to be rejected")
+	}
+	if err := validateRelPathGlobs("--only", []string{"  "}); err == nil {
+		t.Fatal("expected empty pattern to be rejected")
+	}
+}
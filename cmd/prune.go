@@ -9,16 +9,22 @@ import (
 
 	"github.com/charmbracelet/huh"
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
 	"github.com/spf13/cobra"
 )
 
+var flagPruneDryRun bool
+
 func newPruneCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "prune [TARGET]",
-		Short: "Delete orphaned local assets",
-		Long: `Prune scans assets/ inside a managed space and deletes files that are no longer referenced
-by any markdown page in that space.
+		Use:     "prune [TARGET]",
+		Aliases: []string{"prune-assets"},
+		Short:   "Delete orphaned local assets",
+		Long: `Prune scans assets/ inside a managed space, and each page's attachment directory in a space
+pulled with --flatten-assets, and deletes files that are no longer referenced by any markdown
+page in that space. Attachments still tracked in .confluence-state.json for a page in the page
+index are kept.
 
 TARGET follows the standard rule:
 - .md suffix => file mode (space inferred from file)
@@ -34,6 +40,7 @@ TARGET follows the standard rule:
 	}
 
 	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve orphan asset deletion")
+	cmd.Flags().BoolVar(&flagPruneDryRun, "dry-run", false, "List orphan assets without deleting them")
 	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when confirmation is required")
 	return cmd
 }
@@ -54,7 +61,12 @@ func runPrune(cmd *cobra.Command, target config.Target) error {
 		return fmt.Errorf("space directory not found: %s", spaceDir)
 	}
 
-	orphans, err := syncflow.FindOrphanAssets(spaceDir)
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	orphans, err := syncflow.FindOrphanAssets(spaceDir, state)
 	if err != nil {
 		return fmt.Errorf("scan orphan assets: %w", err)
 	}
@@ -69,6 +81,11 @@ func runPrune(cmd *cobra.Command, target config.Target) error {
 		_, _ = fmt.Fprintf(out, "  - %s\n", relPath)
 	}
 
+	if flagPruneDryRun {
+		_, _ = fmt.Fprintf(out, "prune dry run: would delete %d orphan asset(s); no files were changed\n", len(orphans))
+		return nil
+	}
+
 	if err := confirmPruneDeletion(cmd.InOrStdin(), out, len(orphans)); err != nil {
 		return err
 	}
@@ -90,6 +90,50 @@ func TestRunPrune_Integration(t *testing.T) {
 	}
 }
 
+func TestRunPrune_DryRunListsOrphansWithoutDeleting(t *testing.T) {
+	// DO NOT runParallelCommandTest here because we modify global flags
+	repo := setupGitRepoForPrune(t)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "TEST")
+	if err := os.MkdirAll(filepath.Join(spaceDir, "assets", "9"), 0750); err != nil {
+		t.Fatalf("failed to create assets dir: %v", err)
+	}
+	state := fs.NewSpaceState()
+	state.SpaceKey = "TEST"
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	orphanPath := filepath.Join(spaceDir, "assets", "9", "orphan.png")
+	if err := os.WriteFile(orphanPath, []byte("png"), 0600); err != nil {
+		t.Fatalf("failed to write orphan asset: %v", err)
+	}
+
+	oldYes := flagYes
+	oldDryRun := flagPruneDryRun
+	defer func() {
+		flagYes = oldYes
+		flagPruneDryRun = oldDryRun
+	}()
+
+	cmd := newPruneCmd()
+	flagYes = false
+	flagPruneDryRun = true
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+
+	if err := runPrune(cmd, config.Target{Value: "TEST", Mode: config.TargetModeSpace}); err != nil {
+		t.Fatalf("runPrune failed: %v\nOutput: %s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "assets/9/orphan.png") || !strings.Contains(out.String(), "would delete 1 orphan asset(s)") {
+		t.Fatalf("expected dry-run listing, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(orphanPath); err != nil {
+		t.Fatalf("expected orphan asset to survive dry run: %v", err)
+	}
+}
+
 func setupGitRepoForPrune(t *testing.T) string {
 	t.Helper()
 	repo := t.TempDir()
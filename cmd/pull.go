@@ -21,6 +21,22 @@ var (
 	flagPullForce        = false
 	flagPullDiscardLocal = false
 	flagPullRelink       = false
+	flagPullLimit        = 0
+	flagPullComments     = false
+	flagPullHistory      = false
+	flagPullHistoryLimit = 10
+	flagPullFlatten      = false
+	flagPullOverlap      = syncflow.DefaultPullOverlapWindow
+	flagPullConcurrency  = syncflow.DefaultPullConcurrency
+	flagPullInclude      []string
+	flagPullExclude      []string
+
+	flagPullSpaces          []string
+	flagPullContinueOnError = false
+
+	// flagPullOverwriteLocal takes the website version of files whose local
+	// uncommitted edits conflict with the pull, keeping every other local change.
+	flagPullOverwriteLocal = false
 
 	newPullRemote = func(cfg *config.Config) (syncflow.PullRemote, error) {
 		return newConfluenceClientFromConfig(cfg)
@@ -44,22 +60,56 @@ func newPullCmd() *cobra.Command {
 		Long: `Pull fetches Confluence pages and converts them to local Markdown files.
 
 TARGET can be a SPACE_KEY (e.g. "MYSPACE") or a path to a .md file.
-If omitted, the space is inferred from the current directory name.`,
+If omitted, the space is inferred from the current directory name.
+
+Local uncommitted changes are stashed before the pull and reapplied after it.
+When a reapplied edit conflicts with pulled content:
+- by default the file is three-way merged, then you choose how to resolve
+  any overlapping edits,
+- --overwrite-local takes the website version of each conflicting file and
+  keeps all non-conflicting local changes,
+- --discard-local drops the stash, discarding every local uncommitted change
+  in scope whether it conflicts or not.
+Committed local edits are never discarded: a page changed on Confluence is
+rewritten in the pull commit and the earlier commit stays in history.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var raw string
 			if len(args) > 0 {
 				raw = args[0]
 			}
+			if len(flagPullSpaces) > 0 {
+				if raw != "" {
+					return errors.New("--spaces cannot be combined with a TARGET argument")
+				}
+				return runPullSpaces(cmd, flagPullSpaces)
+			}
 			return runPull(cmd, config.ParseTarget(raw))
 		},
 	}
 	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve safety confirmations")
 	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when a decision is required")
+	cmd.Flags().IntVar(&flagMaxImpact, "max-impact", 0, "Proceed without confirmation when fewer than N files are affected; larger runs need --yes (deletes always do; 0 = default of 11)")
 	cmd.Flags().BoolVarP(&flagSkipMissingAssets, "skip-missing-assets", "s", false, "Continue if an attachment is missing (not found)")
 	cmd.Flags().BoolVarP(&flagPullForce, "force", "f", false, "Force full space pull and refresh all tracked pages")
-	cmd.Flags().BoolVar(&flagPullDiscardLocal, "discard-local", false, "Discard local uncommitted changes if they conflict with remote updates")
+	cmd.Flags().BoolVar(&flagPullDiscardLocal, "discard-local", false, "Discard ALL local uncommitted changes in scope (the stash is dropped), including edits that do not conflict")
+	cmd.Flags().BoolVar(&flagPullOverwriteLocal, "overwrite-local", false, "Take the website version of files whose local uncommitted edits conflict with remote updates; keep every other local change")
+	cmd.Flags().BoolVar(&flagPullAutoMerge, "auto-merge", true, "Three-way merge non-overlapping local and remote edits before asking how to resolve a conflict")
 	cmd.Flags().BoolVarP(&flagPullRelink, "relink", "r", false, "Automatically relink references to this space from other spaces after pull")
+	cmd.Flags().DurationVar(&flagPullOverlap, "overlap", syncflow.DefaultPullOverlapWindow, "Re-check remote changes this far before the last pull watermark to tolerate clock skew (larger = more re-fetches, fewer missed changes)")
+	cmd.Flags().BoolVar(&flagPullPruneLocal, "prune-local", false, "Delete local markdown files that have no page in the space's page index (full-space pulls only)")
+	cmd.Flags().BoolVar(&flagPullFlatten, "flatten-assets", false, "Store each page's attachments in a directory named after the page, next to its Markdown file, instead of assets/<page-id>/ (remembered for later pulls and pushes; --flatten-assets=false switches back)")
+	cmd.Flags().BoolVar(&flagPullComments, "comments", false, "Mirror the footer comments of every tracked page in scope into a read-only <page>.comments.md file that push ignores")
+	cmd.Flags().BoolVar(&flagPullHistory, "with-history", false, "Mirror each pulled page's recent version history into a read-only <page>.history.md file that push ignores (one extra API call per page)")
+	cmd.Flags().IntVar(&flagPullHistoryLimit, "history-limit", 10, "Maximum number of versions captured per page by --with-history")
+	cmd.Flags().StringSliceVar(&flagPullSpaces, "spaces", nil, "Pull several spaces in turn, each into its own directory (comma-separated keys; globs such as 'ENG*' match spaces already tracked in this repository)")
+	cmd.Flags().BoolVar(&flagPullContinueOnError, "continue-on-error", false, "With --spaces, keep pulling the remaining spaces when one fails and report all failures at the end")
+	cmd.Flags().IntVar(&flagPullConcurrency, "concurrency", syncflow.DefaultPullConcurrency, "Number of pages fetched and attachments downloaded at the same time")
+	cmd.Flags().StringArrayVar(&flagPullInclude, "include", nil, "Only write, move or delete pages whose planned space-relative path matches this glob (repeatable; supports ** e.g. \"API/**\")")
+	cmd.Flags().StringArrayVar(&flagPullExclude, "exclude", nil, "Leave pages whose planned space-relative path matches this glob untouched (repeatable; supports **)")
+	cmd.Flags().IntVar(&flagPullLimit, "limit", 0, "Maximum number of remote pages to list in this run; later runs resume from the saved cursor (0 = unlimited)")
+	cmd.Flags().StringVar(&flagPullCommitTemplate, "commit-template", "", "Go text/template for the pull commit message: first line is the subject ({{.SpaceKey}}, {{.Version}}, {{.UpdatedPages}}, {{.DeletedPages}}) (overrides pull.commit_template in .cms-space.yaml)")
+	addCommandTimeoutFlag(cmd)
 	addReportJSONFlag(cmd)
 	return cmd
 }
@@ -69,8 +119,13 @@ func runPull(cmd *cobra.Command, target config.Target) (runErr error) {
 	return runErr
 }
 
-func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport bool) (report commandRunReport, runErr error) {
-	ctx := getCommandContext(cmd)
+func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport bool) (commandRunReport, error) {
+	return runPullWithRemote(cmd, target, emitJSONReport, nil)
+}
+
+// runPullWithRemote runs one pull. A non-nil sharedRemote is used instead of
+// creating a client from the space's config and is left open for the caller.
+func runPullWithRemote(cmd *cobra.Command, target config.Target, emitJSONReport bool, sharedRemote syncflow.PullRemote) (report commandRunReport, runErr error) {
 	actualOut := ensureSynchronizedCmdOutput(cmd)
 	out := reportWriter(cmd, actualOut)
 	forceFull := flagPullForce
@@ -121,6 +176,13 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 		)
 	}()
 
+	ctx, cancel, err := withCommandTimeout(cmd)
+	if err != nil {
+		return report, err
+	}
+	defer cancel()
+	defer func() { runErr = explainCommandInterruption(ctx, "pull", runErr) }()
+
 	// 1. Initial resolution of key/dir
 	initialCtx, err := resolveInitialPullContext(target)
 	if err != nil {
@@ -129,19 +191,66 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 	if forceFull && strings.TrimSpace(initialCtx.targetPageID) != "" {
 		return report, errors.New("--force is only supported for space targets")
 	}
+	if discardLocal && flagPullOverwriteLocal {
+		return report, errors.New("--discard-local and --overwrite-local are mutually exclusive: --discard-local drops every uncommitted change, --overwrite-local only replaces conflicting files")
+	}
+	if flagPullOverlap < 0 {
+		return report, errors.New("--overlap must be a non-negative duration")
+	}
+	overlapWindow := flagPullOverlap
+	if overlapWindow == 0 {
+		overlapWindow = syncflow.DefaultPullOverlapWindow
+	}
+	if flagPullConcurrency < 1 {
+		return report, errors.New("--concurrency must be at least 1")
+	}
+	if flagPullLimit < 0 {
+		return report, errors.New("--limit must be zero or a positive number of pages")
+	}
+	historyLimit := 0
+	if flagPullHistory {
+		if flagPullHistoryLimit < 1 {
+			return report, errors.New("--history-limit must be a positive number of versions")
+		}
+		historyLimit = flagPullHistoryLimit
+	}
+	if err := validateMaxImpactFlag(); err != nil {
+		return report, err
+	}
+	if flagPullLimit > 0 && strings.TrimSpace(initialCtx.targetPageID) != "" {
+		return report, errors.New("--limit is only supported for space targets")
+	}
+	if flagPullPruneLocal && strings.TrimSpace(initialCtx.targetPageID) != "" {
+		return report, errors.New("--prune-local is only supported for space targets")
+	}
+	if flagPullPruneLocal && flagPullLimit > 0 {
+		return report, errors.New("--prune-local cannot be combined with --limit")
+	}
+	if err := validateRelPathGlobs("--include", flagPullInclude); err != nil {
+		return report, err
+	}
+	if err := validateRelPathGlobs("--exclude", flagPullExclude); err != nil {
+		return report, err
+	}
+	if flagPullPruneLocal && (len(flagPullInclude) > 0 || len(flagPullExclude) > 0) {
+		return report, errors.New("--prune-local cannot be combined with --include or --exclude")
+	}
 
 	// 2. Load config to talk to Confluence
-	envPath := findEnvPath(initialCtx.spaceDir)
-	cfg, err := config.Load(envPath)
-	if err != nil {
-		return report, fmt.Errorf("failed to load config: %w", err)
-	}
+	remote := sharedRemote
+	if remote == nil {
+		envPath := findEnvPath(initialCtx.spaceDir)
+		cfg, err := config.Load(envPath)
+		if err != nil {
+			return report, fmt.Errorf("failed to load config: %w", err)
+		}
 
-	remote, err := newPullRemote(cfg)
-	if err != nil {
-		return report, fmt.Errorf("create confluence client: %w", err)
+		remote, err = newPullRemote(cfg)
+		if err != nil {
+			return report, fmt.Errorf("create confluence client: %w", err)
+		}
+		defer closeRemoteIfPossible(remote)
 	}
-	defer closeRemoteIfPossible(remote)
 
 	// 3. Resolve actual space metadata and final directory
 	space, err := remote.GetSpace(ctx, initialCtx.spaceKey)
@@ -168,6 +277,22 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 	}
 	telemetrySpaceKey = pullCtx.spaceKey
 
+	spaceCfg, err := loadSpaceConfig(pullCtx.spaceDir)
+	if err != nil {
+		return report, err
+	}
+	if spaceCfg.PullOverlap > 0 && !flagWasSet(cmd, "overlap") {
+		overlapWindow = spaceCfg.PullOverlap
+	}
+	filenameMode, err := fs.ParseFilenameMode(spaceCfg.FilenameMode)
+	if err != nil {
+		return report, err
+	}
+	commitTemplate, err := resolvePullCommitTemplate(spaceCfg)
+	if err != nil {
+		return report, err
+	}
+
 	scopeDirExisted := dirExists(pullCtx.spaceDir)
 
 	if err := os.MkdirAll(pullCtx.spaceDir, 0o750); err != nil {
@@ -179,17 +304,42 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 		return report, err
 	}
 
+	assetLayout := state.AssetLayout
+	if flagWasSet(cmd, "flatten-assets") {
+		assetLayout = ""
+		if flagPullFlatten {
+			assetLayout = fs.AssetLayoutFlatten
+		}
+	}
+	if assetLayout != state.AssetLayout {
+		if strings.TrimSpace(pullCtx.targetPageID) != "" {
+			return report, errors.New("changing the asset layout with --flatten-assets requires a space target")
+		}
+		// Every page is re-pulled so all attachments move to the new layout.
+		forceFull = true
+	}
+
 	var progress syncflow.Progress
 	if !flagVerbose && outputSupportsProgress(out) {
 		progress = newConsoleProgress(out, "Syncing from Confluence")
 	}
 
-	impact, err := estimatePullImpactWithSpace(ctx, remote, space, pullCtx.targetPageID, state, syncflow.DefaultPullOverlapWindow, forceFull, progress)
+	impact, err := estimatePullImpactWithSpace(ctx, remote, space, pullCtx.targetPageID, state, overlapWindow, forceFull, progress)
 	if err != nil {
 		return report, err
 	}
-	affectedCount := impact.changedMarkdown + impact.deletedMarkdown
-	if err := requireSafetyConfirmation(cmd.InOrStdin(), out, "pull", affectedCount, impact.deletedMarkdown > 0); err != nil {
+	strayMarkdown := strayLocalMarkdown{}
+	if flagPullPruneLocal {
+		strayMarkdown, err = findStrayLocalMarkdown(pullCtx.spaceDir, state, impact.prefetchedPages, spaceCfg.Ignore)
+		if err != nil {
+			return report, err
+		}
+		printStrayLocalMarkdown(out, pullCtx.spaceDir, strayMarkdown.all())
+	}
+	strayCount := len(strayMarkdown.tracked) + len(strayMarkdown.untracked)
+
+	affectedCount := impact.changedMarkdown + impact.deletedMarkdown + strayCount
+	if err := requireSafetyConfirmation(cmd.InOrStdin(), out, "pull", affectedCount, impact.deletedMarkdown > 0 || strayCount > 0); err != nil {
 		return report, err
 	}
 
@@ -219,7 +369,15 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 		}
 	}
 
+	// Read cms_skip before stashing: a flag added as an uncommitted edit is
+	// only in the working tree.
+	skippedPaths := syncflow.SyncSkippedPaths(pullCtx.spaceDir, state.PagePathIndex)
+
 	pullStartedAt := nowUTC()
+	tagTime, err := resolveSyncTimestamp()
+	if err != nil {
+		return report, err
+	}
 	stashRef := ""
 	var result syncflow.PullResult
 	if scopeDirExisted {
@@ -256,6 +414,10 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 					// pre-pull local version that the stash may have reintroduced.
 					if runErr == nil {
 						fixPulledVersionsAfterStashRestore(repoRoot, pullCtx.spaceDir, result.UpdatedMarkdown, out)
+						// Untracked strays ride along in the stash, so a failed
+						// pull gives them back; they go only once the pull
+						// has been committed.
+						return removeStrayLocalMarkdown(pullCtx.spaceDir, strayMarkdown.untracked)
 					}
 					return nil
 				}
@@ -281,6 +443,12 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 		}()
 	}
 
+	// Tracked strays are removed after the scope is stashed so the pull
+	// commit records their deletion; a failed pull restores them from HEAD.
+	if err := removeStrayLocalMarkdown(pullCtx.spaceDir, strayMarkdown.tracked); err != nil {
+		return report, err
+	}
+
 	globalPageIndex, err := syncflow.BuildGlobalPageIndex(repoRoot)
 	if err != nil {
 		return report, fmt.Errorf("build global page index: %w", err)
@@ -292,11 +460,25 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 		State:             state,
 		GlobalPageIndex:   globalPageIndex,
 		PullStartedAt:     pullStartedAt,
-		OverlapWindow:     syncflow.DefaultPullOverlapWindow,
+		Now:               nowUTC,
+		OverlapWindow:     overlapWindow,
 		TargetPageID:      pullCtx.targetPageID,
 		ForceFull:         forceFull,
 		SkipMissingAssets: flagSkipMissingAssets,
 		PrefetchedPages:   impact.prefetchedPages,
+		MaxPages:          flagPullLimit,
+		FilenameMode:      filenameMode,
+		OrderPrefix:       spaceCfg.OrderPrefix,
+		AssetLayout:       assetLayout,
+		Comments:          flagPullComments,
+		HistoryLimit:      historyLimit,
+		StripTitleHeading: spaceCfg.StripTitleHeading,
+		PageURL:           spaceCfg.PullPageURL,
+		PropertyKeys:      spaceCfg.PullProperties,
+		SkippedPaths:      skippedPaths,
+		Concurrency:       flagPullConcurrency,
+		Include:           flagPullInclude,
+		Exclude:           flagPullExclude,
 		OnDownloadError: func(attachmentID string, pageID string, err error) bool {
 			return askToContinueOnDownloadError(cmd.InOrStdin(), out, attachmentID, pageID, err)
 		},
@@ -304,6 +486,11 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 	})
 
 	if err != nil {
+		if scopeDirExisted && stashRef == "" {
+			// The scope was clean, so everything in it now was written by this
+			// pull; the next run resumes from the pull progress instead.
+			cleanupFailedPullScope(repoRoot, scopePath)
+		}
 		return report, err
 	}
 	telemetryUpdated = len(result.UpdatedMarkdown)
@@ -317,6 +504,7 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 	for _, path := range result.DeletedMarkdown {
 		report.MutatedFiles = append(report.MutatedFiles, reportRelativePath(pullCtx.spaceDir, path))
 	}
+	report.MutatedFiles = append(report.MutatedFiles, strayMarkdown.all()...)
 	report.AttachmentOperations = append(report.AttachmentOperations, reportAttachmentOpsFromPull(result, pullCtx.spaceDir)...)
 	report.FallbackModes = append(report.FallbackModes, fallbackModesFromPullDiagnostics(result.Diagnostics)...)
 
@@ -350,18 +538,29 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 			return nil
 		}
 
-		commitMsg := fmt.Sprintf("Sync from Confluence: [%s] (v%d)", pullCtx.spaceKey, result.MaxVersion)
+		commitMsg := pullCommitMessage(commitTemplate, pullCommitTemplateData{
+			SpaceKey:     pullCtx.spaceKey,
+			Version:      result.MaxVersion,
+			UpdatedPages: len(result.UpdatedMarkdown),
+			DeletedPages: len(result.DeletedMarkdown),
+		})
 		if _, err := runGit(repoRoot, "commit", "-m", commitMsg); err != nil {
 			return err
 		}
+		head, err := runGit(repoRoot, "rev-parse", "HEAD")
+		if err != nil {
+			return err
+		}
+		report.Commit = strings.TrimSpace(head)
 
-		ts := pullStartedAt.UTC().Format("20060102T150405Z")
+		ts := formatSyncTimestamp(tagTime)
 		refKey := fs.SanitizePathSegment(pullCtx.spaceKey)
 		tagName = fmt.Sprintf("confluence-sync/pull/%s/%s", refKey, ts)
 		tagMsg := fmt.Sprintf("Confluence pull sync for %s at %s", pullCtx.spaceKey, ts)
 		if _, err := runGit(repoRoot, "tag", "-a", tagName, "-m", tagMsg); err != nil {
 			return err
 		}
+		report.Tag = tagName
 
 		return nil
 	}
@@ -377,6 +576,7 @@ func runPullWithReport(cmd *cobra.Command, target config.Target, emitJSONReport
 	}
 
 	if !hasChanges {
+		report.NoChanges = true
 		if result.RemotePagesChecked == 0 {
 			_, _ = fmt.Fprintln(out, "pull completed with no remote changes since last sync (no-op)")
 		} else {
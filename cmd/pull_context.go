@@ -74,7 +74,7 @@ func resolveInitialPullContext(target config.Target) (initialPullContext, error)
 
 	if target.Value == "" {
 		// If we are in a tracked directory, use it.
-		if _, err := os.Stat(filepath.Join(cwd, fs.StateFileName)); err == nil {
+		if fs.HasState(cwd) {
 			state, err := fs.LoadState(cwd)
 			if err == nil {
 				if strings.TrimSpace(state.SpaceKey) != "" {
@@ -111,7 +111,7 @@ func resolveInitialPullContext(target config.Target) (initialPullContext, error)
 		}
 
 		// Check if it is a tracked directory
-		if _, err := os.Stat(filepath.Join(spaceDir, fs.StateFileName)); err == nil {
+		if fs.HasState(spaceDir) {
 			state, err := fs.LoadState(spaceDir)
 			if err == nil {
 				if strings.TrimSpace(state.SpaceKey) != "" {
@@ -232,6 +232,7 @@ func estimatePullImpactWithSpace(
 		return pullImpact{
 			changedMarkdown: len(pageByID),
 			deletedMarkdown: len(deletedIDs),
+			prefetchedPages: pages,
 		}, nil
 	}
 
@@ -349,7 +350,7 @@ func findSpaceDirFromFile(filePath, spaceKey string) string {
 		if filepath.Base(dir) == spaceKey {
 			return dir
 		}
-		if _, err := os.Stat(filepath.Join(dir, fs.StateFileName)); err == nil {
+		if fs.HasState(dir) {
 			return dir
 		}
 		parent := filepath.Dir(dir)
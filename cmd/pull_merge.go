@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// flagPullAutoMerge enables content-aware three-way merging of conflicted
+// Markdown files before falling back to the keep-local/website/both prompt.
+var flagPullAutoMerge = true
+
+// autoMergePullConflicts tries a three-way merge for each conflicted Markdown
+// file using the pre-pull baseline (the stash parent), the stashed local
+// version, and the pulled website version. Cleanly merged files are written
+// and marked resolved; the paths that still overlap are returned.
+func autoMergePullConflicts(repoRoot, stashRef string, conflictedPaths []string, out io.Writer) ([]string, error) {
+	if !flagPullAutoMerge {
+		return conflictedPaths, nil
+	}
+
+	remaining := make([]string, 0, len(conflictedPaths))
+	merged := 0
+	for _, repoPath := range conflictedPaths {
+		if !strings.HasSuffix(strings.ToLower(repoPath), ".md") {
+			remaining = append(remaining, repoPath)
+			continue
+		}
+
+		base, baseErr := runGit(repoRoot, "show", fmt.Sprintf("%s^1:%s", stashRef, repoPath))
+		local, localErr := runGit(repoRoot, "show", fmt.Sprintf("%s:%s", stashRef, repoPath))
+		remote, remoteErr := runGit(repoRoot, "show", "HEAD:"+repoPath)
+		if baseErr != nil || localErr != nil || remoteErr != nil {
+			remaining = append(remaining, repoPath)
+			continue
+		}
+
+		content, ok, err := mergeMarkdownThreeWay(base, local, remote)
+		if err != nil {
+			return nil, fmt.Errorf("merge %s: %w", repoPath, err)
+		}
+		if !ok {
+			remaining = append(remaining, repoPath)
+			continue
+		}
+
+		absPath := filepath.Join(repoRoot, filepath.FromSlash(repoPath))
+		if err := os.WriteFile(absPath, []byte(content), 0o600); err != nil {
+			return nil, fmt.Errorf("write merged %s: %w", repoPath, err)
+		}
+		if _, err := runGit(repoRoot, "add", "--", repoPath); err != nil {
+			return nil, err
+		}
+		merged++
+		_, _ = fmt.Fprintf(out, "Merged local and website edits in %q automatically.\n", repoPath)
+	}
+
+	if merged > 0 && len(remaining) > 0 {
+		_, _ = fmt.Fprintf(out, "%d file(s) still have overlapping edits.\n", len(remaining))
+	}
+	return remaining, nil
+}
+
+// mergeMarkdownThreeWay merges frontmatter field by field and the body line
+// by line. It reports ok=false when local and remote edit the same region.
+func mergeMarkdownThreeWay(base, local, remote string) (string, bool, error) {
+	baseDoc, baseErr := fs.ParseMarkdownDocument([]byte(base))
+	localDoc, localErr := fs.ParseMarkdownDocument([]byte(local))
+	remoteDoc, remoteErr := fs.ParseMarkdownDocument([]byte(remote))
+	if baseErr != nil || localErr != nil || remoteErr != nil {
+		return mergeTextThreeWay(base, local, remote)
+	}
+
+	fm, ok := mergeFrontmatterThreeWay(baseDoc.Frontmatter, localDoc.Frontmatter, remoteDoc.Frontmatter)
+	if !ok {
+		return "", false, nil
+	}
+	body, ok, err := mergeTextThreeWay(baseDoc.Body, localDoc.Body, remoteDoc.Body)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	raw, err := fs.FormatMarkdownDocument(fs.MarkdownDocument{Frontmatter: fm, Body: body})
+	if err != nil {
+		return "", false, err
+	}
+	return string(raw), true, nil
+}
+
+// mergeFrontmatterThreeWay always takes sync-managed keys from the website
+// version and merges user-editable keys independently.
+func mergeFrontmatterThreeWay(base, local, remote fs.Frontmatter) (fs.Frontmatter, bool) {
+	merged := remote
+	ok := true
+	pick := func(b, l, r any, assign func(any)) {
+		switch {
+		case reflect.DeepEqual(l, r), reflect.DeepEqual(l, b):
+			assign(r)
+		case reflect.DeepEqual(r, b):
+			assign(l)
+		default:
+			ok = false
+		}
+	}
+
+	pick(base.Title, local.Title, remote.Title, func(v any) { merged.Title = v.(string) })
+	pick(base.ID, local.ID, remote.ID, func(v any) { merged.ID = v.(string) })
+	pick(base.State, local.State, remote.State, func(v any) { merged.State = v.(string) })
+	pick(base.Status, local.Status, remote.Status, func(v any) { merged.Status = v.(string) })
+	pick(base.ParentID, local.ParentID, remote.ParentID, func(v any) { merged.ParentID = v.(string) })
+	pick(base.ParentPath, local.ParentPath, remote.ParentPath, func(v any) { merged.ParentPath = v.(string) })
+	pick(fs.NormalizeLabels(base.Labels), fs.NormalizeLabels(local.Labels), fs.NormalizeLabels(remote.Labels), func(v any) {
+		merged.Labels = slices.Clone(v.([]string))
+	})
+	pick(base.Restrictions, local.Restrictions, remote.Restrictions, func(v any) { merged.Restrictions = v.(*fs.Restrictions) })
+
+	keys := map[string]struct{}{}
+	for _, extra := range []map[string]any{base.Extra, local.Extra, remote.Extra} {
+		for key := range extra {
+			keys[key] = struct{}{}
+		}
+	}
+	merged.Extra = map[string]any{}
+	for key := range keys {
+		b, hasBase := base.Extra[key]
+		l, hasLocal := local.Extra[key]
+		r, hasRemote := remote.Extra[key]
+		pick(extraValue{b, hasBase}, extraValue{l, hasLocal}, extraValue{r, hasRemote}, func(v any) {
+			if value := v.(extraValue); value.present {
+				merged.Extra[key] = value.value
+			}
+		})
+	}
+
+	return merged, ok
+}
+
+type extraValue struct {
+	value   any
+	present bool
+}
+
+// mergeTextThreeWay runs `git merge-file` on temporary copies and reports
+// ok=false when the merge leaves conflicts.
+func mergeTextThreeWay(base, local, remote string) (string, bool, error) {
+	dir, err := os.MkdirTemp("", "conf-merge-*")
+	if err != nil {
+		return "", false, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	paths := make([]string, 0, 3)
+	for _, part := range []struct{ name, content string }{{"local", local}, {"base", base}, {"remote", remote}} {
+		path := filepath.Join(dir, part.name)
+		if err := os.WriteFile(path, []byte(part.content), 0o600); err != nil {
+			return "", false, err
+		}
+		paths = append(paths, path)
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p", "--quiet", paths[0], paths[1], paths[2]) //nolint:gosec // paths are temp files we created
+	merged, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("git merge-file: %w", err)
+	}
+	return string(merged), true, nil
+}
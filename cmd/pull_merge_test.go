@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyAndDropStash_AutoMergesNonOverlappingEdits(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space dir: %v", err)
+	}
+
+	repoPath := filepath.ToSlash(filepath.Join("Engineering (ENG)", "Page.md"))
+	mainFile := filepath.Join(spaceDir, "Page.md")
+	writeFile := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(mainFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("write page: %v", err)
+		}
+	}
+
+	writeFile("---\ntitle: Page\nid: \"1\"\nversion: 1\n---\nIntro\n\nMiddle\n\nOutro\n")
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "baseline")
+
+	// Local edit touches the status key (adjacent to version) and the last paragraph.
+	writeFile("---\ntitle: Page\nid: \"1\"\nversion: 1\nstatus: Ready to review\n---\nIntro\n\nMiddle\n\nOutro with local edit\n")
+	runGitForTest(t, repo, "stash", "push", "--include-untracked", "-m", "local", "--", repoPath)
+	stashRef := strings.TrimSpace(runGitForTest(t, repo, "stash", "list", "-1", "--format=%gd"))
+
+	// Website edit bumps version and rewrites the first paragraph.
+	writeFile("---\ntitle: Page\nid: \"1\"\nversion: 2\n---\nIntro from website\n\nMiddle\n\nOutro\n")
+	runGitForTest(t, repo, "add", repoPath)
+	runGitForTest(t, repo, "commit", "-m", "website update")
+
+	setAutomationFlags(t, false, true)
+	out := &bytes.Buffer{}
+	if err := applyAndDropStash(repo, stashRef, filepath.ToSlash(filepath.Base(spaceDir)), strings.NewReader(""), out); err != nil {
+		t.Fatalf("applyAndDropStash() error: %v\nOutput:\n%s", err, out.String())
+	}
+
+	raw, err := os.ReadFile(mainFile) //nolint:gosec // test path is created under t.TempDir
+	if err != nil {
+		t.Fatalf("read page: %v", err)
+	}
+	got := string(raw)
+	for _, want := range []string{"version: 2", "status: Ready to review", "Intro from website", "Outro with local edit"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("merged page missing %q:\n%s", want, got)
+		}
+	}
+	if !strings.Contains(out.String(), "merged without conflicts") {
+		t.Fatalf("expected auto-merge summary, got:\n%s", out.String())
+	}
+	if strings.Contains(got, "<<<<<<<") {
+		t.Fatalf("expected no conflict markers, got:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Page (My Local Changes).md")); !os.IsNotExist(err) {
+		t.Fatalf("expected no backup copy for a clean merge, stat err=%v", err)
+	}
+	if stashList := strings.TrimSpace(runGitForTest(t, repo, "stash", "list")); stashList != "" {
+		t.Fatalf("expected stash to be dropped, got %q", stashList)
+	}
+}
+
+func TestMergeMarkdownThreeWay_OverlappingBodyEditsConflict(t *testing.T) {
+	base := "---\ntitle: Page\n---\nShared line\n"
+	local := "---\ntitle: Page\n---\nLocal line\n"
+	remote := "---\ntitle: Page\n---\nWebsite line\n"
+
+	if _, ok, err := mergeMarkdownThreeWay(base, local, remote); err != nil || ok {
+		t.Fatalf("mergeMarkdownThreeWay() ok=%v err=%v, want conflict", ok, err)
+	}
+}
+
+func TestMergeFrontmatterThreeWay_ConflictingStatusEdits(t *testing.T) {
+	base := "---\ntitle: Page\nstatus: Draft\n---\nBody\n"
+	local := "---\ntitle: Page\nstatus: Ready\n---\nBody\n"
+	remote := "---\ntitle: Page\nstatus: Approved\n---\nBody\n"
+
+	if _, ok, err := mergeMarkdownThreeWay(base, local, remote); err != nil || ok {
+		t.Fatalf("mergeMarkdownThreeWay() ok=%v err=%v, want frontmatter conflict", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// flagPullPruneLocal deletes local Markdown files that have no page in the
+// space's page index during a full-space pull.
+var flagPullPruneLocal bool
+
+// strayLocalMarkdown lists Markdown files under a space directory that are not
+// in the page index, split by whether git already tracks them.
+type strayLocalMarkdown struct {
+	tracked   []string
+	untracked []string
+}
+
+func (s strayLocalMarkdown) all() []string {
+	paths := append(append([]string{}, s.tracked...), s.untracked...)
+	sort.Strings(paths)
+	return paths
+}
+
+// findStrayLocalMarkdown returns space-relative Markdown paths that are not in
+// state.PagePathIndex and whose frontmatter id is not a listed remote page.
+// Files ignored by git or matching one of the space config ignore patterns,
+// page sidecars and anything under assets/ or archived/ are skipped.
+func findStrayLocalMarkdown(spaceDir string, state fs.SpaceState, remotePages []confluence.Page, ignore []string) (strayLocalMarkdown, error) {
+	indexed := map[string]struct{}{}
+	for relPath := range state.PagePathIndex {
+		indexed[normalizeRepoRelPath(relPath)] = struct{}{}
+	}
+	remotePageIDs := make(map[string]struct{}, len(remotePages))
+	for _, page := range remotePages {
+		remotePageIDs[strings.TrimSpace(page.ID)] = struct{}{}
+	}
+
+	stray := strayLocalMarkdown{}
+	for _, mode := range []string{"--cached", "--others"} {
+		raw, err := runGit(spaceDir, "ls-files", mode, "--exclude-standard", "-z", "--", ".")
+		if err != nil {
+			return strayLocalMarkdown{}, fmt.Errorf("list local markdown: %w", err)
+		}
+		for _, relPath := range strings.Split(raw, "\x00") {
+			relPath = normalizeRepoRelPath(relPath)
+			if relPath == "" || !strings.EqualFold(filepath.Ext(relPath), ".md") {
+				continue
+			}
+			if relPath == "assets" || strings.HasPrefix(relPath, "assets/") || strings.HasPrefix(relPath, archivedDirName+"/") || fs.IsPageSidecar(relPath) || matchesSpaceIgnore(ignore, relPath) {
+				continue
+			}
+			if _, ok := indexed[relPath]; ok {
+				continue
+			}
+			fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath)))
+			if err != nil && os.IsNotExist(err) {
+				continue
+			}
+			if id := strings.TrimSpace(fm.ID); id != "" {
+				if _, ok := remotePageIDs[id]; ok {
+					continue
+				}
+			}
+			if mode == "--cached" {
+				stray.tracked = append(stray.tracked, relPath)
+			} else {
+				stray.untracked = append(stray.untracked, relPath)
+			}
+		}
+	}
+	sort.Strings(stray.tracked)
+	sort.Strings(stray.untracked)
+	return stray, nil
+}
+
+func printStrayLocalMarkdown(out io.Writer, spaceDir string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(out, "--prune-local will delete %d local markdown file(s) with no page in %s:\n", len(paths), spaceDir)
+	for _, relPath := range paths {
+		_, _ = fmt.Fprintf(out, "  - %s\n", relPath)
+	}
+}
+
+// removeStrayLocalMarkdown deletes the given space-relative paths and any
+// directories left empty by the removal.
+func removeStrayLocalMarkdown(spaceDir string, paths []string) error {
+	for _, relPath := range paths {
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.Remove(absPath); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("delete stray markdown %s: %w", relPath, err)
+		}
+		_ = removeEmptyAssetParents(filepath.Dir(absPath), spaceDir)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPull_PruneLocalDeletesStrayMarkdown(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 2},
+		Body:        "same body\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Notes", "Committed Stray.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Committed Stray"},
+		Body:        "no remote page\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:              "ENG",
+		LastPullHighWatermark: "2026-02-01T12:00:00Z",
+		PagePathIndex:         map[string]string{"Root.md": "1"},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\nscratch.md\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Untracked Stray.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Untracked Stray"},
+		Body:        "local only\n",
+	})
+	if err := os.WriteFile(filepath.Join(spaceDir, "scratch.md"), []byte("ignored\n"), 0o600); err != nil {
+		t.Fatalf("write ignored file: %v", err)
+	}
+
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC), BodyADF: rawJSON(t, simpleADF("same body"))},
+		},
+		attachments: map[string][]byte{},
+	}
+
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	oldPrune := flagPullPruneLocal
+	flagPullPruneLocal = true
+	t.Cleanup(func() { flagPullPruneLocal = oldPrune })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+	setAutomationFlags(t, true, true)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("runPull() error: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(out.String(), "Notes/Committed Stray.md") || !strings.Contains(out.String(), "Untracked Stray.md") {
+		t.Fatalf("output does not list stray files:\n%s", out.String())
+	}
+	for _, relPath := range []string{"Notes/Committed Stray.md", "Untracked Stray.md"} {
+		if _, err := os.Stat(filepath.Join(spaceDir, filepath.FromSlash(relPath))); !os.IsNotExist(err) {
+			t.Fatalf("%s still exists after --prune-local (err=%v)", relPath, err)
+		}
+	}
+	for _, relPath := range []string{"Root.md", "scratch.md"} {
+		if _, err := os.Stat(filepath.Join(spaceDir, relPath)); err != nil {
+			t.Fatalf("%s should be kept: %v", relPath, err)
+		}
+	}
+
+	tracked := runGitForTest(t, repo, "ls-files", "--", "Engineering (ENG)")
+	if strings.Contains(tracked, "Committed Stray.md") {
+		t.Fatalf("pull commit did not record stray deletion; tracked files:\n%s", tracked)
+	}
+}
+
+func TestRunPull_PruneLocalKeepsSpaceIgnoredMarkdown(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 2},
+		Body:        "same body\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "drafts", "Idea.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Idea"},
+		Body:        "not ready\n",
+	})
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("ignore:\n  - drafts/**\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:              "ENG",
+		LastPullHighWatermark: "2026-02-01T12:00:00Z",
+		PagePathIndex:         map[string]string{"Root.md": "1"},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, simpleADF("same body"))},
+		},
+		attachments: map[string][]byte{},
+	}
+
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	oldPrune := flagPullPruneLocal
+	flagPullPruneLocal = true
+	t.Cleanup(func() { flagPullPruneLocal = oldPrune })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+	setAutomationFlags(t, true, true)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("runPull() error: %v\n%s", err, out.String())
+	}
+	if strings.Contains(out.String(), "drafts/Idea.md") {
+		t.Fatalf("ignored draft was listed as stray:\n%s", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "drafts", "Idea.md")); err != nil {
+		t.Fatalf("ignored draft should survive --prune-local: %v", err)
+	}
+}
+
+func TestRunPull_PruneLocalRejectedForFileTarget(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "body\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"Root.md": "1"}}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	oldPrune := flagPullPruneLocal
+	flagPullPruneLocal = true
+	t.Cleanup(func() { flagPullPruneLocal = oldPrune })
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPull(cmd, config.Target{Mode: config.TargetModeFile, Value: filepath.Join(spaceDir, "Root.md")})
+	if err == nil || !strings.Contains(err.Error(), "--prune-local is only supported for space targets") {
+		t.Fatalf("runPull() error = %v, want space-target rejection", err)
+	}
+}
+
+func TestRunPull_PruneLocalKeepsStrayMarkdownWhenPullFails(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "old body\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Committed Stray.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Committed Stray"},
+		Body:        "no remote page\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:              "ENG",
+		LastPullHighWatermark: "2026-02-01T12:00:00Z",
+		PagePathIndex:         map[string]string{"Root.md": "1"},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Untracked Stray.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Untracked Stray"},
+		Body:        "local only\n",
+	})
+
+	modifiedAt := time.Date(2026, time.February, 2, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space:       confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages:       []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modifiedAt}},
+		getPageErr:  errors.New("boom"),
+		attachments: map[string][]byte{},
+	}
+
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	oldPrune := flagPullPruneLocal
+	flagPullPruneLocal = true
+	t.Cleanup(func() { flagPullPruneLocal = oldPrune })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+	setAutomationFlags(t, true, true)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err == nil {
+		t.Fatalf("runPull() expected error\n%s", out.String())
+	}
+
+	for _, relPath := range []string{"Committed Stray.md", "Untracked Stray.md"} {
+		if _, err := os.Stat(filepath.Join(spaceDir, relPath)); err != nil {
+			t.Fatalf("%s should survive a failed pull: %v", relPath, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+// pullSpaceOutcome is one line of the combined --spaces summary.
+type pullSpaceOutcome struct {
+	spaceKey string
+	err      error
+	skipped  bool
+}
+
+// runPullSpaces pulls each requested space in turn with one shared Confluence
+// client. Every space keeps its own state file, commit and tag exactly as a
+// single-space pull would. Without --continue-on-error the first failure
+// stops the run.
+func runPullSpaces(cmd *cobra.Command, rawSpaces []string) error {
+	out := ensureSynchronizedCmdOutput(cmd)
+	if commandRequestsJSONReport(cmd) {
+		return errors.New("--report-json cannot be combined with --spaces")
+	}
+
+	repoRoot, err := gitRepoRoot()
+	if err != nil {
+		return err
+	}
+	spaceKeys, err := expandPullSpaceKeys(repoRoot, rawSpaces)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(findEnvPath(cwd))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	remote, err := newPullRemote(cfg)
+	if err != nil {
+		return fmt.Errorf("create confluence client: %w", err)
+	}
+	defer closeRemoteIfPossible(remote)
+
+	outcomes := make([]pullSpaceOutcome, 0, len(spaceKeys))
+	var failures []error
+	for i, spaceKey := range spaceKeys {
+		if len(failures) > 0 && !flagPullContinueOnError {
+			outcomes = append(outcomes, pullSpaceOutcome{spaceKey: spaceKey, skipped: true})
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "==> pull %s (%d/%d)\n", spaceKey, i+1, len(spaceKeys))
+		_, runErr := runPullWithRemote(cmd, config.Target{Mode: config.TargetModeSpace, Value: spaceKey}, false, remote)
+		if runErr != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", spaceKey, runErr))
+		}
+		outcomes = append(outcomes, pullSpaceOutcome{spaceKey: spaceKey, err: runErr})
+	}
+
+	printPullSpacesSummary(out, outcomes)
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("pull failed for %d of %d spaces: %w", len(failures), len(spaceKeys), errors.Join(failures...))
+}
+
+// expandPullSpaceKeys turns the --spaces values into an ordered, de-duplicated
+// list of space keys. Glob patterns are matched against the spaces already
+// tracked in the repository; plain keys are used as given so new spaces can
+// be pulled for the first time.
+func expandPullSpaceKeys(repoRoot string, rawSpaces []string) ([]string, error) {
+	var trackedKeys []string
+	seen := map[string]struct{}{}
+	keys := make([]string, 0, len(rawSpaces))
+	add := func(key string) {
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	for _, raw := range rawSpaces {
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+		if !strings.ContainsAny(value, "*?[") {
+			add(value)
+			continue
+		}
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("invalid --spaces pattern %q: %w", value, err)
+		}
+		if trackedKeys == nil {
+			states, err := fs.FindAllStateFiles(repoRoot)
+			if err != nil {
+				return nil, fmt.Errorf("discover tracked spaces: %w", err)
+			}
+			trackedKeys = []string{}
+			for _, state := range states {
+				if key := strings.TrimSpace(state.SpaceKey); key != "" {
+					trackedKeys = append(trackedKeys, key)
+				}
+			}
+			sort.Strings(trackedKeys)
+		}
+		matched := false
+		for _, key := range trackedKeys {
+			if ok, _ := path.Match(value, key); ok {
+				add(key)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("--spaces pattern %q matches no tracked space", value)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("--spaces requires at least one space key")
+	}
+	return keys, nil
+}
+
+func printPullSpacesSummary(out io.Writer, outcomes []pullSpaceOutcome) {
+	pulled, failed, skipped := 0, 0, 0
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.skipped:
+			skipped++
+		case outcome.err != nil:
+			failed++
+		default:
+			pulled++
+		}
+	}
+	_, _ = fmt.Fprintf(out, "\nPull summary: %d pulled, %d failed, %d skipped\n", pulled, failed, skipped)
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.skipped:
+			_, _ = fmt.Fprintf(out, "  - %s: skipped (rerun with --continue-on-error to pull it after a failure)\n", outcome.spaceKey)
+		case outcome.err != nil:
+			_, _ = fmt.Fprintf(out, "  x %s: %v\n", outcome.spaceKey, outcome.err)
+		default:
+			_, _ = fmt.Fprintf(out, "  ✓ %s\n", outcome.spaceKey)
+		}
+	}
+}
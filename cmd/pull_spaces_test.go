@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// multiSpacePullRemote serves a different fake per space key, switching on
+// each GetSpace call the way a real client would answer per space.
+type multiSpacePullRemote struct {
+	syncflow.PullRemote
+	spaces  map[string]*cmdFakePullRemote
+	failKey string
+}
+
+func (m *multiSpacePullRemote) GetSpace(ctx context.Context, key string) (confluence.Space, error) {
+	if key == m.failKey {
+		return confluence.Space{}, &confluence.APIError{StatusCode: 403, Method: "GET", URL: "/spaces", Message: "forbidden"}
+	}
+	fake, ok := m.spaces[key]
+	if !ok {
+		return confluence.Space{}, confluence.ErrNotFound
+	}
+	m.PullRemote = fake
+	return fake.GetSpace(ctx, key)
+}
+
+func singlePageSpaceRemote(t *testing.T, key, name, pageID string) *cmdFakePullRemote {
+	t.Helper()
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	page := confluence.Page{ID: pageID, SpaceID: "space-" + key, Title: "Home", Version: 1, LastModified: modified}
+	full := page
+	full.BodyADF = rawJSON(t, simpleADF("hello "+key))
+	return &cmdFakePullRemote{
+		space:       confluence.Space{ID: "space-" + key, Key: key, Name: name},
+		pages:       []confluence.Page{page},
+		pagesByID:   map[string]confluence.Page{pageID: full},
+		attachments: map[string][]byte{},
+	}
+}
+
+func setupMultiSpacePull(t *testing.T, failKey string) (string, *int) {
+	t.Helper()
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	remote := &multiSpacePullRemote{
+		spaces: map[string]*cmdFakePullRemote{
+			"ENG": singlePageSpaceRemote(t, "ENG", "Engineering", "1"),
+			"OPS": singlePageSpaceRemote(t, "OPS", "Operations", "2"),
+		},
+		failKey: failKey,
+	}
+	factoryCalls := 0
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) {
+		factoryCalls++
+		return remote, nil
+	}
+	oldNow := nowUTC
+	nowUTC = func() time.Time { return time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC) }
+	oldContinue := flagPullContinueOnError
+	t.Cleanup(func() {
+		newPullRemote = oldFactory
+		nowUTC = oldNow
+		flagPullContinueOnError = oldContinue
+	})
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+	return repo, &factoryCalls
+}
+
+func TestRunPullSpaces_ContinueOnErrorPullsRemainingSpaces(t *testing.T) {
+	runParallelCommandTest(t)
+	repo, factoryCalls := setupMultiSpacePull(t, "HR")
+	flagPullContinueOnError = true
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	err := runPullSpaces(cmd, []string{"ENG", "HR", "OPS"})
+	if err == nil {
+		t.Fatalf("expected combined error for HR, got nil\n%s", out.String())
+	}
+	var apiErr *confluence.APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("runPullSpaces() error = %v", err)
+	}
+	if *factoryCalls != 1 {
+		t.Fatalf("expected one shared client, created %d", *factoryCalls)
+	}
+	for _, dir := range []string{"Engineering (ENG)", "Operations (OPS)"} {
+		if _, err := os.Stat(filepath.Join(repo, dir, "Home.md")); err != nil {
+			t.Fatalf("expected %s to be pulled: %v\n%s", dir, err, out.String())
+		}
+	}
+	for _, tag := range []string{"confluence-sync/pull/ENG/*", "confluence-sync/pull/OPS/*"} {
+		if strings.TrimSpace(runGitForTest(t, repo, "tag", "--list", tag)) == "" {
+			t.Fatalf("expected tag %s", tag)
+		}
+	}
+	if !strings.Contains(out.String(), "Pull summary: 2 pulled, 1 failed, 0 skipped") {
+		t.Fatalf("missing summary:\n%s", out.String())
+	}
+}
+
+func TestRunPullSpaces_StopsAtFirstFailureByDefault(t *testing.T) {
+	runParallelCommandTest(t)
+	repo, _ := setupMultiSpacePull(t, "ENG")
+	flagPullContinueOnError = false
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runPullSpaces(cmd, []string{"ENG", "OPS"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := os.Stat(filepath.Join(repo, "Operations (OPS)")); !os.IsNotExist(err) {
+		t.Fatalf("OPS should not be pulled after ENG failed, stat err = %v", err)
+	}
+	if !strings.Contains(out.String(), "0 pulled, 1 failed, 1 skipped") {
+		t.Fatalf("missing summary:\n%s", out.String())
+	}
+}
+
+func TestExpandPullSpaceKeys_MatchesTrackedSpaces(t *testing.T) {
+	repo := t.TempDir()
+	for dir, key := range map[string]string{"Engineering (ENG)": "ENG", "Eng Two (ENG2)": "ENG2", "Operations (OPS)": "OPS"} {
+		state := fs.NewSpaceState()
+		state.SpaceKey = key
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := fs.SaveState(filepath.Join(repo, dir), state); err != nil {
+			t.Fatalf("save state: %v", err)
+		}
+	}
+
+	got, err := expandPullSpaceKeys(repo, []string{"HR", "ENG*", "ENG", " "})
+	if err != nil {
+		t.Fatalf("expandPullSpaceKeys() error: %v", err)
+	}
+	if want := []string{"HR", "ENG", "ENG2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+	if _, err := expandPullSpaceKeys(repo, []string{"X*"}); err == nil {
+		t.Fatal("expected error for a pattern matching no tracked space")
+	}
+}
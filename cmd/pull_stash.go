@@ -145,6 +145,28 @@ func handlePullConflict(repoRoot, stashRef, scopePath string, in io.Reader, out
 		return fmt.Errorf("the workspace is in a syncing state; finish reconciling pending files before running pull again")
 	}
 
+	if flagPullOverwriteLocal {
+		for _, repoPath := range conflictedPaths {
+			_, _ = fmt.Fprintf(out, "Overwriting local edits in %q with the website version (--overwrite-local).\n", repoPath)
+		}
+		return applyPullConflictChoice("remote", repoRoot, stashRef, scopePath, conflictedPaths, out)
+	}
+
+	conflictedPaths, err = autoMergePullConflicts(repoRoot, stashRef, conflictedPaths, out)
+	if err != nil {
+		return err
+	}
+	if len(conflictedPaths) == 0 {
+		if _, err := runGit(repoRoot, "reset", "--", scopePath); err != nil {
+			return err
+		}
+		if _, err := runGit(repoRoot, "stash", "drop", stashRef); err != nil {
+			return fmt.Errorf("merged local changes, but cleanup could not finish automatically")
+		}
+		_, _ = fmt.Fprintln(out, successStyle.Render("Local and website edits merged without conflicts."))
+		return nil
+	}
+
 	if flagNonInteractive || flagYes {
 		switch strings.TrimSpace(flagMergeResolution) {
 		case "keep-local":
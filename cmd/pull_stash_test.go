@@ -135,6 +135,71 @@ func TestApplyAndDropStash_NonInteractiveKeepBothUsesMergeResolution(t *testing.
 	}
 }
 
+func TestApplyAndDropStash_OverwriteLocalTakesWebsiteOnlyForConflicts(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space dir: %v", err)
+	}
+
+	pageFile := filepath.Join(spaceDir, "Page.md")
+	otherFile := filepath.Join(spaceDir, "Other.md")
+	for _, path := range []string{pageFile, otherFile} {
+		if err := os.WriteFile(path, []byte("base\n"), 0o600); err != nil {
+			t.Fatalf("write base file: %v", err)
+		}
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "baseline")
+
+	for _, path := range []string{pageFile, otherFile} {
+		if err := os.WriteFile(path, []byte("local edit\n"), 0o600); err != nil {
+			t.Fatalf("write local edit: %v", err)
+		}
+	}
+	runGitForTest(t, repo, "stash", "push", "--include-untracked", "-m", "local", "--", "Engineering (ENG)")
+	stashRef := strings.TrimSpace(runGitForTest(t, repo, "stash", "list", "-1", "--format=%gd"))
+	if stashRef == "" {
+		t.Fatal("expected stash ref")
+	}
+
+	if err := os.WriteFile(pageFile, []byte("website edit\n"), 0o600); err != nil {
+		t.Fatalf("write website edit: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "website update")
+
+	oldOverwriteLocal := flagPullOverwriteLocal
+	flagPullOverwriteLocal = true
+	t.Cleanup(func() { flagPullOverwriteLocal = oldOverwriteLocal })
+	setAutomationFlags(t, false, true)
+
+	out := &bytes.Buffer{}
+	if err := applyAndDropStash(repo, stashRef, filepath.ToSlash(filepath.Base(spaceDir)), strings.NewReader(""), out); err != nil {
+		t.Fatalf("applyAndDropStash() error: %v", err)
+	}
+
+	if raw, _ := os.ReadFile(pageFile); string(raw) != "website edit\n" { //nolint:gosec // test path is created under t.TempDir
+		t.Fatalf("conflicting file = %q, want website version", raw)
+	}
+	if raw, _ := os.ReadFile(otherFile); string(raw) != "local edit\n" { //nolint:gosec // test path is created under t.TempDir
+		t.Fatalf("non-conflicting file = %q, want local edit kept", raw)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Page (My Local Changes).md")); !os.IsNotExist(err) {
+		t.Fatalf("expected no backup copy with --overwrite-local, stat err = %v", err)
+	}
+	if stashList := strings.TrimSpace(runGitForTest(t, repo, "stash", "list")); stashList != "" {
+		t.Fatalf("expected stash to be dropped, got %q", stashList)
+	}
+	if !strings.Contains(out.String(), "--overwrite-local") {
+		t.Fatalf("expected output to name the overwritten file, got:\n%s", out.String())
+	}
+}
+
 func TestRunPull_DiscardLocalFailureRestoresLocalChanges(t *testing.T) {
 	runParallelCommandTest(t)
 
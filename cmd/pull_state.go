@@ -101,7 +101,11 @@ func rebuildStateFromConfluenceAndLocal(
 		pagePathIndex[relPath] = pageID
 	}
 
-	folderPathIndex, diagnostics, err := syncflow.ResolveFolderPathIndex(ctx, remote, pages)
+	pathLayout, err := loadSpacePagePathLayout(spaceDir)
+	if err != nil {
+		return fs.SpaceState{}, nil, err
+	}
+	folderPathIndex, diagnostics, err := syncflow.ResolveFolderPathIndex(ctx, remote, pages, pathLayout)
 	if err != nil {
 		return fs.SpaceState{}, nil, fmt.Errorf("rebuild folder path index: %w", err)
 	}
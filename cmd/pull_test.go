@@ -118,6 +118,87 @@ func TestRunPull_RestoresScopedStashAndCreatesTag(t *testing.T) {
 	}
 }
 
+func TestRunPull_FailureRestoresCleanScopeAndNextPullResumes(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "old body\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:              "ENG",
+		LastPullHighWatermark: "2026-02-01T00:00:00Z",
+		PagePathIndex:         map[string]string{"root.md": "1"},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	mediaADF := map[string]any{
+		"version": 1,
+		"type":    "doc",
+		"content": []any{map[string]any{
+			"type": "mediaSingle",
+			"content": []any{map[string]any{
+				"type":  "media",
+				"attrs": map[string]any{"type": "file", "id": "att-1", "attachmentId": "att-1", "pageId": "1", "fileName": "diagram.png"},
+			}},
+		}},
+	}
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, mediaADF)},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	previousNonInteractive := flagNonInteractive
+	flagNonInteractive = true
+	t.Cleanup(func() { flagNonInteractive = previousNonInteractive })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err == nil {
+		t.Fatal("runPull() expected the missing attachment to fail the pull")
+	}
+	if status := strings.TrimSpace(runGitForTest(t, repo, "status", "--porcelain")); status != "" {
+		t.Fatalf("failed pull should restore the clean scope, git status:\n%s", status)
+	}
+	if _, err := os.Stat(fs.PullProgressDir(spaceDir)); err != nil {
+		t.Fatalf("failed pull should keep its progress: %v", err)
+	}
+
+	fake.attachments["att-1"] = []byte("png")
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("resumed runPull() error: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "[PULL_RESUMED]") {
+		t.Fatalf("output = %q, want PULL_RESUMED", out.String())
+	}
+	if _, err := os.Stat(fs.PullProgressDir(spaceDir)); !os.IsNotExist(err) {
+		t.Fatalf("completed pull should remove its progress, stat err = %v", err)
+	}
+}
+
 func TestRunPull_FailureCleanupPreservesStateFile(t *testing.T) {
 	runParallelCommandTest(t)
 
@@ -763,3 +844,92 @@ func TestRunPull_IncrementalUpdateReconcilesRemoteVersionWithoutForce(t *testing
 		t.Fatalf("unexpected false no-op message:\n%s", out.String())
 	}
 }
+
+func TestRunPull_OverlapFlagWidensIncrementalChangeWindow(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 2},
+		Body:        "same body\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:              "ENG",
+		LastPullHighWatermark: "2026-02-01T11:00:00Z",
+		PagePathIndex:         map[string]string{"Root.md": "1"},
+		AttachmentIndex:       map[string]string{},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	sinceValues := []time.Time{}
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)},
+		},
+		listChanges: func(opts confluence.ChangeListOptions) (confluence.ChangeListResult, error) {
+			sinceValues = append(sinceValues, opts.Since)
+			return confluence.ChangeListResult{}, nil
+		},
+		attachments: map[string][]byte{},
+	}
+
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	oldOverlap := flagPullOverlap
+	flagPullOverlap = 30 * time.Minute
+	t.Cleanup(func() { flagPullOverlap = oldOverlap })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("runPull() error: %v", err)
+	}
+
+	if len(sinceValues) == 0 {
+		t.Fatal("expected incremental change listing")
+	}
+	want := time.Date(2026, time.February, 1, 10, 30, 0, 0, time.UTC)
+	for _, since := range sinceValues {
+		if !since.Equal(want) {
+			t.Fatalf("change listing since = %s, want %s", since, want)
+		}
+	}
+}
+
+func TestRunPull_RejectsNegativeOverlap(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	oldOverlap := flagPullOverlap
+	flagPullOverlap = -time.Minute
+	t.Cleanup(func() { flagPullOverlap = oldOverlap })
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"})
+	if err == nil || !strings.Contains(err.Error(), "--overlap must be a non-negative duration") {
+		t.Fatalf("runPull() error = %v, want negative overlap rejection", err)
+	}
+}
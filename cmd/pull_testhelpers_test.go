@@ -45,19 +45,25 @@ func buildBulkPullRemote(t *testing.T, pageCount int) *cmdFakePullRemote {
 }
 
 type cmdFakePullRemote struct {
-	space             confluence.Space
-	pages             []confluence.Page
-	folderByID        map[string]confluence.Folder
-	folderErr         error
-	getPageErr        error
-	getPageFunc       func(pageID string) (confluence.Page, error)
-	changes           []confluence.Change
-	listChanges       func(opts confluence.ChangeListOptions) (confluence.ChangeListResult, error)
-	pagesByID         map[string]confluence.Page
-	attachments       map[string][]byte
-	attachmentsByPage map[string][]confluence.Attachment
-	contentStatusByID map[string]string
-	labelsByPage      map[string][]string
+	space              confluence.Space
+	pages              []confluence.Page
+	folderByID         map[string]confluence.Folder
+	folderErr          error
+	getPageErr         error
+	getPageFunc        func(pageID string) (confluence.Page, error)
+	changes            []confluence.Change
+	listChanges        func(opts confluence.ChangeListOptions) (confluence.ChangeListResult, error)
+	pagesByID          map[string]confluence.Page
+	attachments        map[string][]byte
+	attachmentsByPage  map[string][]confluence.Attachment
+	contentStatusByID  map[string]string
+	labelsByPage       map[string][]string
+	restrictionsByPage map[string]confluence.PageRestrictions
+	propertiesByPage   map[string][]confluence.ContentProperty
+}
+
+func (f *cmdFakePullRemote) GetContentProperties(_ context.Context, pageID string) ([]confluence.ContentProperty, error) {
+	return f.propertiesByPage[pageID], nil
 }
 
 func (f *cmdFakePullRemote) GetUser(_ context.Context, accountID string) (confluence.User, error) {
@@ -118,6 +124,13 @@ func (f *cmdFakePullRemote) GetLabels(_ context.Context, pageID string) ([]strin
 	return append([]string(nil), f.labelsByPage[pageID]...), nil
 }
 
+func (f *cmdFakePullRemote) GetRestrictions(_ context.Context, pageID string) (confluence.PageRestrictions, error) {
+	if f.restrictionsByPage == nil {
+		return confluence.PageRestrictions{}, nil
+	}
+	return f.restrictionsByPage[pageID], nil
+}
+
 func (f *cmdFakePullRemote) ListAttachments(_ context.Context, pageID string) ([]confluence.Attachment, error) {
 	if f.attachmentsByPage == nil {
 		return nil, nil
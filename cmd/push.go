@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"path/filepath"
 	"strings"
@@ -36,6 +37,18 @@ var flagPushKeepOrphanAssets bool
 var flagArchiveTaskTimeout = confluence.DefaultArchiveTaskTimeout
 var flagArchiveTaskPollInterval = confluence.DefaultArchiveTaskPollInterval
 var flagMergeResolution string
+var flagPushSkipValidate bool
+var (
+	flagPushOnly    []string
+	flagPushInclude []string
+	flagPushExclude []string
+)
+var flagPushMaxAttachmentBytes = syncflow.DefaultMaxAttachmentBytes
+var flagPushParent string
+var flagPushOnTitleConflict = string(syncflow.PushTitleConflictFail)
+var flagPushContinueOnError bool
+var flagPushNoConsistencyWait bool
+var flagPushParentByTitle bool
 
 func newPushCmd() *cobra.Command {
 	var onConflict string
@@ -51,7 +64,9 @@ If omitted, the space is inferred from the current directory name.
 For space-wide pushes, the conflict policy defaults to "pull-merge" if not specified.
 For single-file pushes, a policy must be specified via --on-conflict or chosen via prompt.
 
-push always runs validate before any remote write.
+push always runs validate before any remote write. The --skip-validate
+opt-out is UNSAFE and only intended for pipelines that already validated in a
+prior stage; it requires --non-interactive and --yes.
 It uses an isolated worktree and a temporary branch to ensure safety.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,14 +78,35 @@ It uses an isolated worktree and a temporary branch to ensure safety.`,
 		},
 	}
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate the push without modifying Confluence or local Git state")
+	cmd.Flags().StringVar(&flagPushOutput, "output", pushOutputText, "Dry-run output format: text|json (json prints the change plan to stdout)")
 	cmd.Flags().BoolVar(&flagPushPreflight, "preflight", false, "Show a concise push plan (changes and validation) without remote writes")
 	cmd.Flags().BoolVar(&flagPushKeepOrphanAssets, "keep-orphan-assets", false, "Keep unreferenced attachments instead of deleting them during push")
 	cmd.Flags().DurationVar(&flagArchiveTaskTimeout, "archive-task-timeout", confluence.DefaultArchiveTaskTimeout, "Max time to wait for Confluence archive long-task completion")
 	cmd.Flags().DurationVar(&flagArchiveTaskPollInterval, "archive-task-poll-interval", confluence.DefaultArchiveTaskPollInterval, "Polling interval while waiting for archive long-task completion")
 	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve safety confirmations")
 	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when a decision is required")
+	cmd.Flags().IntVar(&flagMaxImpact, "max-impact", 0, "Proceed without confirmation when fewer than N files are affected; larger runs need --yes (deletes always do; 0 = default of 11)")
 	cmd.Flags().StringVar(&onConflict, "on-conflict", "", "Non-interactive conflict policy: pull-merge|force|cancel")
 	cmd.Flags().StringVar(&flagMergeResolution, "merge-resolution", "", "Non-interactive merge resolution for pull-merge conflicts: fail|keep-local|keep-remote|keep-both")
+	cmd.Flags().Int64Var(&flagPushMaxAttachmentBytes, "max-attachment-bytes", syncflow.DefaultMaxAttachmentBytes, "Reject attachment uploads larger than this many bytes before contacting Confluence")
+	cmd.Flags().StringVar(&flagPushOnTitleConflict, "on-title-conflict", string(syncflow.PushTitleConflictFail), "When a new page's title is already used in the space: fail|suffix (append \" (2)\", \" (3)\", ... to the new title)")
+	cmd.Flags().StringVar(&flagPushParent, "parent", "", "Parent page ID or tracked .md path for pages newly created by this push")
+	cmd.Flags().BoolVar(&flagPushCreateOnly, "create-only", false, "Only push files without a frontmatter id (create new pages); skip updates to existing pages")
+	cmd.Flags().BoolVar(&flagPushUpdateOnly, "update-only", false, "Only push files that already have a frontmatter id (update existing pages); skip new pages")
+	cmd.Flags().BoolVar(&flagPushSkipDeletes, "skip-deletes", false, "Do not archive or delete remote pages for locally deleted files")
+	cmd.Flags().StringVar(&flagPushSinceRef, "since-tag", "", "Push files changed since this tag, branch or commit instead of since the last sync tag")
+	cmd.Flags().StringArrayVar(&flagPushOnly, "only", nil, "Only push changed files whose space-relative path matches this glob (repeatable; supports ** e.g. \"Guides/**\")")
+	cmd.Flags().StringArrayVar(&flagPushInclude, "include", nil, "Same as --only; the patterns of both flags are combined")
+	cmd.Flags().StringArrayVar(&flagPushExclude, "exclude", nil, "Do not push changed files whose space-relative path matches this glob, including deletions (repeatable; supports **)")
+	cmd.Flags().BoolVar(&flagPushSquash, "squash", false, "Record all pages of this push as a single commit with per-page trailers instead of one commit per page")
+	cmd.Flags().StringVar(&flagPushCommitTemplate, "commit-template", "", "Go text/template for each push commit message: first line is the subject ({{.PageTitle}}, {{.PageID}}, {{.Version}}, {{.SpaceKey}}, {{.URL}}, {{.Path}}); Confluence trailers are always appended (overrides push.commit_template in .cms-space.yaml)")
+	cmd.Flags().BoolVar(&flagPushContinueOnError, "continue-on-error", false, "Keep pushing the remaining pages when one fails, commit the pages that succeeded and report every failure at the end")
+	cmd.Flags().BoolVar(&flagPushNoConsistencyWait, "no-consistency-wait", false, "Do not read pages back after writing them to wait for Confluence to catch up (faster, but later steps may see stale data)")
+	cmd.Flags().BoolVar(&flagPushParentByTitle, "parent-by-title", false, "For new pages in a directory without a local parent page, use the remote page titled like the directory as the parent")
+	cmd.Flags().StringVar(&flagPushMessage, "message", "", "Confluence version comment for every updated page (default: the subject of the latest commit that changed each page)")
+	cmd.Flags().BoolVar(&flagPushResume, "resume", false, "Continue the latest retained failed push for the space, skipping pages it already pushed")
+	cmd.Flags().BoolVar(&flagPushSkipValidate, "skip-validate", false, "UNSAFE: skip the pre-push validate step (requires --yes and --non-interactive; for pipelines that already validated)")
+	addCommandTimeoutFlag(cmd)
 	addReportJSONFlag(cmd)
 	return cmd
 }
@@ -99,8 +135,31 @@ func validateMergeResolution(v string) error {
 	}
 }
 
+func validateOnTitleConflict(v string) error {
+	switch syncflow.PushTitleConflictPolicy(v) {
+	case syncflow.PushTitleConflictFail, syncflow.PushTitleConflictSuffix:
+		return nil
+	default:
+		return fmt.Errorf("invalid --on-title-conflict value %q: must be fail or suffix", v)
+	}
+}
+
+// validateSkipValidateFlags guards --skip-validate so it can only be used by
+// fully unattended runs that explicitly approved the push.
+func validateSkipValidateFlags(preflight, dryRun bool) error {
+	if !flagPushSkipValidate {
+		return nil
+	}
+	if preflight || dryRun {
+		return errors.New("--skip-validate cannot be combined with --preflight or --dry-run")
+	}
+	if !flagNonInteractive || !flagYes {
+		return errors.New("--skip-validate requires both --non-interactive and --yes")
+	}
+	return nil
+}
+
 func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun bool) (runErr error) {
-	ctx := getCommandContext(cmd)
 	actualOut := ensureSynchronizedCmdOutput(cmd)
 	out := reportWriter(cmd, actualOut)
 	runID, restoreLogger := beginCommandRun("push")
@@ -144,12 +203,75 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 		)
 	}()
 
+	timeoutCtx, cancel, err := withCommandTimeout(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	defer func() { runErr = explainCommandInterruption(timeoutCtx, "push", runErr) }()
+	ctx := withRemoteLookupCache(timeoutCtx)
+
 	if preflight && dryRun {
 		return errors.New("--preflight and --dry-run cannot be used together")
 	}
 	if err := validateMergeResolution(flagMergeResolution); err != nil {
 		return err
 	}
+	if err := validateOnTitleConflict(flagPushOnTitleConflict); err != nil {
+		return err
+	}
+	if err := validateSkipValidateFlags(preflight, dryRun); err != nil {
+		return err
+	}
+	if err := validatePushResumeFlags(preflight, dryRun); err != nil {
+		return err
+	}
+	if err := validatePushOutputFlag(cmd, dryRun); err != nil {
+		return err
+	}
+	// With --output json the plan owns stdout; progress and notes go to stderr.
+	var planOut io.Writer
+	if flagPushOutput == pushOutputJSON {
+		planOut = actualOut
+		out = ensureSynchronizedCmdError(cmd)
+	}
+	if err := validateRelPathGlobs("--only", flagPushOnly); err != nil {
+		return err
+	}
+	if err := validateRelPathGlobs("--include", flagPushInclude); err != nil {
+		return err
+	}
+	if err := validateRelPathGlobs("--exclude", flagPushExclude); err != nil {
+		return err
+	}
+	if err := validatePushOperationFlags(); err != nil {
+		return err
+	}
+	if err := validateMaxImpactFlag(); err != nil {
+		return err
+	}
+	if flagPushMaxAttachmentBytes < 0 {
+		return errors.New("--max-attachment-bytes must be a non-negative byte count")
+	}
+	if flagPushSkipValidate {
+		slog.Warn("push_validation_skipped", "reason", "skip_validate_flag")
+		_, _ = fmt.Fprintln(out, "warning: --skip-validate is set; pre-push validation is DISABLED and invalid content may be written to Confluence")
+	}
+	initialCtx, err := resolveInitialPushContext(target)
+	if err != nil {
+		return err
+	}
+	spaceCfg, err := loadSpaceConfig(initialCtx.spaceDir)
+	if err != nil {
+		return err
+	}
+	if _, err := resolvePushCommitTemplate(spaceCfg); err != nil {
+		return err
+	}
+	if onConflict == "" {
+		onConflict = spaceCfg.OnConflict
+	}
+
 	if !preflight {
 		resolvedPolicy, err := resolvePushConflictPolicy(cmd.InOrStdin(), out, onConflict, target.IsSpace())
 		if err != nil {
@@ -162,10 +284,6 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 		}
 	}
 
-	initialCtx, err := resolveInitialPushContext(target)
-	if err != nil {
-		return err
-	}
 	spaceDir := initialCtx.spaceDir
 	spaceKey := initialCtx.spaceKey
 	telemetrySpaceKey = strings.TrimSpace(spaceKey)
@@ -178,7 +296,7 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	}
 
 	if !initialCtx.fixedDir {
-		remote, err := newPushRemote(cfg)
+		remote, err := openPushRemote(ctx, cfg)
 		if err == nil {
 			defer closeRemoteIfPossible(remote)
 			space, err := remote.GetSpace(ctx, spaceKey)
@@ -230,24 +348,46 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	}
 
 	ts := nowUTC()
-	tsStr := ts.Format("20060102T150405Z")
+	tagTime, err := resolveSyncTimestamp()
+	if err != nil {
+		return err
+	}
+	tsStr := formatSyncTimestamp(tagTime)
 
 	if dryRun {
-		return runPushDryRun(ctx, cmd, out, target, spaceKey, spaceDir, onConflict, gitClient, spaceScopePath, changeScopePath)
+		return runPushDryRun(ctx, cmd, out, planOut, target, spaceKey, spaceDir, onConflict, gitClient, spaceScopePath, changeScopePath)
 	}
 
-	baselineRef, err := gitPushBaselineRef(gitClient, spaceKey)
+	// Recovery artifacts are named after the run they belong to; a resumed
+	// push reuses the failed run's names but still tags with the current time.
+	artifactStamp := formatSyncTimestamp(ts)
+	var resume *pushResumeContext
+	if flagPushResume {
+		resume, err = preparePushResume(gitClient, spaceKey, currentBranch, spaceScopePath)
+		if err != nil {
+			return err
+		}
+		artifactStamp = resume.Run.Timestamp
+		_, _ = fmt.Fprintf(out, "resuming failed push %s: %d file(s) already pushed\n", resume.Run.SyncBranch, len(resume.PushedPaths))
+	}
+
+	baselineRef, err := pushBaselineRef(gitClient, spaceKey)
 	if err != nil {
 		return err
 	}
 
-	preSnapshotChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceScopePath, changeScopePath)
+	preSnapshotChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
 	if err != nil {
 		return err
 	}
+	preSnapshotChanges = filterPushChangesByPathFlags(preSnapshotChanges)
+	preSnapshotChanges = filterPushChangesByIgnore(preSnapshotChanges, spaceCfg.Ignore)
+	preSnapshotChanges, skippedChanges := filterPushChangesByOperation(spaceDir, preSnapshotChanges)
+	printSkippedPushChanges(out, skippedChanges)
 
-	if len(preSnapshotChanges) == 0 {
+	if len(preSnapshotChanges) == 0 && resume == nil {
 		_, _ = fmt.Fprintln(out, "push completed: no local markdown changes detected since last sync (no-op)")
+		report.NoChanges = true
 		return nil
 	}
 
@@ -282,7 +422,7 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	refKey := fs.SanitizePathSegment(spaceKey)
 	syncBranchName := ""
 
-	snapshotName := fmt.Sprintf("refs/confluence-sync/snapshots/%s/%s", refKey, tsStr)
+	snapshotName := fmt.Sprintf("refs/confluence-sync/snapshots/%s/%s", refKey, artifactStamp)
 	if err := gitClient.UpdateRef(snapshotName, snapshotCommit, "create snapshot"); err != nil {
 		return fmt.Errorf("create snapshot ref: %w", err)
 	}
@@ -290,7 +430,7 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 
 	// Keep snapshot ref only on failure, delete on success
 	defer func() {
-		if runErr == nil {
+		if pushRunCompleted(runErr) {
 			if err := gitClient.DeleteRef(snapshotName); err == nil {
 				report.setRecoveryArtifactStatus("snapshot_ref", snapshotName, "cleaned_up")
 			} else {
@@ -299,20 +439,24 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 		} else {
 			report.setRecoveryArtifactStatus("snapshot_ref", snapshotName, "retained")
 			_, _ = fmt.Fprintf(out, "\nSnapshot retained for recovery: %s\n", snapshotName)
-			printPushRecoveryGuidance(out, refKey, tsStr, syncBranchName, snapshotName)
+			printPushRecoveryGuidance(out, refKey, artifactStamp, syncBranchName, snapshotName)
 		}
 	}()
 
 	// 2. Create Sync Branch
-	syncBranchName = fmt.Sprintf("sync/%s/%s", refKey, tsStr)
-	if err := gitClient.CreateBranch(syncBranchName, headCommit); err != nil {
-		return fmt.Errorf("create sync branch: %w", err)
+	// A resumed push continues on the failed run's branch, which already
+	// holds the pages that run published.
+	syncBranchName = fmt.Sprintf("sync/%s/%s", refKey, artifactStamp)
+	if resume == nil {
+		if err := gitClient.CreateBranch(syncBranchName, headCommit); err != nil {
+			return fmt.Errorf("create sync branch: %w", err)
+		}
+		report.setRecoveryArtifactStatus("sync_branch", syncBranchName, "created")
 	}
-	report.setRecoveryArtifactStatus("sync_branch", syncBranchName, "created")
 
 	// Keep sync branch only on failure, delete on success
 	defer func() {
-		if runErr == nil {
+		if pushRunCompleted(runErr) {
 			if err := gitClient.DeleteBranch(syncBranchName); err == nil {
 				report.setRecoveryArtifactStatus("sync_branch", syncBranchName, "cleaned_up")
 			} else {
@@ -325,7 +469,7 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	}()
 
 	// 3. Create Worktree
-	worktreeDir := filepath.Join(gitClient.RootDir, ".confluence-worktrees", fmt.Sprintf("%s-%s", refKey, tsStr))
+	worktreeDir := filepath.Join(gitClient.RootDir, ".confluence-worktrees", fmt.Sprintf("%s-%s", refKey, artifactStamp))
 	if err := gitClient.AddWorktree(worktreeDir, syncBranchName); err != nil {
 		return fmt.Errorf("create worktree: %w", err)
 	}
@@ -334,15 +478,15 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	}()
 
 	defer func() {
-		if runErr == nil {
-			if err := deleteRecoveryMetadata(gitClient.RootDir, refKey, tsStr); err != nil {
+		if pushRunCompleted(runErr) {
+			if err := deleteRecoveryMetadata(gitClient.RootDir, refKey, artifactStamp); err != nil {
 				_, _ = fmt.Fprintf(out, "warning: failed to clean up recovery metadata: %v\n", err)
 			}
 			return
 		}
 		if err := writeRecoveryMetadata(gitClient.RootDir, recoveryMetadata{
 			SpaceKey:       refKey,
-			Timestamp:      tsStr,
+			Timestamp:      artifactStamp,
 			SyncBranch:     syncBranchName,
 			SnapshotRef:    snapshotName,
 			OriginalBranch: strings.TrimSpace(currentBranch),
@@ -353,7 +497,10 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 	}()
 
 	outcome, err := runPushInWorktree(ctx, cmd, out, target, spaceKey, spaceDir, onConflict, flagMergeResolution, tsStr,
-		gitClient, spaceScopePath, changeScopePath, worktreeDir, syncBranchName, snapshotName, &stashRef)
+		gitClient, spaceScopePath, changeScopePath, worktreeDir, syncBranchName, snapshotName, &stashRef, resume)
+	report.NoChanges = outcome.NoChanges
+	report.Commit = outcome.Commit
+	report.Tag = outcome.Tag
 	report.Diagnostics = append(report.Diagnostics, reportDiagnosticsFromPush(outcome.Result.Diagnostics, spaceDir)...)
 	for _, commit := range outcome.Result.Commits {
 		report.MutatedFiles = append(report.MutatedFiles, reportRelativePath(spaceDir, commit.Path))
@@ -365,6 +512,7 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 			Deleted: commit.Deleted,
 		})
 	}
+	report.Diagnostics = append(report.Diagnostics, reportDiagnosticsFromPushFailures(outcome.Result.Failures, spaceDir)...)
 	report.AttachmentOperations = append(report.AttachmentOperations, reportAttachmentOpsFromPush(outcome.Result, spaceDir)...)
 	report.FallbackModes = append(report.FallbackModes, fallbackModesFromPushDiagnostics(outcome.Result.Diagnostics)...)
 	if outcome.ConflictResolution != nil {
@@ -374,6 +522,12 @@ func runPush(cmd *cobra.Command, target config.Target, onConflict string, dryRun
 		report.AttachmentOperations = append(report.AttachmentOperations, outcome.ConflictResolution.AttachmentOperations...)
 		report.FallbackModes = append(report.FallbackModes, outcome.ConflictResolution.FallbackModes...)
 	}
+	if err == nil && len(outcome.Result.Failures) > 0 {
+		return &pushPartialFailureError{
+			failed: len(outcome.Result.Failures),
+			total:  len(outcome.Result.Failures) + len(outcome.Result.Commits),
+		}
+	}
 	return err
 }
 
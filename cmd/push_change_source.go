@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
@@ -11,6 +13,25 @@ import (
 	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
 )
 
+// flagPushSinceRef overrides the sync-tag baseline push diffs against.
+var flagPushSinceRef string
+
+// pushBaselineRef returns the commit push diffs the workspace against: the
+// --since-tag ref when set, otherwise the latest sync tag for the space. The
+// override is resolved to a commit ID up front so it means the same commit
+// inside the sync worktree.
+func pushBaselineRef(client *git.Client, spaceKey string) (string, error) {
+	sinceRef := strings.TrimSpace(flagPushSinceRef)
+	if sinceRef == "" {
+		return gitPushBaselineRef(client, spaceKey)
+	}
+	commit, err := client.ResolveRef(sinceRef + "^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("--since-tag %q does not name a commit in this repository", sinceRef)
+	}
+	return commit, nil
+}
+
 func gitPushBaselineRef(client *git.Client, spaceKey string) (string, error) {
 	spaceKey = strings.TrimSpace(spaceKey)
 	if spaceKey == "" {
@@ -68,10 +89,15 @@ func collectSyncPushChanges(client *git.Client, baselineRef, diffScopePath, spac
 	return toSyncPushChanges(changes, spaceScopePath)
 }
 
+// collectPushChangesForTarget lists the changes since baselineRef in the
+// target's scope, plus the pending paths of an earlier partial push recorded
+// in the state of spaceDir. Files are read from the space directory under
+// client.RootDir, which may be the sync worktree.
 func collectPushChangesForTarget(
 	client *git.Client,
 	baselineRef string,
 	target config.Target,
+	spaceDir string,
 	spaceScopePath string,
 	changeScopePath string,
 ) ([]syncflow.PushFileChange, error) {
@@ -79,7 +105,134 @@ func collectPushChangesForTarget(
 	if target.IsFile() {
 		diffScopePath = changeScopePath
 	}
-	return collectSyncPushChanges(client, baselineRef, diffScopePath, spaceScopePath)
+	changes, err := collectSyncPushChanges(client, baselineRef, diffScopePath, spaceScopePath)
+	if err != nil {
+		return nil, err
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		return nil, fmt.Errorf("load state: %w", err)
+	}
+	onlyPath := ""
+	if target.IsFile() {
+		onlyPath = normalizeRepoRelPath(changeScopePath)
+		if scopePath := normalizeRepoRelPath(spaceScopePath); scopePath != "" {
+			onlyPath = strings.TrimPrefix(onlyPath, scopePath+"/")
+		}
+	}
+	filesDir := filepath.Join(client.RootDir, filepath.FromSlash(spaceScopePath))
+	return addPendingPushChanges(changes, state, filesDir, onlyPath), nil
+}
+
+// addPendingPushChanges adds the state's PendingPushPaths that changes does
+// not already hold. A pending file that is gone becomes a delete when it is
+// tracked and is dropped otherwise. onlyPath, when set, limits the pending
+// paths to a file target.
+func addPendingPushChanges(changes []syncflow.PushFileChange, state fs.SpaceState, filesDir, onlyPath string) []syncflow.PushFileChange {
+	if len(state.PendingPushPaths) == 0 {
+		return changes
+	}
+	listed := make(map[string]struct{}, len(changes))
+	for _, change := range changes {
+		listed[normalizeRepoRelPath(change.Path)] = struct{}{}
+	}
+	tracked := make(map[string]struct{}, len(state.PagePathIndex))
+	for relPath := range state.PagePathIndex {
+		tracked[normalizeRepoRelPath(relPath)] = struct{}{}
+	}
+
+	added := false
+	for _, relPath := range state.PendingPushPaths {
+		relPath = normalizeRepoRelPath(relPath)
+		if relPath == "" || (onlyPath != "" && relPath != onlyPath) {
+			continue
+		}
+		if _, ok := listed[relPath]; ok {
+			continue
+		}
+		_, isTracked := tracked[relPath]
+		changeType := syncflow.PushChangeModify
+		if _, err := os.Stat(filepath.Join(filesDir, filepath.FromSlash(relPath))); err != nil {
+			if !isTracked {
+				continue
+			}
+			changeType = syncflow.PushChangeDelete
+		} else if !isTracked {
+			changeType = syncflow.PushChangeAdd
+		}
+		changes = append(changes, syncflow.PushFileChange{Type: changeType, Path: relPath})
+		listed[relPath] = struct{}{}
+		added = true
+	}
+	if added {
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	}
+	return changes
+}
+
+// pendingPushPaths returns the paths the next push must add back: earlier
+// pending paths this run did not publish, plus the paths that failed.
+func pendingPushPaths(previous []string, commits []syncflow.PushCommitPlan, failures []syncflow.PushFailure) []string {
+	pending := map[string]struct{}{}
+	for _, relPath := range previous {
+		if relPath = normalizeRepoRelPath(relPath); relPath != "" {
+			pending[relPath] = struct{}{}
+		}
+	}
+	for _, commit := range commits {
+		delete(pending, normalizeRepoRelPath(commit.Path))
+	}
+	for _, failure := range failures {
+		if relPath := normalizeRepoRelPath(failure.Path); relPath != "" {
+			pending[relPath] = struct{}{}
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(pending))
+	for relPath := range pending {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// filterPushChangesByPathFlags applies --only and --include (combined), then
+// --exclude. Filtered-out deletions are skipped like any other change.
+func filterPushChangesByPathFlags(changes []syncflow.PushFileChange) []syncflow.PushFileChange {
+	include := append(append([]string(nil), flagPushOnly...), flagPushInclude...)
+	return filterPushChangesByIgnore(filterPushChangesByOnly(changes, include), flagPushExclude)
+}
+
+// filterPushChangesByOnly keeps only changes whose space-relative path matches
+// at least one --only pattern. An empty pattern list keeps every change.
+func filterPushChangesByOnly(changes []syncflow.PushFileChange, patterns []string) []syncflow.PushFileChange {
+	if len(patterns) == 0 {
+		return changes
+	}
+	out := make([]syncflow.PushFileChange, 0, len(changes))
+	for _, change := range changes {
+		if fs.MatchAnyRelPathGlob(patterns, change.Path) {
+			out = append(out, change)
+		}
+	}
+	return out
+}
+
+// filterPushChangesByIgnore drops changes matching the space config ignore
+// patterns.
+func filterPushChangesByIgnore(changes []syncflow.PushFileChange, patterns []string) []syncflow.PushFileChange {
+	if len(patterns) == 0 {
+		return changes
+	}
+	out := make([]syncflow.PushFileChange, 0, len(changes))
+	for _, change := range changes {
+		if !matchesSpaceIgnore(patterns, change.Path) {
+			out = append(out, change)
+		}
+	}
+	return out
 }
 
 func collectGitChangesWithUntracked(client *git.Client, baselineRef, scopePath string) ([]git.FileStatus, error) {
@@ -128,7 +281,7 @@ func toSyncPushChanges(changes []git.FileStatus, spaceScopePath string) ([]syncf
 			continue
 		}
 
-		if !strings.HasSuffix(relPath, ".md") || strings.HasPrefix(relPath, "assets/") {
+		if !strings.HasSuffix(relPath, ".md") || strings.HasPrefix(relPath, "assets/") || fs.IsPageSidecar(relPath) {
 			continue
 		}
 
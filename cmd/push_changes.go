@@ -20,6 +20,7 @@ func runPushDryRun(
 	ctx context.Context,
 	cmd *cobra.Command,
 	out io.Writer,
+	planOut io.Writer,
 	target config.Target,
 	spaceKey, spaceDir, onConflict string,
 	gitClient *git.Client,
@@ -27,18 +28,33 @@ func runPushDryRun(
 ) error {
 	_, _ = fmt.Fprintln(out, "[DRY-RUN] Simulating push (no git or confluence state will be modified)")
 
-	baselineRef, err := gitPushBaselineRef(gitClient, spaceKey)
+	baselineRef, err := pushBaselineRef(gitClient, spaceKey)
 	if err != nil {
 		return err
 	}
 
-	syncChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceScopePath, changeScopePath)
+	syncChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
 	if err != nil {
 		return err
 	}
+	syncChanges = filterPushChangesByPathFlags(syncChanges)
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return err
+	}
+	filenameMode, err := fs.ParseFilenameMode(spaceCfg.FilenameMode)
+	if err != nil {
+		return err
+	}
+	syncChanges = filterPushChangesByIgnore(syncChanges, spaceCfg.Ignore)
+	syncChanges, skippedChanges := filterPushChangesByOperation(spaceDir, syncChanges)
+	printSkippedPushChanges(out, skippedChanges)
 
 	if len(syncChanges) == 0 {
 		_, _ = fmt.Fprintln(out, "push completed: no local markdown changes detected since last sync (no-op)")
+		if planOut != nil {
+			return writePushPlanReport(planOut, buildPushPlanReport(spaceKey, spaceDir, fs.SpaceState{}, nil, skippedChanges, nil, nil))
+		}
 		return nil
 	}
 
@@ -52,7 +68,7 @@ func runPushDryRun(
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	realRemote, err := newPushRemote(cfg)
+	realRemote, err := openPushRemote(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("create confluence client: %w", err)
 	}
@@ -87,6 +103,13 @@ func runPushDryRun(
 		DryRun:              true,
 		ArchiveTimeout:      normalizedArchiveTaskTimeout(),
 		ArchivePollInterval: normalizedArchiveTaskPollInterval(),
+		MaxAttachmentBytes:  flagPushMaxAttachmentBytes,
+		NewPageParent:       flagPushParent,
+		ParentByTitle:       flagPushParentByTitle,
+		TitleConflictPolicy: resolvePushTitleConflictPolicy(cmd, spaceCfg),
+		StripTitleHeading:   spaceCfg.StripTitleHeading,
+		OrderPrefix:         spaceCfg.OrderPrefix,
+		FilenameMode:        filenameMode,
 		Progress:            progress,
 	})
 	if err != nil {
@@ -101,6 +124,9 @@ func runPushDryRun(
 	_, _ = fmt.Fprintf(out, "\n[DRY-RUN] push completed: %d page change(s) would be synced\n", len(result.Commits))
 	printPushDiagnostics(out, result.Diagnostics)
 	printPushSyncSummary(out, result.Commits, result.Diagnostics)
+	if planOut != nil {
+		return writePushPlanReport(planOut, buildPushPlanReport(spaceKey, spaceDir, state, syncChanges, skippedChanges, result.Commits, result.Diagnostics))
+	}
 	return nil
 }
 
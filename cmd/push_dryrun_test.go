@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -86,6 +87,85 @@ func TestRunPush_DryRunDoesNotMutateFrontmatter(t *testing.T) {
 	}
 }
 
+func TestRunPush_DryRunOutputJSONPrintsChangePlan(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T10:00:00Z"},
+		Body:        "Updated\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "new-page.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New page"},
+		Body:        "new content\n",
+	})
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	oldOutput := flagPushOutput
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		flagPushOutput = oldOutput
+	})
+	flagPushOutput = pushOutputJSON
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+	setAutomationFlags(t, true, true)
+
+	cmd := &cobra.Command{}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictForce, true); err != nil {
+		t.Fatalf("runPush dry-run error: %v", err)
+	}
+
+	var plan pushPlanReport
+	if err := json.Unmarshal(stdout.Bytes(), &plan); err != nil {
+		t.Fatalf("stdout is not a JSON plan: %v\n%s", err, stdout.String())
+	}
+	if plan.SpaceKey != "ENG" || plan.Summary.Total != 2 || plan.Summary.Adds != 1 || plan.Summary.Modifies != 1 {
+		t.Fatalf("unexpected plan summary: %+v", plan)
+	}
+	if plan.SafetyConfirmationRequired {
+		t.Fatalf("two changes without deletes should not need confirmation: %+v", plan)
+	}
+	byPath := map[string]pushPlanChange{}
+	for _, change := range plan.Changes {
+		byPath[change.Path] = change
+	}
+	if got := byPath["root.md"]; got.Type != "M" || got.PageID != "1" || got.Title != "Root" {
+		t.Fatalf("root.md plan = %+v", got)
+	}
+	if got := byPath["new-page.md"]; got.Type != "A" || got.PageID != "" || got.Title != "New page" {
+		t.Fatalf("new-page.md plan = %+v", got)
+	}
+	if !strings.Contains(stderr.String(), "[DRY-RUN]") {
+		t.Fatalf("expected human dry-run output on stderr, got:\n%s", stderr.String())
+	}
+}
+
+func TestRunPush_OutputJSONRequiresDryRun(t *testing.T) {
+	runParallelCommandTest(t)
+
+	oldOutput := flagPushOutput
+	t.Cleanup(func() { flagPushOutput = oldOutput })
+	flagPushOutput = pushOutputJSON
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}, OnConflictForce, false)
+	if err == nil || !strings.Contains(err.Error(), "--output json requires --dry-run") {
+		t.Fatalf("expected --dry-run requirement error, got %v", err)
+	}
+}
+
 func TestRunPush_DryRunDoesNotMutateExistingFrontmatter(t *testing.T) {
 	runParallelCommandTest(t)
 
@@ -428,6 +508,154 @@ func TestRunPush_PreflightHonorsExplicitForceConflictPolicy(t *testing.T) {
 	}
 }
 
+func TestRunPush_PreflightOnlyFilterRestrictsChangeSet(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated root content\n",
+	})
+	if err := os.MkdirAll(filepath.Join(spaceDir, "Guides"), 0o750); err != nil {
+		t.Fatalf("mkdir Guides: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "intro.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Intro"},
+		Body:        "New guide\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	previousPreflight := flagPushPreflight
+	flagPushPreflight = true
+	previousOnly := flagPushOnly
+	flagPushOnly = []string{"Guides/**"}
+	t.Cleanup(func() {
+		flagPushPreflight = previousPreflight
+		flagPushOnly = previousOnly
+	})
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() preflight unexpected error: %v", err)
+	}
+
+	text := out.String()
+	if !strings.Contains(text, "changes: 1 (A:1 M:0 D:0)") {
+		t.Fatalf("preflight change count should reflect --only filter:\n%s", text)
+	}
+	if !strings.Contains(text, "Guides/intro.md") {
+		t.Fatalf("preflight output missing filtered-in file:\n%s", text)
+	}
+	if strings.Contains(text, "root.md") {
+		t.Fatalf("preflight output should not mention filtered-out root.md:\n%s", text)
+	}
+}
+
+func TestRunPush_PreflightIncludeExcludeSkipsOutOfScopeDeletes(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	if err := os.Remove(filepath.Join(spaceDir, "root.md")); err != nil {
+		t.Fatalf("remove root.md: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(spaceDir, "Guides"), 0o750); err != nil {
+		t.Fatalf("mkdir Guides: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "intro.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Intro"},
+		Body:        "New guide\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "draft.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Draft"},
+		Body:        "Not ready\n",
+	})
+	runGitForTest(t, repo, "add", "-A")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	previousPreflight := flagPushPreflight
+	flagPushPreflight = true
+	previousInclude, previousExclude := flagPushInclude, flagPushExclude
+	flagPushInclude = []string{"Guides/**"}
+	flagPushExclude = []string{"Guides/draft.md"}
+	t.Cleanup(func() {
+		flagPushPreflight = previousPreflight
+		flagPushInclude, flagPushExclude = previousInclude, previousExclude
+	})
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() preflight unexpected error: %v", err)
+	}
+
+	text := out.String()
+	if !strings.Contains(text, "changes: 1 (A:1 M:0 D:0)") {
+		t.Fatalf("preflight change count should reflect --include/--exclude:\n%s", text)
+	}
+	if !strings.Contains(text, "Guides/intro.md") {
+		t.Fatalf("preflight output missing included file:\n%s", text)
+	}
+	if strings.Contains(text, "draft.md") || strings.Contains(text, "root.md") {
+		t.Fatalf("preflight output should skip excluded and out-of-scope files:\n%s", text)
+	}
+}
+
+func TestRunPush_RejectsMalformedOnlyPattern(t *testing.T) {
+	runParallelCommandTest(t)
+
+	previousOnly := flagPushOnly
+	flagPushOnly = []string{"Guides/[abc"}
+	t.Cleanup(func() { flagPushOnly = previousOnly })
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}, OnConflictCancel, false)
+	if err == nil || !strings.Contains(err.Error(), "invalid --only pattern") {
+		t.Fatalf("expected invalid --only pattern error, got %v", err)
+	}
+}
+
 func TestRunPush_PreflightRejectsDryRunCombination(t *testing.T) {
 	runParallelCommandTest(t)
 
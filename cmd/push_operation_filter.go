@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagPushCreateOnly pushes only files without a frontmatter id (new pages).
+var flagPushCreateOnly bool
+
+// flagPushUpdateOnly pushes only files that already have a frontmatter id.
+var flagPushUpdateOnly bool
+
+// flagPushSkipDeletes leaves remote pages of locally deleted files untouched.
+var flagPushSkipDeletes bool
+
+// skippedPushChange is a change dropped by an operation filter flag.
+type skippedPushChange struct {
+	Path   string
+	Reason string
+	// Held marks a file excluded by `cms_skip: true`, which stays out of
+	// every push rather than only this one.
+	Held bool
+}
+
+func validatePushOperationFlags() error {
+	if flagPushCreateOnly && flagPushUpdateOnly {
+		return errors.New("--create-only and --update-only cannot be used together")
+	}
+	return nil
+}
+
+// filterPushChangesByOperation applies --create-only, --update-only and
+// --skip-deletes, and drops files marked `cms_skip: true`. Whether a file
+// creates or updates a page is decided by its frontmatter id, read from
+// spaceDir, not by its git change type.
+func filterPushChangesByOperation(spaceDir string, changes []syncflow.PushFileChange) ([]syncflow.PushFileChange, []skippedPushChange) {
+	kept := make([]syncflow.PushFileChange, 0, len(changes))
+	var skipped []skippedPushChange
+	for _, change := range changes {
+		if change.Type != syncflow.PushChangeDelete && syncflow.IsSyncSkipped(filepath.Join(spaceDir, filepath.FromSlash(change.Path))) {
+			skipped = append(skipped, skippedPushChange{Path: change.Path, Reason: "cms_skip: true in frontmatter", Held: true})
+			continue
+		}
+		if change.Type == syncflow.PushChangeDelete {
+			if flagPushSkipDeletes {
+				skipped = append(skipped, skippedPushChange{Path: change.Path, Reason: "--skip-deletes: local file was deleted"})
+				continue
+			}
+			kept = append(kept, change)
+			continue
+		}
+		if !flagPushCreateOnly && !flagPushUpdateOnly {
+			kept = append(kept, change)
+			continue
+		}
+
+		fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(change.Path)))
+		if err != nil {
+			// Leave unreadable files in the change set so validation reports them.
+			kept = append(kept, change)
+			continue
+		}
+		pageID := strings.TrimSpace(fm.ID)
+		switch {
+		case flagPushCreateOnly && pageID != "":
+			skipped = append(skipped, skippedPushChange{Path: change.Path, Reason: fmt.Sprintf("--create-only: page already exists (id %s)", pageID)})
+		case flagPushUpdateOnly && pageID == "":
+			skipped = append(skipped, skippedPushChange{Path: change.Path, Reason: "--update-only: new page (no id in frontmatter)"})
+		default:
+			kept = append(kept, change)
+		}
+	}
+	return kept, skipped
+}
+
+// skippedChangesLeftBehind reports whether a flag dropped a change that a
+// later push without the flag would still publish.
+func skippedChangesLeftBehind(skipped []skippedPushChange) bool {
+	for _, change := range skipped {
+		if !change.Held {
+			return true
+		}
+	}
+	return false
+}
+
+func printSkippedPushChanges(out io.Writer, skipped []skippedPushChange) {
+	if len(skipped) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(out, "skipping %d changed file(s):\n", len(skipped))
+	for _, change := range skipped {
+		_, _ = fmt.Fprintf(out, "  - %s (%s)\n", change.Path, change.Reason)
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func setPushOperationFlagsForTest(t *testing.T, createOnly, updateOnly, skipDeletes bool) {
+	t.Helper()
+	previousCreateOnly, previousUpdateOnly, previousSkipDeletes := flagPushCreateOnly, flagPushUpdateOnly, flagPushSkipDeletes
+	flagPushCreateOnly, flagPushUpdateOnly, flagPushSkipDeletes = createOnly, updateOnly, skipDeletes
+	t.Cleanup(func() {
+		flagPushCreateOnly, flagPushUpdateOnly, flagPushSkipDeletes = previousCreateOnly, previousUpdateOnly, previousSkipDeletes
+	})
+}
+
+func writeRootUpdateAndNewGuide(t *testing.T, repo, spaceDir string) {
+	t.Helper()
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated root content\n",
+	})
+	if err := os.MkdirAll(filepath.Join(spaceDir, "Guides"), 0o750); err != nil {
+		t.Fatalf("mkdir Guides: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "intro.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Intro"},
+		Body:        "New guide\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+}
+
+func TestRunPush_UpdateOnlySkipsNewPages(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	writeRootUpdateAndNewGuide(t, repo, spaceDir)
+	setPushOperationFlagsForTest(t, false, true, false)
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(out.String(), "Guides/intro.md (--update-only: new page (no id in frontmatter))") {
+		t.Fatalf("output should report the skipped new page:\n%s", out.String())
+	}
+	if len(fake.updateCalls) == 0 {
+		t.Fatal("expected root.md to be updated")
+	}
+	for _, call := range fake.updateCalls {
+		if call.PageID != "1" {
+			t.Fatalf("--update-only wrote page %q; only existing page 1 should be updated", call.PageID)
+		}
+	}
+
+	fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, "Guides", "intro.md"))
+	if err != nil {
+		t.Fatalf("read skipped page: %v", err)
+	}
+	if fm.ID != "" {
+		t.Fatalf("skipped new page should not get an id, got %q", fm.ID)
+	}
+	if tags := strings.TrimSpace(runGitForTest(t, repo, "tag", "--list", "confluence-sync/push/ENG/*")); tags != "" {
+		t.Fatalf("push that skipped a change should not move the baseline, got tags %q", tags)
+	}
+}
+
+func TestRunPush_OnlyFilterKeepsFilteredOutChangesForNextPush(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	writeRootUpdateAndNewGuide(t, repo, spaceDir)
+
+	previousOnly := flagPushOnly
+	flagPushOnly = []string{"Guides/**"}
+	t.Cleanup(func() { flagPushOnly = previousOnly })
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v\n%s", err, out.String())
+	}
+	for _, call := range fake.updateCalls {
+		if call.PageID == "1" {
+			t.Fatal("--only Guides/** should not update root.md")
+		}
+	}
+	if tags := strings.TrimSpace(runGitForTest(t, repo, "tag", "--list", "confluence-sync/push/ENG/*")); tags != "" {
+		t.Fatalf("filtered push should not move the baseline, got tags %q", tags)
+	}
+
+	flagPushOnly = nil
+	previousPreflight := flagPushPreflight
+	flagPushPreflight = true
+	t.Cleanup(func() { flagPushPreflight = previousPreflight })
+
+	out.Reset()
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() preflight unexpected error: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "root.md") {
+		t.Fatalf("next push should still see the filtered-out root.md edit:\n%s", out.String())
+	}
+}
+
+func TestRunPush_PreflightCreateOnlySkipsExistingPages(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	writeRootUpdateAndNewGuide(t, repo, spaceDir)
+	setPushOperationFlagsForTest(t, true, false, false)
+
+	previousPreflight := flagPushPreflight
+	flagPushPreflight = true
+	t.Cleanup(func() { flagPushPreflight = previousPreflight })
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() preflight unexpected error: %v", err)
+	}
+
+	text := out.String()
+	if !strings.Contains(text, "root.md (--create-only: page already exists (id 1))") {
+		t.Fatalf("preflight output should report the skipped update:\n%s", text)
+	}
+	if !strings.Contains(text, "changes: 1 (A:1 M:0 D:0)") {
+		t.Fatalf("preflight change count should reflect --create-only:\n%s", text)
+	}
+}
+
+func TestFilterPushChangesByOperation_SkipDeletesIsIndependent(t *testing.T) {
+	runParallelCommandTest(t)
+
+	setPushOperationFlagsForTest(t, false, false, true)
+
+	changes := []syncflow.PushFileChange{
+		{Type: syncflow.PushChangeModify, Path: "root.md"},
+		{Type: syncflow.PushChangeDelete, Path: "old.md"},
+	}
+	kept, skipped := filterPushChangesByOperation(t.TempDir(), changes)
+	if len(kept) != 1 || kept[0].Path != "root.md" {
+		t.Fatalf("kept = %+v, want only root.md", kept)
+	}
+	if len(skipped) != 1 || skipped[0].Path != "old.md" || !strings.Contains(skipped[0].Reason, "--skip-deletes") {
+		t.Fatalf("skipped = %+v, want old.md skipped by --skip-deletes", skipped)
+	}
+}
+
+func TestRunPush_RejectsCreateOnlyWithUpdateOnly(t *testing.T) {
+	runParallelCommandTest(t)
+
+	setPushOperationFlagsForTest(t, true, true, false)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}, OnConflictCancel, false)
+	if err == nil || !strings.Contains(err.Error(), "--create-only and --update-only cannot be used together") {
+		t.Fatalf("expected flag combination error, got %v", err)
+	}
+}
+
+func TestFilterPushChangesByOperation_SkipsCmsSkipFiles(t *testing.T) {
+	runParallelCommandTest(t)
+
+	setPushOperationFlagsForTest(t, false, false, false)
+
+	spaceDir := t.TempDir()
+	writeMarkdown(t, filepath.Join(spaceDir, "wip.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "WIP", ID: "5", Version: 1, Skip: true},
+		Body:        "draft\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "ready.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Ready", ID: "6", Version: 1},
+		Body:        "done\n",
+	})
+
+	kept, skipped := filterPushChangesByOperation(spaceDir, []syncflow.PushFileChange{
+		{Type: syncflow.PushChangeModify, Path: "wip.md"},
+		{Type: syncflow.PushChangeModify, Path: "ready.md"},
+	})
+	if len(kept) != 1 || kept[0].Path != "ready.md" {
+		t.Fatalf("kept = %+v, want only ready.md", kept)
+	}
+	if len(skipped) != 1 || skipped[0].Path != "wip.md" || !strings.Contains(skipped[0].Reason, "cms_skip") {
+		t.Fatalf("skipped = %+v, want wip.md skipped by cms_skip", skipped)
+	}
+}
@@ -39,6 +39,19 @@ func printPushDiagnostics(out io.Writer, diagnostics []syncflow.PushDiagnostic)
 	}
 }
 
+// printPushFailureSummary lists the changes a --continue-on-error push could
+// not sync, with the reason each one failed.
+func printPushFailureSummary(out io.Writer, failures []syncflow.PushFailure) {
+	if len(failures) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "\nFailed changes (%d):\n", len(failures))
+	for _, failure := range failures {
+		_, _ = fmt.Fprintf(out, "  x %s: %v\n", failure.Path, failure.Err)
+	}
+}
+
 func printPushWarningSummary(out io.Writer, warnings []string) {
 	if len(warnings) == 0 {
 		return
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// pushPartialFailureError is returned by a --continue-on-error push whose
+// successful pages were committed, merged and saved while others failed. The
+// run still exits non-zero, but its recovery artifacts are cleaned up like a
+// successful push because nothing is left to resume.
+type pushPartialFailureError struct {
+	failed int
+	total  int
+}
+
+func (e *pushPartialFailureError) Error() string {
+	return fmt.Sprintf("push failed for %d of %d page change(s); the other changes were synced. Fix the failures and rerun `conf push` to retry them", e.failed, e.total)
+}
+
+// pushRunCompleted reports whether a push got through commit, merge and state
+// save, so its snapshot ref, sync branch and recovery metadata can be removed.
+func pushRunCompleted(err error) bool {
+	if err == nil {
+		return true
+	}
+	var partialErr *pushPartialFailureError
+	return errors.As(err, &partialErr)
+}
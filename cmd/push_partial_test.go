@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPush_ContinueOnErrorCommitsSuccessfulPagesAndReportsFailures(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated local content\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "broken.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Broken"},
+		Body:        "Rejected by Confluence\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	fake := newCmdFakePushRemote(1)
+	fake.failUpdateTitle = "Broken"
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	oldContinue := flagPushContinueOnError
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	flagPushContinueOnError = true
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+		flagPushContinueOnError = oldContinue
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	headBefore := strings.TrimSpace(runGitForTest(t, repo, "rev-parse", "HEAD"))
+
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false)
+	var partialErr *pushPartialFailureError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("runPush() error = %v, want a partial failure", err)
+	}
+	if partialErr.failed != 1 || partialErr.total != 2 {
+		t.Fatalf("partial failure = %+v, want 1 of 2", partialErr)
+	}
+
+	syncCommits := strings.Fields(runGitForTest(t, repo, "rev-list", "--no-merges", headBefore+"..HEAD"))
+	if len(syncCommits) != 1 {
+		t.Fatalf("expected one sync commit for the successful page, got %d", len(syncCommits))
+	}
+	if files := runGitForTest(t, repo, "show", "--name-only", "--pretty=format:", syncCommits[0]); strings.Contains(files, "broken.md") {
+		t.Fatalf("failed page must not be committed:\n%s", files)
+	}
+
+	rootDoc, readErr := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "root.md"))
+	if readErr != nil {
+		t.Fatalf("read root.md: %v", readErr)
+	}
+	if rootDoc.Frontmatter.Version != 2 {
+		t.Fatalf("root.md version = %d, want 2", rootDoc.Frontmatter.Version)
+	}
+	brokenDoc, readErr := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "broken.md"))
+	if readErr != nil {
+		t.Fatalf("read broken.md: %v", readErr)
+	}
+	if brokenDoc.Frontmatter.ID != "" {
+		t.Fatalf("failed page must not get an id, got %q", brokenDoc.Frontmatter.ID)
+	}
+
+	if tags := strings.TrimSpace(runGitForTest(t, repo, "tag", "--list", "confluence-sync/push/*")); tags == "" {
+		t.Fatal("partial push should still move the baseline with a push tag")
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if got := strings.Join(state.PendingPushPaths, ","); got != "broken.md" {
+		t.Fatalf("pending push paths = %q, want broken.md", got)
+	}
+	if branches := strings.TrimSpace(runGitForTest(t, repo, "branch", "--list", "sync/*")); branches != "" {
+		t.Fatalf("partial push should clean up its sync branch, got %q", branches)
+	}
+
+	text := out.String()
+	for _, expected := range []string{
+		"Failed changes (1):",
+		"x broken.md: ",
+		"simulated update failure",
+		"1 page change(s) synced, 1 failed",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("output missing %q:\n%s", expected, text)
+		}
+	}
+
+	// The next push retries only the failed page: root.md already has its new
+	// version and must not get an empty-change version on top.
+	fake.failUpdateTitle = ""
+	fake.updateCalls = nil
+	out.Reset()
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("second runPush() error: %v\n%s", err, out.String())
+	}
+	for _, call := range fake.updateCalls {
+		if call.PageID == "1" {
+			t.Fatalf("root.md was pushed again:\n%s", out.String())
+		}
+	}
+	brokenDoc, readErr = fs.ReadMarkdownDocument(filepath.Join(spaceDir, "broken.md"))
+	if readErr != nil {
+		t.Fatalf("read broken.md: %v", readErr)
+	}
+	if brokenDoc.Frontmatter.ID == "" {
+		t.Fatalf("retried page should be published:\n%s", out.String())
+	}
+	if state, err = fs.LoadState(spaceDir); err != nil || len(state.PendingPushPaths) != 0 {
+		t.Fatalf("pending push paths after retry = %v (err %v), want none", state.PendingPushPaths, err)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+const (
+	pushOutputText = "text"
+	pushOutputJSON = "json"
+)
+
+// flagPushOutput selects the dry-run output format: text or json.
+var flagPushOutput = pushOutputText
+
+// pushPlanReport is the machine-readable `push --dry-run --output json` plan.
+type pushPlanReport struct {
+	SpaceKey                   string               `json:"space_key"`
+	Changes                    []pushPlanChange     `json:"changes"`
+	Skipped                    []pushPlanSkipped    `json:"skipped"`
+	Summary                    pushPlanSummary      `json:"summary"`
+	SafetyConfirmationRequired bool                 `json:"safety_confirmation_required"`
+	Diagnostics                []pushPlanDiagnostic `json:"diagnostics"`
+}
+
+type pushPlanChange struct {
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+	PageID string `json:"page_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+}
+
+type pushPlanSkipped struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+type pushPlanSummary struct {
+	Total    int `json:"total"`
+	Adds     int `json:"adds"`
+	Modifies int `json:"modifies"`
+	Deletes  int `json:"deletes"`
+}
+
+type pushPlanDiagnostic struct {
+	Path    string `json:"path,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func validatePushOutputFlag(cmd *cobra.Command, dryRun bool) error {
+	switch flagPushOutput {
+	case pushOutputText:
+		return nil
+	case pushOutputJSON:
+	default:
+		return fmt.Errorf("invalid --output value %q: must be text or json", flagPushOutput)
+	}
+	if !dryRun {
+		return errors.New("--output json requires --dry-run")
+	}
+	if commandRequestsJSONReport(cmd) {
+		return errors.New("--output json cannot be combined with --report-json")
+	}
+	return nil
+}
+
+// buildPushPlanReport describes the changes a dry run would push. Page IDs and
+// titles come from the simulated push when it produced a commit for the file,
+// otherwise from frontmatter (adds and modifies) or the state index (deletes).
+func buildPushPlanReport(
+	spaceKey, spaceDir string,
+	state fs.SpaceState,
+	changes []syncflow.PushFileChange,
+	skipped []skippedPushChange,
+	commits []syncflow.PushCommitPlan,
+	diagnostics []syncflow.PushDiagnostic,
+) pushPlanReport {
+	commitByPath := make(map[string]syncflow.PushCommitPlan, len(commits))
+	for _, commit := range commits {
+		commitByPath[normalizeRepoRelPath(commit.Path)] = commit
+	}
+
+	report := pushPlanReport{
+		SpaceKey:    spaceKey,
+		Changes:     make([]pushPlanChange, 0, len(changes)),
+		Skipped:     make([]pushPlanSkipped, 0, len(skipped)),
+		Diagnostics: make([]pushPlanDiagnostic, 0, len(diagnostics)),
+	}
+	for _, change := range changes {
+		relPath := normalizeRepoRelPath(change.Path)
+		planned := pushPlanChange{Path: relPath, Type: string(change.Type)}
+		if commit, ok := commitByPath[relPath]; ok {
+			// A dry run gives new pages a synthetic ID; the plan has none yet.
+			if change.Type != syncflow.PushChangeAdd {
+				planned.PageID = strings.TrimSpace(commit.PageID)
+			}
+			planned.Title = strings.TrimSpace(commit.PageTitle)
+		}
+		if change.Type == syncflow.PushChangeDelete {
+			if planned.PageID == "" {
+				planned.PageID = strings.TrimSpace(state.PagePathIndex[relPath])
+			}
+		} else if fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath))); err == nil {
+			if planned.PageID == "" {
+				planned.PageID = strings.TrimSpace(fm.ID)
+			}
+			if planned.Title == "" {
+				planned.Title = strings.TrimSpace(fm.Title)
+			}
+		}
+		report.Changes = append(report.Changes, planned)
+	}
+	for _, change := range skipped {
+		report.Skipped = append(report.Skipped, pushPlanSkipped{Path: change.Path, Reason: change.Reason})
+	}
+	for _, diag := range diagnostics {
+		report.Diagnostics = append(report.Diagnostics, pushPlanDiagnostic{Path: diag.Path, Code: diag.Code, Message: diag.Message})
+	}
+
+	report.Summary.Adds, report.Summary.Modifies, report.Summary.Deletes = summarizePushChanges(changes)
+	report.Summary.Total = len(changes)
+	report.SafetyConfirmationRequired = exceedsSafetyImpact(len(changes)) || pushHasDeleteChange(changes)
+	return report
+}
+
+func writePushPlanReport(out io.Writer, report pushPlanReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -40,14 +40,22 @@ func runPushPreflight(
 	spaceScopePath, changeScopePath string,
 	onConflict string,
 ) error {
-	baselineRef, err := gitPushBaselineRef(gitClient, spaceKey)
+	baselineRef, err := pushBaselineRef(gitClient, spaceKey)
 	if err != nil {
 		return err
 	}
-	syncChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceScopePath, changeScopePath)
+	syncChanges, err := collectPushChangesForTarget(gitClient, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
 	if err != nil {
 		return err
 	}
+	syncChanges = filterPushChangesByPathFlags(syncChanges)
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return err
+	}
+	syncChanges = filterPushChangesByIgnore(syncChanges, spaceCfg.Ignore)
+	syncChanges, skippedChanges := filterPushChangesByOperation(spaceDir, syncChanges)
+	printSkippedPushChanges(out, skippedChanges)
 
 	_, _ = fmt.Fprintf(out, "preflight for space %s\n", spaceKey)
 	if len(syncChanges) == 0 {
@@ -84,7 +92,7 @@ func buildPushPreflightContext(ctx context.Context, spaceKey, spaceDir string, s
 		return pushPreflightContext{}, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	remote, err := newPushRemote(cfg)
+	remote, err := openPushRemote(ctx, cfg)
 	if err != nil {
 		return pushPreflightContext{}, fmt.Errorf("create confluence client: %w", err)
 	}
@@ -329,15 +337,16 @@ func preflightAttachmentMutations(_ context.Context, spaceDir string, syncChange
 			continue
 		}
 
+		assetDir := syncflow.PageAssetDir(preflightCtx.state.AssetLayout, pageID, change.Path)
 		for _, assetPath := range referencedPaths {
-			plannedKey := filepath.ToSlash(filepath.Join("assets", pageID, filepath.Base(assetPath)))
+			plannedKey := assetDir + "/" + filepath.Base(assetPath)
 			plannedUploadKeys[plannedKey] = struct{}{}
 			if strings.TrimSpace(preflightCtx.state.AttachmentIndex[plannedKey]) == "" {
 				uploads = append(uploads, plannedKey)
 			}
 		}
 
-		prefix := "assets/" + pageID + "/"
+		prefix := assetDir + "/"
 		for stateKey := range preflightCtx.state.AttachmentIndex {
 			if !strings.HasPrefix(stateKey, prefix) {
 				continue
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPush_WritesFrontmatterPropertiesThroughCachedRemote(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T10:00:00Z",
+			Extra: map[string]any{fs.PropertiesKey: map[string]any{"dashboard.owner": "docs-team"}},
+		},
+		Body: "Updated local content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	// runPush opens the remote through the lookup cache, so the properties
+	// must reach the real remote through cachedPushRemote.
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+
+	if len(fake.setPropertyCalls) != 1 {
+		t.Fatalf("set property calls = %+v, want one", fake.setPropertyCalls)
+	}
+	if got := fake.setPropertyCalls[0]; got.Key != "dashboard.owner" || string(got.Value) != `"docs-team"` {
+		t.Fatalf("set property = %+v", got)
+	}
+}
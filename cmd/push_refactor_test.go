@@ -115,7 +115,7 @@ func TestPreflightAttachmentMutations_ShowsNewPageUploads(t *testing.T) {
 	}
 }
 
-func TestToSyncPushChanges_FiltersScopeAndNonMarkdownPaths(t *testing.T) {
+func TestToSyncPushChanges_FiltersScopeNonMarkdownAndCommentSidecarPaths(t *testing.T) {
 	t.Parallel()
 
 	changes, err := toSyncPushChanges([]git.FileStatus{
@@ -123,6 +123,7 @@ func TestToSyncPushChanges_FiltersScopeAndNonMarkdownPaths(t *testing.T) {
 		{Code: "A", Path: "Engineering (ENG)/nested/child.md"},
 		{Code: "D", Path: "Engineering (ENG)/assets/image.png"},
 		{Code: "M", Path: "Engineering (ENG)/notes.txt"},
+		{Code: "A", Path: "Engineering (ENG)/root.comments.md"},
 		{Code: "M", Path: "Other/file.md"},
 	}, "Engineering (ENG)")
 	if err != nil {
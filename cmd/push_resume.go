@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/git"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagPushResume continues the latest retained failed push for the space.
+var flagPushResume bool
+
+// pushResumeContext describes a retained failed push that `push --resume`
+// continues. The failed run committed every page it had already published to
+// its sync branch, so those pages carry their new remote versions.
+type pushResumeContext struct {
+	Run recoveryRun
+	// PushedPaths are repo-relative paths committed on Run.SyncBranch.
+	PushedPaths []string
+}
+
+func validatePushResumeFlags(preflight, dryRun bool) error {
+	if !flagPushResume {
+		return nil
+	}
+	if preflight || dryRun {
+		return errors.New("--resume cannot be combined with --preflight or --dry-run")
+	}
+	return nil
+}
+
+// preparePushResume finds the latest retained failed push for spaceKey and
+// checks that it can be continued from the current workspace: HEAD must still
+// be the commit the failed push started from, and files it already published
+// must not have been edited since.
+func preparePushResume(client *git.Client, spaceKey, currentBranch, spaceScopePath string) (*pushResumeContext, error) {
+	run, err := findResumablePushRun(client, spaceKey, currentBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.Run("merge-base", "--is-ancestor", "HEAD", run.SyncBranch); err != nil {
+		return nil, fmt.Errorf("cannot resume %s: HEAD has moved since the failed push; check out the commit it started from or discard the run with `%s`", run.SyncBranch, recoveryDiscardCommand(run.SpaceKey, run.Timestamp))
+	}
+
+	pushedPaths, err := listResumedPushPaths(client, run, spaceScopePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, repoPath := range pushedPaths {
+		edited, err := workspacePathDiffersFromSnapshot(client, run.SnapshotRef, repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if edited {
+			return nil, fmt.Errorf("cannot resume %s: %s was already pushed by the failed run and has been edited since; revert the edit, resume, then push it again", run.SyncBranch, repoPath)
+		}
+	}
+
+	return &pushResumeContext{Run: run, PushedPaths: pushedPaths}, nil
+}
+
+// findResumablePushRun returns the most recent retained push for spaceKey
+// that still has both its sync branch and snapshot ref.
+func findResumablePushRun(client *git.Client, spaceKey, currentBranch string) (recoveryRun, error) {
+	runs, _, err := listRecoveryRuns(client, currentBranch)
+	if err != nil {
+		return recoveryRun{}, err
+	}
+	runs = filterRecoveryRunsBySpace(runs, spaceKey)
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		if run.SnapshotRef == "" || run.CurrentBranch || run.WorktreeBlockReason != "" {
+			continue
+		}
+		if _, err := client.Run("rev-parse", "--verify", "--quiet", "refs/heads/"+run.SyncBranch); err != nil {
+			continue
+		}
+		if run.OriginalBranch != "" && run.OriginalBranch != strings.TrimSpace(currentBranch) {
+			return recoveryRun{}, fmt.Errorf("cannot resume %s: it was started from branch %q; switch to that branch first", run.SyncBranch, run.OriginalBranch)
+		}
+		return run, nil
+	}
+	return recoveryRun{}, fmt.Errorf("no retained failed push to resume for space %s; run `conf recover` to inspect recovery artifacts", spaceKey)
+}
+
+// listResumedPushPaths lists repo-relative paths under spaceScopePath that the
+// failed run committed to its sync branch.
+func listResumedPushPaths(client *git.Client, run recoveryRun, spaceScopePath string) ([]string, error) {
+	forkPoint, err := client.Run("merge-base", run.SnapshotRef, run.SyncBranch)
+	if err != nil {
+		return nil, fmt.Errorf("find start of %s: %w", run.SyncBranch, err)
+	}
+
+	args := []string{"diff", "--name-only", strings.TrimSpace(forkPoint), run.SyncBranch}
+	if scopePath := normalizeRepoRelPath(spaceScopePath); scopePath != "" {
+		args = append(args, "--", scopePath)
+	}
+	raw, err := client.Run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("list pages pushed by %s: %w", run.SyncBranch, err)
+	}
+
+	paths := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		if path := normalizeRepoRelPath(line); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// workspacePathDiffersFromSnapshot reports whether the working-tree file at
+// repoPath no longer matches its content in snapshotRef. A stash snapshot
+// keeps untracked files in its third parent.
+func workspacePathDiffersFromSnapshot(client *git.Client, snapshotRef, repoPath string) (bool, error) {
+	snapshotBlob := ""
+	for _, treeish := range []string{snapshotRef, snapshotRef + "^3"} {
+		if blob, err := client.Run("rev-parse", "--verify", "--quiet", treeish+":"+repoPath); err == nil {
+			snapshotBlob = strings.TrimSpace(blob)
+			break
+		}
+	}
+
+	absPath := filepath.Join(client.RootDir, filepath.FromSlash(repoPath))
+	if _, err := os.Stat(absPath); err != nil {
+		if os.IsNotExist(err) {
+			return snapshotBlob != "", nil
+		}
+		return false, err
+	}
+	if snapshotBlob == "" {
+		return true, nil
+	}
+
+	workspaceBlob, err := client.Run("hash-object", "--", repoPath)
+	if err != nil {
+		return false, fmt.Errorf("hash %s: %w", repoPath, err)
+	}
+	return strings.TrimSpace(workspaceBlob) != snapshotBlob, nil
+}
+
+// hasPushedPaths reports whether the resumed run already published pages.
+func (r *pushResumeContext) hasPushedPaths() bool {
+	return r != nil && len(r.PushedPaths) > 0
+}
+
+// restorePushedPaths puts back the sync-branch version of every already
+// pushed file after the workspace snapshot was materialized over them, so
+// they keep the versions Confluence assigned.
+func (r *pushResumeContext) restorePushedPaths(wtClient *git.Client) error {
+	if !r.hasPushedPaths() {
+		return nil
+	}
+	for _, repoPath := range r.PushedPaths {
+		if _, err := wtClient.Run("cat-file", "-e", "HEAD:"+repoPath); err != nil {
+			if removeErr := os.Remove(filepath.Join(wtClient.RootDir, filepath.FromSlash(repoPath))); removeErr != nil && !os.IsNotExist(removeErr) {
+				return removeErr
+			}
+			continue
+		}
+		if _, err := wtClient.Run("checkout", "HEAD", "--", repoPath); err != nil {
+			return fmt.Errorf("restore pushed file %s: %w", repoPath, err)
+		}
+	}
+	return nil
+}
+
+// skipPushedChanges drops changes for files the resumed run already pushed.
+func (r *pushResumeContext) skipPushedChanges(spaceScopePath string, changes []syncflow.PushFileChange) []syncflow.PushFileChange {
+	if !r.hasPushedPaths() {
+		return changes
+	}
+	pushed := make(map[string]struct{}, len(r.PushedPaths))
+	for _, repoPath := range r.PushedPaths {
+		pushed[repoPath] = struct{}{}
+	}
+	kept := make([]syncflow.PushFileChange, 0, len(changes))
+	for _, change := range changes {
+		repoPath := normalizeRepoRelPath(filepath.Join(spaceScopePath, filepath.FromSlash(change.Path)))
+		if _, ok := pushed[repoPath]; ok {
+			continue
+		}
+		kept = append(kept, change)
+	}
+	return kept
+}
+
+// pushedCommitPlans describes the already pushed files as commit plans so the
+// workspace stash restore treats them as synced.
+func (r *pushResumeContext) pushedCommitPlans(spaceScopePath string) []syncflow.PushCommitPlan {
+	if !r.hasPushedPaths() {
+		return nil
+	}
+	scopePath := normalizeRepoRelPath(spaceScopePath)
+	plans := make([]syncflow.PushCommitPlan, 0, len(r.PushedPaths))
+	for _, repoPath := range r.PushedPaths {
+		relPath := repoPath
+		if scopePath != "" {
+			relPath = strings.TrimPrefix(repoPath, scopePath+"/")
+		}
+		plans = append(plans, syncflow.PushCommitPlan{Path: relPath, StagedPaths: []string{relPath}})
+	}
+	return plans
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+type pageFailingPushRemote struct {
+	*cmdFakePushRemote
+	failPageID string
+}
+
+func (f *pageFailingPushRemote) UpdatePage(ctx context.Context, pageID string, input confluence.PageUpsertInput) (confluence.Page, error) {
+	if pageID == f.failPageID {
+		return confluence.Page{}, errors.New("simulated update failure")
+	}
+	return f.cmdFakePushRemote.UpdatePage(ctx, pageID, input)
+}
+
+func TestRunPush_ResumeContinuesRetainedFailedPush(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithLinkedChildBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "[Child](child.md) updated\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "child.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Child", ID: "2", Version: 1},
+		Body:        "child body updated\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local edits")
+
+	fake := newCmdFakePushRemote(1)
+	child := confluence.Page{ID: "2", SpaceID: "space-1", Title: "Child", ParentPageID: "1", Version: 1, LastModified: time.Date(2026, time.February, 1, 10, 0, 0, 0, time.UTC)}
+	fake.pages = append(fake.pages, child)
+	fake.pagesByID["2"] = child
+	remote := &pageFailingPushRemote{cmdFakePushRemote: fake, failPageID: "1"}
+
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	oldNow := nowUTC
+	oldResume := flagPushResume
+	now := time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return remote, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return remote, nil }
+	nowUTC = func() time.Time { return now }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+		nowUTC = oldNow
+		flagPushResume = oldResume
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err == nil {
+		t.Fatal("runPush() expected the first push to fail")
+	}
+	if !strings.Contains(out.String(), "conf push ENG --resume") {
+		t.Fatalf("expected resume guidance, got:\n%s", out.String())
+	}
+	syncBranch := "sync/ENG/20260201T120000Z"
+	if log := runGitForTest(t, repo, "log", "--format=%s", syncBranch); !strings.Contains(log, `Sync "Child" to Confluence (v2)`) {
+		t.Fatalf("expected the already pushed child page to be committed on %s, got:\n%s", syncBranch, log)
+	}
+
+	remote.failPageID = ""
+	flagPushResume = true
+	now = now.Add(time.Hour)
+	updatesBefore := len(fake.updateCalls)
+
+	out.Reset()
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() --resume failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "resuming failed push "+syncBranch+": 1 file(s) already pushed") {
+		t.Fatalf("expected resume notice, got:\n%s", out.String())
+	}
+
+	resumedUpdates := fake.updateCalls[updatesBefore:]
+	if len(resumedUpdates) != 1 || resumedUpdates[0].PageID != "1" {
+		t.Fatalf("resume should only push the remaining page, got %+v", resumedUpdates)
+	}
+
+	for name, wantVersion := range map[string]int{"root.md": 2, "child.md": 2} {
+		fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if fm.Version != wantVersion {
+			t.Fatalf("%s version = %d, want %d", name, fm.Version, wantVersion)
+		}
+	}
+
+	if branches := runGitForTest(t, repo, "branch", "--list", "sync/*"); strings.TrimSpace(branches) != "" {
+		t.Fatalf("expected retained sync branch to be cleaned up, got %q", branches)
+	}
+	if refs := runGitForTest(t, repo, "for-each-ref", "refs/confluence-sync/snapshots/"); strings.TrimSpace(refs) != "" {
+		t.Fatalf("expected retained snapshot ref to be cleaned up, got %q", refs)
+	}
+	if _, err := os.Stat(recoveryMetadataPath(repo, "ENG", "20260201T120000Z")); !os.IsNotExist(err) {
+		t.Fatalf("expected recovery metadata to be removed, stat err = %v", err)
+	}
+	if tags := runGitForTest(t, repo, "tag", "--list", "confluence-sync/push/ENG/*"); !strings.Contains(tags, "confluence-sync/push/ENG/20260201T130000Z") {
+		t.Fatalf("expected push tag with the resume time, got %q", tags)
+	}
+}
+
+func TestRunPush_ResumeWithoutRetainedRunFails(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	oldResume := flagPushResume
+	flagPushResume = true
+	t.Cleanup(func() { flagPushResume = oldResume })
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false)
+	if err == nil || !strings.Contains(err.Error(), "no retained failed push to resume for space ENG") {
+		t.Fatalf("expected missing recovery run error, got: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
 	"github.com/spf13/cobra"
 )
@@ -89,3 +90,82 @@ func TestRunPush_YesBypassesDeleteConfirmation(t *testing.T) {
 		t.Fatalf("expected archive call for deleted page, got %d", len(fake.archiveCalls))
 	}
 }
+
+func TestRunPush_SkipValidateRequiresYesAndNonInteractive(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+	setAutomationFlags(t, false, true)
+	setPushSkipValidateFlag(t, true)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false)
+	if err == nil {
+		t.Fatal("runPush() expected --skip-validate guard error")
+	}
+	if !strings.Contains(err.Error(), "--skip-validate requires both --non-interactive and --yes") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRunPush_SkipValidateBypassesPrePushValidation(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated without validate\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local change")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+	setAutomationFlags(t, true, true)
+	setPushSkipValidateFlag(t, true)
+
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "pre-push validation is DISABLED") {
+		t.Fatalf("expected skip-validate warning, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "Validation successful") {
+		t.Fatalf("expected validation to be skipped, got:\n%s", out.String())
+	}
+	if len(fake.updateCalls) != 1 {
+		t.Fatalf("expected one page update, got %d", len(fake.updateCalls))
+	}
+}
+
+func setPushSkipValidateFlag(t *testing.T, value bool) {
+	t.Helper()
+	previous := flagPushSkipValidate
+	flagPushSkipValidate = value
+	t.Cleanup(func() { flagPushSkipValidate = previous })
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPush_SinceTagOverridesSyncBaseline(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	runGitForTest(t, repo, "tag", "docs-v1")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Edited before the last sync\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local change")
+	// A later sync tag hides the change from the default baseline.
+	runGitForTest(t, repo, "tag", "-a", "confluence-sync/pull/ENG/20260202T120000Z", "-m", "later pull")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	oldSinceRef := flagPushSinceRef
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+		flagPushSinceRef = oldSinceRef
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	flagPushSinceRef = ""
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "no-op") || len(fake.updateCalls) != 0 {
+		t.Fatalf("expected a no-op push against the latest sync tag, got %d update(s):\n%s", len(fake.updateCalls), out.String())
+	}
+
+	flagPushSinceRef = "does-not-exist"
+	err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false)
+	if err == nil || !strings.Contains(err.Error(), "--since-tag") {
+		t.Fatalf("expected an unknown --since-tag ref to fail, got %v", err)
+	}
+
+	flagPushSinceRef = "docs-v1"
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush(--since-tag) unexpected error: %v", err)
+	}
+	if len(fake.updateCalls) != 1 || fake.updateCalls[0].PageID != "1" {
+		t.Fatalf("expected --since-tag to push root.md, got updates %+v", fake.updateCalls)
+	}
+}
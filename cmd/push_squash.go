@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/git"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagPushSquash records every page of a successful push as one commit on
+// the sync branch instead of one commit per page.
+var flagPushSquash bool
+
+// commitSquashedPushPlans stages every published page in the sync worktree
+// and commits them together. The message lists each page and version and
+// repeats the Confluence trailers once per page, so tooling that reads the
+// per-page trailers keeps working. A push of a single page uses
+// messageTemplate like an unsquashed push. onCommitted runs for each page
+// once the commit exists.
+func commitSquashedPushPlans(wtClient *git.Client, worktreeDir, wtSpaceDir string, commits []syncflow.PushCommitPlan, messageTemplate *template.Template, onCommitted func(syncflow.PushCommitPlan)) error {
+	if len(commits) == 0 {
+		return nil
+	}
+	for _, commitPlan := range commits {
+		if err := stagePushCommitPlan(wtClient, worktreeDir, wtSpaceDir, commitPlan); err != nil {
+			return err
+		}
+	}
+
+	subject, body := squashedPushCommitMessage(commits)
+	if len(commits) == 1 && messageTemplate != nil {
+		subject, body = pushCommitMessage(messageTemplate, commits[0])
+	}
+	if err := wtClient.Commit(subject, body); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+
+	if onCommitted != nil {
+		for _, commitPlan := range commits {
+			onCommitted(commitPlan)
+		}
+	}
+	return nil
+}
+
+func squashedPushCommitMessage(commits []syncflow.PushCommitPlan) (string, string) {
+	subject := fmt.Sprintf("Sync %q to Confluence (v%d)", commits[0].PageTitle, commits[0].Version)
+	if len(commits) > 1 {
+		subject = fmt.Sprintf("Sync %d pages to Confluence (%s)", len(commits), commits[0].SpaceKey)
+	}
+
+	var body strings.Builder
+	body.WriteString("Pages:\n")
+	for _, commitPlan := range commits {
+		_, _ = fmt.Fprintf(&body, "- %q (page %s, v%d) %s\n", commitPlan.PageTitle, commitPlan.PageID, commitPlan.Version, commitPlan.URL)
+	}
+	trailers := make([]string, 0, len(commits))
+	for _, commitPlan := range commits {
+		trailers = append(trailers, pushCommitTrailers(commitPlan))
+	}
+	// Git only parses trailers from the final paragraph, so the per-page
+	// blocks are not separated by blank lines.
+	body.WriteString("\n")
+	body.WriteString(strings.Join(trailers, "\n"))
+	return subject, body.String()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPush_SquashRecordsOneCommitWithPerPageTrailers(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated local content\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "new.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New"},
+		Body:        "Brand new page\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	oldSquash := flagPushSquash
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	flagPushSquash = true
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+		flagPushSquash = oldSquash
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	headBefore := strings.TrimSpace(runGitForTest(t, repo, "rev-parse", "HEAD"))
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+
+	syncCommits := strings.Fields(runGitForTest(t, repo, "rev-list", "--no-merges", headBefore+"..HEAD"))
+	if len(syncCommits) != 1 {
+		t.Fatalf("expected one squashed sync commit, got %d", len(syncCommits))
+	}
+
+	message := runGitForTest(t, repo, "log", "-1", "--pretty=%B", syncCommits[0])
+	if !strings.Contains(message, "Sync 2 pages to Confluence (ENG)") {
+		t.Fatalf("squashed commit subject not found:\n%s", message)
+	}
+	for _, expected := range []string{
+		`- "Root" (page 1, v2)`,
+		`- "New" (page new-page-2, v`,
+		"Confluence-Page-ID: 1\nConfluence-Version: 2\nConfluence-Space-Key: ENG",
+		"Confluence-Page-ID: new-page-2\nConfluence-Version: ",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("squashed commit message missing %q:\n%s", expected, message)
+		}
+	}
+
+	trailers := runGitForTest(t, repo, "log", "-1", "--format=%(trailers:key=Confluence-Page-ID,valueonly)", syncCommits[0])
+	if got := strings.Fields(trailers); len(got) != 2 {
+		t.Fatalf("expected git to parse two Confluence-Page-ID trailers, got %q", trailers)
+	}
+}
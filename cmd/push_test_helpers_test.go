@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -108,12 +109,16 @@ type cmdFakePushRemote struct {
 	space                 confluence.Space
 	pages                 []confluence.Page
 	pagesByID             map[string]confluence.Page
+	createInputs          []confluence.PageUpsertInput
 	updateCalls           []cmdPushUpdateCall
 	archiveCalls          [][]string
 	deletePageCalls       []string
 	uploadAttachmentCalls []confluence.AttachmentUploadInput
 	deleteAttachmentCalls []string
 	webURL                string
+	failUpdateTitle       string
+	properties            []confluence.ContentProperty
+	setPropertyCalls      []confluence.ContentProperty
 }
 
 type cmdPushUpdateCall struct {
@@ -205,7 +210,25 @@ func (f *cmdFakePushRemote) RemoveLabel(_ context.Context, _ string, _ string) e
 	return nil
 }
 
+func (f *cmdFakePushRemote) GetRestrictions(_ context.Context, _ string) (confluence.PageRestrictions, error) {
+	return confluence.PageRestrictions{}, nil
+}
+
+func (f *cmdFakePushRemote) SetRestrictions(_ context.Context, _ string, _ confluence.PageRestrictions) error {
+	return nil
+}
+
+func (f *cmdFakePushRemote) GetContentProperties(_ context.Context, _ string) ([]confluence.ContentProperty, error) {
+	return f.properties, nil
+}
+
+func (f *cmdFakePushRemote) SetContentProperty(_ context.Context, _ string, property confluence.ContentProperty) (confluence.ContentProperty, error) {
+	f.setPropertyCalls = append(f.setPropertyCalls, property)
+	return property, nil
+}
+
 func (f *cmdFakePushRemote) CreatePage(_ context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
+	f.createInputs = append(f.createInputs, input)
 	id := fmt.Sprintf("new-page-%d", len(f.pagesByID)+1)
 	created := confluence.Page{
 		ID:           id,
@@ -223,6 +246,9 @@ func (f *cmdFakePushRemote) CreatePage(_ context.Context, input confluence.PageU
 
 func (f *cmdFakePushRemote) UpdatePage(_ context.Context, pageID string, input confluence.PageUpsertInput) (confluence.Page, error) {
 	f.updateCalls = append(f.updateCalls, cmdPushUpdateCall{PageID: pageID, Input: input})
+	if f.failUpdateTitle != "" && input.Title == f.failUpdateTitle {
+		return confluence.Page{}, errors.New("simulated update failure")
+	}
 	updated := confluence.Page{
 		ID:           pageID,
 		SpaceID:      input.SpaceID,
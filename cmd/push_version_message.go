@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/git"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// flagPushMessage is the Confluence version comment for every page updated
+// by the push; when empty each page gets its latest commit subject instead.
+var flagPushMessage string
+
+// pushVersionMessagesByPath returns, for each added or modified file, the
+// subject of the newest commit since baselineRef that touched it, keyed by
+// space-relative path. Files changed only in the working tree have no entry.
+func pushVersionMessagesByPath(client *git.Client, baselineRef, spaceScopePath string, changes []syncflow.PushFileChange) map[string]string {
+	messages := map[string]string{}
+	for _, change := range changes {
+		if change.Type == syncflow.PushChangeDelete {
+			continue
+		}
+		repoPath := change.Path
+		if spaceScopePath != "" && spaceScopePath != "." {
+			repoPath = path.Join(spaceScopePath, change.Path)
+		}
+		subject, err := client.Run("log", "-1", "--format=%s", baselineRef+"..HEAD", "--", repoPath)
+		if err != nil {
+			continue
+		}
+		if subject = strings.TrimSpace(subject); subject != "" {
+			messages[change.Path] = subject
+		}
+	}
+	return messages
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
@@ -27,6 +28,7 @@ func runPushInWorktree(
 	spaceScopePath, changeScopePath string,
 	worktreeDir, syncBranchName, snapshotRefName string,
 	stashRef *string,
+	resume *pushResumeContext,
 ) (pushWorktreeOutcome, error) {
 	outcome := pushWorktreeOutcome{}
 	warnings := make([]string, 0)
@@ -47,6 +49,9 @@ func runPushInWorktree(
 			return outcome, fmt.Errorf("materialize snapshot in worktree: %w", err)
 		}
 	}
+	if err := resume.restorePushedPaths(wtClient); err != nil {
+		return outcome, fmt.Errorf("restore already pushed files in worktree: %w", err)
+	}
 	if err := os.MkdirAll(wtSpaceDir, 0o750); err != nil {
 		return outcome, fmt.Errorf("prepare worktree scope directory: %w", err)
 	}
@@ -62,23 +67,47 @@ func runPushInWorktree(
 	}
 
 	// 5. Diff (Snapshot vs Baseline)
-	baselineRef, err := gitPushBaselineRef(gitClient, spaceKey)
+	baselineRef, err := pushBaselineRef(gitClient, spaceKey)
 	if err != nil {
 		return outcome, err
 	}
 
 	wtClient = &git.Client{RootDir: worktreeDir}
-	syncChanges, err := collectPushChangesForTarget(wtClient, baselineRef, target, spaceScopePath, changeScopePath)
+	syncChanges, err := collectPushChangesForTarget(wtClient, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
+	if err != nil {
+		return outcome, err
+	}
+	// Changes dropped by a path or operation flag must stay visible to the
+	// next push, so a run that leaves any behind does not move the baseline.
+	scopedChanges := filterPushChangesByPathFlags(syncChanges)
+	changesLeftBehind := len(scopedChanges) < len(syncChanges)
+	syncChanges = scopedChanges
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return outcome, err
+	}
+	commitTemplate, err := resolvePushCommitTemplate(spaceCfg)
+	if err != nil {
+		return outcome, err
+	}
+	filenameMode, err := fs.ParseFilenameMode(spaceCfg.FilenameMode)
 	if err != nil {
 		return outcome, err
 	}
+	syncChanges = filterPushChangesByIgnore(syncChanges, spaceCfg.Ignore)
+	syncChanges, operationSkipped := filterPushChangesByOperation(wtSpaceDir, syncChanges)
+	changesLeftBehind = changesLeftBehind || skippedChangesLeftBehind(operationSkipped)
+	syncChanges = resume.skipPushedChanges(spaceScopePath, syncChanges)
 
 	// 4. Validate (in worktree) using the same scope as preflight and dry-run.
-	if err := runPushValidation(ctx, out, wtTarget, wtSpaceDir, "pre-push validate failed"); err != nil {
-		return outcome, err
+	// --skip-validate is an explicit opt-out for pipelines that validated earlier.
+	if !flagPushSkipValidate {
+		if err := runPushValidation(ctx, out, wtTarget, wtSpaceDir, "pre-push validate failed"); err != nil {
+			return outcome, err
+		}
 	}
 
-	if len(syncChanges) == 0 {
+	if len(syncChanges) == 0 && !resume.hasPushedPaths() {
 		_, _ = fmt.Fprintln(out, "push completed: no in-scope markdown changes found in worktree (no-op)")
 		outcome.NoChanges = true
 		return outcome, nil
@@ -102,7 +131,7 @@ func runPushInWorktree(
 		return outcome, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	remote, err := newPushRemote(cfg)
+	remote, err := openPushRemote(ctx, cfg)
 	if err != nil {
 		return outcome, fmt.Errorf("create confluence client: %w", err)
 	}
@@ -122,21 +151,34 @@ func runPushInWorktree(
 	if !flagVerbose && outputSupportsProgress(out) {
 		progress = newConsoleProgress(out, "Syncing to Confluence")
 	}
+	versionMessageByPath := pushVersionMessagesByPath(wtClient, baselineRef, spaceScopePath, syncChanges)
 
 	var result syncflow.PushResult
 	for {
 		nextResult, pushErr := syncflow.Push(ctx, remote, syncflow.PushOptions{
-			SpaceKey:            spaceKey,
-			SpaceDir:            wtSpaceDir,
-			Domain:              cfg.Domain,
-			State:               state,
-			GlobalPageIndex:     globalPageIndex,
-			Changes:             syncChanges,
-			ConflictPolicy:      toSyncConflictPolicy(onConflict),
-			KeepOrphanAssets:    flagPushKeepOrphanAssets,
-			ArchiveTimeout:      normalizedArchiveTaskTimeout(),
-			ArchivePollInterval: normalizedArchiveTaskPollInterval(),
-			Progress:            progress,
+			SpaceKey:             spaceKey,
+			SpaceDir:             wtSpaceDir,
+			Domain:               cfg.Domain,
+			State:                state,
+			GlobalPageIndex:      globalPageIndex,
+			Changes:              syncChanges,
+			ConflictPolicy:       toSyncConflictPolicy(onConflict),
+			KeepOrphanAssets:     flagPushKeepOrphanAssets,
+			ArchiveTimeout:       normalizedArchiveTaskTimeout(),
+			ArchivePollInterval:  normalizedArchiveTaskPollInterval(),
+			MaxAttachmentBytes:   flagPushMaxAttachmentBytes,
+			NewPageParent:        flagPushParent,
+			ParentByTitle:        flagPushParentByTitle,
+			VersionMessage:       flagPushMessage,
+			VersionMessageByPath: versionMessageByPath,
+			TitleConflictPolicy:  resolvePushTitleConflictPolicy(cmd, spaceCfg),
+			PlaceholderBodyADF:   placeholderBodyADF(spaceCfg.PushPlaceholder),
+			ContinueOnError:      flagPushContinueOnError,
+			StripTitleHeading:    spaceCfg.StripTitleHeading,
+			OrderPrefix:          spaceCfg.OrderPrefix,
+			FilenameMode:         filenameMode,
+			SkipConsistencyWait:  flagPushNoConsistencyWait,
+			Progress:             progress,
 		})
 		result = nextResult
 		outcome.Result = result
@@ -154,13 +196,21 @@ func runPushInWorktree(
 				if target.IsFile() && strings.TrimSpace(indexRelPath) != "" {
 					syncChanges = append(syncChanges, syncflow.PushFileChange{Type: syncflow.PushChangeAdd, Path: indexRelPath})
 				} else {
-					syncChanges, err = collectPushChangesForTarget(wtClient, baselineRef, target, spaceScopePath, changeScopePath)
+					syncChanges, err = collectPushChangesForTarget(wtClient, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
 					if err != nil {
 						return outcome, err
 					}
+					scopedChanges = filterPushChangesByPathFlags(syncChanges)
+					changesLeftBehind = changesLeftBehind || len(scopedChanges) < len(syncChanges)
+					syncChanges = filterPushChangesByIgnore(scopedChanges, spaceCfg.Ignore)
+					syncChanges, operationSkipped = filterPushChangesByOperation(wtSpaceDir, syncChanges)
+					changesLeftBehind = changesLeftBehind || skippedChangesLeftBehind(operationSkipped)
+					syncChanges = resume.skipPushedChanges(spaceScopePath, syncChanges)
 				}
-				if err := runPushValidation(ctx, out, config.Target{Mode: config.TargetModeSpace, Value: wtSpaceDir}, wtSpaceDir, "pre-push validate failed"); err != nil {
-					return outcome, err
+				if !flagPushSkipValidate {
+					if err := runPushValidation(ctx, out, config.Target{Mode: config.TargetModeSpace, Value: wtSpaceDir}, wtSpaceDir, "pre-push validate failed"); err != nil {
+						return outcome, err
+					}
 				}
 				continue
 			}
@@ -225,13 +275,21 @@ func runPushInWorktree(
 				printAutoPullMergeNextSteps(out, target)
 				return outcome, nil
 			}
+			recordPartialPush(out, wtClient, worktreeDir, wtSpaceDir, syncBranchName, result.Commits, commitTemplate)
 			return outcome, formatPushConflictError(conflictErr)
 		}
 		printPushDiagnostics(out, result.Diagnostics)
+		recordPartialPush(out, wtClient, worktreeDir, wtSpaceDir, syncBranchName, result.Commits, commitTemplate)
 		return outcome, err
 	}
 
-	if len(result.Commits) == 0 {
+	if len(result.Commits) == 0 && !resume.hasPushedPaths() && len(result.Failures) > 0 {
+		printPushDiagnostics(out, result.Diagnostics)
+		printPushFailureSummary(out, result.Failures)
+		return outcome, fmt.Errorf("push failed for all %d page change(s)", len(result.Failures))
+	}
+
+	if len(result.Commits) == 0 && !resume.hasPushedPaths() {
 		slog.Info("push_sync_result", "space_key", spaceKey, "commit_count", 0, "diagnostics", len(result.Diagnostics))
 		_, _ = fmt.Fprintln(out, "push completed: changed files produced no pushable content after validation (no-op)")
 		outcome.NoChanges = true
@@ -240,54 +298,16 @@ func runPushInWorktree(
 
 	printPushDiagnostics(out, result.Diagnostics)
 	finalizePushGit := func() error {
-		for _, commitPlan := range result.Commits {
-			filesToAdd := make([]string, 0, len(commitPlan.StagedPaths))
-			for _, relPath := range commitPlan.StagedPaths {
-				filesToAdd = append(filesToAdd, filepath.Join(wtSpaceDir, relPath))
-			}
-
-			repoPaths := make([]string, 0, len(filesToAdd))
-			for _, absPath := range filesToAdd {
-				rel, _ := filepath.Rel(worktreeDir, absPath)
-				repoPaths = append(repoPaths, filepath.ToSlash(rel))
-			}
-
-			addCandidates := make([]string, 0, len(repoPaths))
-			for _, repoPath := range repoPaths {
-				absRepoPath := filepath.Join(worktreeDir, filepath.FromSlash(repoPath))
-				if _, statErr := os.Stat(absRepoPath); os.IsNotExist(statErr) {
-					if _, err := wtClient.Run("rm", "--cached", "--ignore-unmatch", "--", repoPath); err != nil {
-						return fmt.Errorf("git rm failed: %w", err)
-					}
-					continue
-				}
-				addCandidates = append(addCandidates, repoPath)
-			}
-
-			if len(addCandidates) > 0 {
-				addArgs := append([]string{"add", "-A", "--"}, addCandidates...)
-				if _, err := wtClient.Run(addArgs...); err != nil {
-					return fmt.Errorf("git add failed: %w", err)
-				}
-			}
-
-			subject := fmt.Sprintf("Sync %q to Confluence (v%d)", commitPlan.PageTitle, commitPlan.Version)
-			body := fmt.Sprintf(
-				"Page ID: %s\nURL: %s\n\nConfluence-Page-ID: %s\nConfluence-Version: %d\nConfluence-Space-Key: %s\nConfluence-URL: %s",
-				commitPlan.PageID,
-				commitPlan.URL,
-				commitPlan.PageID,
-				commitPlan.Version,
-				commitPlan.SpaceKey,
-				commitPlan.URL,
-			)
-			if err := wtClient.Commit(subject, body); err != nil {
-				return fmt.Errorf("git commit failed: %w", err)
-			}
-
+		commitPlans := commitPushPlans
+		if flagPushSquash {
+			commitPlans = commitSquashedPushPlans
+		}
+		if err := commitPlans(wtClient, worktreeDir, wtSpaceDir, result.Commits, commitTemplate, func(commitPlan syncflow.PushCommitPlan) {
 			if progress == nil {
 				_, _ = fmt.Fprintf(out, "pushed %s (page %s, v%d)\n", commitPlan.Path, commitPlan.PageID, commitPlan.Version)
 			}
+		}); err != nil {
+			return err
 		}
 
 		if err := gitClient.RemoveWorktree(worktreeDir); err != nil {
@@ -297,15 +317,27 @@ func runPushInWorktree(
 		if err := gitClient.Merge(syncBranchName, ""); err != nil {
 			return fmt.Errorf("merge sync branch: %w", err)
 		}
+		if head, err := gitClient.Run("rev-parse", "HEAD"); err == nil {
+			outcome.Commit = strings.TrimSpace(head)
+		}
 
-		refKey := fs.SanitizePathSegment(spaceKey)
-		tagName := fmt.Sprintf("confluence-sync/push/%s/%s", refKey, tsStr)
-		tagMsg := fmt.Sprintf("Confluence push sync for %s at %s", spaceKey, tsStr)
-		if err := gitClient.Tag(tagName, tagMsg); err != nil {
-			addWarning(fmt.Sprintf("failed to create tag: %v", err))
+		// A push tag would become the next baseline and hide committed edits
+		// of filtered-out pages from the next push, so such runs skip it.
+		// Failed pages are kept in the state's PendingPushPaths instead, so
+		// pages that did publish are not pushed again.
+		if !changesLeftBehind {
+			refKey := fs.SanitizePathSegment(spaceKey)
+			tagName := fmt.Sprintf("confluence-sync/push/%s/%s", refKey, tsStr)
+			tagMsg := fmt.Sprintf("Confluence push sync for %s at %s", spaceKey, tsStr)
+			if err := gitClient.Tag(tagName, tagMsg); err != nil {
+				addWarning(fmt.Sprintf("failed to create tag: %v", err))
+			} else {
+				outcome.Tag = tagName
+			}
 		}
 
-		if err := restorePushStash(gitClient, *stashRef, spaceScopePath, result.Commits); err != nil {
+		syncedCommits := append(append([]syncflow.PushCommitPlan(nil), result.Commits...), resume.pushedCommitPlans(spaceScopePath)...)
+		if err := restorePushStash(gitClient, *stashRef, spaceScopePath, syncedCommits); err != nil {
 			addWarning(fmt.Sprintf("stash restore had conflicts: %v", err))
 		}
 		*stashRef = ""
@@ -323,19 +355,100 @@ func runPushInWorktree(
 		}
 	}
 
+	syncedCommits := append(append([]syncflow.PushCommitPlan(nil), result.Commits...), resume.pushedCommitPlans(spaceScopePath)...)
+	result.State.PendingPushPaths = pendingPushPaths(state.PendingPushPaths, syncedCommits, result.Failures)
 	if err := fs.SaveState(spaceDir, result.State); err != nil {
 		addWarning(fmt.Sprintf("failed to save local state: %v", err))
 	}
 
 	printPushWarningSummary(out, warnings)
 	printPushSyncSummary(out, result.Commits, result.Diagnostics)
+	printPushFailureSummary(out, result.Failures)
 
+	if len(result.Failures) > 0 {
+		_, _ = fmt.Fprintf(out, "push completed with failures: %d page change(s) synced, %d failed; the failed changes are recorded in the local state and retried by the next push\n", len(result.Commits), len(result.Failures))
+		slog.Info("push_sync_result", "space_key", spaceKey, "commit_count", len(result.Commits), "failure_count", len(result.Failures), "diagnostics", len(result.Diagnostics))
+		outcome.Warnings = append(outcome.Warnings, warnings...)
+		return outcome, nil
+	}
 	_, _ = fmt.Fprintf(out, "push completed: %d page change(s) synced\n", len(result.Commits))
 	slog.Info("push_sync_result", "space_key", spaceKey, "commit_count", len(result.Commits), "diagnostics", len(result.Diagnostics))
 	outcome.Warnings = append(outcome.Warnings, warnings...)
 	return outcome, nil
 }
 
+// commitPushPlans commits each published page in the sync worktree, one
+// commit per page with Confluence trailers. messageTemplate, when set,
+// replaces the built-in subject and body. onCommitted runs after each commit.
+func commitPushPlans(wtClient *git.Client, worktreeDir, wtSpaceDir string, commits []syncflow.PushCommitPlan, messageTemplate *template.Template, onCommitted func(syncflow.PushCommitPlan)) error {
+	for _, commitPlan := range commits {
+		if err := stagePushCommitPlan(wtClient, worktreeDir, wtSpaceDir, commitPlan); err != nil {
+			return err
+		}
+
+		subject, body := pushCommitMessage(messageTemplate, commitPlan)
+		if err := wtClient.Commit(subject, body); err != nil {
+			return fmt.Errorf("git commit failed: %w", err)
+		}
+
+		if onCommitted != nil {
+			onCommitted(commitPlan)
+		}
+	}
+	return nil
+}
+
+// stagePushCommitPlan stages the files of one published page in the sync
+// worktree, removing deleted files from the index.
+func stagePushCommitPlan(wtClient *git.Client, worktreeDir, wtSpaceDir string, commitPlan syncflow.PushCommitPlan) error {
+	addCandidates := make([]string, 0, len(commitPlan.StagedPaths))
+	for _, relPath := range commitPlan.StagedPaths {
+		rel, _ := filepath.Rel(worktreeDir, filepath.Join(wtSpaceDir, relPath))
+		repoPath := filepath.ToSlash(rel)
+		absRepoPath := filepath.Join(worktreeDir, filepath.FromSlash(repoPath))
+		if _, statErr := os.Stat(absRepoPath); os.IsNotExist(statErr) {
+			if _, err := wtClient.Run("rm", "--cached", "--ignore-unmatch", "--", repoPath); err != nil {
+				return fmt.Errorf("git rm failed: %w", err)
+			}
+			continue
+		}
+		addCandidates = append(addCandidates, repoPath)
+	}
+
+	if len(addCandidates) > 0 {
+		addArgs := append([]string{"add", "-A", "--"}, addCandidates...)
+		if _, err := wtClient.Run(addArgs...); err != nil {
+			return fmt.Errorf("git add failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// pushCommitTrailers returns the machine-readable trailer block for one page.
+func pushCommitTrailers(commitPlan syncflow.PushCommitPlan) string {
+	return fmt.Sprintf(
+		"Confluence-Page-ID: %s\nConfluence-Version: %d\nConfluence-Space-Key: %s\nConfluence-URL: %s",
+		commitPlan.PageID,
+		commitPlan.Version,
+		commitPlan.SpaceKey,
+		commitPlan.URL,
+	)
+}
+
+// recordPartialPush commits the pages a failed push had already published to
+// the retained sync branch, so `conf push --resume` can continue without
+// re-pushing them against stale versions.
+func recordPartialPush(out io.Writer, wtClient *git.Client, worktreeDir, wtSpaceDir, syncBranchName string, commits []syncflow.PushCommitPlan, messageTemplate *template.Template) {
+	if len(commits) == 0 {
+		return
+	}
+	if err := commitPushPlans(wtClient, worktreeDir, wtSpaceDir, commits, messageTemplate, nil); err != nil {
+		_, _ = fmt.Fprintf(out, "warning: failed to record already pushed pages on %s: %v\n", syncBranchName, err)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "%d page(s) were pushed before the failure and are recorded on %s; fix the failure and run `conf push --resume` to continue\n", len(commits), syncBranchName)
+}
+
 func printAutoPullMergeNextSteps(out io.Writer, target config.Target) {
 	_, _ = fmt.Fprintln(out, "Next steps:")
 	_, _ = fmt.Fprintln(out, "  1. Review any conflict markers or preserved backup files.")
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	"github.com/rgonek/confluence-markdown-sync/internal/git"
 	"github.com/spf13/cobra"
 )
@@ -17,29 +18,34 @@ import (
 var (
 	flagRecoverDiscard    string
 	flagRecoverDiscardAll bool
+	flagRecoverDryRun     bool
 )
 
 func newRecoverCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "recover",
+		Use:   "recover [SPACE_KEY]",
 		Short: "inspect or discard retained failed push recovery artifacts",
 		Long: `recover inspects retained failed push recovery artifacts without changing them by default.
 
-It can list retained sync branches, snapshot refs, linked worktrees, and any recorded
+It can list retained sync branches, snapshot refs, linked worktrees, orphaned
+.confluence-worktrees directories that git no longer tracks, and any recorded
 failure reason. With --discard or --discard-all it safely removes abandoned recovery
-artifacts while preserving the current recovery branch and active linked worktrees.`,
-		Args: cobra.NoArgs,
+artifacts while preserving the current recovery branch and active linked worktrees.
+Pass SPACE_KEY to limit the listing and cleanup to one space, and --dry-run to
+preview a discard without changing anything.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: runRecover,
 	}
 
 	cmd.Flags().StringVar(&flagRecoverDiscard, "discard", "", "Discard a specific recovery run (sync branch, snapshot ref, SPACE_KEY/TIMESTAMP, or TIMESTAMP)")
 	cmd.Flags().BoolVar(&flagRecoverDiscardAll, "discard-all", false, "Discard all safe retained recovery artifacts")
+	cmd.Flags().BoolVar(&flagRecoverDryRun, "dry-run", false, "Preview what --discard or --discard-all would remove without changing anything")
 	cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Auto-approve discard actions")
 	cmd.Flags().BoolVar(&flagNonInteractive, "non-interactive", false, "Disable prompts; fail fast when confirmation is required")
 	return cmd
 }
 
-func runRecover(cmd *cobra.Command, _ []string) error {
+func runRecover(cmd *cobra.Command, args []string) error {
 	out := ensureSynchronizedCmdOutput(cmd)
 
 	if strings.TrimSpace(flagRecoverDiscard) != "" && flagRecoverDiscardAll {
@@ -61,6 +67,15 @@ func runRecover(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	orphanedWorktrees, err := listOrphanedRecoveryWorktrees(client)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		spaceKey := strings.TrimSpace(args[0])
+		runs = filterRecoveryRunsBySpace(runs, spaceKey)
+		orphanedWorktrees = filterOrphanedWorktreesBySpace(orphanedWorktrees, spaceKey)
+	}
 
 	_, _ = fmt.Fprintf(out, "Repository: %s\n", client.RootDir)
 	_, _ = fmt.Fprintf(out, "Branch: %s\n", currentBranch)
@@ -68,40 +83,52 @@ func runRecover(cmd *cobra.Command, _ []string) error {
 		_, _ = fmt.Fprintf(out, "warning: %s\n", warning)
 	}
 
-	if len(runs) == 0 {
+	if len(runs) == 0 && len(orphanedWorktrees) == 0 {
 		_, _ = fmt.Fprintln(out, "recover: no retained failed push artifacts found")
 		return nil
 	}
 
 	selectedRuns := runs
+	selectedWorktrees := orphanedWorktrees
 	if selector := strings.TrimSpace(flagRecoverDiscard); selector != "" {
 		selectedRuns = selectRecoveryRuns(runs, selector)
+		selectedWorktrees = nil
 		if len(selectedRuns) == 0 {
 			return fmt.Errorf("recover: no retained recovery run matches %q", selector)
 		}
 	}
 
 	if !flagRecoverDiscardAll && strings.TrimSpace(flagRecoverDiscard) == "" {
-		renderRecoveryRuns(out, runs)
-		_, _ = fmt.Fprintf(out, "recover: %d retained recovery run(s) found; rerun with --discard or --discard-all to remove abandoned artifacts\n", len(runs))
+		renderRecoveryRuns(out, runs, orphanedWorktrees)
+		_, _ = fmt.Fprintf(out, "recover: %d retained recovery run(s) and %d orphaned worktree director(ies) found; rerun with --discard or --discard-all to remove abandoned artifacts\n", len(runs), len(orphanedWorktrees))
+		return nil
+	}
+
+	if flagRecoverDryRun {
+		for _, run := range selectedRuns {
+			if reason := recoveryRunRetainReason(run); reason != "" {
+				_, _ = fmt.Fprintf(out, "[DRY-RUN] Would retain recovery run %s: %s\n", run.SyncBranch, reason)
+				continue
+			}
+			_, _ = fmt.Fprintf(out, "[DRY-RUN] Would discard recovery run: %s\n", run.SyncBranch)
+		}
+		for _, dir := range selectedWorktrees {
+			_, _ = fmt.Fprintf(out, "[DRY-RUN] Would remove orphaned worktree directory: %s\n", dir)
+		}
+		_, _ = fmt.Fprintln(out, "recover dry-run completed: no changes were made")
 		return nil
 	}
 
-	if err := confirmRecoverDiscard(cmd.InOrStdin(), out, len(selectedRuns)); err != nil {
+	if err := confirmRecoverDiscard(cmd.InOrStdin(), out, len(selectedRuns)+len(selectedWorktrees)); err != nil {
 		return err
 	}
 
 	discarded := 0
 	skipped := 0
 	for _, run := range selectedRuns {
-		if run.CurrentBranch {
+		if reason := recoveryRunRetainReason(run); reason != "" {
 			skipped++
-			_, _ = fmt.Fprintf(out, "Retained recovery run %s: current HEAD is on this sync branch\n", run.SyncBranch)
-			continue
-		}
-		if run.WorktreeBlockReason != "" {
-			skipped++
-			_, _ = fmt.Fprintf(out, "Retained recovery run %s: %s\n", run.SyncBranch, run.WorktreeBlockReason)
+			_, _ = fmt.Fprintf(out, "Retained recovery run %s: %s\n", run.SyncBranch, reason)
 			continue
 		}
 
@@ -123,10 +150,88 @@ func runRecover(cmd *cobra.Command, _ []string) error {
 		_, _ = fmt.Fprintf(out, "Discarded recovery run: %s\n", run.SyncBranch)
 	}
 
-	_, _ = fmt.Fprintf(out, "recover completed: discarded %d recovery run(s), retained %d recovery run(s)\n", discarded, skipped)
+	removedWorktrees := 0
+	for _, dir := range selectedWorktrees {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("remove orphaned worktree directory %s: %w", dir, err)
+		}
+		removedWorktrees++
+		_, _ = fmt.Fprintf(out, "Removed orphaned worktree directory: %s\n", dir)
+	}
+	if removedWorktrees > 0 {
+		if err := client.PruneWorktrees(); err != nil {
+			_, _ = fmt.Fprintf(out, "warning: failed to prune worktrees: %v\n", err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "recover completed: discarded %d recovery run(s), retained %d recovery run(s), removed %d orphaned worktree director(ies)\n", discarded, skipped, removedWorktrees)
 	return nil
 }
 
+// recoveryRunRetainReason explains why a run must not be discarded, or returns
+// an empty string when it is safe to remove.
+func recoveryRunRetainReason(run recoveryRun) string {
+	if run.CurrentBranch {
+		return "current HEAD is on this sync branch"
+	}
+	return run.WorktreeBlockReason
+}
+
+func filterRecoveryRunsBySpace(runs []recoveryRun, spaceKey string) []recoveryRun {
+	refKey := fs.SanitizePathSegment(spaceKey)
+	filtered := make([]recoveryRun, 0, len(runs))
+	for _, run := range runs {
+		if strings.EqualFold(run.SpaceKey, spaceKey) || strings.EqualFold(run.SpaceKey, refKey) {
+			filtered = append(filtered, run)
+		}
+	}
+	return filtered
+}
+
+// listOrphanedRecoveryWorktrees returns directories under .confluence-worktrees
+// that are no longer registered as git worktrees. Such directories are left
+// behind when a push is interrupted after git forgot the worktree.
+func listOrphanedRecoveryWorktrees(client *git.Client) ([]string, error) {
+	dirs, err := listCleanWorktreeDirs(filepath.Join(client.RootDir, ".confluence-worktrees"))
+	if err != nil || len(dirs) == 0 {
+		return nil, err
+	}
+
+	raw, err := client.Run("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("list worktrees: %w", err)
+	}
+	registered := make(map[string]struct{})
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "worktree ") {
+			registered[cleanPathForComparison(strings.TrimPrefix(line, "worktree "))] = struct{}{}
+		}
+	}
+
+	orphaned := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if _, ok := registered[cleanPathForComparison(dir)]; ok {
+			continue
+		}
+		orphaned = append(orphaned, dir)
+	}
+	return orphaned, nil
+}
+
+// filterOrphanedWorktreesBySpace keeps worktree directories created for
+// spaceKey; push names them "<SPACE_KEY>-<timestamp>".
+func filterOrphanedWorktreesBySpace(dirs []string, spaceKey string) []string {
+	prefix := strings.ToLower(fs.SanitizePathSegment(spaceKey) + "-")
+	filtered := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if strings.HasPrefix(strings.ToLower(filepath.Base(dir)), prefix) {
+			filtered = append(filtered, dir)
+		}
+	}
+	return filtered
+}
+
 type recoveryRun struct {
 	SpaceKey            string
 	Timestamp           string
@@ -257,7 +362,7 @@ func selectRecoveryRuns(runs []recoveryRun, selector string) []recoveryRun {
 	return selected
 }
 
-func renderRecoveryRuns(out io.Writer, runs []recoveryRun) {
+func renderRecoveryRuns(out io.Writer, runs []recoveryRun, orphanedWorktrees []string) {
 	_, _ = fmt.Fprintln(out, "Recovery artifacts:")
 
 	_, _ = fmt.Fprintln(out, "\nSnapshot refs:")
@@ -284,7 +389,18 @@ func renderRecoveryRuns(out io.Writer, runs []recoveryRun) {
 		_, _ = fmt.Fprintln(out, "  (none)")
 	}
 
+	_, _ = fmt.Fprintln(out, "\nOrphaned worktree directories:")
+	for _, dir := range orphanedWorktrees {
+		_, _ = fmt.Fprintf(out, "  %s\n", dir)
+	}
+	if len(orphanedWorktrees) == 0 {
+		_, _ = fmt.Fprintln(out, "  (none)")
+	}
+
 	_, _ = fmt.Fprintln(out, "\nFailed runs:")
+	if len(runs) == 0 {
+		_, _ = fmt.Fprintln(out, "  (none)")
+	}
 	for _, run := range runs {
 		_, _ = fmt.Fprintf(out, "  %s %s\n", run.SpaceKey, run.Timestamp)
 		if run.SyncBranch != "" {
@@ -308,6 +424,9 @@ func renderRecoveryRuns(out io.Writer, runs []recoveryRun) {
 		} else {
 			_, _ = fmt.Fprintln(out, "    Status: safe to discard")
 		}
+		if resume := recoveryResumeCommand(run.SpaceKey); resume != "" && run.SnapshotRef != "" {
+			_, _ = fmt.Fprintf(out, "    Resume: %s\n", resume)
+		}
 		if inspect := recoveryInspectBranchCommand(run.SyncBranch); inspect != "" {
 			_, _ = fmt.Fprintf(out, "    Inspect: %s\n", inspect)
 		}
@@ -185,6 +185,85 @@ func TestRunRecover_SectionedOutputFormat(t *testing.T) {
 	}
 }
 
+func TestRunRecover_DryRunPreviewsDiscardWithoutChanges(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo, spaceDir, syncBranch, snapshotRef := createFailedPushRecoveryRun(t)
+	chdirRepo(t, spaceDir)
+
+	out, err := runRecoverForTest(t, "--discard-all", "--dry-run")
+	if err != nil {
+		t.Fatalf("recover dry-run failed: %v\nOutput:\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "[DRY-RUN] Would discard recovery run: "+syncBranch) {
+		t.Fatalf("expected dry-run discard preview, got:\n%s", out)
+	}
+	if branchList := strings.TrimSpace(runGitForTest(t, repo, "branch", "--list", syncBranch)); branchList == "" {
+		t.Fatalf("dry-run must not delete sync branch %s", syncBranch)
+	}
+	if refs := strings.TrimSpace(runGitForTest(t, repo, "for-each-ref", "--format=%(refname)", snapshotRef)); refs != snapshotRef {
+		t.Fatalf("dry-run must not delete snapshot ref, got %q", refs)
+	}
+}
+
+func TestRunRecover_SpaceKeyArgumentScopesArtifacts(t *testing.T) {
+	runParallelCommandTest(t)
+
+	_, spaceDir, syncBranch, _ := createFailedPushRecoveryRun(t)
+	chdirRepo(t, spaceDir)
+
+	out, err := runRecoverForTest(t, "OPS")
+	if err != nil {
+		t.Fatalf("recover OPS failed: %v\nOutput:\n%s", err, out)
+	}
+	if !strings.Contains(out, "no retained failed push artifacts found") || strings.Contains(out, syncBranch) {
+		t.Fatalf("expected OPS scope to exclude ENG artifacts, got:\n%s", out)
+	}
+
+	out, err = runRecoverForTest(t, "ENG")
+	if err != nil {
+		t.Fatalf("recover ENG failed: %v\nOutput:\n%s", err, out)
+	}
+	if !strings.Contains(out, syncBranch) {
+		t.Fatalf("expected ENG scope to list %s, got:\n%s", syncBranch, out)
+	}
+}
+
+func TestRunRecover_DiscardAllRemovesOrphanedWorktreeDirectories(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	orphanDir := filepath.Join(repo, ".confluence-worktrees", "ENG-20260101T000000Z")
+	if err := os.MkdirAll(orphanDir, 0o750); err != nil {
+		t.Fatalf("mkdir orphan worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(orphanDir, "leftover.md"), []byte("leftover"), 0o600); err != nil {
+		t.Fatalf("write orphan file: %v", err)
+	}
+	chdirRepo(t, spaceDir)
+
+	out, err := runRecoverForTest(t)
+	if err != nil {
+		t.Fatalf("recover inspection failed: %v\nOutput:\n%s", err, out)
+	}
+	if !strings.Contains(out, "Orphaned worktree directories:") || !strings.Contains(out, "ENG-20260101T000000Z") {
+		t.Fatalf("expected orphaned worktree listing, got:\n%s", out)
+	}
+
+	out, err = runRecoverForTest(t, "--discard-all", "--yes", "--non-interactive")
+	if err != nil {
+		t.Fatalf("recover discard failed: %v\nOutput:\n%s", err, out)
+	}
+	if _, statErr := os.Stat(orphanDir); !os.IsNotExist(statErr) {
+		t.Fatalf("expected orphaned worktree directory to be removed, stat err=%v", statErr)
+	}
+	if !strings.Contains(out, "removed 1 orphaned worktree director(ies)") {
+		t.Fatalf("expected orphan removal summary, got:\n%s", out)
+	}
+}
+
 func createFailedPushRecoveryRun(t *testing.T) (repo string, spaceDir string, syncBranch string, snapshotRef string) {
 	t.Helper()
 
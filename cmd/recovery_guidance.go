@@ -43,10 +43,21 @@ func recoveryDiscardCommand(spaceKey, timestamp string) string {
 	return fmt.Sprintf("conf recover --discard %s --yes", selector)
 }
 
+func recoveryResumeCommand(spaceKey string) string {
+	spaceKey = strings.TrimSpace(spaceKey)
+	if spaceKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("conf push %s --resume", spaceKey)
+}
+
 func printPushRecoveryGuidance(out io.Writer, spaceKey, timestamp, syncBranch, snapshotRef string) {
 	selector := recoveryRunSelector(spaceKey, timestamp)
 	_, _ = fmt.Fprintln(out, "Next steps:")
 	_, _ = fmt.Fprintln(out, "  conf recover")
+	if resume := recoveryResumeCommand(spaceKey); resume != "" {
+		_, _ = fmt.Fprintf(out, "  %s\n", resume)
+	}
 	if inspect := recoveryInspectBranchCommand(syncBranch); inspect != "" {
 		_, _ = fmt.Fprintf(out, "  %s\n", inspect)
 	}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+// remoteLookupCache memoizes space resolution and page listings for the
+// lifetime of one command invocation. It is attached to the command context
+// so every remote the command opens shares it; nothing is persisted.
+type remoteLookupCache struct {
+	mu     sync.Mutex
+	spaces map[string]confluence.Space
+	pages  map[confluence.PageListOptions]confluence.PageListResult
+}
+
+type remoteLookupCacheKey struct{}
+
+func withRemoteLookupCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, remoteLookupCacheKey{}, &remoteLookupCache{
+		spaces: map[string]confluence.Space{},
+		pages:  map[confluence.PageListOptions]confluence.PageListResult{},
+	})
+}
+
+// openPushRemote creates a push remote and, when ctx carries a lookup cache,
+// wraps it so GetSpace and ListPages results are shared across the remotes
+// opened by the same command.
+func openPushRemote(ctx context.Context, cfg *config.Config) (syncflow.PushRemote, error) {
+	remote, err := newPushRemote(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cache, ok := ctx.Value(remoteLookupCacheKey{}).(*remoteLookupCache)
+	if !ok {
+		return remote, nil
+	}
+	return &cachedPushRemote{PushRemote: remote, cache: cache}, nil
+}
+
+// cachedPushRemote serves repeated GetSpace and ListPages calls from a
+// remoteLookupCache. Any page write drops the cached listings, and GetPage is
+// never answered from a listing, so reads after a write see fresh data.
+type cachedPushRemote struct {
+	syncflow.PushRemote
+	cache *remoteLookupCache
+}
+
+func (c *cachedPushRemote) GetSpace(ctx context.Context, spaceKey string) (confluence.Space, error) {
+	c.cache.mu.Lock()
+	space, ok := c.cache.spaces[spaceKey]
+	c.cache.mu.Unlock()
+	if ok {
+		return space, nil
+	}
+
+	space, err := c.PushRemote.GetSpace(ctx, spaceKey)
+	if err != nil {
+		return confluence.Space{}, err
+	}
+	c.cache.mu.Lock()
+	c.cache.spaces[spaceKey] = space
+	c.cache.mu.Unlock()
+	return space, nil
+}
+
+func (c *cachedPushRemote) ListPages(ctx context.Context, opts confluence.PageListOptions) (confluence.PageListResult, error) {
+	c.cache.mu.Lock()
+	result, ok := c.cache.pages[opts]
+	c.cache.mu.Unlock()
+	if ok {
+		return clonePageListResult(result), nil
+	}
+
+	result, err := c.PushRemote.ListPages(ctx, opts)
+	if err != nil {
+		return confluence.PageListResult{}, err
+	}
+	c.cache.mu.Lock()
+	c.cache.pages[opts] = clonePageListResult(result)
+	c.cache.mu.Unlock()
+	return result, nil
+}
+
+func (c *cachedPushRemote) CreatePage(ctx context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
+	defer c.invalidatePages()
+	return c.PushRemote.CreatePage(ctx, input)
+}
+
+func (c *cachedPushRemote) UpdatePage(ctx context.Context, pageID string, input confluence.PageUpsertInput) (confluence.Page, error) {
+	defer c.invalidatePages()
+	return c.PushRemote.UpdatePage(ctx, pageID, input)
+}
+
+func (c *cachedPushRemote) ArchivePages(ctx context.Context, pageIDs []string) (confluence.ArchiveResult, error) {
+	defer c.invalidatePages()
+	return c.PushRemote.ArchivePages(ctx, pageIDs)
+}
+
+func (c *cachedPushRemote) DeletePage(ctx context.Context, pageID string, opts confluence.PageDeleteOptions) error {
+	defer c.invalidatePages()
+	return c.PushRemote.DeletePage(ctx, pageID, opts)
+}
+
+func (c *cachedPushRemote) MovePage(ctx context.Context, pageID string, targetID string) error {
+	defer c.invalidatePages()
+	return c.PushRemote.MovePage(ctx, pageID, targetID)
+}
+
+func (c *cachedPushRemote) Close() error {
+	closeRemoteIfPossible(c.PushRemote)
+	return nil
+}
+
+func (c *cachedPushRemote) invalidatePages() {
+	c.cache.mu.Lock()
+	c.cache.pages = map[confluence.PageListOptions]confluence.PageListResult{}
+	c.cache.mu.Unlock()
+}
+
+func clonePageListResult(result confluence.PageListResult) confluence.PageListResult {
+	result.Pages = append([]confluence.Page(nil), result.Pages...)
+	return result
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+type countingPushRemote struct {
+	*cmdFakePushRemote
+	getSpaceCalls  int
+	listPagesCalls int
+	getPageCalls   int
+}
+
+func (c *countingPushRemote) GetSpace(ctx context.Context, spaceKey string) (confluence.Space, error) {
+	c.getSpaceCalls++
+	return c.cmdFakePushRemote.GetSpace(ctx, spaceKey)
+}
+
+func (c *countingPushRemote) ListPages(ctx context.Context, opts confluence.PageListOptions) (confluence.PageListResult, error) {
+	c.listPagesCalls++
+	return c.cmdFakePushRemote.ListPages(ctx, opts)
+}
+
+func (c *countingPushRemote) GetPage(ctx context.Context, pageID string) (confluence.Page, error) {
+	c.getPageCalls++
+	return c.cmdFakePushRemote.GetPage(ctx, pageID)
+}
+
+func TestCachedPushRemote_ReusesLookupsUntilAWrite(t *testing.T) {
+	runParallelCommandTest(t)
+
+	counting := &countingPushRemote{cmdFakePushRemote: newCmdFakePushRemote(1)}
+	oldFactory := newPushRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return counting, nil }
+	t.Cleanup(func() { newPushRemote = oldFactory })
+
+	ctx := withRemoteLookupCache(context.Background())
+	first, err := openPushRemote(ctx, &config.Config{})
+	if err != nil {
+		t.Fatalf("openPushRemote() error: %v", err)
+	}
+	second, err := openPushRemote(ctx, &config.Config{})
+	if err != nil {
+		t.Fatalf("openPushRemote() error: %v", err)
+	}
+
+	opts := confluence.PageListOptions{SpaceID: "space-1", Status: "current", Limit: 100}
+	for _, remote := range []syncflow.PushRemote{first, second} {
+		if _, err := remote.GetSpace(ctx, "ENG"); err != nil {
+			t.Fatalf("GetSpace() error: %v", err)
+		}
+		if _, err := remote.ListPages(ctx, opts); err != nil {
+			t.Fatalf("ListPages() error: %v", err)
+		}
+	}
+	if counting.getSpaceCalls != 1 || counting.listPagesCalls != 1 {
+		t.Fatalf("calls = GetSpace %d, ListPages %d; want one each across remotes sharing the cache", counting.getSpaceCalls, counting.listPagesCalls)
+	}
+
+	if _, err := second.UpdatePage(ctx, "1", confluence.PageUpsertInput{SpaceID: "space-1", Title: "Root", Version: 2}); err != nil {
+		t.Fatalf("UpdatePage() error: %v", err)
+	}
+	if _, err := first.ListPages(ctx, opts); err != nil {
+		t.Fatalf("ListPages() error: %v", err)
+	}
+	if counting.listPagesCalls != 2 {
+		t.Fatalf("ListPages calls after write = %d, want 2 (cache invalidated)", counting.listPagesCalls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := first.GetPage(ctx, "1"); err != nil {
+			t.Fatalf("GetPage() error: %v", err)
+		}
+	}
+	if counting.getPageCalls != 2 {
+		t.Fatalf("GetPage calls = %d, want every call to reach the remote", counting.getPageCalls)
+	}
+
+	if _, err := openPushRemote(context.Background(), &config.Config{}); err != nil {
+		t.Fatalf("openPushRemote() without cache error: %v", err)
+	}
+}
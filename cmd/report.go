@@ -22,6 +22,12 @@ type commandRunReport struct {
 	Success bool   `json:"success"`
 	Error   string `json:"error,omitempty"`
 
+	// NoChanges is true when a successful run changed nothing: no files were
+	// written or committed and no page was published.
+	NoChanges bool   `json:"no_changes"`
+	Commit    string `json:"commit,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+
 	Timing commandRunReportTiming `json:"timing"`
 	Target commandRunReportTarget `json:"target"`
 
@@ -107,6 +113,8 @@ type pushWorktreeOutcome struct {
 	Result             syncflow.PushResult
 	Warnings           []string
 	NoChanges          bool
+	Commit             string
+	Tag                string
 	ConflictResolution *commandRunReportConflictResolution
 }
 
@@ -157,6 +165,7 @@ func (r *commandRunReport) finalize(runErr error, finishedAt time.Time) {
 	r.Success = runErr == nil
 	if runErr != nil {
 		r.Error = runErr.Error()
+		r.NoChanges = false
 	}
 	r.Timing.FinishedAt = finishedAt.UTC().Format(time.RFC3339Nano)
 	r.Timing.DurationMs = finishedAt.Sub(parseReportTime(r.Timing.StartedAt)).Milliseconds()
@@ -273,6 +282,19 @@ func reportDiagnosticsFromPush(diags []syncflow.PushDiagnostic, spaceDir string)
 	return out
 }
 
+func reportDiagnosticsFromPushFailures(failures []syncflow.PushFailure, spaceDir string) []commandRunReportDiagnostic {
+	out := make([]commandRunReportDiagnostic, 0, len(failures))
+	for _, failure := range failures {
+		out = append(out, commandRunReportDiagnostic{
+			Path:           reportRelativePath(spaceDir, failure.Path),
+			Code:           "PUSH_PAGE_FAILED",
+			Message:        strings.TrimSpace(failure.Err.Error()),
+			ActionRequired: true,
+		})
+	}
+	return out
+}
+
 func reportAttachmentOpsFromPull(result syncflow.PullResult, spaceDir string) []commandRunReportAttachmentOp {
 	out := make([]commandRunReportAttachmentOp, 0, len(result.DownloadedAssets)+len(result.DeletedAssets))
 	for _, path := range result.DownloadedAssets {
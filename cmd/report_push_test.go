@@ -85,6 +85,54 @@ func TestRunPush_ReportJSONSuccessIsStable(t *testing.T) {
 	if !containsRecoveryArtifact(report, "sync_branch", "cleaned_up") {
 		t.Fatalf("recovery artifacts = %+v, want cleaned-up sync branch", report.RecoveryArtifacts)
 	}
+	if report.NoChanges {
+		t.Fatal("no_changes = true for a push that published a page")
+	}
+	if head := strings.TrimSpace(runGitForTest(t, repo, "rev-parse", "HEAD")); report.Commit != head {
+		t.Fatalf("commit = %q, want HEAD %q", report.Commit, head)
+	}
+	if !strings.HasPrefix(report.Tag, "confluence-sync/push/ENG/") {
+		t.Fatalf("tag = %q, want a push sync tag", report.Tag)
+	}
+}
+
+func TestRunPush_ReportJSONMarksNoOpPush(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	setupEnv(t)
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPushRemote = oldPushFactory })
+
+	chdirRepo(t, spaceDir)
+
+	cmd := newPushCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(io.Discard)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"--report-json", "--on-conflict=cancel"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("push command failed: %v", err)
+	}
+
+	report := decodeCommandReportJSON(t, out.Bytes())
+	assertReportMetadata(t, report, "push", true)
+	if !report.NoChanges {
+		t.Fatalf("no_changes = false for a push without local changes: %+v", report)
+	}
+	if report.Commit != "" || report.Tag != "" {
+		t.Fatalf("commit = %q, tag = %q, want neither for a no-op push", report.Commit, report.Tag)
+	}
+	if len(report.MutatedPages) != 0 {
+		t.Fatalf("mutated pages = %+v, want none", report.MutatedPages)
+	}
 }
 
 func TestRunPush_ReportJSONFailureOnWorkspaceSyncStateIsStructured(t *testing.T) {
@@ -24,6 +24,10 @@ type commandReportJSON struct {
 	Success bool   `json:"success"`
 	Error   string `json:"error"`
 
+	NoChanges bool   `json:"no_changes"`
+	Commit    string `json:"commit"`
+	Tag       string `json:"tag"`
+
 	Timing struct {
 		StartedAt  string `json:"started_at"`
 		FinishedAt string `json:"finished_at"`
@@ -197,6 +201,15 @@ func TestRunPull_ReportJSONSuccessIsStable(t *testing.T) {
 	if !containsString(report.MutatedFiles, "Root.md") {
 		t.Fatalf("mutated files = %v, want Root.md", report.MutatedFiles)
 	}
+	if report.NoChanges {
+		t.Fatal("no_changes = true for a pull that committed changes")
+	}
+	if head := strings.TrimSpace(runGitForTest(t, repo, "rev-parse", "HEAD")); report.Commit != head {
+		t.Fatalf("commit = %q, want HEAD %q", report.Commit, head)
+	}
+	if !strings.HasPrefix(report.Tag, "confluence-sync/pull/ENG/") {
+		t.Fatalf("tag = %q, want a pull sync tag", report.Tag)
+	}
 	if !containsString(report.FallbackModes, "folder_lookup_unavailable") {
 		t.Fatalf("fallback modes = %v, want folder_lookup_unavailable", report.FallbackModes)
 	}
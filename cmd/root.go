@@ -17,16 +17,19 @@ import (
 
 // automation flags shared by pull and push.
 var (
-	Version               = "dev"
-	flagYes               bool
-	flagNonInteractive    bool
-	flagSkipMissingAssets bool
-	flagVerbose           bool
-	flagVersion           bool
-	flagRateLimitRPS      int
-	flagRetryMaxAttempts  int
-	flagRetryBaseDelay    time.Duration
-	flagRetryMaxDelay     time.Duration
+	Version                  = "dev"
+	flagYes                  bool
+	flagNonInteractive       bool
+	flagSkipMissingAssets    bool
+	flagVerbose              bool
+	flagLogFormat            = logFormatText
+	flagVersion              bool
+	flagRateLimitRPS         int
+	flagRetryMaxAttempts     int
+	flagRetryBaseDelay       time.Duration
+	flagRetryMaxDelay        time.Duration
+	flagInsecure             bool
+	flagConfluenceAPIVersion string
 )
 
 var rootCmd = &cobra.Command{
@@ -47,13 +50,14 @@ and converts Markdown back to ADF for publishing updates.`,
 
 // Execute runs the root command.
 func Execute() error {
-	return rootCmd.ExecuteContext(context.Background())
+	return ExecuteContext(context.Background())
 }
 
 // ExecuteContext runs the root command with the given context.
 // This enables graceful signal handling (SIGINT/SIGTERM) when called
 // with a signal-aware context.
 func ExecuteContext(ctx context.Context) error {
+	prepareJSONErrors(rootCmd, os.Args[1:])
 	return rootCmd.ExecuteContext(ctx)
 }
 
@@ -71,22 +75,29 @@ func init() {
 	lipgloss.SetHasDarkBackground(true)
 
 	rootCmd.PersistentFlags().BoolVarP(&flagVerbose, "verbose", "v", false, "Enable verbose output (log HTTP requests)")
+	rootCmd.PersistentFlags().StringVar(&flagLogFormat, "log-format", logFormatText, "Diagnostic log format on stderr: text or json")
 	rootCmd.PersistentFlags().IntVar(&flagRateLimitRPS, "rate-limit-rps", confluence.DefaultRateLimitRPS, "Confluence API request rate limit (requests/second)")
 	rootCmd.PersistentFlags().IntVar(&flagRetryMaxAttempts, "retry-max-attempts", confluence.DefaultRetryMaxAttempts, "Maximum retries for retryable Confluence API requests")
 	rootCmd.PersistentFlags().DurationVar(&flagRetryBaseDelay, "retry-base-delay", confluence.DefaultRetryBaseDelay, "Base retry delay for exponential backoff")
 	rootCmd.PersistentFlags().DurationVar(&flagRetryMaxDelay, "retry-max-delay", confluence.DefaultRetryMaxDelay, "Maximum retry delay")
+	rootCmd.PersistentFlags().BoolVar(&flagJSONErrors, "json-errors", false, "Write a failure to stderr as one JSON object with a machine-readable code (CONFLICT, AUTH_FAILED, RATE_LIMITED, NOT_FOUND, ...)")
+	rootCmd.PersistentFlags().BoolVar(&flagInsecure, "insecure", false, "Skip TLS certificate verification (unsafe; prefer ATLASSIAN_CA_BUNDLE)")
+	rootCmd.PersistentFlags().StringVar(&flagConfluenceAPIVersion, "confluence-api-version", "", "Confluence REST API for space and page calls: auto (v2, falling back to v1 when the instance has no v2 API), v2 or v1 (default from ATLASSIAN_API_VERSION, else auto)")
 	rootCmd.Flags().BoolVar(&flagVersion, "version", false, "Print conf version and exit")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		silenceCobraErrorsForJSON(cmd)
 		if err := applyHTTPPolicyEnvOverrides(cmd); err != nil {
 			return err
 		}
-
-		level := slog.LevelWarn
-		if flagVerbose {
-			level = slog.LevelDebug
+		if err := applyStateLocation(); err != nil {
+			return err
 		}
 
-		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+		handler, err := newLogHandler(os.Stderr, flagLogFormat, flagVerbose)
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(slog.New(handler))
 		slog.Debug("http policy",
 			"rate_limit_rps", flagRateLimitRPS,
 			"retry_max_attempts", flagRetryMaxAttempts,
@@ -108,7 +119,15 @@ func init() {
 		newRelinkCmd(),
 		newVersionCmd(),
 		newDoctorCmd(),
+		newWhoamiCmd(),
 		newSearchCmd(),
+		newListCmd(),
+		newTreeCmd(),
+		newArchiveCmd(),
+		newUnarchiveCmd(),
+		newMoveCmd(),
+		newStateCmd(),
+		newManifestCmd(),
 	)
 }
 
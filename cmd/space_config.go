@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// loadSpaceConfig reads the space's .cms-space.yaml (empty when absent) and
+// validates its ignore globs. Files parked under archived/ by `conf archive`
+// are always ignored.
+func loadSpaceConfig(spaceDir string) (config.SpaceConfig, error) {
+	spaceCfg, err := config.LoadSpaceConfig(spaceDir)
+	if err != nil {
+		return config.SpaceConfig{}, err
+	}
+	if err := validateRelPathGlobs(config.SpaceConfigFileName+" ignore", spaceCfg.Ignore); err != nil {
+		return config.SpaceConfig{}, err
+	}
+	spaceCfg.Ignore = append(spaceCfg.Ignore, archivedDirName+"/**")
+	return spaceCfg, nil
+}
+
+// loadSpaceFilenameMode returns the filename mode configured for spaceDir.
+func loadSpaceFilenameMode(spaceDir string) (fs.FilenameMode, error) {
+	spaceCfg, err := config.LoadSpaceConfig(spaceDir)
+	if err != nil {
+		return "", err
+	}
+	return fs.ParseFilenameMode(spaceCfg.FilenameMode)
+}
+
+// loadSpacePagePathLayout returns the page path layout configured for
+// spaceDir: its filename mode and whether names carry an order prefix.
+func loadSpacePagePathLayout(spaceDir string) (syncflow.PagePathLayout, error) {
+	spaceCfg, err := config.LoadSpaceConfig(spaceDir)
+	if err != nil {
+		return syncflow.PagePathLayout{}, err
+	}
+	mode, err := fs.ParseFilenameMode(spaceCfg.FilenameMode)
+	if err != nil {
+		return syncflow.PagePathLayout{}, err
+	}
+	return syncflow.PagePathLayout{FilenameMode: mode, OrderPrefix: spaceCfg.OrderPrefix}, nil
+}
+
+// flagWasSet reports whether the user passed the named flag explicitly.
+// Values from .cms-space.yaml only apply to flags left at their default.
+func flagWasSet(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flags().Lookup(name)
+	return flag != nil && flag.Changed
+}
+
+// matchesSpaceIgnore reports whether a space-relative path matches one of the
+// space config ignore patterns.
+func matchesSpaceIgnore(patterns []string, relPath string) bool {
+	return fs.MatchAnyRelPathGlob(patterns, relPath)
+}
+
+// resolvePushTitleConflictPolicy prefers --on-title-conflict, then the space
+// config, then the built-in default (fail).
+func resolvePushTitleConflictPolicy(cmd *cobra.Command, spaceCfg config.SpaceConfig) syncflow.PushTitleConflictPolicy {
+	if !flagWasSet(cmd, "on-title-conflict") && spaceCfg.OnTitleConflict != "" {
+		return syncflow.PushTitleConflictPolicy(spaceCfg.OnTitleConflict)
+	}
+	return syncflow.PushTitleConflictPolicy(flagPushOnTitleConflict)
+}
+
+// placeholderBodyADF turns push.placeholder_text into the body of pages
+// created ahead of their content. Empty keeps the built-in empty document.
+func placeholderBodyADF(text string) json.RawMessage {
+	if text == "" {
+		return nil
+	}
+	body, _ := json.Marshal(map[string]any{
+		"version": 1,
+		"type":    "doc",
+		"content": []any{map[string]any{
+			"type":    "paragraph",
+			"content": []any{map[string]any{"type": "text", "text": text}},
+		}},
+	})
+	return body
+}
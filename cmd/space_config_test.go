@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestFilterPushChangesByIgnore(t *testing.T) {
+	changes := []syncflow.PushFileChange{
+		{Type: syncflow.PushChangeModify, Path: "Guides/Setup.md"},
+		{Type: syncflow.PushChangeAdd, Path: "Drafts/Idea.md"},
+		{Type: syncflow.PushChangeAdd, Path: "Guides/scratch.md"},
+	}
+
+	got := filterPushChangesByIgnore(changes, []string{"Drafts/**", "**/scratch.md"})
+	if len(got) != 1 || got[0].Path != "Guides/Setup.md" {
+		t.Fatalf("filterPushChangesByIgnore() = %+v, want only Guides/Setup.md", got)
+	}
+}
+
+func TestResolvePushTitleConflictPolicy_FlagOverridesSpaceConfig(t *testing.T) {
+	spaceCfg := config.SpaceConfig{OnTitleConflict: "suffix"}
+
+	cmd := newPushCmd()
+	if got := resolvePushTitleConflictPolicy(cmd, spaceCfg); got != syncflow.PushTitleConflictSuffix {
+		t.Fatalf("policy without flag = %q, want space config value %q", got, syncflow.PushTitleConflictSuffix)
+	}
+
+	if err := cmd.Flags().Set("on-title-conflict", "fail"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	if got := resolvePushTitleConflictPolicy(cmd, spaceCfg); got != syncflow.PushTitleConflictFail {
+		t.Fatalf("policy with flag = %q, want %q", got, syncflow.PushTitleConflictFail)
+	}
+}
+
+func TestResolveValidateTargetContext_SkipsSpaceIgnoredFiles(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+	for _, relPath := range []string{"Root.md", "Drafts/Idea.md"} {
+		path := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("---\ntitle: Page\n---\nbody\n"), 0o600); err != nil {
+			t.Fatalf("write markdown: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("ignore:\n  - \"Drafts/**\"\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	chdirRepo(t, repo)
+
+	targetCtx, err := resolveValidateTargetContext(config.Target{Mode: config.TargetModeSpace, Value: "ENG"}, "")
+	if err != nil {
+		t.Fatalf("resolveValidateTargetContext() error: %v", err)
+	}
+	if len(targetCtx.files) != 1 || !strings.HasSuffix(filepath.ToSlash(targetCtx.files[0]), "ENG/Root.md") {
+		t.Fatalf("files = %v, want only Root.md", targetCtx.files)
+	}
+}
+
+func TestLoadSpaceConfig_RejectsInvalidIgnoreGlob(t *testing.T) {
+	spaceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("ignore:\n  - \"Drafts/[\"\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	if _, err := loadSpaceConfig(spaceDir); err == nil || !strings.Contains(err.Error(), "ignore") {
+		t.Fatalf("loadSpaceConfig() error = %v, want invalid ignore pattern", err)
+	}
+}
+
+func TestRunPush_PlaceholderTextFromSpaceConfig(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("push:\n  placeholder_text: \"Syncing…\"\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "new-page.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New page"},
+		Body:        "Fresh content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "add page")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() error: %v", err)
+	}
+
+	if len(fake.createInputs) != 1 {
+		t.Fatalf("create calls = %d, want 1", len(fake.createInputs))
+	}
+	if body := string(fake.createInputs[0].BodyADF); !strings.Contains(body, `"text":"Syncing…"`) {
+		t.Fatalf("placeholder body = %s, want the configured text", body)
+	}
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+// State drift kinds reported by `conf state verify`.
+const (
+	stateDriftMoved             = "moved"
+	stateDriftMissing           = "missing"
+	stateDriftIDMismatch        = "id-mismatch"
+	stateDriftUntracked         = "untracked"
+	stateDriftDuplicateID       = "duplicate-id"
+	stateDriftMissingAttachment = "missing-attachment"
+)
+
+var flagStateRepair = false
+
+// stateDrift is one disagreement between .confluence-state.json and the
+// files on disk.
+type stateDrift struct {
+	Kind    string
+	Path    string
+	NewPath string
+	PageID  string
+}
+
+func newStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Inspect and repair .confluence-state.json",
+	}
+	cmd.AddCommand(newStateVerifyCmd())
+	return cmd
+}
+
+func newStateVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [TARGET]",
+		Short: "Cross-check the state file against the Markdown and asset files on disk",
+		Long: `verify compares .confluence-state.json with the space directory and reports
+drift left behind by files moved, renamed or deleted outside conf:
+
+- moved:              an indexed page now lives at another path (same frontmatter id)
+- missing:            an indexed path is gone and its id is in no other file
+- id-mismatch:        the file at an indexed path carries a different id
+- untracked:          a file has a frontmatter id the index does not know
+- duplicate-id:       several files carry the same id (fix by hand)
+- missing-attachment: a tracked attachment file is gone
+
+--repair rewrites the page index from the frontmatter ids found on disk and
+drops tracked attachments whose files are missing, so the next pull does not
+turn the drift into unexpected moves or deletes. Remote content is not
+touched. Exits non-zero when drift is found and --repair is not set.
+
+TARGET follows the standard rule:
+- .md suffix  => file mode (space inferred from file)
+- otherwise   => space mode (SPACE_KEY or space directory).`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw string
+			if len(args) > 0 {
+				raw = args[0]
+			}
+			return runStateVerify(cmd, config.ParseTarget(raw))
+		},
+	}
+	cmd.Flags().BoolVar(&flagStateRepair, "repair", false, "Reconcile the page index with the frontmatter ids on disk and drop missing attachments")
+	return cmd
+}
+
+func runStateVerify(cmd *cobra.Command, target config.Target) error {
+	out := ensureSynchronizedCmdOutput(cmd)
+
+	initialCtx, err := resolveInitialPullContext(target)
+	if err != nil {
+		return err
+	}
+	spaceDir := initialCtx.spaceDir
+	if !dirExists(spaceDir) {
+		return fmt.Errorf("space directory not found: %s", spaceDir)
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	actual, err := scanStatePageIndex(spaceDir)
+	if err != nil {
+		return fmt.Errorf("scan local markdown: %w", err)
+	}
+	drift := detectStateDrift(spaceDir, state, actual)
+
+	_, _ = fmt.Fprintf(out, "State verify: %s (%s)\n", spaceDir, strings.TrimSpace(state.SpaceKey))
+	if len(drift) == 0 {
+		_, _ = fmt.Fprintln(out, "State matches the files on disk.")
+		return nil
+	}
+	_, _ = fmt.Fprintf(out, "\nFound %d drift issue(s):\n", len(drift))
+	printStateDrift(out, drift)
+
+	if !flagStateRepair {
+		return fmt.Errorf("state drift detected in %s: rerun with --repair to reconcile the index with the files on disk", spaceDir)
+	}
+
+	lock, err := acquireWorkspaceLock("state")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	manual := repairStateDrift(&state, actual, drift)
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "\nRepaired %d issue(s); the page index now tracks %d file(s).\n", len(drift)-manual, len(state.PagePathIndex))
+	if manual > 0 {
+		_, _ = fmt.Fprintf(out, "%d duplicate-id issue(s) need manual resolution: keep the id in one file only, then rerun.\n", manual)
+	}
+	return nil
+}
+
+// scanStatePageIndex returns path -> id for the Markdown files a pull would
+// track, leaving out sidecars and archived pages.
+func scanStatePageIndex(spaceDir string) (syncflow.PageIndex, error) {
+	index, err := syncflow.BuildPageIndex(spaceDir)
+	if err != nil {
+		return nil, err
+	}
+	for relPath := range index {
+		if fs.IsPageSidecar(relPath) || strings.HasPrefix(relPath, archivedDirName+"/") {
+			delete(index, relPath)
+		}
+	}
+	return index, nil
+}
+
+// detectStateDrift compares the state's indexes with actual, the path -> id
+// map built from the frontmatter of the files on disk.
+func detectStateDrift(spaceDir string, state fs.SpaceState, actual syncflow.PageIndex) []stateDrift {
+	pathsByID := map[string][]string{}
+	for relPath, pageID := range actual {
+		pathsByID[pageID] = append(pathsByID[pageID], relPath)
+	}
+	for _, paths := range pathsByID {
+		sort.Strings(paths)
+	}
+
+	drift := make([]stateDrift, 0)
+	indexedIDs := map[string]struct{}{}
+	for relPath, pageID := range state.PagePathIndex {
+		relPath = normalizeRepoRelPath(relPath)
+		pageID = strings.TrimSpace(pageID)
+		if pageID == "" {
+			continue
+		}
+		indexedIDs[pageID] = struct{}{}
+
+		fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath)))
+		switch {
+		case err == nil && strings.TrimSpace(fm.ID) == pageID:
+			continue
+		case err == nil:
+			drift = append(drift, stateDrift{Kind: stateDriftIDMismatch, Path: relPath, PageID: pageID})
+		case len(pathsByID[pageID]) > 0:
+			drift = append(drift, stateDrift{Kind: stateDriftMoved, Path: relPath, NewPath: pathsByID[pageID][0], PageID: pageID})
+		default:
+			drift = append(drift, stateDrift{Kind: stateDriftMissing, Path: relPath, PageID: pageID})
+		}
+	}
+
+	for pageID, paths := range pathsByID {
+		if len(paths) > 1 {
+			drift = append(drift, stateDrift{Kind: stateDriftDuplicateID, Path: strings.Join(paths, ", "), PageID: pageID})
+			continue
+		}
+		if _, ok := indexedIDs[pageID]; !ok {
+			drift = append(drift, stateDrift{Kind: stateDriftUntracked, Path: paths[0], PageID: pageID})
+		}
+	}
+
+	for relPath, attachmentID := range state.AttachmentIndex {
+		relPath = normalizeRepoRelPath(relPath)
+		if _, err := os.Stat(filepath.Join(spaceDir, filepath.FromSlash(relPath))); os.IsNotExist(err) {
+			drift = append(drift, stateDrift{Kind: stateDriftMissingAttachment, Path: relPath, PageID: strings.TrimSpace(attachmentID)})
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Kind != drift[j].Kind {
+			return drift[i].Kind < drift[j].Kind
+		}
+		return drift[i].Path < drift[j].Path
+	})
+	return drift
+}
+
+func printStateDrift(out io.Writer, drift []stateDrift) {
+	for _, item := range drift {
+		switch item.Kind {
+		case stateDriftMoved:
+			_, _ = fmt.Fprintf(out, "  [%s] %s -> %s (id=%s)\n", item.Kind, item.Path, item.NewPath, item.PageID)
+		case stateDriftIDMismatch:
+			_, _ = fmt.Fprintf(out, "  [%s] %s: state has id=%s but the file has a different id\n", item.Kind, item.Path, item.PageID)
+		case stateDriftMissingAttachment:
+			_, _ = fmt.Fprintf(out, "  [%s] %s (attachment id=%s)\n", item.Kind, item.Path, item.PageID)
+		default:
+			_, _ = fmt.Fprintf(out, "  [%s] %s (id=%s)\n", item.Kind, item.Path, item.PageID)
+		}
+	}
+}
+
+// repairStateDrift rebuilds the page index from actual and drops missing
+// attachments. Pages whose id appears in several files keep their current
+// entry when it is still valid and are otherwise left out; the number of such
+// issues is returned.
+func repairStateDrift(state *fs.SpaceState, actual syncflow.PageIndex, drift []stateDrift) int {
+	duplicates := map[string]struct{}{}
+	manual := 0
+	for _, item := range drift {
+		switch item.Kind {
+		case stateDriftDuplicateID:
+			duplicates[item.PageID] = struct{}{}
+			manual++
+		case stateDriftMissingAttachment:
+			for relPath := range state.AttachmentIndex {
+				if normalizeRepoRelPath(relPath) == item.Path {
+					delete(state.AttachmentIndex, relPath)
+				}
+			}
+		}
+	}
+
+	index := make(map[string]string, len(actual))
+	for relPath, pageID := range actual {
+		if _, duplicated := duplicates[pageID]; duplicated {
+			continue
+		}
+		index[relPath] = pageID
+	}
+	for relPath, pageID := range state.PagePathIndex {
+		relPath = normalizeRepoRelPath(relPath)
+		if _, duplicated := duplicates[pageID]; duplicated && actual[relPath] == pageID {
+			index[relPath] = pageID
+		}
+	}
+	state.PagePathIndex = index
+	return manual
+}
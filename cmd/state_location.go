@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// stateLocationEnv overrides the .conf.yaml state.location setting.
+const stateLocationEnv = "CONF_STATE_LOCATION"
+
+// applyStateLocation selects where every command reads and writes space
+// state: CONF_STATE_LOCATION, else state.location in the repository's
+// .conf.yaml, else the space directory.
+func applyStateLocation() error {
+	source := stateLocationEnv
+	raw := strings.TrimSpace(os.Getenv(stateLocationEnv))
+	if raw == "" {
+		repoRoot, err := gitRepoRoot()
+		if err != nil {
+			fs.SetStateLocation(fs.StateLocationSpace)
+			return nil
+		}
+		source = ".conf.yaml state.location"
+		raw, err = config.LoadStateLocation(repoRoot)
+		if err != nil {
+			return fmt.Errorf("load .conf.yaml: %w", err)
+		}
+	}
+
+	location, err := fs.ParseStateLocation(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	fs.SetStateLocation(location)
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyStateLocation_ReadsEnvThenRepoConfig(t *testing.T) {
+	runParallelCommandTest(t)
+	previous := fs.CurrentStateLocation()
+	t.Cleanup(func() { fs.SetStateLocation(previous) })
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	chdirRepo(t, repo)
+	if err := os.WriteFile(filepath.Join(repo, ".conf.yaml"), []byte("state:\n  location: git\n"), 0o600); err != nil {
+		t.Fatalf("write .conf.yaml: %v", err)
+	}
+
+	t.Setenv(stateLocationEnv, "")
+	if err := applyStateLocation(); err != nil {
+		t.Fatalf("applyStateLocation() error: %v", err)
+	}
+	if got := fs.CurrentStateLocation(); got != fs.StateLocationGit {
+		t.Fatalf("state location = %q, want git from .conf.yaml", got)
+	}
+
+	t.Setenv(stateLocationEnv, "space")
+	if err := applyStateLocation(); err != nil {
+		t.Fatalf("applyStateLocation() error: %v", err)
+	}
+	if got := fs.CurrentStateLocation(); got != fs.StateLocationSpace {
+		t.Fatalf("state location = %q, want space from %s", got, stateLocationEnv)
+	}
+
+	t.Setenv(stateLocationEnv, "elsewhere")
+	if err := applyStateLocation(); err == nil || !strings.Contains(err.Error(), stateLocationEnv) {
+		t.Fatalf("expected invalid %s error, got %v", stateLocationEnv, err)
+	}
+}
+
+func TestRunPull_GitStateLocationKeepsStateOutOfSpaceDirectory(t *testing.T) {
+	runParallelCommandTest(t)
+	previous := fs.CurrentStateLocation()
+	fs.SetStateLocation(fs.StateLocationGit)
+	t.Cleanup(func() { fs.SetStateLocation(previous) })
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 1, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 1, LastModified: modified, BodyADF: rawJSON(t, simpleADF("body"))},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newPullRemote
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newPullRemote = oldFactory })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runPull() error: %v", err)
+	}
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if _, err := os.Stat(filepath.Join(spaceDir, fs.StateFileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected no state file in the space directory, stat error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".git", "cms-state", "Engineering (ENG)", fs.StateFileName)); err != nil {
+		t.Fatalf("expected state under .git/cms-state: %v", err)
+	}
+	state, err := fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if state.SpaceKey != "ENG" || state.PagePathIndex["Root.md"] != "1" {
+		t.Fatalf("state = %+v, want ENG with Root.md tracked", state)
+	}
+	if tracked := runGitForTest(t, repo, "ls-files", "Engineering (ENG)"); strings.Contains(tracked, fs.StateFileName) {
+		t.Fatalf("state file was committed:\n%s", tracked)
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestRunStateVerify_ReportsAndRepairsDrift(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	chdirRepo(t, repo)
+
+	spaceDir := filepath.Join(repo, "ENG")
+	// Moved by hand from Old.md; Gone.md deleted; New.md never pulled.
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Moved.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Moved", ID: "1", Version: 1},
+		Body:        "moved\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "New.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New", ID: "3", Version: 1},
+		Body:        "new\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "archived", "Parked.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Parked", ID: "9", Version: 1},
+		Body:        "parked\n",
+	})
+	state := fs.NewSpaceState()
+	state.SpaceKey = "ENG"
+	state.PagePathIndex = map[string]string{"Old.md": "1", "Gone.md": "2"}
+	state.AttachmentIndex = map[string]string{"assets/1/att-1-diagram.png": "att-1"}
+	if err := fs.SaveState(spaceDir, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	cmd := newStateVerifyCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	err := runStateVerify(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"})
+	if err == nil || !strings.Contains(err.Error(), "--repair") {
+		t.Fatalf("runStateVerify() error = %v, want drift error", err)
+	}
+	for _, want := range []string{
+		"[moved] Old.md -> Guides/Moved.md (id=1)",
+		"[missing] Gone.md (id=2)",
+		"[untracked] New.md (id=3)",
+		"[missing-attachment] assets/1/att-1-diagram.png",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Parked.md") {
+		t.Fatalf("archived page reported as drift:\n%s", out.String())
+	}
+
+	cmd = newStateVerifyCmd()
+	flagStateRepair = true
+	t.Cleanup(func() { flagStateRepair = false })
+	out.Reset()
+	cmd.SetOut(out)
+	if err := runStateVerify(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runStateVerify(--repair) error: %v\n%s", err, out.String())
+	}
+	repaired, err := fs.LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if want := map[string]string{"Guides/Moved.md": "1", "New.md": "3"}; !reflect.DeepEqual(repaired.PagePathIndex, want) {
+		t.Fatalf("page index = %v, want %v", repaired.PagePathIndex, want)
+	}
+	if len(repaired.AttachmentIndex) != 0 {
+		t.Fatalf("attachment index = %v, want empty", repaired.AttachmentIndex)
+	}
+
+	cmd = newStateVerifyCmd()
+	flagStateRepair = false
+	out.Reset()
+	cmd.SetOut(out)
+	if err := runStateVerify(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("verify after repair error: %v\n%s", err, out.String())
+	}
+}
+
+func TestDetectStateDrift_DuplicateIDNeedsManualRepair(t *testing.T) {
+	spaceDir := t.TempDir()
+	for _, name := range []string{"A.md", "Copy of A.md"} {
+		writeMarkdown(t, filepath.Join(spaceDir, name), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: "A", ID: "5", Version: 1},
+			Body:        "a\n",
+		})
+	}
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"A.md": "5"}
+
+	actual, err := scanStatePageIndex(spaceDir)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	drift := detectStateDrift(spaceDir, state, actual)
+	if len(drift) != 1 || drift[0].Kind != stateDriftDuplicateID {
+		t.Fatalf("drift = %+v", drift)
+	}
+	if manual := repairStateDrift(&state, actual, drift); manual != 1 {
+		t.Fatalf("manual = %d, want 1", manual)
+	}
+	if want := map[string]string{"A.md": "5"}; !reflect.DeepEqual(state.PagePathIndex, want) {
+		t.Fatalf("page index = %v, want %v", state.PagePathIndex, want)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Copy of A.md")); err != nil {
+		t.Fatalf("repair must not touch files: %v", err)
+	}
+}
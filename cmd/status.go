@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,20 +30,28 @@ type StatusRemote interface {
 
 // StatusReport contains the results of a sync drift inspection.
 type StatusReport struct {
-	LocalAdded              []string
-	LocalModified           []string
-	LocalDeleted            []string
-	RemoteAdded             []string
-	RemoteModified          []string
-	RemoteDeleted           []string
-	PlannedPathMoves        []syncflow.PlannedPagePathMove
-	ConflictAhead           []string // pages that are both locally modified AND ahead on remote
-	MaxVersionDrift         int
-	LocalAttachmentAdded    []string
-	LocalAttachmentDeleted  []string
-	RemoteAttachmentAdded   []string
-	RemoteAttachmentDeleted []string
-	OrphanedLocalAssets     []string
+	LocalAdded              []string                       `json:"local_added"`
+	LocalModified           []string                       `json:"local_modified"`
+	LocalDeleted            []string                       `json:"local_deleted"`
+	RemoteAdded             []string                       `json:"remote_added"`
+	RemoteModified          []string                       `json:"remote_modified"`
+	RemoteDeleted           []string                       `json:"remote_deleted"`
+	PlannedPathMoves        []syncflow.PlannedPagePathMove `json:"planned_path_moves"`
+	ConflictAhead           []string                       `json:"conflict_ahead"` // pages that are both locally modified AND ahead on remote
+	MaxVersionDrift         int                            `json:"max_version_drift"`
+	LocalAttachmentAdded    []string                       `json:"local_attachment_added,omitempty"`
+	LocalAttachmentDeleted  []string                       `json:"local_attachment_deleted,omitempty"`
+	RemoteAttachmentAdded   []string                       `json:"remote_attachment_added,omitempty"`
+	RemoteAttachmentDeleted []string                       `json:"remote_attachment_deleted,omitempty"`
+	OrphanedLocalAssets     []string                       `json:"orphaned_local_assets,omitempty"`
+	Pages                   []StatusPage                   `json:"pages"`
+}
+
+// statusJSONOutput is the document printed by status --json.
+type statusJSONOutput struct {
+	SpaceKey  string `json:"space_key"`
+	Directory string `json:"directory"`
+	StatusReport
 }
 
 const statusScopeNote = "Scope: markdown/page drift by default. Use `conf status --attachments` to inspect local and remote attachment drift from the same command."
@@ -62,6 +71,11 @@ func newStatusCmd() *cobra.Command {
 
 Status scope defaults to markdown/page drift. Add ` + "`--attachments`" + ` to include local and remote attachment drift plus orphaned local asset files.
 
+A table lists each page with something to sync: its local and remote
+version, changes since the last sync on either side, uncommitted git changes
+and moves the next pull would make. --json prints the full report, with a
+line for every page, for scripting.
+
 TARGET follows the standard rule:
 - .md suffix => file mode (space inferred from file)
 - otherwise => space mode (SPACE_KEY or space directory).`,
@@ -75,6 +89,7 @@ TARGET follows the standard rule:
 		},
 	}
 	cmd.Flags().BoolVar(&flagStatusAttachments, "attachments", false, "Include attachment drift and orphaned local asset inspection")
+	cmd.Flags().BoolVar(&flagStatusJSON, "json", false, "Print the status report, including every page, as JSON")
 
 	return cmd
 }
@@ -141,6 +156,12 @@ func runStatus(cmd *cobra.Command, target config.Target) error {
 		return err
 	}
 
+	if flagStatusJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statusJSONOutput{SpaceKey: spaceKey, Directory: initialCtx.spaceDir, StatusReport: report})
+	}
+
 	_, _ = fmt.Fprintf(out, "Space: %s\n", spaceKey)
 	_, _ = fmt.Fprintf(out, "Directory: %s\n", initialCtx.spaceDir)
 	_, _ = fmt.Fprintf(out, "Note: %s\n", statusScopeNote)
@@ -148,6 +169,7 @@ func runStatus(cmd *cobra.Command, target config.Target) error {
 	printStatusSection(out, "Local not pushed", report.LocalAdded, report.LocalModified, report.LocalDeleted)
 	printStatusSection(out, "Remote not pulled", report.RemoteAdded, report.RemoteModified, report.RemoteDeleted)
 	printPlannedPathMoves(out, report.PlannedPathMoves)
+	printStatusPages(out, report.Pages)
 
 	if len(report.ConflictAhead) > 0 {
 		_, _ = fmt.Fprintf(out, "\nConflict ahead (%d) — locally modified AND remote is ahead:\n", len(report.ConflictAhead))
@@ -225,6 +247,8 @@ func buildStatusReport(
 	}
 
 	remoteByID := make(map[string]confluence.Page, len(remotePages))
+	remoteVersionByID := make(map[string]int, len(remotePages))
+	untrackedRemote := make([]StatusPage, 0)
 	remoteAdded := make([]string, 0)
 	remoteModified := make([]string, 0)
 	maxVersionDrift := 0
@@ -235,11 +259,18 @@ func buildStatusReport(
 			continue
 		}
 		remoteByID[pageID] = page
+		remoteVersionByID[pageID] = page.Version
 
 		trackedPath, tracked := pathByID[pageID]
 		if !tracked {
 			if targetRelPath == "" {
 				remoteAdded = append(remoteAdded, fmt.Sprintf("%s (id=%s)", strings.TrimSpace(page.Title), pageID))
+				untrackedRemote = append(untrackedRemote, StatusPage{
+					PageID:        pageID,
+					Title:         strings.TrimSpace(page.Title),
+					RemoteVersion: page.Version,
+					RemoteChange:  "added",
+				})
 			}
 			continue
 		}
@@ -282,7 +313,11 @@ func buildStatusReport(
 	if err != nil {
 		return StatusReport{}, fmt.Errorf("resolve folder hierarchy: %w", err)
 	}
-	_, plannedPathByID := syncflow.PlanPagePaths(initialCtx.spaceDir, state.PagePathIndex, remotePages, folderByID)
+	pathLayout, err := loadSpacePagePathLayout(initialCtx.spaceDir)
+	if err != nil {
+		return StatusReport{}, err
+	}
+	_, plannedPathByID := syncflow.PlanPagePaths(initialCtx.spaceDir, state.PagePathIndex, remotePages, folderByID, pathLayout)
 	plannedPathMoves := syncflow.PlannedPagePathMoves(state.PagePathIndex, plannedPathByID)
 	if targetRelPath != "" {
 		filteredMoves := make([]syncflow.PlannedPagePathMove, 0, len(plannedPathMoves))
@@ -294,6 +329,27 @@ func buildStatusReport(
 		plannedPathMoves = filteredMoves
 	}
 
+	uncommittedByPath, err := listUncommittedStatusPaths(initialCtx.spaceDir)
+	if err != nil {
+		return StatusReport{}, err
+	}
+	sort.Slice(untrackedRemote, func(i, j int) bool { return untrackedRemote[i].Title < untrackedRemote[j].Title })
+	pages := buildStatusPages(statusPageInputs{
+		spaceDir:          initialCtx.spaceDir,
+		trackedPathByID:   trackedPathByID,
+		localVersionByID:  localVersionByID,
+		remoteVersionByID: remoteVersionByID,
+		untrackedRemote:   untrackedRemote,
+		localAdded:        localAdded,
+		localModified:     localModified,
+		localDeleted:      localDeleted,
+		remoteModified:    remoteModified,
+		remoteDeleted:     remoteDeleted,
+		plannedPathMoves:  plannedPathMoves,
+		uncommittedByPath: uncommittedByPath,
+		targetRelPath:     targetRelPath,
+	})
+
 	// ConflictAhead = pages that are BOTH locally modified AND ahead on remote.
 	conflictAhead := computeConflictAhead(localModified, remoteModified)
 	sort.Strings(conflictAhead)
@@ -335,6 +391,7 @@ func buildStatusReport(
 		RemoteAttachmentAdded:   remoteAttachmentAdded,
 		RemoteAttachmentDeleted: remoteAttachmentDeleted,
 		OrphanedLocalAssets:     orphanedLocalAssets,
+		Pages:                   pages,
 	}, nil
 }
 
@@ -355,7 +412,7 @@ func collectAttachmentStatus(
 	trackedStateByPageID := map[string]map[string]string{}
 	attachmentIDToPath := map[string]string{}
 	for relPath, attachmentID := range state.AttachmentIndex {
-		pageID := pageIDFromAttachmentPath(relPath)
+		pageID := attachmentOwnerPageID(state, relPath)
 		if pageID == "" {
 			continue
 		}
@@ -399,7 +456,7 @@ func collectAttachmentStatus(
 			continue
 		}
 		for _, assetPath := range referencedPaths {
-			plannedKey := normalizeRepoRelPath(filepath.ToSlash(filepath.Join("assets", pageID, filepath.Base(assetPath))))
+			plannedKey := normalizeRepoRelPath(syncflow.PageAssetDir(state.AssetLayout, pageID, relPath) + "/" + filepath.Base(assetPath))
 			referencedByPage[pageID][plannedKey] = struct{}{}
 			if strings.TrimSpace(state.AttachmentIndex[plannedKey]) == "" {
 				localAdded[plannedKey] = struct{}{}
@@ -439,7 +496,8 @@ func collectAttachmentStatus(
 			}
 			remoteIDs[attachmentID] = attachment
 			if _, tracked := attachmentIDToPath[attachmentID]; !tracked {
-				remoteAdded[normalizeRepoRelPath(filepath.ToSlash(filepath.Join("assets", pageID, attachmentID+"-"+strings.TrimSpace(attachment.Filename))))] = struct{}{}
+				assetDir := syncflow.PageAssetDir(state.AssetLayout, pageID, trackedPathByID[pageID])
+				remoteAdded[normalizeRepoRelPath(assetDir+"/"+attachmentID+"-"+strings.TrimSpace(attachment.Filename))] = struct{}{}
 			}
 		}
 		for relPath, attachmentID := range trackedState {
@@ -548,7 +606,7 @@ func collectLocalStatusChanges(target config.Target, spaceDir, spaceKey string)
 		return nil, nil, nil, fmt.Errorf("resolve change scope: %w", err)
 	}
 
-	changes, err := collectPushChangesForTarget(client, baselineRef, target, spaceScopePath, changeScopePath)
+	changes, err := collectPushChangesForTarget(client, baselineRef, target, spaceDir, spaceScopePath, changeScopePath)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("collect local changes: %w", err)
 	}
@@ -630,3 +688,16 @@ func computeConflictAhead(localModified, remoteModified []string) []string {
 	}
 	return result
 }
+
+// attachmentOwnerPageID returns the page owning a tracked attachment path in
+// either asset layout; a flattened page asset directory shares its path with
+// the page's Markdown file minus the extension.
+func attachmentOwnerPageID(state fs.SpaceState, relPath string) string {
+	if pageID := pageIDFromAttachmentPath(relPath); pageID != "" {
+		return pageID
+	}
+	if state.AssetLayout != fs.AssetLayoutFlatten {
+		return ""
+	}
+	return strings.TrimSpace(state.PagePathIndex[filepath.ToSlash(filepath.Dir(normalizeRepoRelPath(relPath)))+".md"])
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+)
+
+var flagStatusJSON bool
+
+// StatusPage is the per-page line of a status report.
+type StatusPage struct {
+	// Path is the space-relative Markdown path; empty for a remote page that
+	// has never been pulled.
+	Path    string `json:"path,omitempty"`
+	PageID  string `json:"page_id,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Tracked bool   `json:"tracked"`
+	// LocalVersion is the frontmatter version, RemoteVersion the version on
+	// Confluence; zero when the side has no copy of the page.
+	LocalVersion  int `json:"local_version,omitempty"`
+	RemoteVersion int `json:"remote_version,omitempty"`
+	// LocalChange is added, modified or deleted relative to the last sync.
+	LocalChange string `json:"local_change,omitempty"`
+	// Uncommitted reports uncommitted git changes to the Markdown file.
+	Uncommitted bool `json:"uncommitted"`
+	// RemoteChange is added, modified, moved or deleted on Confluence
+	// relative to the local copy.
+	RemoteChange string `json:"remote_change,omitempty"`
+	// PlannedPath is where the next pull would move the file.
+	PlannedPath string `json:"planned_path,omitempty"`
+}
+
+// inSync reports whether neither side has anything to sync for the page.
+func (p StatusPage) inSync() bool {
+	return p.Tracked && p.LocalChange == "" && !p.Uncommitted && p.RemoteChange == ""
+}
+
+// statusPageInputs carries the already computed drift that buildStatusPages
+// folds into one line per page.
+type statusPageInputs struct {
+	spaceDir          string
+	trackedPathByID   map[string]string
+	localVersionByID  map[string]int
+	remoteVersionByID map[string]int
+	untrackedRemote   []StatusPage
+	localAdded        []string
+	localModified     []string
+	localDeleted      []string
+	remoteModified    []string
+	remoteDeleted     []string
+	plannedPathMoves  []syncflow.PlannedPagePathMove
+	uncommittedByPath map[string]struct{}
+	targetRelPath     string
+}
+
+// buildStatusPages returns one StatusPage per tracked page, per local
+// Markdown file not yet pushed and per remote page not yet pulled, ordered
+// by path with unpulled remote pages last.
+func buildStatusPages(in statusPageInputs) []StatusPage {
+	localChangeByPath := map[string]string{}
+	for _, relPath := range in.localAdded {
+		localChangeByPath[relPath] = "added"
+	}
+	for _, relPath := range in.localModified {
+		localChangeByPath[relPath] = "modified"
+	}
+	for _, relPath := range in.localDeleted {
+		localChangeByPath[relPath] = "deleted"
+	}
+	remoteChangeByPath := map[string]string{}
+	for _, relPath := range in.remoteModified {
+		remoteChangeByPath[relPath] = "modified"
+	}
+	for _, relPath := range in.remoteDeleted {
+		remoteChangeByPath[relPath] = "deleted"
+	}
+	plannedPathByID := map[string]string{}
+	for _, move := range in.plannedPathMoves {
+		plannedPathByID[move.PageID] = move.PlannedPath
+	}
+
+	pages := make([]StatusPage, 0, len(in.trackedPathByID)+len(in.untrackedRemote))
+	trackedPaths := map[string]struct{}{}
+	for pageID, relPath := range in.trackedPathByID {
+		trackedPaths[relPath] = struct{}{}
+		page := StatusPage{
+			Path:          relPath,
+			PageID:        pageID,
+			Tracked:       true,
+			LocalVersion:  in.localVersionByID[pageID],
+			RemoteVersion: in.remoteVersionByID[pageID],
+			LocalChange:   localChangeByPath[relPath],
+			RemoteChange:  remoteChangeByPath[relPath],
+			PlannedPath:   plannedPathByID[pageID],
+		}
+		if _, dirty := in.uncommittedByPath[relPath]; dirty {
+			page.Uncommitted = true
+		}
+		if page.RemoteChange == "" && page.PlannedPath != "" {
+			page.RemoteChange = "moved"
+		}
+		pages = append(pages, page)
+	}
+
+	// Local files the state does not know yet: new pages, or files whose
+	// page was created by a push not yet followed by a pull.
+	localOnly := map[string]struct{}{}
+	for _, relPath := range in.localAdded {
+		localOnly[relPath] = struct{}{}
+	}
+	for relPath := range in.uncommittedByPath {
+		if in.targetRelPath == "" || relPath == in.targetRelPath {
+			localOnly[relPath] = struct{}{}
+		}
+	}
+	for relPath := range localOnly {
+		if _, tracked := trackedPaths[relPath]; tracked || fs.IsPageSidecar(relPath) {
+			continue
+		}
+		page := StatusPage{
+			Path:        relPath,
+			LocalChange: localChangeByPath[relPath],
+		}
+		if _, dirty := in.uncommittedByPath[relPath]; dirty {
+			page.Uncommitted = true
+		}
+		if fm, err := fs.ReadFrontmatter(filepath.Join(in.spaceDir, filepath.FromSlash(relPath))); err == nil {
+			page.PageID = strings.TrimSpace(fm.ID)
+			page.LocalVersion = fm.Version
+			page.Title = strings.TrimSpace(fm.Title)
+		} else if page.LocalChange == "" {
+			// Deleted from disk and never synced: nothing to show.
+			continue
+		}
+		pages = append(pages, page)
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Path < pages[j].Path })
+	pages = append(pages, in.untrackedRemote...)
+	return pages
+}
+
+// printStatusPages prints the pages that have something to sync as a table.
+func printStatusPages(out io.Writer, pages []StatusPage) {
+	rows := [][]string{{"PATH", "ID", "LOCAL", "REMOTE", "CHANGES"}}
+	inSync := 0
+	for _, page := range pages {
+		if page.inSync() {
+			inSync++
+			continue
+		}
+		path := page.Path
+		if path == "" {
+			path = "(not pulled) " + page.Title
+		}
+		pageID := page.PageID
+		if pageID == "" {
+			pageID = "-"
+		}
+		rows = append(rows, []string{
+			path,
+			pageID,
+			statusVersionCell(page.LocalVersion),
+			statusVersionCell(page.RemoteVersion),
+			statusChangesCell(page),
+		})
+	}
+
+	_, _ = fmt.Fprintf(out, "\nPages (%d to sync, %d in sync):\n", len(rows)-1, inSync)
+	if len(rows) == 1 {
+		return
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	for _, row := range rows {
+		var line strings.Builder
+		line.WriteString("  ")
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+				break
+			}
+			line.WriteString(cell)
+			line.WriteString(strings.Repeat(" ", widths[i]-len([]rune(cell))+2))
+		}
+		_, _ = fmt.Fprintln(out, strings.TrimRight(line.String(), " "))
+	}
+}
+
+func statusVersionCell(version int) string {
+	if version <= 0 {
+		return "-"
+	}
+	return "v" + strconv.Itoa(version)
+}
+
+func statusChangesCell(page StatusPage) string {
+	var changes []string
+	if !page.Tracked && page.Path != "" {
+		changes = append(changes, "untracked")
+	}
+	if page.LocalChange != "" {
+		changes = append(changes, "local "+page.LocalChange)
+	}
+	if page.Uncommitted {
+		changes = append(changes, "uncommitted")
+	}
+	if page.RemoteChange != "" {
+		change := "remote " + page.RemoteChange
+		if page.PlannedPath != "" {
+			change += " -> " + page.PlannedPath
+		}
+		changes = append(changes, change)
+	}
+	return strings.Join(changes, ", ")
+}
+
+// listUncommittedStatusPaths returns the space-relative Markdown paths under
+// spaceDir with uncommitted git changes, including untracked files.
+func listUncommittedStatusPaths(spaceDir string) (map[string]struct{}, error) {
+	repoRoot, err := gitRepoRoot()
+	if err != nil {
+		return nil, err
+	}
+	scopePath, err := gitScopePathFromPath(spaceDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve git scope for %s: %w", spaceDir, err)
+	}
+	dirty, err := listDirtyMarkdownPathsForScope(repoRoot, scopePath)
+	if err != nil {
+		return nil, fmt.Errorf("list uncommitted changes: %w", err)
+	}
+	return dirty, nil
+}
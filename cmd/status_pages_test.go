@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestRunStatus_ReportsPerPageStateAsTableAndJSON(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	chdirRepo(t, repo)
+	setupEnv(t)
+
+	spaceDir := filepath.Join(repo, "TEST")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "Behind.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Behind", ID: "1", Version: 1},
+		Body:        "behind\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Edited.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Edited", ID: "2", Version: 4},
+		Body:        "edited\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Clean.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Clean", ID: "3", Version: 2},
+		Body:        "clean\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey: "TEST",
+		PagePathIndex: map[string]string{
+			"Behind.md": "1",
+			"Edited.md": "2",
+			"Clean.md":  "3",
+		},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	runGitForStatus(t, repo, "add", ".")
+	runGitForStatus(t, repo, "commit", "-m", "baseline")
+	runGitForStatus(t, repo, "tag", "-a", "confluence-sync/pull/TEST/"+time.Now().UTC().Format("20060102T150405Z"), "-m", "pull")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Edited.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Edited", ID: "2", Version: 4},
+		Body:        "edited locally\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "New.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New"},
+		Body:        "new\n",
+	})
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	remotePages := []confluence.Page{
+		{ID: "1", SpaceID: "space-1", Title: "Behind", Version: 3, LastModified: modifiedAt, Status: "current"},
+		{ID: "2", SpaceID: "space-1", Title: "Edited", Version: 4, LastModified: modifiedAt, Status: "current"},
+		{ID: "3", SpaceID: "space-1", Title: "Clean", Version: 2, LastModified: modifiedAt, Status: "current"},
+		{ID: "9", SpaceID: "space-1", Title: "Remote Only", Version: 1, LastModified: modifiedAt, Status: "current"},
+	}
+	pagesByID := map[string]confluence.Page{}
+	for _, page := range remotePages {
+		pagesByID[page.ID] = page
+	}
+	fake := &cmdFakePullRemote{
+		space:     confluence.Space{ID: "space-1", Key: "TEST", Name: "Test Space"},
+		pages:     remotePages,
+		pagesByID: pagesByID,
+	}
+	oldNewStatusRemote := newStatusRemote
+	newStatusRemote = func(*config.Config) (StatusRemote, error) { return fake, nil }
+	oldJSON := flagStatusJSON
+	t.Cleanup(func() {
+		newStatusRemote = oldNewStatusRemote
+		flagStatusJSON = oldJSON
+	})
+
+	target := config.Target{Value: "TEST", Mode: config.TargetModeSpace}
+	cmd := newStatusCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runStatus(cmd, target); err != nil {
+		t.Fatalf("runStatus() error: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"Pages (4 to sync, 1 in sync):",
+		"Behind.md",
+		"remote modified",
+		"uncommitted",
+		"untracked, local added, uncommitted",
+		"(not pulled) Remote Only",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("status output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Clean.md") {
+		t.Fatalf("status table should leave out pages in sync:\n%s", text)
+	}
+
+	flagStatusJSON = true
+	out.Reset()
+	if err := runStatus(cmd, target); err != nil {
+		t.Fatalf("runStatus(--json) error: %v", err)
+	}
+	var report statusJSONOutput
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode status JSON: %v\n%s", err, out.String())
+	}
+	if report.SpaceKey != "TEST" {
+		t.Fatalf("space_key = %q, want TEST", report.SpaceKey)
+	}
+	byKey := map[string]StatusPage{}
+	for _, page := range report.Pages {
+		key := page.Path
+		if key == "" {
+			key = page.PageID
+		}
+		byKey[key] = page
+	}
+	if len(byKey) != 5 {
+		t.Fatalf("pages = %+v, want 5 entries", report.Pages)
+	}
+	if got := byKey["Behind.md"]; !got.Tracked || got.LocalVersion != 1 || got.RemoteVersion != 3 || got.RemoteChange != "modified" {
+		t.Fatalf("Behind.md = %+v", got)
+	}
+	if got := byKey["Edited.md"]; !got.Uncommitted || got.LocalChange != "modified" || got.RemoteChange != "" {
+		t.Fatalf("Edited.md = %+v", got)
+	}
+	if got := byKey["Clean.md"]; !got.inSync() {
+		t.Fatalf("Clean.md = %+v, want in sync", got)
+	}
+	if got := byKey["New.md"]; got.Tracked || got.LocalChange != "added" {
+		t.Fatalf("New.md = %+v", got)
+	}
+	if got := byKey["9"]; got.Tracked || got.RemoteChange != "added" || got.Title != "Remote Only" {
+		t.Fatalf("remote-only page = %+v", got)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// syncTimestampLayout is the timestamp suffix of confluence-sync tags and
+// push recovery artifacts.
+const syncTimestampLayout = "20060102T150405Z"
+
+// syncTimestampEnv pins the timestamp used in pull and push tag names, so
+// scripted runs can produce reproducible tags. Watermarks and recovery
+// artifacts keep using the real clock.
+const syncTimestampEnv = "CONF_SYNC_TIMESTAMP"
+
+// resolveSyncTimestamp returns the time used to name this run's sync tag:
+// CONF_SYNC_TIMESTAMP when set (RFC3339 or 20060102T150405Z), nowUTC otherwise.
+func resolveSyncTimestamp() (time.Time, error) {
+	raw := strings.TrimSpace(os.Getenv(syncTimestampEnv))
+	if raw == "" {
+		return nowUTC(), nil
+	}
+	for _, layout := range []string{syncTimestampLayout, time.RFC3339} {
+		if ts, err := time.Parse(layout, raw); err == nil {
+			return ts.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid %s value %q: use RFC3339 (2026-02-01T12:00:00Z) or %s", syncTimestampEnv, raw, syncTimestampLayout)
+}
+
+func formatSyncTimestamp(ts time.Time) string {
+	return ts.UTC().Format(syncTimestampLayout)
+}
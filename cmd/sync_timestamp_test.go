@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveSyncTimestamp(t *testing.T) {
+	runParallelCommandTest(t)
+
+	oldNow := nowUTC
+	nowUTC = func() time.Time { return time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { nowUTC = oldNow })
+
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "unset uses clock", value: "", want: "20260201T120000Z"},
+		{name: "tag layout", value: "20260315T090000Z", want: "20260315T090000Z"},
+		{name: "rfc3339 with offset", value: "2026-03-15T10:00:00+01:00", want: "20260315T090000Z"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(syncTimestampEnv, tc.value)
+			got, err := resolveSyncTimestamp()
+			if err != nil {
+				t.Fatalf("resolveSyncTimestamp() error: %v", err)
+			}
+			if formatted := formatSyncTimestamp(got); formatted != tc.want {
+				t.Fatalf("resolveSyncTimestamp() = %s, want %s", formatted, tc.want)
+			}
+		})
+	}
+
+	t.Setenv(syncTimestampEnv, "yesterday")
+	if _, err := resolveSyncTimestamp(); err == nil || !strings.Contains(err.Error(), syncTimestampEnv) {
+		t.Fatalf("expected an error naming %s, got %v", syncTimestampEnv, err)
+	}
+}
+
+func TestRunPush_SyncTimestampEnvPinsTagName(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{
+			Title:                  "Root",
+			ID:                     "1",
+			Version:                1,
+			ConfluenceLastModified: "2026-02-01T10:00:00Z",
+		},
+		Body: "Updated local content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local change")
+
+	fake := newCmdFakePushRemote(1)
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+	t.Setenv(syncTimestampEnv, "2026-03-15T09:00:00Z")
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+
+	tags := strings.TrimSpace(runGitForTest(t, repo, "tag", "--list", "confluence-sync/push/*"))
+	if tags != "confluence-sync/push/ENG/20260315T090000Z" {
+		t.Fatalf("push tags = %q, want the pinned timestamp", tags)
+	}
+}
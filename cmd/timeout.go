@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const commandTimeoutFlagName = "timeout"
+
+func addCommandTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration(commandTimeoutFlagName, 0, "Abort the command if it has not finished after this long, e.g. 10m (0 = no limit)")
+}
+
+// withCommandTimeout returns the context a long-running command works under:
+// the command context, which main cancels on Ctrl-C/SIGTERM, bounded by
+// --timeout when set. The bounded context is also installed on cmd so nested
+// runs (the pull behind push's pull-merge) share the same deadline; the
+// returned cancel restores the previous one.
+func withCommandTimeout(cmd *cobra.Command) (context.Context, context.CancelFunc, error) {
+	parent := getCommandContext(cmd)
+	timeout := time.Duration(0)
+	if cmd.Flags().Lookup(commandTimeoutFlagName) != nil {
+		value, err := cmd.Flags().GetDuration(commandTimeoutFlagName)
+		if err != nil {
+			return nil, nil, err
+		}
+		timeout = value
+	}
+	if timeout < 0 {
+		return nil, nil, errors.New("--timeout must be a non-negative duration")
+	}
+	if _, hasDeadline := parent.Deadline(); timeout == 0 || hasDeadline {
+		return parent, func() {}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	cmd.SetContext(ctx)
+	return ctx, func() {
+		cancel()
+		cmd.SetContext(parent)
+	}, nil
+}
+
+// commandInterruptedError marks an error already explained by
+// explainCommandInterruption, so nested runs are not annotated twice.
+type commandInterruptedError struct {
+	reason   string
+	timedOut bool
+	err      error
+}
+
+func (e *commandInterruptedError) Error() string {
+	return e.reason + ": " + e.err.Error()
+}
+
+func (e *commandInterruptedError) Unwrap() error {
+	return e.err
+}
+
+// explainCommandInterruption annotates err when ctx was cancelled by
+// --timeout or by the user, so the failure does not read like a remote error.
+func explainCommandInterruption(ctx context.Context, command string, err error) error {
+	var interrupted *commandInterruptedError
+	if err == nil || errors.As(err, &interrupted) {
+		return err
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return &commandInterruptedError{reason: fmt.Sprintf("%s aborted: --timeout exceeded", command), timedOut: true, err: err}
+	case errors.Is(ctx.Err(), context.Canceled):
+		return &commandInterruptedError{reason: command + " interrupted", err: err}
+	default:
+		return err
+	}
+}
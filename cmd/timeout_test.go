@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newTimeoutTestCmd(t *testing.T, timeout string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addCommandTimeoutFlag(cmd)
+	cmd.SetContext(context.Background())
+	if timeout != "" {
+		if err := cmd.Flags().Set(commandTimeoutFlagName, timeout); err != nil {
+			t.Fatalf("set --timeout: %v", err)
+		}
+	}
+	return cmd
+}
+
+func TestWithCommandTimeout_BoundsAndRestoresCommandContext(t *testing.T) {
+	cmd := newTimeoutTestCmd(t, "1h")
+
+	ctx, cancel, err := withCommandTimeout(cmd)
+	if err != nil {
+		t.Fatalf("withCommandTimeout() error: %v", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Hour {
+		t.Fatalf("context deadline = %v (set=%v), want within 1h", deadline, ok)
+	}
+	if cmd.Context() != ctx {
+		t.Fatalf("expected bounded context to be installed on the command")
+	}
+
+	// A nested run on the same command keeps the outer deadline.
+	nested, cancelNested, err := withCommandTimeout(cmd)
+	if err != nil {
+		t.Fatalf("nested withCommandTimeout() error: %v", err)
+	}
+	if nested != ctx {
+		t.Fatalf("nested run should reuse the outer bounded context")
+	}
+	cancelNested()
+
+	cancel()
+	if cmd.Context() != context.Background() {
+		t.Fatalf("cancel should restore the original command context")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("cancel should cancel the bounded context, got %v", ctx.Err())
+	}
+}
+
+func TestWithCommandTimeout_ZeroAndNegative(t *testing.T) {
+	ctx, cancel, err := withCommandTimeout(newTimeoutTestCmd(t, ""))
+	if err != nil {
+		t.Fatalf("withCommandTimeout() error: %v", err)
+	}
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatalf("default --timeout should not set a deadline")
+	}
+
+	if _, _, err := withCommandTimeout(newTimeoutTestCmd(t, "-1s")); err == nil || !strings.Contains(err.Error(), "--timeout") {
+		t.Fatalf("expected negative --timeout to be rejected, got %v", err)
+	}
+}
+
+func TestExplainCommandInterruption(t *testing.T) {
+	expired, cancelExpired := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancelExpired()
+	<-expired.Done()
+
+	err := explainCommandInterruption(expired, "diff", errors.New("fetch page 1: context deadline exceeded"))
+	if err == nil || !strings.HasPrefix(err.Error(), "diff aborted: --timeout exceeded: fetch page 1") {
+		t.Fatalf("unexpected timeout error: %v", err)
+	}
+	if again := explainCommandInterruption(expired, "push", err); again != err {
+		t.Fatalf("already explained error should be returned unchanged, got %v", again)
+	}
+
+	interrupted, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := explainCommandInterruption(interrupted, "push", context.Canceled); err == nil || !strings.HasPrefix(err.Error(), "push interrupted: ") {
+		t.Fatalf("unexpected interruption error: %v", err)
+	}
+
+	plain := errors.New("boom")
+	if err := explainCommandInterruption(context.Background(), "pull", plain); err != plain {
+		t.Fatalf("errors from a live context should be unchanged, got %v", err)
+	}
+}
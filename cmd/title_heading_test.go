@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+func TestRunPull_StripTitleHeadingFromSpaceConfig(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+	writeMarkdown(t, filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T08:00:00Z"},
+		Body:        "old body\n",
+	})
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("strip_title_heading: true\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "initial")
+
+	body := map[string]any{
+		"version": 1,
+		"type":    "doc",
+		"content": []any{
+			map[string]any{
+				"type":    "heading",
+				"attrs":   map[string]any{"level": 1},
+				"content": []any{map[string]any{"type": "text", "text": "Root"}},
+			},
+			map[string]any{
+				"type":    "paragraph",
+				"content": []any{map[string]any{"type": "text", "text": "new body"}},
+			},
+		},
+	}
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, body)},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newPullRemote
+	previousForce := flagPullForce
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	flagPullForce = true
+	t.Cleanup(func() {
+		newPullRemote = oldFactory
+		flagPullForce = previousForce
+	})
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPull(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runPull() error: %v", err)
+	}
+
+	doc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Root.md"))
+	if err != nil {
+		t.Fatalf("read Root.md: %v", err)
+	}
+	if strings.Contains(doc.Body, "# Root") || !strings.Contains(doc.Body, "new body") {
+		t.Fatalf("pulled body should drop the title heading and keep the rest:\n%s", doc.Body)
+	}
+}
+
+func TestRunPush_StripTitleHeadingFromSpaceConfigAddsHeadingBack(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := preparePushRepoWithBaseline(t, repo)
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("strip_title_heading: true\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ConfluenceLastModified: "2026-02-01T10:00:00Z"},
+		Body:        "Updated local content\n",
+	})
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "local changes")
+
+	fake := newCmdFakePushRemote(1)
+	remotePage := fake.pagesByID["1"]
+	remotePage.BodyADF = []byte(`{"version":1,"type":"doc","content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Root"}]},{"type":"paragraph","content":[{"type":"text","text":"remote content"}]}]}`)
+	fake.pagesByID["1"] = remotePage
+	oldPushFactory := newPushRemote
+	oldPullFactory := newPullRemote
+	newPushRemote = func(_ *config.Config) (syncflow.PushRemote, error) { return fake, nil }
+	newPullRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() {
+		newPushRemote = oldPushFactory
+		newPullRemote = oldPullFactory
+	})
+
+	setupEnv(t)
+	chdirRepo(t, spaceDir)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runPush(cmd, config.Target{Mode: config.TargetModeSpace, Value: ""}, OnConflictCancel, false); err != nil {
+		t.Fatalf("runPush() unexpected error: %v", err)
+	}
+
+	if len(fake.updateCalls) == 0 {
+		t.Fatal("expected root.md to be updated")
+	}
+	adf := string(fake.updateCalls[len(fake.updateCalls)-1].Input.BodyADF)
+	heading := strings.Index(adf, `"type":"heading"`)
+	if heading < 0 || !strings.Contains(adf, `"text":"Root"`) || heading > strings.Index(adf, "Updated local content") {
+		t.Fatalf("pushed body should start with the title heading:\n%s", adf)
+	}
+}
+
+func TestRunDiff_StripTitleHeadingFromSpaceConfig(t *testing.T) {
+	runParallelCommandTest(t)
+
+	repo := t.TempDir()
+	spaceDir := filepath.Join(repo, "ENG")
+	localFile := filepath.Join(spaceDir, "root.md")
+	writeMarkdown(t, localFile, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 2, ConfluenceLastModified: "2026-02-01T11:00:00Z"},
+		Body:        "same body\n",
+	})
+	if err := os.WriteFile(filepath.Join(spaceDir, config.SpaceConfigFileName), []byte("strip_title_heading: true\n"), 0o600); err != nil {
+		t.Fatalf("write space config: %v", err)
+	}
+
+	body := map[string]any{
+		"version": 1,
+		"type":    "doc",
+		"content": []any{
+			map[string]any{
+				"type":    "heading",
+				"attrs":   map[string]any{"level": 1},
+				"content": []any{map[string]any{"type": "text", "text": "Root"}},
+			},
+			map[string]any{
+				"type":    "paragraph",
+				"content": []any{map[string]any{"type": "text", "text": "same body"}},
+			},
+		},
+	}
+	modified := time.Date(2026, time.February, 1, 11, 0, 0, 0, time.UTC)
+	fake := &cmdFakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, body)},
+		},
+		attachments: map[string][]byte{},
+	}
+	oldFactory := newDiffRemote
+	newDiffRemote = func(_ *config.Config) (syncflow.PullRemote, error) { return fake, nil }
+	t.Cleanup(func() { newDiffRemote = oldFactory })
+
+	setupEnv(t)
+	chdirRepo(t, repo)
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runDiff(cmd, config.Target{Mode: config.TargetModeFile, Value: localFile}); err != nil {
+		t.Fatalf("runDiff() error: %v", err)
+	}
+
+	if got := out.String(); strings.Contains(got, "# Root") {
+		t.Fatalf("diff should leave out the title heading pull strips:\n%s", got)
+	}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	syncflow "github.com/rgonek/confluence-markdown-sync/internal/sync"
+	"github.com/spf13/cobra"
+)
+
+var flagTreeAssets bool
+
+// localTreeNode is one directory or Markdown file of the local space tree.
+type localTreeNode struct {
+	Name     string
+	RelPath  string
+	IsDir    bool
+	PageID   string
+	Version  int
+	Status   string // "added" or "modified" relative to the sync baseline
+	Assets   int
+	Children []*localTreeNode
+}
+
+func newTreeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree [SPACE_KEY]",
+		Short: "Print the local Markdown tree of a space with sync status",
+		Long: `tree prints the local directory tree of a space's Markdown files.
+
+Each page shows its page ID and local version from frontmatter, and is marked
+[added] or [modified] when it differs from the last sync tag. Only local files,
+state and Git history are read, so tree works offline; use list for the
+remote page tree.
+
+Examples:
+  conf tree
+  conf tree ENG
+  conf tree ENG --assets`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var raw string
+			if len(args) > 0 {
+				raw = args[0]
+			}
+			return runTree(cmd, config.ParseTarget(raw))
+		},
+	}
+	cmd.Flags().BoolVar(&flagTreeAssets, "assets", false, "Show how many local attachments each page references")
+	return cmd
+}
+
+func runTree(cmd *cobra.Command, target config.Target) error {
+	if target.IsFile() {
+		return errors.New("tree takes a SPACE_KEY or space directory, not a Markdown file")
+	}
+	out := ensureSynchronizedCmdOutput(cmd)
+
+	initialCtx, err := resolveInitialPullContext(target)
+	if err != nil {
+		return err
+	}
+	spaceDir := initialCtx.spaceDir
+	if info, statErr := os.Stat(spaceDir); statErr != nil || !info.IsDir() {
+		return fmt.Errorf("space directory %s not found; run pull first", spaceDir)
+	}
+	spaceKey := strings.TrimSpace(initialCtx.spaceKey)
+
+	added, modified, deleted, err := collectLocalStatusChanges(config.Target{Mode: config.TargetModeSpace, Value: spaceDir}, spaceDir, spaceKey)
+	if err != nil {
+		return err
+	}
+	statusByPath := map[string]string{}
+	for _, relPath := range added {
+		statusByPath[relPath] = "added"
+	}
+	for _, relPath := range modified {
+		statusByPath[relPath] = "modified"
+	}
+
+	root, pageCount, err := buildLocalTree(spaceDir, statusByPath, flagTreeAssets)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(out, "Space: %s (%d page(s), %d added, %d modified, %d deleted since last sync)\n",
+		spaceKey, pageCount, len(added), len(modified), len(deleted))
+	printLocalTree(out, root.Children, 0, flagTreeAssets)
+	if len(deleted) > 0 {
+		_, _ = fmt.Fprintln(out, "\nDeleted since last sync:")
+		for _, relPath := range deleted {
+			_, _ = fmt.Fprintf(out, "  - %s\n", relPath)
+		}
+	}
+	return nil
+}
+
+// buildLocalTree walks spaceDir with the same filters as BuildPageIndex and
+// returns its Markdown pages arranged by directory. Directories without any
+// page are left out.
+func buildLocalTree(spaceDir string, statusByPath map[string]string, withAssets bool) (*localTreeNode, int, error) {
+	root := &localTreeNode{IsDir: true}
+	dirs := map[string]*localTreeNode{".": root}
+	pageCount := 0
+
+	var dirFor func(relDir string) *localTreeNode
+	dirFor = func(relDir string) *localTreeNode {
+		if node, ok := dirs[relDir]; ok {
+			return node
+		}
+		parent := dirFor(filepath.ToSlash(filepath.Dir(relDir)))
+		node := &localTreeNode{Name: filepath.Base(relDir), RelPath: relDir, IsDir: true}
+		parent.Children = append(parent.Children, node)
+		dirs[relDir] = node
+		return node
+	}
+
+	err := filepath.WalkDir(spaceDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != spaceDir && (d.Name() == "assets" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") || fs.IsPageSidecar(d.Name()) {
+			return nil
+		}
+		relPath, err := filepath.Rel(spaceDir, path)
+		if err != nil {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		node := &localTreeNode{Name: d.Name(), RelPath: relPath, Status: statusByPath[relPath]}
+		if withAssets {
+			doc, readErr := fs.ReadMarkdownDocument(path)
+			if readErr == nil {
+				node.PageID = strings.TrimSpace(doc.Frontmatter.ID)
+				node.Version = doc.Frontmatter.Version
+				if refs, refErr := syncflow.CollectReferencedAssetPaths(spaceDir, path, doc.Body); refErr == nil {
+					node.Assets = len(refs)
+				}
+			}
+		} else if fm, readErr := fs.ReadFrontmatter(path); readErr == nil {
+			node.PageID = strings.TrimSpace(fm.ID)
+			node.Version = fm.Version
+		}
+
+		parent := dirFor(filepath.ToSlash(filepath.Dir(relPath)))
+		parent.Children = append(parent.Children, node)
+		pageCount++
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("walk space directory: %w", err)
+	}
+	sortLocalTree(root.Children)
+	return root, pageCount, nil
+}
+
+// sortLocalTree orders each directory's Markdown files before its
+// subdirectories, both alphabetically.
+func sortLocalTree(nodes []*localTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].IsDir != nodes[j].IsDir {
+			return !nodes[i].IsDir
+		}
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, node := range nodes {
+		sortLocalTree(node.Children)
+	}
+}
+
+func printLocalTree(out io.Writer, nodes []*localTreeNode, depth int, withAssets bool) {
+	indent := strings.Repeat("  ", depth)
+	for _, node := range nodes {
+		if node.IsDir {
+			_, _ = fmt.Fprintf(out, "%s%s/\n", indent, node.Name)
+			printLocalTree(out, node.Children, depth+1, withAssets)
+			continue
+		}
+
+		details := "no id"
+		if node.PageID != "" {
+			details = fmt.Sprintf("id %s, v%d", node.PageID, node.Version)
+		}
+		if withAssets {
+			details += fmt.Sprintf(", %d asset(s)", node.Assets)
+		}
+		line := fmt.Sprintf("%s%s (%s)", indent, node.Name, details)
+		if node.Status != "" {
+			line += " [" + node.Status + "]"
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+}
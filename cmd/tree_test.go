@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+func TestRunTree_PrintsLocalTreeWithSyncStatus(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	spaceDir := filepath.Join(repo, "ENG")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Home.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Home", ID: "1", Version: 4},
+		Body:        "Home\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Guides.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Guides", ID: "2", Version: 2},
+		Body:        "![diagram](../assets/2/10-diagram.png)\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Setup.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Setup", ID: "3", Version: 1},
+		Body:        "Setup\n",
+	})
+	if err := os.MkdirAll(filepath.Join(spaceDir, "assets", "2"), 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(spaceDir, "assets", "2", "10-diagram.png"), []byte("png"), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+	if err := fs.SaveState(spaceDir, fs.SpaceState{
+		SpaceKey:        "ENG",
+		PagePathIndex:   map[string]string{"Home.md": "1", "Guides/Guides.md": "2", "Guides/Setup.md": "3"},
+		AttachmentIndex: map[string]string{"assets/2/10-diagram.png": "10"},
+	}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte(".env\n.confluence-state.json\n"), 0o600); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	runGitForTest(t, repo, "add", ".")
+	runGitForTest(t, repo, "commit", "-m", "pull")
+	runGitForTest(t, repo, "tag", "confluence-sync/pull/ENG/20260201T120000Z")
+
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Setup.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Setup", ID: "3", Version: 1},
+		Body:        "Setup edited\n",
+	})
+	writeMarkdown(t, filepath.Join(spaceDir, "Guides", "Draft.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Draft"},
+		Body:        "Draft\n",
+	})
+
+	oldAssets := flagTreeAssets
+	t.Cleanup(func() { flagTreeAssets = oldAssets })
+	chdirRepo(t, repo)
+
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	flagTreeAssets = false
+	if err := runTree(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runTree() error: %v", err)
+	}
+	want := strings.Join([]string{
+		"Space: ENG (4 page(s), 1 added, 1 modified, 0 deleted since last sync)",
+		"Home.md (id 1, v4)",
+		"Guides/",
+		"  Draft.md (no id) [added]",
+		"  Guides.md (id 2, v2)",
+		"  Setup.md (id 3, v1) [modified]",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Fatalf("tree output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	flagTreeAssets = true
+	if err := runTree(cmd, config.Target{Mode: config.TargetModeSpace, Value: "ENG"}); err != nil {
+		t.Fatalf("runTree(--assets) error: %v", err)
+	}
+	if !strings.Contains(out.String(), "  Guides.md (id 2, v2, 1 asset(s))\n") {
+		t.Fatalf("expected attachment count for Guides.md, got:\n%s", out.String())
+	}
+}
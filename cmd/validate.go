@@ -235,6 +235,7 @@ func runValidateTargetWithContextReportWithOverride(ctx context.Context, out io.
 
 		fileResult := validateFile(ctx, file, targetCtx.spaceDir, linkHook, state.AttachmentIndex)
 		issues := append(fileResult.Issues, immutableResolver.validate(file)...)
+		issues = append(issues, validateParentPathOverride(file, index)...)
 		printValidateWarnings(out, rel, fileResult.Warnings)
 		for _, warning := range fileResult.Warnings {
 			result.Diagnostics = append(result.Diagnostics, commandRunReportDiagnostic{
@@ -303,6 +304,11 @@ func resolveValidateTargetContext(target config.Target, fileSpaceDirOverride str
 		return validateTargetContext{}, fmt.Errorf("resolved space path is not a directory: %s", spaceDir)
 	}
 
+	spaceCfg, err := loadSpaceConfig(spaceDir)
+	if err != nil {
+		return validateTargetContext{}, err
+	}
+
 	files := make([]string, 0)
 	err = filepath.WalkDir(spaceDir, func(path string, d os.DirEntry, walkErr error) error {
 		if walkErr != nil {
@@ -314,9 +320,13 @@ func resolveValidateTargetContext(target config.Target, fileSpaceDirOverride str
 			}
 			return nil
 		}
-		if filepath.Ext(path) == ".md" {
-			files = append(files, path)
+		if filepath.Ext(path) != ".md" || fs.IsPageSidecar(path) {
+			return nil
+		}
+		if relPath, relErr := filepath.Rel(spaceDir, path); relErr == nil && matchesSpaceIgnore(spaceCfg.Ignore, filepath.ToSlash(relPath)) {
+			return nil
 		}
+		files = append(files, path)
 		return nil
 	})
 	if err != nil {
@@ -452,6 +462,7 @@ func validateFile(ctx context.Context, path, spaceDir string, linkHook mdconvert
 		return result
 	}
 	result.Warnings = append(result.Warnings, mermaidValidationWarnings(doc.Body)...)
+	result.Warnings = append(result.Warnings, titleValidationWarnings(doc)...)
 
 	// 1. Validate Schema
 	res := fs.ValidateFrontmatterSchema(doc.Frontmatter)
@@ -475,9 +486,22 @@ func validateFile(ctx context.Context, path, spaceDir string, linkHook mdconvert
 	}
 	mediaHook := syncflow.NewReverseMediaHook(spaceDir, strictAttachmentIndex)
 
+	// Links into another space directory still resolve, but only as absolute
+	// links to that space; warn once per target so authors are not surprised.
+	crossSpaceTargets := map[string]struct{}{}
+	crossSpaceLinkHook := func(ctx context.Context, in mdconverter.LinkParseInput) (mdconverter.LinkParseOutput, error) {
+		if diag, ok := syncflow.CrossSpaceLinkDiagnostic(spaceDir, in.SourcePath, in.Destination); ok {
+			if _, seen := crossSpaceTargets[in.Destination]; !seen {
+				crossSpaceTargets[in.Destination] = struct{}{}
+				result.Warnings = append(result.Warnings, validateWarning{Code: diag.Code, Message: diag.Message})
+			}
+		}
+		return linkHook(ctx, in)
+	}
+
 	// 2. Strict Conversion
-	_, err = converter.Reverse(ctx, []byte(preparedBody), converter.ReverseConfig{
-		LinkHook:  linkHook,
+	reverse, err := converter.Reverse(ctx, []byte(preparedBody), converter.ReverseConfig{
+		LinkHook:  crossSpaceLinkHook,
 		MediaHook: mediaHook,
 		Strict:    true,
 	}, path)
@@ -488,10 +512,42 @@ func validateFile(ctx context.Context, path, spaceDir string, linkHook mdconvert
 			Message: err.Error(),
 		})
 	}
+	for _, warning := range reverse.Warnings {
+		switch warning.Type {
+		case converter.WarningUnsupportedColor:
+			result.Warnings = append(result.Warnings, validateWarning{Code: "UNSUPPORTED_COLOR", Message: warning.Message})
+		case converter.WarningHTMLBlockPassthrough:
+			result.Warnings = append(result.Warnings, validateWarning{Code: "HTML_BLOCK_PASSTHROUGH", Message: warning.Message})
+		case converter.WarningHTMLCommentDropped:
+			result.Warnings = append(result.Warnings, validateWarning{Code: "HTML_COMMENT_DROPPED", Message: warning.Message})
+		}
+	}
 
 	return result
 }
 
+// validateParentPathOverride checks that a parent_path frontmatter pin names a
+// Markdown page tracked in the same space.
+func validateParentPathOverride(path string, index syncflow.PageIndex) []fs.ValidationIssue {
+	doc, err := fs.ReadMarkdownDocument(path)
+	if err != nil {
+		return nil
+	}
+	parentPath := strings.TrimSpace(doc.Frontmatter.ParentPath)
+	if parentPath == "" {
+		return nil
+	}
+	normalized := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(filepath.FromSlash(parentPath))), "./")
+	if strings.TrimSpace(index[normalized]) != "" {
+		return nil
+	}
+	return []fs.ValidationIssue{{
+		Field:   "parent_path",
+		Code:    "unresolved",
+		Message: fmt.Sprintf("parent_path %q does not match a Markdown page in this space", parentPath),
+	}}
+}
+
 func buildWorkspaceGlobalPageIndex(spaceDir string) (syncflow.GlobalPageIndex, error) {
 	globalIndexRoot, err := syncflow.ResolveGlobalIndexRoot(spaceDir)
 	if err != nil {
@@ -547,6 +603,24 @@ func printValidateWarnings(out io.Writer, relPath string, warnings []validateWar
 	}
 }
 
+// titleValidationWarnings flags documents whose frontmatter title and first
+// H1 disagree; the frontmatter title is what push publishes.
+func titleValidationWarnings(doc fs.MarkdownDocument) []validateWarning {
+	title := strings.TrimSpace(doc.Frontmatter.Title)
+	heading := converter.MarkdownH1Title(doc.Body)
+	if title == "" || heading == "" || title == heading {
+		return nil
+	}
+	return []validateWarning{{
+		Code: "TITLE_H1_MISMATCH",
+		Message: fmt.Sprintf(
+			"frontmatter title %q differs from the first H1 %q; push publishes the frontmatter title",
+			title,
+			heading,
+		),
+	}}
+}
+
 func mermaidValidationWarnings(body string) []validateWarning {
 	structure := search.ParseMarkdownStructure([]byte(body))
 	warnings := make([]validateWarning, 0)
@@ -413,6 +413,13 @@ func TestRunValidateTarget_AllowsCrossSpaceEncodedRelativeLink(t *testing.T) {
 	if err := runValidateTargetWithContext(context.Background(), out, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
 		t.Fatalf("expected validate success, got: %v\nOutput:\n%s", err, out.String())
 	}
+	got := out.String()
+	if !strings.Contains(got, "[CROSS_SPACE_LINK]") || !strings.Contains(got, "targets space TD, not this file's space ENG") {
+		t.Fatalf("expected cross-space link warning, got:\n%s", got)
+	}
+	if !strings.Contains(got, `link "../Technical%20Docs%20(TD)/target.md" in root.md`) {
+		t.Fatalf("expected warning to name the source file and link target, got:\n%s", got)
+	}
 }
 
 func TestRunValidateTarget_AllowsLinkToSimultaneousNewPageInSpaceScope(t *testing.T) {
@@ -682,3 +689,91 @@ func TestRunValidateCommand(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+func TestRunValidateTarget_BlocksUnresolvedParentPath(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space dir: %v", err)
+	}
+
+	writeMarkdown(t, filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, ParentPath: "missing/parent.md"},
+		Body:        "content\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1"}}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	chdirRepo(t, repo)
+	out := &bytes.Buffer{}
+	err := runValidateTargetWithContext(context.Background(), out, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"})
+	if err == nil {
+		t.Fatalf("expected validate failure for unresolved parent_path\nOutput:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "missing/parent.md") {
+		t.Fatalf("expected parent_path issue in output, got:\n%s", out.String())
+	}
+}
+
+func TestRunValidateTarget_WarnsWhenTitleAndH1Disagree(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space dir: %v", err)
+	}
+
+	writeMarkdown(t, filepath.Join(spaceDir, "api.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "API Reference Guide"},
+		Body:        "# API\n\ncontent\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{SpaceKey: "ENG"}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	chdirRepo(t, repo)
+	out := &bytes.Buffer{}
+	if err := runValidateTargetWithContext(context.Background(), out, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("expected validate success, got: %v\nOutput:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "TITLE_H1_MISMATCH") {
+		t.Fatalf("expected title mismatch warning, got:\n%s", out.String())
+	}
+}
+
+func TestRunValidateTarget_WarnsOnUnsupportedColor(t *testing.T) {
+	runParallelCommandTest(t)
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	setupEnv(t)
+
+	spaceDir := filepath.Join(repo, "Engineering (ENG)")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space dir: %v", err)
+	}
+
+	writeMarkdown(t, filepath.Join(spaceDir, "status.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Status"},
+		Body:        "[late]{style=\"color: rgb(255, 0, 0);\"}\n",
+	})
+	if err := fs.SaveState(spaceDir, fs.SpaceState{SpaceKey: "ENG"}); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	chdirRepo(t, repo)
+	out := &bytes.Buffer{}
+	if err := runValidateTargetWithContext(context.Background(), out, config.Target{Mode: config.TargetModeSpace, Value: "Engineering (ENG)"}); err != nil {
+		t.Fatalf("expected validate success, got: %v\nOutput:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "UNSUPPORTED_COLOR") {
+		t.Fatalf("expected unsupported color warning, got:\n%s", out.String())
+	}
+}
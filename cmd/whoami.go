@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/spf13/cobra"
+)
+
+// whoamiCurrentUser looks up the account the configured credentials
+// authenticate as.
+var whoamiCurrentUser = func(ctx context.Context, cfg *config.Config) (confluence.User, error) {
+	client, err := newConfluenceClientFromConfig(cfg)
+	if err != nil {
+		return confluence.User{}, err
+	}
+	defer closeRemoteIfPossible(client)
+	return client.GetCurrentUser(ctx)
+}
+
+func newWhoamiCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "whoami",
+		Aliases: []string{"login"},
+		Short:   "Check the Confluence credentials and print the signed-in account",
+		Long: `whoami loads the credentials from .env or the environment, signs in to
+ATLASSIAN_DOMAIN and prints the account they authenticate as. Run it after
+'conf init' or after rotating a token to catch a mistyped domain or token before
+a pull fails halfway.
+
+A failure says whether the token was rejected (401), the account lacks access
+(403), or the site could not be reached (DNS, connection or TLS problems).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWhoami(cmd)
+		},
+	}
+}
+
+func runWhoami(cmd *cobra.Command) error {
+	out := ensureSynchronizedCmdOutput(cmd)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(findEnvPath(cwd))
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if detail, ok := validateDoctorDomain(cfg.Domain); !ok {
+		return fmt.Errorf("invalid ATLASSIAN_DOMAIN: %s", detail)
+	}
+
+	ctx, cancel := context.WithTimeout(getCommandContext(cmd), doctorAuthProbeTimeout)
+	defer cancel()
+	user, err := whoamiCurrentUser(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("sign in to %s: %w (hint: %s)", cfg.Domain, err, doctorAuthHint(err))
+	}
+
+	authMode := "basic (email and API token)"
+	if confluence.ResolveAuthMode(confluence.AuthMode(cfg.AuthMode), cfg.Email) == confluence.AuthModeBearer {
+		authMode = "bearer (API token)"
+	}
+	_, _ = fmt.Fprintf(out, "signed in to %s as %s\n", cfg.Domain, accountLabel(user))
+	_, _ = fmt.Fprintf(out, "account ID: %s\n", user.AccountID)
+	_, _ = fmt.Fprintf(out, "auth: %s\n", authMode)
+	return nil
+}
+
+// accountLabel names a Confluence account as "Display Name <email>", leaving
+// out whichever part the API did not return.
+func accountLabel(user confluence.User) string {
+	name := strings.TrimSpace(user.DisplayName)
+	email := strings.TrimSpace(user.Email)
+	switch {
+	case name == "" && email == "":
+		return strings.TrimSpace(user.AccountID)
+	case email == "":
+		return name
+	case name == "":
+		return email
+	default:
+		return name + " <" + email + ">"
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+)
+
+func stubWhoamiCurrentUser(t *testing.T, user confluence.User, err error) {
+	t.Helper()
+	old := whoamiCurrentUser
+	whoamiCurrentUser = func(context.Context, *config.Config) (confluence.User, error) { return user, err }
+	t.Cleanup(func() { whoamiCurrentUser = old })
+}
+
+func TestRunWhoami_PrintsAuthenticatedAccount(t *testing.T) {
+	runParallelCommandTest(t)
+	newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "https://example.atlassian.net")
+	stubWhoamiCurrentUser(t, confluence.User{AccountID: "acc-1", DisplayName: "Jane Doe", Email: "jane@example.com"}, nil)
+
+	out := new(bytes.Buffer)
+	cmd := newWhoamiCmd()
+	cmd.SetOut(out)
+	if err := runWhoami(cmd); err != nil {
+		t.Fatalf("runWhoami() error: %v", err)
+	}
+	for _, want := range []string{
+		"signed in to https://example.atlassian.net as Jane Doe <jane@example.com>",
+		"account ID: acc-1",
+		"auth: basic",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunWhoami_ExplainsFailures(t *testing.T) {
+	runParallelCommandTest(t)
+	newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "https://example.atlassian.net")
+
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "unauthorized", err: &confluence.APIError{StatusCode: http.StatusUnauthorized, Method: "GET", URL: "/wiki/rest/api/user/current"}, want: "token rejected"},
+		{name: "forbidden", err: &confluence.APIError{StatusCode: http.StatusForbidden, Method: "GET", URL: "/wiki/rest/api/user/current"}, want: "lacks Confluence access"},
+		{name: "dns", err: &url.Error{Op: "Get", URL: "https://exmaple.atlassian.net", Err: &net.DNSError{Err: "no such host", Name: "exmaple.atlassian.net"}}, want: "does not resolve"},
+		{name: "connection", err: &url.Error{Op: "Get", URL: "https://example.atlassian.net", Err: &net.OpError{Op: "dial", Err: &net.AddrError{Err: "connection refused"}}}, want: "could not reach Confluence"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stubWhoamiCurrentUser(t, confluence.User{}, tc.err)
+			cmd := newWhoamiCmd()
+			cmd.SetOut(new(bytes.Buffer))
+			err := runWhoami(cmd)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("runWhoami() error = %v, want hint %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunWhoami_RejectsInvalidDomainBeforeSigningIn(t *testing.T) {
+	runParallelCommandTest(t)
+	newDoctorSetupWorkspace(t)
+	setDoctorCredentialEnv(t, "http://example.atlassian.net")
+	stubWhoamiCurrentUser(t, confluence.User{}, nil)
+	whoamiCurrentUser = func(context.Context, *config.Config) (confluence.User, error) {
+		t.Fatal("credentials must not be sent to an invalid domain")
+		return confluence.User{}, nil
+	}
+
+	err := runWhoami(newWhoamiCmd())
+	if err == nil || !strings.Contains(err.Error(), "must use https://") {
+		t.Fatalf("runWhoami() error = %v, want an https domain error", err)
+	}
+}
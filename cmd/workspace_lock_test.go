@@ -62,6 +62,7 @@ func TestDoctor_ReportsStaleWorkspaceSyncLock(t *testing.T) {
 		t.Fatalf("write lock: %v", err)
 	}
 
+	stubDoctorAuthProbe(t, nil)
 	out := new(bytes.Buffer)
 	cmd := newDoctorCmd()
 	cmd.SetOut(out)
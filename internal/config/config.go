@@ -1,12 +1,16 @@
 // Package config handles environment variable loading and configuration resolution.
-// Precedence: CONFLUENCE_* (legacy) -> ATLASSIAN_* -> .env file -> error.
+// Precedence: process environment (CONFLUENCE_* then ATLASSIAN_*) -> .env file
+// (CONFLUENCE_* then ATLASSIAN_*) -> error.
 package config
 
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,32 +20,58 @@ type Config struct {
 	Domain   string
 	Email    string
 	APIToken string //nolint:gosec // Not a hardcoded secret
+
+	// CABundle is an optional PEM file of extra root CAs trusted for TLS,
+	// for example a corporate proxy's internal CA.
+	CABundle string
+
+	// APIVersion selects the Confluence REST API generation: auto, v2 or
+	// v1. Empty means auto.
+	APIVersion string
+
+	// HTTPTimeout bounds each Confluence API request; zero means the client
+	// default.
+	HTTPTimeout time.Duration
+
+	// AuthMode is basic (email and API token) or bearer (Personal Access
+	// Token). Empty means bearer when Email is empty and basic otherwise.
+	AuthMode string
 }
 
 // ErrMissingConfig is returned when required config values cannot be resolved.
 var ErrMissingConfig = errors.New("missing configuration")
 
 // Load resolves credentials from environment and optional .env file.
-// Precedence: CONFLUENCE_* (legacy) -> ATLASSIAN_* -> .env file.
-// The .env path is loaded only if explicit env vars are absent.
+// A non-empty variable in the process environment always wins over the .env
+// file, so credentials injected by CI cannot be shadowed by a stale or
+// committed .env. Within each source the legacy CONFLUENCE_* name wins over
+// the ATLASSIAN_* name. The .env file is read, not exported into the process
+// environment.
 func Load(dotEnvPath string) (*Config, error) {
-	// Attempt to load .env if provided and env vars are not already set.
+	fileValues := map[string]string{}
 	if dotEnvPath != "" {
 		if _, err := os.Stat(dotEnvPath); err == nil {
-			// Only load .env values that aren't already set in the environment.
-			_ = godotenv.Load(dotEnvPath)
+			if values, err := godotenv.Read(dotEnvPath); err == nil {
+				fileValues = values
+			}
 		}
 	}
+	resolve := func(legacyKey, canonicalKey string) string {
+		return resolveValue(fileValues, legacyKey, canonicalKey)
+	}
 
 	domain := resolve("CONFLUENCE_URL", "ATLASSIAN_DOMAIN")
 	email := resolve("CONFLUENCE_EMAIL", "ATLASSIAN_EMAIL")
 	token := resolve("CONFLUENCE_API_TOKEN", "ATLASSIAN_API_TOKEN")
+	authMode := strings.ToLower(strings.TrimSpace(resolve("CONFLUENCE_AUTH_MODE", "ATLASSIAN_AUTH_MODE")))
 
 	var missing []string
 	if domain == "" {
 		missing = append(missing, "ATLASSIAN_DOMAIN")
 	}
-	if email == "" {
+	// A token without an email is a bearer-only credential set (a Personal
+	// Access Token); the email is only required once basic auth is chosen.
+	if email == "" && (authMode == "basic" || (authMode == "" && token == "")) {
 		missing = append(missing, "ATLASSIAN_EMAIL")
 	}
 	if token == "" {
@@ -51,17 +81,60 @@ func Load(dotEnvPath string) (*Config, error) {
 		return nil, fmt.Errorf("%w: %s", ErrMissingConfig, strings.Join(missing, ", "))
 	}
 
+	var httpTimeout time.Duration
+	if raw := strings.TrimSpace(resolve("CONFLUENCE_HTTP_TIMEOUT", "ATLASSIAN_HTTP_TIMEOUT")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid ATLASSIAN_HTTP_TIMEOUT value %q: want a duration such as 2m or 90s", raw)
+		}
+		httpTimeout = parsed
+	}
+
 	return &Config{
-		Domain:   strings.TrimRight(domain, "/"),
-		Email:    email,
-		APIToken: token,
+		Domain:      NormalizeBaseURL(domain),
+		Email:       email,
+		APIToken:    token,
+		CABundle:    strings.TrimSpace(resolve("CONFLUENCE_CA_BUNDLE", "ATLASSIAN_CA_BUNDLE")),
+		APIVersion:  strings.TrimSpace(resolve("CONFLUENCE_API_VERSION", "ATLASSIAN_API_VERSION")),
+		HTTPTimeout: httpTimeout,
+		AuthMode:    authMode,
 	}, nil
 }
 
-// resolve returns the first non-empty value from the legacy key then the canonical key.
-func resolve(legacyKey, canonicalKey string) string {
-	if v := os.Getenv(legacyKey); v != "" {
-		return v
+// NormalizeBaseURL accepts the forms users paste for a site URL. API paths
+// and page links are built as <base>/wiki/..., so a trailing /wiki (any
+// case, with or without a slash) is dropped to avoid /wiki/wiki/... paths. The scheme and
+// host are lowercased and trailing slashes removed; any other path, such as
+// a Data Center context path, is kept.
+func NormalizeBaseURL(raw string) string {
+	baseURL := strings.TrimRight(strings.TrimSpace(raw), "/")
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return baseURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	if strings.EqualFold(path.Base(u.Path), "wiki") {
+		u.Path = strings.TrimRight(path.Dir(u.Path), "/")
+	}
+	u.RawPath = ""
+	return u.String()
+}
+
+// resolveValue returns the first non-empty value from the process
+// environment (legacy key, then canonical key), then from fileValues in the
+// same order.
+func resolveValue(fileValues map[string]string, legacyKey, canonicalKey string) string {
+	for _, key := range []string{legacyKey, canonicalKey} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	for _, key := range []string{legacyKey, canonicalKey} {
+		if v := fileValues[key]; v != "" {
+			return v
+		}
 	}
-	return os.Getenv(canonicalKey)
+	return ""
 }
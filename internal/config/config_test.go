@@ -1,9 +1,12 @@
 package config_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/config"
 )
@@ -76,6 +79,41 @@ func TestLoad_AtlassianVars(t *testing.T) {
 	}
 }
 
+func TestLoad_CABundle(t *testing.T) {
+	t.Setenv("ATLASSIAN_DOMAIN", "https://example.atlassian.net")
+	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "tok123")
+	t.Setenv("ATLASSIAN_CA_BUNDLE", " /etc/ssl/corp-ca.pem ")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.CABundle != "/etc/ssl/corp-ca.pem" {
+		t.Errorf("CABundle = %q; want %q", cfg.CABundle, "/etc/ssl/corp-ca.pem")
+	}
+}
+
+func TestLoad_HTTPTimeout(t *testing.T) {
+	t.Setenv("ATLASSIAN_DOMAIN", "https://example.atlassian.net")
+	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "tok123")
+	t.Setenv("ATLASSIAN_HTTP_TIMEOUT", "2m")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.HTTPTimeout != 2*time.Minute {
+		t.Errorf("HTTPTimeout = %s; want 2m0s", cfg.HTTPTimeout)
+	}
+
+	t.Setenv("ATLASSIAN_HTTP_TIMEOUT", "30")
+	if _, err := config.Load(""); err == nil || !strings.Contains(err.Error(), "ATLASSIAN_HTTP_TIMEOUT") {
+		t.Fatalf("Load() error = %v, want an invalid ATLASSIAN_HTTP_TIMEOUT error", err)
+	}
+}
+
 func TestLoad_LegacyVarsPrecedence(t *testing.T) {
 	// Legacy CONFLUENCE_* should win over ATLASSIAN_*.
 	t.Setenv("CONFLUENCE_URL", "https://legacy.atlassian.net")
@@ -93,7 +131,7 @@ func TestLoad_LegacyVarsPrecedence(t *testing.T) {
 }
 
 func TestLoad_DotEnvFile(t *testing.T) {
-	// Fully unset vars so godotenv.Load can populate them from the .env file.
+	// Fully unset vars so the values come from the .env file.
 	unsetEnvForTest(t,
 		"ATLASSIAN_DOMAIN", "ATLASSIAN_EMAIL", "ATLASSIAN_API_TOKEN",
 		"CONFLUENCE_URL", "CONFLUENCE_EMAIL", "CONFLUENCE_API_TOKEN",
@@ -123,6 +161,90 @@ func TestLoad_DotEnvFile(t *testing.T) {
 	}
 }
 
+func TestLoad_EnvironmentOverridesDotEnv(t *testing.T) {
+	fileContent := "ATLASSIAN_DOMAIN=https://dotenv.atlassian.net\n" +
+		"ATLASSIAN_EMAIL=dotenv@example.com\n" +
+		"ATLASSIAN_API_TOKEN=stale-dotenv-token\n"
+	envValues := map[string]string{
+		"ATLASSIAN_DOMAIN":    "https://env.atlassian.net",
+		"ATLASSIAN_EMAIL":     "env@example.com",
+		"ATLASSIAN_API_TOKEN": "ci-token",
+	}
+
+	cases := []struct {
+		name      string
+		withEnv   bool
+		withFile  bool
+		wantErr   bool
+		wantToken string
+		wantEmail string
+	}{
+		{name: "env only", withEnv: true, wantToken: "ci-token", wantEmail: "env@example.com"},
+		{name: "file only", withFile: true, wantToken: "stale-dotenv-token", wantEmail: "dotenv@example.com"},
+		{name: "both", withEnv: true, withFile: true, wantToken: "ci-token", wantEmail: "env@example.com"},
+		{name: "neither", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			unsetEnvForTest(t,
+				"ATLASSIAN_DOMAIN", "ATLASSIAN_EMAIL", "ATLASSIAN_API_TOKEN",
+				"CONFLUENCE_URL", "CONFLUENCE_EMAIL", "CONFLUENCE_API_TOKEN",
+			)
+			if tc.withEnv {
+				for key, value := range envValues {
+					t.Setenv(key, value)
+				}
+			}
+			envFile := filepath.Join(t.TempDir(), ".env")
+			if tc.withFile {
+				if err := os.WriteFile(envFile, []byte(fileContent), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := config.Load(envFile)
+			if tc.wantErr {
+				if !errors.Is(err, config.ErrMissingConfig) {
+					t.Fatalf("Load() error = %v; want ErrMissingConfig", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.APIToken != tc.wantToken {
+				t.Errorf("APIToken = %q; want %q", cfg.APIToken, tc.wantToken)
+			}
+			if cfg.Email != tc.wantEmail {
+				t.Errorf("Email = %q; want %q", cfg.Email, tc.wantEmail)
+			}
+			if _, exported := os.LookupEnv("ATLASSIAN_API_TOKEN"); exported && !tc.withEnv {
+				t.Errorf("Load() exported .env values into the process environment")
+			}
+		})
+	}
+}
+
+func TestLoad_LegacyDotEnvDoesNotShadowEnvironment(t *testing.T) {
+	unsetEnvForTest(t, "CONFLUENCE_URL", "CONFLUENCE_EMAIL", "CONFLUENCE_API_TOKEN")
+	t.Setenv("ATLASSIAN_DOMAIN", "https://env.atlassian.net")
+	t.Setenv("ATLASSIAN_EMAIL", "env@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "ci-token")
+
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("CONFLUENCE_API_TOKEN=stale-legacy-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(envFile)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.APIToken != "ci-token" {
+		t.Errorf("APIToken = %q; want environment value", cfg.APIToken)
+	}
+}
+
 func TestLoad_MissingConfig(t *testing.T) {
 	unsetEnvForTest(t,
 		"ATLASSIAN_DOMAIN", "ATLASSIAN_EMAIL", "ATLASSIAN_API_TOKEN",
@@ -135,6 +257,38 @@ func TestLoad_MissingConfig(t *testing.T) {
 	}
 }
 
+func TestLoad_BearerTokenWithoutEmail(t *testing.T) {
+	unsetEnvForTest(t,
+		"ATLASSIAN_EMAIL", "ATLASSIAN_AUTH_MODE",
+		"CONFLUENCE_URL", "CONFLUENCE_EMAIL", "CONFLUENCE_API_TOKEN", "CONFLUENCE_AUTH_MODE",
+	)
+	t.Setenv("ATLASSIAN_DOMAIN", "https://confluence.example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "pat-123")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load() unexpected error for bearer-only credentials: %v", err)
+	}
+	if cfg.Email != "" || cfg.APIToken != "pat-123" {
+		t.Errorf("Email/APIToken = %q/%q; want empty/pat-123", cfg.Email, cfg.APIToken)
+	}
+
+	t.Setenv("ATLASSIAN_AUTH_MODE", "Basic")
+	_, err = config.Load("")
+	if !errors.Is(err, config.ErrMissingConfig) || !strings.Contains(err.Error(), "ATLASSIAN_EMAIL") {
+		t.Fatalf("Load() with basic auth mode error = %v; want missing ATLASSIAN_EMAIL", err)
+	}
+
+	t.Setenv("ATLASSIAN_AUTH_MODE", "bearer")
+	cfg, err = config.Load("")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.AuthMode != "bearer" {
+		t.Errorf("AuthMode = %q; want bearer", cfg.AuthMode)
+	}
+}
+
 func TestLoad_TrailingSlashStripped(t *testing.T) {
 	t.Setenv("ATLASSIAN_DOMAIN", "https://example.atlassian.net/")
 	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
@@ -149,6 +303,20 @@ func TestLoad_TrailingSlashStripped(t *testing.T) {
 	}
 }
 
+func TestLoad_WikiSuffixStripped(t *testing.T) {
+	t.Setenv("ATLASSIAN_DOMAIN", "https://Example.atlassian.net/wiki/")
+	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "tok")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.Domain != "https://example.atlassian.net" {
+		t.Errorf("Domain = %q, want the site URL without /wiki", cfg.Domain)
+	}
+}
+
 func unsetEnvForTest(t *testing.T, keys ...string) {
 	t.Helper()
 
@@ -21,6 +21,9 @@ type confYAML struct {
 		Limit        int    `yaml:"limit"`
 		ResultDetail string `yaml:"result_detail"`
 	} `yaml:"search"`
+	State struct {
+		Location string `yaml:"location"`
+	} `yaml:"state"`
 }
 
 // LoadSearchConfig reads <repoRoot>/.conf.yaml and returns a SearchConfig with
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpaceConfigFileName is the per-space settings file kept at the root of a
+// space directory and committed with it.
+const SpaceConfigFileName = ".cms-space.yaml"
+
+// SpaceConfig holds per-space sync defaults loaded from .cms-space.yaml.
+// Zero values mean "not set": built-in defaults apply, and command-line flags
+// always override anything set here.
+type SpaceConfig struct {
+	PullOverlap        time.Duration // pull.overlap
+	PullPageURL        bool          // pull.page_url: write each page's web URL to frontmatter `url`
+	PullCommitTemplate string        // pull.commit_template: text/template for the pull commit message
+	PullProperties     []string      // pull.properties: globs of content property keys pull writes to frontmatter
+	OnConflict         string        // push.on_conflict: pull-merge | force | cancel
+	OnTitleConflict    string        // push.on_title_conflict: fail | suffix
+	PushCommitTemplate string        // push.commit_template: text/template for each push commit message
+	PushPlaceholder    string        // push.placeholder_text: body text of new pages until their content is written
+	Ignore             []string      // space-relative globs push, validate and diff skip
+	FilenameMode       string        // filename_mode: conservative | transliterate | preserve-unicode
+	OrderPrefix        bool          // order_prefix: prefix page and folder names with their sibling position
+	StripTitleHeading  bool          // strip_title_heading: pull drops a leading H1 equal to the title, push adds it back
+}
+
+type spaceConfigYAML struct {
+	Pull struct {
+		Overlap        string   `yaml:"overlap"`
+		PageURL        bool     `yaml:"page_url"`
+		CommitTemplate string   `yaml:"commit_template"`
+		Properties     []string `yaml:"properties"`
+	} `yaml:"pull"`
+	Push struct {
+		OnConflict      string `yaml:"on_conflict"`
+		OnTitleConflict string `yaml:"on_title_conflict"`
+		CommitTemplate  string `yaml:"commit_template"`
+		PlaceholderText string `yaml:"placeholder_text"`
+	} `yaml:"push"`
+	Ignore            []string `yaml:"ignore"`
+	FilenameMode      string   `yaml:"filename_mode"`
+	OrderPrefix       bool     `yaml:"order_prefix"`
+	StripTitleHeading bool     `yaml:"strip_title_heading"`
+}
+
+// LoadSpaceConfig reads <spaceDir>/.cms-space.yaml. A missing file is not an
+// error — an empty SpaceConfig is returned. Unknown keys are rejected so
+// typos do not silently fall back to defaults.
+func LoadSpaceConfig(spaceDir string) (SpaceConfig, error) {
+	path := filepath.Join(spaceDir, SpaceConfigFileName)
+	data, err := os.ReadFile(path) //nolint:gosec // path is space dir + fixed filename
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return SpaceConfig{}, nil
+		}
+		return SpaceConfig{}, err
+	}
+
+	var raw spaceConfigYAML
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return SpaceConfig{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cfg := SpaceConfig{
+		PullPageURL:        raw.Pull.PageURL,
+		PullCommitTemplate: strings.TrimSpace(raw.Pull.CommitTemplate),
+		PullProperties:     raw.Pull.Properties,
+		OnConflict:         strings.TrimSpace(raw.Push.OnConflict),
+		OnTitleConflict:    strings.TrimSpace(raw.Push.OnTitleConflict),
+		PushCommitTemplate: strings.TrimSpace(raw.Push.CommitTemplate),
+		PushPlaceholder:    strings.TrimSpace(raw.Push.PlaceholderText),
+		Ignore:             raw.Ignore,
+		FilenameMode:       strings.TrimSpace(raw.FilenameMode),
+		OrderPrefix:        raw.OrderPrefix,
+		StripTitleHeading:  raw.StripTitleHeading,
+	}
+	if overlap := strings.TrimSpace(raw.Pull.Overlap); overlap != "" {
+		cfg.PullOverlap, err = time.ParseDuration(overlap)
+		if err != nil || cfg.PullOverlap < 0 {
+			return SpaceConfig{}, fmt.Errorf("%s: pull.overlap %q must be a non-negative duration such as 10m", path, overlap)
+		}
+	}
+	for _, pattern := range cfg.PullProperties {
+		if _, err := pathpkg.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return SpaceConfig{}, fmt.Errorf("%s: pull.properties entry %q is not a valid key pattern", path, pattern)
+		}
+	}
+	switch cfg.OnConflict {
+	case "", "pull-merge", "force", "cancel":
+	default:
+		return SpaceConfig{}, fmt.Errorf("%s: push.on_conflict %q must be pull-merge, force, or cancel", path, cfg.OnConflict)
+	}
+	switch cfg.OnTitleConflict {
+	case "", "fail", "suffix":
+	default:
+		return SpaceConfig{}, fmt.Errorf("%s: push.on_title_conflict %q must be fail or suffix", path, cfg.OnTitleConflict)
+	}
+	switch cfg.FilenameMode {
+	case "", "conservative", "transliterate", "preserve-unicode":
+	default:
+		return SpaceConfig{}, fmt.Errorf("%s: filename_mode %q must be conservative, transliterate, or preserve-unicode", path, cfg.FilenameMode)
+	}
+	return cfg, nil
+}
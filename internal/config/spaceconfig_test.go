@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+)
+
+func TestLoadSpaceConfig_MissingFile(t *testing.T) {
+	cfg, err := config.LoadSpaceConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PullOverlap != 0 || cfg.OnConflict != "" || cfg.OnTitleConflict != "" || len(cfg.Ignore) != 0 {
+		t.Fatalf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadSpaceConfig_FullFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "pull:\n  overlap: 15m\n  page_url: true\n  commit_template: \"chore(docs): sync {{.SpaceKey}}\"\n  properties:\n    - \"dashboard.*\"\npush:\n  on_conflict: cancel\n  on_title_conflict: suffix\n  commit_template: \"docs: {{.PageTitle}}\"\n  placeholder_text: \"Syncing…\"\nignore:\n  - \"Drafts/**\"\n  - \"**/scratch.md\"\nfilename_mode: transliterate\norder_prefix: true\n"
+	if err := os.WriteFile(filepath.Join(dir, config.SpaceConfigFileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadSpaceConfig(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.PullOverlap != 15*time.Minute {
+		t.Errorf("PullOverlap = %v; want 15m", cfg.PullOverlap)
+	}
+	if cfg.OnConflict != "cancel" {
+		t.Errorf("OnConflict = %q; want cancel", cfg.OnConflict)
+	}
+	if cfg.OnTitleConflict != "suffix" {
+		t.Errorf("OnTitleConflict = %q; want suffix", cfg.OnTitleConflict)
+	}
+	if strings.Join(cfg.Ignore, ",") != "Drafts/**,**/scratch.md" {
+		t.Errorf("Ignore = %v", cfg.Ignore)
+	}
+	if cfg.FilenameMode != "transliterate" {
+		t.Errorf("FilenameMode = %q; want transliterate", cfg.FilenameMode)
+	}
+	if !cfg.OrderPrefix {
+		t.Error("OrderPrefix = false; want true")
+	}
+	if !cfg.PullPageURL {
+		t.Error("PullPageURL = false; want true")
+	}
+	if cfg.PullCommitTemplate != "chore(docs): sync {{.SpaceKey}}" {
+		t.Errorf("PullCommitTemplate = %q", cfg.PullCommitTemplate)
+	}
+	if cfg.PushCommitTemplate != "docs: {{.PageTitle}}" {
+		t.Errorf("PushCommitTemplate = %q", cfg.PushCommitTemplate)
+	}
+	if cfg.PushPlaceholder != "Syncing…" {
+		t.Errorf("PushPlaceholder = %q", cfg.PushPlaceholder)
+	}
+	if strings.Join(cfg.PullProperties, ",") != "dashboard.*" {
+		t.Errorf("PullProperties = %v", cfg.PullProperties)
+	}
+}
+
+func TestLoadSpaceConfig_RejectsInvalidValues(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "unknown key", content: "hierarchy_layout: flat\n", want: "hierarchy_layout"},
+		{name: "bad overlap", content: "pull:\n  overlap: soon\n", want: "pull.overlap"},
+		{name: "negative overlap", content: "pull:\n  overlap: -1m\n", want: "pull.overlap"},
+		{name: "bad conflict policy", content: "push:\n  on_conflict: merge\n", want: "push.on_conflict"},
+		{name: "bad title policy", content: "push:\n  on_title_conflict: rename\n", want: "push.on_title_conflict"},
+		{name: "bad filename mode", content: "filename_mode: ascii\n", want: "filename_mode"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, config.SpaceConfigFileName), []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := config.LoadSpaceConfig(dir)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("LoadSpaceConfig() error = %v, want mention of %q", err, tc.want)
+			}
+		})
+	}
+}
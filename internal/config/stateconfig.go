@@ -0,0 +1,29 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadStateLocation reads state.location from <repoRoot>/.conf.yaml: where
+// space state files are kept ("space" or "git"). A missing file or key
+// returns an empty string, which selects the default.
+func LoadStateLocation(repoRoot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(repoRoot, ".conf.yaml")) //nolint:gosec // path is repo root + fixed filename
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var raw confYAML
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(raw.State.Location), nil
+}
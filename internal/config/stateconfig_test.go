@@ -0,0 +1,28 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
+)
+
+func TestLoadStateLocation(t *testing.T) {
+	if got, err := config.LoadStateLocation(t.TempDir()); err != nil || got != "" {
+		t.Fatalf("missing file: got %q, %v; want empty", got, err)
+	}
+
+	dir := t.TempDir()
+	content := "search:\n  engine: bleve\nstate:\n  location: git\n"
+	if err := os.WriteFile(filepath.Join(dir, ".conf.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := config.LoadStateLocation(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "git" {
+		t.Fatalf("LoadStateLocation() = %q; want git", got)
+	}
+}
@@ -0,0 +1,411 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// APIVersion selects the Confluence REST API generation used for space and
+// page listing, fetching and upsert.
+type APIVersion string
+
+const (
+	// APIVersionAuto uses v2 and switches to v1 once the instance turns out
+	// to have no v2 API.
+	APIVersionAuto APIVersion = "auto"
+	// APIVersionV2 always uses the v2 API (/wiki/api/v2).
+	APIVersionV2 APIVersion = "v2"
+	// APIVersionV1 uses the v1 API (/wiki/rest/api) where an equivalent
+	// exists; v2-only operations fail with ErrV2Unavailable.
+	APIVersionV1 APIVersion = "v1"
+)
+
+const v2APIPathPrefix = "/wiki/api/v2/"
+
+// ParseAPIVersion validates an API version setting. Empty means auto.
+func ParseAPIVersion(raw string) (APIVersion, error) {
+	switch version := APIVersion(strings.ToLower(strings.TrimSpace(raw))); version {
+	case "":
+		return APIVersionAuto, nil
+	case APIVersionAuto, APIVersionV2, APIVersionV1:
+		return version, nil
+	default:
+		return "", fmt.Errorf("invalid Confluence API version %q: must be auto, v2 or v1", raw)
+	}
+}
+
+type v2ProbeContextKey struct{}
+
+// usesV1 reports whether space and page operations should go to v1 endpoints.
+func (c *Client) usesV1() bool {
+	return c.apiVersion == APIVersionV1 || c.v2Missing.Load()
+}
+
+// checkV2Request runs before (err == nil) and after a request. It stops v2
+// requests once v1 is in use and, in auto mode, turns a 404 from a v2
+// endpoint into ErrV2Unavailable when the instance turns out to have no v2
+// API at all. Other errors are returned unchanged.
+func (c *Client) checkV2Request(req *http.Request, err error) error {
+	if !strings.HasPrefix(req.URL.Path, c.basePath()+v2APIPathPrefix) {
+		return err
+	}
+	if c.usesV1() {
+		return v2UnavailableError(req)
+	}
+	if err == nil || c.apiVersion != APIVersionAuto || !isHTTPStatus(err, http.StatusNotFound) {
+		return err
+	}
+	if req.Context().Value(v2ProbeContextKey{}) != nil {
+		return err
+	}
+
+	c.v2ProbeOnce.Do(func() {
+		c.v2Missing.Store(c.probeV2Missing(req.Context()))
+	})
+	if c.v2Missing.Load() {
+		return v2UnavailableError(req)
+	}
+	return err
+}
+
+func v2UnavailableError(req *http.Request) error {
+	return fmt.Errorf("%w (needed by %s %s)", ErrV2Unavailable, req.Method, req.URL.Path)
+}
+
+// probeV2Missing checks once whether the instance lacks the v2 API: the v2
+// space list must be missing while the v1 one answers, so an ordinary 404
+// for a missing page is never mistaken for an absent API.
+func (c *Client) probeV2Missing(ctx context.Context) bool {
+	ctx = context.WithValue(ctx, v2ProbeContextKey{}, true)
+	query := url.Values{"limit": []string{"1"}}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/api/v2/spaces", query, nil)
+	if err != nil {
+		return false
+	}
+	if err := c.do(req, nil); !isHTTPStatus(err, http.StatusNotFound) {
+		return false
+	}
+
+	req, err = c.newRequest(ctx, http.MethodGet, "/wiki/rest/api/space", query, nil)
+	if err != nil {
+		return false
+	}
+	return c.do(req, nil) == nil
+}
+
+func (c *Client) basePath() string {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(u.Path, "/")
+}
+
+// rememberSpaceKey records the key of a space ID; v1 page endpoints address
+// spaces by key while callers pass IDs.
+func (c *Client) rememberSpaceKey(space Space) {
+	if space.ID == "" || space.Key == "" {
+		return
+	}
+	c.spaceKeyByID.Store(space.ID, space.Key)
+}
+
+func (c *Client) spaceKeyForV1(spaceID, spaceKey string) (string, error) {
+	if key := strings.TrimSpace(spaceKey); key != "" {
+		return key, nil
+	}
+	if key, ok := c.spaceKeyByID.Load(strings.TrimSpace(spaceID)); ok {
+		return key.(string), nil
+	}
+	return "", fmt.Errorf("v1 API needs the key of space %s; resolve the space with GetSpace first", spaceID)
+}
+
+type v1SpaceDTO struct {
+	ID   json.Number `json:"id"`
+	Key  string      `json:"key"`
+	Name string      `json:"name"`
+	Type string      `json:"type"`
+}
+
+type v1ListResponse[T any] struct {
+	Results []T `json:"results"`
+	Start   int `json:"start"`
+	Limit   int `json:"limit"`
+	Size    int `json:"size"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
+}
+
+// nextV1Cursor turns v1 offset paging into the opaque cursor used by the
+// list results.
+func nextV1Cursor[T any](payload v1ListResponse[T]) string {
+	if strings.TrimSpace(payload.Links.Next) == "" {
+		return ""
+	}
+	next := extractNextStart(payload.Start, payload.Links.Next)
+	if next <= payload.Start {
+		next = payload.Start + payload.Size
+	}
+	return strconv.Itoa(next)
+}
+
+func (c *Client) listSpacesV1(ctx context.Context, opts SpaceListOptions) (SpaceListResult, error) {
+	query := url.Values{}
+	for _, key := range opts.Keys {
+		query.Add("spaceKey", key)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("start", opts.Cursor)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/rest/api/space", query, nil)
+	if err != nil {
+		return SpaceListResult{}, err
+	}
+
+	var payload v1ListResponse[v1SpaceDTO]
+	if err := c.do(req, &payload); err != nil {
+		return SpaceListResult{}, err
+	}
+
+	out := SpaceListResult{
+		Spaces:     make([]Space, 0, len(payload.Results)),
+		NextCursor: nextV1Cursor(payload),
+	}
+	for _, item := range payload.Results {
+		space := Space{ID: item.ID.String(), Key: item.Key, Name: item.Name, Type: item.Type}
+		c.rememberSpaceKey(space)
+		out.Spaces = append(out.Spaces, space)
+	}
+	return out, nil
+}
+
+type v1PageDTO struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Space  struct {
+		ID  json.Number `json:"id"`
+		Key string      `json:"key"`
+	} `json:"space"`
+	Ancestors []struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"ancestors"`
+	Version struct {
+		Number int    `json:"number"`
+		When   string `json:"when"`
+		By     struct {
+			AccountID string `json:"accountId"`
+		} `json:"by"`
+	} `json:"version"`
+	History struct {
+		CreatedDate string `json:"createdDate"`
+		CreatedBy   struct {
+			AccountID string `json:"accountId"`
+		} `json:"createdBy"`
+	} `json:"history"`
+	Body struct {
+		AtlasDocFormat struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"atlas_doc_format"`
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Links struct {
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+func (p v1PageDTO) toModel(baseURL string) Page {
+	page := Page{
+		ID:                   p.ID,
+		SpaceID:              p.Space.ID.String(),
+		Title:                p.Title,
+		Status:               p.Status,
+		Version:              p.Version.Number,
+		AuthorID:             p.History.CreatedBy.AccountID,
+		CreatedAt:            parseRemoteTime(p.History.CreatedDate),
+		LastModifiedAuthorID: p.Version.By.AccountID,
+		LastModified:         parseRemoteTime(p.Version.When),
+		WebURL:               resolveWebURL(baseURL, p.Links.WebUI),
+		BodyADF:              normalizeADFValue(p.Body.AtlasDocFormat.Value),
+		BodyStorage:          strings.TrimSpace(p.Body.Storage.Value),
+	}
+	if n := len(p.Ancestors); n > 0 {
+		page.ParentPageID = p.Ancestors[n-1].ID
+		page.ParentType = firstNonEmpty(p.Ancestors[n-1].Type, "page")
+	}
+	if len(page.BodyADF) > 0 {
+		page.BodyStorage = ""
+	}
+	return page
+}
+
+const v1PageListExpand = "version,ancestors,space,history"
+
+func (c *Client) listPagesV1(ctx context.Context, opts PageListOptions) (PageListResult, error) {
+	spaceKey, err := c.spaceKeyForV1(opts.SpaceID, opts.SpaceKey)
+	if err != nil {
+		return PageListResult{}, err
+	}
+
+	query := url.Values{}
+	query.Set("type", "page")
+	query.Set("spaceKey", spaceKey)
+	query.Set("status", defaultPageStatus(opts.Status))
+	query.Set("expand", v1PageListExpand)
+	if opts.Title != "" {
+		query.Set("title", opts.Title)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("start", opts.Cursor)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/rest/api/content", query, nil)
+	if err != nil {
+		return PageListResult{}, err
+	}
+
+	var payload v1ListResponse[v1PageDTO]
+	if err := c.do(req, &payload); err != nil {
+		return PageListResult{}, err
+	}
+
+	out := PageListResult{
+		Pages:      make([]Page, 0, len(payload.Results)),
+		NextCursor: nextV1Cursor(payload),
+	}
+	for _, item := range payload.Results {
+		out.Pages = append(out.Pages, item.toModel(c.baseURL))
+	}
+	return out, nil
+}
+
+func (c *Client) getPageV1(ctx context.Context, pageID, etag string) (Page, error) {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodGet,
+		"/wiki/rest/api/content/"+url.PathEscape(pageID),
+		url.Values{"expand": []string{v1PageListExpand + ",body.atlas_doc_format,body.storage"}},
+		nil,
+	)
+	if err != nil {
+		return Page{}, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var payload v1PageDTO
+	header, err := c.doWithHeader(req, &payload)
+	if err != nil {
+		if isHTTPStatus(err, http.StatusNotModified) {
+			return Page{}, ErrNotModified
+		}
+		if isHTTPStatus(err, http.StatusNotFound) {
+			return Page{}, ErrNotFound
+		}
+		if isArchivedAPIError(err) {
+			return Page{}, ErrArchived
+		}
+		return Page{}, err
+	}
+	page := payload.toModel(c.baseURL)
+	page.ETag = strings.TrimSpace(header.Get("ETag"))
+	return page, nil
+}
+
+func (c *Client) v1PageWritePayload(id string, input PageUpsertInput) (map[string]any, error) {
+	payload := map[string]any{
+		"type":   "page",
+		"title":  strings.TrimSpace(input.Title),
+		"status": defaultPageStatus(input.Status),
+	}
+	if id != "" {
+		payload["id"] = id
+	}
+	if spaceID := strings.TrimSpace(input.SpaceID); spaceID != "" || id == "" {
+		spaceKey, err := c.spaceKeyForV1(spaceID, "")
+		if err != nil {
+			return nil, err
+		}
+		payload["space"] = map[string]any{"key": spaceKey}
+	}
+	if parentID := strings.TrimSpace(input.ParentPageID); parentID != "" {
+		payload["ancestors"] = []map[string]any{{"id": parentID}}
+	}
+	if input.Version > 0 {
+		payload["version"] = pageVersionPayload(input)
+	}
+	if len(input.BodyADF) > 0 {
+		payload["body"] = map[string]any{
+			"atlas_doc_format": map[string]any{
+				"representation": "atlas_doc_format",
+				"value":          string(input.BodyADF),
+			},
+		}
+	}
+	return payload, nil
+}
+
+func (c *Client) createPageV1(ctx context.Context, input PageUpsertInput) (Page, error) {
+	body, err := c.v1PageWritePayload("", input)
+	if err != nil {
+		return Page{}, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/wiki/rest/api/content", nil, body)
+	if err != nil {
+		return Page{}, err
+	}
+
+	var payload v1PageDTO
+	if err := c.do(req, &payload); err != nil {
+		return Page{}, err
+	}
+	return payload.toModel(c.baseURL), nil
+}
+
+func (c *Client) updatePageV1(ctx context.Context, pageID string, input PageUpsertInput) (Page, error) {
+	body, err := c.v1PageWritePayload(pageID, input)
+	if err != nil {
+		return Page{}, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPut, "/wiki/rest/api/content/"+url.PathEscape(pageID), nil, body)
+	if err != nil {
+		return Page{}, err
+	}
+
+	var payload v1PageDTO
+	if err := c.do(req, &payload); err != nil {
+		if isHTTPStatus(err, http.StatusNotFound) {
+			return Page{}, ErrNotFound
+		}
+		if isArchivedAPIError(err) {
+			return Page{}, ErrArchived
+		}
+		return Page{}, err
+	}
+	return payload.toModel(c.baseURL), nil
+}
+
+// isV2Unavailable reports whether a v2 call failed because the instance has
+// no v2 API, so the caller can retry with its v1 equivalent.
+func isV2Unavailable(err error) bool {
+	return errors.Is(err, ErrV2Unavailable)
+}
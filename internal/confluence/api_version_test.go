@@ -0,0 +1,173 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newV1OnlyServer serves the v1 space and page endpoints and answers every v2
+// request with a plain 404, like a Data Center instance without the v2 API.
+func newV1OnlyServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/wiki/api/v2/"):
+			http.NotFound(w, r)
+		case r.URL.Path == "/wiki/rest/api/space":
+			_, _ = io.WriteString(w, `{"results":[{"id":98305,"key":"ENG","name":"Engineering","type":"global"}],"start":0,"limit":25,"size":1}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/content":
+			if got := r.URL.Query().Get("spaceKey"); got != "ENG" {
+				t.Errorf("v1 page list spaceKey = %q, want ENG", got)
+			}
+			_, _ = io.WriteString(w, `{"results":[{"id":"2","status":"current","title":"Child","space":{"id":98305,"key":"ENG"},"ancestors":[{"id":"1","type":"page"}],"version":{"number":3}}],"start":0,"limit":25,"size":1}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/content/2":
+			_, _ = io.WriteString(w, `{"id":"2","status":"current","title":"Child","space":{"id":98305,"key":"ENG"},"ancestors":[{"id":"1","type":"page"}],"version":{"number":3,"when":"2026-03-06T12:00:00.000Z","by":{"accountId":"acc-1"}},"body":{"atlas_doc_format":{"value":"{\"type\":\"doc\",\"version\":1,\"content\":[]}"}}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/wiki/rest/api/content":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("decode create payload: %v", err)
+			}
+			space, _ := payload["space"].(map[string]any)
+			if payload["type"] != "page" || space["key"] != "ENG" {
+				t.Errorf("unexpected v1 create payload: %v", payload)
+			}
+			_, _ = io.WriteString(w, `{"id":"3","status":"current","title":"New","space":{"id":98305,"key":"ENG"},"version":{"number":1}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestClient_AutoAPIVersionFallsBackToV1WhenV2IsMissing(t *testing.T) {
+	server, requests := newV1OnlyServer(t)
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "u", APIToken: "t"})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	space, err := client.GetSpace(ctx, "ENG")
+	if err != nil {
+		t.Fatalf("GetSpace() error: %v", err)
+	}
+	if space.ID != "98305" || space.Key != "ENG" {
+		t.Fatalf("space = %+v", space)
+	}
+
+	pages, err := client.ListPages(ctx, PageListOptions{SpaceID: space.ID})
+	if err != nil {
+		t.Fatalf("ListPages() error: %v", err)
+	}
+	if len(pages.Pages) != 1 || pages.Pages[0].ParentPageID != "1" || pages.Pages[0].Version != 3 {
+		t.Fatalf("pages = %+v", pages.Pages)
+	}
+
+	if _, err := client.ListFooterComments(ctx, "2"); !errors.Is(err, ErrV2Unavailable) {
+		t.Fatalf("ListFooterComments() error = %v, want ErrV2Unavailable", err)
+	}
+
+	v2Requests := 0
+	for _, request := range requests() {
+		if strings.Contains(request, "/wiki/api/v2/") {
+			v2Requests++
+		}
+	}
+	// The failed space lookup plus the one-time /spaces probe; later calls
+	// go straight to v1 or fail without a request.
+	if v2Requests != 2 {
+		t.Fatalf("v2 requests = %d, want 2: %v", v2Requests, requests())
+	}
+}
+
+func TestClient_V1APIVersionUsesV1PageEndpoints(t *testing.T) {
+	server, requests := newV1OnlyServer(t)
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "u", APIToken: "t", APIVersion: APIVersionV1})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	space, err := client.GetSpace(ctx, "ENG")
+	if err != nil {
+		t.Fatalf("GetSpace() error: %v", err)
+	}
+	page, err := client.GetPage(ctx, "2")
+	if err != nil {
+		t.Fatalf("GetPage() error: %v", err)
+	}
+	if page.SpaceID != space.ID || page.LastModifiedAuthorID != "acc-1" || !strings.Contains(string(page.BodyADF), `"type":"doc"`) {
+		t.Fatalf("page = %+v", page)
+	}
+	created, err := client.CreatePage(ctx, PageUpsertInput{SpaceID: space.ID, Title: "New", BodyADF: []byte(`{"type":"doc","version":1,"content":[]}`)})
+	if err != nil {
+		t.Fatalf("CreatePage() error: %v", err)
+	}
+	if created.ID != "3" {
+		t.Fatalf("created page = %+v", created)
+	}
+
+	if _, err := client.ListAttachments(ctx, "2"); !errors.Is(err, ErrV2Unavailable) {
+		t.Fatalf("ListAttachments() error = %v, want ErrV2Unavailable", err)
+	}
+	for _, request := range requests() {
+		if strings.Contains(request, "/wiki/api/v2/") {
+			t.Fatalf("v1 mode sent a v2 request: %v", requests())
+		}
+	}
+}
+
+func TestClient_AutoAPIVersionKeepsNotFoundWhenV2Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/wiki/api/v2/spaces" {
+			_, _ = io.WriteString(w, `{"results":[]}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "u", APIToken: "t"})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	if _, err := client.GetPage(context.Background(), "404"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetPage() error = %v, want ErrNotFound", err)
+	}
+	if client.usesV1() {
+		t.Fatalf("a missing page must not switch the client to v1")
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	for raw, want := range map[string]APIVersion{"": APIVersionAuto, " V1 ": APIVersionV1, "v2": APIVersionV2, "auto": APIVersionAuto} {
+		got, err := ParseAPIVersion(raw)
+		if err != nil || got != want {
+			t.Errorf("ParseAPIVersion(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	if _, err := ParseAPIVersion("v3"); err == nil {
+		t.Fatalf("expected v3 to be rejected")
+	}
+}
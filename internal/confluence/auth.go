@@ -0,0 +1,52 @@
+package confluence
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AuthMode selects how requests authenticate against Confluence.
+type AuthMode string
+
+const (
+	// AuthModeBasic sends HTTP basic auth with the account email and API
+	// token, as Confluence Cloud expects.
+	AuthModeBasic AuthMode = "basic"
+	// AuthModeBearer sends the token as "Authorization: Bearer <token>", as
+	// Personal Access Tokens on Confluence Data Center expect. No email is
+	// needed.
+	AuthModeBearer AuthMode = "bearer"
+)
+
+// ParseAuthMode validates an auth mode setting. Empty is returned as is and
+// means the mode is inferred from the credentials (see ResolveAuthMode).
+func ParseAuthMode(raw string) (AuthMode, error) {
+	switch mode := AuthMode(strings.ToLower(strings.TrimSpace(raw))); mode {
+	case "", AuthModeBasic, AuthModeBearer:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid Confluence auth mode %q: must be basic or bearer", raw)
+	}
+}
+
+// ResolveAuthMode returns mode, or when it is empty infers bearer for a
+// token without an email and basic otherwise.
+func ResolveAuthMode(mode AuthMode, email string) AuthMode {
+	if mode != "" {
+		return mode
+	}
+	if strings.TrimSpace(email) == "" {
+		return AuthModeBearer
+	}
+	return AuthModeBasic
+}
+
+// setAuth adds the Authorization header for the client's auth mode.
+func (c *Client) setAuth(req *http.Request) {
+	if c.authMode == AuthModeBearer {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+		return
+	}
+	req.SetBasicAuth(c.email, c.apiToken)
+}
@@ -0,0 +1,93 @@
+//nolint:errcheck // test handlers intentionally ignore best-effort response write errors
+package confluence
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewClient_BearerAuthWithoutEmailSendsBearerHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer pat-123" {
+			t.Fatalf("%s Authorization = %q, want %q", r.URL.Path, got, "Bearer pat-123")
+		}
+		switch r.URL.Path {
+		case "/wiki/api/v2/attachments/att-1":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id":"att-1","downloadLink":"/download/attachments/1/diagram.png"}`)
+		case "/download/attachments/1/diagram.png":
+			io.WriteString(w, "binary-data")
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		APIToken: "pat-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if client.authMode != AuthModeBearer {
+		t.Fatalf("auth mode = %q, want %q", client.authMode, AuthModeBearer)
+	}
+
+	var buf strings.Builder
+	if err := client.DownloadAttachment(context.Background(), "att-1", "123", &buf); err != nil {
+		t.Fatalf("DownloadAttachment() unexpected error: %v", err)
+	}
+	if buf.String() != "binary-data" {
+		t.Fatalf("attachment bytes = %q, want %q", buf.String(), "binary-data")
+	}
+}
+
+func TestNewClient_ExplicitBearerIgnoresEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Fatal("request uses basic auth, want bearer")
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer pat-123" {
+			t.Fatalf("Authorization = %q, want %q", got, "Bearer pat-123")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "pat-123",
+		AuthMode: "Bearer",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if _, err := client.ListSpaces(context.Background(), SpaceListOptions{}); err != nil {
+		t.Fatalf("ListSpaces() unexpected error: %v", err)
+	}
+}
+
+func TestNewClient_AuthModeValidation(t *testing.T) {
+	if _, err := NewClient(ClientConfig{
+		BaseURL:  "https://example.test",
+		APIToken: "token-123",
+		AuthMode: AuthModeBasic,
+	}); err == nil || !strings.Contains(err.Error(), "email is required") {
+		t.Fatalf("NewClient() basic without email error = %v, want email is required", err)
+	}
+	if _, err := NewClient(ClientConfig{
+		BaseURL:  "https://example.test",
+		Email:    "user@example.com",
+		APIToken: "token-123",
+		AuthMode: "oauth",
+	}); err == nil || !strings.Contains(err.Error(), "invalid Confluence auth mode") {
+		t.Fatalf("NewClient() unknown mode error = %v, want invalid auth mode", err)
+	}
+}
@@ -3,6 +3,8 @@ package confluence
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,9 +12,14 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
 )
 
 const (
@@ -39,10 +46,52 @@ type ClientConfig struct {
 	HTTPClient *http.Client
 	UserAgent  string
 
+	// AuthMode selects basic (email and API token) or bearer (Personal
+	// Access Token) auth. Empty means bearer when Email is empty and basic
+	// otherwise.
+	AuthMode AuthMode
+
+	// HTTPTimeout bounds each API request of the default HTTP client; zero
+	// means 60 seconds. Attachment downloads allow at least 30 minutes, or
+	// HTTPTimeout when it is longer. It is ignored when HTTPClient is supplied.
+	HTTPTimeout time.Duration
+
+	// CABundle and InsecureSkipVerify tune TLS for the default transport.
+	// They are ignored when HTTPClient is supplied.
+	CABundle           string
+	InsecureSkipVerify bool
+
 	RateLimitRPS     int
 	RetryMaxAttempts int
 	RetryBaseDelay   time.Duration
 	RetryMaxDelay    time.Duration
+
+	// APIVersion selects v2 or v1 endpoints for space and page operations;
+	// empty means APIVersionAuto.
+	APIVersion APIVersion
+
+	// RequestObserver, when set, is called after every HTTP attempt the
+	// client makes, including retries, error responses and attachment
+	// downloads. It runs synchronously on the request goroutine, so it should
+	// return quickly.
+	RequestObserver func(RequestInfo)
+}
+
+// RequestInfo describes one HTTP attempt reported to
+// ClientConfig.RequestObserver. It never carries request headers, so the
+// Authorization header cannot leak through it.
+type RequestInfo struct {
+	Method string
+	// URL is the request URL with any user info redacted.
+	URL string
+	// StatusCode is 0 when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	// Retry is the number of earlier attempts for the same request: 0 for
+	// the first attempt, 1 for the first retry, and so on.
+	Retry int
+	// Err is the transport error when no response was received.
+	Err error
 }
 
 // Client is an HTTP-backed Confluence API client.
@@ -50,24 +99,36 @@ type Client struct {
 	baseURL        string
 	email          string
 	apiToken       string
+	authMode       AuthMode
 	httpClient     *http.Client
 	downloadClient *http.Client
 	limiter        *rateLimiter
 	retry          retryPolicy
 	userAgent      string
+	observer       func(RequestInfo)
+
+	apiVersion   APIVersion
+	v2ProbeOnce  sync.Once
+	v2Missing    atomic.Bool
+	spaceKeyByID sync.Map
 }
 
 // NewClient creates a Confluence HTTP client.
 func NewClient(cfg ClientConfig) (*Client, error) {
-	baseURL := strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	baseURL := config.NormalizeBaseURL(cfg.BaseURL)
 	email := strings.TrimSpace(cfg.Email)
 	token := strings.TrimSpace(cfg.APIToken)
 
 	if baseURL == "" {
 		return nil, errors.New("confluence base URL is required")
 	}
-	if email == "" {
-		return nil, errors.New("confluence email is required")
+	authMode, err := ParseAuthMode(string(cfg.AuthMode))
+	if err != nil {
+		return nil, err
+	}
+	authMode = ResolveAuthMode(authMode, email)
+	if authMode == AuthModeBasic && email == "" {
+		return nil, errors.New("confluence email is required for basic auth")
 	}
 	if token == "" {
 		return nil, errors.New("confluence API token is required")
@@ -76,21 +137,39 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("invalid confluence base URL: %w", err)
 	}
 
+	httpTimeout := cfg.HTTPTimeout
+	if httpTimeout <= 0 {
+		httpTimeout = defaultHTTPTimeout
+	}
+
 	var transport http.RoundTripper
 	httpClient := cfg.HTTPClient
 	if httpClient == nil {
 		// Clone DefaultTransport so both clients share the same connection pool
-		// and TLS settings, but we can tune timeouts independently.
+		// and TLS settings, but we can tune timeouts independently. The clone
+		// keeps ProxyFromEnvironment, so HTTPS_PROXY/HTTP_PROXY/NO_PROXY apply.
 		t := http.DefaultTransport.(*http.Transport).Clone()
+		tlsConfig, err := newTLSConfig(cfg.CABundle, cfg.InsecureSkipVerify)
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			t.TLSClientConfig = tlsConfig
+		}
 		transport = t
 		httpClient = &http.Client{
-			Timeout:   defaultHTTPTimeout,
+			Timeout:   httpTimeout,
 			Transport: transport,
 		}
 	} else {
 		transport = httpClient.Transport
 	}
 
+	apiVersion, err := ParseAPIVersion(string(cfg.APIVersion))
+	if err != nil {
+		return nil, err
+	}
+
 	userAgent := strings.TrimSpace(cfg.UserAgent)
 	if userAgent == "" {
 		userAgent = defaultUserAgent
@@ -111,7 +190,7 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 	retry := newRetryPolicy(retryAttempts, cfg.RetryBaseDelay, cfg.RetryMaxDelay)
 
 	downloadClient := &http.Client{
-		Timeout:   defaultDownloadTimeout,
+		Timeout:   max(defaultDownloadTimeout, httpTimeout),
 		Transport: transport,
 	}
 
@@ -119,14 +198,63 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 		baseURL:        baseURL,
 		email:          email,
 		apiToken:       token,
+		authMode:       authMode,
 		httpClient:     httpClient,
 		downloadClient: downloadClient,
 		limiter:        newRateLimiter(rateLimitRPS),
 		retry:          retry,
 		userAgent:      userAgent,
+		observer:       cfg.RequestObserver,
+		apiVersion:     apiVersion,
 	}, nil
 }
 
+// newTLSConfig returns nil when no TLS customization is requested.
+func newTLSConfig(caBundle string, insecureSkipVerify bool) (*tls.Config, error) {
+	caBundle = strings.TrimSpace(caBundle)
+	if caBundle == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify, //nolint:gosec // explicit, loudly discouraged opt-in
+	}
+	if caBundle != "" {
+		pemBytes, err := os.ReadFile(caBundle) //nolint:gosec // CA bundle path is user-configured
+		if err != nil {
+			return nil, fmt.Errorf("read CA bundle %s: %w", caBundle, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", caBundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// observeRequest reports one HTTP attempt to the configured RequestObserver.
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, err error, started time.Time, attempt int) {
+	if c.observer == nil {
+		return
+	}
+	info := RequestInfo{
+		Method:   req.Method,
+		URL:      req.URL.Redacted(),
+		Duration: time.Since(started),
+		Retry:    attempt,
+		Err:      err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	c.observer(info)
+}
+
 // Close releases background resources used by the client.
 func (c *Client) Close() error {
 	if c == nil || c.limiter == nil {
@@ -171,6 +299,33 @@ func (c *Client) GetUser(ctx context.Context, accountID string) (User, error) {
 	return User(payload), nil
 }
 
+// GetCurrentUser returns the account the client's credentials authenticate
+// as. Credentials Confluence does not accept fail with a 401 APIError, also
+// when the site answers with its anonymous user instead.
+func (c *Client) GetCurrentUser(ctx context.Context) (User, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/rest/api/user/current", nil, nil)
+	if err != nil {
+		return User{}, err
+	}
+
+	var payload struct {
+		userDTO
+		Type string `json:"type"`
+	}
+	if err := c.do(req, &payload); err != nil {
+		return User{}, err
+	}
+	if strings.EqualFold(payload.Type, "anonymous") || strings.TrimSpace(payload.AccountID) == "" {
+		return User{}, &APIError{
+			StatusCode: http.StatusUnauthorized,
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			Message:    "the credentials were not accepted; Confluence answered as an anonymous user",
+		}
+	}
+	return User(payload.userDTO), nil
+}
+
 func (c *Client) newRequest(
 	ctx context.Context,
 	method string,
@@ -207,7 +362,7 @@ func (c *Client) newRequest(
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(c.email, c.apiToken)
+	c.setAuth(req)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
 	if body != nil {
@@ -217,14 +372,34 @@ func (c *Client) newRequest(
 }
 
 func (c *Client) do(req *http.Request, out any) error {
-	slog.Debug("http request", "method", req.Method, "url", req.URL.String()) //nolint:gosec // Safe log
+	_, err := c.doWithHeader(req, out)
+	return err
+}
 
-	if err := c.limiter.wait(req.Context()); err != nil {
-		return err
+// doWithHeader is do that also returns the headers of the final response.
+func (c *Client) doWithHeader(req *http.Request, out any) (http.Header, error) {
+	if err := c.checkV2Request(req, nil); err != nil {
+		return nil, err
+	}
+	header, err := c.send(req, out)
+	if err != nil {
+		return nil, c.checkV2Request(req, err)
 	}
+	return header, nil
+}
+
+func (c *Client) send(req *http.Request, out any) (http.Header, error) {
+	slog.Debug("http request", "method", req.Method, "url", req.URL.String()) //nolint:gosec // Safe log
 
 	for attempt := 0; ; attempt++ {
+		// Retries take a token too, so they slow down with everything else.
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		started := time.Now()
 		resp, err := c.httpClient.Do(req) //nolint:gosec // Target URL comes from API client internals
+		c.observeRequest(req, resp, err, started, attempt)
+		c.limiter.observe(resp)
 		if err != nil {
 			if c.retry.shouldRetry(req, nil, err, attempt) {
 				delay := c.retry.retryDelay(attempt+1, nil)
@@ -237,19 +412,26 @@ func (c *Client) do(req *http.Request, out any) error {
 					"error", err,
 				)
 				if sleepErr := contextSleep(req.Context(), delay); sleepErr != nil {
-					return sleepErr
+					return nil, sleepErr
 				}
 				if req.GetBody != nil {
 					newBody, gbErr := req.GetBody()
 					if gbErr != nil {
-						return fmt.Errorf("reset request body for retry: %w", gbErr)
+						return nil, fmt.Errorf("reset request body for retry: %w", gbErr)
 					}
 					req.Body = newBody
 				}
 				continue
 			}
-			return err
+			return nil, err
 		}
+		slog.Debug("http response", //nolint:gosec // Safe log
+			"method", req.Method,
+			"url", req.URL.String(),
+			"status", resp.StatusCode,
+			"duration_ms", time.Since(started).Milliseconds(),
+			"attempt", attempt+1,
+		)
 
 		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 			bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
@@ -266,19 +448,19 @@ func (c *Client) do(req *http.Request, out any) error {
 					"status", resp.StatusCode,
 				)
 				if sleepErr := contextSleep(req.Context(), delay); sleepErr != nil {
-					return sleepErr
+					return nil, sleepErr
 				}
 				if req.GetBody != nil {
 					newBody, gbErr := req.GetBody()
 					if gbErr != nil {
-						return fmt.Errorf("reset request body for retry: %w", gbErr)
+						return nil, fmt.Errorf("reset request body for retry: %w", gbErr)
 					}
 					req.Body = newBody
 				}
 				continue
 			}
 
-			return &APIError{
+			return nil, &APIError{
 				StatusCode: resp.StatusCode,
 				Method:     req.Method,
 				URL:        req.URL.String(),
@@ -293,14 +475,14 @@ func (c *Client) do(req *http.Request, out any) error {
 
 		if out == nil {
 			_, _ = io.Copy(io.Discard, resp.Body)
-			return nil
+			return resp.Header, nil
 		}
 
 		if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
-			return fmt.Errorf("decode response JSON: %w", err)
+			return nil, fmt.Errorf("decode response JSON: %w", err)
 		}
 		_, _ = io.Copy(io.Discard, resp.Body)
-		return nil
+		return resp.Header, nil
 	}
 }
 
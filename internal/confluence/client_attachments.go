@@ -13,6 +13,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type attachmentDTO struct {
@@ -21,6 +22,7 @@ type attachmentDTO struct {
 	Title        string `json:"title"`
 	Filename     string `json:"filename"`
 	MediaType    string `json:"mediaType"`
+	FileSize     int64  `json:"fileSize"`
 	DownloadLink string `json:"downloadLink"`
 	Links        struct {
 		Download string `json:"download"`
@@ -101,7 +103,7 @@ func (c *Client) resolveAttachmentIDByFileID(ctx context.Context, fileID string,
 		if err != nil {
 			return "", err
 		}
-		req.SetBasicAuth(c.email, c.apiToken)
+		c.setAuth(req)
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", c.userAgent)
 
@@ -143,11 +145,13 @@ func (c *Client) ListAttachments(ctx context.Context, pageID string) ([]Attachme
 			}
 
 			attachments = append(attachments, Attachment{
-				ID:        attachmentID,
-				FileID:    strings.TrimSpace(item.FileID),
-				PageID:    pageID,
-				Filename:  firstNonEmpty(item.Title, item.Filename),
-				MediaType: item.MediaType,
+				ID:           attachmentID,
+				FileID:       strings.TrimSpace(item.FileID),
+				PageID:       pageID,
+				Filename:     firstNonEmpty(item.Title, item.Filename),
+				MediaType:    item.MediaType,
+				FileSize:     item.FileSize,
+				DownloadLink: c.attachmentDownloadLink(item),
 			})
 		}
 
@@ -164,7 +168,7 @@ func (c *Client) ListAttachments(ctx context.Context, pageID string) ([]Attachme
 		if err != nil {
 			return nil, err
 		}
-		req.SetBasicAuth(c.email, c.apiToken)
+		c.setAuth(req)
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", c.userAgent)
 
@@ -194,15 +198,32 @@ func (c *Client) GetAttachment(ctx context.Context, attachmentID string) (Attach
 	}
 
 	return Attachment{
-		ID:        strings.TrimSpace(payload.ID),
-		FileID:    strings.TrimSpace(payload.FileID),
-		Filename:  firstNonEmpty(payload.Title, payload.Filename),
-		MediaType: payload.MediaType,
-		WebURL:    resolveWebURL(c.baseURL, payload.Links.Download),
+		ID:           strings.TrimSpace(payload.ID),
+		FileID:       strings.TrimSpace(payload.FileID),
+		Filename:     firstNonEmpty(payload.Title, payload.Filename),
+		MediaType:    payload.MediaType,
+		FileSize:     payload.FileSize,
+		WebURL:       resolveWebURL(c.baseURL, payload.Links.Download),
+		DownloadLink: c.attachmentDownloadLink(payload),
 	}, nil
 }
 
-// DownloadAttachment downloads attachment bytes by attachment ID.
+// attachmentDownloadLink returns the absolute download URL reported for an
+// attachment, preferring downloadLink over _links.download.
+func (c *Client) attachmentDownloadLink(item attachmentDTO) string {
+	downloadURL := strings.TrimSpace(item.DownloadLink)
+	if downloadURL == "" {
+		downloadURL = strings.TrimSpace(item.Links.Download)
+	}
+	if downloadURL == "" {
+		return ""
+	}
+	return resolveWebURL(c.baseURL, downloadURL)
+}
+
+// DownloadAttachment downloads attachment bytes by attachment ID. When the
+// attachment metadata reports a file size, a download with a different byte
+// count fails with ErrAttachmentIncomplete; out may then hold partial data.
 func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, pageID string, out io.Writer) error {
 	id := strings.TrimSpace(attachmentID)
 	if id == "" {
@@ -234,15 +255,10 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, pa
 		return err
 	}
 
-	downloadURL := strings.TrimSpace(payload.DownloadLink)
-	if downloadURL == "" {
-		downloadURL = strings.TrimSpace(payload.Links.Download)
+	resolvedDownloadURL := c.attachmentDownloadLink(payload)
+	if resolvedDownloadURL == "" {
+		resolvedDownloadURL = resolveWebURL(c.baseURL, "/wiki/api/v2/attachments/"+url.PathEscape(id)+"/download")
 	}
-	if downloadURL == "" {
-		downloadURL = "/wiki/api/v2/attachments/" + url.PathEscape(id) + "/download"
-	}
-
-	resolvedDownloadURL := resolveWebURL(c.baseURL, downloadURL)
 	if strings.TrimSpace(resolvedDownloadURL) == "" {
 		return fmt.Errorf("attachment %s download URL is empty", id)
 	}
@@ -258,7 +274,7 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, pa
 	if u, err := url.Parse(resolvedDownloadURL); err == nil {
 		if baseU, err := url.Parse(c.baseURL); err == nil {
 			if u.Host == baseU.Host {
-				downloadReq.SetBasicAuth(c.email, c.apiToken)
+				c.setAuth(downloadReq)
 			}
 		}
 	}
@@ -268,10 +284,25 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, pa
 
 	slog.Debug("http request", "method", downloadReq.Method, "url", downloadReq.URL.String()) //nolint:gosec // Safe log of request URL
 
+	if err := c.limiter.wait(ctx); err != nil {
+		return fmt.Errorf("download attachment %s: %w", id, err)
+	}
+	started := time.Now()
 	resp, err := c.downloadClient.Do(downloadReq) //nolint:gosec // Intended SSRF for downloading user's content
+	c.observeRequest(downloadReq, resp, err, started, 0)
+	c.limiter.observe(resp)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("download attachment %s: %w", id, ctxErr)
+		}
 		return err
 	}
+	slog.Debug("http response", //nolint:gosec // Safe log of request URL
+		"method", downloadReq.Method,
+		"url", downloadReq.URL.String(),
+		"status", resp.StatusCode,
+		"duration_ms", time.Since(started).Milliseconds(),
+	)
 	defer func() {
 		_ = resp.Body.Close()
 	}()
@@ -290,10 +321,16 @@ func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, pa
 		}
 	}
 
-	_, err = io.Copy(out, resp.Body)
+	written, err := io.Copy(out, resp.Body)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("download attachment %s: %w", id, ctxErr)
+		}
 		return fmt.Errorf("write attachment response: %w", err)
 	}
+	if payload.FileSize > 0 && written != payload.FileSize {
+		return fmt.Errorf("%w: attachment %s: received %d bytes, expected %d", ErrAttachmentIncomplete, id, written, payload.FileSize)
+	}
 
 	return nil
 }
@@ -332,7 +369,7 @@ func (c *Client) UploadAttachment(ctx context.Context, input AttachmentUploadInp
 	if err != nil {
 		return Attachment{}, err
 	}
-	req.SetBasicAuth(c.email, c.apiToken)
+	c.setAuth(req)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("X-Atlassian-Token", "no-check")
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDownloadAttachment_ResolvesUUID(t *testing.T) {
@@ -68,7 +69,7 @@ func TestListAttachments_PaginatesAndMapsFields(t *testing.T) {
 				t.Fatalf("first call path = %s", r.URL.Path)
 			}
 			if _, err := io.WriteString(w, `{
-				"results":[{"id":"att-1","fileId":"file-1","title":"diagram.png","mediaType":"image/png"}],
+				"results":[{"id":"att-1","fileId":"file-1","title":"diagram.png","mediaType":"image/png","downloadLink":"/wiki/download/attachments/123/diagram.png"}],
 				"_links":{"next":"/wiki/api/v2/pages/123/attachments?cursor=next-token"}
 			}`); err != nil {
 				t.Fatalf("write response: %v", err)
@@ -77,7 +78,7 @@ func TestListAttachments_PaginatesAndMapsFields(t *testing.T) {
 			if !strings.Contains(r.URL.RawQuery, "cursor=next-token") {
 				t.Fatalf("second call query = %s", r.URL.RawQuery)
 			}
-			if _, err := io.WriteString(w, `{"results":[{"id":"att-2","fileId":"file-2","filename":"spec.pdf","mediaType":"application/pdf"}]}`); err != nil {
+			if _, err := io.WriteString(w, `{"results":[{"id":"att-2","fileId":"file-2","filename":"spec.pdf","mediaType":"application/pdf","_links":{"download":"/wiki/download/attachments/123/spec.pdf"}}]}`); err != nil {
 				t.Fatalf("write response: %v", err)
 			}
 		default:
@@ -114,6 +115,12 @@ func TestListAttachments_PaginatesAndMapsFields(t *testing.T) {
 	if attachments[1].FileID != "file-2" {
 		t.Fatalf("second attachment file id = %q, want file-2", attachments[1].FileID)
 	}
+	if want := server.URL + "/wiki/download/attachments/123/diagram.png"; attachments[0].DownloadLink != want {
+		t.Fatalf("first attachment download link = %q, want %q", attachments[0].DownloadLink, want)
+	}
+	if want := server.URL + "/wiki/download/attachments/123/spec.pdf"; attachments[1].DownloadLink != want {
+		t.Fatalf("second attachment download link = %q, want %q", attachments[1].DownloadLink, want)
+	}
 }
 
 func TestResolveAttachmentIDByFileID_Pagination(t *testing.T) {
@@ -171,7 +178,7 @@ func TestDownloadAttachment_ResolvesAndDownloadsBytes(t *testing.T) {
 				t.Fatalf("method = %s, want GET", r.Method)
 			}
 			w.Header().Set("Content-Type", "application/json")
-			if _, err := io.WriteString(w, `{"id":"att-1","downloadLink":"/download/attachments/1/diagram.png"}`); err != nil {
+			if _, err := io.WriteString(w, `{"id":"att-1","fileSize":11,"downloadLink":"/download/attachments/1/diagram.png"}`); err != nil {
 				t.Fatalf("write response: %v", err)
 			}
 		case "/download/attachments/1/diagram.png":
@@ -207,6 +214,83 @@ func TestDownloadAttachment_ResolvesAndDownloadsBytes(t *testing.T) {
 	}
 }
 
+func TestDownloadAttachment_StopsAtContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/api/v2/attachments/att-1":
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := io.WriteString(w, `{"id":"att-1","fileSize":1024,"downloadLink":"/download/attachments/1/large.pdf"}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		case "/download/attachments/1/large.pdf":
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, "partial")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var buf strings.Builder
+	err = client.DownloadAttachment(ctx, "att-1", "1", &buf)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DownloadAttachment() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDownloadAttachment_RejectsSizeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/api/v2/attachments/att-1":
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := io.WriteString(w, `{"id":"att-1","fileSize":11,"downloadLink":"/download/attachments/1/diagram.png"}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		case "/download/attachments/1/diagram.png":
+			// A proxy cutting a chunked transfer short still reports 200.
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			if _, err := io.WriteString(w, "binary"); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	var buf strings.Builder
+	err = client.DownloadAttachment(context.Background(), "att-1", "123", &buf)
+	if !errors.Is(err, ErrAttachmentIncomplete) {
+		t.Fatalf("DownloadAttachment() error = %v, want ErrAttachmentIncomplete", err)
+	}
+	if !strings.Contains(err.Error(), "received 6 bytes, expected 11") {
+		t.Fatalf("error should report both sizes, got %v", err)
+	}
+}
+
 func TestUploadAndDeleteAttachmentEndpoints(t *testing.T) {
 	uploadCalls := 0
 	deleteCalls := 0
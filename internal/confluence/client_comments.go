@@ -0,0 +1,88 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type commentDTO struct {
+	ID      string `json:"id"`
+	PageID  string `json:"pageId"`
+	Version struct {
+		AuthorID  string `json:"authorId"`
+		CreatedAt string `json:"createdAt"`
+	} `json:"version"`
+	Body struct {
+		AtlasDocFormat struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"atlas_doc_format"`
+	} `json:"body"`
+}
+
+// ListFooterComments returns the top-level footer comments of a page, oldest
+// first as ordered by Confluence. Replies and inline comments are not
+// included.
+func (c *Client) ListFooterComments(ctx context.Context, pageID string) ([]Comment, error) {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return nil, errors.New("page ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("body-format", "atlas_doc_format")
+	query.Set("limit", "100")
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/api/v2/pages/"+url.PathEscape(pageID)+"/footer-comments", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []Comment{}
+	var payload v2ListResponse[commentDTO]
+	for {
+		if err := c.do(req, &payload); err != nil {
+			if isHTTPStatus(err, http.StatusNotFound) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+
+		for _, item := range payload.Results {
+			commentID := strings.TrimSpace(item.ID)
+			if commentID == "" {
+				continue
+			}
+			comments = append(comments, Comment{
+				ID:        commentID,
+				PageID:    firstNonEmpty(item.PageID, pageID),
+				AuthorID:  strings.TrimSpace(item.Version.AuthorID),
+				CreatedAt: parseRemoteTime(item.Version.CreatedAt),
+				BodyADF:   normalizeADFValue(item.Body.AtlasDocFormat.Value),
+			})
+		}
+
+		nextURLStr := strings.TrimSpace(payload.Links.Next)
+		if nextURLStr == "" {
+			break
+		}
+		if !strings.HasPrefix(nextURLStr, "http") {
+			nextURLStr = resolveWebURL(c.baseURL, nextURLStr)
+		}
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, nextURLStr, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setAuth(req)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+
+		payload = v2ListResponse[commentDTO]{}
+	}
+
+	return comments, nil
+}
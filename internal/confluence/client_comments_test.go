@@ -0,0 +1,75 @@
+package confluence
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListFooterComments_PaginatesAndMapsFields(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "application/json")
+
+		switch callCount {
+		case 1:
+			if r.URL.Path != "/wiki/api/v2/pages/123/footer-comments" {
+				t.Fatalf("first call path = %s", r.URL.Path)
+			}
+			if got := r.URL.Query().Get("body-format"); got != "atlas_doc_format" {
+				t.Fatalf("body-format = %q, want atlas_doc_format", got)
+			}
+			if _, err := io.WriteString(w, `{
+				"results":[{"id":"c-1","pageId":"123","version":{"authorId":"acc-1","createdAt":"2026-03-06T12:00:00.000Z"},"body":{"atlas_doc_format":{"value":"{\"type\":\"doc\",\"version\":1,\"content\":[]}"}}}],
+				"_links":{"next":"/wiki/api/v2/pages/123/footer-comments?cursor=next-token"}
+			}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		case 2:
+			if !strings.Contains(r.URL.RawQuery, "cursor=next-token") {
+				t.Fatalf("second call query = %s", r.URL.RawQuery)
+			}
+			if _, err := io.WriteString(w, `{"results":[{"id":"c-2","version":{"authorId":"acc-2"}}]}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		default:
+			t.Fatalf("unexpected call %d", callCount)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "u",
+		APIToken: "t",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	comments, err := client.ListFooterComments(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("ListFooterComments() error: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("comment count = %d, want 2", len(comments))
+	}
+	first := comments[0]
+	if first.ID != "c-1" || first.PageID != "123" || first.AuthorID != "acc-1" {
+		t.Fatalf("first comment = %+v", first)
+	}
+	if !first.CreatedAt.Equal(time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("first comment created at = %v", first.CreatedAt)
+	}
+	if !strings.Contains(string(first.BodyADF), `"type":"doc"`) {
+		t.Fatalf("first comment body = %s", first.BodyADF)
+	}
+	if comments[1].ID != "c-2" || comments[1].PageID != "123" {
+		t.Fatalf("second comment = %+v, want page ID defaulted from the request", comments[1])
+	}
+}
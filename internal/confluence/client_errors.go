@@ -17,6 +17,14 @@ var (
 	ErrArchiveTaskFailed = errors.New("confluence archive task failed")
 	// ErrArchiveTaskTimeout indicates archive long-task polling timed out.
 	ErrArchiveTaskTimeout = errors.New("confluence archive task timeout")
+	// ErrNotModified indicates a conditional request matched the current ETag.
+	ErrNotModified = errors.New("confluence resource not modified")
+	// ErrAttachmentIncomplete indicates a downloaded attachment does not
+	// match the size Confluence reports for it, e.g. a truncated transfer.
+	ErrAttachmentIncomplete = errors.New("confluence attachment download incomplete")
+	// ErrV2Unavailable indicates the instance has no v2 REST API
+	// (/wiki/api/v2), as on some Data Center versions.
+	ErrV2Unavailable = errors.New("this Confluence instance lacks the v2 REST API (/wiki/api/v2)")
 )
 
 // APIError is returned for non-2xx responses.
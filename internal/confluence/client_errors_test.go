@@ -164,3 +164,40 @@ func TestClient_VerboseDoesNotLeakToken(t *testing.T) {
 		t.Errorf("verbose output missing HTTP method: %q", output)
 	}
 }
+
+func TestClient_VerboseLogsResponseStatusAndDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.WriteString(w, `{"results":[]}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	original := slog.Default()
+	slog.SetDefault(slog.New(handler))
+	t.Cleanup(func() { slog.SetDefault(original) })
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, _ = client.ListSpaces(context.Background(), SpaceListOptions{Limit: 1})
+
+	output := buf.String()
+	if !strings.Contains(output, `msg="http response"`) {
+		t.Fatalf("verbose output missing http response record: %q", output)
+	}
+	for _, field := range []string{"method=GET", "status=200", "duration_ms="} {
+		if !strings.Contains(output, field) {
+			t.Errorf("http response record missing %q: %q", field, output)
+		}
+	}
+}
@@ -18,6 +18,7 @@ type folderDTO struct {
 	Title      string `json:"title"`
 	ParentID   string `json:"parentId"`
 	ParentType string `json:"parentType"`
+	Position   int    `json:"position"`
 }
 
 func (f folderDTO) toModel() Folder {
@@ -31,6 +32,7 @@ type pageDTO struct {
 	Title      string `json:"title"`
 	ParentID   string `json:"parentId"`
 	ParentType string `json:"parentType"`
+	Position   int    `json:"position"`
 	AuthorID   string `json:"authorId"`
 	CreatedAt  string `json:"createdAt"`
 	Version    struct {
@@ -48,6 +50,9 @@ type pageDTO struct {
 		AtlasDocFormat struct {
 			Value json.RawMessage `json:"value"`
 		} `json:"atlas_doc_format"`
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
 	} `json:"body"`
 	Links struct {
 		WebUI string `json:"webui"`
@@ -62,6 +67,7 @@ func (p pageDTO) toModel(baseURL string) Page {
 		Status:               p.Status,
 		ParentPageID:         p.ParentID,
 		ParentType:           p.ParentType,
+		Position:             p.Position,
 		Version:              p.Version.Number,
 		AuthorID:             p.AuthorID,
 		CreatedAt:            parseRemoteTime(p.CreatedAt),
@@ -73,11 +79,12 @@ func (p pageDTO) toModel(baseURL string) Page {
 }
 
 type changeSearchResponse struct {
-	Results []changeResultDTO `json:"results"`
-	Start   int               `json:"start"`
-	Limit   int               `json:"limit"`
-	Size    int               `json:"size"`
-	Links   struct {
+	Results   []changeResultDTO `json:"results"`
+	Start     int               `json:"start"`
+	Limit     int               `json:"limit"`
+	Size      int               `json:"size"`
+	TotalSize int               `json:"totalSize"`
+	Links     struct {
 		Next string `json:"next"`
 	} `json:"_links"`
 }
@@ -137,17 +144,38 @@ type longTaskMessageDTO struct {
 	Title       string `json:"title"`
 }
 
-func buildChangeCQL(spaceKey string, since time.Time) string {
+// cqlTimeLayout is the CQL date literal layout; CQL has no seconds.
+const cqlTimeLayout = "2006-01-02 15:04"
+
+// buildChangeCQL builds the lastmodified query behind ListChanges. Since is
+// rounded down and Until up to the minute, so a change made during a
+// boundary minute falls inside the window rather than between two windows.
+func buildChangeCQL(spaceKey string, since, until time.Time) string {
 	parts := []string{
 		"type=page",
-		fmt.Sprintf(`space="%s"`, strings.ReplaceAll(spaceKey, `"`, `\"`)),
+		"space=" + quoteCQLString(spaceKey),
 	}
 	if !since.IsZero() {
-		parts = append(parts, fmt.Sprintf(`lastmodified >= "%s"`, since.UTC().Format("2006-01-02 15:04")))
+		since = since.UTC().Truncate(time.Minute)
+		parts = append(parts, fmt.Sprintf(`lastmodified >= "%s"`, since.Format(cqlTimeLayout)))
+	}
+	if !until.IsZero() {
+		rounded := until.UTC().Truncate(time.Minute)
+		if rounded.Before(until) {
+			rounded = rounded.Add(time.Minute)
+		}
+		parts = append(parts, fmt.Sprintf(`lastmodified < "%s"`, rounded.Format(cqlTimeLayout)))
 	}
 	return strings.Join(parts, " AND ")
 }
 
+// quoteCQLString returns value as a double-quoted CQL string literal,
+// escaping backslashes and double quotes.
+func quoteCQLString(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
+
 func extractNextStart(current int, nextLink string) int {
 	if strings.TrimSpace(nextLink) == "" {
 		return current
@@ -169,6 +197,10 @@ func extractNextStart(current int, nextLink string) int {
 
 // ListPages returns a list of pages.
 func (c *Client) ListPages(ctx context.Context, opts PageListOptions) (PageListResult, error) {
+	if c.usesV1() {
+		return c.listPagesV1(ctx, opts)
+	}
+
 	query := url.Values{}
 	if opts.SpaceID != "" {
 		query.Set("space-id", opts.SpaceID)
@@ -199,6 +231,9 @@ func (c *Client) ListPages(ctx context.Context, opts PageListOptions) (PageListR
 
 	var payload v2ListResponse[pageDTO]
 	if err := c.do(req, &payload); err != nil {
+		if isV2Unavailable(err) {
+			return c.listPagesV1(ctx, opts)
+		}
 		return PageListResult{}, err
 	}
 
@@ -243,10 +278,24 @@ func (c *Client) GetFolder(ctx context.Context, folderID string) (Folder, error)
 
 // GetPage fetches a single page by ID.
 func (c *Client) GetPage(ctx context.Context, pageID string) (Page, error) {
+	return c.getPage(ctx, pageID, "")
+}
+
+// GetPageIfNoneMatch fetches a page only when its current ETag differs from
+// etag, returning ErrNotModified otherwise. Servers that ignore conditional
+// requests simply return the page.
+func (c *Client) GetPageIfNoneMatch(ctx context.Context, pageID, etag string) (Page, error) {
+	return c.getPage(ctx, pageID, strings.TrimSpace(etag))
+}
+
+func (c *Client) getPage(ctx context.Context, pageID, etag string) (Page, error) {
 	id := strings.TrimSpace(pageID)
 	if id == "" {
 		return Page{}, errors.New("page ID is required")
 	}
+	if c.usesV1() {
+		return c.getPageV1(ctx, id, etag)
+	}
 
 	req, err := c.newRequest(
 		ctx,
@@ -258,9 +307,19 @@ func (c *Client) GetPage(ctx context.Context, pageID string) (Page, error) {
 	if err != nil {
 		return Page{}, err
 	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
 	var payload pageDTO
-	if err := c.do(req, &payload); err != nil {
+	header, err := c.doWithHeader(req, &payload)
+	if err != nil {
+		if isV2Unavailable(err) {
+			return c.getPageV1(ctx, id, etag)
+		}
+		if isHTTPStatus(err, http.StatusNotModified) {
+			return Page{}, ErrNotModified
+		}
 		if isHTTPStatus(err, http.StatusNotFound) {
 			return Page{}, ErrNotFound
 		}
@@ -269,7 +328,42 @@ func (c *Client) GetPage(ctx context.Context, pageID string) (Page, error) {
 		}
 		return Page{}, err
 	}
-	return payload.toModel(c.baseURL), nil
+	page := payload.toModel(c.baseURL)
+	page.ETag = strings.TrimSpace(header.Get("ETag"))
+	if len(page.BodyADF) == 0 {
+		// Some older pages have no ADF body; fall back to the storage format.
+		// A failed fallback keeps the ADF-only page so the rest of the pull
+		// goes on; the caller reports BodyStorageError.
+		storage, err := c.getPageStorageBody(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return Page{}, ctx.Err()
+			}
+			page.BodyStorageError = err.Error()
+		}
+		page.BodyStorage = storage
+	}
+	return page, nil
+}
+
+// getPageStorageBody fetches the storage (XHTML) representation of a page body.
+func (c *Client) getPageStorageBody(ctx context.Context, pageID string) (string, error) {
+	req, err := c.newRequest(
+		ctx,
+		http.MethodGet,
+		"/wiki/api/v2/pages/"+url.PathEscape(pageID),
+		url.Values{"body-format": []string{"storage"}},
+		nil,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var payload pageDTO
+	if err := c.do(req, &payload); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(payload.Body.Storage.Value), nil
 }
 
 // CreatePage creates a page.
@@ -284,7 +378,7 @@ func (c *Client) ListChanges(ctx context.Context, opts ChangeListOptions) (Chang
 	}
 
 	query := url.Values{}
-	query.Set("cql", buildChangeCQL(spaceKey, opts.Since))
+	query.Set("cql", buildChangeCQL(spaceKey, opts.Since, opts.Until))
 	if opts.Limit > 0 {
 		query.Set("limit", strconv.Itoa(opts.Limit))
 	}
@@ -303,8 +397,9 @@ func (c *Client) ListChanges(ctx context.Context, opts ChangeListOptions) (Chang
 	}
 
 	out := ChangeListResult{
-		Changes: make([]Change, 0, len(payload.Results)),
-		HasMore: payload.Size == payload.Limit && payload.Size > 0,
+		Changes:   make([]Change, 0, len(payload.Results)),
+		HasMore:   payload.Size == payload.Limit && payload.Size > 0,
+		TotalSize: payload.TotalSize,
 	}
 	out.NextStart = extractNextStart(payload.Start, payload.Links.Next)
 	if out.NextStart > payload.Start {
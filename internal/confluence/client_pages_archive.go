@@ -220,3 +220,54 @@ func (c *Client) getArchiveTaskStatus(ctx context.Context, taskID string) (Archi
 	}
 	return status, nil
 }
+
+// unarchivePageRequest restores an archived page by publishing its next
+// version with status "current"; the body is left unchanged.
+type unarchivePageRequest struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+}
+
+// UnarchivePage restores an archived page to the current pages of its space.
+func (c *Client) UnarchivePage(ctx context.Context, pageID string) (Page, error) {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return Page{}, errors.New("page ID is required")
+	}
+	path := "/wiki/rest/api/content/" + url.PathEscape(pageID)
+
+	getReq, err := c.newRequest(ctx, http.MethodGet, path, url.Values{"status": []string{"archived"}, "expand": []string{"version"}}, nil)
+	if err != nil {
+		return Page{}, err
+	}
+	var archived v1PageDTO
+	if err := c.do(getReq, &archived); err != nil {
+		if isHTTPStatus(err, http.StatusNotFound) {
+			return Page{}, ErrNotFound
+		}
+		return Page{}, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(archived.Status), "archived") {
+		return Page{}, fmt.Errorf("page %s is not archived (status %q)", pageID, archived.Status)
+	}
+
+	body := unarchivePageRequest{ID: pageID, Type: "page", Status: "current", Title: archived.Title}
+	body.Version.Number = archived.Version.Number + 1
+	putReq, err := c.newRequest(ctx, http.MethodPut, path, nil, body)
+	if err != nil {
+		return Page{}, err
+	}
+	var restored v1PageDTO
+	if err := c.do(putReq, &restored); err != nil {
+		if isHTTPStatus(err, http.StatusNotFound) {
+			return Page{}, ErrNotFound
+		}
+		return Page{}, err
+	}
+	return restored.toModel(c.baseURL), nil
+}
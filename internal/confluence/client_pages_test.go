@@ -34,6 +34,124 @@ func TestGetPage_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetPage_FallsBackToStorageBodyWhenADFIsEmpty(t *testing.T) {
+	formats := make([]string, 0, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("body-format")
+		formats = append(formats, format)
+
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"id":"42","title":"Legacy","version":{"number":3},"body":{"atlas_doc_format":{"value":""}}}`
+		if format == "storage" {
+			body = `{"id":"42","title":"Legacy","version":{"number":3},"body":{"storage":{"value":"<p>Hello <strong>world</strong></p>"}}}`
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	page, err := client.GetPage(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetPage() unexpected error: %v", err)
+	}
+	if len(page.BodyADF) != 0 {
+		t.Fatalf("BodyADF = %s, want empty", string(page.BodyADF))
+	}
+	if page.BodyStorage != "<p>Hello <strong>world</strong></p>" {
+		t.Fatalf("BodyStorage = %q", page.BodyStorage)
+	}
+	if strings.Join(formats, ",") != "atlas_doc_format,storage" {
+		t.Fatalf("requested body formats = %v, want atlas_doc_format then storage", formats)
+	}
+}
+
+func TestGetPage_KeepsADFOnlyPageWhenStorageFallbackFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("body-format") == "storage" {
+			http.Error(w, `{"message":"storage body unavailable"}`, http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.WriteString(w, `{"id":"42","title":"Legacy","version":{"number":3},"body":{"atlas_doc_format":{"value":""}}}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	page, err := client.GetPage(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetPage() unexpected error: %v", err)
+	}
+	if page.Title != "Legacy" || page.BodyStorage != "" {
+		t.Fatalf("page = %+v, want the ADF-only page", page)
+	}
+	if !strings.Contains(page.BodyStorageError, "403") {
+		t.Fatalf("BodyStorageError = %q, want the storage fetch error", page.BodyStorageError)
+	}
+}
+
+func TestGetPageIfNoneMatch_ReturnsNotModifiedForMatchingETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v3"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v3"`)
+		if _, err := io.WriteString(w, `{"id":"42","title":"Doc","version":{"number":3},"body":{"atlas_doc_format":{"value":"{\"type\":\"doc\",\"content\":[]}"}}}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	page, err := client.GetPage(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetPage() unexpected error: %v", err)
+	}
+	if page.ETag != `"v3"` {
+		t.Fatalf("ETag = %q, want %q", page.ETag, `"v3"`)
+	}
+
+	if _, err := client.GetPageIfNoneMatch(context.Background(), "42", page.ETag); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("GetPageIfNoneMatch() error = %v, want ErrNotModified", err)
+	}
+	refetched, err := client.GetPageIfNoneMatch(context.Background(), "42", `"v2"`)
+	if err != nil {
+		t.Fatalf("GetPageIfNoneMatch() with stale ETag unexpected error: %v", err)
+	}
+	if refetched.Version != 3 {
+		t.Fatalf("refetched version = %d, want 3", refetched.Version)
+	}
+}
+
 func TestGetFolder_ByID(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -130,6 +248,72 @@ func TestListChanges_BuildsCQLFromSpaceAndSince(t *testing.T) {
 	}
 }
 
+func TestListChanges_BoundsWindowWithUntilAndReportsTotalSize(t *testing.T) {
+	since := time.Date(2026, time.January, 2, 15, 4, 0, 0, time.UTC)
+	until := since.Add(90 * time.Minute)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cql := r.URL.Query().Get("cql")
+		if !strings.Contains(cql, `lastmodified >= "2026-01-02 15:04"`) {
+			t.Fatalf("cql = %q, missing since predicate", cql)
+		}
+		if !strings.Contains(cql, `lastmodified < "2026-01-02 16:34"`) {
+			t.Fatalf("cql = %q, missing until predicate", cql)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.WriteString(w, `{"results":[],"start":0,"limit":25,"size":0,"totalSize":1200}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	result, err := client.ListChanges(context.Background(), ChangeListOptions{
+		SpaceKey: "ENG",
+		Since:    since,
+		Until:    until,
+		Limit:    25,
+	})
+	if err != nil {
+		t.Fatalf("ListChanges() unexpected error: %v", err)
+	}
+	if result.TotalSize != 1200 {
+		t.Fatalf("total size = %d, want 1200", result.TotalSize)
+	}
+}
+
+func TestBuildChangeCQL_EscapesSpaceKeyAndRoundsBoundaries(t *testing.T) {
+	since := time.Date(2026, time.January, 2, 15, 4, 59, 0, time.UTC)
+	until := time.Date(2026, time.January, 2, 16, 34, 1, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		spaceKey string
+		want     string
+	}{
+		{name: "backslash", spaceKey: `ENG\" OR space="OPS`, want: `space="ENG\\\" OR space=\"OPS"`},
+		{name: "apostrophe", spaceKey: "O'NEIL", want: `space="O'NEIL"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cql := buildChangeCQL(tc.spaceKey, since, until)
+			want := "type=page AND " + tc.want + ` AND lastmodified >= "2026-01-02 15:04" AND lastmodified < "2026-01-02 16:35"`
+			if cql != want {
+				t.Fatalf("cql = %q, want %q", cql, want)
+			}
+		})
+	}
+}
+
 func TestArchiveAndDeleteEndpoints(t *testing.T) {
 	var archiveCalls int
 	var deleteCalls int
@@ -233,6 +417,48 @@ func TestArchivePages_AlreadyArchivedReturnsErrArchived(t *testing.T) {
 	}
 }
 
+func TestUnarchivePage_RestoresNextVersionAsCurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/7" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query().Get("status"); got != "archived" {
+				t.Fatalf("status query = %q, want archived", got)
+			}
+			io.WriteString(w, `{"id":"7","type":"page","status":"archived","title":"Old Plan","version":{"number":4}}`)
+		case http.MethodPut:
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			version, _ := payload["version"].(map[string]any)
+			if payload["status"] != "current" || payload["title"] != "Old Plan" || version["number"] != float64(5) {
+				t.Fatalf("unexpected unarchive payload: %v", payload)
+			}
+			io.WriteString(w, `{"id":"7","type":"page","status":"current","title":"Old Plan","version":{"number":5}}`)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "user@example.com", APIToken: "token-123"})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	page, err := client.UnarchivePage(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("UnarchivePage() error: %v", err)
+	}
+	if page.Status != "current" || page.Version != 5 {
+		t.Fatalf("restored page = %+v, want current version 5", page)
+	}
+}
+
 func TestWaitForArchiveTask_CompletesAfterPolling(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -403,6 +629,25 @@ func TestCreateAndUpdatePage_Payloads(t *testing.T) {
 	}
 }
 
+func TestPageWritePayload_KeepsEmptyContentADFDocument(t *testing.T) {
+	payload := pageWritePayload("", PageUpsertInput{
+		SpaceID: "S1",
+		Title:   "New",
+		BodyADF: json.RawMessage(`{"version":1,"type":"doc","content":[]}`),
+	})
+
+	body, ok := payload["body"].(map[string]any)
+	if !ok {
+		t.Fatalf("payload body missing for empty-content document: %#v", payload)
+	}
+	if body["representation"] != "atlas_doc_format" {
+		t.Fatalf("body representation = %v, want atlas_doc_format", body["representation"])
+	}
+	if body["value"] != `{"version":1,"type":"doc","content":[]}` {
+		t.Fatalf("body value = %v, want the empty ADF document", body["value"])
+	}
+}
+
 func TestUpdatePage_ArchivedReturnsErrArchived(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut || r.URL.Path != "/wiki/api/v2/pages/101" {
@@ -566,3 +811,24 @@ func TestMovePage_NotFound(t *testing.T) {
 		t.Fatalf("MovePage() error = %v, want ErrNotFound", err)
 	}
 }
+
+func TestPageWritePayload_IncludesVersionMessage(t *testing.T) {
+	payload := pageWritePayload("101", PageUpsertInput{
+		SpaceID:        "S1",
+		Title:          "Page",
+		Version:        4,
+		VersionMessage: "  Move the rollout to Friday ",
+	})
+	version, ok := payload["version"].(map[string]any)
+	if !ok {
+		t.Fatalf("version = %#v, want object", payload["version"])
+	}
+	if version["number"] != 4 || version["message"] != "Move the rollout to Friday" {
+		t.Fatalf("version = %#v, want number 4 with trimmed message", version)
+	}
+
+	payload = pageWritePayload("101", PageUpsertInput{SpaceID: "S1", Title: "Page", Version: 5})
+	if _, hasMessage := payload["version"].(map[string]any)["message"]; hasMessage {
+		t.Fatalf("expected no message without VersionMessage, got %#v", payload["version"])
+	}
+}
@@ -22,9 +22,7 @@ func pageWritePayload(id string, input PageUpsertInput) map[string]any {
 		payload["parentId"] = strings.TrimSpace(input.ParentPageID)
 	}
 	if input.Version > 0 {
-		payload["version"] = map[string]any{
-			"number": input.Version,
-		}
+		payload["version"] = pageVersionPayload(input)
 	}
 	if len(input.BodyADF) > 0 {
 		payload["body"] = map[string]any{
@@ -35,6 +33,14 @@ func pageWritePayload(id string, input PageUpsertInput) map[string]any {
 	return payload
 }
 
+func pageVersionPayload(input PageUpsertInput) map[string]any {
+	version := map[string]any{"number": input.Version}
+	if message := strings.TrimSpace(input.VersionMessage); message != "" {
+		version["message"] = message
+	}
+	return version
+}
+
 func defaultPageStatus(v string) string {
 	status := strings.TrimSpace(v)
 	if status == "" {
@@ -50,6 +56,9 @@ func (c *Client) CreatePage(ctx context.Context, input PageUpsertInput) (Page, e
 	if strings.TrimSpace(input.Title) == "" {
 		return Page{}, errors.New("page title is required")
 	}
+	if c.usesV1() {
+		return c.createPageV1(ctx, input)
+	}
 
 	req, err := c.newRequest(ctx, http.MethodPost, "/wiki/api/v2/pages", nil, pageWritePayload("", input))
 	if err != nil {
@@ -58,6 +67,9 @@ func (c *Client) CreatePage(ctx context.Context, input PageUpsertInput) (Page, e
 
 	var payload pageDTO
 	if err := c.do(req, &payload); err != nil {
+		if isV2Unavailable(err) {
+			return c.createPageV1(ctx, input)
+		}
 		return Page{}, err
 	}
 	return payload.toModel(c.baseURL), nil
@@ -71,6 +83,9 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, input PageUpsert
 	if strings.TrimSpace(input.Title) == "" {
 		return Page{}, errors.New("page title is required")
 	}
+	if c.usesV1() {
+		return c.updatePageV1(ctx, id, input)
+	}
 
 	req, err := c.newRequest(
 		ctx,
@@ -85,6 +100,9 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, input PageUpsert
 
 	var payload pageDTO
 	if err := c.do(req, &payload); err != nil {
+		if isV2Unavailable(err) {
+			return c.updatePageV1(ctx, id, input)
+		}
 		if isHTTPStatus(err, http.StatusNotFound) {
 			return Page{}, ErrNotFound
 		}
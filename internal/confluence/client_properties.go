@@ -0,0 +1,119 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type contentPropertyDTO struct {
+	ID      string          `json:"id"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+}
+
+func (dto contentPropertyDTO) property() ContentProperty {
+	return ContentProperty{
+		ID:      strings.TrimSpace(dto.ID),
+		Key:     dto.Key,
+		Value:   dto.Value,
+		Version: dto.Version.Number,
+	}
+}
+
+// GetContentProperties lists the content properties of a page.
+func (c *Client) GetContentProperties(ctx context.Context, pageID string) ([]ContentProperty, error) {
+	id := strings.TrimSpace(pageID)
+	if id == "" {
+		return nil, errors.New("page ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("limit", "100")
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/api/v2/pages/"+url.PathEscape(id)+"/properties", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := []ContentProperty{}
+	var payload v2ListResponse[contentPropertyDTO]
+	for {
+		if err := c.do(req, &payload); err != nil {
+			if isHTTPStatus(err, http.StatusNotFound) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+		for _, item := range payload.Results {
+			if item.Key == "" {
+				continue
+			}
+			properties = append(properties, item.property())
+		}
+
+		nextURLStr := strings.TrimSpace(payload.Links.Next)
+		if nextURLStr == "" {
+			break
+		}
+		if !strings.HasPrefix(nextURLStr, "http") {
+			nextURLStr = resolveWebURL(c.baseURL, nextURLStr)
+		}
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, nextURLStr, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setAuth(req)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+
+		payload = v2ListResponse[contentPropertyDTO]{}
+	}
+
+	return properties, nil
+}
+
+// SetContentProperty stores property.Value under property.Key on a page.
+// A property with an ID updates that existing property, whose current
+// version must be property.Version; one without an ID is created.
+func (c *Client) SetContentProperty(ctx context.Context, pageID string, property ContentProperty) (ContentProperty, error) {
+	id := strings.TrimSpace(pageID)
+	if id == "" {
+		return ContentProperty{}, errors.New("page ID is required")
+	}
+	if property.Key == "" {
+		return ContentProperty{}, errors.New("property key is required")
+	}
+	if len(property.Value) == 0 {
+		return ContentProperty{}, fmt.Errorf("property %q has no value", property.Key)
+	}
+
+	path := "/wiki/api/v2/pages/" + url.PathEscape(id) + "/properties"
+	method := http.MethodPost
+	payload := map[string]any{
+		"key":   property.Key,
+		"value": property.Value,
+	}
+	if propertyID := strings.TrimSpace(property.ID); propertyID != "" {
+		path += "/" + url.PathEscape(propertyID)
+		method = http.MethodPut
+		payload["version"] = map[string]any{"number": property.Version + 1}
+	}
+
+	req, err := c.newRequest(ctx, method, path, nil, payload)
+	if err != nil {
+		return ContentProperty{}, err
+	}
+	var result contentPropertyDTO
+	if err := c.do(req, &result); err != nil {
+		return ContentProperty{}, fmt.Errorf("set content property %q: %w", property.Key, err)
+	}
+	return result.property(), nil
+}
@@ -0,0 +1,117 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ContentProperties(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/wiki/api/v2/pages/123/properties", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("cursor") == "" {
+				if _, err := io.WriteString(w, `{
+					"results":[{"id":"p-1","key":"reviewers","value":["alice","bob"],"version":{"number":3}}],
+					"_links":{"next":"/wiki/api/v2/pages/123/properties?cursor=next-token"}
+				}`); err != nil {
+					t.Fatalf("write response: %v", err)
+				}
+				return
+			}
+			if _, err := io.WriteString(w, `{"results":[{"id":"p-2","key":"review","value":{"due":"2026-03-01","count":12345678901234},"version":{"number":1}}]}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		case http.MethodPost:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode POST body: %v", err)
+			}
+			bodies = append(bodies, body)
+			if _, err := io.WriteString(w, `{"id":"p-3","key":"owner","value":"docs-team","version":{"number":1}}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/wiki/api/v2/pages/123/properties/p-1", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" p-1")
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode PUT body: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.WriteString(w, `{"id":"p-1","key":"reviewers","value":["carol"],"version":{"number":4}}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "test@example.com",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	properties, err := client.GetContentProperties(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetContentProperties() failed: %v", err)
+	}
+	if len(properties) != 2 {
+		t.Fatalf("property count = %d, want 2 across both pages", len(properties))
+	}
+	if got := properties[0]; got.ID != "p-1" || got.Key != "reviewers" || got.Version != 3 || string(got.Value) != `["alice","bob"]` {
+		t.Fatalf("first property = %+v", got)
+	}
+	// Values are passed through as raw JSON so nothing is lost in decoding.
+	if got := string(properties[1].Value); got != `{"due":"2026-03-01","count":12345678901234}` {
+		t.Fatalf("second property value = %s", got)
+	}
+
+	updated, err := client.SetContentProperty(ctx, "123", ContentProperty{ID: "p-1", Key: "reviewers", Value: json.RawMessage(`["carol"]`), Version: 3})
+	if err != nil {
+		t.Fatalf("SetContentProperty() update failed: %v", err)
+	}
+	if updated.Version != 4 {
+		t.Fatalf("updated version = %d, want 4", updated.Version)
+	}
+	if version, _ := bodies[0]["version"].(map[string]any); version["number"] != float64(4) {
+		t.Fatalf("PUT body = %v, want version.number 4", bodies[0])
+	}
+
+	created, err := client.SetContentProperty(ctx, "123", ContentProperty{Key: "owner", Value: json.RawMessage(`"docs-team"`)})
+	if err != nil {
+		t.Fatalf("SetContentProperty() create failed: %v", err)
+	}
+	if created.ID != "p-3" {
+		t.Fatalf("created property = %+v", created)
+	}
+	if _, ok := bodies[1]["version"]; ok || bodies[1]["key"] != "owner" || bodies[1]["value"] != "docs-team" {
+		t.Fatalf("POST body = %v, want key and value only", bodies[1])
+	}
+	if len(requests) != 4 || requests[2] != "PUT p-1" || requests[3] != "POST " {
+		t.Fatalf("requests = %v", requests)
+	}
+}
@@ -0,0 +1,149 @@
+package confluence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type restrictionOperationDTO struct {
+	Operation    string `json:"operation"`
+	Restrictions struct {
+		User struct {
+			Results []struct {
+				AccountID string `json:"accountId"`
+			} `json:"results"`
+		} `json:"user"`
+		Group struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"group"`
+	} `json:"restrictions"`
+}
+
+// GetRestrictions returns the read and update restrictions of a page via the
+// v1 content restriction API.
+func (c *Client) GetRestrictions(ctx context.Context, pageID string) (PageRestrictions, error) {
+	id := strings.TrimSpace(pageID)
+	if id == "" {
+		return PageRestrictions{}, errors.New("page ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("expand", "restrictions.user,restrictions.group")
+	req, err := c.newRequest(
+		ctx,
+		http.MethodGet,
+		"/wiki/rest/api/content/"+url.PathEscape(id)+"/restriction/byOperation",
+		query,
+		nil,
+	)
+	if err != nil {
+		return PageRestrictions{}, fmt.Errorf("create get restrictions request: %w", err)
+	}
+
+	var result map[string]restrictionOperationDTO
+	if err := c.do(req, &result); err != nil {
+		return PageRestrictions{}, fmt.Errorf("execute get restrictions request: %w", err)
+	}
+
+	return PageRestrictions{
+		Read:   restrictionSubjects(result["read"]),
+		Update: restrictionSubjects(result["update"]),
+	}, nil
+}
+
+func restrictionSubjects(op restrictionOperationDTO) []string {
+	subjects := make([]string, 0, len(op.Restrictions.User.Results)+len(op.Restrictions.Group.Results))
+	for _, user := range op.Restrictions.User.Results {
+		if accountID := strings.TrimSpace(user.AccountID); accountID != "" {
+			subjects = append(subjects, "user:"+accountID)
+		}
+	}
+	for _, group := range op.Restrictions.Group.Results {
+		if name := strings.TrimSpace(group.Name); name != "" {
+			subjects = append(subjects, "group:"+name)
+		}
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+// SetRestrictions replaces the read and update restrictions of a page. Empty
+// restrictions remove every restriction from the page.
+func (c *Client) SetRestrictions(ctx context.Context, pageID string, restrictions PageRestrictions) error {
+	id := strings.TrimSpace(pageID)
+	if id == "" {
+		return errors.New("page ID is required")
+	}
+
+	if len(restrictions.Read) == 0 && len(restrictions.Update) == 0 {
+		req, err := c.newRequest(ctx, http.MethodDelete, "/wiki/rest/api/content/"+url.PathEscape(id)+"/restriction", nil, nil)
+		if err != nil {
+			return fmt.Errorf("create delete restrictions request: %w", err)
+		}
+		if err := c.do(req, nil); err != nil {
+			return fmt.Errorf("execute delete restrictions request: %w", err)
+		}
+		return nil
+	}
+
+	type userPayload struct {
+		Type      string `json:"type"`
+		AccountID string `json:"accountId"`
+	}
+	type groupPayload struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	type operationPayload struct {
+		Operation    string `json:"operation"`
+		Restrictions struct {
+			User  []userPayload  `json:"user"`
+			Group []groupPayload `json:"group"`
+		} `json:"restrictions"`
+	}
+
+	payload := make([]operationPayload, 0, 2)
+	for _, op := range []struct {
+		name     string
+		subjects []string
+	}{{"read", restrictions.Read}, {"update", restrictions.Update}} {
+		entry := operationPayload{Operation: op.name}
+		entry.Restrictions.User = []userPayload{}
+		entry.Restrictions.Group = []groupPayload{}
+		for _, subject := range op.subjects {
+			kind, value, ok := strings.Cut(strings.TrimSpace(subject), ":")
+			value = strings.TrimSpace(value)
+			if !ok || value == "" {
+				return fmt.Errorf("invalid restriction subject %q", subject)
+			}
+			switch strings.ToLower(strings.TrimSpace(kind)) {
+			case "user":
+				entry.Restrictions.User = append(entry.Restrictions.User, userPayload{Type: "known", AccountID: value})
+			case "group":
+				entry.Restrictions.Group = append(entry.Restrictions.Group, groupPayload{Type: "group", Name: value})
+			default:
+				return fmt.Errorf("invalid restriction subject %q", subject)
+			}
+		}
+		payload = append(payload, entry)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, "/wiki/rest/api/content/"+url.PathEscape(id)+"/restriction", nil, payload)
+	if err != nil {
+		return fmt.Errorf("create set restrictions request: %w", err)
+	}
+	if err := c.do(req, nil); err != nil {
+		return fmt.Errorf("execute set restrictions request: %w", err)
+	}
+	return nil
+}
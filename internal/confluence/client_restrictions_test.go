@@ -0,0 +1,103 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Restrictions(t *testing.T) {
+	var putBody []map[string]any
+	deleted := false
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/wiki/rest/api/content/123/restriction/byOperation", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := io.WriteString(w, `{
+			"read": {"operation": "read", "restrictions": {
+				"user": {"results": [{"accountId": "acct-2"}]},
+				"group": {"results": [{"name": "legal"}]}
+			}},
+			"update": {"operation": "update", "restrictions": {
+				"user": {"results": [{"accountId": "acct-1"}]},
+				"group": {"results": []}
+			}}
+		}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	})
+	mux.HandleFunc("/wiki/rest/api/content/123/restriction", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&putBody); err != nil {
+				t.Fatalf("decode PUT body: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := io.WriteString(w, `{"results": []}`); err != nil {
+				t.Fatalf("write response: %v", err)
+			}
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "test@example.com",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	ctx := context.Background()
+
+	got, err := client.GetRestrictions(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetRestrictions() failed: %v", err)
+	}
+	want := PageRestrictions{Read: []string{"group:legal", "user:acct-2"}, Update: []string{"user:acct-1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetRestrictions() = %+v, want %+v", got, want)
+	}
+
+	if err := client.SetRestrictions(ctx, "123", PageRestrictions{Read: []string{"group:legal"}, Update: []string{"user:acct-1"}}); err != nil {
+		t.Fatalf("SetRestrictions() failed: %v", err)
+	}
+	if len(putBody) != 2 || putBody[0]["operation"] != "read" || putBody[1]["operation"] != "update" {
+		t.Fatalf("unexpected PUT payload: %+v", putBody)
+	}
+	readGroups := putBody[0]["restrictions"].(map[string]any)["group"].([]any)
+	if len(readGroups) != 1 || readGroups[0].(map[string]any)["name"] != "legal" {
+		t.Fatalf("read restriction should name group legal: %+v", putBody[0])
+	}
+	updateUsers := putBody[1]["restrictions"].(map[string]any)["user"].([]any)
+	if len(updateUsers) != 1 || updateUsers[0].(map[string]any)["accountId"] != "acct-1" {
+		t.Fatalf("update restriction should name user acct-1: %+v", putBody[1])
+	}
+
+	if err := client.SetRestrictions(ctx, "123", PageRestrictions{}); err != nil {
+		t.Fatalf("SetRestrictions() clear failed: %v", err)
+	}
+	if !deleted {
+		t.Fatal("empty restrictions should delete every restriction")
+	}
+
+	if err := client.SetRestrictions(ctx, "123", PageRestrictions{Read: []string{"team:legal"}}); err == nil {
+		t.Fatal("SetRestrictions() should reject unknown subject kinds")
+	}
+}
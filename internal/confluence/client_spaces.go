@@ -3,12 +3,15 @@ package confluence
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 )
 
+const getSpacePageLimit = 25
+
 type spaceDTO struct {
 	ID   string `json:"id"`
 	Key  string `json:"key"`
@@ -22,6 +25,10 @@ func (s spaceDTO) toModel() Space {
 
 // ListSpaces returns a list of spaces.
 func (c *Client) ListSpaces(ctx context.Context, opts SpaceListOptions) (SpaceListResult, error) {
+	if c.usesV1() {
+		return c.listSpacesV1(ctx, opts)
+	}
+
 	query := url.Values{}
 	if len(opts.Keys) > 0 {
 		query.Set("keys", strings.Join(opts.Keys, ","))
@@ -40,6 +47,9 @@ func (c *Client) ListSpaces(ctx context.Context, opts SpaceListOptions) (SpaceLi
 
 	var payload v2ListResponse[spaceDTO]
 	if err := c.do(req, &payload); err != nil {
+		if isV2Unavailable(err) {
+			return c.listSpacesV1(ctx, opts)
+		}
 		return SpaceListResult{}, err
 	}
 
@@ -48,29 +58,47 @@ func (c *Client) ListSpaces(ctx context.Context, opts SpaceListOptions) (SpaceLi
 		NextCursor: extractCursor(payload.Cursor, payload.Meta.Cursor, payload.Links.Next),
 	}
 	for _, item := range payload.Results {
+		c.rememberSpaceKey(item.toModel())
 		out.Spaces = append(out.Spaces, item.toModel())
 	}
 	return out, nil
 }
 
 // GetSpace finds a space by key.
+//
+// Some tenants apply the keys filter loosely and return near-matches, so
+// results are paged until an exact (case-insensitive) key match is found.
 func (c *Client) GetSpace(ctx context.Context, spaceKey string) (Space, error) {
 	key := strings.TrimSpace(spaceKey)
 	if key == "" {
 		return Space{}, errors.New("space key is required")
 	}
 
-	result, err := c.ListSpaces(ctx, SpaceListOptions{
-		Keys:  []string{key},
-		Limit: 1,
-	})
-	if err != nil {
-		return Space{}, err
-	}
-	for _, item := range result.Spaces {
-		if strings.EqualFold(item.Key, key) {
-			return item, nil
+	closeMatches := []string{}
+	cursor := ""
+	for {
+		result, err := c.ListSpaces(ctx, SpaceListOptions{
+			Keys:   []string{key},
+			Limit:  getSpacePageLimit,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return Space{}, err
+		}
+		for _, item := range result.Spaces {
+			if strings.EqualFold(item.Key, key) {
+				return item, nil
+			}
+			closeMatches = append(closeMatches, item.Key)
+		}
+		if strings.TrimSpace(result.NextCursor) == "" || result.NextCursor == cursor {
+			break
 		}
+		cursor = result.NextCursor
+	}
+
+	if len(closeMatches) > 0 {
+		return Space{}, fmt.Errorf("space %q: %w; close matches: %s", key, ErrNotFound, strings.Join(closeMatches, ", "))
 	}
 	return Space{}, ErrNotFound
 }
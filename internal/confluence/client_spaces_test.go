@@ -3,9 +3,11 @@ package confluence
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -93,3 +95,57 @@ func TestListSpaces_UsesConfiguredUserAgent(t *testing.T) {
 		t.Fatalf("ListSpaces() unexpected error: %v", err)
 	}
 }
+
+func TestGetSpace_PaginatesPastNearMatchesToExactKey(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			io.WriteString(w, `{"results":[{"id":"1","key":"ENGOPS","name":"Eng Ops","type":"global"}],"meta":{"cursor":"page-2"}}`)
+		case "page-2":
+			io.WriteString(w, `{"results":[{"id":"2","key":"eng","name":"Engineering","type":"global"}]}`)
+		default:
+			t.Fatalf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "user@example.com", APIToken: "token-123"})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	space, err := client.GetSpace(context.Background(), "ENG")
+	if err != nil {
+		t.Fatalf("GetSpace() unexpected error: %v", err)
+	}
+	if space.ID != "2" {
+		t.Fatalf("space ID = %q, want 2", space.ID)
+	}
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2", requests)
+	}
+}
+
+func TestGetSpace_NotFoundListsCloseMatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[{"id":"1","key":"ENGOPS","name":"Eng Ops","type":"global"},{"id":"3","key":"ENG2","name":"Eng 2","type":"global"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "user@example.com", APIToken: "token-123"})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	_, err = client.GetSpace(context.Background(), "ENG")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetSpace() error = %v, want ErrNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "close matches: ENGOPS, ENG2") {
+		t.Fatalf("GetSpace() error = %q, want close matches listed", err.Error())
+	}
+}
@@ -2,6 +2,15 @@
 package confluence
 
 import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -47,3 +56,188 @@ func TestNewClient_AppliesRateAndRetryPolicyConfig(t *testing.T) {
 		t.Fatalf("Close() should be idempotent, got error: %v", err)
 	}
 }
+
+func TestNewClient_DefaultTransportHonorsProxyEnvironment(t *testing.T) {
+	client, err := NewClient(ClientConfig{
+		BaseURL:  "https://example.test",
+		Email:    "user@example.com",
+		APIToken: "token-123",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+	}
+	if transport.Proxy == nil {
+		t.Fatal("default transport must keep ProxyFromEnvironment")
+	}
+	if client.downloadClient.Transport != transport {
+		t.Fatal("download client should share the API transport")
+	}
+}
+
+func TestNewClient_HTTPTimeoutBoundsAPIAndDownloadClients(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantAPI      time.Duration
+		wantDownload time.Duration
+	}{
+		{name: "default", wantAPI: defaultHTTPTimeout, wantDownload: defaultDownloadTimeout},
+		{name: "short", timeout: 5 * time.Second, wantAPI: 5 * time.Second, wantDownload: defaultDownloadTimeout},
+		{name: "longer than downloads", timeout: time.Hour, wantAPI: time.Hour, wantDownload: time.Hour},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient(ClientConfig{
+				BaseURL:     "https://example.test",
+				Email:       "user@example.com",
+				APIToken:    "token-123",
+				HTTPTimeout: tc.timeout,
+			})
+			if err != nil {
+				t.Fatalf("NewClient() unexpected error: %v", err)
+			}
+			t.Cleanup(func() { _ = client.Close() })
+
+			if client.httpClient.Timeout != tc.wantAPI {
+				t.Fatalf("API timeout = %s, want %s", client.httpClient.Timeout, tc.wantAPI)
+			}
+			if client.downloadClient.Timeout != tc.wantDownload {
+				t.Fatalf("download timeout = %s, want %s", client.downloadClient.Timeout, tc.wantDownload)
+			}
+		})
+	}
+}
+
+func TestNewClient_CABundleTrustsCustomCertificateAuthority(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[{"id":"1","key":"ENG","name":"Engineering","type":"global"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundlePath, pemBytes, 0o600); err != nil {
+		t.Fatalf("write CA bundle: %v", err)
+	}
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:          server.URL,
+		Email:            "user@example.com",
+		APIToken:         "token-123",
+		CABundle:         bundlePath,
+		RetryMaxAttempts: -1,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	if _, err := client.ListSpaces(context.Background(), SpaceListOptions{Limit: 1}); err != nil {
+		t.Fatalf("ListSpaces() with CA bundle unexpected error: %v", err)
+	}
+}
+
+func TestNewClient_NormalizesBaseURL(t *testing.T) {
+	cases := map[string]string{
+		"https://example.atlassian.net":         "https://example.atlassian.net",
+		"https://example.atlassian.net/":        "https://example.atlassian.net",
+		"https://example.atlassian.net/wiki":    "https://example.atlassian.net",
+		"https://example.atlassian.net/wiki/":   "https://example.atlassian.net",
+		"https://example.atlassian.net/WIKI//":  "https://example.atlassian.net",
+		" HTTPS://Example.Atlassian.NET/wiki ":  "https://example.atlassian.net",
+		"https://confluence.example.com/docs":   "https://confluence.example.com/docs",
+		"https://confluence.example.com/docs/":  "https://confluence.example.com/docs",
+		"https://confluence.example.com/a/wiki": "https://confluence.example.com/a",
+		"http://localhost:8090/wiki/":           "http://localhost:8090",
+	}
+	for input, want := range cases {
+		client, err := NewClient(ClientConfig{BaseURL: input, Email: "user@example.com", APIToken: "token-123"})
+		if err != nil {
+			t.Fatalf("NewClient(%q) unexpected error: %v", input, err)
+		}
+		if client.baseURL != want {
+			t.Errorf("NewClient(%q) base URL = %q, want %q", input, client.baseURL, want)
+		}
+	}
+}
+
+func TestNewClient_BaseURLWithWikiBuildsSingleWikiPath(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"results":[{"id":"1","key":"ENG","name":"Engineering"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL + "/wiki/", Email: "user@example.com", APIToken: "token-123"})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if _, err := client.GetSpace(context.Background(), "ENG"); err != nil {
+		t.Fatalf("GetSpace() unexpected error: %v", err)
+	}
+	if strings.Contains(gotPath, "/wiki/wiki/") || !strings.HasPrefix(gotPath, "/wiki/") {
+		t.Fatalf("request path = %q, want a single /wiki prefix", gotPath)
+	}
+}
+
+func TestNewClient_RejectsCABundleWithoutCertificates(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(bundlePath, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("write CA bundle: %v", err)
+	}
+
+	_, err := NewClient(ClientConfig{
+		BaseURL:  "https://example.test",
+		Email:    "user@example.com",
+		APIToken: "token-123",
+		CABundle: bundlePath,
+	})
+	if err == nil || !strings.Contains(err.Error(), "contains no PEM certificates") {
+		t.Fatalf("NewClient() error = %v, want PEM error", err)
+	}
+}
+
+func TestGetCurrentUser_ReturnsAccountAndRejectsAnonymous(t *testing.T) {
+	anonymous := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/user/current" {
+			t.Fatalf("path = %s, want /wiki/rest/api/user/current", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if anonymous {
+			_, _ = io.WriteString(w, `{"type":"anonymous","displayName":"Anonymous"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"type":"known","accountId":"acc-1","displayName":"Jane Doe","email":"jane@example.com"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "jane@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser() unexpected error: %v", err)
+	}
+	if user.AccountID != "acc-1" || user.DisplayName != "Jane Doe" || user.Email != "jane@example.com" {
+		t.Fatalf("user = %+v", user)
+	}
+
+	anonymous = true
+	_, err = client.GetCurrentUser(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("anonymous answer error = %v, want a 401 APIError", err)
+	}
+}
@@ -0,0 +1,84 @@
+package confluence
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type pageVersionDTO struct {
+	Number    int    `json:"number"`
+	AuthorID  string `json:"authorId"`
+	CreatedAt string `json:"createdAt"`
+	Message   string `json:"message"`
+	MinorEdit bool   `json:"minorEdit"`
+}
+
+// ListPageVersions returns up to limit versions of a page, newest first.
+// A limit of zero or less returns every version.
+func (c *Client) ListPageVersions(ctx context.Context, pageID string, limit int) ([]PageVersion, error) {
+	pageID = strings.TrimSpace(pageID)
+	if pageID == "" {
+		return nil, errors.New("page ID is required")
+	}
+
+	pageSize := 50
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	query := url.Values{}
+	query.Set("sort", "-modified-date")
+	query.Set("limit", strconv.Itoa(pageSize))
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/wiki/api/v2/pages/"+url.PathEscape(pageID)+"/versions", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []PageVersion{}
+	var payload v2ListResponse[pageVersionDTO]
+	for {
+		if err := c.do(req, &payload); err != nil {
+			if isHTTPStatus(err, http.StatusNotFound) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+
+		for _, item := range payload.Results {
+			versions = append(versions, PageVersion{
+				Number:    item.Number,
+				AuthorID:  strings.TrimSpace(item.AuthorID),
+				CreatedAt: parseRemoteTime(item.CreatedAt),
+				Message:   strings.TrimSpace(item.Message),
+				MinorEdit: item.MinorEdit,
+			})
+			if limit > 0 && len(versions) >= limit {
+				return versions, nil
+			}
+		}
+
+		nextURLStr := strings.TrimSpace(payload.Links.Next)
+		if nextURLStr == "" {
+			break
+		}
+		if !strings.HasPrefix(nextURLStr, "http") {
+			nextURLStr = resolveWebURL(c.baseURL, nextURLStr)
+		}
+
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, nextURLStr, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setAuth(req)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+
+		payload = v2ListResponse[pageVersionDTO]{}
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,60 @@
+package confluence
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListPageVersions_StopsAtLimitAndMapsFields(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/wiki/api/v2/pages/123/versions" {
+			t.Fatalf("path = %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Fatalf("limit = %q, want 2", got)
+		}
+		if got := r.URL.Query().Get("sort"); got != "-modified-date" {
+			t.Fatalf("sort = %q, want -modified-date", got)
+		}
+		if _, err := io.WriteString(w, `{
+			"results":[
+				{"number":5,"authorId":"acc-1","createdAt":"2026-03-06T12:00:00.000Z","message":"Fix typo","minorEdit":true},
+				{"number":4,"authorId":"acc-2","createdAt":"2026-03-05T09:30:00.000Z"}
+			],
+			"_links":{"next":"/wiki/api/v2/pages/123/versions?cursor=next-token"}
+		}`); err != nil {
+			t.Fatalf("write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "u", APIToken: "t"})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	versions, err := client.ListPageVersions(context.Background(), "123", 2)
+	if err != nil {
+		t.Fatalf("ListPageVersions() error: %v", err)
+	}
+	if callCount != 1 {
+		t.Fatalf("calls = %d, want 1 once the limit is reached", callCount)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("version count = %d, want 2", len(versions))
+	}
+	first := versions[0]
+	if first.Number != 5 || first.AuthorID != "acc-1" || first.Message != "Fix typo" || !first.MinorEdit {
+		t.Fatalf("first version = %+v", first)
+	}
+	if !first.CreatedAt.Equal(time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("first version created at = %v", first.CreatedAt)
+	}
+}
@@ -264,7 +264,35 @@ func decodeContentStateListPayload(payload json.RawMessage) ([]ContentState, err
 	return normalizeContentStates(wrapped.ContentStates, wrapped.Results, wrapped.SpaceContentStates, wrapped.CustomContentStates), nil
 }
 
-// GetLabels fetches all labels for a given page via v1 API.
+// labelPrefixes are the label namespaces a user can add to a page. Labels
+// in the default global namespace are written without a prefix; others keep
+// theirs (for example "my:todo") so they round-trip through frontmatter.
+var labelPrefixes = map[string]struct{}{"global": {}, "my": {}, "team": {}}
+
+// labelWithPrefix returns the frontmatter form of a remote label.
+func labelWithPrefix(prefix, name string) string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" || prefix == "global" {
+		return name
+	}
+	return prefix + ":" + name
+}
+
+// splitLabelPrefix splits a frontmatter label into its namespace and name.
+// Only known namespaces are split off, so any other colon is part of the
+// name of a global label.
+func splitLabelPrefix(label string) (string, string) {
+	label = strings.TrimSpace(label)
+	if prefix, name, ok := strings.Cut(label, ":"); ok && name != "" {
+		if _, known := labelPrefixes[strings.ToLower(prefix)]; known {
+			return strings.ToLower(prefix), name
+		}
+	}
+	return "global", label
+}
+
+// GetLabels fetches all labels for a given page via v1 API. Labels outside
+// the global namespace are returned as "prefix:name".
 func (c *Client) GetLabels(ctx context.Context, pageID string) ([]string, error) {
 	id := strings.TrimSpace(pageID)
 	if id == "" {
@@ -284,7 +312,8 @@ func (c *Client) GetLabels(ctx context.Context, pageID string) ([]string, error)
 
 	var result struct {
 		Results []struct {
-			Name string `json:"name"`
+			Prefix string `json:"prefix"`
+			Name   string `json:"name"`
 		} `json:"results"`
 	}
 
@@ -294,13 +323,15 @@ func (c *Client) GetLabels(ctx context.Context, pageID string) ([]string, error)
 
 	labels := make([]string, 0, len(result.Results))
 	for _, l := range result.Results {
-		labels = append(labels, l.Name)
+		labels = append(labels, labelWithPrefix(l.Prefix, l.Name))
 	}
 
 	return labels, nil
 }
 
-// AddLabels adds labels to a given page via v1 API.
+// AddLabels adds labels to a given page via v1 API. A label written as
+// "my:name" or "team:name" is added in that namespace; all others are
+// global.
 func (c *Client) AddLabels(ctx context.Context, pageID string, labels []string) error {
 	id := strings.TrimSpace(pageID)
 	if id == "" {
@@ -317,9 +348,10 @@ func (c *Client) AddLabels(ctx context.Context, pageID string, labels []string)
 
 	var payload []labelPayload
 	for _, l := range labels {
+		prefix, name := splitLabelPrefix(l)
 		payload = append(payload, labelPayload{
-			Prefix: "global",
-			Name:   l,
+			Prefix: prefix,
+			Name:   name,
 		})
 	}
 
@@ -352,12 +384,15 @@ func (c *Client) RemoveLabel(ctx context.Context, pageID string, labelName strin
 	if label == "" {
 		return errors.New("label name is required")
 	}
+	if prefix, name := splitLabelPrefix(label); prefix == "global" {
+		label = name
+	}
 
 	req, err := c.newRequest(
 		ctx,
 		http.MethodDelete,
-		"/wiki/rest/api/content/"+url.PathEscape(id)+"/label?name="+url.QueryEscape(label),
-		nil,
+		"/wiki/rest/api/content/"+url.PathEscape(id)+"/label",
+		url.Values{"name": []string{label}},
 		nil,
 	)
 	if err != nil {
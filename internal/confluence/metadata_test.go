@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -176,3 +178,64 @@ func TestClient_Labels(t *testing.T) {
 		t.Fatalf("RemoveLabel() failed: %v", err)
 	}
 }
+
+func TestClient_LabelsKeepNamespacePrefix(t *testing.T) {
+	var added []map[string]string
+	var removed []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/wiki/rest/api/content/123/label", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"results": [{"prefix": "global", "name": "arch"}, {"prefix": "my", "name": "todo"}, {"prefix": "team", "name": "ops"}]}`)
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+				t.Fatalf("decode add labels payload: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"results": []}`)
+		case http.MethodDelete:
+			removed = append(removed, r.URL.Query().Get("name"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "test@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	labels, err := client.GetLabels(ctx, "123")
+	if err != nil {
+		t.Fatalf("GetLabels() failed: %v", err)
+	}
+	if got := strings.Join(labels, ","); got != "arch,my:todo,team:ops" {
+		t.Fatalf("labels = %q, want arch,my:todo,team:ops", got)
+	}
+
+	if err := client.AddLabels(ctx, "123", []string{"my:todo", "global:api", "ticket:42"}); err != nil {
+		t.Fatalf("AddLabels() failed: %v", err)
+	}
+	want := []map[string]string{
+		{"prefix": "my", "name": "todo"},
+		{"prefix": "global", "name": "api"},
+		{"prefix": "global", "name": "ticket:42"},
+	}
+	if !reflect.DeepEqual(added, want) {
+		t.Fatalf("add labels payload = %v, want %v", added, want)
+	}
+
+	for _, label := range []string{"my:todo", "global:api"} {
+		if err := client.RemoveLabel(ctx, "123", label); err != nil {
+			t.Fatalf("RemoveLabel(%q) failed: %v", label, err)
+		}
+	}
+	if got := strings.Join(removed, ","); got != "my:todo,api" {
+		t.Fatalf("removed label names = %q, want my:todo,api", got)
+	}
+}
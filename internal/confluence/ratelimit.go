@@ -2,18 +2,33 @@ package confluence
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 const DefaultRateLimitRPS = 5 // requests per second
 
+// minAdaptiveRateLimitRPS is the lowest rate the limiter slows down to when
+// Confluence reports that the client is close to its rate limit.
+const minAdaptiveRateLimitRPS = 1.0
+
 // rateLimiter is a simple token-bucket rate limiter backed by a time.Ticker.
 // It allows up to rps requests per second by consuming one token per request.
+// The rate only ever goes down: slowDown lowers it when Confluence signals
+// that the client is about to be, or has been, rate limited.
 type rateLimiter struct {
 	tokens   chan struct{}
 	done     chan struct{}
 	stopOnce sync.Once
+
+	mu      sync.Mutex
+	ticker  *time.Ticker
+	rps     float64
+	lowered time.Time
 }
 
 // newRateLimiter creates a rate limiter that allows rps requests per second.
@@ -25,6 +40,8 @@ func newRateLimiter(rps int) *rateLimiter {
 	rl := &rateLimiter{
 		tokens: make(chan struct{}, rps),
 		done:   make(chan struct{}),
+		ticker: time.NewTicker(time.Second / time.Duration(rps)),
+		rps:    float64(rps),
 	}
 
 	// Pre-fill the bucket so the first burst of rps requests is not delayed.
@@ -32,12 +49,11 @@ func newRateLimiter(rps int) *rateLimiter {
 		rl.tokens <- struct{}{}
 	}
 
-	ticker := time.NewTicker(time.Second / time.Duration(rps))
 	go func() {
-		defer ticker.Stop()
+		defer rl.ticker.Stop()
 		for {
 			select {
-			case <-ticker.C:
+			case <-rl.ticker.C:
 				select {
 				case rl.tokens <- struct{}{}:
 				default:
@@ -68,3 +84,111 @@ func (rl *rateLimiter) stop() {
 		close(rl.done)
 	})
 }
+
+// currentRPS returns the rate the limiter currently allows.
+func (rl *rateLimiter) currentRPS() float64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.rps
+}
+
+// observe lowers the rate when resp shows that the client is being, or is
+// about to be, rate limited:
+//
+//   - a 429 response halves the rate;
+//   - X-RateLimit-Remaining with X-RateLimit-Reset caps the rate at the
+//     remaining requests spread over the time left in the window;
+//   - X-RateLimit-NearLimit: true halves the rate.
+//
+// Halving happens at most once per second so a burst of responses sent
+// before the first slowdown took effect does not drive the rate to the floor.
+func (rl *rateLimiter) observe(resp *http.Response) {
+	if rl == nil || resp == nil {
+		return
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rl.halve("status 429")
+		return
+	}
+	if rps, ok := rateLimitWindowRPS(resp.Header, time.Now()); ok {
+		rl.slowDown(rps, "X-RateLimit-Remaining")
+		return
+	}
+	if strings.EqualFold(strings.TrimSpace(resp.Header.Get("X-RateLimit-NearLimit")), "true") {
+		rl.halve("X-RateLimit-NearLimit")
+	}
+}
+
+func (rl *rateLimiter) halve(reason string) {
+	rl.mu.Lock()
+	if time.Since(rl.lowered) < time.Second {
+		rl.mu.Unlock()
+		return
+	}
+	rps := rl.rps / 2
+	rl.mu.Unlock()
+	rl.slowDown(rps, reason)
+}
+
+// slowDown lowers the rate to rps, but not below minAdaptiveRateLimitRPS,
+// and drops tokens saved up at the old rate.
+func (rl *rateLimiter) slowDown(rps float64, reason string) {
+	if rps < minAdaptiveRateLimitRPS {
+		rps = minAdaptiveRateLimitRPS
+	}
+
+	rl.mu.Lock()
+	if rps >= rl.rps {
+		rl.mu.Unlock()
+		return
+	}
+	previous := rl.rps
+	rl.rps = rps
+	rl.lowered = time.Now()
+	rl.ticker.Reset(time.Duration(float64(time.Second) / rps))
+	rl.mu.Unlock()
+
+	for drained := false; !drained; {
+		select {
+		case <-rl.tokens:
+		default:
+			drained = true
+		}
+	}
+	slog.Info("http rate limit lowered", "from_rps", previous, "to_rps", rps, "reason", reason)
+}
+
+// rateLimitWindowRPS derives the rate that spends X-RateLimit-Remaining
+// requests evenly until X-RateLimit-Reset. The reset may be an RFC 3339
+// time, Unix seconds, or seconds from now.
+func rateLimitWindowRPS(header http.Header, now time.Time) (float64, bool) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Remaining")))
+	if err != nil || remaining < 0 {
+		return 0, false
+	}
+	rawReset := strings.TrimSpace(header.Get("X-RateLimit-Reset"))
+	if rawReset == "" {
+		return 0, false
+	}
+
+	var reset time.Time
+	if parsed, err := time.Parse(time.RFC3339, rawReset); err == nil {
+		reset = parsed
+	} else if secs, err := strconv.ParseInt(rawReset, 10, 64); err == nil {
+		// Values this large are Unix timestamps, smaller ones a delay.
+		if secs > 1_000_000_000 {
+			reset = time.Unix(secs, 0)
+		} else {
+			reset = now.Add(time.Duration(secs) * time.Second)
+		}
+	} else {
+		return 0, false
+	}
+
+	window := reset.Sub(now).Seconds()
+	if window <= 0 {
+		return 0, false
+	}
+	return float64(remaining) / window, true
+}
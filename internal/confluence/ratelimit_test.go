@@ -2,6 +2,10 @@ package confluence
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -78,3 +82,93 @@ func TestRateLimiter_StopIsIdempotent(t *testing.T) {
 	rl.stop()
 	rl.stop()
 }
+
+func TestRateLimiter_ObserveLowersRateOnRateLimitSignals(t *testing.T) {
+	rl := newRateLimiter(8)
+	defer rl.stop()
+
+	rl.observe(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	if got := rl.currentRPS(); got != 8 {
+		t.Fatalf("rate after a plain response = %v, want 8", got)
+	}
+
+	rl.observe(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if got := rl.currentRPS(); got != 4 {
+		t.Fatalf("rate after 429 = %v, want 4", got)
+	}
+	// A response already in flight during the first slowdown must not halve again.
+	rl.observe(&http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Ratelimit-Nearlimit": {"true"}}})
+	if got := rl.currentRPS(); got != 4 {
+		t.Fatalf("rate after an immediate near-limit response = %v, want 4", got)
+	}
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "20")
+	header.Set("X-RateLimit-Reset", "10")
+	rl.observe(&http.Response{StatusCode: http.StatusOK, Header: header})
+	if got := rl.currentRPS(); got != 2 {
+		t.Fatalf("rate after 20 remaining in 10s = %v, want 2", got)
+	}
+
+	header.Set("X-RateLimit-Remaining", "0")
+	rl.observe(&http.Response{StatusCode: http.StatusOK, Header: header})
+	if got := rl.currentRPS(); got != minAdaptiveRateLimitRPS {
+		t.Fatalf("rate with no requests left = %v, want the %v floor", got, minAdaptiveRateLimitRPS)
+	}
+}
+
+func TestRateLimitWindowRPS_ParsesResetForms(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		reset string
+		want  float64
+		ok    bool
+	}{
+		{name: "rfc3339", reset: "2026-03-01T12:00:20Z", want: 5, ok: true},
+		{name: "unix seconds", reset: "1772366420", want: 5, ok: true},
+		{name: "delay seconds", reset: "20", want: 5, ok: true},
+		{name: "past reset", reset: "2026-03-01T11:59:00Z"},
+		{name: "unparseable", reset: "soon"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("X-RateLimit-Remaining", "100")
+			header.Set("X-RateLimit-Reset", tc.reset)
+			got, ok := rateLimitWindowRPS(header, now)
+			if ok != tc.ok || got != tc.want {
+				t.Fatalf("rateLimitWindowRPS() = %v, %v; want %v, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestDownloadAttachment_SlowsClientWhenNearRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/api/v2/attachments/att-1":
+			_, _ = io.WriteString(w, `{"id":"att-1","downloadLink":"/download/attachments/1/diagram.png"}`)
+		case "/download/attachments/1/diagram.png":
+			w.Header().Set("X-RateLimit-NearLimit", "true")
+			_, _ = io.WriteString(w, "binary-data")
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, Email: "u", APIToken: "t", RateLimitRPS: 6})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	var buf strings.Builder
+	if err := client.DownloadAttachment(context.Background(), "att-1", "1", &buf); err != nil {
+		t.Fatalf("DownloadAttachment() error: %v", err)
+	}
+	if got := client.limiter.currentRPS(); got != 3 {
+		t.Fatalf("client rate = %v, want 3 after a near-limit download response", got)
+	}
+}
@@ -260,3 +260,59 @@ type roundTripperFunc func(req *http.Request) (*http.Response, error)
 func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
+
+func TestDo_RequestObserverSeesEveryAttempt(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/spaces") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"results":[],"meta":{"cursor":""}}`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	var observed []RequestInfo
+	client, err := NewClient(ClientConfig{
+		BaseURL:  server.URL,
+		Email:    "user@example.com",
+		APIToken: "secret-token",
+		RequestObserver: func(info RequestInfo) {
+			observed = append(observed, info)
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+
+	if _, err := client.ListSpaces(context.Background(), SpaceListOptions{}); err != nil {
+		t.Fatalf("ListSpaces() error = %v", err)
+	}
+	if _, err := client.GetPage(context.Background(), "42"); err == nil {
+		t.Fatal("GetPage() error = nil, want 403")
+	}
+
+	if len(observed) != 3 {
+		t.Fatalf("observed %d requests, want 3: %+v", len(observed), observed)
+	}
+	wantStatus := []int{http.StatusTooManyRequests, http.StatusOK, http.StatusForbidden}
+	wantRetry := []int{0, 1, 0}
+	for i, info := range observed {
+		if info.Method != http.MethodGet || info.StatusCode != wantStatus[i] || info.Retry != wantRetry[i] {
+			t.Fatalf("observed[%d] = %+v, want status %d retry %d", i, info, wantStatus[i], wantRetry[i])
+		}
+		if !strings.HasPrefix(info.URL, server.URL) || info.Duration < 0 {
+			t.Fatalf("observed[%d] = %+v", i, info)
+		}
+		if strings.Contains(info.URL, "secret-token") || strings.Contains(info.URL, "user@example.com") {
+			t.Fatalf("observed URL leaks credentials: %s", info.URL)
+		}
+	}
+}
@@ -29,6 +29,8 @@ type Service interface {
 	GetFolder(ctx context.Context, folderID string) (Folder, error)
 	GetPage(ctx context.Context, pageID string) (Page, error)
 	ListAttachments(ctx context.Context, pageID string) ([]Attachment, error)
+	ListFooterComments(ctx context.Context, pageID string) ([]Comment, error)
+	ListPageVersions(ctx context.Context, pageID string, limit int) ([]PageVersion, error)
 	GetAttachment(ctx context.Context, attachmentID string) (Attachment, error)
 	DownloadAttachment(ctx context.Context, attachmentID string, pageID string, out io.Writer) error
 	UploadAttachment(ctx context.Context, input AttachmentUploadInput) (Attachment, error)
@@ -39,6 +41,7 @@ type Service interface {
 	ListChanges(ctx context.Context, opts ChangeListOptions) (ChangeListResult, error)
 	ArchivePages(ctx context.Context, pageIDs []string) (ArchiveResult, error)
 	WaitForArchiveTask(ctx context.Context, taskID string, opts ArchiveTaskWaitOptions) (ArchiveTaskStatus, error)
+	UnarchivePage(ctx context.Context, pageID string) (Page, error)
 	DeletePage(ctx context.Context, pageID string, opts PageDeleteOptions) error
 	CreateFolder(ctx context.Context, input FolderCreateInput) (Folder, error)
 	ListFolders(ctx context.Context, opts FolderListOptions) (FolderListResult, error)
@@ -76,14 +79,21 @@ type SpaceListResult struct {
 
 // Page is a Confluence page.
 type Page struct {
-	ID                   string
-	SpaceID              string
-	Title                string
-	Status               string // maps to draft vs current
-	ContentStatus        string // maps to UI lozenge (e.g. "Ready to review")
-	Labels               []string
-	ParentPageID         string
-	ParentType           string
+	ID            string
+	SpaceID       string
+	Title         string
+	Status        string // maps to draft vs current
+	ContentStatus string // maps to UI lozenge (e.g. "Ready to review")
+	Labels        []string
+	Restrictions  PageRestrictions
+	// Properties holds the page's content properties by key, with their JSON
+	// values as stored by Confluence. Pull fills it only for the keys a space
+	// mirrors into frontmatter.
+	Properties   map[string]json.RawMessage
+	ParentPageID string
+	ParentType   string
+	// Position orders the page among its siblings; zero when unknown.
+	Position             int
 	Version              int
 	AuthorID             string
 	CreatedAt            time.Time
@@ -91,6 +101,31 @@ type Page struct {
 	LastModified         time.Time
 	WebURL               string
 	BodyADF              json.RawMessage
+	// BodyStorage is the storage-format (XHTML) body, fetched by GetPage only
+	// when the page has no ADF body.
+	BodyStorage string
+	// BodyStorageError is why GetPage could not fetch BodyStorage for a page
+	// without an ADF body; the page is then returned with its empty body.
+	BodyStorageError string
+	// ETag is the entity tag GetPage received, used for conditional fetches.
+	ETag string
+}
+
+// ContentProperty is a JSON value stored on a page under a key, used by
+// apps and integrations for structured page metadata.
+type ContentProperty struct {
+	ID      string
+	Key     string
+	Value   json.RawMessage
+	Version int
+}
+
+// PageRestrictions lists the subjects allowed to read or update a page.
+// Subjects are "user:<accountId>" or "group:<name>"; an empty list means the
+// operation is not restricted.
+type PageRestrictions struct {
+	Read   []string
+	Update []string
 }
 
 // PageListOptions configures page listing.
@@ -116,6 +151,8 @@ type Folder struct {
 	Title      string
 	ParentID   string
 	ParentType string
+	// Position orders the folder among its siblings; zero when unknown.
+	Position int
 }
 
 // FolderListOptions configures folder listing.
@@ -139,7 +176,10 @@ type PageUpsertInput struct {
 	Title        string
 	Status       string
 	Version      int
-	BodyADF      json.RawMessage
+	// VersionMessage is the edit comment stored with the new version. It is
+	// only sent with updates (Version > 0).
+	VersionMessage string
+	BodyADF        json.RawMessage
 }
 
 // PageDeleteOptions controls page deletion semantics for current vs draft content.
@@ -161,8 +201,10 @@ type Change struct {
 type ChangeListOptions struct {
 	SpaceKey string
 	Since    time.Time
-	Limit    int
-	Start    int
+	// Until is an optional exclusive upper bound on the last-modified time.
+	Until time.Time
+	Limit int
+	Start int
 }
 
 // ChangeListResult is a page of change results.
@@ -170,6 +212,8 @@ type ChangeListResult struct {
 	Changes   []Change
 	NextStart int
 	HasMore   bool
+	// TotalSize is the server-reported match count, or 0 when not reported.
+	TotalSize int
 }
 
 // ArchiveResult captures archive task metadata returned by Confluence.
@@ -201,6 +245,24 @@ type ArchiveTaskWaitOptions struct {
 	PollInterval time.Duration
 }
 
+// Comment represents a top-level footer comment on a page.
+type Comment struct {
+	ID        string
+	PageID    string
+	AuthorID  string
+	CreatedAt time.Time
+	BodyADF   json.RawMessage
+}
+
+// PageVersion is one entry of a page's version history.
+type PageVersion struct {
+	Number    int
+	AuthorID  string
+	CreatedAt time.Time
+	Message   string
+	MinorEdit bool
+}
+
 // Attachment represents a Confluence attachment.
 type Attachment struct {
 	ID        string
@@ -208,7 +270,12 @@ type Attachment struct {
 	PageID    string
 	Filename  string
 	MediaType string
-	WebURL    string
+	// FileSize is the size in bytes reported by Confluence; zero when unknown.
+	FileSize int64
+	WebURL   string
+	// DownloadLink is the absolute URL of the attachment content; empty when
+	// Confluence reports none.
+	DownloadLink string
 }
 
 // AttachmentUploadInput is used to upload an attachment to a page.
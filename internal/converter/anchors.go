@@ -0,0 +1,157 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// HeadingAnchor returns the anchor Confluence assigns to a heading: the
+// heading's plain text, trimmed, with every whitespace run replaced by "-".
+// Case and punctuation are kept, so "Section A: Setup" becomes
+// "Section-A:-Setup". Intra-page links written by either direction of the
+// conversion use this form.
+func HeadingAnchor(text string) string {
+	return strings.Join(strings.Fields(text), "-")
+}
+
+// ResolveHeadingAnchor finds the heading an anchor refers to and returns that
+// heading's HeadingAnchor. Matching ignores case, punctuation and separators,
+// so a Confluence anchor ("Section-A") and a Markdown-style slug
+// ("section-a") both resolve to the same heading.
+func ResolveHeadingAnchor(anchor string, headings []string) (string, bool) {
+	key := headingAnchorKey(anchor)
+	if key == "" {
+		return "", false
+	}
+	for _, heading := range headings {
+		if headingAnchorKey(heading) == key {
+			return HeadingAnchor(heading), true
+		}
+	}
+	return "", false
+}
+
+func headingAnchorKey(value string) string {
+	var key strings.Builder
+	for _, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return key.String()
+}
+
+var (
+	markdownHeadingPattern    = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownHeadingLinkRegexp = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+)
+
+// MarkdownHeadings returns the plain text of the ATX headings in markdown, in
+// document order. Headings inside fenced code blocks are ignored.
+func MarkdownHeadings(markdown string) []string {
+	headings := make([]string, 0)
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		match := markdownHeadingPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		text := markdownHeadingLinkRegexp.ReplaceAllString(match[1], "$1")
+		text = strings.NewReplacer("**", "", "__", "", "`", "", "*", "", "~~", "").Replace(text)
+		if text = strings.TrimSpace(text); text != "" {
+			headings = append(headings, text)
+		}
+	}
+	return headings
+}
+
+// normalizeIntraPageAnchors rewrites `#anchor` links that point at a heading
+// of the same ADF document to that heading's HeadingAnchor. Anchors that match
+// no heading are left alone.
+func normalizeIntraPageAnchors(adf []byte) []byte {
+	if !bytes.Contains(adf, []byte(`"heading"`)) || !bytes.Contains(adf, []byte(`"#`)) {
+		return adf
+	}
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf
+	}
+
+	headings := make([]string, 0)
+	walkADFNodes(content, func(node map[string]any) {
+		if nodeType, _ := node["type"].(string); nodeType == "heading" {
+			headings = append(headings, adfNodeText(node))
+		}
+	})
+
+	changed := false
+	walkADFNodes(content, func(node map[string]any) {
+		marks, _ := node["marks"].([]any)
+		for _, rawMark := range marks {
+			mark, ok := rawMark.(map[string]any)
+			if !ok {
+				continue
+			}
+			if markType, _ := mark["type"].(string); markType != "link" {
+				continue
+			}
+			attrs, _ := mark["attrs"].(map[string]any)
+			href, _ := attrs["href"].(string)
+			if !strings.HasPrefix(href, "#") {
+				continue
+			}
+			anchor, ok := ResolveHeadingAnchor(href[1:], headings)
+			if !ok || anchor == href[1:] {
+				continue
+			}
+			attrs["href"] = "#" + anchor
+			changed = true
+		}
+	})
+	if !changed {
+		return adf
+	}
+
+	normalized, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return normalized
+}
+
+func walkADFNodes(nodes []any, visit func(map[string]any)) {
+	for _, rawNode := range nodes {
+		node, ok := rawNode.(map[string]any)
+		if !ok {
+			continue
+		}
+		visit(node)
+		children, _ := node["content"].([]any)
+		walkADFNodes(children, visit)
+	}
+}
+
+func adfNodeText(node map[string]any) string {
+	var text strings.Builder
+	walkADFNodes([]any{node}, func(child map[string]any) {
+		if value, ok := child["text"].(string); ok {
+			text.WriteString(value)
+		}
+	})
+	return strings.TrimSpace(text.String())
+}
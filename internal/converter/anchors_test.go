@@ -0,0 +1,63 @@
+package converter
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHeadingAnchor_MatchesConfluenceHeadingIDs(t *testing.T) {
+	cases := map[string]string{
+		"Section A":          "Section-A",
+		"  Setup   & Usage ": "Setup-&-Usage",
+		"Step 2: Deploy":     "Step-2:-Deploy",
+		"Overview":           "Overview",
+	}
+	for heading, want := range cases {
+		if got := HeadingAnchor(heading); got != want {
+			t.Errorf("HeadingAnchor(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
+
+func TestResolveHeadingAnchor_AcceptsSlugsAndConfluenceAnchors(t *testing.T) {
+	headings := []string{"Overview", "Step 2: Deploy"}
+
+	for _, anchor := range []string{"step-2-deploy", "Step-2:-Deploy", "STEP-2-DEPLOY"} {
+		got, ok := ResolveHeadingAnchor(anchor, headings)
+		if !ok || got != "Step-2:-Deploy" {
+			t.Errorf("ResolveHeadingAnchor(%q) = %q, %v; want Step-2:-Deploy", anchor, got, ok)
+		}
+	}
+	if _, ok := ResolveHeadingAnchor("missing", headings); ok {
+		t.Error("expected unknown anchor to stay unresolved")
+	}
+}
+
+func TestMarkdownHeadings_SkipsFencedCode(t *testing.T) {
+	markdown := "# Title\n\n```bash\n# not a heading\n```\n\n````md\n```\n# nested fence\n````\n\n## [Linked](x.md) **Bold** ##\n"
+
+	got := MarkdownHeadings(markdown)
+
+	if want := []string{"Title", "Linked Bold"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("MarkdownHeadings() = %#v, want %#v", got, want)
+	}
+}
+
+func TestReverse_RewritesIntraPageAnchorsToHeadingIDs(t *testing.T) {
+	markdown := "## Section A\n\nSee [above](#section-a) and [elsewhere](#nowhere).\n"
+
+	result, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{Strict: true}, "doc.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	adf := string(result.ADF)
+	if !strings.Contains(adf, `"href":"#Section-A"`) {
+		t.Fatalf("expected heading anchor in ADF, got %s", adf)
+	}
+	if !strings.Contains(adf, `"href":"#nowhere"`) {
+		t.Fatalf("expected unmatched anchor to be kept, got %s", adf)
+	}
+}
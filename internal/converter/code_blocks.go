@@ -0,0 +1,25 @@
+package converter
+
+// codeBlockLanguageAliases maps common Markdown fence info strings to the
+// language names Confluence code blocks use. Reverse applies it to fenced
+// blocks; any other language is kept verbatim, and Forward writes the ADF
+// language as the fence info string unchanged.
+var codeBlockLanguageAliases = map[string]string{
+	"c#":     "csharp",
+	"c++":    "cpp",
+	"cs":     "csharp",
+	"golang": "go",
+	"js":     "javascript",
+	"kt":     "kotlin",
+	"md":     "markdown",
+	"objc":   "objectivec",
+	"ps1":    "powershell",
+	"pwsh":   "powershell",
+	"py":     "python",
+	"rb":     "ruby",
+	"rs":     "rust",
+	"sh":     "shell",
+	"ts":     "typescript",
+	"yml":    "yaml",
+	"zsh":    "shell",
+}
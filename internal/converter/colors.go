@@ -0,0 +1,141 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// WarningUnsupportedColor is reported by Reverse when a `textColor` or
+// `backgroundColor` span uses a color Confluence cannot store. The color mark
+// is dropped and the text is kept.
+const WarningUnsupportedColor adfconv.WarningType = "unsupported_color"
+
+var (
+	hexColorPattern      = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	shortHexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{3}$`)
+)
+
+// namedColors maps the CSS basic color keywords to the hex form Confluence
+// expects, so hand-written `color: red` spans still publish.
+var namedColors = map[string]string{
+	"black":   "#000000",
+	"silver":  "#c0c0c0",
+	"gray":    "#808080",
+	"grey":    "#808080",
+	"white":   "#ffffff",
+	"maroon":  "#800000",
+	"red":     "#ff0000",
+	"purple":  "#800080",
+	"fuchsia": "#ff00ff",
+	"green":   "#008000",
+	"lime":    "#00ff00",
+	"olive":   "#808000",
+	"yellow":  "#ffff00",
+	"navy":    "#000080",
+	"blue":    "#0000ff",
+	"teal":    "#008080",
+	"aqua":    "#00ffff",
+}
+
+// normalizeColorValue returns the `#rrggbb` form of a CSS color value, or
+// false when the value cannot be represented as an ADF color mark.
+func normalizeColorValue(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch {
+	case hexColorPattern.MatchString(value):
+		return value, true
+	case shortHexColorPattern.MatchString(value):
+		return "#" + strings.Repeat(value[1:2], 2) + strings.Repeat(value[2:3], 2) + strings.Repeat(value[3:4], 2), true
+	}
+	if hex, ok := namedColors[strings.ToLower(value)]; ok {
+		return hex, true
+	}
+	return "", false
+}
+
+// normalizeColorMarks rewrites `textColor` and `backgroundColor` mark colors
+// to `#rrggbb` and drops marks whose color cannot be represented, returning a
+// warning for each dropped mark. The ADF is returned unchanged when no mark
+// needs rewriting.
+func normalizeColorMarks(adf []byte) ([]byte, []adfconv.Warning) {
+	if !bytes.Contains(adf, []byte(`"textColor"`)) && !bytes.Contains(adf, []byte(`"backgroundColor"`)) {
+		return adf, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(adf))
+	decoder.UseNumber()
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		return adf, nil
+	}
+
+	var warnings []adfconv.Warning
+	if !normalizeColorMarksInNode(root, &warnings) {
+		return adf, warnings
+	}
+
+	normalized, err := json.Marshal(root)
+	if err != nil {
+		return adf, warnings
+	}
+	return normalized, warnings
+}
+
+func normalizeColorMarksInNode(node any, warnings *[]adfconv.Warning) bool {
+	changed := false
+	switch typed := node.(type) {
+	case []any:
+		for _, item := range typed {
+			if normalizeColorMarksInNode(item, warnings) {
+				changed = true
+			}
+		}
+	case map[string]any:
+		if marks, ok := typed["marks"].([]any); ok {
+			kept := make([]any, 0, len(marks))
+			for _, rawMark := range marks {
+				mark, ok := rawMark.(map[string]any)
+				markType, _ := mark["type"].(string)
+				if !ok || (markType != "textColor" && markType != "backgroundColor") {
+					kept = append(kept, rawMark)
+					continue
+				}
+				attrs, _ := mark["attrs"].(map[string]any)
+				color, _ := attrs["color"].(string)
+				normalized, ok := normalizeColorValue(color)
+				if !ok {
+					text, _ := typed["text"].(string)
+					*warnings = append(*warnings, adfconv.Warning{
+						Type:     WarningUnsupportedColor,
+						NodeType: markType,
+						Context:  text,
+						Message:  fmt.Sprintf("%s %q is not a #RRGGBB color or basic CSS color name; the color was dropped from %q", markType, color, text),
+					})
+					changed = true
+					continue
+				}
+				if normalized != color {
+					attrs["color"] = normalized
+					changed = true
+				}
+				kept = append(kept, rawMark)
+			}
+			if len(kept) != len(marks) {
+				if len(kept) == 0 {
+					delete(typed, "marks")
+				} else {
+					typed["marks"] = kept
+				}
+			}
+		}
+		if content, ok := typed["content"]; ok && normalizeColorMarksInNode(content, warnings) {
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,180 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// WarningUnsupportedDecisionState is reported by Forward when a decisionItem
+// carries a state other than DECIDED or UNDECIDED. The decision is rendered
+// as a plain `**Decision**:` item.
+const WarningUnsupportedDecisionState adfconv.WarningType = "unsupported_decision_state"
+
+const (
+	decisionStateDecided   = "DECIDED"
+	decisionStateUndecided = "UNDECIDED"
+)
+
+// decisionStateWarnings lists the decision items whose state the Markdown
+// decision convention cannot express.
+func decisionStateWarnings(adf []byte) []adfconv.Warning {
+	if !bytes.Contains(adf, []byte(`"decisionItem"`)) {
+		return nil
+	}
+	_, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return nil
+	}
+
+	var warnings []adfconv.Warning
+	walkADFNodes(content, func(node map[string]any) {
+		if nodeType, _ := node["type"].(string); nodeType != "decisionItem" {
+			return
+		}
+		attrs, _ := node["attrs"].(map[string]any)
+		state, _ := attrs["state"].(string)
+		switch state {
+		case "", decisionStateDecided, decisionStateUndecided:
+			return
+		}
+		text := adfNodeText(node)
+		warnings = append(warnings, adfconv.Warning{
+			Type:     WarningUnsupportedDecisionState,
+			NodeType: "decisionItem",
+			Context:  text,
+			Message:  fmt.Sprintf("decision %q has unsupported state %q; it is shown as a plain decision and push keeps the state only while the text is unchanged", text, state),
+		})
+	})
+	return warnings
+}
+
+// previousLocalNode is a decisionItem or taskItem of the page body a push
+// replaces.
+type previousLocalNode struct {
+	localID string
+	state   string
+	text    string
+	used    bool
+}
+
+// rebuildDecisionLists turns the decision nodes parsed from Markdown into
+// valid ADF: every item gets inline content, a state and a localId. localIds
+// and states are taken from previous, the page's current ADF, so unchanged
+// decisions keep their identity: lists match by position, items first by
+// text and then by position. Anything unmatched gets a new localId and
+// items without a state become DECIDED.
+func rebuildDecisionLists(adf, previous []byte) []byte {
+	if !bytes.Contains(adf, []byte(`"decisionList"`)) {
+		return adf
+	}
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf
+	}
+
+	var previousLists []string
+	var previousItems []*previousLocalNode
+	if _, previousContent, ok := decodeADFDocContent(previous); ok {
+		walkADFNodes(previousContent, func(node map[string]any) {
+			attrs, _ := node["attrs"].(map[string]any)
+			localID, _ := attrs["localId"].(string)
+			switch nodeType, _ := node["type"].(string); nodeType {
+			case "decisionList":
+				previousLists = append(previousLists, localID)
+			case "decisionItem":
+				state, _ := attrs["state"].(string)
+				previousItems = append(previousItems, &previousLocalNode{localID: localID, state: state, text: adfNodeText(node)})
+			}
+		})
+	}
+
+	listIndex, itemIndex := 0, 0
+	walkADFNodes(content, func(node map[string]any) {
+		switch nodeType, _ := node["type"].(string); nodeType {
+		case "decisionList":
+			attrs := nodeAttrs(node)
+			if listIndex < len(previousLists) && previousLists[listIndex] != "" {
+				attrs["localId"] = previousLists[listIndex]
+			} else {
+				attrs["localId"] = uuid.NewString()
+			}
+			listIndex++
+		case "decisionItem":
+			unwrapDecisionParagraph(node)
+			attrs := nodeAttrs(node)
+			state, _ := attrs["state"].(string)
+			localID := ""
+			if match := matchPreviousLocalNode(previousItems, adfNodeText(node), itemIndex); match != nil {
+				localID = match.localID
+				if state == "" {
+					state = match.state
+				}
+			}
+			if localID == "" {
+				localID = uuid.NewString()
+			}
+			if state == "" {
+				state = decisionStateDecided
+			}
+			attrs["localId"] = localID
+			attrs["state"] = state
+			itemIndex++
+		}
+	})
+
+	rebuilt, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return rebuilt
+}
+
+// matchPreviousLocalNode returns the first unused previous item with text, or
+// else the unused item at index.
+func matchPreviousLocalNode(previous []*previousLocalNode, text string, index int) *previousLocalNode {
+	for _, candidate := range previous {
+		if !candidate.used && candidate.text == text {
+			candidate.used = true
+			return candidate
+		}
+	}
+	if index < len(previous) && !previous[index].used {
+		previous[index].used = true
+		return previous[index]
+	}
+	return nil
+}
+
+// unwrapDecisionParagraph replaces a decisionItem's single paragraph with
+// the paragraph's inline content; ADF decision items hold inline nodes only.
+func unwrapDecisionParagraph(node map[string]any) {
+	children, _ := node["content"].([]any)
+	if len(children) != 1 {
+		return
+	}
+	paragraph, ok := children[0].(map[string]any)
+	if !ok {
+		return
+	}
+	if nodeType, _ := paragraph["type"].(string); nodeType != "paragraph" {
+		return
+	}
+	inline, _ := paragraph["content"].([]any)
+	if inline == nil {
+		inline = []any{}
+	}
+	node["content"] = inline
+}
+
+func nodeAttrs(node map[string]any) map[string]any {
+	attrs, ok := node["attrs"].(map[string]any)
+	if !ok {
+		attrs = map[string]any{}
+		node["attrs"] = attrs
+	}
+	return attrs
+}
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const decisionsTestADF = `{"version":1,"type":"doc","content":[` +
+	`{"type":"decisionList","attrs":{"localId":"list-1"},"content":[` +
+	`{"type":"decisionItem","attrs":{"localId":"item-1","state":"DECIDED"},"content":[{"type":"text","text":"Ship on Friday"}]},` +
+	`{"type":"decisionItem","attrs":{"localId":"item-2","state":"UNDECIDED"},"content":[{"type":"text","text":"Rename the service"}]},` +
+	`{"type":"decisionItem","attrs":{"localId":"item-3","state":"PROPOSED"},"content":[{"type":"text","text":"Drop IE support"}]}` +
+	`]}]}`
+
+type decisionTestNode struct {
+	Type    string             `json:"type"`
+	Attrs   map[string]any     `json:"attrs"`
+	Content []decisionTestNode `json:"content"`
+	Text    string             `json:"text"`
+}
+
+func decodeDecisionList(t *testing.T, adf []byte) decisionTestNode {
+	t.Helper()
+	var doc decisionTestNode
+	if err := json.Unmarshal(adf, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	for _, node := range doc.Content {
+		if node.Type == "decisionList" {
+			return node
+		}
+	}
+	t.Fatalf("no decisionList in ADF: %s", adf)
+	return decisionTestNode{}
+}
+
+func TestForward_RendersDecisionsAndWarnsOnUnsupportedState(t *testing.T) {
+	res, err := Forward(context.Background(), []byte(decisionsTestADF), ForwardConfig{}, "notes.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	for _, want := range []string{"> **✓ Decision**: Ship on Friday", "> **? Decision**: Rename the service", "> **Decision**: Drop IE support"} {
+		if !strings.Contains(res.Markdown, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, res.Markdown)
+		}
+	}
+
+	if len(res.Warnings) != 1 || res.Warnings[0].Type != WarningUnsupportedDecisionState {
+		t.Fatalf("warnings = %+v, want one %s", res.Warnings, WarningUnsupportedDecisionState)
+	}
+	if !strings.Contains(res.Warnings[0].Message, `"PROPOSED"`) {
+		t.Fatalf("warning should name the state: %s", res.Warnings[0].Message)
+	}
+}
+
+func TestReverse_RebuildsDecisionListsWithLocalIDsAndInlineContent(t *testing.T) {
+	markdown := "> **✓ Decision**: Ship on Friday\n> \n> **Decision**: Drop IE support\n"
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "notes.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	list := decodeDecisionList(t, res.ADF)
+	if id, _ := list.Attrs["localId"].(string); id == "" {
+		t.Fatalf("decisionList needs a localId: %s", res.ADF)
+	}
+	if len(list.Content) != 2 {
+		t.Fatalf("decision items = %d, want 2: %s", len(list.Content), res.ADF)
+	}
+	seen := map[string]bool{}
+	for i, wantState := range []string{"DECIDED", "DECIDED"} {
+		item := list.Content[i]
+		id, _ := item.Attrs["localId"].(string)
+		if id == "" || seen[id] {
+			t.Fatalf("item %d needs a unique localId: %s", i, res.ADF)
+		}
+		seen[id] = true
+		if item.Attrs["state"] != wantState {
+			t.Fatalf("item %d state = %v, want %s", i, item.Attrs["state"], wantState)
+		}
+		if len(item.Content) == 0 || item.Content[0].Type != "text" {
+			t.Fatalf("item %d should hold inline content: %s", i, res.ADF)
+		}
+	}
+}
+
+func TestReverse_DecisionsKeepPreviousLocalIDsAcrossRoundTrip(t *testing.T) {
+	forward, err := Forward(context.Background(), []byte(decisionsTestADF), ForwardConfig{}, "notes.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	// Reordering must not swap identities: items match by text first.
+	markdown := strings.Replace(forward.Markdown,
+		"> **✓ Decision**: Ship on Friday\n> \n> **? Decision**: Rename the service",
+		"> **? Decision**: Rename the service\n> \n> **✓ Decision**: Ship on Friday", 1)
+
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{PreviousADF: []byte(decisionsTestADF)}, "notes.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	list := decodeDecisionList(t, res.ADF)
+	if list.Attrs["localId"] != "list-1" {
+		t.Fatalf("decisionList localId = %v, want list-1", list.Attrs["localId"])
+	}
+	want := []struct{ id, state string }{
+		{"item-2", "UNDECIDED"},
+		{"item-1", "DECIDED"},
+		{"item-3", "PROPOSED"},
+	}
+	if len(list.Content) != len(want) {
+		t.Fatalf("decision items = %d, want %d: %s", len(list.Content), len(want), res.ADF)
+	}
+	for i, expected := range want {
+		item := list.Content[i]
+		if item.Attrs["localId"] != expected.id || item.Attrs["state"] != expected.state {
+			t.Fatalf("item %d attrs = %v, want localId %s state %s", i, item.Attrs, expected.id, expected.state)
+		}
+	}
+}
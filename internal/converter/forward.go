@@ -16,17 +16,28 @@ type ForwardResult struct {
 type ForwardConfig struct {
 	LinkHook  adfconv.LinkRenderHook
 	MediaHook adfconv.MediaRenderHook
+
+	// StripLeadingH1 drops a leading H1 whose text equals Title, since the
+	// title is already kept in frontmatter. Formatted headings are kept.
+	StripLeadingH1 bool
+	Title          string
 }
 
 // Forward converts ADF JSON to Markdown using best-effort resolution.
 // This is used for pull and diff operations where partial success is preferred over failure.
 func Forward(ctx context.Context, adfJSON []byte, cfg ForwardConfig, sourcePath string) (ForwardResult, error) {
+	mediaHook := cfg.MediaHook
+	if mediaHook != nil {
+		mediaHook = inlineFileMediaHook(mediaHook)
+		adfJSON = markInlineFileMedia(adfJSON)
+	}
+
 	// Create converter with best-effort resolution.
 	// We want to recover as much content as possible even if some references are broken.
 	c, err := adfconv.New(adfconv.Config{
 		ResolutionMode:       adfconv.ResolutionBestEffort,
 		LinkHook:             cfg.LinkHook,
-		MediaHook:            cfg.MediaHook,
+		MediaHook:            mediaHook,
 		UnderlineStyle:       adfconv.UnderlinePandoc,
 		SubSupStyle:          adfconv.SubSupPandoc,
 		TextColorStyle:       adfconv.ColorPandoc,
@@ -34,28 +45,44 @@ func Forward(ctx context.Context, adfJSON []byte, cfg ForwardConfig, sourcePath
 		MentionStyle:         adfconv.MentionPandoc,
 		HardBreakStyle:       adfconv.HardBreakDoubleSpace,
 		AlignmentStyle:       adfconv.AlignPandoc,
+		PanelStyle:           adfconv.PanelTitle,
 		ExpandStyle:          adfconv.ExpandPandoc,
+		CaptionStyle:         adfconv.CaptionPandoc,
 		InlineCardStyle:      adfconv.InlineCardLink,
 		LayoutSectionStyle:   adfconv.LayoutSectionPandoc,
 		TableMode:            adfconv.TableAutoPandoc,
 		ExtensionHandlers: map[string]adfconv.ExtensionHandler{
+			"html":          &HTMLMacroHandler{},
 			"plantumlcloud": &PlantUMLHandler{},
+			"toc":           &TOCHandler{},
 		},
 	})
 	if err != nil {
 		return ForwardResult{}, err
 	}
 
+	adfJSON = normalizeIntraPageAnchors(adfJSON)
+	adfJSON = orderInlineMarks(adfJSON)
+	if cfg.StripLeadingH1 {
+		adfJSON = stripLeadingTitleHeading(adfJSON, cfg.Title)
+	}
+	adfJSON, tableCells, tableWarnings := prepareTableCells(adfJSON)
+
 	// Run conversion with context and source path for relative link resolution.
-	res, err := c.ConvertWithContext(ctx, adfJSON, adfconv.ConvertOptions{
-		SourcePath: sourcePath,
-	})
+	opts := adfconv.ConvertOptions{SourcePath: sourcePath}
+	res, err := c.ConvertWithContext(ctx, adfJSON, opts)
+	if err != nil {
+		return ForwardResult{}, err
+	}
+	markdown, cellWarnings, err := renderTableCells(ctx, c, res.Markdown, tableCells, opts)
 	if err != nil {
 		return ForwardResult{}, err
 	}
 
+	warnings := append(res.Warnings, cellWarnings...)
+	warnings = append(warnings, tableWarnings...)
 	return ForwardResult{
-		Markdown: normalizeForwardMarkdown(res.Markdown),
-		Warnings: res.Warnings,
+		Markdown: normalizeForwardMarkdown(markdown),
+		Warnings: append(warnings, decisionStateWarnings(adfJSON)...),
 	}, nil
 }
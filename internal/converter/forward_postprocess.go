@@ -8,8 +8,15 @@ import (
 var escapedInlineMarkdownLinkPattern = regexp.MustCompile(`\\\[((?:\\.|[^\\\]\n])+?)\\\]\\\(((?:\\.|[^\\\n])+?)\\\)`)
 var invisibleDateGuardPattern = strings.NewReplacer("\u2060", "", "\u2011", "-")
 
+// mediaCaptionLinePattern matches an image immediately followed by its
+// `[...]{.media-caption}` span, optionally behind blockquote markers.
+var mediaCaptionLinePattern = regexp.MustCompile(`^([ \t>]*)(!\[(?:\\.|[^\]\\\n])*\]\((?:<[^>\n]*>|[^)\n]*)\))(\[.*\]\{\.media-caption\})[ \t]*$`)
+
 func normalizeForwardMarkdown(markdown string) string {
 	markdown = invisibleDateGuardPattern.Replace(markdown)
+	markdown = normalizeIntrawordEmphasis(markdown)
+	markdown = normalizeConsecutiveHardBreaks(markdown)
+	markdown = splitMediaCaptionLines(markdown)
 	if !strings.Contains(markdown, `\[`) || !strings.Contains(markdown, `\]`) || !strings.Contains(markdown, `\(`) {
 		return normalizeEscapedParentheses(markdown)
 	}
@@ -35,6 +42,85 @@ func normalizeForwardMarkdown(markdown string) string {
 	return normalizeEscapedParentheses(normalized)
 }
 
+// normalizeConsecutiveHardBreaks keeps runs of ADF hardBreak nodes inside one
+// paragraph. Hard breaks are emitted as two trailing spaces, but a second break
+// in a row yields a whitespace-only line that Markdown treats as a paragraph
+// separator. Such lines are rewritten to a backslash hard break instead.
+func normalizeConsecutiveHardBreaks(markdown string) string {
+	if !strings.Contains(markdown, "  \n") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	previousBreaks := false
+	for i, line := range lines {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			previousBreaks = false
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if previousBreaks && strings.HasSuffix(line, "  ") && isHardBreakContainerPrefix(line) {
+			lines[i] = line[:len(line)-2] + `\`
+			continue
+		}
+
+		previousBreaks = !isHardBreakContainerPrefix(line) && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// splitMediaCaptionLines moves a mediaSingle caption onto its own line under
+// the image. Reverse conversion joins the two lines back together.
+func splitMediaCaptionLines(markdown string) string {
+	if !strings.Contains(markdown, "{.media-caption}") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for _, line := range lines {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			out = append(out, line)
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		parts := mediaCaptionLinePattern.FindStringSubmatch(line)
+		if parts == nil {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, parts[1]+parts[2], parts[1]+parts[3])
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// isHardBreakContainerPrefix reports whether line holds only list indentation
+// and blockquote markers, i.e. no inline content.
+func isHardBreakContainerPrefix(line string) bool {
+	return strings.Trim(line, " >") == ""
+}
+
 func normalizeEscapedParentheses(markdown string) string {
 	if !strings.Contains(markdown, `\(`) && !strings.Contains(markdown, `\)`) {
 		return markdown
@@ -138,6 +138,34 @@ func TestForwardWithMediaHook(t *testing.T) {
 	}
 }
 
+func TestForward_RendersMediaInlineAsFile(t *testing.T) {
+	adfJSON := []byte(`{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"See "},{"type":"mediaInline","attrs":{"id":"chip","collection":"col"}},{"type":"text","text":" and "},{"type":"mediaInline","attrs":{"id":"pic","type":"image","collection":"col"}}]},{"type":"mediaSingle","content":[{"type":"media","attrs":{"id":"block","collection":"col"}}]}]}`)
+
+	seen := map[string]adfconv.MediaRenderInput{}
+	mediaHook := func(_ context.Context, in adfconv.MediaRenderInput) (adfconv.MediaRenderOutput, error) {
+		seen[in.ID] = in
+		if in.MediaType == "file" {
+			return adfconv.MediaRenderOutput{Markdown: "[" + in.ID + ".pdf](assets/" + in.ID + ".pdf)", Handled: true}, nil
+		}
+		return adfconv.MediaRenderOutput{Markdown: "![" + in.ID + "](assets/" + in.ID + ".png)", Handled: true}, nil
+	}
+
+	res, err := Forward(context.Background(), adfJSON, ForwardConfig{MediaHook: mediaHook}, "test.md")
+	if err != nil {
+		t.Fatalf("Forward failed: %v", err)
+	}
+
+	expected := "See [chip.pdf](assets/chip.pdf) and ![pic](assets/pic.png)\n\n![block](assets/block.png)\n"
+	if res.Markdown != expected {
+		t.Errorf("Expected markdown %q, got %q", expected, res.Markdown)
+	}
+	for id, in := range seen {
+		if _, leaked := in.Attrs[inlineFileMediaAttr]; leaked {
+			t.Errorf("media %s: hook saw internal attr %q", id, inlineFileMediaAttr)
+		}
+	}
+}
+
 func TestNormalizeForwardMarkdown_Complex(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -169,3 +197,37 @@ func TestNormalizeForwardMarkdown_Complex(t *testing.T) {
 		})
 	}
 }
+
+func TestForward_StripLeadingH1MatchingTitle(t *testing.T) {
+	ctx := context.Background()
+	doc := func(heading string) []byte {
+		return []byte(`{"version":1,"type":"doc","content":[` + heading + `,{"type":"paragraph","content":[{"type":"text","text":"Body"}]}]}`)
+	}
+	plain := `{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Release Notes"}]}`
+	bold := `{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Release Notes","marks":[{"type":"strong"}]}]}`
+	level2 := `{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Release Notes"}]}`
+
+	tests := []struct {
+		name string
+		adf  []byte
+		cfg  ForwardConfig
+		want string
+	}{
+		{name: "matching title is stripped", adf: doc(plain), cfg: ForwardConfig{StripLeadingH1: true, Title: "Release Notes"}, want: "Body\n"},
+		{name: "disabled by default", adf: doc(plain), cfg: ForwardConfig{Title: "Release Notes"}, want: "# Release Notes\n\nBody\n"},
+		{name: "different title is kept", adf: doc(plain), cfg: ForwardConfig{StripLeadingH1: true, Title: "Changelog"}, want: "# Release Notes\n\nBody\n"},
+		{name: "formatted heading is kept", adf: doc(bold), cfg: ForwardConfig{StripLeadingH1: true, Title: "Release Notes"}, want: "# **Release Notes**\n\nBody\n"},
+		{name: "non-H1 heading is kept", adf: doc(level2), cfg: ForwardConfig{StripLeadingH1: true, Title: "Release Notes"}, want: "## Release Notes\n\nBody\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Forward(ctx, tc.adf, tc.cfg, "test.md")
+			if err != nil {
+				t.Fatalf("Forward failed: %v", err)
+			}
+			if res.Markdown != tc.want {
+				t.Fatalf("Markdown = %q, want %q", res.Markdown, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// WarningHTMLBlockPassthrough is reported by Reverse for a raw HTML block
+// that has no ADF equivalent. The block is published verbatim in a
+// Confluence HTML macro instead of being flattened into a paragraph.
+const WarningHTMLBlockPassthrough adfconv.WarningType = "html_block_passthrough"
+
+// WarningHTMLCommentDropped is reported by Reverse for a top-level HTML block
+// holding only comments, which is left out of the published page.
+const WarningHTMLCommentDropped adfconv.WarningType = "html_comment_dropped"
+
+const htmlExtensionKey = "html"
+
+// HTMLMacroHandler implements adfconv.ExtensionHandler for the Confluence
+// "html" macro, which carries raw HTML blocks Markdown has no ADF node for.
+type HTMLMacroHandler struct{}
+
+// ToMarkdown renders the macro as an adf-extension wrapper holding the raw
+// HTML, so a pulled block is pushed back as the same macro.
+func (h *HTMLMacroHandler) ToMarkdown(ctx context.Context, in adfconv.ExtensionRenderInput) (adfconv.ExtensionRenderOutput, error) {
+	if in.Node.Type != "extension" {
+		return adfconv.ExtensionRenderOutput{Handled: false}, nil
+	}
+	if in.Node.GetStringAttr("extensionKey", "") != htmlExtensionKey {
+		return adfconv.ExtensionRenderOutput{Handled: false}, nil
+	}
+
+	raw := ""
+	if params, ok := in.Node.Attrs["parameters"].(map[string]interface{}); ok {
+		if macroParams, ok := params["macroParams"].(map[string]interface{}); ok {
+			if body, ok := macroParams["__bodyContent"].(map[string]interface{}); ok {
+				raw, _ = body["value"].(string)
+			}
+		}
+	}
+	if strings.TrimSpace(raw) == "" {
+		return adfconv.ExtensionRenderOutput{Handled: false}, nil
+	}
+
+	return adfconv.ExtensionRenderOutput{
+		Markdown: strings.TrimSpace(raw) + "\n",
+		Handled:  true,
+	}, nil
+}
+
+// FromMarkdown rebuilds the macro from the raw HTML in the wrapper.
+func (h *HTMLMacroHandler) FromMarkdown(ctx context.Context, in adfconv.ExtensionParseInput) (adfconv.ExtensionParseOutput, error) {
+	if in.ExtensionKey != htmlExtensionKey {
+		return adfconv.ExtensionParseOutput{Handled: false}, nil
+	}
+	return adfconv.ExtensionParseOutput{
+		Node:    adfconv.Node{Type: "extension", Attrs: htmlMacroAttrs(strings.TrimSpace(in.Body))},
+		Handled: true,
+	}, nil
+}
+
+func htmlMacroAttrs(raw string) map[string]interface{} {
+	return map[string]interface{}{
+		"extensionType": "com.atlassian.confluence.macro.core",
+		"extensionKey":  htmlExtensionKey,
+		"parameters": map[string]interface{}{
+			"macroParams": map[string]interface{}{
+				"__bodyContent": map[string]interface{}{"value": raw},
+			},
+			"macroMetadata": map[string]interface{}{
+				"schemaVersion": map[string]interface{}{"value": "1"},
+				"title":         "HTML",
+			},
+		},
+	}
+}
+
+// preserveUnsupportedHTMLBlocks rewrites the paragraphs the Markdown
+// converter produces for HTML blocks it cannot map (<table> and <details>
+// are mapped by the converter itself). A fallback paragraph is only
+// rewritten when its text is the next HTML block of markdown, the source
+// the converter was given, so a paragraph that merely looks like HTML is
+// left alone. Each such block becomes an HTML macro with a
+// WarningHTMLBlockPassthrough warning; a top-level block holding only HTML
+// comments is dropped with a WarningHTMLCommentDropped warning, since
+// comments are author notes that Confluence would otherwise show as text.
+// The converter's generic unknown_node warnings for those blocks are
+// replaced.
+func preserveUnsupportedHTMLBlocks(markdown string, adf []byte, warnings []adfconv.Warning) ([]byte, []adfconv.Warning) {
+	unknown := 0
+	for _, warning := range warnings {
+		if warning.Type == adfconv.WarningUnknownNode && warning.NodeType == "HTMLBlock" {
+			unknown++
+		}
+	}
+	if unknown == 0 {
+		return adf, warnings
+	}
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf, warnings
+	}
+
+	sources := htmlBlockSources(markdown)
+	next := 0
+	matched := 0
+	var replaced []adfconv.Warning
+	var rewrite func(nodes []any, topLevel bool) []any
+	rewrite = func(nodes []any, topLevel bool) []any {
+		out := make([]any, 0, len(nodes))
+		for _, rawNode := range nodes {
+			node, ok := rawNode.(map[string]any)
+			if !ok {
+				out = append(out, rawNode)
+				continue
+			}
+			if raw, isHTML := htmlBlockFallbackText(node); isHTML {
+				if index := indexOfSource(sources, next, raw); index >= 0 {
+					next = index + 1
+					matched++
+					if topLevel && isHTMLCommentOnly(raw) {
+						replaced = append(replaced, adfconv.Warning{
+							Type:     WarningHTMLCommentDropped,
+							NodeType: "HTMLBlock",
+							Context:  firstLine(raw),
+							Message:  fmt.Sprintf("HTML comment %q is not published to Confluence", firstLine(raw)),
+						})
+						continue
+					}
+					replaced = append(replaced, adfconv.Warning{
+						Type:     WarningHTMLBlockPassthrough,
+						NodeType: "HTMLBlock",
+						Context:  firstLine(raw),
+						Message:  fmt.Sprintf("HTML block %q has no Confluence equivalent; it is published in an HTML macro, which renders only where the macro is enabled", firstLine(raw)),
+					})
+					out = append(out, map[string]any{"type": "extension", "attrs": htmlMacroAttrs(raw)})
+					continue
+				}
+			}
+			if children, ok := node["content"].([]any); ok {
+				node["content"] = rewrite(children, false)
+			}
+			out = append(out, node)
+		}
+		return out
+	}
+	root["content"] = rewrite(content, true)
+	if matched == 0 {
+		return adf, warnings
+	}
+
+	rewritten, err := json.Marshal(root)
+	if err != nil {
+		return adf, warnings
+	}
+
+	// Keep the converter's warning for any block that could not be matched
+	// back to the source.
+	kept := make([]adfconv.Warning, 0, len(warnings))
+	for _, warning := range warnings {
+		if matched > 0 && warning.Type == adfconv.WarningUnknownNode && warning.NodeType == "HTMLBlock" {
+			matched--
+			continue
+		}
+		kept = append(kept, warning)
+	}
+	return rewritten, append(kept, replaced...)
+}
+
+// htmlBlockSources returns the trimmed text of every HTML block in markdown,
+// in document order, the way the converter reads it.
+func htmlBlockSources(markdown string) []string {
+	source := []byte(markdown)
+	doc := goldmark.New().Parser().Parse(text.NewReader(source))
+	var blocks []string
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		block, ok := node.(*ast.HTMLBlock)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		raw := block.Lines().Value(source)
+		if block.HasClosure() {
+			raw = append(raw, block.ClosureLine.Value(source)...)
+		}
+		blocks = append(blocks, strings.TrimSpace(string(raw)))
+		return ast.WalkSkipChildren, nil
+	})
+	return blocks
+}
+
+func indexOfSource(sources []string, from int, raw string) int {
+	for i := from; i < len(sources); i++ {
+		if sources[i] == raw {
+			return i
+		}
+	}
+	return -1
+}
+
+// htmlBlockFallbackText reports whether node is the plain paragraph the
+// converter emits for an unsupported HTML block, and returns the raw HTML.
+func htmlBlockFallbackText(node map[string]any) (string, bool) {
+	if nodeType, _ := node["type"].(string); nodeType != "paragraph" {
+		return "", false
+	}
+	if _, hasAttrs := node["attrs"]; hasAttrs {
+		return "", false
+	}
+	children, _ := node["content"].([]any)
+	if len(children) != 1 {
+		return "", false
+	}
+	text, ok := children[0].(map[string]any)
+	if !ok {
+		return "", false
+	}
+	if textType, _ := text["type"].(string); textType != "text" {
+		return "", false
+	}
+	if _, hasMarks := text["marks"]; hasMarks {
+		return "", false
+	}
+	raw, _ := text["text"].(string)
+	if !strings.HasPrefix(raw, "<") || !strings.HasSuffix(raw, ">") {
+		return "", false
+	}
+	return raw, true
+}
+
+func isHTMLCommentOnly(raw string) bool {
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		if !strings.HasPrefix(rest, "<!--") {
+			return false
+		}
+		end := strings.Index(rest, "-->")
+		if end < 0 {
+			return false
+		}
+		rest = strings.TrimSpace(rest[end+len("-->"):])
+	}
+	return true
+}
+
+func firstLine(raw string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(raw), "\n")
+	return strings.TrimSpace(line)
+}
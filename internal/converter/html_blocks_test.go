@@ -0,0 +1,140 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+func TestReverse_ConvertsSupportedHTMLBlocks(t *testing.T) {
+	markdown := "<details>\n<summary>More</summary>\n\nHidden text\n\n</details>\n\n<table>\n<tr><th>A</th></tr>\n<tr><td>1</td></tr>\n</table>\n"
+
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	adf := string(res.ADF)
+	if !strings.Contains(adf, `"type":"expand"`) || !strings.Contains(adf, `"title":"More"`) {
+		t.Fatalf("expected <details> to become an expand, got %s", adf)
+	}
+	if !strings.Contains(adf, `"type":"table"`) {
+		t.Fatalf("expected <table> to become a table, got %s", adf)
+	}
+	if len(res.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %+v", res.Warnings)
+	}
+}
+
+func TestReverse_PassesUnsupportedHTMLBlockThroughHTMLMacro(t *testing.T) {
+	markdown := "Intro\n\n<!-- reviewer note -->\n\n<iframe src=\"https://example.com\"></iframe>\n\n- item\n\n  <div class=\"x\">inside</div>\n"
+
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	var doc struct {
+		Content []struct {
+			Type  string         `json:"type"`
+			Attrs map[string]any `json:"attrs"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(res.ADF, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	var types []string
+	for _, node := range doc.Content {
+		types = append(types, node.Type)
+	}
+	if got := strings.Join(types, ","); got != "paragraph,extension,bulletList" {
+		t.Fatalf("top-level nodes = %s, want paragraph,extension,bulletList (comment dropped)", got)
+	}
+	if doc.Content[1].Attrs["extensionKey"] != "html" || !strings.Contains(string(res.ADF), `"__bodyContent":{"value":"\u003ciframe`) {
+		t.Fatalf("expected an html macro holding the iframe, got %s", res.ADF)
+	}
+	if !strings.Contains(string(res.ADF), `"value":"\u003cdiv class=\"x\"\u003einside\u003c/div\u003e"`) {
+		t.Fatalf("expected the nested block in an html macro too, got %s", res.ADF)
+	}
+	if strings.Contains(string(res.ADF), "reviewer note") {
+		t.Fatalf("expected HTML comment to be dropped, got %s", res.ADF)
+	}
+
+	var warningTypes []string
+	for _, warning := range res.Warnings {
+		warningTypes = append(warningTypes, string(warning.Type))
+	}
+	if got := strings.Join(warningTypes, ","); got != "html_comment_dropped,html_block_passthrough,html_block_passthrough" {
+		t.Fatalf("warnings = %+v", res.Warnings)
+	}
+	if !strings.Contains(res.Warnings[1].Message, "<iframe") {
+		t.Fatalf("warning should quote the block, got %q", res.Warnings[1].Message)
+	}
+}
+
+func TestPreserveUnsupportedHTMLBlocks_MatchesBlocksFromSource(t *testing.T) {
+	// The first paragraph looks like the converter's fallback for an HTML
+	// block but has no HTML block behind it in the source.
+	markdown := "Some text\n\n<iframe src=\"https://example.com\"></iframe>\n"
+	adf := []byte(`{"type":"doc","version":1,"content":[` +
+		`{"type":"paragraph","content":[{"type":"text","text":"<b>not a block</b>"}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"<iframe src=\"https://example.com\"></iframe>"}]}]}`)
+	warnings := []adfconv.Warning{{Type: adfconv.WarningUnknownNode, NodeType: "HTMLBlock"}}
+
+	got, gotWarnings := preserveUnsupportedHTMLBlocks(markdown, adf, warnings)
+
+	var doc struct {
+		Content []struct {
+			Type string `json:"type"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	if len(doc.Content) != 2 || doc.Content[0].Type != "paragraph" || doc.Content[1].Type != "extension" {
+		t.Fatalf("want the paragraph kept and only the iframe in a macro: %s", got)
+	}
+	if len(gotWarnings) != 1 || gotWarnings[0].Type != WarningHTMLBlockPassthrough {
+		t.Fatalf("warnings = %+v, want one passthrough warning", gotWarnings)
+	}
+}
+
+func TestHTMLMacro_RoundTrips(t *testing.T) {
+	// Underscores in the raw HTML are not emphasis and must survive the
+	// emphasis rewriting Forward and NormalizeMarkdown apply to Markdown.
+	markdown := "<div class=\"my_class_x\" data-key=\"a__b__c\">\n<iframe src=\"https://example.com/_x_\"></iframe>\n</div>\n"
+
+	pushed, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	pulled, err := Forward(context.Background(), pushed.ADF, ForwardConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	normalized := NormalizeMarkdown(pulled.Markdown)
+	if !strings.Contains(normalized, `::: { .adf-extension key="html" }`+"\n"+strings.TrimSpace(markdown)+"\n:::") {
+		t.Fatalf("pulled Markdown should keep the raw HTML in an html wrapper:\n%s", normalized)
+	}
+
+	again, err := Reverse(context.Background(), []byte(normalized), ReverseConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	var first, second any
+	if err := json.Unmarshal(pushed.ADF, &first); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	if err := json.Unmarshal(again.ADF, &second); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("html macro changed on round trip:\n%s\n%s", pushed.ADF, again.ADF)
+	}
+	if len(again.Warnings) != 0 {
+		t.Fatalf("a pulled html macro should push back without warnings: %+v", again.Warnings)
+	}
+}
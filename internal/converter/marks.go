@@ -0,0 +1,172 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// intrawordEmphasisProtectedPattern matches inline spans whose underscores are
+// not emphasis delimiters: escapes, code spans, link destinations, Pandoc
+// attribute blocks, autolinks and emoji shortcodes such as
+// :white_check_mark:, which the converter writes without escaping.
+var intrawordEmphasisProtectedPattern = regexp.MustCompile("\\\\.|(`+)[^`]*?(?:`+)|\\]\\((?:[^()\\\\]|\\\\.)*\\)|\\]\\{[^}]*\\}|<[^>\\s]+>|:[A-Za-z0-9_+-]+:")
+
+// inlineMarkRank orders the marks of one text node from outermost to
+// innermost. Markdown nesting is order-sensitive while ADF mark arrays are
+// not: a link must wrap everything else, and inline code must be innermost
+// because nothing inside backticks is parsed as Markdown.
+func inlineMarkRank(markType string) int {
+	switch markType {
+	case "link":
+		return 0
+	case "code":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// orderInlineMarks stable-sorts every text node's marks by inlineMarkRank.
+// Forward uses it so code-then-strong renders as **`x`** rather than a code
+// span containing literal asterisks, and adjacent nodes share the longest
+// run of open delimiters; Reverse uses it so parsed marks come back in the
+// same canonical order. The ADF is returned unchanged when nothing moves.
+func orderInlineMarks(adf []byte) []byte {
+	if !bytes.Contains(adf, []byte(`"marks"`)) {
+		return adf
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(adf))
+	decoder.UseNumber()
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		return adf
+	}
+	if !orderInlineMarksInNode(root) {
+		return adf
+	}
+
+	ordered, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return ordered
+}
+
+func orderInlineMarksInNode(node any) bool {
+	changed := false
+	switch typed := node.(type) {
+	case []any:
+		for _, item := range typed {
+			if orderInlineMarksInNode(item) {
+				changed = true
+			}
+		}
+	case map[string]any:
+		if marks, ok := typed["marks"].([]any); ok && len(marks) > 1 {
+			rank := func(i int) int {
+				mark, _ := marks[i].(map[string]any)
+				markType, _ := mark["type"].(string)
+				return inlineMarkRank(markType)
+			}
+			if !sort.SliceIsSorted(marks, func(i, j int) bool { return rank(i) < rank(j) }) {
+				sort.SliceStable(marks, func(i, j int) bool { return rank(i) < rank(j) })
+				changed = true
+			}
+		}
+		if content, ok := typed["content"]; ok && orderInlineMarksInNode(content) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// normalizeIntrawordEmphasis rewrites `_` emphasis delimiters that touch a
+// letter or digit to `*`. The converter writes emphasis as `_` whenever a
+// paragraph mixes strong and em, but CommonMark cannot open or close `_`
+// emphasis inside a word, so **bold_both_** would lose the em mark when
+// parsed back. Literal underscores are escaped by the converter everywhere
+// but in emoji shortcodes, so every bare `_` outside protected spans is a
+// delimiter, and delimiters pair up in order within a line. Fenced code and
+// adf-extension wrapper bodies are skipped.
+func normalizeIntrawordEmphasis(markdown string) string {
+	if !strings.Contains(markdown, "_") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	inWrapper := false
+	for i, line := range lines {
+		if inWrapper {
+			inWrapper = strings.TrimSpace(line) != ":::"
+			continue
+		}
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if isADFExtensionOpener(strings.TrimSpace(line)) {
+			inWrapper = true
+			continue
+		}
+		if strings.Contains(line, "_") {
+			lines[i] = rewriteIntrawordEmphasisLine(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func rewriteIntrawordEmphasisLine(line string) string {
+	protected := intrawordEmphasisProtectedPattern.FindAllStringIndex(line, -1)
+	delimiters := make([]int, 0)
+	next := 0
+	for i := 0; i < len(line); i++ {
+		for next < len(protected) && protected[next][1] <= i {
+			next++
+		}
+		if next < len(protected) && i >= protected[next][0] {
+			continue
+		}
+		if line[i] == '_' {
+			delimiters = append(delimiters, i)
+		}
+	}
+	if len(delimiters) == 0 || len(delimiters)%2 != 0 {
+		return line
+	}
+
+	out := []byte(line)
+	changed := false
+	for i := 0; i < len(delimiters); i += 2 {
+		opener, closer := delimiters[i], delimiters[i+1]
+		before, _ := utf8.DecodeLastRuneInString(line[:opener])
+		after, _ := utf8.DecodeRuneInString(line[closer+1:])
+		if !isWordRune(before) && !isWordRune(after) {
+			continue
+		}
+		out[opener] = '*'
+		out[closer] = '*'
+		changed = true
+	}
+	if !changed {
+		return line
+	}
+	return string(out)
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
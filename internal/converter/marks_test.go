@@ -0,0 +1,220 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type markedText struct {
+	Text  string           `json:"text"`
+	Marks []map[string]any `json:"marks,omitempty"`
+}
+
+func paragraphADF(t *testing.T, nodes ...markedText) []byte {
+	t.Helper()
+	content := make([]map[string]any, 0, len(nodes))
+	for _, node := range nodes {
+		item := map[string]any{"type": "text", "text": node.Text}
+		if len(node.Marks) > 0 {
+			item["marks"] = node.Marks
+		}
+		content = append(content, item)
+	}
+	raw, err := json.Marshal(map[string]any{
+		"type":    "doc",
+		"version": 1,
+		"content": []any{map[string]any{"type": "paragraph", "content": content}},
+	})
+	if err != nil {
+		t.Fatalf("marshal adf: %v", err)
+	}
+	return raw
+}
+
+func paragraphNodes(t *testing.T, adf []byte) []markedText {
+	t.Helper()
+	var doc struct {
+		Content []struct {
+			Content []markedText `json:"content"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(adf, &doc); err != nil {
+		t.Fatalf("decode adf: %v", err)
+	}
+	if len(doc.Content) != 1 {
+		t.Fatalf("expected one paragraph, got %s", adf)
+	}
+	return doc.Content[0].Content
+}
+
+func marks(types ...string) []map[string]any {
+	out := make([]map[string]any, 0, len(types))
+	for _, markType := range types {
+		if markType == "link" {
+			out = append(out, map[string]any{"type": "link", "attrs": map[string]any{"href": "https://example.com/x_y"}})
+			continue
+		}
+		out = append(out, map[string]any{"type": markType})
+	}
+	return out
+}
+
+func TestMarkCombinationsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodes        []markedText
+		wantMarkdown string
+		// wantNodes defaults to nodes: ADF mark arrays come back in the
+		// canonical order, link first and code last.
+		wantNodes []markedText
+	}{
+		{
+			name:         "code then strong",
+			nodes:        []markedText{{Text: "word", Marks: marks("code", "strong")}},
+			wantMarkdown: "**`word`**\n",
+			wantNodes:    []markedText{{Text: "word", Marks: marks("strong", "code")}},
+		},
+		{
+			name:         "code then link",
+			nodes:        []markedText{{Text: "word", Marks: marks("code", "link")}},
+			wantMarkdown: "[`word`](https://example.com/x_y)\n",
+			wantNodes:    []markedText{{Text: "word", Marks: marks("link", "code")}},
+		},
+		{
+			name:         "strike and code",
+			nodes:        []markedText{{Text: "word", Marks: marks("strike", "code")}},
+			wantMarkdown: "~~`word`~~\n",
+		},
+		{
+			name:         "strong and em",
+			nodes:        []markedText{{Text: "word", Marks: marks("strong", "em")}},
+			wantMarkdown: "**_word_**\n",
+		},
+		{
+			name:         "strike em strong",
+			nodes:        []markedText{{Text: "word", Marks: marks("strike", "em", "strong")}},
+			wantMarkdown: "~~_**word**_~~\n",
+		},
+		{
+			name:         "strong em strike inside link",
+			nodes:        []markedText{{Text: "word", Marks: marks("strong", "em", "strike", "link")}},
+			wantMarkdown: "[**_~~word~~_**](https://example.com/x_y)\n",
+			wantNodes:    []markedText{{Text: "word", Marks: marks("link", "strong", "em", "strike")}},
+		},
+		{
+			name: "adjacent code, strong, strong+em and em",
+			nodes: []markedText{
+				{Text: "code", Marks: marks("code")},
+				{Text: "bold", Marks: marks("strong")},
+				{Text: "both", Marks: marks("strong", "em")},
+				{Text: "em", Marks: marks("em")},
+			},
+			wantMarkdown: "`code`**bold*both***_em_\n",
+		},
+		{
+			name: "em closing inside a word",
+			nodes: []markedText{
+				{Text: "both", Marks: marks("strong", "em")},
+				{Text: "more", Marks: marks("strong")},
+			},
+			wantMarkdown: "***both*more**\n",
+		},
+		{
+			name: "literal underscores stay escaped",
+			nodes: []markedText{
+				{Text: "snake_case ", Marks: marks("strong")},
+				{Text: "x", Marks: marks("strong", "em")},
+			},
+			wantMarkdown: "**snake\\_case _x_**\n",
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forward, err := Forward(ctx, paragraphADF(t, tt.nodes...), ForwardConfig{}, "page.md")
+			if err != nil {
+				t.Fatalf("Forward() error: %v", err)
+			}
+			if forward.Markdown != tt.wantMarkdown {
+				t.Fatalf("Forward() markdown = %q, want %q", forward.Markdown, tt.wantMarkdown)
+			}
+			if normalized := NormalizeMarkdown(forward.Markdown); normalized != forward.Markdown {
+				t.Fatalf("NormalizeMarkdown changed converter output: %q -> %q", forward.Markdown, normalized)
+			}
+
+			reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{}, "page.md")
+			if err != nil {
+				t.Fatalf("Reverse() error: %v", err)
+			}
+			want := tt.wantNodes
+			if want == nil {
+				want = tt.nodes
+			}
+			if got, wantJSON := mustJSON(t, paragraphNodes(t, reverse.ADF)), mustJSON(t, want); got != wantJSON {
+				t.Fatalf("Reverse() nodes = %s, want %s", got, wantJSON)
+			}
+		})
+	}
+}
+
+func TestNormalizeIntrawordEmphasis(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "**bold_both_**", want: "**bold*both***"},
+		{in: "**_both_more**", want: "***both*more**"},
+		{in: "**_both_** and _em_", want: "**_both_** and _em_"},
+		{in: "snake\\_case and `a_b_c` and [x](https://h/a_b_c)", want: "snake\\_case and `a_b_c` and [x](https://h/a_b_c)"},
+		{in: "```\nx_y_z\n```", want: "```\nx_y_z\n```"},
+	}
+	for _, tt := range tests {
+		if got := normalizeIntrawordEmphasis(tt.in); got != tt.want {
+			t.Errorf("normalizeIntrawordEmphasis(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func mustJSON(t *testing.T, value any) string {
+	t.Helper()
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(raw)
+}
+
+func TestIntrawordEmphasisKeepsShortcodesAndSnakeCase(t *testing.T) {
+	adf := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+		`{"type":"emoji","attrs":{"shortName":":white_check_mark:","text":"✅"}},` +
+		`{"type":"text","text":" run snake_case_name "},` +
+		`{"type":"text","text":"bold","marks":[{"type":"strong"}]},` +
+		`{"type":"text","text":"both","marks":[{"type":"strong"},{"type":"em"}]}]}]}`
+
+	ctx := context.Background()
+	forward, err := Forward(ctx, []byte(adf), ForwardConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	if want := ":white_check_mark: run snake\\_case\\_name **bold*both***\n"; forward.Markdown != want {
+		t.Fatalf("Forward() markdown = %q, want %q", forward.Markdown, want)
+	}
+
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	got := string(reverse.ADF)
+	if !strings.Contains(got, `{"type":"emoji","attrs":{"shortName":":white_check_mark:"}}`) {
+		t.Fatalf("Reverse() should bring the emoji back: %s", got)
+	}
+	if !strings.Contains(got, `{"type":"text","text":" run snake_case_name "}`) {
+		t.Fatalf("snake_case should stay plain text: %s", got)
+	}
+	if !strings.Contains(got, `{"type":"text","text":"both","marks":[{"type":"strong"},{"type":"em"}]}`) {
+		t.Fatalf("intraword emphasis should keep strong and em: %s", got)
+	}
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// inlineFileMediaAttr tags mediaInline file nodes before conversion. The
+// media hook input carries the node's attrs but not its type, so this is how
+// inlineFileMediaHook tells an inline file chip from a block media node.
+const inlineFileMediaAttr = "__confInlineFile"
+
+// markInlineFileMedia tags every mediaInline node that is not an image with
+// inlineFileMediaAttr. The ADF is returned unchanged when there is none.
+func markInlineFileMedia(adf []byte) []byte {
+	if !bytes.Contains(adf, []byte(`"mediaInline"`)) {
+		return adf
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(adf))
+	decoder.UseNumber()
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		return adf
+	}
+	if !markInlineFileMediaInNode(root) {
+		return adf
+	}
+
+	marked, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return marked
+}
+
+func markInlineFileMediaInNode(node any) bool {
+	changed := false
+	switch typed := node.(type) {
+	case []any:
+		for _, item := range typed {
+			if markInlineFileMediaInNode(item) {
+				changed = true
+			}
+		}
+	case map[string]any:
+		if nodeType, _ := typed["type"].(string); nodeType == "mediaInline" {
+			attrs, _ := typed["attrs"].(map[string]any)
+			mediaType, _ := attrs["type"].(string)
+			if attrs != nil && !strings.EqualFold(strings.TrimSpace(mediaType), "image") {
+				attrs[inlineFileMediaAttr] = true
+				changed = true
+			}
+		}
+		if content, ok := typed["content"]; ok && markInlineFileMediaInNode(content) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// inlineFileMediaHook wraps hook so nodes tagged by markInlineFileMedia are
+// rendered as files. Confluence often leaves the type off inline chips, and a
+// chip for a .png would otherwise come back as an embedded ![image](...),
+// which push turns into block media. A file renders as an inline
+// [filename](asset) link, and push rebuilds the mediaInline node from it.
+func inlineFileMediaHook(hook adfconv.MediaRenderHook) adfconv.MediaRenderHook {
+	return func(ctx context.Context, in adfconv.MediaRenderInput) (adfconv.MediaRenderOutput, error) {
+		if marked, _ := in.Attrs[inlineFileMediaAttr].(bool); marked {
+			delete(in.Attrs, inlineFileMediaAttr)
+			in.MediaType = "file"
+		}
+		return hook(ctx, in)
+	}
+}
@@ -0,0 +1,172 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// normalizeBulletPattern matches a `*` or `+` bullet item, optionally
+	// behind blockquote markers.
+	normalizeBulletPattern = regexp.MustCompile(`^((?:[ \t]*>)*)([ \t]*)[*+]([ \t]+\S.*)$`)
+	// normalizeListItemPattern matches any bullet or ordered list item.
+	normalizeListItemPattern = regexp.MustCompile(`^(?:[ \t]*>)*[ \t]*(?:[-*+]|\d{1,9}[.)])[ \t]+\S`)
+	// normalizeProtectedInlinePattern matches inline spans whose underscores
+	// are literal: code spans, link destinations, autolinks and bare URLs.
+	normalizeProtectedInlinePattern = regexp.MustCompile("(`+)[^`]*?(?:`+)|\\]\\([^)\\s]*\\)|<[^>\\s]+>|https?://\\S+|\\\\.")
+	// Underscore spans that touch an asterisk delimiter stay as they are:
+	// **_x_** would become the ambiguous ***x***, and _x_ right after
+	// ***bold*** would run into its closing run.
+	normalizeUnderscoreStrongPattern = regexp.MustCompile(`(^|[^\p{L}\p{N}_\\*])__([^\s_*](?:[^_*\n]*?[^\s_*\\])?)__($|[^\p{L}\p{N}_*])`)
+	normalizeUnderscoreEmPattern     = regexp.MustCompile(`(^|[^\p{L}\p{N}_\\*])_([^\s_*](?:[^_*\n]*?[^\s_*\\])?)_($|[^\p{L}\p{N}_*])`)
+)
+
+// NormalizeMarkdown rewrites pulled Markdown into one canonical layout so that
+// semantically identical bodies are byte-identical: `-` bullets, `*`/`**`
+// emphasis, no trailing whitespace except two-space hard breaks, at most two
+// consecutive blank lines and a single trailing newline. Fenced code blocks
+// and adf-extension wrapper bodies are left untouched. The result is stable:
+// normalizing twice is a no-op.
+func NormalizeMarkdown(markdown string) string {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(markdown, " \t\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return ""
+	}
+
+	out := make([]string, 0, len(lines))
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	blankRun := 0
+	previousListItem := false
+	inWrapper := false
+	for i, line := range lines {
+		if inWrapper {
+			inWrapper = strings.TrimSpace(line) != ":::"
+			out = append(out, line)
+			continue
+		}
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			out = append(out, strings.TrimRight(line, " \t"))
+			blankRun = 0
+			previousListItem = false
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+		if isADFExtensionOpener(strings.TrimSpace(line)) {
+			inWrapper = true
+			out = append(out, strings.TrimRight(line, " \t"))
+			blankRun = 0
+			previousListItem = false
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			blankRun++
+			if blankRun <= 2 {
+				out = append(out, "")
+			}
+			continue
+		}
+		blankRun = 0
+
+		nextBlank := i+1 >= len(lines) || strings.TrimSpace(lines[i+1]) == ""
+		line = normalizeTrailingWhitespace(line, nextBlank)
+		line = normalizeBulletMarker(line, previousListItem)
+		line = normalizeEmphasisMarkers(line)
+		previousListItem = normalizeListItemPattern.MatchString(line) && !isThematicBreakLine(line)
+		out = append(out, line)
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+// isADFExtensionOpener reports whether a trimmed line opens an
+// `::: { .adf-extension ... }` wrapper. The wrapper body belongs to an
+// extension handler, such as the raw HTML of an html macro, and is not
+// Markdown.
+func isADFExtensionOpener(trimmed string) bool {
+	return strings.HasPrefix(trimmed, ":::") && strings.Contains(trimmed, "adf-extension")
+}
+
+// normalizeTrailingWhitespace keeps a two-space hard break only where one can
+// take effect, i.e. when the paragraph continues on the next line.
+func normalizeTrailingWhitespace(line string, nextBlank bool) string {
+	trimmed := strings.TrimRight(line, " \t")
+	if !nextBlank && strings.HasSuffix(line, "  ") && !isHardBreakContainerPrefix(trimmed) {
+		return trimmed + "  "
+	}
+	return trimmed
+}
+
+// normalizeBulletMarker rewrites `*` and `+` bullets to `-`. A line indented
+// by four or more columns only counts as a list item when it follows one;
+// otherwise it is an indented code block. Thematic breaks such as `* * *`
+// are left alone.
+func normalizeBulletMarker(line string, previousListItem bool) string {
+	parts := normalizeBulletPattern.FindStringSubmatch(line)
+	if parts == nil {
+		return line
+	}
+	if isThematicBreakLine(line) {
+		return line
+	}
+	if len(strings.ReplaceAll(parts[2], "\t", "    ")) >= 4 && !previousListItem {
+		return line
+	}
+	return parts[1] + parts[2] + "-" + parts[3]
+}
+
+func isThematicBreakLine(line string) bool {
+	return strings.Trim(line, "*+-_ \t>") == ""
+}
+
+// normalizeEmphasisMarkers rewrites `_em_` and `__strong__` to their asterisk
+// forms outside code spans, link destinations and URLs.
+func normalizeEmphasisMarkers(line string) string {
+	if !strings.Contains(line, "_") {
+		return line
+	}
+
+	var out strings.Builder
+	out.Grow(len(line))
+	last := 0
+	for _, loc := range normalizeProtectedInlinePattern.FindAllStringIndex(line, -1) {
+		out.WriteString(rewriteUnderscoreEmphasis(line[last:loc[0]]))
+		out.WriteString(line[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	out.WriteString(rewriteUnderscoreEmphasis(line[last:]))
+	return out.String()
+}
+
+func rewriteUnderscoreEmphasis(text string) string {
+	if !strings.Contains(text, "_") {
+		return text
+	}
+	// Adjacent spans share their boundary character, so repeat until no
+	// match is left.
+	for _, rewrite := range []struct {
+		pattern *regexp.Regexp
+		repl    string
+	}{
+		{normalizeUnderscoreStrongPattern, "$1**$2**$3"},
+		{normalizeUnderscoreEmPattern, "$1*$2*$3"},
+	} {
+		for {
+			next := rewrite.pattern.ReplaceAllString(text, rewrite.repl)
+			if next == text {
+				break
+			}
+			text = next
+		}
+	}
+	return text
+}
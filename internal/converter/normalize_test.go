@@ -0,0 +1,74 @@
+package converter
+
+import "testing"
+
+func TestNormalizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "single trailing newline",
+			in:   "Hello\n\n\n",
+			want: "Hello\n",
+		},
+		{
+			name: "collapses long blank runs",
+			in:   "one\n\n\n\n\ntwo\n",
+			want: "one\n\n\ntwo\n",
+		},
+		{
+			name: "trims trailing whitespace but keeps hard breaks",
+			in:   "line one  \nline two \t\n   \nlast   \n",
+			want: "line one  \nline two\n\nlast\n",
+		},
+		{
+			name: "rewrites bullet markers",
+			in:   "* one\n  + nested\n> * quoted\n",
+			want: "- one\n  - nested\n> - quoted\n",
+		},
+		{
+			name: "keeps thematic breaks and indented code",
+			in:   "* * *\n\n    * not a list\n",
+			want: "* * *\n\n    * not a list\n",
+		},
+		{
+			name: "rewrites underscore emphasis",
+			in:   "This is __bold__ and _tracked_, not snake_case_name.\n",
+			want: "This is **bold** and *tracked*, not snake_case_name.\n",
+		},
+		{
+			name: "keeps underscores next to asterisk delimiters",
+			in:   "**_both_** and **bold*both***_em_\n",
+			want: "**_both_** and **bold*both***_em_\n",
+		},
+		{
+			name: "leaves code, links and urls alone",
+			in:   "`_x_` [a](docs/_x_/y.md) <https://h/_y_> https://h/_z_ \\_w\\_\n",
+			want: "`_x_` [a](docs/_x_/y.md) <https://h/_y_> https://h/_z_ \\_w\\_\n",
+		},
+		{
+			name: "leaves fenced code untouched",
+			in:   "```\n* _x_  \n\n\n\n```\n",
+			want: "```\n* _x_  \n\n\n\n```\n",
+		},
+		{
+			name: "empty",
+			in:   "\n\n",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeMarkdown(tt.in)
+			if got != tt.want {
+				t.Fatalf("NormalizeMarkdown(%q)\n got: %q\nwant: %q", tt.in, got, tt.want)
+			}
+			if again := NormalizeMarkdown(got); again != got {
+				t.Fatalf("NormalizeMarkdown is not idempotent: %q -> %q", got, again)
+			}
+		})
+	}
+}
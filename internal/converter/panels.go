@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// panelCalloutLinePattern matches a `[!TYPE]` or `[!TYPE: title]` callout
+// marker at the start of a blockquote line, with any text after it.
+var panelCalloutLinePattern = regexp.MustCompile(`^([ \t]*>[ \t]?)\[!([A-Za-z]+)((?::[^\]]*)?)\][ \t]*(.*)$`)
+
+// panelCalloutAliases maps GitHub alert types without a Confluence panel
+// of the same name to the closest panel type.
+var panelCalloutAliases = map[string]string{
+	"caution":   "ERROR",
+	"important": "NOTE",
+	"tip":       "SUCCESS",
+}
+
+// splitPanelCalloutLines puts each callout marker in a paragraph of its
+// own before the Markdown converter sees it. The converter rebuilds text
+// that shares the marker's paragraph as plain text, so without the split
+// the first line of a panel would lose its formatting and links. GitHub
+// alert types from panelCalloutAliases are renamed to panel types.
+func splitPanelCalloutLines(markdown string) string {
+	if !strings.Contains(markdown, "[!") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for i, line := range lines {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			out = append(out, line)
+			continue
+		}
+		match := panelCalloutLinePattern.FindStringSubmatch(line)
+		if inFence || match == nil || (i > 0 && isQuotedTextLine(lines[i-1])) {
+			out = append(out, line)
+			continue
+		}
+
+		prefix, panelType, title, rest := match[1], match[2], match[3], strings.TrimSpace(match[4])
+		if alias, ok := panelCalloutAliases[strings.ToLower(panelType)]; ok {
+			panelType = alias
+		}
+		out = append(out, prefix+"[!"+panelType+title+"]")
+		quote := strings.TrimRight(prefix, " \t")
+		switch {
+		case rest != "":
+			out = append(out, quote, prefix+rest)
+		case i+1 < len(lines) && isQuotedTextLine(lines[i+1]):
+			out = append(out, quote)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isQuotedTextLine reports whether line is a blockquote line with text.
+func isQuotedTextLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, ">") && strings.TrimSpace(strings.TrimLeft(trimmed, "> \t")) != ""
+}
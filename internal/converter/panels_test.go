@@ -0,0 +1,49 @@
+package converter
+
+import "testing"
+
+func TestSplitPanelCalloutLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "marker followed by text",
+			in:   "> [!NOTE]\n> **Read** this\n",
+			want: "> [!NOTE]\n>\n> **Read** this\n",
+		},
+		{
+			name: "text on the marker line",
+			in:   "> [!WARNING: Careful] *now*\n",
+			want: "> [!WARNING: Careful]\n>\n> *now*\n",
+		},
+		{
+			name: "github alert alias",
+			in:   "> [!CAUTION]\n> hot\n",
+			want: "> [!ERROR]\n>\n> hot\n",
+		},
+		{
+			name: "marker alone",
+			in:   "> [!INFO]\n>\n> body\n",
+			want: "> [!INFO]\n>\n> body\n",
+		},
+		{
+			name: "marker inside a quote is text",
+			in:   "> quoted\n> [!NOTE] not a panel\n",
+			want: "> quoted\n> [!NOTE] not a panel\n",
+		},
+		{
+			name: "fenced code is untouched",
+			in:   "```\n> [!TIP]\n> x\n```\n",
+			want: "```\n> [!TIP]\n> x\n```\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitPanelCalloutLines(tt.in); got != tt.want {
+				t.Fatalf("splitPanelCalloutLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	referenceDefinitionPattern = regexp.MustCompile(`^ {0,3}\[((?:\\.|[^\]\\])+)\]:[ \t]*(<[^>\n]*>|\S+)(?:[ \t]+("[^"\n]*"|'[^'\n]*'|\([^)\n]*\)))?[ \t]*$`)
+	referenceUsagePattern      = regexp.MustCompile(`(!?)\[((?:\\.|[^\[\]\\\n])*)\](?:\[((?:\\.|[^\[\]\\\n])*)\])?`)
+)
+
+type referenceDefinition struct {
+	destination string
+	title       string
+}
+
+// InlineReferenceLinks rewrites reference-style links and images
+// (`[text][ref]`, `[text][]`, `[ref]` with a `[ref]: url` definition) into
+// inline `[text](url)` form and drops the definitions they used. Push code
+// that scans Markdown for link and image destinations only understands the
+// inline form. Code fences and inline code are left alone, as are references
+// without a matching definition.
+func InlineReferenceLinks(markdown string) string {
+	if !strings.Contains(markdown, "]:") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	definitions := map[string]referenceDefinition{}
+	definitionLines := map[string][]int{}
+	forEachProseLine(lines, func(i int) {
+		match := referenceDefinitionPattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			return
+		}
+		label := referenceLabelKey(match[1])
+		if label == "" || strings.HasPrefix(label, "^") {
+			return
+		}
+		if _, exists := definitions[label]; !exists {
+			definitions[label] = referenceDefinition{destination: match[2], title: match[3]}
+		}
+		definitionLines[label] = append(definitionLines[label], i)
+	})
+	if len(definitions) == 0 {
+		return markdown
+	}
+
+	used := map[string]bool{}
+	isDefinitionLine := map[int]bool{}
+	for _, indexes := range definitionLines {
+		for _, i := range indexes {
+			isDefinitionLine[i] = true
+		}
+	}
+	forEachProseLine(lines, func(i int) {
+		if isDefinitionLine[i] {
+			return
+		}
+		lines[i] = inlineReferenceUsages(lines[i], definitions, used)
+	})
+	if len(used) == 0 {
+		return markdown
+	}
+
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if isDefinitionLine[i] {
+			if match := referenceDefinitionPattern.FindStringSubmatch(line); match != nil && used[referenceLabelKey(match[1])] {
+				continue
+			}
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// forEachProseLine calls visit with the index of every line outside fenced
+// code blocks.
+func forEachProseLine(lines []string, visit func(i int)) {
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for i, line := range lines {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			continue
+		}
+		if !inFence {
+			visit(i)
+		}
+	}
+}
+
+// inlineReferenceUsages rewrites the reference-style links of one line,
+// skipping inline code spans, and records the labels it resolved in used.
+func inlineReferenceUsages(line string, definitions map[string]referenceDefinition, used map[string]bool) string {
+	if !strings.Contains(line, "[") {
+		return line
+	}
+
+	var out strings.Builder
+	for start := 0; start < len(line); {
+		codeStart := strings.IndexByte(line[start:], '`')
+		if codeStart < 0 {
+			out.WriteString(inlineReferenceUsagesInText(line[start:], definitions, used))
+			break
+		}
+		codeStart += start
+		out.WriteString(inlineReferenceUsagesInText(line[start:codeStart], definitions, used))
+
+		run := countRepeatedByte(line, codeStart, '`')
+		closing := strings.Index(line[codeStart+run:], strings.Repeat("`", run))
+		if closing < 0 {
+			out.WriteString(line[codeStart:])
+			break
+		}
+		codeEnd := codeStart + run + closing + run
+		out.WriteString(line[codeStart:codeEnd])
+		start = codeEnd
+	}
+	return out.String()
+}
+
+func inlineReferenceUsagesInText(text string, definitions map[string]referenceDefinition, used map[string]bool) string {
+	matches := referenceUsagePattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if start > 0 && (text[start-1] == '\\' || text[start-1] == ']') {
+			// Escaped, or the label of a reference whose text this scan
+			// cannot parse (e.g. an image inside a link).
+			continue
+		}
+		linkText := text[m[4]:m[5]]
+		full := m[6] >= 0
+		if !full && end < len(text) && strings.ContainsRune("([{:", rune(text[end])) {
+			// Inline link, pandoc span or attribute, or a definition.
+			continue
+		}
+
+		label := linkText
+		if full && m[7] > m[6] {
+			label = text[m[6]:m[7]]
+		}
+		key := referenceLabelKey(label)
+		definition, ok := definitions[key]
+		if !ok {
+			continue
+		}
+
+		out.WriteString(text[last:start])
+		out.WriteString(text[m[2]:m[3]])
+		out.WriteString("[" + linkText + "](" + definition.destination)
+		if definition.title != "" {
+			out.WriteString(" " + definition.title)
+		}
+		out.WriteString(")")
+		last = end
+		used[key] = true
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
+
+// referenceLabelKey normalizes a link label the way CommonMark matches them:
+// case-insensitively with whitespace runs collapsed.
+func referenceLabelKey(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
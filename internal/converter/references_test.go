@@ -0,0 +1,38 @@
+package converter
+
+import "testing"
+
+func TestInlineReferenceLinks(t *testing.T) {
+	cases := map[string]struct {
+		in, want string
+	}{
+		"full, collapsed and shortcut references": {
+			in: "See [the guide][guide], ![Diagram][] and [Setup].\n\n" +
+				"[guide]: Guides/Guide.md \"Guide\"\n" +
+				"[diagram]: <assets/1/my diagram.png>\n" +
+				"[setup]: Setup.md\n",
+			want: "See [the guide](Guides/Guide.md \"Guide\"), ![Diagram](<assets/1/my diagram.png>) and [Setup](Setup.md).\n\n",
+		},
+		"labels match case-insensitively": {
+			in:   "[Docs][API  Ref]\n\n[api ref]: https://example.com/api\n",
+			want: "[Docs](https://example.com/api)\n\n",
+		},
+		"undefined references and inline links are kept": {
+			in:   "[a][missing] [b](b.md) [c]\n\n[other]: other.md\n",
+			want: "[a][missing] [b](b.md) [c]\n\n[other]: other.md\n",
+		},
+		"code is left alone and keeps unused definitions": {
+			in:   "`[x][ref]`\n\n```\n[x][ref]\n```\n\n[ref]: ref.md\n",
+			want: "`[x][ref]`\n\n```\n[x][ref]\n```\n\n[ref]: ref.md\n",
+		},
+		"image inside a reference link keeps the outer definition": {
+			in:   "[![Logo][img]][home]\n\n[img]: logo.png\n[home]: Home.md\n",
+			want: "[![Logo](logo.png)][home]\n\n[home]: Home.md\n",
+		},
+	}
+	for name, tc := range cases {
+		if got := InlineReferenceLinks(tc.in); got != tc.want {
+			t.Errorf("%s:\n got: %q\nwant: %q", name, got, tc.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package converter
 
 import (
 	"context"
+	"regexp"
+	"strings"
 
 	adfconv "github.com/rgonek/jira-adf-converter/converter"
 	mdconv "github.com/rgonek/jira-adf-converter/mdconverter"
@@ -18,6 +20,16 @@ type ReverseConfig struct {
 	LinkHook  mdconv.LinkParseHook
 	MediaHook mdconv.MediaParseHook
 	Strict    bool
+
+	// AddLeadingH1 puts back the H1 removed by ForwardConfig.StripLeadingH1:
+	// the output starts with an H1 holding Title unless it already does.
+	AddLeadingH1 bool
+	Title        string
+
+	// PreviousADF is the page body being replaced, if any. Decisions and
+	// tasks reuse its localIds (and decision states Markdown cannot express)
+	// to avoid churn.
+	PreviousADF []byte
 }
 
 // Reverse converts Markdown to ADF JSON.
@@ -37,28 +49,88 @@ func Reverse(ctx context.Context, markdown []byte, cfg ReverseConfig, sourcePath
 		ColorDetection:         mdconv.ColorDetectPandoc,
 		AlignmentDetection:     mdconv.AlignDetectPandoc,
 		MentionDetection:       mdconv.MentionDetectPandoc,
-		ExpandDetection:        mdconv.ExpandDetectPandoc,
+		ExpandDetection:        mdconv.ExpandDetectAll,
+		CaptionDetection:       mdconv.CaptionDetectPandoc,
 		InlineCardDetection:    mdconv.InlineCardDetectPandoc,
 		MediaInlineDetection:   mdconv.MediaInlineDetectPandoc,
 		LayoutSectionDetection: mdconv.LayoutSectionDetectPandoc,
 		TableGridDetection:     true,
+		LanguageMap:            codeBlockLanguageAliases,
 		ExtensionHandlers: map[string]adfconv.ExtensionHandler{
+			"html":          &HTMLMacroHandler{},
 			"plantumlcloud": &PlantUMLHandler{},
+			"toc":           &TOCHandler{},
 		},
 	})
 	if err != nil {
 		return ReverseResult{}, err
 	}
 
-	res, err := c.ConvertWithContext(ctx, string(markdown), mdconv.ConvertOptions{
+	prepared := splitMixedTaskLists(splitPanelCalloutLines(expandTOCMarkers(joinMediaCaptionLines(InlineReferenceLinks(string(markdown))))))
+	res, err := c.ConvertWithContext(ctx, prepared, mdconv.ConvertOptions{
 		SourcePath: sourcePath,
 	})
 	if err != nil {
 		return ReverseResult{}, err
 	}
 
+	adf, warnings := preserveUnsupportedHTMLBlocks(prepared, res.ADF, res.Warnings)
+	adf, colorWarnings := normalizeColorMarks(adf)
+	adf = orderInlineMarks(adf)
+	adf = unescapeTableCellPipes(adf)
+	if cfg.AddLeadingH1 {
+		adf = addLeadingTitleHeading(adf, cfg.Title)
+	}
+	adf = normalizeIntraPageAnchors(adf)
+	adf = rebuildDecisionLists(adf, cfg.PreviousADF)
+	adf = rebuildTaskLists(adf, cfg.PreviousADF)
+
 	return ReverseResult{
-		ADF:      res.ADF,
-		Warnings: res.Warnings,
+		ADF:      adf,
+		Warnings: append(warnings, colorWarnings...),
 	}, nil
 }
+
+var (
+	mediaOnlyLinePattern    = regexp.MustCompile(`^([ \t>]*)(!\[(?:\\.|[^\]\\\n])*\]\((?:<[^>\n]*>|[^)\n]*)\))[ \t]*$`)
+	mediaCaptionOnlyPattern = regexp.MustCompile(`^([ \t>]*)(\[.*\]\{\.media-caption\})[ \t]*$`)
+)
+
+// joinMediaCaptionLines puts a `[...]{.media-caption}` line back on the line
+// of the image above it, where the converter attaches it to the mediaSingle.
+func joinMediaCaptionLines(markdown string) string {
+	if !strings.Contains(markdown, "{.media-caption}") {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			out = append(out, line)
+			continue
+		}
+		if inFence || i+1 >= len(lines) {
+			out = append(out, line)
+			continue
+		}
+
+		media := mediaOnlyLinePattern.FindStringSubmatch(line)
+		caption := mediaCaptionOnlyPattern.FindStringSubmatch(lines[i+1])
+		if media == nil || caption == nil || media[1] != caption[1] {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, media[1]+media[2]+caption[2])
+		i++
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -70,6 +70,14 @@ func TestReverseWithMediaHook(t *testing.T) {
 	}
 }
 
+func TestJoinMediaCaptionLines(t *testing.T) {
+	markdown := "![Alt](a.png)\n[Caption]{.media-caption}\n\n> ![Quoted](b.png)\n> [Quoted caption]{.media-caption}\n\n```\n![Code](c.png)\n[Kept]{.media-caption}\n```\n"
+	want := "![Alt](a.png)[Caption]{.media-caption}\n\n> ![Quoted](b.png)[Quoted caption]{.media-caption}\n\n```\n![Code](c.png)\n[Kept]{.media-caption}\n```\n"
+	if got := joinMediaCaptionLines(markdown); got != want {
+		t.Fatalf("joinMediaCaptionLines() = %q, want %q", got, want)
+	}
+}
+
 func TestReverseStrict(t *testing.T) {
 	ctx := context.Background()
 	markdown := []byte("[Broken Link](broken.md)\n")
@@ -120,3 +128,107 @@ func TestReverse_PlainISODateTextRemainsText(t *testing.T) {
 		t.Fatalf("expected plain ISO date text to remain visible text, got %s", adfStr)
 	}
 }
+
+func TestReverse_NormalizesColorsAndWarnsOnUnsupportedValues(t *testing.T) {
+	markdown := `[a]{style="color: #f00;"} [b]{style="background-color: Navy;"} [c]{style="color: rgb(1, 2, 3);"}` + "\n"
+
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "colors.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	adf := string(res.ADF)
+	if !strings.Contains(adf, `"color":"#ff0000"`) {
+		t.Fatalf("short hex color was not expanded: %s", adf)
+	}
+	if !strings.Contains(adf, `"color":"#000080"`) {
+		t.Fatalf("named color was not converted to hex: %s", adf)
+	}
+	if strings.Contains(adf, "rgb(") {
+		t.Fatalf("unsupported color should be dropped: %s", adf)
+	}
+	if !strings.Contains(adf, `"text":"c"`) {
+		t.Fatalf("text with dropped color should be kept: %s", adf)
+	}
+
+	if len(res.Warnings) != 1 || res.Warnings[0].Type != WarningUnsupportedColor {
+		t.Fatalf("warnings = %+v, want one %s warning", res.Warnings, WarningUnsupportedColor)
+	}
+	if !strings.Contains(res.Warnings[0].Message, "rgb(1, 2, 3)") {
+		t.Fatalf("warning should name the color, got %q", res.Warnings[0].Message)
+	}
+}
+
+func TestReverse_AddLeadingH1RestoresStrippedTitle(t *testing.T) {
+	ctx := context.Background()
+	adf := []byte(`{"version":1,"type":"doc","content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Release Notes"}]},{"type":"paragraph","content":[{"type":"text","text":"Body"}]}]}`)
+
+	forward, err := Forward(ctx, adf, ForwardConfig{StripLeadingH1: true, Title: "Release Notes"}, "test.md")
+	if err != nil {
+		t.Fatalf("Forward failed: %v", err)
+	}
+	if strings.Contains(forward.Markdown, "# Release Notes") {
+		t.Fatalf("expected leading H1 to be stripped, got %q", forward.Markdown)
+	}
+
+	cfg := ReverseConfig{AddLeadingH1: true, Title: "Release Notes"}
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), cfg, "test.md")
+	if err != nil {
+		t.Fatalf("Reverse failed: %v", err)
+	}
+	back, err := Forward(ctx, reverse.ADF, ForwardConfig{}, "test.md")
+	if err != nil {
+		t.Fatalf("Forward failed: %v", err)
+	}
+	if back.Markdown != "# Release Notes\n\nBody\n" {
+		t.Fatalf("expected title heading to be restored, got %q", back.Markdown)
+	}
+
+	again, err := Reverse(ctx, []byte(back.Markdown), cfg, "test.md")
+	if err != nil {
+		t.Fatalf("Reverse failed: %v", err)
+	}
+	if got := strings.Count(string(again.ADF), `"heading"`); got != 1 {
+		t.Fatalf("a body that already starts with the title heading should not get a second one, found %d headings", got)
+	}
+}
+
+func TestMarkdownH1Title_SkipsTildeFences(t *testing.T) {
+	body := "~~~~\n# not a title\n~~~\nstill code\n~~~~\n# Real Title\n"
+	if got := MarkdownH1Title(body); got != "Real Title" {
+		t.Fatalf("MarkdownH1Title() = %q, want Real Title", got)
+	}
+}
+
+func TestHasLeadingTitleHeading(t *testing.T) {
+	for _, tc := range []struct {
+		adf  string
+		want bool
+	}{
+		{`{"type":"doc","content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Release Notes"}]}]}`, true},
+		{`{"type":"doc","content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Overview"}]}]}`, false},
+		{`{"type":"doc","content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Release Notes","marks":[{"type":"strong"}]}]}]}`, false},
+		{`{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Release Notes"}]}]}`, false},
+		{``, false},
+	} {
+		if got := HasLeadingTitleHeading([]byte(tc.adf), "Release Notes"); got != tc.want {
+			t.Errorf("HasLeadingTitleHeading(%s) = %v, want %v", tc.adf, got, tc.want)
+		}
+	}
+}
+
+func TestReverse_MapsCodeFenceLanguageAliases(t *testing.T) {
+	markdown := []byte("```ts\nlet a = 1\n```\n\n```my-dsl\nx\n```\n")
+
+	res, err := Reverse(context.Background(), markdown, ReverseConfig{Strict: true}, "test.md")
+	if err != nil {
+		t.Fatalf("Reverse failed: %v", err)
+	}
+
+	adf := string(res.ADF)
+	for _, want := range []string{`"language":"typescript"`, `"language":"my-dsl"`} {
+		if !strings.Contains(adf, want) {
+			t.Errorf("ADF missing %s: %s", want, adf)
+		}
+	}
+}
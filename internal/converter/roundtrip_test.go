@@ -2,13 +2,16 @@ package converter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	adfconv "github.com/rgonek/jira-adf-converter/converter"
+	mdconv "github.com/rgonek/jira-adf-converter/mdconverter"
 )
 
 func TestRoundTripGolden(t *testing.T) {
@@ -92,6 +95,43 @@ func TestRoundTrip_PreservesDoubleSpaceHardBreaks(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_PreservesConsecutiveHardBreaks(t *testing.T) {
+	ctx := context.Background()
+	adfJSON := []byte(`{"version":1,"type":"doc","content":[` +
+		`{"type":"paragraph","content":[{"type":"text","text":"Line 1"},{"type":"hardBreak"},{"type":"hardBreak"},{"type":"hardBreak"},{"type":"text","text":"Line 2"},{"type":"hardBreak"},{"type":"text","text":"Line 3"}]},` +
+		`{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"Quote 1"},{"type":"hardBreak"},{"type":"hardBreak"},{"type":"text","text":"Quote 2"}]}]}` +
+		`]}`)
+
+	forward, err := Forward(ctx, adfJSON, ForwardConfig{}, "fixtures/hard-breaks.md")
+	if err != nil {
+		t.Fatalf("forward conversion failed: %v", err)
+	}
+
+	wantMarkdown := "Line 1  \n\\\n\\\nLine 2  \nLine 3\n\n> Quote 1  \n> \\\n> Quote 2\n"
+	if forward.Markdown != wantMarkdown {
+		t.Fatalf("forward markdown mismatch\n--- got ---\n%q\n--- want ---\n%q", forward.Markdown, wantMarkdown)
+	}
+
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{Strict: true}, "fixtures/hard-breaks.md")
+	if err != nil {
+		t.Fatalf("reverse conversion failed: %v", err)
+	}
+	if got := strings.Count(string(reverse.ADF), `"type":"hardBreak"`); got != 6 {
+		t.Fatalf("hardBreak count = %d, want 6; ADF: %s", got, string(reverse.ADF))
+	}
+	if got := strings.Count(string(reverse.ADF), `"type":"paragraph"`); got != 2 {
+		t.Fatalf("paragraph count = %d, want 2; ADF: %s", got, string(reverse.ADF))
+	}
+
+	again, err := Forward(ctx, reverse.ADF, ForwardConfig{}, "fixtures/hard-breaks.md")
+	if err != nil {
+		t.Fatalf("second forward conversion failed: %v", err)
+	}
+	if again.Markdown != forward.Markdown {
+		t.Fatalf("hard-break round-trip not stable\n--- got ---\n%q\n--- want ---\n%q", again.Markdown, forward.Markdown)
+	}
+}
+
 func formatWarningTypes(warnings []adfconv.Warning) string {
 	types := make([]string, 0, len(warnings))
 	for _, warning := range warnings {
@@ -99,3 +139,181 @@ func formatWarningTypes(warnings []adfconv.Warning) string {
 	}
 	return fmt.Sprintf("%v", types)
 }
+
+func TestRoundTrip_PreservesUnhandledMacrosVerbatim(t *testing.T) {
+	ctx := context.Background()
+	cases := map[string]string{
+		"extension":       `{"version":1,"type":"doc","content":[{"type":"extension","attrs":{"extensionKey":"jira","extensionType":"com.atlassian.confluence.macro.core","parameters":{"macroParams":{"key":{"value":"ENG-1"}}}}}]}`,
+		"bodiedExtension": `{"version":1,"type":"doc","content":[{"type":"bodiedExtension","content":[{"type":"paragraph","content":[{"type":"text","text":"inner"}]}],"attrs":{"extensionKey":"details","extensionType":"com.atlassian.confluence.macro.core","parameters":{}}}]}`,
+	}
+
+	for name, adfJSON := range cases {
+		t.Run(name, func(t *testing.T) {
+			forward, err := Forward(ctx, []byte(adfJSON), ForwardConfig{}, "fixtures/macro.md")
+			if err != nil {
+				t.Fatalf("forward conversion failed: %v", err)
+			}
+			reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{Strict: true}, "fixtures/macro.md")
+			if err != nil {
+				t.Fatalf("reverse conversion failed: %v", err)
+			}
+
+			var want, got any
+			if err := json.Unmarshal([]byte(adfJSON), &want); err != nil {
+				t.Fatalf("unmarshal input ADF: %v", err)
+			}
+			if err := json.Unmarshal(reverse.ADF, &got); err != nil {
+				t.Fatalf("unmarshal round-trip ADF: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("macro did not survive round-trip\n--- markdown ---\n%s\n--- got ---\n%s", forward.Markdown, string(reverse.ADF))
+			}
+		})
+	}
+}
+
+func TestRoundTrip_PreservesNestedMixedLists(t *testing.T) {
+	ctx := context.Background()
+	adfJSON := `{"version":1,"type":"doc","content":[{"type":"orderedList","attrs":{"order":3},"content":[` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"level one"}]},` +
+		`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"level two"}]},` +
+		`{"type":"orderedList","attrs":{"order":5},"content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"level three"}]}]}]}]}]}]},` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"sibling"}]}]}]}]}`
+
+	forward, err := Forward(ctx, []byte(adfJSON), ForwardConfig{}, "fixtures/lists.md")
+	if err != nil {
+		t.Fatalf("forward conversion failed: %v", err)
+	}
+	for _, line := range []string{"3. level one", "   - level two", "     5. level three", "4. sibling"} {
+		if !strings.Contains(forward.Markdown, line+"\n") {
+			t.Fatalf("forward markdown missing %q:\n%s", line, forward.Markdown)
+		}
+	}
+
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{Strict: true}, "fixtures/lists.md")
+	if err != nil {
+		t.Fatalf("reverse conversion failed: %v", err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal([]byte(adfJSON), &want); err != nil {
+		t.Fatalf("unmarshal input ADF: %v", err)
+	}
+	if err := json.Unmarshal(reverse.ADF, &got); err != nil {
+		t.Fatalf("unmarshal round-trip ADF: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("nested lists did not survive round-trip\n--- markdown ---\n%s\n--- got ---\n%s", forward.Markdown, string(reverse.ADF))
+	}
+}
+
+func TestRoundTrip_PreservesRulesAndMultiParagraphBlockquotes(t *testing.T) {
+	ctx := context.Background()
+	adfJSON := `{"version":1,"type":"doc","content":[{"type":"rule"},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"lead"}]},{"type":"rule"},` +
+		`{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"second"}]},` +
+		`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"item"}]}]}]},` +
+		`{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1\n\ny := 2"}]}]},` +
+		`{"type":"rule"}]}`
+
+	forward, err := Forward(ctx, []byte(adfJSON), ForwardConfig{}, "fixtures/quotes.md")
+	if err != nil {
+		t.Fatalf("forward conversion failed: %v", err)
+	}
+	if !strings.HasPrefix(forward.Markdown, "---\n\nlead\n") {
+		t.Fatalf("expected leading rule as ---, got:\n%s", forward.Markdown)
+	}
+
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{Strict: true}, "fixtures/quotes.md")
+	if err != nil {
+		t.Fatalf("reverse conversion failed: %v", err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal([]byte(adfJSON), &want); err != nil {
+		t.Fatalf("unmarshal input ADF: %v", err)
+	}
+	if err := json.Unmarshal(reverse.ADF, &got); err != nil {
+		t.Fatalf("unmarshal round-trip ADF: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rules/blockquotes did not survive round-trip\n--- markdown ---\n%s\n--- got ---\n%s", forward.Markdown, string(reverse.ADF))
+	}
+}
+
+func TestRoundTrip_PreservesMediaAltTextAndCaption(t *testing.T) {
+	ctx := context.Background()
+	adfJSON := `{"version":1,"type":"doc","content":[` +
+		`{"type":"mediaSingle","content":[{"type":"media","attrs":{"alt":"Request flow","id":"att-1","type":"image"}},` +
+		`{"type":"caption","content":[{"type":"text","text":"Figure 1: request flow"}]}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"after"}]}]}`
+
+	forwardHook := func(_ context.Context, in adfconv.MediaRenderInput) (adfconv.MediaRenderOutput, error) {
+		return adfconv.MediaRenderOutput{Markdown: fmt.Sprintf("![%s](assets/flow.png)", in.Alt), Handled: true}, nil
+	}
+	forward, err := Forward(ctx, []byte(adfJSON), ForwardConfig{MediaHook: forwardHook}, "fixtures/media.md")
+	if err != nil {
+		t.Fatalf("forward conversion failed: %v", err)
+	}
+	wantMarkdown := "![Request flow](assets/flow.png)\n[Figure 1: request flow]{.media-caption}\n\nafter\n"
+	if forward.Markdown != wantMarkdown {
+		t.Fatalf("forward markdown = %q, want %q", forward.Markdown, wantMarkdown)
+	}
+
+	reverseHook := func(_ context.Context, in mdconv.MediaParseInput) (mdconv.MediaParseOutput, error) {
+		return mdconv.MediaParseOutput{MediaType: "image", ID: "att-1", Alt: in.Alt, Handled: true}, nil
+	}
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{MediaHook: reverseHook, Strict: true}, "fixtures/media.md")
+	if err != nil {
+		t.Fatalf("reverse conversion failed: %v", err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal([]byte(adfJSON), &want); err != nil {
+		t.Fatalf("unmarshal input ADF: %v", err)
+	}
+	if err := json.Unmarshal(reverse.ADF, &got); err != nil {
+		t.Fatalf("unmarshal round-trip ADF: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("media alt/caption did not survive round-trip\n--- markdown ---\n%s\n--- got ---\n%s", forward.Markdown, string(reverse.ADF))
+	}
+}
+
+func TestRoundTrip_PreservesTextAndBackgroundColor(t *testing.T) {
+	ctx := context.Background()
+	adfJSON := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+		`{"type":"text","text":"red","marks":[{"type":"textColor","attrs":{"color":"#ff5630"}}]},` +
+		`{"type":"text","text":" and "},` +
+		`{"type":"text","text":"highlighted","marks":[{"type":"backgroundColor","attrs":{"color":"#fedec8"}}]},` +
+		`{"type":"text","text":" and "},` +
+		`{"type":"text","text":"both","marks":[{"type":"strong"},{"type":"textColor","attrs":{"color":"#0052cc"}},{"type":"backgroundColor","attrs":{"color":"#fedec8"}}]}]}]}`
+
+	forward, err := Forward(ctx, []byte(adfJSON), ForwardConfig{}, "fixtures/color.md")
+	if err != nil {
+		t.Fatalf("forward conversion failed: %v", err)
+	}
+	if !strings.Contains(forward.Markdown, `[red]{style="color: #ff5630;"}`) || !strings.Contains(forward.Markdown, `[highlighted]{style="background-color: #fedec8;"}`) {
+		t.Fatalf("forward markdown lost color spans: %q", forward.Markdown)
+	}
+
+	reverse, err := Reverse(ctx, []byte(forward.Markdown), ReverseConfig{Strict: true}, "fixtures/color.md")
+	if err != nil {
+		t.Fatalf("reverse conversion failed: %v", err)
+	}
+	if len(reverse.Warnings) != 0 {
+		t.Fatalf("unexpected reverse warnings: %+v", reverse.Warnings)
+	}
+
+	var want, got any
+	if err := json.Unmarshal([]byte(adfJSON), &want); err != nil {
+		t.Fatalf("unmarshal input ADF: %v", err)
+	}
+	if err := json.Unmarshal(reverse.ADF, &got); err != nil {
+		t.Fatalf("unmarshal round-trip ADF: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("color marks did not survive round-trip\n--- markdown ---\n%s\n--- got ---\n%s", forward.Markdown, string(reverse.ADF))
+	}
+}
@@ -0,0 +1,373 @@
+package converter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// WarningStorageFallback is reported by ForwardStorage: the page had no ADF
+// body and was converted from its legacy storage (XHTML) representation.
+const WarningStorageFallback adfconv.WarningType = "storage_body_fallback"
+
+// storageNode is a parsed storage-format element or text run.
+type storageNode struct {
+	Name     string
+	Attrs    map[string]string
+	Children []*storageNode
+	Text     string
+	IsText   bool
+}
+
+// ForwardStorage converts a Confluence storage-format (XHTML) body to Markdown
+// on a best-effort basis. It is used for older pages whose ADF body is empty.
+// Common block and inline elements are converted; other elements keep only
+// their text and are listed in the fallback warning so the page can be
+// reviewed by hand.
+func ForwardStorage(storage string) ForwardResult {
+	root, err := parseStorage(storage)
+	if err != nil {
+		// Keep the content even when the XHTML cannot be parsed.
+		return ForwardResult{
+			Markdown: "```html\n" + strings.TrimSpace(storage) + "\n```\n",
+			Warnings: []adfconv.Warning{{
+				Type:     WarningStorageFallback,
+				NodeType: "storage",
+				Message:  fmt.Sprintf("page body was empty in ADF and its storage representation could not be parsed (%v); the raw storage XHTML was kept in an html code block and needs manual cleanup", err),
+			}},
+		}
+	}
+
+	r := storageRenderer{unsupported: map[string]struct{}{}}
+	markdown := r.renderBlocks(root.Children)
+	markdown = strings.TrimSpace(markdown)
+	if markdown != "" {
+		markdown += "\n"
+	}
+
+	message := "page body was empty in ADF and was converted from its storage representation; review it for formatting loss"
+	if len(r.unsupported) > 0 {
+		names := make([]string, 0, len(r.unsupported))
+		for name := range r.unsupported {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		message += fmt.Sprintf(" (only the text of %s was kept)", strings.Join(names, ", "))
+	}
+	return ForwardResult{
+		Markdown: markdown,
+		Warnings: []adfconv.Warning{{Type: WarningStorageFallback, NodeType: "storage", Message: message}},
+	}
+}
+
+func parseStorage(storage string) (*storageNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader("<storage-root>" + storage + "</storage-root>"))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	document := &storageNode{}
+	stack := []*storageNode{document}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch typed := token.(type) {
+		case xml.StartElement:
+			node := &storageNode{Name: storageElementName(typed.Name), Attrs: map[string]string{}}
+			for _, attr := range typed.Attr {
+				node.Attrs[storageElementName(attr.Name)] = attr.Value
+			}
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.Children = append(parent.Children, &storageNode{IsText: true, Text: string(typed)})
+		}
+	}
+	for _, child := range document.Children {
+		if child.Name == "storage-root" {
+			return child, nil
+		}
+	}
+	return document, nil
+}
+
+// storageElementName restores the `ac:` / `ri:` prefixes that encoding/xml
+// splits into a namespace.
+func storageElementName(name xml.Name) string {
+	if name.Space == "" {
+		return strings.ToLower(name.Local)
+	}
+	return strings.ToLower(name.Space + ":" + name.Local)
+}
+
+type storageRenderer struct {
+	unsupported map[string]struct{}
+}
+
+func (r *storageRenderer) renderBlocks(nodes []*storageNode) string {
+	var out strings.Builder
+	var inline strings.Builder
+	flushInline := func() {
+		if text := strings.TrimSpace(inline.String()); text != "" {
+			out.WriteString(text)
+			out.WriteString("\n\n")
+		}
+		inline.Reset()
+	}
+
+	for _, node := range nodes {
+		block, isBlock := r.renderBlock(node)
+		if !isBlock {
+			inline.WriteString(r.renderInline(node))
+			continue
+		}
+		flushInline()
+		if block = strings.TrimSpace(block); block != "" {
+			out.WriteString(block)
+			out.WriteString("\n\n")
+		}
+	}
+	flushInline()
+	return out.String()
+}
+
+func (r *storageRenderer) renderBlock(node *storageNode) (string, bool) {
+	if node.IsText {
+		return "", false
+	}
+	switch node.Name {
+	case "p":
+		return r.renderInlineChildren(node), true
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(node.Name[1] - '0')
+		return strings.Repeat("#", level) + " " + r.renderInlineChildren(node), true
+	case "ul", "ol":
+		return r.renderList(node, 0), true
+	case "blockquote":
+		lines := strings.Split(strings.TrimSpace(r.renderBlocks(node.Children)), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n"), true
+	case "pre":
+		return "```\n" + strings.Trim(storageText(node), "\n") + "\n```", true
+	case "hr":
+		return "---", true
+	case "table":
+		return r.renderTable(node), true
+	case "div", "section", "tbody", "ac:layout", "ac:layout-section", "ac:layout-cell", "ac:rich-text-body":
+		return r.renderBlocks(node.Children), true
+	case "ac:structured-macro":
+		return r.renderMacro(node), true
+	}
+	return "", false
+}
+
+func (r *storageRenderer) renderMacro(node *storageNode) string {
+	macroName := node.Attrs["ac:name"]
+	if macroName == "code" || macroName == "noformat" {
+		language := ""
+		body := ""
+		for _, child := range node.Children {
+			switch {
+			case child.Name == "ac:parameter" && child.Attrs["ac:name"] == "language":
+				language = strings.TrimSpace(storageText(child))
+			case child.Name == "ac:plain-text-body":
+				body = storageText(child)
+			}
+		}
+		return "```" + language + "\n" + strings.Trim(body, "\n") + "\n```"
+	}
+
+	r.unsupported["macro "+macroName] = struct{}{}
+	for _, child := range node.Children {
+		if child.Name == "ac:rich-text-body" {
+			return r.renderBlocks(child.Children)
+		}
+	}
+	return ""
+}
+
+func (r *storageRenderer) renderList(node *storageNode, depth int) string {
+	lines := make([]string, 0, len(node.Children))
+	index := 1
+	for _, item := range node.Children {
+		if item.IsText || item.Name != "li" {
+			continue
+		}
+		marker := "-"
+		if node.Name == "ol" {
+			marker = fmt.Sprintf("%d.", index)
+			index++
+		}
+
+		var text strings.Builder
+		nested := make([]string, 0)
+		for _, child := range item.Children {
+			if child.Name == "ul" || child.Name == "ol" {
+				nested = append(nested, r.renderList(child, depth+1))
+				continue
+			}
+			if block, isBlock := r.renderBlock(child); isBlock {
+				text.WriteString(" " + strings.TrimSpace(block))
+				continue
+			}
+			text.WriteString(r.renderInline(child))
+		}
+		lines = append(lines, strings.Repeat("  ", depth)+marker+" "+collapseStorageSpace(text.String()))
+		lines = append(lines, nested...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *storageRenderer) renderTable(node *storageNode) string {
+	rows := make([][]string, 0)
+	var collect func(nodes []*storageNode)
+	collect = func(nodes []*storageNode) {
+		for _, child := range nodes {
+			switch child.Name {
+			case "tr":
+				cells := make([]string, 0)
+				for _, cell := range child.Children {
+					if cell.Name == "th" || cell.Name == "td" {
+						text := collapseStorageSpace(strings.ReplaceAll(r.renderBlocks(cell.Children), "\n", " "))
+						cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+					}
+				}
+				rows = append(rows, cells)
+			case "thead", "tbody", "tfoot":
+				collect(child.Children)
+			}
+		}
+	}
+	collect(node.Children)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", width))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *storageRenderer) renderInlineChildren(node *storageNode) string {
+	var out strings.Builder
+	for _, child := range node.Children {
+		out.WriteString(r.renderInline(child))
+	}
+	return collapseStorageSpace(out.String())
+}
+
+func (r *storageRenderer) renderInline(node *storageNode) string {
+	if node.IsText {
+		return node.Text
+	}
+	switch node.Name {
+	case "strong", "b":
+		return wrapStorageInline("**", r.renderInlineChildren(node))
+	case "em", "i":
+		return wrapStorageInline("*", r.renderInlineChildren(node))
+	case "s", "del":
+		return wrapStorageInline("~~", r.renderInlineChildren(node))
+	case "code":
+		return wrapStorageInline("`", storageText(node))
+	case "br":
+		return "  \n"
+	case "a":
+		text := r.renderInlineChildren(node)
+		href := strings.TrimSpace(node.Attrs["href"])
+		if href == "" {
+			return text
+		}
+		if text == "" {
+			text = href
+		}
+		return "[" + text + "](" + href + ")"
+	case "ac:link":
+		return r.renderStorageLink(node)
+	case "span", "u", "sub", "sup", "small", "font":
+		return r.renderInlineChildren(node)
+	}
+	r.unsupported[node.Name] = struct{}{}
+	return r.renderInlineChildren(node)
+}
+
+// renderStorageLink keeps the visible text of an `ac:link`, falling back to
+// the linked page title.
+func (r *storageRenderer) renderStorageLink(node *storageNode) string {
+	title := ""
+	text := ""
+	for _, child := range node.Children {
+		switch child.Name {
+		case "ri:page":
+			title = child.Attrs["ri:content-title"]
+		case "ac:plain-text-link-body", "ac:link-body":
+			text = collapseStorageSpace(storageText(child))
+		}
+	}
+	r.unsupported["ac:link"] = struct{}{}
+	return firstNonEmptyString(text, title)
+}
+
+func wrapStorageInline(marker, text string) string {
+	if strings.TrimSpace(text) == "" {
+		return text
+	}
+	return marker + text + marker
+}
+
+func storageText(node *storageNode) string {
+	if node.IsText {
+		return node.Text
+	}
+	var out strings.Builder
+	for _, child := range node.Children {
+		out.WriteString(storageText(child))
+	}
+	return out.String()
+}
+
+// collapseStorageSpace folds XHTML whitespace runs into single spaces while
+// keeping Markdown hard breaks.
+func collapseStorageSpace(text string) string {
+	parts := strings.Split(text, "  \n")
+	for i, part := range parts {
+		parts[i] = strings.Join(strings.Fields(part), " ")
+	}
+	return strings.TrimSpace(strings.Join(parts, "  \n"))
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestForwardStorage_ConvertsCommonElements(t *testing.T) {
+	storage := `<h1>Overview</h1>
+<p>Hello <strong>bold</strong> and <em>italic</em> with <code>code</code> and a <a href="https://example.com">link</a>.</p>
+<ul><li>one</li><li>two<ol><li>nested</li></ol></li></ul>
+<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[fmt.Println("hi")]]></ac:plain-text-body></ac:structured-macro>
+<table><tbody><tr><th>Key</th><th>Value</th></tr><tr><td>a</td><td>1</td></tr></tbody></table>
+<p>Caf&eacute;&nbsp;ok<br/>next</p>`
+
+	result := ForwardStorage(storage)
+
+	want := "# Overview\n\n" +
+		"Hello **bold** and *italic* with `code` and a [link](https://example.com).\n\n" +
+		"- one\n- two\n  1. nested\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n" +
+		"| Key | Value |\n| --- | --- |\n| a | 1 |\n\n" +
+		"Café ok  \nnext\n"
+	if result.Markdown != want {
+		t.Fatalf("markdown mismatch\n got: %q\nwant: %q", result.Markdown, want)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != WarningStorageFallback {
+		t.Fatalf("warnings = %+v, want one %s warning", result.Warnings, WarningStorageFallback)
+	}
+}
+
+func TestForwardStorage_KeepsTextOfUnsupportedMacros(t *testing.T) {
+	storage := `<ac:structured-macro ac:name="info"><ac:rich-text-body><p>Heads up</p></ac:rich-text-body></ac:structured-macro>`
+
+	result := ForwardStorage(storage)
+
+	if strings.TrimSpace(result.Markdown) != "Heads up" {
+		t.Fatalf("markdown = %q, want macro body text", result.Markdown)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0].Message, "macro info") {
+		t.Fatalf("warnings = %+v, want unsupported macro listed", result.Warnings)
+	}
+}
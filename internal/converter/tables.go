@@ -0,0 +1,306 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// WarningTableLossy is reported by Forward for a table whose cells hold
+// lists, code blocks or several paragraphs. A GFM table cell is a single
+// line, so those cells are flattened into <br>-separated lines and push
+// publishes them back as plain lines.
+const WarningTableLossy adfconv.WarningType = "table_lossy"
+
+// tableCellBreakSentinel stands in for a hard break inside a table cell
+// until the Markdown is rendered, where it becomes <br>.
+const tableCellBreakSentinel = "\uE000"
+
+// tableCellPlaceholderMark delimits the index of a flattened cell in the
+// placeholder text left in its place.
+const tableCellPlaceholderMark = "\uE001"
+
+// tableCellBlockTypes are the cell blocks a single GFM table line cannot
+// hold.
+var tableCellBlockTypes = map[string]struct{}{
+	"bulletList":    {},
+	"orderedList":   {},
+	"taskList":      {},
+	"decisionList":  {},
+	"codeBlock":     {},
+	"table":         {},
+	"blockquote":    {},
+	"panel":         {},
+	"expand":        {},
+	"nestedExpand":  {},
+	"layoutSection": {},
+}
+
+// flattenedTableCell is a cell whose blocks are rendered on their own and
+// spliced back into the table as one <br>-joined line.
+type flattenedTableCell struct {
+	placeholder string
+	doc         map[string]any
+}
+
+// prepareTableCells keeps tables without merged cells renderable as GFM
+// pipe tables. Hard breaks in cells become tableCellBreakSentinel, and a
+// cell that holds more than one block or a block from tableCellBlockTypes
+// is replaced by a placeholder paragraph and returned for separate
+// rendering, with one WarningTableLossy per affected table.
+func prepareTableCells(adf []byte) ([]byte, []flattenedTableCell, []adfconv.Warning) {
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf, nil, nil
+	}
+
+	var cells []flattenedTableCell
+	var warnings []adfconv.Warning
+	changed := false
+	var walk func(nodes []any)
+	walk = func(nodes []any) {
+		for _, rawNode := range nodes {
+			node, ok := rawNode.(map[string]any)
+			if !ok {
+				continue
+			}
+			if nodeType, _ := node["type"].(string); nodeType != "table" || tableHasMergedCells(node) {
+				if children, ok := node["content"].([]any); ok {
+					walk(children)
+				}
+				continue
+			}
+			lossy := 0
+			for _, cell := range tableCells(node) {
+				blocks, _ := cell["content"].([]any)
+				if breaks := replaceHardBreaks(blocks); breaks {
+					changed = true
+				}
+				if !tableCellNeedsFlattening(blocks) {
+					continue
+				}
+				placeholder := tableCellPlaceholderMark + strconv.Itoa(len(cells)) + tableCellPlaceholderMark
+				cells = append(cells, flattenedTableCell{
+					placeholder: placeholder,
+					doc: map[string]any{
+						"type":    "doc",
+						"version": 1,
+						"content": codeBlocksAsCodeLines(blocks),
+					},
+				})
+				cell["content"] = []any{map[string]any{
+					"type":    "paragraph",
+					"content": []any{map[string]any{"type": "text", "text": placeholder}},
+				}}
+				changed = true
+				lossy++
+			}
+			if lossy > 0 {
+				warnings = append(warnings, adfconv.Warning{
+					Type:     WarningTableLossy,
+					NodeType: "table",
+					Message:  fmt.Sprintf("table has %d cell(s) with lists, code blocks or several paragraphs; they are flattened into <br>-separated lines and pushed back as plain lines", lossy),
+				})
+			}
+		}
+	}
+	walk(content)
+	if !changed {
+		return adf, nil, nil
+	}
+
+	rewritten, err := json.Marshal(root)
+	if err != nil {
+		return adf, nil, nil
+	}
+	return rewritten, cells, warnings
+}
+
+// renderTableCells renders each flattened cell with c, joins its lines with
+// <br>, and splices the result into markdown in place of the placeholder.
+// Hard break sentinels are turned into <br> as well.
+func renderTableCells(ctx context.Context, c *adfconv.Converter, markdown string, cells []flattenedTableCell, opts adfconv.ConvertOptions) (string, []adfconv.Warning, error) {
+	var warnings []adfconv.Warning
+	for _, cell := range cells {
+		docJSON, err := json.Marshal(cell.doc)
+		if err != nil {
+			return "", nil, err
+		}
+		res, err := c.ConvertWithContext(ctx, docJSON, opts)
+		if err != nil {
+			return "", nil, err
+		}
+		warnings = append(warnings, res.Warnings...)
+		markdown = strings.Replace(markdown, cell.placeholder, tableCellLine(res.Markdown), 1)
+	}
+	return strings.ReplaceAll(markdown, tableCellBreakSentinel, "<br>"), warnings, nil
+}
+
+// tableCellLine joins the non-blank lines of rendered cell Markdown with
+// <br> and escapes pipes so the result stays inside its column.
+func tableCellLine(markdown string) string {
+	var lines []string
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.ReplaceAll(strings.Join(lines, "<br>"), "|", `\|`)
+}
+
+func tableHasMergedCells(table map[string]any) bool {
+	for _, cell := range tableCells(table) {
+		attrs, _ := cell["attrs"].(map[string]any)
+		for _, key := range []string{"colspan", "rowspan"} {
+			if span, ok := attrs[key].(json.Number); ok && span.String() != "1" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tableCells(table map[string]any) []map[string]any {
+	var cells []map[string]any
+	rows, _ := table["content"].([]any)
+	for _, rawRow := range rows {
+		row, ok := rawRow.(map[string]any)
+		if !ok {
+			continue
+		}
+		rowCells, _ := row["content"].([]any)
+		for _, rawCell := range rowCells {
+			if cell, ok := rawCell.(map[string]any); ok {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+func tableCellNeedsFlattening(blocks []any) bool {
+	if len(blocks) > 1 {
+		return true
+	}
+	for _, rawBlock := range blocks {
+		block, _ := rawBlock.(map[string]any)
+		blockType, _ := block["type"].(string)
+		if _, ok := tableCellBlockTypes[blockType]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceHardBreaks swaps hardBreak nodes in the cell's top-level
+// paragraphs for tableCellBreakSentinel text and reports whether any was
+// found.
+func replaceHardBreaks(blocks []any) bool {
+	found := false
+	for _, rawBlock := range blocks {
+		block, _ := rawBlock.(map[string]any)
+		if blockType, _ := block["type"].(string); blockType != "paragraph" {
+			continue
+		}
+		inline, _ := block["content"].([]any)
+		for i, rawInline := range inline {
+			node, _ := rawInline.(map[string]any)
+			if nodeType, _ := node["type"].(string); nodeType == "hardBreak" {
+				inline[i] = map[string]any{"type": "text", "text": tableCellBreakSentinel}
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// codeBlocksAsCodeLines turns top-level code blocks into one paragraph of
+// inline code per line, since a fenced block cannot live in a table cell.
+func codeBlocksAsCodeLines(blocks []any) []any {
+	out := make([]any, 0, len(blocks))
+	for _, rawBlock := range blocks {
+		block, _ := rawBlock.(map[string]any)
+		if blockType, _ := block["type"].(string); blockType != "codeBlock" {
+			out = append(out, rawBlock)
+			continue
+		}
+		var code strings.Builder
+		children, _ := block["content"].([]any)
+		for _, rawChild := range children {
+			child, _ := rawChild.(map[string]any)
+			text, _ := child["text"].(string)
+			code.WriteString(text)
+		}
+		for _, line := range strings.Split(code.String(), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			out = append(out, map[string]any{
+				"type": "paragraph",
+				"content": []any{map[string]any{
+					"type":  "text",
+					"text":  line,
+					"marks": []any{map[string]any{"type": "code"}},
+				}},
+			})
+		}
+	}
+	return out
+}
+
+// unescapeTableCellPipes drops the backslash the Markdown parser leaves in
+// front of an escaped pipe in table cell text, so `x \| y` is published as
+// "x | y". Code spans already come through unescaped.
+func unescapeTableCellPipes(adf []byte) []byte {
+	if !strings.Contains(string(adf), `\\|`) {
+		return adf
+	}
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf
+	}
+
+	var unescape func(nodes []any, inCell bool)
+	unescape = func(nodes []any, inCell bool) {
+		for _, rawNode := range nodes {
+			node, ok := rawNode.(map[string]any)
+			if !ok {
+				continue
+			}
+			nodeType, _ := node["type"].(string)
+			if nodeType == "text" && inCell && !hasCodeMark(node) {
+				if text, _ := node["text"].(string); strings.Contains(text, `\|`) {
+					node["text"] = strings.ReplaceAll(text, `\|`, "|")
+				}
+				continue
+			}
+			if children, ok := node["content"].([]any); ok {
+				unescape(children, inCell || nodeType == "tableCell" || nodeType == "tableHeader")
+			}
+		}
+	}
+	unescape(content, false)
+
+	rewritten, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return rewritten
+}
+
+func hasCodeMark(node map[string]any) bool {
+	marks, _ := node["marks"].([]any)
+	for _, rawMark := range marks {
+		mark, _ := rawMark.(map[string]any)
+		if markType, _ := mark["type"].(string); markType == "code" {
+			return true
+		}
+	}
+	return false
+}
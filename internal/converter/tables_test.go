@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestForward_FlattensBlockTableCellsIntoBRLines(t *testing.T) {
+	adfJSON := []byte(`{"version":1,"type":"doc","content":[{"type":"table","content":[
+		{"type":"tableRow","content":[
+			{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Step"}]}]},
+			{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Notes"}]}]}
+		]},
+		{"type":"tableRow","content":[
+			{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"Deploy"},{"type":"hardBreak"},{"type":"text","text":"prod"}]}]},
+			{"type":"tableCell","content":[
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"build"}]}]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"ship"}]}]}
+				]},
+				{"type":"codeBlock","attrs":{"language":"sh"},"content":[{"type":"text","text":"make | tee log"}]}
+			]}
+		]}
+	]}]}`)
+
+	res, err := Forward(context.Background(), adfJSON, ForwardConfig{}, "test.md")
+	if err != nil {
+		t.Fatalf("Forward failed: %v", err)
+	}
+
+	want := "| Deploy<br>prod | - build<br>- ship<br>`make \\| tee log` |"
+	if !strings.Contains(res.Markdown, want) {
+		t.Fatalf("markdown missing %q:\n%s", want, res.Markdown)
+	}
+	lossy := 0
+	for _, warning := range res.Warnings {
+		if warning.Type == WarningTableLossy {
+			lossy++
+		}
+	}
+	if lossy != 1 {
+		t.Fatalf("table_lossy warnings = %d, want 1: %+v", lossy, res.Warnings)
+	}
+}
+
+func TestReverse_UnescapesPipesInTableCellText(t *testing.T) {
+	res, err := Reverse(context.Background(), []byte("| A |\n| --- |\n| x \\| y |\n"), ReverseConfig{}, "test.md")
+	if err != nil {
+		t.Fatalf("Reverse failed: %v", err)
+	}
+	adf := string(res.ADF)
+	if !strings.Contains(adf, `"text":"x | y"`) {
+		t.Fatalf("ADF = %s, want unescaped pipe", adf)
+	}
+}
@@ -0,0 +1,162 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// splitMixedTaskLists gives every run of `- [ ]`/`- [x]` items in a list that
+// also holds plain items a different list marker, so each run parses as a
+// list of its own. The converter turns a list into a taskList only when
+// every item is a task; a mixed list would otherwise become a bulletList
+// with its checkboxes dropped.
+func splitMixedTaskLists(markdown string) string {
+	if !strings.Contains(markdown, "[ ]") && !strings.Contains(markdown, "[x]") && !strings.Contains(markdown, "[X]") {
+		return markdown
+	}
+
+	source := []byte(markdown)
+	doc := goldmark.New(goldmark.WithExtensions(extension.TaskList)).Parser().Parse(text.NewReader(source))
+	changed := false
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := node.(*ast.List)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		var markers []int
+		var tasks []bool
+		hasTask, hasPlain := false, false
+		for child := list.FirstChild(); child != nil; child = child.NextSibling() {
+			marker, task := listItemMarker(child, source)
+			if marker < 0 {
+				return ast.WalkContinue, nil
+			}
+			markers = append(markers, marker)
+			tasks = append(tasks, task)
+			hasTask = hasTask || task
+			hasPlain = hasPlain || !task
+		}
+		if !hasTask || !hasPlain {
+			return ast.WalkContinue, nil
+		}
+
+		alternate := alternateListMarker(list.Marker)
+		run := 0
+		for i, marker := range markers {
+			if i > 0 && tasks[i] != tasks[i-1] {
+				run++
+			}
+			if run%2 == 1 {
+				source[marker] = alternate
+				changed = true
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	if !changed {
+		return markdown
+	}
+	return string(source)
+}
+
+// listItemMarker returns the offset of the item's bullet or ordered-list
+// delimiter and whether the item starts with a task checkbox. The offset is
+// -1 when the item has no text to locate the marker from.
+func listItemMarker(item ast.Node, source []byte) (int, bool) {
+	block := item.FirstChild()
+	if block == nil || block.Lines().Len() == 0 {
+		return -1, false
+	}
+	_, task := block.FirstChild().(*extast.TaskCheckBox)
+
+	pos := block.Lines().At(0).Start - 1
+	for pos >= 0 && (source[pos] == ' ' || source[pos] == '\t') {
+		pos--
+	}
+	if pos < 0 || !strings.ContainsRune("-*+.)", rune(source[pos])) {
+		return -1, false
+	}
+	return pos, task
+}
+
+func alternateListMarker(marker byte) byte {
+	switch marker {
+	case '-':
+		return '*'
+	case '.':
+		return ')'
+	case ')':
+		return '.'
+	default:
+		return '-'
+	}
+}
+
+// rebuildTaskLists gives every taskList and taskItem parsed from Markdown the
+// localId ADF requires. localIds are taken from previous, the page's current
+// ADF, the same way rebuildDecisionLists does, so ticking a box or editing
+// another part of the page keeps the task's identity.
+func rebuildTaskLists(adf, previous []byte) []byte {
+	if !bytes.Contains(adf, []byte(`"taskList"`)) {
+		return adf
+	}
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf
+	}
+
+	var previousLists []string
+	var previousItems []*previousLocalNode
+	if _, previousContent, ok := decodeADFDocContent(previous); ok {
+		walkADFNodes(previousContent, func(node map[string]any) {
+			attrs, _ := node["attrs"].(map[string]any)
+			localID, _ := attrs["localId"].(string)
+			switch nodeType, _ := node["type"].(string); nodeType {
+			case "taskList":
+				previousLists = append(previousLists, localID)
+			case "taskItem":
+				previousItems = append(previousItems, &previousLocalNode{localID: localID, text: adfNodeText(node)})
+			}
+		})
+	}
+
+	listIndex, itemIndex := 0, 0
+	walkADFNodes(content, func(node map[string]any) {
+		switch nodeType, _ := node["type"].(string); nodeType {
+		case "taskList":
+			attrs := nodeAttrs(node)
+			if listIndex < len(previousLists) && previousLists[listIndex] != "" {
+				attrs["localId"] = previousLists[listIndex]
+			} else {
+				attrs["localId"] = uuid.NewString()
+			}
+			listIndex++
+		case "taskItem":
+			attrs := nodeAttrs(node)
+			localID := ""
+			if match := matchPreviousLocalNode(previousItems, adfNodeText(node), itemIndex); match != nil {
+				localID = match.localID
+			}
+			if localID == "" {
+				localID = uuid.NewString()
+			}
+			attrs["localId"] = localID
+			itemIndex++
+		}
+	})
+
+	rebuilt, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return rebuilt
+}
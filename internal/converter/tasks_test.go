@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const tasksTestADF = `{"version":1,"type":"doc","content":[` +
+	`{"type":"taskList","attrs":{"localId":"list-1"},"content":[` +
+	`{"type":"taskItem","attrs":{"localId":"item-1","state":"DONE"},"content":[{"type":"text","text":"Book the room"}]},` +
+	`{"type":"taskList","attrs":{"localId":"list-2"},"content":[` +
+	`{"type":"taskItem","attrs":{"localId":"item-2","state":"TODO"},"content":[{"type":"text","text":"Send the invite"}]}` +
+	`]},` +
+	`{"type":"taskItem","attrs":{"localId":"item-3","state":"TODO"},"content":[{"type":"text","text":"Write the agenda"}]}` +
+	`]}]}`
+
+func decodeTestDoc(t *testing.T, adf []byte) decisionTestNode {
+	t.Helper()
+	var doc decisionTestNode
+	if err := json.Unmarshal(adf, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	return doc
+}
+
+func TestForward_RendersTaskListsAsNestedCheckboxes(t *testing.T) {
+	res, err := Forward(context.Background(), []byte(tasksTestADF), ForwardConfig{}, "notes.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	want := "- [x] Book the room\n  - [ ] Send the invite\n- [ ] Write the agenda\n"
+	if !strings.Contains(res.Markdown, want) {
+		t.Fatalf("markdown = %q, want %q", res.Markdown, want)
+	}
+	if len(res.Warnings) != 0 {
+		t.Fatalf("warnings = %+v, want none", res.Warnings)
+	}
+}
+
+func TestReverse_TaskListsKeepStateNestingAndPreviousLocalIDs(t *testing.T) {
+	// Ticking a box must not change the task's identity.
+	markdown := "- [x] Book the room\n  - [x] Send the invite\n- [ ] Write the agenda\n- [ ] Print handouts\n"
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{PreviousADF: []byte(tasksTestADF)}, "notes.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	doc := decodeTestDoc(t, res.ADF)
+	if len(doc.Content) != 1 || doc.Content[0].Type != "taskList" {
+		t.Fatalf("want one taskList: %s", res.ADF)
+	}
+	list := doc.Content[0]
+	if list.Attrs["localId"] != "list-1" {
+		t.Fatalf("taskList localId = %v, want list-1", list.Attrs["localId"])
+	}
+	if len(list.Content) != 4 || list.Content[1].Type != "taskList" {
+		t.Fatalf("want item, nested list, item, item: %s", res.ADF)
+	}
+	nested := list.Content[1]
+	if nested.Attrs["localId"] != "list-2" || nested.Content[0].Attrs["localId"] != "item-2" || nested.Content[0].Attrs["state"] != "DONE" {
+		t.Fatalf("nested task list = %+v, want list-2 with DONE item-2", nested)
+	}
+	for _, want := range []struct {
+		index     int
+		id, state string
+	}{{0, "item-1", "DONE"}, {2, "item-3", "TODO"}} {
+		if item := list.Content[want.index]; item.Attrs["localId"] != want.id || item.Attrs["state"] != want.state {
+			t.Fatalf("item %d attrs = %v, want localId %s state %s", want.index, item.Attrs, want.id, want.state)
+		}
+	}
+	added := list.Content[3]
+	if id, _ := added.Attrs["localId"].(string); id == "" || strings.HasPrefix(id, "item-") {
+		t.Fatalf("new task needs a fresh localId, got %v", added.Attrs["localId"])
+	}
+}
+
+func TestReverse_SplitsMixedListsIntoBulletAndTaskLists(t *testing.T) {
+	markdown := "- Agenda\n- [ ] Book the room\n- [x] Send the invite\n- Notes\n  - [ ] Follow up\n  - Details\n"
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{}, "notes.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+
+	doc := decodeTestDoc(t, res.ADF)
+	var types []string
+	for _, node := range doc.Content {
+		types = append(types, node.Type)
+	}
+	if strings.Join(types, ",") != "bulletList,taskList,bulletList" {
+		t.Fatalf("top-level nodes = %v, want bulletList,taskList,bulletList: %s", types, res.ADF)
+	}
+	tasks := doc.Content[1]
+	if len(tasks.Content) != 2 || tasks.Content[0].Attrs["state"] != "TODO" || tasks.Content[1].Attrs["state"] != "DONE" {
+		t.Fatalf("task list = %+v, want TODO then DONE", tasks)
+	}
+	if !strings.Contains(string(res.ADF), `"text":"Book the room"`) || strings.Contains(string(res.ADF), "[ ]") {
+		t.Fatalf("checkboxes should become task state, not text: %s", res.ADF)
+	}
+
+	// The nested mixed list is split the same way.
+	nested := doc.Content[2].Content[0].Content
+	if len(nested) != 3 || nested[1].Type != "taskList" || nested[2].Type != "bulletList" {
+		t.Fatalf("nested list = %+v, want paragraph, taskList, bulletList", nested)
+	}
+}
@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// stripLeadingTitleHeading removes the first top-level node of the ADF
+// document when it is an H1 whose plain text equals title. The heading is only
+// dropped when it holds unformatted text, so addLeadingTitleHeading can put
+// back exactly what was removed; anything else leaves the ADF untouched.
+func stripLeadingTitleHeading(adf []byte, title string) []byte {
+	title = strings.TrimSpace(title)
+	if title == "" || !bytes.Contains(adf, []byte(`"heading"`)) {
+		return adf
+	}
+
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok || len(content) == 0 || !isPlainTitleHeading(content[0], title) {
+		return adf
+	}
+
+	root["content"] = content[1:]
+	stripped, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return stripped
+}
+
+// MarkdownH1Title returns the text of the first ATX H1 heading in body,
+// ignoring lines inside fenced code blocks.
+func MarkdownH1Title(body string) string {
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	for _, line := range strings.Split(body, "\n") {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			if title := strings.TrimSpace(strings.TrimPrefix(line, "# ")); title != "" {
+				return title
+			}
+		}
+	}
+	return ""
+}
+
+// HasLeadingTitleHeading reports whether the ADF document starts with the
+// unformatted title H1 that ForwardConfig.StripLeadingH1 removes.
+func HasLeadingTitleHeading(adf []byte, title string) bool {
+	title = strings.TrimSpace(title)
+	if title == "" || !bytes.Contains(adf, []byte(`"heading"`)) {
+		return false
+	}
+	_, content, ok := decodeADFDocContent(adf)
+	return ok && len(content) > 0 && isPlainTitleHeading(content[0], title)
+}
+
+// addLeadingTitleHeading prepends an H1 holding title to the ADF document,
+// the reverse of stripLeadingTitleHeading. A document that already starts
+// with that heading is returned unchanged.
+func addLeadingTitleHeading(adf []byte, title string) []byte {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return adf
+	}
+
+	root, content, ok := decodeADFDocContent(adf)
+	if !ok {
+		return adf
+	}
+	if len(content) > 0 && isPlainTitleHeading(content[0], title) {
+		return adf
+	}
+
+	heading := map[string]any{
+		"type":    "heading",
+		"attrs":   map[string]any{"level": 1},
+		"content": []any{map[string]any{"type": "text", "text": title}},
+	}
+	root["content"] = append([]any{heading}, content...)
+	withHeading, err := json.Marshal(root)
+	if err != nil {
+		return adf
+	}
+	return withHeading
+}
+
+func decodeADFDocContent(adf []byte) (map[string]any, []any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(adf))
+	decoder.UseNumber()
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		return nil, nil, false
+	}
+	if docType, _ := root["type"].(string); docType != "doc" {
+		return nil, nil, false
+	}
+	content, _ := root["content"].([]any)
+	return root, content, true
+}
+
+func isPlainTitleHeading(node any, title string) bool {
+	heading, ok := node.(map[string]any)
+	if !ok {
+		return false
+	}
+	if nodeType, _ := heading["type"].(string); nodeType != "heading" {
+		return false
+	}
+	attrs, _ := heading["attrs"].(map[string]any)
+	if level, _ := attrs["level"].(json.Number); level.String() != "1" {
+		return false
+	}
+
+	children, _ := heading["content"].([]any)
+	var text strings.Builder
+	for _, rawChild := range children {
+		child, ok := rawChild.(map[string]any)
+		if !ok {
+			return false
+		}
+		if childType, _ := child["type"].(string); childType != "text" {
+			return false
+		}
+		if marks, _ := child["marks"].([]any); len(marks) > 0 {
+			return false
+		}
+		value, _ := child["text"].(string)
+		text.WriteString(value)
+	}
+	return strings.TrimSpace(text.String()) == title
+}
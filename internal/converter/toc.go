@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"context"
+	"strings"
+
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
+)
+
+// TOCMarker is the Markdown body of a Table of Contents macro. Confluence
+// renders the TOC from the page headings, so only the macro and its
+// parameters are kept, never a static list of headings.
+const TOCMarker = "[[TOC]]"
+
+const tocExtensionKey = "toc"
+
+// TOCHandler implements adfconv.ExtensionHandler for the "toc" macro.
+type TOCHandler struct{}
+
+// ToMarkdown renders the TOC macro as an adf-extension wrapper holding
+// TOCMarker; the macro parameters (maxLevel, style, ...) become wrapper
+// attributes.
+func (h *TOCHandler) ToMarkdown(ctx context.Context, in adfconv.ExtensionRenderInput) (adfconv.ExtensionRenderOutput, error) {
+	if in.Node.Type != "extension" {
+		return adfconv.ExtensionRenderOutput{Handled: false}, nil
+	}
+	if in.Node.GetStringAttr("extensionKey", "") != tocExtensionKey {
+		return adfconv.ExtensionRenderOutput{Handled: false}, nil
+	}
+
+	metadata := map[string]string{}
+	if params, ok := in.Node.Attrs["parameters"].(map[string]interface{}); ok {
+		if macroParams, ok := params["macroParams"].(map[string]interface{}); ok {
+			for name, param := range macroParams {
+				if name == "key" {
+					continue
+				}
+				if value, ok := param.(map[string]interface{})["value"].(string); ok {
+					metadata[name] = value
+				}
+			}
+		}
+	}
+
+	return adfconv.ExtensionRenderOutput{
+		Markdown: TOCMarker + "\n",
+		Metadata: metadata,
+		Handled:  true,
+	}, nil
+}
+
+// FromMarkdown rebuilds the TOC macro from the wrapper attributes.
+func (h *TOCHandler) FromMarkdown(ctx context.Context, in adfconv.ExtensionParseInput) (adfconv.ExtensionParseOutput, error) {
+	if in.ExtensionKey != tocExtensionKey {
+		return adfconv.ExtensionParseOutput{Handled: false}, nil
+	}
+
+	macroParams := map[string]interface{}{}
+	for name, value := range in.Metadata {
+		macroParams[name] = map[string]interface{}{"value": value}
+	}
+
+	return adfconv.ExtensionParseOutput{
+		Node: adfconv.Node{
+			Type: "extension",
+			Attrs: map[string]interface{}{
+				"extensionType": "com.atlassian.confluence.macro.core",
+				"extensionKey":  tocExtensionKey,
+				"parameters": map[string]interface{}{
+					"macroParams": macroParams,
+					"macroMetadata": map[string]interface{}{
+						"schemaVersion": map[string]interface{}{"value": "1"},
+						"title":         "Table of Contents",
+					},
+				},
+			},
+		},
+		Handled: true,
+	}, nil
+}
+
+// expandTOCMarkers wraps every TOCMarker that stands alone on a line outside
+// code fences in an adf-extension block, so authors can add a TOC by writing
+// the marker by itself.
+func expandTOCMarkers(markdown string) string {
+	if !strings.Contains(markdown, TOCMarker) {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	var fenceChar byte
+	fenceLen := 0
+	inWrapper := false
+	for _, line := range lines {
+		if toggled, nextInFence, nextFenceChar, nextFenceLen, _ := maybeToggleMarkdownFence(line, 0, inFence, fenceChar, fenceLen); toggled {
+			inFence = nextInFence
+			fenceChar = nextFenceChar
+			fenceLen = nextFenceLen
+			out = append(out, line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inFence:
+		case isADFExtensionOpener(trimmed):
+			inWrapper = true
+		case inWrapper && trimmed == ":::":
+			inWrapper = false
+		case !inWrapper && line == TOCMarker:
+			out = append(out, `::: { .adf-extension key="`+tocExtensionKey+`" }`, TOCMarker, ":::")
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
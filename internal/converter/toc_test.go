@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestForward_RendersTOCMacroAsMarker(t *testing.T) {
+	adf := []byte(`{"version":1,"type":"doc","content":[
+		{"type":"extension","attrs":{
+			"extensionType":"com.atlassian.confluence.macro.core",
+			"extensionKey":"toc",
+			"parameters":{
+				"macroParams":{"maxLevel":{"value":"2"},"exclude":{"value":"Appendix"}},
+				"macroMetadata":{"macroId":{"value":"abc"},"schemaVersion":{"value":"1"},"title":"Table of Contents"}
+			}
+		}},
+		{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Intro"}]}
+	]}`)
+
+	res, err := Forward(context.Background(), adf, ForwardConfig{}, "page.md")
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	want := "::: { .adf-extension key=\"toc\" exclude=\"Appendix\" maxLevel=\"2\" }\n[[TOC]]\n:::\n"
+	if !strings.HasPrefix(res.Markdown, want) {
+		t.Fatalf("Forward() markdown = %q, want prefix %q", res.Markdown, want)
+	}
+	if strings.Contains(res.Markdown, "- Intro") || strings.Contains(res.Markdown, "adf:extension") {
+		t.Fatalf("expected only the TOC marker, got %q", res.Markdown)
+	}
+}
+
+func TestReverse_RebuildsTOCMacroFromMarker(t *testing.T) {
+	markdown := "[[TOC]]\n\n::: { .adf-extension key=\"toc\" maxLevel=\"2\" }\n[[TOC]]\n:::\n\nSee `[[TOC]]` inline.\n"
+
+	res, err := Reverse(context.Background(), []byte(markdown), ReverseConfig{Strict: true}, "page.md")
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	adf := string(res.ADF)
+	if got := strings.Count(adf, `"extensionKey":"toc"`); got != 2 {
+		t.Fatalf("expected 2 toc macros, got %d in %s", got, adf)
+	}
+	if !strings.Contains(adf, `"macroParams":{"maxLevel":{"value":"2"}}`) {
+		t.Fatalf("expected maxLevel to be kept, got %s", adf)
+	}
+	if !strings.Contains(adf, "See ") {
+		t.Fatalf("expected inline marker text to stay a paragraph, got %s", adf)
+	}
+}
@@ -30,6 +30,7 @@ var (
 		"created_at",
 		"updated_by",
 		"updated_at",
+		"url",
 	}
 )
 
@@ -52,6 +53,22 @@ type Frontmatter struct {
 	CreatedAt string
 	UpdatedBy string
 	UpdatedAt string
+	// URL is the page's Confluence web UI address, written on pull when the
+	// space enables pull.page_url. It is informational and never pushed.
+	URL string
+
+	// ParentID and ParentPath pin the remote parent page on push, overriding
+	// the parent derived from the directory layout. At most one may be set.
+	ParentID   string
+	ParentPath string
+
+	// Restrictions limits who may read or update the page. A nil value
+	// leaves remote restrictions untouched on push; an empty value clears them.
+	Restrictions *Restrictions
+
+	// Skip (`cms_skip: true`) keeps the file tracked but out of sync: push
+	// skips it and pull leaves the local copy untouched.
+	Skip bool
 
 	// Legacy metadata retained in-memory only for transitional behavior.
 	ConfluenceLastModified string `yaml:"-"`
@@ -71,6 +88,14 @@ type frontmatterYAML struct {
 	CreatedAt string   `yaml:"created_at,omitempty"`
 	UpdatedBy string   `yaml:"updated_by,omitempty"`
 	UpdatedAt string   `yaml:"updated_at,omitempty"`
+	URL       string   `yaml:"url,omitempty"`
+
+	ParentID   string `yaml:"parent_id,omitempty"`
+	ParentPath string `yaml:"parent_path,omitempty"`
+
+	Restrictions *Restrictions `yaml:"restrictions,omitempty"`
+
+	Skip bool `yaml:"cms_skip,omitempty"`
 
 	LegacyPageID       string `yaml:"confluence_page_id,omitempty"`
 	LegacySpaceKey     string `yaml:"confluence_space_key,omitempty"`
@@ -86,7 +111,8 @@ func (fm Frontmatter) MarshalYAML() (any, error) {
 	for key, value := range fm.Extra {
 		switch key {
 		case "title", "id", "space", "version", "state", "status", "labels",
-			"created_by", "created_at", "updated_by", "updated_at",
+			"created_by", "created_at", "updated_by", "updated_at", "url",
+			"parent_id", "parent_path", "restrictions", "cms_skip",
 			"author", "last_modified_by", "last_modified_at",
 			"confluence_page_id", "confluence_space_key", "confluence_version",
 			"confluence_last_modified", "confluence_parent_page_id":
@@ -107,7 +133,16 @@ func (fm Frontmatter) MarshalYAML() (any, error) {
 		CreatedAt: fm.CreatedAt,
 		UpdatedBy: fm.UpdatedBy,
 		UpdatedAt: fm.UpdatedAt,
-		Extra:     extra,
+		URL:       fm.URL,
+
+		ParentID:   fm.ParentID,
+		ParentPath: fm.ParentPath,
+
+		Restrictions: fm.Restrictions.normalized(),
+
+		Skip: fm.Skip,
+
+		Extra: extra,
 	}, nil
 }
 
@@ -135,6 +170,11 @@ func (fm *Frontmatter) UnmarshalYAML(value *yaml.Node) error {
 	fm.CreatedAt = strings.TrimSpace(decoded.CreatedAt)
 	fm.UpdatedBy = strings.TrimSpace(decoded.UpdatedBy)
 	fm.UpdatedAt = strings.TrimSpace(decoded.UpdatedAt)
+	fm.URL = strings.TrimSpace(decoded.URL)
+	fm.ParentID = strings.TrimSpace(decoded.ParentID)
+	fm.ParentPath = strings.TrimSpace(decoded.ParentPath)
+	fm.Restrictions = decoded.Restrictions.normalized()
+	fm.Skip = decoded.Skip
 
 	if fm.ID == "" {
 		fm.ID = strings.TrimSpace(decoded.LegacyPageID)
@@ -162,6 +202,11 @@ func (fm *Frontmatter) UnmarshalYAML(value *yaml.Node) error {
 	delete(decoded.Extra, "created_at")
 	delete(decoded.Extra, "updated_by")
 	delete(decoded.Extra, "updated_at")
+	delete(decoded.Extra, "url")
+	delete(decoded.Extra, "parent_id")
+	delete(decoded.Extra, "parent_path")
+	delete(decoded.Extra, "restrictions")
+	delete(decoded.Extra, "cms_skip")
 	delete(decoded.Extra, "author")
 	delete(decoded.Extra, "last_modified_by")
 	delete(decoded.Extra, "last_modified_at")
@@ -193,7 +238,8 @@ type ValidationResult struct {
 }
 
 // NormalizeLabels returns a deterministic, deduplicated label list.
-// Labels are trimmed, lowercased, de-duplicated, and sorted.
+// Labels are trimmed, lowercased, de-duplicated, and sorted. The `global:`
+// prefix is dropped, since a global label is written without one.
 func NormalizeLabels(labels []string) []string {
 	if len(labels) == 0 {
 		return nil
@@ -202,6 +248,9 @@ func NormalizeLabels(labels []string) []string {
 	set := map[string]struct{}{}
 	for _, label := range labels {
 		normalized := strings.TrimSpace(strings.ToLower(label))
+		if name, ok := strings.CutPrefix(normalized, "global:"); ok && name != "" {
+			normalized = name
+		}
 		if normalized == "" {
 			continue
 		}
@@ -391,6 +440,39 @@ func ValidateFrontmatterSchema(fm Frontmatter) ValidationResult {
 		}
 	}
 
+	if fm.Restrictions != nil {
+		for _, operation := range []struct {
+			name     string
+			subjects []string
+		}{{"read", fm.Restrictions.Read}, {"update", fm.Restrictions.Update}} {
+			for _, subject := range operation.subjects {
+				if _, _, ok := ParseRestrictionSubject(subject); !ok {
+					result.Issues = append(result.Issues, ValidationIssue{
+						Field:   "restrictions",
+						Code:    "invalid",
+						Message: fmt.Sprintf("restrictions.%s entry %q is invalid: use user:<account-id> or group:<name>", operation.name, subject),
+					})
+				}
+			}
+		}
+	}
+
+	if _, err := fm.Properties(); err != nil {
+		result.Issues = append(result.Issues, ValidationIssue{
+			Field:   PropertiesKey,
+			Code:    "invalid",
+			Message: err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(fm.ParentID) != "" && strings.TrimSpace(fm.ParentPath) != "" {
+		result.Issues = append(result.Issues, ValidationIssue{
+			Field:   "parent_path",
+			Code:    "conflict",
+			Message: "parent_id and parent_path cannot both be set; choose one parent override",
+		})
+	}
+
 	return result
 }
 
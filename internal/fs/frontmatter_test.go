@@ -114,6 +114,25 @@ func TestParseMarkdownDocument_MissingFrontmatter(t *testing.T) {
 	}
 }
 
+func TestParseMarkdownDocument_BodyStartingWithHorizontalRule(t *testing.T) {
+	body := "---\n\nAfter rule\n\n---\n"
+	raw, err := FormatMarkdownDocument(MarkdownDocument{Frontmatter: Frontmatter{Title: "Rules", ID: "7"}, Body: body})
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() unexpected error: %v", err)
+	}
+
+	got, err := ParseMarkdownDocument(raw)
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() unexpected error: %v", err)
+	}
+	if got.Frontmatter.ID != "7" {
+		t.Fatalf("ID = %q, want 7", got.Frontmatter.ID)
+	}
+	if got.Body != body {
+		t.Fatalf("body = %q, want %q", got.Body, body)
+	}
+}
+
 func TestValidateFrontmatterSchema(t *testing.T) {
 	result := ValidateFrontmatterSchema(Frontmatter{})
 	if !result.IsValid() {
@@ -153,6 +172,15 @@ func TestNormalizeLabels_DedupesAndSorts(t *testing.T) {
 	}
 }
 
+func TestNormalizeLabels_DropsGlobalPrefix(t *testing.T) {
+	got := NormalizeLabels([]string{"global:api", "API", "Global:Docs", "team:ops", "global:"})
+	want := []string{"api", "docs", "global:", "team:ops"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeLabels() = %v, want %v", got, want)
+	}
+}
+
 func TestValidateFrontmatterSchema_InvalidLabels(t *testing.T) {
 	result := ValidateFrontmatterSchema(Frontmatter{
 
@@ -183,6 +211,52 @@ func TestValidateFrontmatterSchema_InvalidLabels(t *testing.T) {
 	}
 }
 
+func TestParseMarkdownDocument_ParentOverrideKeys(t *testing.T) {
+	doc, err := ParseMarkdownDocument([]byte("---\ntitle: Intro\nparent_path: Team/Team.md\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if doc.Frontmatter.ParentPath != "Team/Team.md" {
+		t.Fatalf("ParentPath = %q, want Team/Team.md", doc.Frontmatter.ParentPath)
+	}
+	if _, leaked := doc.Frontmatter.Extra["parent_path"]; leaked {
+		t.Fatal("parent_path must not be kept in Extra")
+	}
+
+	raw, err := FormatMarkdownDocument(MarkdownDocument{Frontmatter: Frontmatter{Title: "Intro", ParentID: "42"}, Body: "body\n"})
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() error: %v", err)
+	}
+	if !strings.Contains(string(raw), "parent_id: \"42\"") {
+		t.Fatalf("expected parent_id in formatted frontmatter, got:\n%s", raw)
+	}
+}
+
+func TestValidateFrontmatterSchema_RejectsBothParentOverrides(t *testing.T) {
+	result := ValidateFrontmatterSchema(Frontmatter{ParentID: "42", ParentPath: "Team/Team.md"})
+	if result.IsValid() {
+		t.Fatal("ValidateFrontmatterSchema() should reject parent_id together with parent_path")
+	}
+	if result.Issues[0].Field != "parent_path" || result.Issues[0].Code != "conflict" {
+		t.Fatalf("unexpected issue: %#v", result.Issues[0])
+	}
+}
+
+func TestValidateFrontmatterSchema_PropertiesMustBeAMapping(t *testing.T) {
+	doc, err := ParseMarkdownDocument([]byte("---\ntitle: Plan\nproperties:\n  dashboard.review:\n    due: \"2026-03-01\"\n    tags: [a, b]\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if result := ValidateFrontmatterSchema(doc.Frontmatter); !result.IsValid() {
+		t.Fatalf("mapping of properties should be valid: %#v", result.Issues)
+	}
+
+	result := ValidateFrontmatterSchema(Frontmatter{Extra: map[string]any{PropertiesKey: "dashboard.review"}})
+	if result.IsValid() || result.Issues[0].Field != PropertiesKey {
+		t.Fatalf("scalar properties should be rejected, got %#v", result.Issues)
+	}
+}
+
 func TestValidateImmutableFrontmatter_State(t *testing.T) {
 	previous := Frontmatter{
 		ID:    "1",
@@ -230,3 +304,98 @@ func TestValidateImmutableFrontmatter(t *testing.T) {
 		t.Fatalf("issues = %d, want 1", len(result.Issues))
 	}
 }
+
+func TestFrontmatter_RestrictionsRoundTrip(t *testing.T) {
+	doc, err := ParseMarkdownDocument([]byte("---\ntitle: Secret\nrestrictions:\n  read: [\"group:legal\", \" user:acct-2 \", group:legal]\n  update: [\"user:acct-1\"]\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if doc.Frontmatter.Restrictions == nil {
+		t.Fatal("Restrictions should be parsed")
+	}
+	if got := strings.Join(doc.Frontmatter.Restrictions.Read, ","); got != "group:legal,user:acct-2" {
+		t.Fatalf("Read = %q, want normalized subjects", got)
+	}
+	if _, leaked := doc.Frontmatter.Extra["restrictions"]; leaked {
+		t.Fatal("restrictions must not be kept in Extra")
+	}
+
+	cleared, err := ParseMarkdownDocument([]byte("---\ntitle: Open\nrestrictions: {}\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if cleared.Frontmatter.Restrictions == nil || !cleared.Frontmatter.Restrictions.IsEmpty() {
+		t.Fatalf("restrictions: {} should parse as empty non-nil restrictions, got %#v", cleared.Frontmatter.Restrictions)
+	}
+	raw, err := FormatMarkdownDocument(cleared)
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() error: %v", err)
+	}
+	if !strings.Contains(string(raw), "restrictions: {}") {
+		t.Fatalf("expected empty restrictions to be kept, got:\n%s", raw)
+	}
+}
+
+func TestValidateFrontmatterSchema_InvalidRestrictionSubject(t *testing.T) {
+	result := ValidateFrontmatterSchema(Frontmatter{
+		Title:        "Secret",
+		Restrictions: &Restrictions{Read: []string{"legal"}},
+	})
+	if result.IsValid() {
+		t.Fatal("ValidateFrontmatterSchema() should reject a subject without user:/group: prefix")
+	}
+	if result.Issues[0].Field != "restrictions" || result.Issues[0].Code != "invalid" {
+		t.Fatalf("unexpected issue: %#v", result.Issues[0])
+	}
+}
+
+func TestFrontmatter_SkipRoundTrip(t *testing.T) {
+	doc, err := ParseMarkdownDocument([]byte("---\ntitle: Draft\nid: \"7\"\nversion: 2\ncms_skip: true\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if !doc.Frontmatter.Skip {
+		t.Fatal("Skip should be parsed from cms_skip")
+	}
+	if _, leaked := doc.Frontmatter.Extra["cms_skip"]; leaked {
+		t.Fatal("cms_skip must not be kept in Extra")
+	}
+
+	raw, err := FormatMarkdownDocument(doc)
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() error: %v", err)
+	}
+	if !strings.Contains(string(raw), "cms_skip: true") {
+		t.Fatalf("expected cms_skip in formatted frontmatter, got:\n%s", raw)
+	}
+
+	doc.Frontmatter.Skip = false
+	raw, err = FormatMarkdownDocument(doc)
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() error: %v", err)
+	}
+	if strings.Contains(string(raw), "cms_skip") {
+		t.Fatalf("cms_skip: false should be omitted, got:\n%s", raw)
+	}
+}
+
+func TestFrontmatter_URLRoundTrip(t *testing.T) {
+	doc, err := ParseMarkdownDocument([]byte("---\ntitle: Plan\nid: \"7\"\nversion: 2\nurl: https://example.atlassian.net/wiki/spaces/ENG/pages/7/Plan\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("ParseMarkdownDocument() error: %v", err)
+	}
+	if doc.Frontmatter.URL != "https://example.atlassian.net/wiki/spaces/ENG/pages/7/Plan" {
+		t.Fatalf("URL = %q", doc.Frontmatter.URL)
+	}
+	if _, leaked := doc.Frontmatter.Extra["url"]; leaked {
+		t.Fatal("url must not be kept in Extra")
+	}
+
+	raw, err := FormatMarkdownDocument(doc)
+	if err != nil {
+		t.Fatalf("FormatMarkdownDocument() error: %v", err)
+	}
+	if strings.Count(string(raw), "url: ") != 1 {
+		t.Fatalf("expected url once in formatted frontmatter, got:\n%s", raw)
+	}
+}
@@ -5,8 +5,38 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
+// FilenameMode selects how page and folder titles become file and directory
+// names. Every mode is deterministic, so re-pulling never renames files.
+type FilenameMode string
+
+const (
+	// FilenameModeConservative keeps letters as written and replaces
+	// path-illegal characters and whitespace runs with "-". It is the default.
+	FilenameModeConservative FilenameMode = "conservative"
+	// FilenameModeTransliterate romanizes accented Latin, Greek and Cyrillic
+	// letters to ASCII, then applies the conservative rules. Scripts without a
+	// transliteration (e.g. CJK) are kept as written.
+	FilenameModeTransliterate FilenameMode = "transliterate"
+	// FilenameModePreserveUnicode keeps the title as written, including single
+	// spaces, and only drops characters that are illegal in paths.
+	FilenameModePreserveUnicode FilenameMode = "preserve-unicode"
+)
+
+// ParseFilenameMode validates a filename mode; empty means conservative.
+func ParseFilenameMode(v string) (FilenameMode, error) {
+	switch mode := FilenameMode(strings.TrimSpace(v)); mode {
+	case "":
+		return FilenameModeConservative, nil
+	case FilenameModeConservative, FilenameModeTransliterate, FilenameModePreserveUnicode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid filename mode %q: must be conservative, transliterate, or preserve-unicode", v)
+	}
+}
+
 var (
 	invalidPathChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
 	separatorRun     = regexp.MustCompile(`[\s-]+`)
@@ -16,6 +46,18 @@ var (
 // SanitizePathSegment converts arbitrary text into a safe single path segment.
 // It truncates the result to 100 characters to stay within path limits.
 func SanitizePathSegment(v string) string {
+	return SanitizePathSegmentWithMode(v, FilenameModeConservative)
+}
+
+// SanitizePathSegmentWithMode is SanitizePathSegment using the given mode.
+func SanitizePathSegmentWithMode(v string, mode FilenameMode) string {
+	switch mode {
+	case FilenameModePreserveUnicode:
+		return sanitizePathSegmentPreservingUnicode(v)
+	case FilenameModeTransliterate:
+		v = transliterate(v)
+	}
+
 	s := strings.TrimSpace(v)
 	s = invalidPathChars.ReplaceAllString(s, "-")
 	s = strings.Trim(s, ". ")
@@ -36,15 +78,81 @@ func SanitizePathSegment(v string) string {
 	return s
 }
 
+func sanitizePathSegmentPreservingUnicode(v string) string {
+	s := invalidPathChars.ReplaceAllString(v, "")
+	s = spaceRun.ReplaceAllString(s, " ")
+	s = strings.Trim(s, ". ")
+
+	if utf8.RuneCountInString(s) > 100 {
+		s = strings.TrimRight(string([]rune(s)[:100]), ". ")
+	}
+
+	if s == "" {
+		s = "untitled"
+	}
+	if isWindowsReservedName(s) {
+		s += "-item"
+	}
+	return s
+}
+
 // SanitizeMarkdownFilename sanitizes a page title and enforces a .md suffix.
 func SanitizeMarkdownFilename(title string) string {
-	name := SanitizePathSegment(title)
+	return SanitizeMarkdownFilenameWithMode(title, FilenameModeConservative)
+}
+
+// SanitizeMarkdownFilenameWithMode is SanitizeMarkdownFilename using the given mode.
+func SanitizeMarkdownFilenameWithMode(title string, mode FilenameMode) string {
+	name := SanitizePathSegmentWithMode(title, mode)
 	if !strings.HasSuffix(strings.ToLower(name), ".md") {
 		name += ".md"
 	}
+	// Keep page files distinguishable from the sidecars next to them.
+	if IsCommentsSidecar(name) {
+		name = name[:len(name)-len(CommentsSidecarSuffix)] + "-comments.md"
+	} else if IsPageSidecar(name) {
+		name = name[:len(name)-len(HistorySidecarSuffix)] + "-history.md"
+	}
 	return name
 }
 
+// CommentsSidecarSuffix ends the name of the read-only file that pull writes
+// next to a page to mirror its Confluence footer comments.
+const CommentsSidecarSuffix = ".comments.md"
+
+// CommentsSidecarPath returns the comments sidecar path for a page's Markdown
+// path: "Page.md" becomes "Page.comments.md".
+func CommentsSidecarPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + CommentsSidecarSuffix
+}
+
+// IsCommentsSidecar reports whether path names a comments sidecar.
+func IsCommentsSidecar(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), CommentsSidecarSuffix)
+}
+
+// HistorySidecarSuffix ends the name of the read-only file that pull writes
+// next to a page to mirror its Confluence version history.
+const HistorySidecarSuffix = ".history.md"
+
+// HistorySidecarPath returns the history sidecar path for a page's Markdown
+// path: "Page.md" becomes "Page.history.md".
+func HistorySidecarPath(markdownPath string) string {
+	return strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + HistorySidecarSuffix
+}
+
+// IsPageSidecar reports whether path names a comments or history sidecar.
+// Sidecars are not pages: push, validate and page indexing skip them.
+func IsPageSidecar(path string) bool {
+	return IsCommentsSidecar(path) || strings.HasSuffix(strings.ToLower(path), HistorySidecarSuffix)
+}
+
+// PageSidecarPaths returns every sidecar path that may exist next to a
+// page's Markdown file.
+func PageSidecarPaths(markdownPath string) []string {
+	return []string{CommentsSidecarPath(markdownPath), HistorySidecarPath(markdownPath)}
+}
+
 // JoinSanitizedPath joins multiple sanitized path segments.
 func JoinSanitizedPath(segments ...string) string {
 	clean := make([]string, 0, len(segments))
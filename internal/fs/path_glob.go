@@ -0,0 +1,52 @@
+package fs
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchRelPathGlob reports whether a slash-separated relative path matches a
+// glob pattern. Segments follow path.Match syntax and a "**" segment matches
+// zero or more whole path segments (e.g. "Guides/**" or "**/index.md").
+func MatchRelPathGlob(pattern, relPath string) bool {
+	pattern = strings.Trim(strings.TrimPrefix(strings.TrimSpace(pattern), "./"), "/")
+	relPath = strings.Trim(strings.ReplaceAll(strings.TrimSpace(relPath), "\\", "/"), "/")
+	if pattern == "" {
+		return false
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+// MatchAnyRelPathGlob reports whether relPath matches at least one pattern.
+func MatchAnyRelPathGlob(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if MatchRelPathGlob(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(rest, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], segments[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern = pattern[1:]
+		segments = segments[1:]
+	}
+	return len(segments) == 0
+}
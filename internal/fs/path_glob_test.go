@@ -0,0 +1,31 @@
+package fs
+
+import "testing"
+
+func TestMatchRelPathGlob(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "Guides/**", path: "Guides/intro.md", want: true},
+		{pattern: "Guides/**", path: "Guides/Deep/nested.md", want: true},
+		{pattern: "Guides/**", path: "Other/intro.md", want: false},
+		{pattern: "**/index.md", path: "index.md", want: true},
+		{pattern: "**/index.md", path: "a/b/index.md", want: true},
+		{pattern: "*.md", path: "root.md", want: true},
+		{pattern: "*.md", path: "Guides/intro.md", want: false},
+		{pattern: "Guides/*.md", path: "Guides/intro.md", want: true},
+		{pattern: "Guides/*.md", path: "Guides/Deep/nested.md", want: false},
+		{pattern: "./Guides/**", path: "Guides/intro.md", want: true},
+		{pattern: "Guides/**", path: `Guides\intro.md`, want: true},
+	}
+
+	for _, tc := range cases {
+		if got := MatchRelPathGlob(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("MatchRelPathGlob(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
@@ -32,3 +32,70 @@ func TestSanitizeMarkdownFilename(t *testing.T) {
 		t.Fatalf("SanitizeMarkdownFilename() should keep .md suffix, got %q", got)
 	}
 }
+
+func TestSanitizePathSegmentWithMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  FilenameMode
+		input string
+		want  string
+	}{
+		{name: "conservative keeps unicode", mode: FilenameModeConservative, input: "Café Überblick", want: "Café-Überblick"},
+		{name: "transliterate accents", mode: FilenameModeTransliterate, input: "Café Überblick", want: "Cafe-Uberblick"},
+		{name: "transliterate special latin", mode: FilenameModeTransliterate, input: "Straße Łódź Æble", want: "Strasse-Lodz-Aeble"},
+		{name: "transliterate cyrillic", mode: FilenameModeTransliterate, input: "Журнал изменений", want: "Zhurnal-izmeneniy"},
+		{name: "transliterate greek", mode: FilenameModeTransliterate, input: "Άλφα", want: "Alfa"},
+		{name: "transliterate keeps cjk", mode: FilenameModeTransliterate, input: "設計 Notes", want: "設計-Notes"},
+		{name: "preserve unicode keeps spaces", mode: FilenameModePreserveUnicode, input: "  Журнал:  изменений? ", want: "Журнал изменений"},
+		{name: "preserve unicode reserved name", mode: FilenameModePreserveUnicode, input: "NUL", want: "NUL-item"},
+		{name: "empty mode is conservative", mode: "", input: "My Page", want: "My-Page"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SanitizePathSegmentWithMode(tc.input, tc.mode)
+			if got != tc.want {
+				t.Fatalf("SanitizePathSegmentWithMode(%q, %q) = %q, want %q", tc.input, tc.mode, got, tc.want)
+			}
+			if again := SanitizePathSegmentWithMode(tc.input, tc.mode); again != got {
+				t.Fatalf("sanitization is not deterministic: %q then %q", got, again)
+			}
+		})
+	}
+}
+
+func TestParseFilenameMode(t *testing.T) {
+	if mode, err := ParseFilenameMode(""); err != nil || mode != FilenameModeConservative {
+		t.Fatalf("ParseFilenameMode(\"\") = %q, %v; want conservative", mode, err)
+	}
+	if mode, err := ParseFilenameMode("preserve-unicode"); err != nil || mode != FilenameModePreserveUnicode {
+		t.Fatalf("ParseFilenameMode(preserve-unicode) = %q, %v", mode, err)
+	}
+	if _, err := ParseFilenameMode("ascii"); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+}
+
+func TestCommentsSidecarPath(t *testing.T) {
+	if got := CommentsSidecarPath("docs/Plan.md"); got != "docs/Plan.comments.md" {
+		t.Fatalf("CommentsSidecarPath() = %q", got)
+	}
+	if !IsCommentsSidecar("docs/Plan.comments.md") || IsCommentsSidecar("docs/Plan.md") {
+		t.Fatal("IsCommentsSidecar() misclassified a path")
+	}
+	if got := SanitizeMarkdownFilename("Release.comments"); got != "Release-comments.md" {
+		t.Fatalf("SanitizeMarkdownFilename() = %q, want a name that is not a comments sidecar", got)
+	}
+}
+
+func TestHistorySidecarPath(t *testing.T) {
+	if got := HistorySidecarPath("docs/Plan.md"); got != "docs/Plan.history.md" {
+		t.Fatalf("HistorySidecarPath() = %q", got)
+	}
+	if !IsPageSidecar("docs/Plan.history.md") || !IsPageSidecar("docs/Plan.comments.md") || IsPageSidecar("docs/Plan.md") {
+		t.Fatal("IsPageSidecar() misclassified a path")
+	}
+	if got := SanitizeMarkdownFilename("Release.history"); got != "Release-history.md" {
+		t.Fatalf("SanitizeMarkdownFilename() = %q, want a name that is not a history sidecar", got)
+	}
+}
@@ -0,0 +1,46 @@
+package fs
+
+import "fmt"
+
+// PropertiesKey is the frontmatter key holding Confluence content
+// properties. It is kept in Frontmatter.Extra so values stay plain YAML
+// (mappings, lists, numbers, strings) rather than stringified JSON.
+const PropertiesKey = "properties"
+
+// Properties returns the `properties` frontmatter key as a map from property
+// key to value. A missing or empty key yields nil; anything other than a
+// mapping is an error.
+func (fm Frontmatter) Properties() (map[string]any, error) {
+	raw, ok := fm.Extra[PropertiesKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	switch properties := raw.(type) {
+	case map[string]any:
+		if len(properties) == 0 {
+			return nil, nil
+		}
+		return properties, nil
+	case map[any]any:
+		converted := make(map[string]any, len(properties))
+		for key, value := range properties {
+			converted[fmt.Sprint(key)] = value
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("properties must be a mapping of property keys to values, got %T", raw)
+	}
+}
+
+// SetProperties replaces the `properties` frontmatter key. An empty map
+// removes it.
+func (fm *Frontmatter) SetProperties(properties map[string]any) {
+	if len(properties) == 0 {
+		delete(fm.Extra, PropertiesKey)
+		return
+	}
+	if fm.Extra == nil {
+		fm.Extra = map[string]any{}
+	}
+	fm.Extra[PropertiesKey] = properties
+}
@@ -0,0 +1,70 @@
+package fs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Restrictions is the `restrictions` frontmatter key. Each entry is a subject
+// of the form `user:<account-id>` or `group:<name>`; an empty list leaves that
+// operation unrestricted.
+type Restrictions struct {
+	Read   []string `yaml:"read,omitempty"`
+	Update []string `yaml:"update,omitempty"`
+}
+
+// IsEmpty reports whether no operation is restricted.
+func (r *Restrictions) IsEmpty() bool {
+	return r == nil || (len(r.Read) == 0 && len(r.Update) == 0)
+}
+
+// normalized trims, de-duplicates and sorts subjects so the key diffs and
+// merges deterministically. A nil receiver stays nil.
+func (r *Restrictions) normalized() *Restrictions {
+	if r == nil {
+		return nil
+	}
+	return &Restrictions{
+		Read:   NormalizeRestrictionSubjects(r.Read),
+		Update: NormalizeRestrictionSubjects(r.Update),
+	}
+}
+
+// NormalizeRestrictionSubjects returns a deterministic, deduplicated subject
+// list. The `user`/`group` prefix is lowercased; the value keeps its case.
+func NormalizeRestrictionSubjects(subjects []string) []string {
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	set := map[string]struct{}{}
+	for _, subject := range subjects {
+		normalized := strings.TrimSpace(subject)
+		if kind, value, ok := ParseRestrictionSubject(normalized); ok {
+			normalized = kind + ":" + value
+		}
+		if normalized == "" {
+			continue
+		}
+		set[normalized] = struct{}{}
+	}
+
+	result := make([]string, 0, len(set))
+	for subject := range set {
+		result = append(result, subject)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ParseRestrictionSubject splits `user:<account-id>` or `group:<name>` into
+// its kind and value.
+func ParseRestrictionSubject(subject string) (kind, value string, ok bool) {
+	kind, value, found := strings.Cut(strings.TrimSpace(subject), ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	value = strings.TrimSpace(value)
+	if !found || value == "" || (kind != "user" && kind != "group") {
+		return "", "", false
+	}
+	return kind, value, true
+}
@@ -15,6 +15,11 @@ const StateFileName = ".confluence-state.json"
 
 var ErrStateConflictMarkers = errors.New("state file contains git conflict markers")
 
+// AssetLayoutFlatten stores each page's attachments in a directory named after
+// the page, next to its Markdown file (Guides/api/ for Guides/api.md). The
+// default, empty layout keeps them under assets/<page-id>/.
+const AssetLayoutFlatten = "flatten"
+
 // SpaceState stores per-space sync metadata used for pull/push planning.
 type SpaceState struct {
 	LastPullHighWatermark string            `json:"last_pull_high_watermark,omitempty"`
@@ -22,6 +27,28 @@ type SpaceState struct {
 	PagePathIndex         map[string]string `json:"page_path_index,omitempty"`
 	AttachmentIndex       map[string]string `json:"attachment_index,omitempty"`
 	FolderPathIndex       map[string]string `json:"folder_path_index,omitempty"`
+	// PageETags maps page IDs to the ETag of their last pulled version so
+	// incremental pulls can skip pages the server reports as not modified.
+	PageETags map[string]string `json:"page_etags,omitempty"`
+	// PullResumeCursor is the page-listing cursor where a page-capped pull stopped.
+	// The next capped pull continues from it; it is cleared once a listing completes.
+	PullResumeCursor string `json:"pull_resume_cursor,omitempty"`
+	// AssetLayout is the attachment layout of the last pull: empty for
+	// assets/<page-id>/ or AssetLayoutFlatten. Push keeps new attachments in
+	// the same layout.
+	AssetLayout string `json:"asset_layout,omitempty"`
+	// AttachmentHashIndex maps the content hash ("sha256:<hex>") of an
+	// uploaded asset to its attachment ID, so push can reuse the attachment
+	// instead of uploading identical bytes to the same page again.
+	AttachmentHashIndex map[string]string `json:"attachment_hash_index,omitempty"`
+	// PagePropertyHashes maps page IDs to the hash of each content property
+	// value last pulled or pushed, so push only writes back properties whose
+	// frontmatter value was changed locally.
+	PagePropertyHashes map[string]map[string]string `json:"page_property_hashes,omitempty"`
+	// PendingPushPaths lists space-relative Markdown paths whose change a
+	// `push --continue-on-error` run failed to publish. That run still moves
+	// the push baseline, so the next push adds these paths back.
+	PendingPushPaths []string `json:"pending_push_paths,omitempty"`
 }
 
 // NewSpaceState returns an initialized empty state object.
@@ -33,16 +60,30 @@ func NewSpaceState() SpaceState {
 	}
 }
 
-// StatePath returns the state file path for a space directory.
+// StatePath returns the state file path for a space directory in the
+// configured StateLocation.
 func StatePath(spaceDir string) string {
+	if CurrentStateLocation() == StateLocationGit {
+		if path, ok := gitStatePath(spaceDir); ok {
+			return path
+		}
+	}
 	return filepath.Join(spaceDir, StateFileName)
 }
 
-// LoadState reads .confluence-state.json from a space directory.
-// Missing state files return an empty initialized state.
+// LoadState reads .confluence-state.json for a space directory.
+// Missing state files return an empty initialized state. With state kept in
+// the git directory, a space that has no state there yet falls back to the
+// file in the space directory, so switching locations keeps existing state.
 func LoadState(spaceDir string) (SpaceState, error) {
 	path := StatePath(spaceDir)
 	raw, err := os.ReadFile(path) //nolint:gosec // state path is derived from workspace spaceDir
+	if os.IsNotExist(err) {
+		if inTree := filepath.Join(spaceDir, StateFileName); inTree != path {
+			path = inTree
+			raw, err = os.ReadFile(path) //nolint:gosec // state path is derived from workspace spaceDir
+		}
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			return NewSpaceState(), nil
@@ -67,7 +108,8 @@ func LoadState(spaceDir string) (SpaceState, error) {
 	return state, nil
 }
 
-// SaveState writes .confluence-state.json for a space directory.
+// SaveState writes .confluence-state.json for a space directory to the
+// configured StateLocation.
 func SaveState(spaceDir string, state SpaceState) error {
 	state.normalize()
 	if err := validateWatermark(state.LastPullHighWatermark); err != nil {
@@ -80,16 +122,19 @@ func SaveState(spaceDir string, state SpaceState) error {
 	}
 	raw = append(raw, '\n')
 
-	if err := os.MkdirAll(spaceDir, 0o755); err != nil { //nolint:gosec // workspace directories intentionally use standard permissions
+	path := StatePath(spaceDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // workspace directories intentionally use standard permissions
 		return err
 	}
-	return os.WriteFile(StatePath(spaceDir), raw, 0o644) //nolint:gosec // state file is expected to be readable for local tooling
+	return os.WriteFile(path, raw, 0o644) //nolint:gosec // state file is expected to be readable for local tooling
 }
 
-// FindAllStateFiles scans root for all .confluence-state.json files.
+// FindAllStateFiles scans root for all .confluence-state.json files, plus
+// the spaces under root whose state is kept in the git directory.
 // It returns a map of space directory -> SpaceState.
 func FindAllStateFiles(root string) (map[string]SpaceState, error) {
 	states := make(map[string]SpaceState)
+	seen := map[string]struct{}{}
 	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -108,17 +153,64 @@ func FindAllStateFiles(root string) (map[string]SpaceState, error) {
 				return err
 			}
 			states[dir] = state
+			if absDir, err := filepath.Abs(dir); err == nil {
+				seen[absDir] = struct{}{}
+			}
 		}
 		return nil
 	})
-	return states, err
+	if err != nil || CurrentStateLocation() != StateLocationGit {
+		return states, err
+	}
+
+	gitDirs, err := findGitStateDirs(root)
+	if err != nil {
+		return states, err
+	}
+	for _, dir := range gitDirs {
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		state, err := LoadState(dir)
+		if err != nil {
+			return states, err
+		}
+		states[dir] = state
+	}
+	return states, nil
 }
 
 func (s *SpaceState) normalize() {
 	s.SpaceKey = strings.TrimSpace(s.SpaceKey)
+	s.PullResumeCursor = strings.TrimSpace(s.PullResumeCursor)
 	s.PagePathIndex = normalizeStatePathMap(s.PagePathIndex)
 	s.AttachmentIndex = normalizeStatePathMap(s.AttachmentIndex)
 	s.FolderPathIndex = normalizeStatePathMap(s.FolderPathIndex)
+	s.AttachmentHashIndex = pruneAttachmentHashIndex(s.AttachmentHashIndex, s.AttachmentIndex)
+}
+
+// pruneAttachmentHashIndex drops hashes of attachments that are no longer
+// tracked, so a deleted attachment is never reused.
+func pruneAttachmentHashIndex(hashes, attachmentIndex map[string]string) map[string]string {
+	if len(hashes) == 0 {
+		return nil
+	}
+	tracked := make(map[string]struct{}, len(attachmentIndex))
+	for _, attachmentID := range attachmentIndex {
+		tracked[attachmentID] = struct{}{}
+	}
+	out := make(map[string]string, len(hashes))
+	for hash, attachmentID := range hashes {
+		hash = strings.TrimSpace(hash)
+		attachmentID = strings.TrimSpace(attachmentID)
+		if _, ok := tracked[attachmentID]; ok && hash != "" {
+			out[hash] = attachmentID
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
 }
 
 func normalizeStatePathMap(in map[string]string) map[string]string {
@@ -0,0 +1,197 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// StateLocation selects where space state files are kept.
+type StateLocation string
+
+const (
+	// StateLocationSpace keeps .confluence-state.json in the space directory.
+	// It is the default.
+	StateLocationSpace StateLocation = "space"
+	// StateLocationGit keeps state under the repository's git directory, in
+	// cms-state/<space-path>/.confluence-state.json, so it never shows up in
+	// the working tree or in commits.
+	StateLocationGit StateLocation = "git"
+)
+
+// gitStateDirName is the directory under the git common dir that holds state
+// files in StateLocationGit mode.
+const gitStateDirName = "cms-state"
+
+// PullProgressDirName is the directory that holds the pages and attachments
+// an interrupted pull already fetched (see PullProgressDir).
+const PullProgressDirName = ".confluence-pull-progress"
+
+var currentStateLocation atomic.Value
+
+// ParseStateLocation validates a state location setting. An empty value
+// selects StateLocationSpace.
+func ParseStateLocation(raw string) (StateLocation, error) {
+	switch location := StateLocation(strings.ToLower(strings.TrimSpace(raw))); location {
+	case "", StateLocationSpace:
+		return StateLocationSpace, nil
+	case StateLocationGit:
+		return StateLocationGit, nil
+	default:
+		return "", fmt.Errorf("unknown state location %q (want %s or %s)", raw, StateLocationSpace, StateLocationGit)
+	}
+}
+
+// SetStateLocation selects where LoadState, SaveState and FindAllStateFiles
+// look for state. It is set once at startup, before any state is read.
+func SetStateLocation(location StateLocation) {
+	currentStateLocation.Store(location)
+}
+
+// CurrentStateLocation returns the location selected by SetStateLocation.
+func CurrentStateLocation() StateLocation {
+	if location, ok := currentStateLocation.Load().(StateLocation); ok && location != "" {
+		return location
+	}
+	return StateLocationSpace
+}
+
+// HasState reports whether a state file exists for spaceDir, in the
+// configured location or in the space directory itself.
+func HasState(spaceDir string) bool {
+	if _, err := os.Stat(StatePath(spaceDir)); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(spaceDir, StateFileName))
+	return err == nil
+}
+
+// gitStatePath returns the StateLocationGit path for spaceDir. ok is false
+// when spaceDir is not inside a git working tree.
+func gitStatePath(spaceDir string) (string, bool) {
+	dir, ok := gitSpaceDir(spaceDir)
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(dir, StateFileName), true
+}
+
+// PullProgressDir returns where pull keeps the resume data of an interrupted
+// run for spaceDir. Inside a git working tree it lives under the git common
+// dir, so the cleanup, stash and commit of a pull never touch it; otherwise
+// it is a hidden directory in spaceDir.
+func PullProgressDir(spaceDir string) string {
+	if dir, ok := gitSpaceDir(spaceDir); ok {
+		return filepath.Join(dir, PullProgressDirName)
+	}
+	return filepath.Join(spaceDir, PullProgressDirName)
+}
+
+// gitSpaceDir returns the directory under the git common dir that mirrors
+// spaceDir. ok is false when spaceDir is not inside a git working tree.
+func gitSpaceDir(spaceDir string) (string, bool) {
+	absDir, err := filepath.Abs(spaceDir)
+	if err != nil {
+		return "", false
+	}
+	worktreeRoot, commonDir, ok := findGitDirs(absDir)
+	if !ok {
+		return "", false
+	}
+	rel, err := filepath.Rel(worktreeRoot, absDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.Join(commonDir, gitStateDirName, rel), true
+}
+
+// findGitDirs walks up from dir to the enclosing working tree and returns its
+// root and the git common dir. Linked worktrees share the common dir of the
+// main repository, so a push worktree sees the same state.
+func findGitDirs(dir string) (worktreeRoot, commonDir string, ok bool) {
+	for current := dir; ; current = filepath.Dir(current) {
+		dotGit := filepath.Join(current, ".git")
+		info, err := os.Stat(dotGit)
+		if err == nil {
+			if info.IsDir() {
+				return current, dotGit, true
+			}
+			gitDir, ok := readGitDirFile(dotGit)
+			if !ok {
+				return "", "", false
+			}
+			return current, resolveGitCommonDir(gitDir), true
+		}
+		if filepath.Dir(current) == current {
+			return "", "", false
+		}
+	}
+}
+
+// readGitDirFile reads the "gitdir: <path>" pointer of a linked worktree.
+func readGitDirFile(path string) (string, bool) {
+	raw, err := os.ReadFile(path) //nolint:gosec // path is the .git file of the enclosing worktree
+	if err != nil {
+		return "", false
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(raw)), "gitdir:")
+	if !found {
+		return "", false
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return filepath.Clean(gitDir), true
+}
+
+func resolveGitCommonDir(gitDir string) string {
+	raw, err := os.ReadFile(filepath.Join(gitDir, "commondir")) //nolint:gosec // fixed file inside the git directory
+	if err != nil {
+		return gitDir
+	}
+	commonDir := strings.TrimSpace(string(raw))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return filepath.Clean(commonDir)
+}
+
+// findGitStateDirs lists the space directories under root whose state is
+// kept in the git directory.
+func findGitStateDirs(root string) ([]string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	worktreeRoot, commonDir, ok := findGitDirs(absRoot)
+	if !ok {
+		return nil, nil
+	}
+	stateRoot := filepath.Join(commonDir, gitStateDirName)
+	if _, err := os.Stat(stateRoot); err != nil {
+		return nil, nil
+	}
+
+	var dirs []string
+	err = filepath.WalkDir(stateRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != StateFileName {
+			return nil
+		}
+		rel, err := filepath.Rel(stateRoot, filepath.Dir(path))
+		if err != nil {
+			return nil
+		}
+		spaceDir := filepath.Join(worktreeRoot, rel)
+		if relToRoot, err := filepath.Rel(absRoot, spaceDir); err == nil && relToRoot != ".." && !strings.HasPrefix(relToRoot, ".."+string(filepath.Separator)) {
+			dirs = append(dirs, spaceDir)
+		}
+		return nil
+	})
+	return dirs, err
+}
@@ -0,0 +1,122 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func useStateLocation(t *testing.T, location StateLocation) {
+	t.Helper()
+	previous := CurrentStateLocation()
+	SetStateLocation(location)
+	t.Cleanup(func() { SetStateLocation(previous) })
+}
+
+func TestParseStateLocation(t *testing.T) {
+	for raw, want := range map[string]StateLocation{"": StateLocationSpace, "space": StateLocationSpace, " Git ": StateLocationGit} {
+		got, err := ParseStateLocation(raw)
+		if err != nil || got != want {
+			t.Fatalf("ParseStateLocation(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	if _, err := ParseStateLocation("home"); err == nil {
+		t.Fatal("expected an error for an unknown location")
+	}
+}
+
+func TestSaveState_GitLocationKeepsStateOutOfWorkingTree(t *testing.T) {
+	useStateLocation(t, StateLocationGit)
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	spaceDir := filepath.Join(repo, "ENG")
+
+	if err := SaveState(spaceDir, SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"Home.md": "1"}}); err != nil {
+		t.Fatalf("SaveState() error: %v", err)
+	}
+	wantPath := filepath.Join(repo, ".git", "cms-state", "ENG", StateFileName)
+	if got := StatePath(spaceDir); got != wantPath {
+		t.Fatalf("StatePath() = %q, want %q", got, wantPath)
+	}
+	if _, err := os.Stat(wantPath); err != nil {
+		t.Fatalf("expected state under the git directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, StateFileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected no state file in the space directory, stat error = %v", err)
+	}
+	if !HasState(spaceDir) {
+		t.Fatal("HasState() = false, want true")
+	}
+
+	state, err := LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("LoadState() error: %v", err)
+	}
+	if state.SpaceKey != "ENG" || state.PagePathIndex["Home.md"] != "1" {
+		t.Fatalf("LoadState() = %+v", state)
+	}
+
+	states, err := FindAllStateFiles(repo)
+	if err != nil {
+		t.Fatalf("FindAllStateFiles() error: %v", err)
+	}
+	if got, ok := states[spaceDir]; !ok || got.SpaceKey != "ENG" {
+		t.Fatalf("FindAllStateFiles() = %+v, want ENG at %s", states, spaceDir)
+	}
+}
+
+func TestLoadState_GitLocationFallsBackToSpaceDirectory(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	spaceDir := filepath.Join(repo, "ENG")
+	if err := SaveState(spaceDir, SpaceState{SpaceKey: "ENG"}); err != nil {
+		t.Fatalf("SaveState() error: %v", err)
+	}
+
+	useStateLocation(t, StateLocationGit)
+	state, err := LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("LoadState() error: %v", err)
+	}
+	if state.SpaceKey != "ENG" {
+		t.Fatalf("SpaceKey = %q, want the in-tree state", state.SpaceKey)
+	}
+}
+
+func TestStatePath_GitLocationSharesStateWithLinkedWorktrees(t *testing.T) {
+	useStateLocation(t, StateLocationGit)
+	root := t.TempDir()
+	commonDir := filepath.Join(root, "repo", ".git")
+	worktreeGitDir := filepath.Join(commonDir, "worktrees", "push")
+	if err := os.MkdirAll(worktreeGitDir, 0o750); err != nil {
+		t.Fatalf("mkdir worktree git dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0o600); err != nil {
+		t.Fatalf("write commondir: %v", err)
+	}
+	worktree := filepath.Join(root, "wt")
+	if err := os.MkdirAll(worktree, 0o750); err != nil {
+		t.Fatalf("mkdir worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+worktreeGitDir+"\n"), 0o600); err != nil {
+		t.Fatalf("write .git file: %v", err)
+	}
+
+	got := StatePath(filepath.Join(worktree, "ENG"))
+	want := filepath.Join(commonDir, "cms-state", "ENG", StateFileName)
+	if got != want {
+		t.Fatalf("StatePath() = %q, want %q", got, want)
+	}
+}
+
+func TestStatePath_GitLocationOutsideRepositoryUsesSpaceDirectory(t *testing.T) {
+	useStateLocation(t, StateLocationGit)
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if got, want := StatePath(spaceDir), filepath.Join(spaceDir, StateFileName); got != want {
+		t.Fatalf("StatePath() = %q, want %q", got, want)
+	}
+}
@@ -115,3 +115,24 @@ func TestLoadState_GitConflictMarkersReturnsTypedError(t *testing.T) {
 		t.Fatalf("IsStateConflictError(%v) = false, want true", err)
 	}
 }
+
+func TestSaveState_PrunesHashesOfUntrackedAttachments(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := SaveState(spaceDir, SpaceState{
+		AttachmentIndex: map[string]string{"assets/1/diagram.png": "att-1"},
+		AttachmentHashIndex: map[string]string{
+			"sha256:aaa": "att-1",
+			"sha256:bbb": "att-deleted",
+		},
+	}); err != nil {
+		t.Fatalf("SaveState() unexpected error: %v", err)
+	}
+
+	got, err := LoadState(spaceDir)
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	if len(got.AttachmentHashIndex) != 1 || got.AttachmentHashIndex["sha256:aaa"] != "att-1" {
+		t.Fatalf("AttachmentHashIndex = %v, want only the tracked attachment", got.AttachmentHashIndex)
+	}
+}
@@ -0,0 +1,67 @@
+package fs
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations maps lowercase letters that do not decompose to an ASCII
+// base letter. Uppercase letters use the same entry, capitalized.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i",
+	// Cyrillic (Russian, Ukrainian, Belarusian)
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// transliterate romanizes v to ASCII where a mapping exists: accents are
+// stripped after canonical decomposition and letters without an ASCII base
+// use the transliterations table. Other characters are kept unchanged.
+func transliterate(v string) string {
+	var out strings.Builder
+	for _, r := range v {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+			continue
+		}
+		if repl, ok := transliterateRune(r); ok {
+			out.WriteString(repl)
+			continue
+		}
+		for _, d := range norm.NFKD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+			if repl, ok := transliterateRune(d); ok {
+				out.WriteString(repl)
+				continue
+			}
+			out.WriteRune(d)
+		}
+	}
+	return out.String()
+}
+
+func transliterateRune(r rune) (string, bool) {
+	repl, ok := transliterations[unicode.ToLower(r)]
+	if !ok {
+		return "", false
+	}
+	if unicode.IsUpper(r) && repl != "" {
+		first, size := utf8.DecodeRuneInString(repl)
+		repl = string(unicode.ToUpper(first)) + repl[size:]
+	}
+	return repl, true
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/yuin/goldmark/text"
 )
 
-// FindOrphanAssets returns asset files in assets/ that are not referenced by
-// any markdown file in the same space directory.
-func FindOrphanAssets(spaceDir string) ([]string, error) {
-	referenced := map[string]struct{}{}
+// FindOrphanAssets returns asset files in assets/, or in a flattened page's
+// asset directory, that are not referenced by any markdown file in the same
+// space directory and are not tracked in the state attachment index for a
+// page that is still in the page index.
+func FindOrphanAssets(spaceDir string, state fs.SpaceState) ([]string, error) {
+	referenced := trackedAttachmentPaths(state)
 
 	err := filepath.WalkDir(spaceDir, func(path string, d os.DirEntry, walkErr error) error {
 		if walkErr != nil {
@@ -49,20 +51,8 @@ func FindOrphanAssets(spaceDir string) ([]string, error) {
 		return nil, err
 	}
 
-	assetsRoot := filepath.Join(spaceDir, "assets")
-	if _, err := os.Stat(assetsRoot); os.IsNotExist(err) {
-		return nil, nil
-	}
-
 	orphans := make([]string, 0)
-	err = filepath.WalkDir(assetsRoot, func(path string, d os.DirEntry, walkErr error) error {
-		if walkErr != nil {
-			return walkErr
-		}
-		if d.IsDir() {
-			return nil
-		}
-
+	addOrphan := func(path string) error {
 		relPath, err := filepath.Rel(spaceDir, path)
 		if err != nil {
 			return err
@@ -74,18 +64,82 @@ func FindOrphanAssets(spaceDir string) ([]string, error) {
 		if _, ok := referenced[relPath]; ok {
 			return nil
 		}
-
 		orphans = append(orphans, relPath)
 		return nil
-	})
-	if err != nil {
-		return nil, err
+	}
+
+	assetsRoot := filepath.Join(spaceDir, "assets")
+	if _, err := os.Stat(assetsRoot); err == nil {
+		err = filepath.WalkDir(assetsRoot, func(path string, d os.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if d.IsDir() {
+				return nil
+			}
+			return addOrphan(path)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A flattened page keeps its attachments directly in the directory named
+	// after it, next to any child pages, so only its non-Markdown files are
+	// candidates.
+	if state.AssetLayout == fs.AssetLayoutFlatten {
+		for _, assetDir := range trackedPageAssetDirs(state) {
+			if strings.HasPrefix(assetDir, "assets/") {
+				continue
+			}
+			entries, err := os.ReadDir(filepath.Join(spaceDir, filepath.FromSlash(assetDir)))
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(strings.ToLower(entry.Name()), ".md") {
+					continue
+				}
+				if err := addOrphan(filepath.Join(spaceDir, filepath.FromSlash(assetDir), entry.Name())); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
 	sort.Strings(orphans)
 	return orphans, nil
 }
 
+// trackedAttachmentPaths returns the attachment index entries that belong to
+// a page still in the page index, under the asset directory of either
+// layout.
+func trackedAttachmentPaths(state fs.SpaceState) map[string]struct{} {
+	assetDirs := trackedPageAssetDirs(state)
+	tracked := map[string]struct{}{}
+	for relPath := range state.AttachmentIndex {
+		if attachmentBelongsToPage(relPath, assetDirs...) {
+			tracked[normalizeRelPath(relPath)] = struct{}{}
+		}
+	}
+	return tracked
+}
+
+// trackedPageAssetDirs returns the asset directories of every page in the
+// page index: assets/<page-id> and, for the flatten layout, the directory
+// named after the page.
+func trackedPageAssetDirs(state fs.SpaceState) []string {
+	dirs := map[string]struct{}{}
+	for relPath, pageID := range state.PagePathIndex {
+		if pageID = strings.TrimSpace(pageID); pageID == "" {
+			continue
+		}
+		dirs[PageAssetDir("", pageID, relPath)] = struct{}{}
+		dirs[PageAssetDir(state.AssetLayout, pageID, relPath)] = struct{}{}
+	}
+	return sortedStringKeys(dirs)
+}
+
 func collectReferencedAssetPathsFromMarkdown(spaceDir, sourcePath, markdown string) ([]string, error) {
 	doc := goldmark.New().Parser().Parse(text.NewReader([]byte(markdown)))
 	paths := map[string]struct{}{}
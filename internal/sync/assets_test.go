@@ -33,7 +33,7 @@ func TestFindOrphanAssets_ReturnsOnlyUnreferencedAssets(t *testing.T) {
 		}
 	}
 
-	orphans, err := FindOrphanAssets(spaceDir)
+	orphans, err := FindOrphanAssets(spaceDir, fs.NewSpaceState())
 	if err != nil {
 		t.Fatalf("FindOrphanAssets() error: %v", err)
 	}
@@ -43,3 +43,74 @@ func TestFindOrphanAssets_ReturnsOnlyUnreferencedAssets(t *testing.T) {
 		t.Fatalf("orphans = %v, want %v", orphans, want)
 	}
 }
+
+func TestFindOrphanAssets_KeepsAttachmentsTrackedForIndexedPages(t *testing.T) {
+	spaceDir := t.TempDir()
+
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1"},
+		Body:        "no asset links\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	tracked := filepath.Join(spaceDir, "assets", "1", "att1-diagram.png")
+	deletedPage := filepath.Join(spaceDir, "assets", "2", "att2-old.png")
+	for _, path := range []string{tracked, deletedPage} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	state := fs.NewSpaceState()
+	state.PagePathIndex["root.md"] = "1"
+	state.AttachmentIndex["assets/1/att1-diagram.png"] = "att1"
+	state.AttachmentIndex["assets/2/att2-old.png"] = "att2"
+
+	orphans, err := FindOrphanAssets(spaceDir, state)
+	if err != nil {
+		t.Fatalf("FindOrphanAssets() error: %v", err)
+	}
+
+	want := []string{"assets/2/att2-old.png"}
+	if !reflect.DeepEqual(orphans, want) {
+		t.Fatalf("orphans = %v, want %v", orphans, want)
+	}
+}
+
+func TestFindOrphanAssets_UnderstandsFlattenLayout(t *testing.T) {
+	spaceDir := t.TempDir()
+
+	for _, relPath := range []string{"Guides/api.md", "Guides/api/child.md"} {
+		if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, filepath.FromSlash(relPath)), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: relPath},
+			Body:        "no asset links\n",
+		}); err != nil {
+			t.Fatalf("write markdown: %v", err)
+		}
+	}
+	for _, relPath := range []string{"Guides/api/diagram.png", "Guides/api/stale.png"} {
+		if err := os.WriteFile(filepath.Join(spaceDir, filepath.FromSlash(relPath)), []byte("x"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+
+	state := fs.NewSpaceState()
+	state.AssetLayout = fs.AssetLayoutFlatten
+	state.PagePathIndex["Guides/api.md"] = "1"
+	state.PagePathIndex["Guides/api/child.md"] = "2"
+	state.AttachmentIndex["Guides/api/diagram.png"] = "att1"
+
+	orphans, err := FindOrphanAssets(spaceDir, state)
+	if err != nil {
+		t.Fatalf("FindOrphanAssets() error: %v", err)
+	}
+
+	want := []string{"Guides/api/stale.png"}
+	if !reflect.DeepEqual(orphans, want) {
+		t.Fatalf("orphans = %v, want %v", orphans, want)
+	}
+}
@@ -8,6 +8,7 @@ const (
 	DiagnosticCategoryBlockingReference     = "blocking_reference"
 	DiagnosticCategoryDegradedContent       = "degraded_content"
 	DiagnosticCategoryPathChange            = "path_change"
+	DiagnosticCategoryAccessRestriction     = "access_restriction"
 )
 
 func NormalizePullDiagnostic(diag PullDiagnostic) PullDiagnostic {
@@ -34,7 +35,7 @@ func NormalizePullDiagnostics(diags []PullDiagnostic) []PullDiagnostic {
 
 func classifyPullDiagnostic(code string) (category string, actionRequired bool) {
 	switch strings.TrimSpace(code) {
-	case "CROSS_SPACE_LINK_PRESERVED":
+	case "CROSS_SPACE_LINK_PRESERVED", "CROSS_SPACE_LINK":
 		return DiagnosticCategoryPreservedExternalLink, false
 	case "unresolved_reference":
 		return DiagnosticCategoryDegradedReference, true
@@ -42,13 +43,17 @@ func classifyPullDiagnostic(code string) (category string, actionRequired bool)
 		return DiagnosticCategoryBlockingReference, true
 	case "PAGE_PATH_MOVED":
 		return DiagnosticCategoryPathChange, false
+	case "RESTRICTED_PAGE":
+		return DiagnosticCategoryAccessRestriction, false
 	case "FOLDER_LOOKUP_UNAVAILABLE",
 		"CONTENT_STATUS_FETCH_FAILED",
 		"LABELS_FETCH_FAILED",
+		"COMMENTS_FETCH_FAILED",
 		"UNKNOWN_MEDIA_ID_LOOKUP_FAILED",
 		"UNKNOWN_MEDIA_ID_RESOLVED",
 		"UNKNOWN_MEDIA_ID_UNRESOLVED",
 		"ATTACHMENT_DOWNLOAD_SKIPPED",
+		"ATTACHMENT_NOT_REFERENCED",
 		"MALFORMED_ADF":
 		return DiagnosticCategoryDegradedContent, false
 	default:
@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	adfconv "github.com/rgonek/jira-adf-converter/converter"
 	mdconv "github.com/rgonek/jira-adf-converter/mdconverter"
@@ -230,7 +232,15 @@ func ExtractPageID(href string) string {
 // NewForwardMediaHook creates a media hook for ADF -> Markdown conversion.
 // It resolves attachment IDs to local asset paths.
 func NewForwardMediaHook(sourcePath string, attachmentPathByID map[string]string) adfconv.MediaRenderHook {
-	pageAssetPaths := buildPageAssetPathIndex(attachmentPathByID)
+	return NewForwardMediaHookWithOwners(sourcePath, attachmentPathByID, nil)
+}
+
+// NewForwardMediaHookWithOwners is NewForwardMediaHook for asset layouts where
+// the owning page cannot be read from an attachment path, such as
+// fs.AssetLayoutFlatten. attachmentPageByID maps attachment IDs to their page
+// IDs; attachments missing from it fall back to the assets/<page-id>/ path.
+func NewForwardMediaHookWithOwners(sourcePath string, attachmentPathByID, attachmentPageByID map[string]string) adfconv.MediaRenderHook {
+	pageAssetPaths := buildPageAssetPathIndex(attachmentPathByID, attachmentPageByID)
 
 	return func(ctx context.Context, in adfconv.MediaRenderInput) (adfconv.MediaRenderOutput, error) {
 		targetPath := ""
@@ -251,7 +261,7 @@ func NewForwardMediaHook(sourcePath string, attachmentPathByID map[string]string
 						label = strings.TrimSpace(in.Alt)
 					}
 					if label == "" {
-						label = strings.TrimSpace(filepath.Base(targetPath))
+						label = strings.TrimSpace(forwardMediaFilename(in, targetPath))
 					}
 					if label == "" {
 						label = "Attachment"
@@ -286,6 +296,17 @@ func NewForwardMediaHook(sourcePath string, attachmentPathByID map[string]string
 	}
 }
 
+// forwardMediaFilename returns the attachment filename of a resolved asset
+// path, without the "<attachment-id>-" prefix pull adds to asset files.
+func forwardMediaFilename(in adfconv.MediaRenderInput, targetPath string) string {
+	for _, attachmentID := range []string{in.Meta.AttachmentID, in.ID} {
+		if filename := attachmentFilenameFromAssetPath(targetPath, attachmentID); filename != filepath.Base(targetPath) {
+			return filename
+		}
+	}
+	return filepath.Base(targetPath)
+}
+
 func resolveForwardMediaType(in adfconv.MediaRenderInput, resolvedPath string) string {
 	mediaType := strings.ToLower(strings.TrimSpace(in.MediaType))
 	if mediaType == "" {
@@ -427,9 +448,14 @@ func mediaPathMatchesFilename(path, filename string) bool {
 	return strings.HasSuffix(base, "-"+filename)
 }
 
-func buildPageAssetPathIndex(attachmentPathByID map[string]string) map[string][]string {
+func buildPageAssetPathIndex(attachmentPathByID, attachmentPageByID map[string]string) map[string][]string {
 	out := map[string][]string{}
-	for _, rawPath := range attachmentPathByID {
+	for attachmentID, rawPath := range attachmentPathByID {
+		if owner := strings.TrimSpace(attachmentPageByID[attachmentID]); owner != "" {
+			pageID := fs.SanitizePathSegment(owner)
+			out[pageID] = append(out[pageID], rawPath)
+			continue
+		}
 		normalized := normalizeRelPath(rawPath)
 		if normalized == "" {
 			continue
@@ -465,6 +491,7 @@ func NewReverseLinkHook(spaceDir string, index PageIndex, domain string) mdconv.
 func NewReverseLinkHookWithGlobalIndex(spaceDir string, index PageIndex, globalIndex GlobalPageIndex, domain string) mdconv.LinkParseHook {
 	globalPathIndex := invertGlobalPageIndex(globalIndex)
 	sourceSpaceKey, _ := loadSpaceKeyForPath(spaceDir)
+	headings := &markdownHeadingCache{byPath: map[string][]string{}}
 
 	return func(ctx context.Context, in mdconv.LinkParseInput) (mdconv.LinkParseOutput, error) {
 		// If absolute URL or non-http scheme, let it pass (Handled=false)
@@ -521,7 +548,7 @@ func NewReverseLinkHookWithGlobalIndex(spaceDir string, index PageIndex, globalI
 			dest = strings.TrimRight(domain, "/") + "/wiki/spaces/" + url.PathEscape(targetSpaceKey) + "/pages/" + pageID
 		}
 		if strings.TrimSpace(anchor) != "" {
-			dest += "#" + anchor
+			dest += "#" + headings.resolveAnchor(destPath, anchor)
 		}
 
 		return mdconv.LinkParseOutput{
@@ -531,6 +558,77 @@ func NewReverseLinkHookWithGlobalIndex(spaceDir string, index PageIndex, globalI
 	}
 }
 
+// CrossSpaceLinkDiagnostic reports a relative link in sourcePath whose target
+// file belongs to a different space than spaceDir. Push still resolves such a
+// link, but publishes it as an absolute link into the other space rather than
+// an intra-space page reference. ok is false for external, anchor-only and
+// same-space links.
+func CrossSpaceLinkDiagnostic(spaceDir, sourcePath, destination string) (PullDiagnostic, bool) {
+	if isExternalDestination(destination) {
+		return PullDiagnostic{}, false
+	}
+	target := strings.TrimSpace(destination)
+	if idx := strings.Index(target, "#"); idx >= 0 {
+		target = strings.TrimSpace(target[:idx])
+	}
+	if target == "" {
+		return PullDiagnostic{}, false
+	}
+	destPath := filepath.Join(filepath.Dir(sourcePath), decodeMarkdownPath(target))
+	if isSubpathOrSame(spaceDir, destPath) {
+		return PullDiagnostic{}, false
+	}
+
+	sourceSpaceKey, _ := loadSpaceKeyForPath(spaceDir)
+	targetSpaceKey, ok := loadSpaceKeyForPath(destPath)
+	if !ok || strings.EqualFold(targetSpaceKey, sourceSpaceKey) {
+		return PullDiagnostic{}, false
+	}
+
+	relPath, err := filepath.Rel(spaceDir, sourcePath)
+	if err != nil {
+		relPath = sourcePath
+	}
+	relPath = filepath.ToSlash(relPath)
+	return PullDiagnostic{
+		Path: relPath,
+		Code: "CROSS_SPACE_LINK",
+		Message: fmt.Sprintf(
+			"link %q in %s targets space %s, not this file's space %s; it is published as an absolute link to the other space and will not round-trip as an intra-space reference",
+			destination,
+			relPath,
+			targetSpaceKey,
+			sourceSpaceKey,
+		),
+	}, true
+}
+
+// markdownHeadingCache reads the headings of linked Markdown files once per
+// hook so `file.md#anchor` links can be mapped to Confluence heading anchors.
+type markdownHeadingCache struct {
+	mu     sync.Mutex
+	byPath map[string][]string
+}
+
+// resolveAnchor maps anchor to the Confluence anchor of the matching heading
+// in targetPath; an anchor matching no heading is returned unchanged.
+func (c *markdownHeadingCache) resolveAnchor(targetPath, anchor string) string {
+	c.mu.Lock()
+	headings, ok := c.byPath[targetPath]
+	if !ok {
+		if doc, err := fs.ReadMarkdownDocument(targetPath); err == nil {
+			headings = converter.MarkdownHeadings(doc.Body)
+		}
+		c.byPath[targetPath] = headings
+	}
+	c.mu.Unlock()
+
+	if resolved, ok := converter.ResolveHeadingAnchor(anchor, headings); ok {
+		return resolved
+	}
+	return anchor
+}
+
 func decodeMarkdownPath(path string) string {
 	decoded, err := url.PathUnescape(path)
 	if err != nil {
@@ -600,8 +698,7 @@ func loadSpaceKeyForPath(path string) (string, bool) {
 	}
 
 	for {
-		statePath := filepath.Join(current, fs.StateFileName)
-		if _, err := os.Stat(statePath); err == nil {
+		if fs.HasState(current) {
 			state, loadErr := fs.LoadState(current)
 			if loadErr != nil {
 				return "", false
@@ -4,8 +4,10 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/rgonek/confluence-markdown-sync/internal/config"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 	adfconv "github.com/rgonek/jira-adf-converter/converter"
 	mdconv "github.com/rgonek/jira-adf-converter/mdconverter"
@@ -409,6 +411,58 @@ func TestReverseLinkHookWithGlobalIndex_ResolvesCrossSpaceLink(t *testing.T) {
 	}
 }
 
+func TestCrossSpaceLinkDiagnostic_FlagsLinksIntoAnotherSpace(t *testing.T) {
+	tmpDir := t.TempDir()
+	engDir := filepath.Join(tmpDir, "Engineering (ENG)")
+	tdDir := filepath.Join(tmpDir, "Technical Docs (TD)")
+	for dir, key := range map[string]string{engDir: "ENG", tdDir: "TD"} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fs.StateFileName), []byte("{\"space_key\":\""+key+"\"}\n"), 0o600); err != nil {
+			t.Fatalf("write %s state file: %v", key, err)
+		}
+	}
+	sourcePath := filepath.Join(engDir, "Guides", "index.md")
+
+	diag, ok := CrossSpaceLinkDiagnostic(engDir, sourcePath, "../../Technical%20Docs%20(TD)/Target.md#intro")
+	if !ok {
+		t.Fatal("expected a diagnostic for a link into another space")
+	}
+	if diag.Path != "Guides/index.md" || diag.Code != "CROSS_SPACE_LINK" {
+		t.Fatalf("diagnostic = %+v", diag)
+	}
+	if !strings.Contains(diag.Message, "targets space TD, not this file's space ENG") {
+		t.Fatalf("message = %q, want both space keys", diag.Message)
+	}
+
+	for _, destination := range []string{"../Sibling.md", "Child.md#part", "#local", "https://example.com/x", "../../Unknown/Page.md"} {
+		if diag, ok := CrossSpaceLinkDiagnostic(engDir, sourcePath, destination); ok {
+			t.Fatalf("unexpected diagnostic for %q: %+v", destination, diag)
+		}
+	}
+}
+
+func TestReverseLinkHook_MapsAnchorToTargetHeadingID(t *testing.T) {
+	spaceDir := t.TempDir()
+	targetPath := filepath.Join(spaceDir, "Target.md")
+	if err := os.WriteFile(targetPath, []byte("---\nid: \"77\"\n---\n# Target\n\n## Section A\n"), 0o600); err != nil {
+		t.Fatalf("write target file: %v", err)
+	}
+
+	hook := NewReverseLinkHook(spaceDir, PageIndex{"Target.md": "77"}, "https://example.atlassian.net")
+	out, err := hook(context.Background(), mdconv.LinkParseInput{
+		SourcePath:  filepath.Join(spaceDir, "index.md"),
+		Destination: "Target.md#section-a",
+	})
+	if err != nil {
+		t.Fatalf("hook returned error: %v", err)
+	}
+	if got, want := out.Destination, "https://example.atlassian.net/wiki/pages/viewpage.action?pageId=77#Section-A"; got != want {
+		t.Fatalf("destination = %q, want %q", got, want)
+	}
+}
+
 func TestReverseLinkHookWithGlobalIndex_ResolvesViaSameFileFallback(t *testing.T) {
 	tmpDir := t.TempDir()
 	engDir := filepath.Join(tmpDir, "Engineering (ENG)")
@@ -579,3 +633,32 @@ func TestReverseMediaHook(t *testing.T) {
 		t.Errorf("Expected ErrUnresolved for missing file, got %v", err)
 	}
 }
+
+func TestReverseLinkHookWithGlobalIndex_WikiSuffixedDomainBuildsSingleWikiPath(t *testing.T) {
+	t.Setenv("ATLASSIAN_DOMAIN", "https://example.atlassian.net/wiki/")
+	t.Setenv("ATLASSIAN_EMAIL", "user@example.com")
+	t.Setenv("ATLASSIAN_API_TOKEN", "token-123")
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	spaceDir := t.TempDir()
+	hook := NewReverseLinkHookWithGlobalIndex(
+		spaceDir,
+		PageIndex{"index.md": "1", "child.md": "2"},
+		nil,
+		cfg.Domain,
+	)
+
+	out, err := hook(context.Background(), mdconv.LinkParseInput{
+		SourcePath:  filepath.Join(spaceDir, "index.md"),
+		Destination: "child.md",
+	})
+	if err != nil {
+		t.Fatalf("hook returned error: %v", err)
+	}
+	if got, want := out.Destination, "https://example.atlassian.net/wiki/pages/viewpage.action?pageId=2"; got != want {
+		t.Fatalf("destination = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,391 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// PageMoveResult describes a page moved by MovePage.
+type PageMoveResult struct {
+	// From and To are the space-relative paths of the page. To differs from
+	// the requested destination when the page is the index page of a
+	// directory: it is then moved to <dest-dir>/<name>/<name>.md.
+	From string
+	To   string
+	// FromDir and ToDir are the page's directory (its children, or its
+	// attachments in the flattened layout) before and after the move; both
+	// are empty when the page has none.
+	FromDir string
+	ToDir   string
+	// MovedFiles lists every file that was relocated, by its new path.
+	MovedFiles []string
+	// RewrittenFiles lists the Markdown files, by their new path, whose
+	// relative links or parent_path pins were updated.
+	RewrittenFiles []string
+	LinksRewritten int
+	// DroppedParentPin is set when the page's parent_id or parent_path pin
+	// was removed so the new directory decides its parent.
+	DroppedParentPin bool
+	// ParentID is the parent push derives for the page at its new path; empty
+	// when the new path is at the space root, where push keeps the current
+	// Confluence parent.
+	ParentID string
+}
+
+// MovePage moves the Markdown page at fromRelPath to toRelPath inside
+// spaceDir, together with its sidecars and its directory of child pages. It
+// rewrites relative links in the space that point into the moved files, and
+// the links of the moved files themselves, updates parent_path pins and the
+// path keys of state, and drops the page's own parent pins, so the next push
+// re-parents the page after its new directory. Nothing is sent to Confluence.
+func MovePage(spaceDir string, state *fs.SpaceState, fromRelPath, toRelPath string) (PageMoveResult, error) {
+	from := normalizeRelPath(fromRelPath)
+	to := normalizeRelPath(toRelPath)
+	if !isMarkdownFilePath(from) || !isMarkdownFilePath(to) {
+		return PageMoveResult{}, fmt.Errorf("move needs Markdown paths, got %q and %q", fromRelPath, toRelPath)
+	}
+	if to == ".." || strings.HasPrefix(to, "../") || path.IsAbs(to) {
+		return PageMoveResult{}, fmt.Errorf("%s is outside the space directory; moving pages across spaces is not supported", toRelPath)
+	}
+	if info, err := os.Stat(filepath.Join(spaceDir, filepath.FromSlash(from))); err != nil || info.IsDir() {
+		return PageMoveResult{}, fmt.Errorf("%s is not a Markdown file in this space", fromRelPath)
+	}
+
+	result := PageMoveResult{From: from, To: to}
+	switch {
+	case isIndexFile(from):
+		result.FromDir = path.Dir(from)
+		if isIndexFile(to) {
+			result.ToDir = path.Dir(to)
+		} else {
+			result.ToDir = strings.TrimSuffix(to, path.Ext(to))
+			result.To = indexPagePathForDir(result.ToDir)
+		}
+	case isDir(filepath.Join(spaceDir, filepath.FromSlash(strings.TrimSuffix(from, path.Ext(from))))):
+		result.FromDir = strings.TrimSuffix(from, path.Ext(from))
+		result.ToDir = strings.TrimSuffix(to, path.Ext(to))
+	}
+	if result.To == from {
+		return PageMoveResult{}, fmt.Errorf("%s is already at %s", fromRelPath, result.To)
+	}
+	if result.FromDir != "" && (result.To == result.FromDir || strings.HasPrefix(result.To, result.FromDir+"/")) {
+		return PageMoveResult{}, fmt.Errorf("cannot move %s into its own directory %s", from, result.FromDir)
+	}
+
+	markdownPaths, err := listSpaceMarkdownPaths(spaceDir)
+	if err != nil {
+		return PageMoveResult{}, err
+	}
+	used := map[string]struct{}{}
+	for _, relPath := range markdownPaths {
+		used[relPath] = struct{}{}
+	}
+	for relPath := range state.PagePathIndex {
+		used[normalizeRelPath(relPath)] = struct{}{}
+	}
+	if unique := ensureUniqueMarkdownPath(result.To, used); unique != result.To {
+		return PageMoveResult{}, fmt.Errorf("%s already exists; choose another destination such as %s", result.To, unique)
+	}
+	if result.ToDir != "" {
+		if _, err := os.Stat(filepath.Join(spaceDir, filepath.FromSlash(result.ToDir))); err == nil {
+			return PageMoveResult{}, fmt.Errorf("directory %s already exists; choose another destination", result.ToDir)
+		}
+	}
+
+	mapping := newPageMoveMapping(result)
+	fileMoves, err := planPageFileMoves(spaceDir, result, mapping)
+	if err != nil {
+		return PageMoveResult{}, err
+	}
+
+	rewrites := map[string][]byte{}
+	for _, relPath := range markdownPaths {
+		if fs.IsPageSidecar(relPath) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(spaceDir, filepath.FromSlash(relPath))) //nolint:gosec // path comes from space markdown traversal
+		if err != nil {
+			return PageMoveResult{}, fmt.Errorf("read %s: %w", relPath, err)
+		}
+		if rewritten, count := rewriteMovedLinkDestinations(content, relPath, mapping.target(relPath), mapping); count > 0 {
+			rewrites[mapping.target(relPath)] = rewritten
+			result.LinksRewritten += count
+		}
+	}
+
+	for _, move := range fileMoves {
+		if err := moveSpaceFile(spaceDir, move[0], move[1]); err != nil {
+			return PageMoveResult{}, err
+		}
+		result.MovedFiles = append(result.MovedFiles, move[1])
+	}
+	if result.FromDir != "" {
+		removeEmptyDirTree(filepath.Join(spaceDir, filepath.FromSlash(result.FromDir)))
+	}
+	if err := removeEmptyParentDirs(filepath.Join(spaceDir, filepath.FromSlash(path.Dir(from))), spaceDir); err != nil {
+		return PageMoveResult{}, fmt.Errorf("remove empty directories: %w", err)
+	}
+
+	rewritten := map[string]struct{}{}
+	for relPath, content := range rewrites {
+		if err := os.WriteFile(filepath.Join(spaceDir, filepath.FromSlash(relPath)), content, 0o644); err != nil { //nolint:gosec // markdown files are intentionally group-readable
+			return PageMoveResult{}, fmt.Errorf("write %s: %w", relPath, err)
+		}
+		rewritten[relPath] = struct{}{}
+	}
+
+	for _, oldPath := range markdownPaths {
+		if fs.IsPageSidecar(oldPath) {
+			continue
+		}
+		relPath := mapping.target(oldPath)
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		fm, err := fs.ReadFrontmatter(absPath)
+		if err != nil {
+			continue
+		}
+		dropPins := relPath == result.To && (fm.ParentID != "" || fm.ParentPath != "")
+		newParentPath, pinMoved := mapping.lookup(normalizeRelPath(fm.ParentPath))
+		if !dropPins && (fm.ParentPath == "" || !pinMoved) {
+			continue
+		}
+		doc, err := fs.ReadMarkdownDocument(absPath)
+		if err != nil {
+			return PageMoveResult{}, fmt.Errorf("read %s: %w", relPath, err)
+		}
+		if dropPins {
+			doc.Frontmatter.ParentID = ""
+			doc.Frontmatter.ParentPath = ""
+			result.DroppedParentPin = true
+		} else {
+			doc.Frontmatter.ParentPath = newParentPath
+		}
+		if err := fs.WriteMarkdownDocument(absPath, doc); err != nil {
+			return PageMoveResult{}, fmt.Errorf("write %s: %w", relPath, err)
+		}
+		rewritten[relPath] = struct{}{}
+	}
+	result.RewrittenFiles = sortedStringKeys(rewritten)
+
+	state.PagePathIndex = mapping.remapKeys(state.PagePathIndex)
+	state.AttachmentIndex = mapping.remapKeys(state.AttachmentIndex)
+	state.FolderPathIndex = mapping.remapKeys(state.FolderPathIndex)
+
+	pageIDByPath, err := BuildPageIndex(spaceDir)
+	if err != nil {
+		return result, fmt.Errorf("build page index: %w", err)
+	}
+	if fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(result.To))); err == nil {
+		result.ParentID = LocalParentID(result.To, fm, pageIDByPath, state.FolderPathIndex)
+	}
+	return result, nil
+}
+
+// pageMoveMapping maps space-relative paths from before a move to after it.
+type pageMoveMapping struct {
+	exact   map[string]string
+	fromDir string
+	toDir   string
+}
+
+func newPageMoveMapping(result PageMoveResult) pageMoveMapping {
+	mapping := pageMoveMapping{
+		exact:   map[string]string{result.From: result.To},
+		fromDir: result.FromDir,
+		toDir:   result.ToDir,
+	}
+	fromSidecars := fs.PageSidecarPaths(result.From)
+	for i, toSidecar := range fs.PageSidecarPaths(result.To) {
+		mapping.exact[normalizeRelPath(fromSidecars[i])] = normalizeRelPath(toSidecar)
+	}
+	return mapping
+}
+
+// lookup returns the new path of relPath and whether the move changes it.
+func (m pageMoveMapping) lookup(relPath string) (string, bool) {
+	if relPath == "" {
+		return "", false
+	}
+	if target, ok := m.exact[relPath]; ok {
+		return target, true
+	}
+	if m.fromDir == "" {
+		return relPath, false
+	}
+	if relPath == m.fromDir {
+		return m.toDir, true
+	}
+	if rest, ok := strings.CutPrefix(relPath, m.fromDir+"/"); ok {
+		return m.toDir + "/" + rest, true
+	}
+	return relPath, false
+}
+
+func (m pageMoveMapping) target(relPath string) string {
+	target, _ := m.lookup(relPath)
+	return target
+}
+
+func (m pageMoveMapping) remapKeys(index map[string]string) map[string]string {
+	if len(index) == 0 {
+		return index
+	}
+	out := make(map[string]string, len(index))
+	for relPath, id := range index {
+		out[m.target(normalizeRelPath(relPath))] = id
+	}
+	return out
+}
+
+// planPageFileMoves lists the [from, to] pairs of every file the move
+// relocates: the page, its sidecars, and everything under its directory.
+func planPageFileMoves(spaceDir string, result PageMoveResult, mapping pageMoveMapping) ([][2]string, error) {
+	var moves [][2]string
+	for from, to := range mapping.exact {
+		if from == result.From || fileExists(filepath.Join(spaceDir, filepath.FromSlash(from))) {
+			moves = append(moves, [2]string{from, to})
+		}
+	}
+	if result.FromDir != "" {
+		root := filepath.Join(spaceDir, filepath.FromSlash(result.FromDir))
+		err := filepath.WalkDir(root, func(absPath string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(spaceDir, absPath)
+			if err != nil {
+				return err
+			}
+			relPath := normalizeRelPath(rel)
+			if _, explicit := mapping.exact[relPath]; !explicit {
+				moves = append(moves, [2]string{relPath, mapping.target(relPath)})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", result.FromDir, err)
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i][0] < moves[j][0] })
+	return moves, nil
+}
+
+// rewriteMovedLinkDestinations rewrites the relative link and image
+// destinations of a file read at oldPath and written to newPath whose
+// target or source changes with the move.
+func rewriteMovedLinkDestinations(content []byte, oldPath, newPath string, mapping pageMoveMapping) ([]byte, int) {
+	var builder strings.Builder
+	last := 0
+	count := 0
+	for _, occurrence := range collectDestinationOccurrences(content, true) {
+		destination := normalizeMarkdownDestination(occurrence.raw)
+		if isExternalDestination(destination) || strings.HasPrefix(destination, "/") {
+			continue
+		}
+		pathPart, suffix := destination, ""
+		if idx := strings.IndexAny(destination, "#?"); idx >= 0 {
+			pathPart, suffix = destination[:idx], destination[idx:]
+		}
+		unescaped, err := url.PathUnescape(pathPart)
+		if err != nil || strings.TrimSpace(unescaped) == "" {
+			continue
+		}
+		oldTarget := path.Join(path.Dir(oldPath), unescaped)
+		newTarget, targetMoved := mapping.lookup(oldTarget)
+		if !targetMoved && oldPath == newPath {
+			continue
+		}
+		if path.Join(path.Dir(newPath), unescaped) == newTarget {
+			continue
+		}
+		relTarget, err := filepath.Rel(filepath.FromSlash(path.Dir(newPath)), filepath.FromSlash(newTarget))
+		if err != nil {
+			continue
+		}
+
+		builder.Write(content[last:occurrence.start])
+		builder.WriteString(formatRelinkDestinationToken(occurrence.raw, encodeMarkdownPath(filepath.ToSlash(relTarget))+suffix))
+		last = occurrence.end
+		count++
+	}
+	if count == 0 {
+		return content, 0
+	}
+	builder.Write(content[last:])
+	return []byte(builder.String()), count
+}
+
+// listSpaceMarkdownPaths returns the space-relative paths of every Markdown
+// file in spaceDir, skipping hidden directories and assets/.
+func listSpaceMarkdownPaths(spaceDir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(spaceDir, func(absPath string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if absPath != spaceDir && (d.Name() == "assets" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(d.Name()), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(spaceDir, absPath)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, normalizeRelPath(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan space markdown: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func moveSpaceFile(spaceDir, fromRelPath, toRelPath string) error {
+	toAbs := filepath.Join(spaceDir, filepath.FromSlash(toRelPath))
+	if err := os.MkdirAll(filepath.Dir(toAbs), 0o750); err != nil {
+		return fmt.Errorf("prepare directory for %s: %w", toRelPath, err)
+	}
+	if err := os.Rename(filepath.Join(spaceDir, filepath.FromSlash(fromRelPath)), toAbs); err != nil {
+		return fmt.Errorf("move %s to %s: %w", fromRelPath, toRelPath, err)
+	}
+	return nil
+}
+
+// removeEmptyDirTree removes root and the directories below it, deepest
+// first, that the move left empty.
+func removeEmptyDirTree(root string) {
+	var dirs []string
+	_ = filepath.WalkDir(root, func(absPath string, d os.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, absPath)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i])
+	}
+}
+
+func isDir(absPath string) bool {
+	info, err := os.Stat(absPath)
+	return err == nil && info.IsDir()
+}
+
+func fileExists(absPath string) bool {
+	_, err := os.Stat(absPath)
+	return !errors.Is(err, os.ErrNotExist)
+}
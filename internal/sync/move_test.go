@@ -0,0 +1,170 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func writeMoveTestPage(t *testing.T, spaceDir, relPath string, fm fs.Frontmatter, body string) {
+	t.Helper()
+	absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+		t.Fatalf("mkdir %s: %v", relPath, err)
+	}
+	if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fm, Body: body}); err != nil {
+		t.Fatalf("write %s: %v", relPath, err)
+	}
+}
+
+func readMoveTestFile(t *testing.T, spaceDir, relPath string) string {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join(spaceDir, filepath.FromSlash(relPath))) //nolint:gosec // test temp dir
+	if err != nil {
+		t.Fatalf("read %s: %v", relPath, err)
+	}
+	return string(raw)
+}
+
+func TestMovePage_MovesIndexPageWithChildrenAndRewritesLinks(t *testing.T) {
+	spaceDir := t.TempDir()
+	writeMoveTestPage(t, spaceDir, "Home.md", fs.Frontmatter{Title: "Home", ID: "1", Version: 1},
+		"See [setup](Guides/Setup.md#install) and [guides](Guides/Guides.md).\n")
+	writeMoveTestPage(t, spaceDir, "Other.md", fs.Frontmatter{Title: "Other", ID: "2", Version: 1, ParentPath: "Guides/Setup.md"},
+		"other\n")
+	writeMoveTestPage(t, spaceDir, "Guides/Guides.md", fs.Frontmatter{Title: "Guides", ID: "10", Version: 1, ParentID: "1"},
+		"[Setup](Setup.md)\n\n![diagram](../assets/10/att-1-diagram.png)\n")
+	writeMoveTestPage(t, spaceDir, "Guides/Setup.md", fs.Frontmatter{Title: "Setup", ID: "11", Version: 1},
+		"Back to [guides](Guides.md) or [home](../Home.md).\n")
+	writeMoveTestPage(t, spaceDir, "Handbook/Handbook.md", fs.Frontmatter{Title: "Handbook", ID: "20", Version: 1},
+		"handbook\n")
+
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{
+		"Home.md":              "1",
+		"Other.md":             "2",
+		"Guides/Guides.md":     "10",
+		"Guides/Setup.md":      "11",
+		"Handbook/Handbook.md": "20",
+	}
+	state.AttachmentIndex = map[string]string{"assets/10/att-1-diagram.png": "att-1"}
+
+	result, err := MovePage(spaceDir, &state, "Guides/Guides.md", "Handbook/Guides.md")
+	if err != nil {
+		t.Fatalf("MovePage() error: %v", err)
+	}
+
+	if result.To != "Handbook/Guides/Guides.md" || result.ToDir != "Handbook/Guides" {
+		t.Fatalf("move target = %s (dir %s), want Handbook/Guides/Guides.md", result.To, result.ToDir)
+	}
+	if result.ParentID != "20" {
+		t.Fatalf("parent id = %q, want 20", result.ParentID)
+	}
+	if !result.DroppedParentPin {
+		t.Fatal("expected the parent_id pin of the moved page to be dropped")
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Guides")); !os.IsNotExist(err) {
+		t.Fatalf("old Guides directory should be removed, stat err = %v", err)
+	}
+
+	if got := readMoveTestFile(t, spaceDir, "Home.md"); !strings.Contains(got, "(Handbook/Guides/Setup.md#install)") || !strings.Contains(got, "(Handbook/Guides/Guides.md)") {
+		t.Fatalf("Home.md links not rewritten:\n%s", got)
+	}
+	guides := readMoveTestFile(t, spaceDir, "Handbook/Guides/Guides.md")
+	if !strings.Contains(guides, "[Setup](Setup.md)") || !strings.Contains(guides, "(../../assets/10/att-1-diagram.png)") {
+		t.Fatalf("moved index page links not rewritten:\n%s", guides)
+	}
+	if strings.Contains(guides, "parent_id") {
+		t.Fatalf("moved index page should lose its parent_id pin:\n%s", guides)
+	}
+	if got := readMoveTestFile(t, spaceDir, "Handbook/Guides/Setup.md"); !strings.Contains(got, "[guides](Guides.md)") || !strings.Contains(got, "[home](../../Home.md)") {
+		t.Fatalf("moved child links not rewritten:\n%s", got)
+	}
+
+	fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, "Other.md"))
+	if err != nil {
+		t.Fatalf("read Other.md frontmatter: %v", err)
+	}
+	if fm.ParentPath != "Handbook/Guides/Setup.md" {
+		t.Fatalf("parent_path = %q, want Handbook/Guides/Setup.md", fm.ParentPath)
+	}
+
+	if state.PagePathIndex["Handbook/Guides/Guides.md"] != "10" || state.PagePathIndex["Handbook/Guides/Setup.md"] != "11" {
+		t.Fatalf("state index not remapped: %+v", state.PagePathIndex)
+	}
+	if _, ok := state.PagePathIndex["Guides/Guides.md"]; ok {
+		t.Fatalf("old path still tracked: %+v", state.PagePathIndex)
+	}
+	if state.AttachmentIndex["assets/10/att-1-diagram.png"] != "att-1" {
+		t.Fatalf("attachment index changed: %+v", state.AttachmentIndex)
+	}
+}
+
+func TestMovePage_RejectsCollisionsAndPathsOutsideTheSpace(t *testing.T) {
+	spaceDir := t.TempDir()
+	writeMoveTestPage(t, spaceDir, "Setup.md", fs.Frontmatter{Title: "Setup", ID: "11", Version: 1}, "setup\n")
+	writeMoveTestPage(t, spaceDir, "Guides/Install.md", fs.Frontmatter{Title: "Install", ID: "12", Version: 1}, "install\n")
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Setup.md": "11", "Guides/Install.md": "12"}
+
+	_, err := MovePage(spaceDir, &state, "Setup.md", "Guides/Install.md")
+	if err == nil || !strings.Contains(err.Error(), "Guides/Install-2.md") {
+		t.Fatalf("collision error = %v, want a suggestion of Guides/Install-2.md", err)
+	}
+
+	_, err = MovePage(spaceDir, &state, "Setup.md", "../OPS/Setup.md")
+	if err == nil || !strings.Contains(err.Error(), "across spaces") {
+		t.Fatalf("cross-space error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(spaceDir, "Setup.md")); err != nil {
+		t.Fatalf("rejected move should leave the page in place: %v", err)
+	}
+}
+
+func TestMovePage_NextPushReparentsInsteadOfArchiving(t *testing.T) {
+	spaceDir := t.TempDir()
+	writeMoveTestPage(t, spaceDir, "Setup.md", fs.Frontmatter{Title: "Setup", ID: "11", Version: 1}, "setup\n")
+	writeMoveTestPage(t, spaceDir, "Handbook/Handbook.md", fs.Frontmatter{Title: "Handbook", ID: "20", Version: 1}, "handbook\n")
+	state := fs.NewSpaceState()
+	state.SpaceKey = "ENG"
+	state.PagePathIndex = map[string]string{"Setup.md": "11", "Handbook/Handbook.md": "20"}
+
+	if _, err := MovePage(spaceDir, &state, "Setup.md", "Handbook/Setup.md"); err != nil {
+		t.Fatalf("MovePage() error: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	for _, page := range []confluence.Page{
+		{ID: "11", SpaceID: "space-1", Title: "Setup", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)},
+		{ID: "20", SpaceID: "space-1", Title: "Handbook", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)},
+	} {
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		Domain:   "https://example.atlassian.net",
+		State:    state,
+		Changes: []PushFileChange{
+			{Type: PushChangeDelete, Path: "Setup.md"},
+			{Type: PushChangeAdd, Path: "Handbook/Setup.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+	if len(remote.archiveTaskCalls) > 0 || len(remote.deletePageCalls) > 0 {
+		t.Fatalf("moved page must not be archived or deleted: archive=%v delete=%v", remote.archiveTaskCalls, remote.deletePageCalls)
+	}
+	if got := remote.updateInputsByPageID["11"].ParentPageID; got != "20" {
+		t.Fatalf("updated parent = %q, want 20", got)
+	}
+}
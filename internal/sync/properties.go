@@ -0,0 +1,332 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// contentPropertyRemote is implemented by pull remotes that can read page
+// content properties, such as *confluence.Client. Every PushRemote can.
+type contentPropertyRemote interface {
+	GetContentProperties(ctx context.Context, pageID string) ([]confluence.ContentProperty, error)
+}
+
+// fetchPageProperties returns the content properties of a page that pull
+// writes to frontmatter: keys matching one of patterns, plus keys already
+// tracked locally so a property added by hand keeps being refreshed.
+func fetchPageProperties(ctx context.Context, remote contentPropertyRemote, pageID string, patterns []string, localKeys map[string]any) (map[string]json.RawMessage, error) {
+	remoteProperties, err := remote.GetContentProperties(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]json.RawMessage{}
+	for _, property := range remoteProperties {
+		if _, tracked := localKeys[property.Key]; tracked || matchPropertyKey(patterns, property.Key) {
+			properties[property.Key] = property.Value
+		}
+	}
+	return properties, nil
+}
+
+// FetchPullProperties returns the raw content properties pull would write
+// to a page's frontmatter, given the configured key patterns and the
+// properties already in its local frontmatter. It returns nil when the
+// remote cannot read properties or no key is tracked, meaning the local
+// values stand.
+func FetchPullProperties(ctx context.Context, remote PullRemote, pageID string, patterns []string, local map[string]any) (map[string]json.RawMessage, error) {
+	propertyRemote, ok := remote.(contentPropertyRemote)
+	if !ok || (len(patterns) == 0 && len(local) == 0) {
+		return nil, nil
+	}
+	return fetchPageProperties(ctx, propertyRemote, pageID, patterns, local)
+}
+
+// FrontmatterPropertyValues decodes fetched content properties into the
+// values pull writes under the `properties` frontmatter key.
+func FrontmatterPropertyValues(properties map[string]json.RawMessage) (map[string]any, error) {
+	values, _, err := frontmatterProperties(properties)
+	return values, err
+}
+
+func matchPropertyKey(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, key); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// frontmatterProperties decodes remote property values into the `properties`
+// frontmatter map along with the hash of each value. Numbers stay numbers:
+// integers that fit int64 or uint64 keep full precision instead of becoming
+// float64.
+func frontmatterProperties(properties map[string]json.RawMessage) (map[string]any, map[string]string, error) {
+	values := make(map[string]any, len(properties))
+	hashes := make(map[string]string, len(properties))
+	for key, raw := range properties {
+		value, err := decodePropertyValue(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode property %q: %w", key, err)
+		}
+		hash, err := propertyValueHash(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("hash property %q: %w", key, err)
+		}
+		values[key] = value
+		hashes[key] = hash
+	}
+	return values, hashes, nil
+}
+
+func decodePropertyValue(raw json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertJSONNumbers(value), nil
+}
+
+func convertJSONNumbers(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(typed.String(), 10, 64); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(typed.String(), 10, 64); err == nil {
+			return u
+		}
+		if f, err := typed.Float64(); err == nil {
+			return f
+		}
+		return typed.String()
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = convertJSONNumbers(item)
+		}
+		return typed
+	case []any:
+		for i, item := range typed {
+			typed[i] = convertJSONNumbers(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// propertyJSON encodes a frontmatter property value as the JSON sent to
+// Confluence. YAML-only types are mapped to their JSON counterparts: an
+// unquoted date becomes its date string and a mapping with non-string keys
+// gets string keys.
+func propertyJSON(value any) (json.RawMessage, error) {
+	normalized, err := jsonCompatibleValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalized)
+}
+
+func jsonCompatibleValue(value any) (any, error) {
+	switch typed := value.(type) {
+	case time.Time:
+		if typed.Equal(time.Date(typed.Year(), typed.Month(), typed.Day(), 0, 0, 0, 0, typed.Location())) {
+			return typed.Format(time.DateOnly), nil
+		}
+		return typed.Format(time.RFC3339Nano), nil
+	case float64:
+		if math.IsInf(typed, 0) || math.IsNaN(typed) {
+			return nil, fmt.Errorf("%v cannot be stored as JSON", typed)
+		}
+		return typed, nil
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			converted, err := jsonCompatibleValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = converted
+		}
+		return out, nil
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			converted, err := jsonCompatibleValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key)] = converted
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			converted, err := jsonCompatibleValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+// propertyValueHash hashes the JSON form of a frontmatter property value.
+// Map keys are encoded in sorted order, so equal values hash equally.
+func propertyValueHash(value any) (string, error) {
+	encoded, err := propertyJSON(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// updatedPagePropertyHashes keeps the property hashes of pages still in the
+// space and replaces those of pages whose properties this pull fetched.
+func updatedPagePropertyHashes(previous map[string]map[string]string, pageByID map[string]confluence.Page, fetched map[string]map[string]string) map[string]map[string]string {
+	hashes := make(map[string]map[string]string, len(previous)+len(fetched))
+	for pageID, pageHashes := range previous {
+		if _, ok := pageByID[pageID]; ok && len(pageHashes) > 0 {
+			hashes[pageID] = pageHashes
+		}
+	}
+	for pageID, pageHashes := range fetched {
+		if len(pageHashes) == 0 {
+			delete(hashes, pageID)
+			continue
+		}
+		hashes[pageID] = pageHashes
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return hashes
+}
+
+// syncPageProperties writes the `properties` frontmatter key back to a page.
+// Only keys whose value differs from the one last pulled or pushed are
+// written, so properties another tool updated since are left alone; keys
+// missing from frontmatter are never deleted. A token that may not change a
+// property produces a PROPERTIES_PERMISSION_DENIED diagnostic instead of
+// failing the push. propertyHashes is the push state's
+// PagePropertyHashes and is updated with the values written.
+func syncPageProperties(ctx context.Context, remote PushRemote, relPath, pageID string, fm fs.Frontmatter, propertyHashes map[string]map[string]string, diagnostics *[]PushDiagnostic) error {
+	local, err := fm.Properties()
+	if err != nil {
+		return err
+	}
+	if len(local) == 0 {
+		return nil
+	}
+
+	baseline := propertyHashes[pageID]
+	type pendingProperty struct {
+		key   string
+		value json.RawMessage
+		hash  string
+	}
+	var pending []pendingProperty
+	for key, value := range local {
+		encoded, err := propertyJSON(value)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", key, err)
+		}
+		hash, err := propertyValueHash(value)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", key, err)
+		}
+		if baseline[key] == hash {
+			continue
+		}
+		pending = append(pending, pendingProperty{key: key, value: encoded, hash: hash})
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].key < pending[j].key })
+
+	current, err := remote.GetContentProperties(ctx, pageID)
+	if err != nil {
+		if isRestrictionPermissionError(err) {
+			appendPropertyPermissionDiagnostic(diagnostics, relPath, "read", err)
+			return nil
+		}
+		if !errors.Is(err, confluence.ErrNotFound) {
+			return fmt.Errorf("get content properties: %w", err)
+		}
+	}
+	currentByKey := make(map[string]confluence.ContentProperty, len(current))
+	for _, property := range current {
+		currentByKey[property.Key] = property
+	}
+
+	hashes := make(map[string]string, len(baseline)+len(pending))
+	for key, hash := range baseline {
+		hashes[key] = hash
+	}
+	for _, property := range pending {
+		existing, exists := currentByKey[property.key]
+		if exists && remotePropertyHash(existing.Value) == property.hash {
+			hashes[property.key] = property.hash
+			continue
+		}
+		if _, err := remote.SetContentProperty(ctx, pageID, confluence.ContentProperty{
+			ID:      existing.ID,
+			Key:     property.key,
+			Value:   property.value,
+			Version: existing.Version,
+		}); err != nil {
+			if isRestrictionPermissionError(err) {
+				appendPropertyPermissionDiagnostic(diagnostics, relPath, "update", err)
+				continue
+			}
+			return err
+		}
+		hashes[property.key] = property.hash
+	}
+
+	propertyHashes[pageID] = hashes
+	return nil
+}
+
+// remotePropertyHash hashes a remote value the way pull stores it, or
+// returns "" when it cannot be decoded.
+func remotePropertyHash(raw json.RawMessage) string {
+	value, err := decodePropertyValue(raw)
+	if err != nil {
+		return ""
+	}
+	hash, err := propertyValueHash(value)
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+func appendPropertyPermissionDiagnostic(diagnostics *[]PushDiagnostic, relPath, action string, err error) {
+	appendPushDiagnostic(
+		diagnostics,
+		relPath,
+		"PROPERTIES_PERMISSION_DENIED",
+		fmt.Sprintf("could not %s content properties (%v); the page content was pushed but its properties were left unchanged", action, err),
+	)
+}
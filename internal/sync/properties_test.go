@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+type propertyFakePullRemote struct {
+	*fakePullRemote
+	properties map[string][]confluence.ContentProperty
+}
+
+func (f *propertyFakePullRemote) GetContentProperties(_ context.Context, pageID string) ([]confluence.ContentProperty, error) {
+	return f.properties[pageID], nil
+}
+
+func TestPull_WritesMatchingContentPropertiesToFrontmatter(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &propertyFakePullRemote{
+		fakePullRemote: &fakePullRemote{
+			space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+			pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt}},
+			pagesByID: map[string]confluence.Page{
+				"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Plan body</p>"},
+			},
+		},
+		properties: map[string][]confluence.ContentProperty{"1": {
+			{ID: "p-1", Key: "dashboard.review", Value: json.RawMessage(`{"due":"2026-03-01","ticket":18446744073709551615,"score":0.5,"tags":["a","b"],"done":false}`)},
+			{ID: "p-2", Key: "content-appearance-published", Value: json.RawMessage(`"full-width"`)},
+		}},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:     "ENG",
+		SpaceDir:     spaceDir,
+		State:        fs.NewSpaceState(),
+		PropertyKeys: []string{"dashboard.*"},
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Plan.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Plan.md: %v", err)
+	}
+	for _, want := range []string{"properties:\n", "dashboard.review:", `due: "2026-03-01"`, "ticket: 18446744073709551615", "score: 0.5", "done: false"} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("frontmatter missing %q:\n%s", want, raw)
+		}
+	}
+	if strings.Contains(string(raw), "content-appearance") {
+		t.Fatalf("property outside pull.properties was written:\n%s", raw)
+	}
+
+	// The written value must hash the same once read back, or push would
+	// treat every pulled property as a local edit.
+	doc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Plan.md"))
+	if err != nil {
+		t.Fatalf("read Plan.md: %v", err)
+	}
+	properties, err := doc.Frontmatter.Properties()
+	if err != nil {
+		t.Fatalf("Properties() error: %v", err)
+	}
+	hash, err := propertyValueHash(properties["dashboard.review"])
+	if err != nil {
+		t.Fatalf("propertyValueHash() error: %v", err)
+	}
+	if got := result.State.PagePropertyHashes["1"]; len(got) != 1 || got["dashboard.review"] != hash {
+		t.Fatalf("property hashes = %v, want dashboard.review = %s", got, hash)
+	}
+}
+
+func TestSyncPageProperties_WritesOnlyLocallyChangedKeys(t *testing.T) {
+	pulled, hashes, err := frontmatterProperties(map[string]json.RawMessage{
+		"dashboard.owner":  json.RawMessage(`"docs-team"`),
+		"dashboard.review": json.RawMessage(`{"due":"2026-03-01","count":3}`),
+	})
+	if err != nil {
+		t.Fatalf("frontmatterProperties() error: %v", err)
+	}
+	propertyHashes := map[string]map[string]string{"1": hashes}
+
+	remote := newRollbackPushRemote()
+	remote.propertiesByPage = map[string][]confluence.ContentProperty{"1": {
+		// Another tool changed the owner since the pull; it must survive.
+		{ID: "p-1", Key: "dashboard.owner", Value: json.RawMessage(`"platform-team"`), Version: 2},
+		{ID: "p-2", Key: "dashboard.review", Value: json.RawMessage(`{"count":3,"due":"2026-03-01"}`), Version: 5},
+	}}
+
+	fm := fs.Frontmatter{}
+	fm.SetProperties(pulled)
+	if err := syncPageProperties(context.Background(), remote, "Plan.md", "1", fm, propertyHashes, nil); err != nil {
+		t.Fatalf("syncPageProperties() error: %v", err)
+	}
+	if len(remote.setPropertyCalls) != 0 {
+		t.Fatalf("unchanged properties were written: %+v", remote.setPropertyCalls)
+	}
+
+	fm.SetProperties(map[string]any{
+		"dashboard.owner":  "docs-team",
+		"dashboard.review": map[string]any{"due": "2026-04-01", "count": 3},
+		"dashboard.new":    []any{1, 2},
+	})
+	if err := syncPageProperties(context.Background(), remote, "Plan.md", "1", fm, propertyHashes, nil); err != nil {
+		t.Fatalf("syncPageProperties() error: %v", err)
+	}
+	if len(remote.setPropertyCalls) != 2 {
+		t.Fatalf("set calls = %+v, want the new and the edited property", remote.setPropertyCalls)
+	}
+	if created := remote.setPropertyCalls[0]; created.Key != "dashboard.new" || created.ID != "" || string(created.Value) != "[1,2]" {
+		t.Fatalf("created property = %+v", created)
+	}
+	if updated := remote.setPropertyCalls[1]; updated.Key != "dashboard.review" || updated.ID != "p-2" || updated.Version != 5 || string(updated.Value) != `{"count":3,"due":"2026-04-01"}` {
+		t.Fatalf("updated property = %+v", updated)
+	}
+	if propertyHashes["1"]["dashboard.owner"] != hashes["dashboard.owner"] || len(propertyHashes["1"]) != 3 {
+		t.Fatalf("property hashes = %v, want the owner baseline kept and both writes recorded", propertyHashes["1"])
+	}
+}
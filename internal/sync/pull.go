@@ -12,6 +12,7 @@ import (
 	gosync "sync"
 	"time"
 
+	adfconv "github.com/rgonek/jira-adf-converter/converter"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
@@ -25,6 +26,10 @@ const (
 	pullPageBatchSize        = 100
 	pullChangeBatchSize      = 100
 	maxPaginationIterations  = 500
+
+	// DefaultPullConcurrency is how many pages are fetched, and attachments
+	// downloaded, at the same time when PullOptions.Concurrency is not set.
+	DefaultPullConcurrency = 5
 )
 
 // PullRemote defines the remote operations required by pull orchestration.
@@ -37,6 +42,7 @@ type PullRemote interface {
 	GetPage(ctx context.Context, pageID string) (confluence.Page, error)
 	GetContentStatus(ctx context.Context, pageID string, pageStatus string) (string, error)
 	GetLabels(ctx context.Context, pageID string) ([]string, error)
+	GetRestrictions(ctx context.Context, pageID string) (confluence.PageRestrictions, error)
 	ListAttachments(ctx context.Context, pageID string) ([]confluence.Attachment, error)
 	DownloadAttachment(ctx context.Context, attachmentID string, pageID string, out io.Writer) error
 }
@@ -52,11 +58,14 @@ type Progress interface {
 
 // PullOptions controls pull orchestration behavior.
 type PullOptions struct {
-	SpaceKey          string
-	SpaceDir          string
-	State             fs.SpaceState
-	GlobalPageIndex   GlobalPageIndex
-	PullStartedAt     time.Time
+	SpaceKey        string
+	SpaceDir        string
+	State           fs.SpaceState
+	GlobalPageIndex GlobalPageIndex
+	PullStartedAt   time.Time
+	// Now is the clock used for timestamps the pull generates itself, such
+	// as the default PullStartedAt. Nil uses the current UTC time.
+	Now               func() time.Time
 	OverlapWindow     time.Duration
 	TargetPageID      string
 	ForceFull         bool
@@ -64,6 +73,66 @@ type PullOptions struct {
 	OnDownloadError   func(attachmentID string, pageID string, err error) bool // return true to skip and continue
 	Progress          Progress
 	PrefetchedPages   []confluence.Page // pages fetched during estimate phase to avoid duplicate listing
+	// MaxPages caps how many pages are listed from the space in one run (0 = unlimited).
+	// A capped run resumes from State.PullResumeCursor and stores the next cursor.
+	MaxPages int
+	// FilenameMode controls how page and folder titles become local names.
+	// Empty uses fs.FilenameModeConservative.
+	FilenameMode fs.FilenameMode
+	// OrderPrefix prefixes page and folder names with their sibling position
+	// (see PagePathLayout).
+	OrderPrefix bool
+	// AssetLayout selects where attachments are written: empty for
+	// assets/<page-id>/ or fs.AssetLayoutFlatten for a directory named after
+	// each page. Changing it from State.AssetLayout re-pulls every page so all
+	// attachments move to the new layout.
+	AssetLayout string
+	// Comments mirrors the footer comments of every tracked page in scope
+	// into a read-only "<page>.comments.md" sidecar, including pages this
+	// run does not rewrite: a new comment does not change the page version.
+	Comments bool
+	// HistoryLimit mirrors up to this many recent versions of every written
+	// page into a read-only "<page>.history.md" sidecar. Zero disables it.
+	HistoryLimit int
+	// StripTitleHeading drops a leading H1 that repeats the page title from
+	// the written Markdown; push puts it back (PushOptions.StripTitleHeading).
+	StripTitleHeading bool
+	// PageURL writes each page's resolved web UI URL to the frontmatter `url`
+	// key of every page this pull writes.
+	PageURL bool
+	// PropertyKeys lists globs of content property keys written to the
+	// frontmatter `properties` key. Keys already in a page's frontmatter are
+	// always refreshed; with neither, properties are not fetched.
+	PropertyKeys []string
+	// SkippedPaths lists tracked files marked `cms_skip: true`, captured
+	// before local edits were stashed. Nil reads the flag from SpaceDir.
+	SkippedPaths map[string]struct{}
+	// Concurrency bounds how many pages are fetched, and attachments
+	// downloaded, at the same time. Zero or less uses DefaultPullConcurrency.
+	Concurrency int
+	// Include and Exclude are globs against the planned space-relative
+	// Markdown path (see fs.MatchRelPathGlob). When set, only pages matching
+	// an Include glob and no Exclude glob are written, moved or deleted.
+	Include []string
+	Exclude []string
+}
+
+func (opts PullOptions) now() time.Time {
+	if opts.Now != nil {
+		return opts.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (opts PullOptions) concurrency() int {
+	if opts.Concurrency > 0 {
+		return opts.Concurrency
+	}
+	return DefaultPullConcurrency
+}
+
+func (opts PullOptions) pagePathLayout() PagePathLayout {
+	return PagePathLayout{FilenameMode: opts.FilenameMode, OrderPrefix: opts.OrderPrefix}
 }
 
 // PullDiagnostic captures non-fatal conversion diagnostics.
@@ -105,10 +174,14 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}
 
 	state := normalizePullState(opts.State)
+	previousAssetLayout := state.AssetLayout
+	if opts.AssetLayout != previousAssetLayout {
+		opts.ForceFull = true
+	}
 
 	pullStartedAt := opts.PullStartedAt
 	if pullStartedAt.IsZero() {
-		pullStartedAt = time.Now().UTC()
+		pullStartedAt = opts.now()
 	}
 	overlapWindow := opts.OverlapWindow
 	if overlapWindow <= 0 {
@@ -148,28 +221,64 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		state.SpaceKey = strings.TrimSpace(opts.SpaceKey)
 	}
 
+	// Pages and attachments fetched by an interrupted run of this same pull
+	// are reused instead of being fetched again.
+	progress := openPullProgress(spaceDir, pullProgressRun{
+		SpaceKey:    state.SpaceKey,
+		Since:       state.LastPullHighWatermark,
+		AssetLayout: opts.AssetLayout,
+	})
+	defer progress.close()
+
 	if opts.Progress != nil {
 		opts.Progress.SetDescription("Scanning space for pages")
 	}
 
+	maxPages := opts.MaxPages
+	if maxPages < 0 || strings.TrimSpace(opts.TargetPageID) != "" {
+		maxPages = 0
+	}
+	listingTruncated := false
+
 	var pages []confluence.Page
-	if len(opts.PrefetchedPages) > 0 {
+	if len(opts.PrefetchedPages) > 0 && maxPages == 0 {
 		pages = opts.PrefetchedPages
 	} else {
-		pages, err = listAllPages(ctx, remote, confluence.PageListOptions{
+		listOpts := confluence.PageListOptions{
 			SpaceID:  space.ID,
 			SpaceKey: opts.SpaceKey,
 			Status:   "current",
 			Limit:    pullPageBatchSize,
-		}, opts.Progress)
+		}
+		if maxPages > 0 {
+			listOpts.Cursor = state.PullResumeCursor
+		}
+		var nextCursor string
+		pages, nextCursor, err = listAllPages(ctx, remote, listOpts, maxPages, opts.Progress)
 		if err != nil {
 			return PullResult{}, fmt.Errorf("list pages: %w", err)
 		}
+		if maxPages > 0 {
+			listingTruncated = nextCursor != ""
+			state.PullResumeCursor = nextCursor
+			if listingTruncated {
+				diagnostics = append(diagnostics, PullDiagnostic{
+					Path:    opts.SpaceKey,
+					Code:    "PULL_PAGE_LIMIT_REACHED",
+					Message: fmt.Sprintf("page listing stopped after %d page(s) because of the page limit (%d); rerun pull to continue from the saved cursor", len(pages), maxPages),
+				})
+			}
+		}
 	}
 
-	pages, err = recoverMissingPages(ctx, remote, space.ID, state.PagePathIndex, pages)
-	if err != nil {
-		return PullResult{}, fmt.Errorf("recover missing pages: %w", err)
+	// A listing cut short by the page limit has not reached every tracked
+	// page yet. Fetching the rest one by one would defeat the limit, so they
+	// are left alone until the run that completes the listing.
+	if !listingTruncated {
+		pages, err = recoverMissingPages(ctx, remote, space.ID, state.PagePathIndex, pages)
+		if err != nil {
+			return PullResult{}, fmt.Errorf("recover missing pages: %w", err)
+		}
 	}
 
 	if opts.Progress != nil {
@@ -207,7 +316,21 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}
 	sort.Strings(pageIDs)
 
-	pagePathByIDAbs, pagePathByIDRel := PlanPagePaths(spaceDir, state.PagePathIndex, pages, folderByID)
+	pagePathByIDAbs, pagePathByIDRel := PlanPagePaths(spaceDir, state.PagePathIndex, pages, folderByID, opts.pagePathLayout())
+	heldPages := heldPullPageIDs(spaceDir, state.PagePathIndex, opts.SkippedPaths)
+	for _, keptPaths := range []map[string]string{heldPages, pinnedParentPagePaths(spaceDir, state.PagePathIndex)} {
+		for pageID, relPath := range keptPaths {
+			if _, planned := pagePathByIDRel[pageID]; planned {
+				pagePathByIDRel[pageID] = relPath
+				pagePathByIDAbs[pageID] = filepath.Join(spaceDir, filepath.FromSlash(relPath))
+			}
+		}
+	}
+	outOfScopePages, scopeDiags := scopePullPagePaths(opts, spaceDir, state.PagePathIndex, pageByID, pagePathByIDAbs, pagePathByIDRel)
+	diagnostics = append(diagnostics, scopeDiags...)
+	if listingTruncated {
+		keepUnlistedTrackedPages(spaceDir, state.PagePathIndex, pageByID, pagePathByIDAbs, pagePathByIDRel, outOfScopePages)
+	}
 	pathMoves := PlannedPagePathMoves(state.PagePathIndex, pagePathByIDRel)
 	for _, move := range pathMoves {
 		diagnostics = append(diagnostics, pagePathMoveDiagnostic(move))
@@ -216,10 +339,11 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	if opts.Progress != nil {
 		opts.Progress.SetDescription("Identifying changed pages")
 	}
-	changedPageIDs, changedPageMeta, err := selectChangedPages(ctx, remote, opts, overlapWindow, pageByID)
+	changedPageIDs, changedPageMeta, changeDiags, err := selectChangedPages(ctx, remote, opts, overlapWindow, pageByID)
 	if err != nil {
 		return PullResult{}, err
 	}
+	diagnostics = append(diagnostics, changeDiags...)
 	if strings.TrimSpace(opts.TargetPageID) == "" {
 		changedSet := map[string]struct{}{}
 		for _, pageID := range changedPageIDs {
@@ -230,6 +354,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		}
 		changedPageIDs = sortedStringKeys(changedSet)
 	}
+	changedPageIDs = dropOutOfScopePageIDs(changedPageIDs, outOfScopePages)
 
 	if opts.Progress != nil {
 		opts.Progress.SetDescription("Fetching pages")
@@ -237,8 +362,34 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}
 
 	changedPages := make(map[string]confluence.Page, len(changedPageIDs))
+	resumedPageIDs := map[string]struct{}{}
+	failedPageIDs := map[string]struct{}{}
 	var changedPagesMu gosync.Mutex
+	keepChangedPage := func(page confluence.Page) {
+		changedPagesMu.Lock()
+		defer changedPagesMu.Unlock()
+		changedPages[page.ID] = page
+		if page.Version > maxVersion {
+			maxVersion = page.Version
+		}
+		if page.LastModified.After(maxRemoteModified) {
+			maxRemoteModified = page.LastModified
+		}
+	}
+	movedPageIDs := make(map[string]struct{}, len(pathMoves))
+	for _, move := range pathMoves {
+		movedPageIDs[move.PageID] = struct{}{}
+	}
+	// Fetch diagnostics are kept per page and appended in changedPageIDs
+	// order once all workers finish, so the report does not depend on which
+	// worker ran first.
+	fetchDiagnosticsByPageID := map[string][]PullDiagnostic{}
 	var diagMu gosync.Mutex
+	addFetchDiagnostic := func(pageID, code, message string) {
+		diagMu.Lock()
+		defer diagMu.Unlock()
+		fetchDiagnosticsByPageID[pageID] = append(fetchDiagnosticsByPageID[pageID], PullDiagnostic{Path: pageID, Code: code, Message: message})
+	}
 
 	readExistingFrontmatter := func(pageID string) (fs.Frontmatter, bool) {
 		absPath, ok := pagePathByIDAbs[pageID]
@@ -257,7 +408,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}
 
 	g, gCtx := errgroup.WithContext(ctx)
-	g.SetLimit(5)
+	g.SetLimit(opts.concurrency())
 
 	for _, pageID := range changedPageIDs {
 		pageID := pageID // copy for goroutine
@@ -266,14 +417,51 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 				opts.Progress.SetCurrentItem(pageID)
 			}
 
-			page, err := fetchChangedPageWithRetry(gCtx, remote, pageID, pageByID[pageID], changedPageMeta[pageID])
-			if err != nil {
+			if page, ok := progress.page(pageID, max(pageByID[pageID].Version, changedPageMeta[pageID].Version)); ok {
+				keepChangedPage(page)
+				changedPagesMu.Lock()
+				resumedPageIDs[pageID] = struct{}{}
+				changedPagesMu.Unlock()
+				if opts.Progress != nil {
+					opts.Progress.Add(1)
+				}
+				return nil
+			}
+
+			// A page whose local copy is already at the listed version (an
+			// overlap-window re-fetch) is fetched conditionally so an unchanged
+			// page costs a 304 instead of its full body. --force rewrites
+			// every page, so it always fetches the body.
+			etag := ""
+			if _, moved := movedPageIDs[pageID]; !moved && !opts.ForceFull {
+				existingFM, ok := readExistingFrontmatter(pageID)
+				if ok && existingFM.Version >= max(pageByID[pageID].Version, changedPageMeta[pageID].Version) {
+					etag = state.PageETags[pageID]
+				}
+			}
+
+			page, unchanged, err := fetchChangedPageConditionally(gCtx, remote, pageID, etag, pageByID[pageID], changedPageMeta[pageID])
+			if err != nil || unchanged {
 				if opts.Progress != nil {
 					opts.Progress.Add(1)
 				}
 				return err
 			}
 
+			// Writing an empty body would record the page as current and a
+			// later push would blank it, so the local copy and its state are
+			// kept and the next pull fetches the page again.
+			if len(page.BodyADF) == 0 && page.BodyStorageError != "" {
+				addFetchDiagnostic(pageID, "PAGE_BODY_FETCH_FAILED", fmt.Sprintf("page %s has no ADF body and its storage body could not be fetched (%s); kept the local copy, the next pull retries it", pageID, page.BodyStorageError))
+				changedPagesMu.Lock()
+				failedPageIDs[pageID] = struct{}{}
+				changedPagesMu.Unlock()
+				if opts.Progress != nil {
+					opts.Progress.Add(1)
+				}
+				return nil
+			}
+
 			if contentStatusMode == tenantContentStatusModeDisabled {
 				existingFM, ok := readExistingFrontmatter(pageID)
 				if ok && existingFM.Status != "" {
@@ -286,13 +474,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 					if ok && existingFM.Status != "" {
 						page.ContentStatus = existingFM.Status
 					}
-					diagMu.Lock()
-					diagnostics = append(diagnostics, PullDiagnostic{
-						Path:    pageID,
-						Code:    "CONTENT_STATUS_FETCH_FAILED",
-						Message: fmt.Sprintf("fetch content status for page %s: %v", pageID, err),
-					})
-					diagMu.Unlock()
+					addFetchDiagnostic(pageID, "CONTENT_STATUS_FETCH_FAILED", fmt.Sprintf("fetch content status for page %s: %v", pageID, err))
 				} else {
 					page.ContentStatus = status
 				}
@@ -304,26 +486,35 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 				if ok && len(existingFM.Labels) > 0 {
 					page.Labels = existingFM.Labels
 				}
-				diagMu.Lock()
-				diagnostics = append(diagnostics, PullDiagnostic{
-					Path:    pageID,
-					Code:    "LABELS_FETCH_FAILED",
-					Message: fmt.Sprintf("fetch labels for page %s: %v", pageID, err),
-				})
-				diagMu.Unlock()
+				addFetchDiagnostic(pageID, "LABELS_FETCH_FAILED", fmt.Sprintf("fetch labels for page %s: %v", pageID, err))
 			} else {
 				page.Labels = labels
 			}
 
-			changedPagesMu.Lock()
-			changedPages[pageID] = page
-			if page.Version > maxVersion {
-				maxVersion = page.Version
+			restrictions, err := remote.GetRestrictions(gCtx, pageID)
+			if err != nil {
+				existingFM, ok := readExistingFrontmatter(pageID)
+				if ok && existingFM.Restrictions != nil {
+					page.Restrictions = confluence.PageRestrictions{Read: existingFM.Restrictions.Read, Update: existingFM.Restrictions.Update}
+				}
+				addFetchDiagnostic(pageID, "RESTRICTIONS_FETCH_FAILED", fmt.Sprintf("fetch restrictions for page %s: %v", pageID, err))
+			} else {
+				page.Restrictions = restrictions
 			}
-			if page.LastModified.After(maxRemoteModified) {
-				maxRemoteModified = page.LastModified
+
+			// Properties stay nil unless fetched, so the write below knows to
+			// keep the local frontmatter values and their hashes.
+			existingFM, _ := readExistingFrontmatter(pageID)
+			localProperties, _ := existingFM.Properties()
+			properties, err := FetchPullProperties(gCtx, remote, pageID, opts.PropertyKeys, localProperties)
+			if err != nil {
+				addFetchDiagnostic(pageID, "PROPERTIES_FETCH_FAILED", fmt.Sprintf("fetch content properties for page %s: %v", pageID, err))
+			} else {
+				page.Properties = properties
 			}
-			changedPagesMu.Unlock()
+
+			keepChangedPage(page)
+			progress.recordPage(page)
 
 			if opts.Progress != nil {
 				opts.Progress.Add(1)
@@ -334,6 +525,20 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	if err := g.Wait(); err != nil {
 		return PullResult{}, err
 	}
+	for _, pageID := range changedPageIDs {
+		diagnostics = append(diagnostics, fetchDiagnosticsByPageID[pageID]...)
+	}
+	// A page that could not be fetched stays where it was tracked, or stays
+	// untracked, so no other step moves or indexes it.
+	for pageID := range failedPageIDs {
+		if relPath, tracked := trackedPathForPageID(state.PagePathIndex, pageID); tracked {
+			pagePathByIDRel[pageID] = relPath
+			pagePathByIDAbs[pageID] = filepath.Join(spaceDir, filepath.FromSlash(relPath))
+			continue
+		}
+		delete(pagePathByIDRel, pageID)
+		delete(pagePathByIDAbs, pageID)
+	}
 
 	attachmentIndex := cloneStringMap(state.AttachmentIndex)
 	// Build reverse index for O(1) lookups during planning
@@ -347,9 +552,22 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	attachmentPageByID := map[string]string{}
 	staleAttachmentPaths := map[string]struct{}{}
 
-	deletedPageIDs := deletedPageIDs(state.PagePathIndex, pageByID)
+	// Attachments are tracked under the directory of the previous pull and move
+	// to the planned one when a page moves or the asset layout changes.
+	previousPathByID := make(map[string]string, len(state.PagePathIndex))
+	for relPath, pageID := range state.PagePathIndex {
+		previousPathByID[strings.TrimSpace(pageID)] = normalizeRelPath(relPath)
+	}
+	previousAssetDir := func(pageID string) string {
+		return PageAssetDir(previousAssetLayout, pageID, previousPathByID[pageID])
+	}
+	plannedAssetDir := func(pageID string) string {
+		return PageAssetDir(opts.AssetLayout, pageID, pagePathByIDRel[pageID])
+	}
+
+	deletedPageIDs := dropOutOfScopePageIDs(deletedPageIDs(state.PagePathIndex, pageByID), outOfScopePages)
 	for _, pageID := range deletedPageIDs {
-		for _, removedPath := range removeAttachmentsForPage(attachmentIndex, pageID) {
+		for _, removedPath := range removeAttachmentsForPage(attachmentIndex, previousAssetDir(pageID)) {
 			staleAttachmentPaths[removedPath] = struct{}{}
 			// Sync reverse index
 			for id, p := range pathByAttachmentID {
@@ -384,7 +602,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 			refs, _ = resolveAttachmentRefsByRemoteMetadata(refs, remoteAttachments)
 		}
 
-		refs, resolvedUnknownCount, unresolvedUnknownCount, resolveErr := resolveUnknownAttachmentRefsByFilename(refs, attachmentIndex, remoteAttachments)
+		refs, resolvedUnknownCount, unresolvedUnknownCount, resolveErr := resolveUnknownAttachmentRefsByFilename(refs, attachmentIndex, remoteAttachments, previousAssetDir(page.ID))
 		if resolveErr == nil && listAttachmentsErr != nil && hasUnknownMediaRefs {
 			resolveErr = listAttachmentsErr
 		}
@@ -404,8 +622,18 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 			})
 		}
 
+		if listAttachmentsErr == nil && unresolvedUnknownCount == 0 {
+			if unreferenced := unreferencedRemoteAttachments(refs, remoteAttachments); len(unreferenced) > 0 {
+				diagnostics = append(diagnostics, PullDiagnostic{
+					Path:    page.ID,
+					Code:    "ATTACHMENT_NOT_REFERENCED",
+					Message: fmt.Sprintf("page %s has %d attachment(s) not referenced by its body (%s); they were not downloaded", page.ID, len(unreferenced), strings.Join(unreferenced, ", ")),
+				})
+			}
+		}
+
 		if unresolvedUnknownCount == 0 {
-			for _, removedPath := range removeStaleAttachmentsForPage(attachmentIndex, page.ID, refs) {
+			for _, removedPath := range removeStaleAttachmentsForPage(attachmentIndex, refs, previousAssetDir(page.ID), plannedAssetDir(page.ID)) {
 				staleAttachmentPaths[removedPath] = struct{}{}
 				// Sync reverse index
 				for id, p := range pathByAttachmentID {
@@ -434,7 +662,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 			if isUnknownMediaID(ref.AttachmentID) {
 				continue
 			}
-			relAssetPath := buildAttachmentPath(ref)
+			relAssetPath := buildAttachmentPath(ref, plannedAssetDir(ref.PageID))
 
 			// Optimized: check if this attachment ID was already at a different path
 			if existingPath, found := pathByAttachmentID[ref.AttachmentID]; found && existingPath != relAssetPath {
@@ -466,91 +694,103 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		}
 	}
 
-	for _, attachmentID := range assetIDs {
-		assetPath := attachmentPathByID[attachmentID]
-		pageID := attachmentPageByID[attachmentID]
+	// Downloads run concurrently; results are kept per asset and collected in
+	// assetIDs order so DownloadedAssets and diagnostics stay deterministic.
+	// OnDownloadError may prompt, so calls to it are serialized.
+	downloaded := make([]bool, len(assetIDs))
+	resumedAssets := make([]bool, len(assetIDs))
+	skippedDiagnostics := make([]*PullDiagnostic, len(assetIDs))
+	var onDownloadErrorMu gosync.Mutex
+	assetGroup, assetCtx := errgroup.WithContext(ctx)
+	assetGroup.SetLimit(opts.concurrency())
+
+	for i, attachmentID := range assetIDs {
+		assetGroup.Go(func() error {
+			assetPath := attachmentPathByID[attachmentID]
+			pageID := attachmentPageByID[attachmentID]
 
-		if opts.Progress != nil {
-			opts.Progress.SetCurrentItem(filepath.Base(assetPath))
-		}
-
-		if err := os.MkdirAll(filepath.Dir(assetPath), 0o750); err != nil {
-			return PullResult{}, fmt.Errorf("prepare attachment directory %s: %w", assetPath, err)
-		}
+			if opts.Progress != nil {
+				opts.Progress.SetCurrentItem(filepath.Base(assetPath))
+			}
 
-		err := func() error {
-			var lastErr error
-			for retry := 0; retry < 3; retry++ {
-				if retry > 0 {
-					if err := contextSleep(ctx, time.Duration(retry)*time.Second); err != nil {
-						return err
-					}
-				}
+			if err := os.MkdirAll(filepath.Dir(assetPath), 0o750); err != nil {
+				return fmt.Errorf("prepare attachment directory %s: %w", assetPath, err)
+			}
 
-				tempFile, err := os.CreateTemp(filepath.Dir(assetPath), "asset-*")
-				if err != nil {
-					return fmt.Errorf("create temp attachment file %s: %w", assetPath, err)
+			// Attachments are only reused together with their page, so a page
+			// edited since the interrupted run downloads them again.
+			if _, resumedPage := resumedPageIDs[pageID]; resumedPage && progress.restoreAsset(attachmentID, pageID, assetPath) {
+				downloaded[i] = true
+				resumedAssets[i] = true
+				if opts.Progress != nil {
+					opts.Progress.Add(1)
 				}
-				tempName := tempFile.Name()
-
-				downloadErr := remote.DownloadAttachment(ctx, attachmentID, pageID, tempFile)
-				closeErr := tempFile.Close()
+				return nil
+			}
 
-				if downloadErr == nil && closeErr == nil {
-					if err := os.Rename(tempName, assetPath); err != nil { //nolint:gosec // Path is controlled by application
-						_ = os.Remove(tempName) //nolint:gosec // Path is controlled by application
-						return fmt.Errorf("rename attachment file %s: %w", assetPath, err)
-					}
-					return nil
+			if err := downloadPullAttachment(assetCtx, remote, attachmentID, pageID, assetPath); err != nil {
+				// Clean up partially downloaded file
+				_ = os.Remove(assetPath)
+				if assetCtx.Err() != nil {
+					return err
 				}
-				_ = os.Remove(tempName) //nolint:gosec // Path is controlled by application
 
-				if downloadErr == nil && closeErr != nil {
-					return fmt.Errorf("close temp attachment file %s: %w", assetPath, closeErr)
+				skip := false
+				if errors.Is(err, confluence.ErrNotFound) && opts.SkipMissingAssets {
+					skip = true
+				} else if opts.OnDownloadError != nil {
+					onDownloadErrorMu.Lock()
+					skip = opts.OnDownloadError(attachmentID, pageID, err)
+					onDownloadErrorMu.Unlock()
 				}
-
-				lastErr = downloadErr
-				if errors.Is(downloadErr, confluence.ErrNotFound) {
-					break // No point in retrying 404
+				if !skip {
+					return fmt.Errorf("download attachment %s (page %s): %w", attachmentID, pageID, err)
 				}
-			}
-			return lastErr
-		}()
-
-		if err != nil {
-			// Clean up partially downloaded file
-			_ = os.Remove(assetPath)
 
-			skip := false
-			if errors.Is(err, confluence.ErrNotFound) && opts.SkipMissingAssets {
-				skip = true
-			} else if opts.OnDownloadError != nil && opts.OnDownloadError(attachmentID, pageID, err) {
-				skip = true
-			}
-
-			if skip {
-				diagnostics = append(diagnostics, PullDiagnostic{
+				skippedDiagnostics[i] = &PullDiagnostic{
 					Path:    attachmentID,
 					Code:    "ATTACHMENT_DOWNLOAD_SKIPPED",
 					Message: fmt.Sprintf("download attachment %s (page %s) failed, skipping: %v", attachmentID, pageID, err),
-				})
-				if opts.Progress != nil {
-					opts.Progress.Add(1)
 				}
-				continue
+			} else {
+				downloaded[i] = true
+				progress.recordAsset(attachmentID, pageID, assetPath)
 			}
-			return PullResult{}, fmt.Errorf("download attachment %s (page %s): %w", attachmentID, pageID, err)
-		}
 
+			if opts.Progress != nil {
+				opts.Progress.Add(1)
+			}
+			return nil
+		})
+	}
+	if err := assetGroup.Wait(); err != nil {
+		return PullResult{}, err
+	}
+	resumedAssetCount := 0
+	for i, attachmentID := range assetIDs {
+		if resumedAssets[i] {
+			resumedAssetCount++
+		}
+		if skippedDiagnostics[i] != nil {
+			diagnostics = append(diagnostics, *skippedDiagnostics[i])
+		}
+		if !downloaded[i] {
+			continue
+		}
+		assetPath := attachmentPathByID[attachmentID]
 		relAssetPath, relErr := filepath.Rel(spaceDir, assetPath)
 		if relErr != nil {
 			relAssetPath = assetPath
 		}
 		downloadedAssets = append(downloadedAssets, filepath.ToSlash(relAssetPath))
+	}
 
-		if opts.Progress != nil {
-			opts.Progress.Add(1)
-		}
+	if len(resumedPageIDs) > 0 {
+		diagnostics = append(diagnostics, PullDiagnostic{
+			Path:    opts.SpaceKey,
+			Code:    "PULL_RESUMED",
+			Message: fmt.Sprintf("resumed an interrupted pull: reused %d page(s) and %d attachment(s) fetched by the previous run", len(resumedPageIDs), resumedAssetCount),
+		})
 	}
 
 	if opts.Progress != nil {
@@ -558,6 +798,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}
 
 	updatedMarkdown := make([]string, 0, len(changedPages))
+	fetchedPropertyHashes := map[string]map[string]string{}
 	changedPageIDsSorted := sortedStringKeys(changedPages)
 
 	if opts.Progress != nil {
@@ -568,12 +809,31 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		}
 	}
 
+	mediaHook := func(outputPath string) adfconv.MediaRenderHook {
+		if opts.AssetLayout == fs.AssetLayoutFlatten {
+			return NewForwardMediaHookWithOwners(outputPath, forwardAttachmentPathByID, attachmentPageByID)
+		}
+		return NewForwardMediaHook(outputPath, forwardAttachmentPathByID)
+	}
+
+	commentsMirrored := map[string]struct{}{}
 	for _, pageID := range changedPageIDsSorted {
 		page := changedPages[pageID]
 		outputPath, ok := pagePathByIDAbs[page.ID]
 		if !ok {
 			return PullResult{}, fmt.Errorf("planned path missing for page %s", page.ID)
 		}
+		if relPath, held := heldPages[page.ID]; held {
+			diagnostics = append(diagnostics, PullDiagnostic{
+				Path:    relPath,
+				Code:    "SYNC_SKIPPED",
+				Message: fmt.Sprintf("cms_skip: true in frontmatter; kept the local copy instead of remote version %d", page.Version),
+			})
+			if opts.Progress != nil {
+				opts.Progress.Add(1)
+			}
+			continue
+		}
 		if previousRelPath, tracked := trackedPathForPageID(state.PagePathIndex, page.ID); tracked {
 			plannedRelPath := pagePathByIDRel[page.ID]
 			if sameRelPathDifferentCase(previousRelPath, plannedRelPath) {
@@ -589,7 +849,7 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		}
 
 		linkNotices := make([]ForwardLinkNotice, 0)
-		forward, err := converter.Forward(ctx, page.BodyADF, converter.ForwardConfig{
+		forward, err := ForwardPageBody(ctx, page, converter.ForwardConfig{
 			LinkHook: NewForwardLinkHookWithGlobalIndex(
 				outputPath,
 				spaceDir,
@@ -600,7 +860,9 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 					linkNotices = append(linkNotices, notice)
 				},
 			),
-			MediaHook: NewForwardMediaHook(outputPath, forwardAttachmentPathByID),
+			MediaHook:      mediaHook(outputPath),
+			StripLeadingH1: opts.StripTitleHeading,
+			Title:          page.Title,
 		}, outputPath)
 		if err != nil {
 			return PullResult{}, fmt.Errorf("convert page %s: %w", page.ID, err)
@@ -613,6 +875,10 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		if !page.LastModified.IsZero() {
 			lastModifiedDate = page.LastModified.Format(time.RFC3339)
 		}
+		var pageURL string
+		if opts.PageURL {
+			pageURL = strings.TrimSpace(page.WebURL)
+		}
 
 		doc := fs.MarkdownDocument{
 			Frontmatter: fs.Frontmatter{
@@ -626,10 +892,32 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 				CreatedAt: createdDate,
 				UpdatedBy: getUserDisplayName(ctx, page.LastModifiedAuthorID),
 				UpdatedAt: lastModifiedDate,
+				URL:       pageURL,
+
+				Restrictions: FrontmatterRestrictions(page.Restrictions),
 			},
 			Body: forward.Markdown,
 		}
 
+		existingFM, hasExistingFM := readExistingFrontmatter(page.ID)
+		if hasExistingFM {
+			// Parent pins are local intent the remote page does not record.
+			doc.Frontmatter.ParentID = existingFM.ParentID
+			doc.Frontmatter.ParentPath = existingFM.ParentPath
+		}
+		if page.Properties != nil {
+			properties, hashes, err := frontmatterProperties(page.Properties)
+			if err != nil {
+				return PullResult{}, fmt.Errorf("content properties of page %s: %w", page.ID, err)
+			}
+			doc.Frontmatter.SetProperties(properties)
+			fetchedPropertyHashes[page.ID] = hashes
+		} else if hasExistingFM {
+			if properties, err := existingFM.Properties(); err == nil {
+				doc.Frontmatter.SetProperties(properties)
+			}
+		}
+
 		if err := fs.WriteMarkdownDocument(outputPath, doc); err != nil {
 			return PullResult{}, fmt.Errorf("write page %s: %w", page.ID, err)
 		}
@@ -641,6 +929,41 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		relPath = filepath.ToSlash(relPath)
 		updatedMarkdown = append(updatedMarkdown, relPath)
 
+		// Restrictions are kept in frontmatter and re-applied on push; flag
+		// the page so nobody widens its access by editing that block.
+		if restrictions := doc.Frontmatter.Restrictions; restrictions != nil {
+			diagnostics = append(diagnostics, PullDiagnostic{
+				Path: relPath,
+				Code: "RESTRICTED_PAGE",
+				Message: fmt.Sprintf("page %q is restricted (read: %s; update: %s); its restrictions are kept in frontmatter and applied on push",
+					page.Title, describeRestrictionSubjects(restrictions.Read), describeRestrictionSubjects(restrictions.Update)),
+			})
+		}
+
+		if opts.Comments {
+			commentsMirrored[page.ID] = struct{}{}
+			if commentRemote, ok := remote.(footerCommentRemote); ok {
+				if err := writePageCommentsSidecar(ctx, commentRemote, page, outputPath, getUserDisplayName); err != nil {
+					diagnostics = append(diagnostics, PullDiagnostic{
+						Path:    relPath,
+						Code:    "COMMENTS_FETCH_FAILED",
+						Message: fmt.Sprintf("mirror footer comments of page %s: %v", page.ID, err),
+					})
+				}
+			}
+		}
+		if opts.HistoryLimit > 0 {
+			if versionRemote, ok := remote.(pageVersionRemote); ok {
+				if err := writePageHistorySidecar(ctx, versionRemote, page, outputPath, opts.HistoryLimit, getUserDisplayName); err != nil {
+					diagnostics = append(diagnostics, PullDiagnostic{
+						Path:    relPath,
+						Code:    "HISTORY_FETCH_FAILED",
+						Message: fmt.Sprintf("mirror version history of page %s: %v", page.ID, err),
+					})
+				}
+			}
+		}
+
 		for _, notice := range linkNotices {
 			diagnostics = append(diagnostics, PullDiagnostic{
 				Path:    relPath,
@@ -655,6 +978,9 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 				Message: warning.Message,
 			})
 		}
+		if diag := collectMacroPassthroughDiagnostic(page.BodyADF, relPath); diag != nil {
+			diagnostics = append(diagnostics, *diag)
+		}
 
 		if opts.Progress != nil {
 			opts.Progress.Add(1)
@@ -666,6 +992,39 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		opts.Progress.Done()
 	}
 
+	if opts.Comments {
+		if commentRemote, ok := remote.(footerCommentRemote); ok {
+			for _, pageID := range pageIDs {
+				if _, done := commentsMirrored[pageID]; done {
+					continue
+				}
+				if _, skip := outOfScopePages[pageID]; skip {
+					continue
+				}
+				if target := strings.TrimSpace(opts.TargetPageID); target != "" && target != pageID {
+					continue
+				}
+				// Only pages with a local file get a sidecar; the rest are
+				// written, and mirrored, by the pull that fetches them.
+				absPath, planned := pagePathByIDAbs[pageID]
+				if !planned {
+					continue
+				}
+				if _, err := os.Stat(absPath); err != nil {
+					continue
+				}
+				relPath := pagePathByIDRel[pageID]
+				if err := writePageCommentsSidecar(ctx, commentRemote, pageByID[pageID], absPath, getUserDisplayName); err != nil {
+					diagnostics = append(diagnostics, PullDiagnostic{
+						Path:    relPath,
+						Code:    "COMMENTS_FETCH_FAILED",
+						Message: fmt.Sprintf("mirror footer comments of page %s: %v", pageID, err),
+					})
+				}
+			}
+		}
+	}
+
 	deletedMarkdownSet := map[string]struct{}{}
 	for oldPath, pageID := range state.PagePathIndex {
 		newPath, exists := pagePathByIDRel[pageID]
@@ -687,6 +1046,11 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
 			return PullResult{}, fmt.Errorf("delete markdown %s: %w", relPath, err)
 		}
+		for _, sidecarPath := range fs.PageSidecarPaths(absPath) {
+			if err := os.Remove(sidecarPath); err != nil && !os.IsNotExist(err) {
+				return PullResult{}, fmt.Errorf("delete sidecar of %s: %w", relPath, err)
+			}
+		}
 		_ = removeEmptyParentDirs(filepath.Dir(absPath), spaceDir)
 	}
 
@@ -700,9 +1064,9 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 		if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
 			return PullResult{}, fmt.Errorf("delete attachment %s: %w", relPath, err)
 		}
-		_ = removeEmptyParentDirs(filepath.Dir(absPath), assetsRoot)
+		_ = removeEmptyAssetDirs(spaceDir, absPath)
 	}
-	orphanPageAssets, err := removeAssetDirsForMissingPages(spaceDir, assetsRoot, pageByID)
+	orphanPageAssets, err := removeAssetDirsForMissingPages(spaceDir, assetsRoot, pageByID, outOfScopePages)
 	if err != nil {
 		return PullResult{}, fmt.Errorf("delete orphan asset directories: %w", err)
 	}
@@ -717,14 +1081,22 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	state.PagePathIndex = invertPathByID(pagePathByIDRel)
 	state.AttachmentIndex = attachmentIndex
 
-	folderPathIndex := buildFolderPathIndex(folderByID, pageByID)
+	folderPathIndex := buildFolderPathIndex(folderByID, pageByID, opts.pagePathLayout())
 	state.FolderPathIndex = folderPathIndex
-
-	highWatermark := pullStartedAt.UTC()
-	if maxRemoteModified.After(highWatermark) {
-		highWatermark = maxRemoteModified.UTC()
+	state.PageETags = updatedPageETags(state.PageETags, pageByID, changedPages)
+	state.PagePropertyHashes = updatedPagePropertyHashes(state.PagePropertyHashes, pageByID, fetchedPropertyHashes)
+	state.AssetLayout = opts.AssetLayout
+
+	// A truncated listing has not seen the whole space yet, so the previous
+	// watermark is kept and the next run still treats unseen pages as changed.
+	if !listingTruncated {
+		highWatermark := pullStartedAt.UTC()
+		if maxRemoteModified.After(highWatermark) {
+			highWatermark = maxRemoteModified.UTC()
+		}
+		state.LastPullHighWatermark = highWatermark.Format(time.RFC3339)
 	}
-	state.LastPullHighWatermark = highWatermark.Format(time.RFC3339)
+	progress.remove()
 
 	return PullResult{
 		State:              state,
@@ -738,7 +1110,26 @@ func Pull(ctx context.Context, remote PullRemote, opts PullOptions) (PullResult,
 	}, nil
 }
 
-func removeAssetDirsForMissingPages(spaceDir, assetsRoot string, pageByID map[string]confluence.Page) ([]string, error) {
+// updatedPageETags keeps the ETags of pages still in the space and replaces
+// those of pages fetched by this pull.
+func updatedPageETags(previous map[string]string, pageByID map[string]confluence.Page, fetched map[string]confluence.Page) map[string]string {
+	etags := make(map[string]string, len(pageByID))
+	for pageID, etag := range previous {
+		if _, ok := pageByID[pageID]; ok && strings.TrimSpace(etag) != "" {
+			etags[pageID] = etag
+		}
+	}
+	for pageID, page := range fetched {
+		if etag := strings.TrimSpace(page.ETag); etag != "" {
+			etags[pageID] = etag
+		} else {
+			delete(etags, pageID)
+		}
+	}
+	return etags
+}
+
+func removeAssetDirsForMissingPages(spaceDir, assetsRoot string, pageByID map[string]confluence.Page, keptPageIDs map[string]struct{}) ([]string, error) {
 	if _, err := os.Stat(assetsRoot); os.IsNotExist(err) {
 		return nil, nil
 	}
@@ -760,6 +1151,9 @@ func removeAssetDirsForMissingPages(spaceDir, assetsRoot string, pageByID map[st
 		if _, exists := pageByID[pageID]; exists {
 			continue
 		}
+		if _, kept := keptPageIDs[pageID]; kept {
+			continue
+		}
 
 		pageDir := filepath.Join(assetsRoot, entry.Name())
 		_ = filepath.WalkDir(pageDir, func(path string, d os.DirEntry, walkErr error) error {
@@ -822,6 +1216,17 @@ func removeUntrackedAssetFiles(spaceDir, assetsRoot string, attachmentIndex map[
 	return deleted, nil
 }
 
+// removeEmptyAssetDirs removes the directories a deleted attachment leaves
+// empty, up to assets/ or, for flattened page asset directories, up to the
+// space directory.
+func removeEmptyAssetDirs(spaceDir, assetAbsPath string) error {
+	assetsRoot := filepath.Join(spaceDir, "assets")
+	if isSubpathOrSame(assetsRoot, assetAbsPath) {
+		return removeEmptyParentDirs(filepath.Dir(assetAbsPath), assetsRoot)
+	}
+	return removeEmptyParentDirs(filepath.Dir(assetAbsPath), spaceDir)
+}
+
 func removeEmptyParentDirs(startDir, stopDir string) error {
 	startDir = filepath.Clean(startDir)
 	stopDir = filepath.Clean(stopDir)
@@ -929,3 +1334,45 @@ func contextSleep(ctx context.Context, d time.Duration) error {
 		return ctx.Err()
 	}
 }
+
+// downloadPullAttachment downloads one attachment to assetPath through a
+// temporary file in the same directory, retrying transient failures up to
+// three times. A missing attachment is not retried.
+func downloadPullAttachment(ctx context.Context, remote PullRemote, attachmentID, pageID, assetPath string) error {
+	var lastErr error
+	for retry := 0; retry < 3; retry++ {
+		if retry > 0 {
+			if err := contextSleep(ctx, time.Duration(retry)*time.Second); err != nil {
+				return err
+			}
+		}
+
+		tempFile, err := os.CreateTemp(filepath.Dir(assetPath), "asset-*")
+		if err != nil {
+			return fmt.Errorf("create temp attachment file %s: %w", assetPath, err)
+		}
+		tempName := tempFile.Name()
+
+		downloadErr := remote.DownloadAttachment(ctx, attachmentID, pageID, tempFile)
+		closeErr := tempFile.Close()
+
+		if downloadErr == nil && closeErr == nil {
+			if err := os.Rename(tempName, assetPath); err != nil { //nolint:gosec // Path is controlled by application
+				_ = os.Remove(tempName) //nolint:gosec // Path is controlled by application
+				return fmt.Errorf("rename attachment file %s: %w", assetPath, err)
+			}
+			return nil
+		}
+		_ = os.Remove(tempName) //nolint:gosec // Path is controlled by application
+
+		if downloadErr == nil && closeErr != nil {
+			return fmt.Errorf("close temp attachment file %s: %w", assetPath, closeErr)
+		}
+
+		lastErr = downloadErr
+		if errors.Is(downloadErr, confluence.ErrNotFound) {
+			break // No point in retrying 404
+		}
+	}
+	return lastErr
+}
@@ -11,10 +11,29 @@ import (
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
-func removeAttachmentsForPage(attachmentIndex map[string]string, pageID string) []string {
+// PageAssetDir returns the space-relative directory holding a page's
+// attachments: assets/<page-id> by default, or the page's Markdown path
+// without its extension (Guides/api for Guides/api.md) under
+// fs.AssetLayoutFlatten. Pages without a known path, and a top-level page
+// whose directory would be assets/ itself, use the default layout.
+func PageAssetDir(layout, pageID, pageRelPath string) string {
+	if layout == fs.AssetLayoutFlatten {
+		relPath := normalizeRelPath(pageRelPath)
+		if dir := strings.TrimSuffix(relPath, filepath.Ext(relPath)); dir != "" && !strings.EqualFold(dir, "assets") {
+			return dir
+		}
+	}
+	pageID = fs.SanitizePathSegment(strings.TrimSpace(pageID))
+	if pageID == "" {
+		pageID = "unknown-page"
+	}
+	return "assets/" + pageID
+}
+
+func removeAttachmentsForPage(attachmentIndex map[string]string, assetDirs ...string) []string {
 	removed := []string{}
 	for relPath := range attachmentIndex {
-		if !attachmentBelongsToPage(relPath, pageID) {
+		if !attachmentBelongsToPage(relPath, assetDirs...) {
 			continue
 		}
 		removed = append(removed, normalizeRelPath(relPath))
@@ -26,12 +45,12 @@ func removeAttachmentsForPage(attachmentIndex map[string]string, pageID string)
 
 func removeStaleAttachmentsForPage(
 	attachmentIndex map[string]string,
-	pageID string,
 	currentRefs map[string]attachmentRef,
+	assetDirs ...string,
 ) []string {
 	removed := []string{}
 	for relPath, attachmentID := range attachmentIndex {
-		if !attachmentBelongsToPage(relPath, pageID) {
+		if !attachmentBelongsToPage(relPath, assetDirs...) {
 			continue
 		}
 		if _, keep := currentRefs[attachmentID]; keep {
@@ -44,16 +63,17 @@ func removeStaleAttachmentsForPage(
 	return removed
 }
 
-func attachmentBelongsToPage(relPath, pageID string) bool {
+// attachmentBelongsToPage reports whether an attachment path lies inside one
+// of a page's asset directories (see PageAssetDir).
+func attachmentBelongsToPage(relPath string, assetDirs ...string) bool {
 	relPath = normalizeRelPath(relPath)
-	parts := strings.Split(relPath, "/")
-	if len(parts) < 3 {
-		return false
-	}
-	if parts[0] != "assets" {
-		return false
+	for _, assetDir := range assetDirs {
+		assetDir = normalizeRelPath(assetDir)
+		if assetDir != "" && strings.HasPrefix(relPath, assetDir+"/") {
+			return true
+		}
 	}
-	return parts[1] == pageID
+	return false
 }
 
 func collectAttachmentRefs(adfJSON []byte, defaultPageID string) (map[string]attachmentRef, *PullDiagnostic) {
@@ -216,10 +236,45 @@ func resolveAttachmentRefsByRemoteMetadata(
 	return refs, resolved
 }
 
+// unreferencedRemoteAttachments returns the sorted filenames of remote
+// attachments that no ref points at by attachment ID or file ID, such as
+// files only reachable through macros.
+func unreferencedRemoteAttachments(refs map[string]attachmentRef, remoteAttachments []confluence.Attachment) []string {
+	referenced := map[string]struct{}{}
+	for key, ref := range refs {
+		referenced[strings.TrimSpace(key)] = struct{}{}
+		referenced[strings.TrimSpace(ref.AttachmentID)] = struct{}{}
+	}
+
+	filenames := make([]string, 0)
+	for _, attachment := range remoteAttachments {
+		id := strings.TrimSpace(attachment.ID)
+		if id == "" {
+			continue
+		}
+		if _, ok := referenced[id]; ok {
+			continue
+		}
+		if fileID := strings.TrimSpace(attachment.FileID); fileID != "" {
+			if _, ok := referenced[fileID]; ok {
+				continue
+			}
+		}
+		filename := strings.TrimSpace(attachment.Filename)
+		if filename == "" {
+			filename = id
+		}
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
 func resolveUnknownAttachmentRefsByFilename(
 	refs map[string]attachmentRef,
 	attachmentIndex map[string]string,
 	remoteAttachments []confluence.Attachment,
+	assetDir string,
 ) (map[string]attachmentRef, int, int, error) {
 	if len(refs) == 0 {
 		return refs, 0, 0, nil
@@ -228,15 +283,7 @@ func resolveUnknownAttachmentRefsByFilename(
 	resolved := 0
 	refs = cloneAttachmentRefs(refs)
 
-	defaultPageID := ""
-	for _, ref := range refs {
-		defaultPageID = strings.TrimSpace(ref.PageID)
-		if defaultPageID != "" {
-			break
-		}
-	}
-
-	localFilenameIndex := buildLocalAttachmentFilenameIndex(attachmentIndex, defaultPageID)
+	localFilenameIndex := buildLocalAttachmentFilenameIndex(attachmentIndex, assetDir)
 	unresolvedKeys := make([]string, 0)
 	for _, key := range sortedStringKeys(refs) {
 		ref := refs[key]
@@ -291,15 +338,14 @@ func cloneAttachmentRefs(refs map[string]attachmentRef) map[string]attachmentRef
 	return out
 }
 
-func buildLocalAttachmentFilenameIndex(attachmentIndex map[string]string, pageID string) map[string][]string {
-	pageID = strings.TrimSpace(pageID)
+func buildLocalAttachmentFilenameIndex(attachmentIndex map[string]string, assetDir string) map[string][]string {
 	byFilename := map[string][]string{}
 
 	for relPath, attachmentID := range attachmentIndex {
 		if strings.TrimSpace(attachmentID) == "" {
 			continue
 		}
-		if pageID != "" && !attachmentBelongsToPage(relPath, pageID) {
+		if assetDir != "" && !attachmentBelongsToPage(relPath, assetDir) {
 			continue
 		}
 
@@ -390,17 +436,13 @@ func appendUniqueString(values []string, candidate string) []string {
 	return append(values, candidate)
 }
 
-func buildAttachmentPath(ref attachmentRef) string {
+func buildAttachmentPath(ref attachmentRef, assetDir string) string {
 	filename := filepath.Base(strings.TrimSpace(ref.Filename))
 	filename = fs.SanitizePathSegment(filename)
 	if filename == "" {
 		filename = "attachment"
 	}
-	pageID := fs.SanitizePathSegment(ref.PageID)
-	if pageID == "" {
-		pageID = "unknown-page"
-	}
 
 	name := fs.SanitizePathSegment(ref.AttachmentID) + "-" + filename
-	return filepath.ToSlash(filepath.Join("assets", pageID, name))
+	return filepath.ToSlash(filepath.Join(filepath.FromSlash(assetDir), name))
 }
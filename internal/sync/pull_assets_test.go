@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
 func TestPull_SkipsMissingAssets(t *testing.T) {
@@ -148,6 +149,61 @@ func TestPull_ResolvesUnknownMediaIDByFilename(t *testing.T) {
 	}
 }
 
+func TestPull_WarnsAboutRemoteAttachmentsNotReferencedByBody(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Page 1"}},
+		pagesByID: map[string]confluence.Page{
+			"1": {
+				ID:      "1",
+				Title:   "Page 1",
+				BodyADF: rawJSON(t, sampleRootADF()),
+			},
+		},
+		attachments: map[string][]byte{
+			"att-1": []byte("asset-bytes"),
+		},
+		attachmentsByPage: map[string][]confluence.Attachment{
+			"1": {
+				{ID: "att-1", PageID: "1", Filename: "diagram.png"},
+				{ID: "att-macro", PageID: "1", Filename: "report.xlsx"},
+			},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+	})
+	if err != nil {
+		t.Fatalf("Pull() unexpected error: %v", err)
+	}
+
+	var found *PullDiagnostic
+	for i := range result.Diagnostics {
+		if result.Diagnostics[i].Code == "ATTACHMENT_NOT_REFERENCED" {
+			found = &result.Diagnostics[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected ATTACHMENT_NOT_REFERENCED diagnostic, got %+v", result.Diagnostics)
+	}
+	if !strings.Contains(found.Message, "report.xlsx") || strings.Contains(found.Message, "diagram.png") {
+		t.Fatalf("diagnostic message = %q, want only report.xlsx listed", found.Message)
+	}
+	for path, attachmentID := range result.State.AttachmentIndex {
+		if attachmentID == "att-macro" {
+			t.Fatalf("unreferenced attachment should not be tracked, got %s", path)
+		}
+	}
+}
+
 func TestPull_PrefersAttachmentIDMetadataForDownloadedAssetPaths(t *testing.T) {
 	tmpDir := t.TempDir()
 	spaceDir := filepath.Join(tmpDir, "ENG")
@@ -353,3 +409,79 @@ func TestPull_ResolvesFileIDOnlyMediaNodesToLocalMarkdownAssets(t *testing.T) {
 		t.Fatalf("file attachment index = %q, want att-doc", got)
 	}
 }
+
+func TestPageAssetDir(t *testing.T) {
+	cases := []struct {
+		layout, pageID, relPath, want string
+	}{
+		{"", "42", "Guides/Setup.md", "assets/42"},
+		{fs.AssetLayoutFlatten, "42", "Guides/Setup.md", "Guides/Setup"},
+		{fs.AssetLayoutFlatten, "42", "", "assets/42"},
+		{fs.AssetLayoutFlatten, "42", "assets.md", "assets/42"},
+	}
+	for _, tc := range cases {
+		if got := PageAssetDir(tc.layout, tc.pageID, tc.relPath); got != tc.want {
+			t.Errorf("PageAssetDir(%q, %q, %q) = %q, want %q", tc.layout, tc.pageID, tc.relPath, got, tc.want)
+		}
+	}
+}
+
+func TestPull_FlattenAssetsMovesAttachmentsNextToPage(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	legacyAssetPath := filepath.Join(spaceDir, "assets", "1", "att-1-diagram.png")
+	if err := os.MkdirAll(filepath.Dir(legacyAssetPath), 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	if err := os.WriteFile(legacyAssetPath, []byte("diagram-bytes"), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, BodyADF: rawJSON(t, sampleRootADF())},
+		},
+		attachments: map[string][]byte{"att-1": []byte("diagram-bytes")},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State: fs.SpaceState{
+			PagePathIndex:   map[string]string{"Root.md": "1"},
+			AttachmentIndex: map[string]string{"assets/1/att-1-diagram.png": "att-1"},
+		},
+		AssetLayout: fs.AssetLayoutFlatten,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	if result.State.AssetLayout != fs.AssetLayoutFlatten {
+		t.Fatalf("state asset layout = %q, want %q", result.State.AssetLayout, fs.AssetLayoutFlatten)
+	}
+	if got := result.State.AttachmentIndex["Root/att-1-diagram.png"]; got != "att-1" {
+		t.Fatalf("flattened attachment index = %q, want att-1 (index: %v)", got, result.State.AttachmentIndex)
+	}
+	if _, exists := result.State.AttachmentIndex["assets/1/att-1-diagram.png"]; exists {
+		t.Fatalf("old assets/ index entry should be removed: %v", result.State.AttachmentIndex)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Root", "att-1-diagram.png")); err != nil {
+		t.Fatalf("flattened asset should exist: %v", err)
+	}
+	if _, err := os.Stat(legacyAssetPath); !os.IsNotExist(err) {
+		t.Fatalf("old assets/ file should be removed, stat error=%v", err)
+	}
+
+	rootDoc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Root.md"))
+	if err != nil {
+		t.Fatalf("read Root.md: %v", err)
+	}
+	if !strings.Contains(rootDoc.Body, "![Diagram](Root/att-1-diagram.png)") {
+		t.Fatalf("expected flattened media link in root body, got:\n%s", rootDoc.Body)
+	}
+}
@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// footerCommentRemote is implemented by remotes that can list page footer
+// comments, such as *confluence.Client.
+type footerCommentRemote interface {
+	ListFooterComments(ctx context.Context, pageID string) ([]confluence.Comment, error)
+}
+
+// writePageCommentsSidecar mirrors the footer comments of page into the
+// sidecar next to markdownPath. A page without comments has its sidecar
+// removed. The sidecar is never converted back, so comments cannot leak into
+// the page body on push.
+func writePageCommentsSidecar(
+	ctx context.Context,
+	remote footerCommentRemote,
+	page confluence.Page,
+	markdownPath string,
+	displayName func(ctx context.Context, accountID string) string,
+) error {
+	comments, err := remote.ListFooterComments(ctx, page.ID)
+	if err != nil {
+		return err
+	}
+
+	sidecarPath := fs.CommentsSidecarPath(markdownPath)
+	if len(comments) == 0 {
+		if err := os.Remove(sidecarPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var out strings.Builder
+	out.WriteString("<!-- Footer comments mirrored from Confluence by `conf pull`. This file is read-only: push ignores it. -->\n\n")
+	_, _ = fmt.Fprintf(&out, "# Comments on %s\n", page.Title)
+	for _, comment := range comments {
+		author := displayName(ctx, comment.AuthorID)
+		if author == "" {
+			author = "Unknown"
+		}
+		heading := author
+		if !comment.CreatedAt.IsZero() {
+			heading += " - " + comment.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(&out, "\n## %s\n\n", heading)
+
+		body, err := converter.Forward(ctx, comment.BodyADF, converter.ForwardConfig{}, sidecarPath)
+		if err != nil {
+			return fmt.Errorf("convert comment %s: %w", comment.ID, err)
+		}
+		out.WriteString(strings.TrimSpace(body.Markdown))
+		out.WriteString("\n")
+	}
+
+	return os.WriteFile(sidecarPath, []byte(out.String()), 0o644) //nolint:gosec // markdown files are intentionally group-readable
+}
@@ -2,7 +2,9 @@ package sync
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -74,3 +76,86 @@ func TestPull_ConcurrentPageDetails(t *testing.T) {
 		t.Logf("concurrency was %d, expected > 1 (maybe slow CI?)", highestConcurrency)
 	}
 }
+
+func TestPull_ConcurrentAttachmentDownloadsAreBoundedAndOrdered(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+
+	var activeDownloads int32
+	var maxDownloads int32
+
+	numPages := 8
+	pages := make([]confluence.Page, 0, numPages)
+	pagesByID := make(map[string]confluence.Page, numPages)
+	attachments := make(map[string][]byte, numPages)
+	for i := 1; i <= numPages; i++ {
+		id := fmt.Sprintf("%d", i)
+		attachmentID := "att-" + id
+		page := confluence.Page{
+			ID:      id,
+			SpaceID: "space-1",
+			Title:   "Page " + id,
+			BodyADF: rawJSON(t, map[string]any{
+				"version": 1,
+				"type":    "doc",
+				"content": []any{
+					map[string]any{
+						"type": "mediaSingle",
+						"content": []any{
+							map[string]any{
+								"type": "media",
+								"attrs": map[string]any{
+									"type":         "file",
+									"id":           attachmentID,
+									"attachmentId": attachmentID,
+									"pageId":       id,
+									"fileName":     "file.txt",
+								},
+							},
+						},
+					},
+				},
+			}),
+		}
+		pages = append(pages, page)
+		pagesByID[id] = page
+		attachments[attachmentID] = []byte("bytes-" + id)
+	}
+
+	fake := &fakePullRemote{
+		space:       confluence.Space{ID: "space-1", Key: "ENG"},
+		pages:       pages,
+		pagesByID:   pagesByID,
+		attachments: attachments,
+		downloadHook: func(string) {
+			current := atomic.AddInt32(&activeDownloads, 1)
+			for {
+				seen := atomic.LoadInt32(&maxDownloads)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxDownloads, seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&activeDownloads, -1)
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:    "ENG",
+		SpaceDir:    spaceDir,
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("Pull() unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&maxDownloads); got > 2 {
+		t.Errorf("max concurrent downloads = %d, want <= 2", got)
+	}
+	if len(result.DownloadedAssets) != numPages {
+		t.Fatalf("downloaded assets = %v, want %d", result.DownloadedAssets, numPages)
+	}
+	if !slices.IsSorted(result.DownloadedAssets) {
+		t.Errorf("downloaded assets are not in attachment order: %v", result.DownloadedAssets)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
 func TestPlanPagePaths_HierarchyWithSubpages(t *testing.T) {
@@ -16,7 +17,7 @@ func TestPlanPagePaths_HierarchyWithSubpages(t *testing.T) {
 		{ID: "4", Title: "Leaf"},
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	// Root has a child (Child), so it should be Root/Root.md
 	if got := relByID["1"]; got != "Root/Root.md" {
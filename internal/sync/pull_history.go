@@ -0,0 +1,71 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// pageVersionRemote is implemented by remotes that can list a page's version
+// history, such as *confluence.Client.
+type pageVersionRemote interface {
+	ListPageVersions(ctx context.Context, pageID string, limit int) ([]confluence.PageVersion, error)
+}
+
+// writePageHistorySidecar mirrors the latest limit versions of page into the
+// sidecar next to markdownPath as a compact table. Like the comments sidecar
+// it is read-only enrichment that push never converts back.
+func writePageHistorySidecar(
+	ctx context.Context,
+	remote pageVersionRemote,
+	page confluence.Page,
+	markdownPath string,
+	limit int,
+	displayName func(ctx context.Context, accountID string) string,
+) error {
+	versions, err := remote.ListPageVersions(ctx, page.ID, limit)
+	if err != nil {
+		return err
+	}
+
+	sidecarPath := fs.HistorySidecarPath(markdownPath)
+	if len(versions) == 0 {
+		if err := os.Remove(sidecarPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var out strings.Builder
+	out.WriteString("<!-- Version history mirrored from Confluence by `conf pull`. This file is read-only: push ignores it. -->\n\n")
+	_, _ = fmt.Fprintf(&out, "# History of %s\n\n", page.Title)
+	out.WriteString("| Version | Author | When | Comment |\n")
+	out.WriteString("| --- | --- | --- | --- |\n")
+	for _, version := range versions {
+		author := displayName(ctx, version.AuthorID)
+		if author == "" {
+			author = "Unknown"
+		}
+		when := ""
+		if !version.CreatedAt.IsZero() {
+			when = version.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		comment := version.Message
+		if version.MinorEdit {
+			comment = strings.TrimSpace(comment + " (minor edit)")
+		}
+		_, _ = fmt.Fprintf(&out, "| %d | %s | %s | %s |\n", version.Number, escapeHistoryCell(author), when, escapeHistoryCell(comment))
+	}
+
+	return os.WriteFile(sidecarPath, []byte(out.String()), 0o644) //nolint:gosec // markdown files are intentionally group-readable
+}
+
+func escapeHistoryCell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
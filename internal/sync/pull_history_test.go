@@ -0,0 +1,114 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+type historyFakePullRemote struct {
+	*fakePullRemote
+	versions map[string][]confluence.PageVersion
+	limits   []int
+}
+
+func (f *historyFakePullRemote) ListPageVersions(_ context.Context, pageID string, limit int) ([]confluence.PageVersion, error) {
+	f.limits = append(f.limits, limit)
+	versions := f.versions[pageID]
+	if limit > 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+	return versions, nil
+}
+
+func TestPull_WritesHistorySidecarWhenEnabled(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &historyFakePullRemote{
+		fakePullRemote: &fakePullRemote{
+			space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+			pages: []confluence.Page{
+				{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 3, LastModified: modifiedAt},
+			},
+			pagesByID: map[string]confluence.Page{
+				"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 3, LastModified: modifiedAt, BodyStorage: "<p>Plan body</p>"},
+			},
+		},
+		versions: map[string][]confluence.PageVersion{
+			"1": {
+				{Number: 3, AuthorID: "acc-1", CreatedAt: modifiedAt, Message: "Tighten | rollout", MinorEdit: true},
+				{Number: 2, AuthorID: "acc-2", CreatedAt: modifiedAt.Add(-time.Hour)},
+				{Number: 1, AuthorID: "acc-1", CreatedAt: modifiedAt.Add(-2 * time.Hour), Message: "Initial draft"},
+			},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:     "ENG",
+		SpaceDir:     spaceDir,
+		State:        fs.NewSpaceState(),
+		HistoryLimit: 2,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	if len(fake.limits) != 1 || fake.limits[0] != 2 {
+		t.Fatalf("ListPageVersions limits = %v, want [2]", fake.limits)
+	}
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Plan.history.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Plan.history.md: %v", err)
+	}
+	for _, want := range []string{
+		"# History of Plan",
+		"| 3 | User acc-1 | 2026-03-06T12:00:00Z | Tighten \\| rollout (minor edit) |",
+		"| 2 | User acc-2 | 2026-03-06T11:00:00Z |  |",
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("sidecar missing %q, got:\n%s", want, raw)
+		}
+	}
+	if strings.Contains(string(raw), "Initial draft") {
+		t.Fatalf("history should be capped at 2 versions, got:\n%s", raw)
+	}
+	for _, path := range result.UpdatedMarkdown {
+		if fs.IsPageSidecar(path) {
+			t.Fatalf("sidecar reported as updated page markdown: %v", result.UpdatedMarkdown)
+		}
+	}
+}
+
+func TestPull_SkipsHistoryByDefault(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	fake := &historyFakePullRemote{
+		fakePullRemote: &fakePullRemote{
+			space:     confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+			pages:     []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1}},
+			pagesByID: map[string]confluence.Page{"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, BodyStorage: "<p>Plan body</p>"}},
+		},
+	}
+	if _, err := Pull(context.Background(), fake, PullOptions{SpaceKey: "ENG", SpaceDir: spaceDir, State: fs.NewSpaceState()}); err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+	if len(fake.limits) != 0 {
+		t.Fatalf("history fetched without --with-history: %v", fake.limits)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Plan.history.md")); !os.IsNotExist(err) {
+		t.Fatalf("unexpected history sidecar, stat err = %v", err)
+	}
+}
@@ -615,3 +615,130 @@ func TestPull_PreservesAbsoluteCrossSpaceLinksWithoutUnresolvedWarnings(t *testi
 		t.Fatalf("expected CROSS_SPACE_LINK_PRESERVED diagnostic, got %+v", result.Diagnostics)
 	}
 }
+
+type conditionalFakePullRemote struct {
+	*fakePullRemote
+	conditionalCalls atomic.Int32
+}
+
+func (f *conditionalFakePullRemote) GetPageIfNoneMatch(ctx context.Context, pageID, etag string) (confluence.Page, error) {
+	f.conditionalCalls.Add(1)
+	if page, ok := f.pagesByID[pageID]; ok && page.ETag == etag {
+		return confluence.Page{}, confluence.ErrNotModified
+	}
+	return f.GetPage(ctx, pageID)
+}
+
+func TestPull_SkipsOverlapRefetchWhenETagIsNotModified(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	modifiedAt := time.Date(2026, time.March, 9, 10, 58, 0, 0, time.UTC)
+
+	doc := fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 3},
+		Body:        "local body\n",
+	}
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "Root.md"), doc); err != nil {
+		t.Fatalf("write Root.md: %v", err)
+	}
+
+	fake := &conditionalFakePullRemote{fakePullRemote: &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modifiedAt},
+			{ID: "2", SpaceID: "space-1", Title: "New", Version: 1, LastModified: modifiedAt},
+		},
+		changes: []confluence.Change{
+			{PageID: "1", SpaceKey: "ENG", Version: 3, LastModified: modifiedAt},
+		},
+		attachments: map[string][]byte{
+			"att-1": []byte("diagram-bytes"),
+			"att-2": []byte("inline-bytes"),
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modifiedAt, ETag: `"root-3"`, BodyADF: rawJSON(t, sampleRootADF())},
+			"2": {ID: "2", SpaceID: "space-1", Title: "New", Version: 1, LastModified: modifiedAt, ETag: `"new-1"`, BodyADF: rawJSON(t, sampleChildADF())},
+		},
+	}}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State: fs.SpaceState{
+			LastPullHighWatermark: "2026-03-09T11:00:00Z",
+			PagePathIndex:         map[string]string{"Root.md": "1"},
+			PageETags:             map[string]string{"1": `"root-3"`},
+		},
+		PullStartedAt: time.Date(2026, time.March, 9, 12, 0, 0, 0, time.UTC),
+		OverlapWindow: 5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	if got := fake.conditionalCalls.Load(); got != 1 {
+		t.Fatalf("conditional fetches = %d, want 1 (only the tracked page)", got)
+	}
+	if got := fake.getPageCallCount["1"]; got != 0 {
+		t.Fatalf("unconditional GetPage calls for unchanged page = %d, want 0", got)
+	}
+	if strings.Join(result.UpdatedMarkdown, ",") != "New.md" {
+		t.Fatalf("updated markdown = %v, want only New.md", result.UpdatedMarkdown)
+	}
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Root.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Root.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "local body") {
+		t.Fatalf("expected unchanged page to be left alone, got:\n%s", raw)
+	}
+	if got := result.State.PageETags; got["1"] != `"root-3"` || got["2"] != `"new-1"` {
+		t.Fatalf("page ETags = %v, want kept root ETag and stored new ETag", got)
+	}
+}
+
+func TestPull_ForceFullIgnoresStoredETag(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	modifiedAt := time.Date(2026, time.March, 9, 10, 58, 0, 0, time.UTC)
+
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "Root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 3},
+		Body:        "local body\n",
+	}); err != nil {
+		t.Fatalf("write Root.md: %v", err)
+	}
+
+	fake := &conditionalFakePullRemote{fakePullRemote: &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modifiedAt}},
+		attachments: map[string][]byte{
+			"att-1": []byte("diagram-bytes"),
+			"att-2": []byte("inline-bytes"),
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modifiedAt, ETag: `"root-3"`, BodyADF: rawJSON(t, sampleRootADF())},
+		},
+	}}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State: fs.SpaceState{
+			LastPullHighWatermark: "2026-03-09T11:00:00Z",
+			PagePathIndex:         map[string]string{"Root.md": "1"},
+			PageETags:             map[string]string{"1": `"root-3"`},
+		},
+		PullStartedAt: time.Date(2026, time.March, 9, 12, 0, 0, 0, time.UTC),
+		ForceFull:     true,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	if got := fake.conditionalCalls.Load(); got != 0 {
+		t.Fatalf("conditional fetches with ForceFull = %d, want 0", got)
+	}
+	if strings.Join(result.UpdatedMarkdown, ",") != "Root.md" {
+		t.Fatalf("updated markdown = %v, want Root.md rewritten", result.UpdatedMarkdown)
+	}
+}
@@ -0,0 +1,63 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// renderedExtensionKeys lists extension keys that have a repo-specific
+// converter handler and therefore are not opaque passthrough content.
+var renderedExtensionKeys = map[string]struct{}{
+	"plantumlcloud": {},
+	"toc":           {},
+}
+
+// collectMacroPassthroughDiagnostic reports Confluence macros (extension,
+// bodiedExtension, inlineExtension nodes) that pull keeps as raw ADF because
+// no handler renders them as editable Markdown.
+func collectMacroPassthroughDiagnostic(adfJSON []byte, relPath string) *PullDiagnostic {
+	if len(adfJSON) == 0 {
+		return nil
+	}
+
+	var raw any
+	if err := json.Unmarshal(adfJSON, &raw); err != nil {
+		return nil
+	}
+
+	count := 0
+	keys := map[string]struct{}{}
+	walkADFNode(raw, func(node map[string]any) {
+		nodeType, _ := node["type"].(string)
+		if nodeType != "extension" && nodeType != "bodiedExtension" && nodeType != "inlineExtension" {
+			return
+		}
+		attrs, _ := node["attrs"].(map[string]any)
+		key := firstString(attrs, "extensionKey")
+		if _, rendered := renderedExtensionKeys[key]; rendered {
+			return
+		}
+		count++
+		if key == "" {
+			key = nodeType
+		}
+		keys[key] = struct{}{}
+	})
+	if count == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	return &PullDiagnostic{
+		Path:    relPath,
+		Code:    "MACRO_PASSTHROUGH",
+		Message: fmt.Sprintf("preserved %d Confluence macro(s) as raw ADF passthrough (%s); they are pushed back unchanged but are not editable as Markdown", count, strings.Join(names, ", ")),
+	}
+}
@@ -0,0 +1,157 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestPull_MaxPagesTruncatesListingAndResumesFromCursor(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 9, 9, 30, 0, 0, time.UTC)
+	emptyADF := map[string]any{"version": 1, "type": "doc", "content": []any{}}
+	batches := map[string]confluence.PageListResult{
+		"": {
+			Pages:      []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "One", Version: 1, LastModified: modifiedAt}},
+			NextCursor: "cursor-2",
+		},
+		"cursor-2": {
+			Pages: []confluence.Page{{ID: "2", SpaceID: "space-1", Title: "Two", Version: 1, LastModified: modifiedAt}},
+		},
+	}
+	listedCursors := []string{}
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		listPagesFunc: func(opts confluence.PageListOptions) (confluence.PageListResult, error) {
+			listedCursors = append(listedCursors, opts.Cursor)
+			return batches[opts.Cursor], nil
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "One", Version: 1, LastModified: modifiedAt, BodyADF: rawJSON(t, emptyADF)},
+			"2": {ID: "2", SpaceID: "space-1", Title: "Two", Version: 1, LastModified: modifiedAt, BodyADF: rawJSON(t, emptyADF)},
+		},
+	}
+
+	first, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:      "ENG",
+		SpaceDir:      spaceDir,
+		State:         fs.NewSpaceState(),
+		PullStartedAt: time.Date(2026, time.March, 9, 10, 0, 0, 0, time.UTC),
+		MaxPages:      1,
+	})
+	if err != nil {
+		t.Fatalf("first Pull() error: %v", err)
+	}
+	if first.State.PullResumeCursor != "cursor-2" {
+		t.Fatalf("resume cursor = %q, want cursor-2", first.State.PullResumeCursor)
+	}
+	if first.State.LastPullHighWatermark != "" {
+		t.Fatalf("watermark = %q, want unchanged empty watermark for truncated listing", first.State.LastPullHighWatermark)
+	}
+	if findPullDiagnostic(first.Diagnostics, "PULL_PAGE_LIMIT_REACHED") == nil {
+		t.Fatalf("expected PULL_PAGE_LIMIT_REACHED diagnostic, got %+v", first.Diagnostics)
+	}
+	if got := first.State.PagePathIndex["One.md"]; got != "1" {
+		t.Fatalf("page_path_index[One.md] = %q, want 1", got)
+	}
+	if _, ok := first.State.PagePathIndex["Two.md"]; ok {
+		t.Fatalf("expected Two.md to be left for the resumed run, got %+v", first.State.PagePathIndex)
+	}
+
+	second, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:      "ENG",
+		SpaceDir:      spaceDir,
+		State:         first.State,
+		PullStartedAt: time.Date(2026, time.March, 9, 10, 5, 0, 0, time.UTC),
+		MaxPages:      1,
+	})
+	if err != nil {
+		t.Fatalf("second Pull() error: %v", err)
+	}
+	if got := listedCursors[len(listedCursors)-1]; got != "cursor-2" {
+		t.Fatalf("second run listed from cursor %q, want cursor-2", got)
+	}
+	if second.State.PullResumeCursor != "" {
+		t.Fatalf("resume cursor = %q, want cleared after complete listing", second.State.PullResumeCursor)
+	}
+	if second.State.LastPullHighWatermark == "" {
+		t.Fatal("expected watermark to advance once listing completed")
+	}
+	if findPullDiagnostic(second.Diagnostics, "PULL_PAGE_LIMIT_REACHED") != nil {
+		t.Fatalf("unexpected PULL_PAGE_LIMIT_REACHED diagnostic on completed listing: %+v", second.Diagnostics)
+	}
+	if got := second.State.PagePathIndex["One.md"]; got != "1" {
+		t.Fatalf("expected previously pulled page to stay tracked, got %+v", second.State.PagePathIndex)
+	}
+	if got := second.State.PagePathIndex["Two.md"]; got != "2" {
+		t.Fatalf("page_path_index[Two.md] = %q, want 2", got)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "One.md")); err != nil {
+		t.Fatalf("expected One.md to remain on disk: %v", err)
+	}
+}
+
+func TestPull_TruncatedListingDoesNotFetchUnlistedTrackedPages(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	for name, id := range map[string]string{"One.md": "1", "Two.md": "2"} {
+		if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, name), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: strings.TrimSuffix(name, ".md"), ID: id, Version: 1},
+			Body:        "body\n",
+		}); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"One.md": "1", "Two.md": "2"}
+
+	modifiedAt := time.Date(2026, time.March, 9, 9, 30, 0, 0, time.UTC)
+	emptyADF := map[string]any{"version": 1, "type": "doc", "content": []any{}}
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		listPagesFunc: func(_ confluence.PageListOptions) (confluence.PageListResult, error) {
+			return confluence.PageListResult{
+				Pages:      []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "One", Version: 2, LastModified: modifiedAt}},
+				NextCursor: "cursor-2",
+			}, nil
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "One", Version: 2, LastModified: modifiedAt, BodyADF: rawJSON(t, emptyADF)},
+			"2": {ID: "2", SpaceID: "space-1", Title: "Two", Version: 1, LastModified: modifiedAt, BodyADF: rawJSON(t, emptyADF)},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:      "ENG",
+		SpaceDir:      spaceDir,
+		State:         state,
+		PullStartedAt: time.Date(2026, time.March, 9, 10, 0, 0, 0, time.UTC),
+		MaxPages:      1,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+	if calls := fake.getPageCallCount["2"]; calls != 0 {
+		t.Fatalf("unlisted tracked page was fetched %d time(s)", calls)
+	}
+	if got := result.State.PagePathIndex["Two.md"]; got != "2" {
+		t.Fatalf("page_path_index[Two.md] = %q, want 2", got)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Two.md")); err != nil {
+		t.Fatalf("expected Two.md to stay on disk: %v", err)
+	}
+	if len(result.DeletedMarkdown) != 0 {
+		t.Fatalf("deleted markdown = %v, want none", result.DeletedMarkdown)
+	}
+}
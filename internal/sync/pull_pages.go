@@ -10,28 +10,56 @@ import (
 	"time"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
+// ForwardPageBody converts a page body to Markdown. Pages without an ADF body
+// fall back to their storage representation, which is converted on a
+// best-effort basis and reported with a storage_body_fallback warning. A page
+// whose storage body could not be fetched either is an error rather than an
+// empty body.
+//
+// The Markdown is normalized with converter.NormalizeMarkdown. Pull writes and
+// diff renders both go through here, so diffs only show real changes.
+func ForwardPageBody(ctx context.Context, page confluence.Page, cfg converter.ForwardConfig, sourcePath string) (converter.ForwardResult, error) {
+	var (
+		forward converter.ForwardResult
+		err     error
+	)
+	switch {
+	case len(page.BodyADF) == 0 && strings.TrimSpace(page.BodyStorage) != "":
+		forward = converter.ForwardStorage(page.BodyStorage)
+	case len(page.BodyADF) == 0 && page.BodyStorageError != "":
+		return converter.ForwardResult{}, fmt.Errorf("page body was empty in ADF and its storage representation could not be fetched: %s", page.BodyStorageError)
+	default:
+		if forward, err = converter.Forward(ctx, page.BodyADF, cfg, sourcePath); err != nil {
+			return converter.ForwardResult{}, err
+		}
+	}
+	forward.Markdown = converter.NormalizeMarkdown(forward.Markdown)
+	return forward, nil
+}
+
 func selectChangedPages(
 	ctx context.Context,
 	remote PullRemote,
 	opts PullOptions,
 	overlapWindow time.Duration,
 	pageByID map[string]confluence.Page,
-) ([]string, map[string]confluence.Change, error) {
+) ([]string, map[string]confluence.Change, []PullDiagnostic, error) {
 	changeByPageID := map[string]confluence.Change{}
 
 	if strings.TrimSpace(opts.TargetPageID) != "" {
 		targetID := strings.TrimSpace(opts.TargetPageID)
 		if _, ok := pageByID[targetID]; !ok {
-			return nil, changeByPageID, nil
+			return nil, changeByPageID, nil, nil
 		}
 		changeByPageID[targetID] = changeFromPage(pageByID[targetID], opts.SpaceKey)
 		if latestPage, err := remote.GetPage(ctx, targetID); err == nil {
 			changeByPageID[targetID] = mergeChangedPage(changeByPageID[targetID], changeFromPage(latestPage, opts.SpaceKey))
 		}
-		return []string{targetID}, changeByPageID, nil
+		return []string{targetID}, changeByPageID, nil, nil
 	}
 
 	ids := map[string]struct{}{}
@@ -41,7 +69,7 @@ func selectChangedPages(
 			ids[id] = struct{}{}
 			changeByPageID[id] = changeFromPage(page, opts.SpaceKey)
 		}
-		return sortedStringKeys(ids), changeByPageID, nil
+		return sortedStringKeys(ids), changeByPageID, nil, nil
 	}
 
 	if strings.TrimSpace(opts.State.LastPullHighWatermark) == "" {
@@ -49,22 +77,22 @@ func selectChangedPages(
 			ids[id] = struct{}{}
 			changeByPageID[id] = changeFromPage(page, opts.SpaceKey)
 		}
-		return sortedStringKeys(ids), changeByPageID, nil
+		return sortedStringKeys(ids), changeByPageID, nil, nil
 	}
 
 	watermark, err := time.Parse(time.RFC3339, strings.TrimSpace(opts.State.LastPullHighWatermark))
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse last_pull_high_watermark: %w", err)
+		return nil, nil, nil, fmt.Errorf("parse last_pull_high_watermark: %w", err)
 	}
 
 	since := watermark.Add(-overlapWindow)
-	changes, err := listAllChanges(ctx, remote, confluence.ChangeListOptions{
+	changes, diagnostics, err := listAllChanges(ctx, remote, confluence.ChangeListOptions{
 		SpaceKey: opts.SpaceKey,
 		Since:    since,
 		Limit:    pullChangeBatchSize,
-	}, opts.Progress)
+	}, opts.Progress, opts.now)
 	if err != nil {
-		return nil, nil, fmt.Errorf("list incremental changes: %w", err)
+		return nil, nil, nil, fmt.Errorf("list incremental changes: %w", err)
 	}
 
 	for _, change := range changes {
@@ -88,7 +116,7 @@ func selectChangedPages(
 		}
 	}
 
-	return sortedStringKeys(ids), changeByPageID, nil
+	return sortedStringKeys(ids), changeByPageID, diagnostics, nil
 }
 
 func changeFromPage(page confluence.Page, spaceKey string) confluence.Change {
@@ -141,6 +169,38 @@ func loadTrackedPageVersions(spaceDir string, pagePathIndex map[string]string) m
 	return versions
 }
 
+// conditionalPageRemote is implemented by remotes that can fetch a page with
+// If-None-Match, such as *confluence.Client.
+type conditionalPageRemote interface {
+	GetPageIfNoneMatch(ctx context.Context, pageID, etag string) (confluence.Page, error)
+}
+
+// fetchChangedPageConditionally re-fetches a page with If-None-Match when an
+// ETag from the previous pull is known. It reports unchanged=true when the
+// server answers 304 Not Modified. Any other outcome, including remotes
+// without conditional support, falls back to fetchChangedPageWithRetry.
+func fetchChangedPageConditionally(
+	ctx context.Context,
+	remote PullRemote,
+	pageID string,
+	etag string,
+	listedPage confluence.Page,
+	changedPage confluence.Change,
+) (page confluence.Page, unchanged bool, err error) {
+	if conditional, ok := remote.(conditionalPageRemote); ok && strings.TrimSpace(etag) != "" {
+		page, err := conditional.GetPageIfNoneMatch(ctx, pageID, etag)
+		if errors.Is(err, confluence.ErrNotModified) {
+			return confluence.Page{}, true, nil
+		}
+		if err == nil && pageMatchesExpectedState(page, max(listedPage.Version, changedPage.Version), listedPage.LastModified) {
+			return page, false, nil
+		}
+	}
+
+	page, err = fetchChangedPageWithRetry(ctx, remote, pageID, listedPage, changedPage)
+	return page, false, err
+}
+
 func fetchChangedPageWithRetry(
 	ctx context.Context,
 	remote PullRemote,
@@ -201,19 +261,22 @@ func shouldIgnoreFolderHierarchyError(err error) bool {
 	return shouldDegradeFolderLookupError(err)
 }
 
-func listAllPages(ctx context.Context, remote PullRemote, opts confluence.PageListOptions, progress Progress) ([]confluence.Page, error) {
+// listAllPages pages through the space listing. When maxPages is positive the
+// listing stops once that many pages were collected and the cursor for the
+// remaining pages is returned so a later run can resume from it.
+func listAllPages(ctx context.Context, remote PullRemote, opts confluence.PageListOptions, maxPages int, progress Progress) ([]confluence.Page, string, error) {
 	result := []confluence.Page{}
 	cursor := opts.Cursor
 	iterations := 0
 	for {
 		if iterations >= maxPaginationIterations {
-			return nil, fmt.Errorf("pagination loop exceeded %d iterations for space %s", maxPaginationIterations, opts.SpaceID)
+			return nil, "", fmt.Errorf("pagination loop exceeded %d iterations for space %s", maxPaginationIterations, opts.SpaceID)
 		}
 		iterations++
 		opts.Cursor = cursor
 		pageResult, err := remote.ListPages(ctx, opts)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		result = append(result, pageResult.Pages...)
 		if progress != nil {
@@ -223,8 +286,11 @@ func listAllPages(ctx context.Context, remote PullRemote, opts confluence.PageLi
 			break
 		}
 		cursor = pageResult.NextCursor
+		if maxPages > 0 && len(result) >= maxPages {
+			return result, cursor, nil
+		}
 	}
-	return result, nil
+	return result, "", nil
 }
 
 func resolveFolderHierarchyFromPages(ctx context.Context, remote PullRemote, pages []confluence.Page) (map[string]confluence.Folder, []PullDiagnostic, error) {
@@ -299,8 +365,16 @@ func resolveFolderHierarchyFromPagesWithMode(ctx context.Context, remote PullRem
 	return folderByID, diagnostics, nil
 }
 
-// ResolveFolderPathIndex rebuilds folder_path_index from remote hierarchy.
-func ResolveFolderPathIndex(ctx context.Context, remote PullRemote, pages []confluence.Page) (map[string]string, []PullDiagnostic, error) {
+// ResolveFolderHierarchy fetches every folder above pages, keyed by folder
+// ID. Folders the tenant cannot return are skipped and reported as
+// diagnostics.
+func ResolveFolderHierarchy(ctx context.Context, remote PullRemote, pages []confluence.Page) (map[string]confluence.Folder, []PullDiagnostic, error) {
+	return resolveFolderHierarchyFromPages(ctx, remote, pages)
+}
+
+// ResolveFolderPathIndex rebuilds folder_path_index from remote hierarchy,
+// naming folders according to layout.
+func ResolveFolderPathIndex(ctx context.Context, remote PullRemote, pages []confluence.Page, layout PagePathLayout) (map[string]string, []PullDiagnostic, error) {
 	folderByID, diagnostics, err := resolveFolderHierarchyFromPages(ctx, remote, pages)
 	if err != nil {
 		return nil, nil, err
@@ -311,25 +385,105 @@ func ResolveFolderPathIndex(ctx context.Context, remote PullRemote, pages []conf
 		pageByID[strings.TrimSpace(page.ID)] = page
 	}
 
-	folderPathIndex := buildFolderPathIndex(folderByID, pageByID)
+	folderPathIndex := buildFolderPathIndex(folderByID, pageByID, layout)
 	return folderPathIndex, diagnostics, nil
 }
 
-func listAllChanges(ctx context.Context, remote PullRemote, opts confluence.ChangeListOptions, progress Progress) ([]confluence.Change, error) {
+// listAllChanges lists every change in the options' lastmodified window. When
+// the server stops paginating before returning all matches (its hard result
+// cap), the window is bisected and each half is listed separately so no
+// changed page is missed on very active spaces.
+func listAllChanges(ctx context.Context, remote PullRemote, opts confluence.ChangeListOptions, progress Progress, now func() time.Time) ([]confluence.Change, []PullDiagnostic, error) {
+	bisect := changeWindowBisection{now: now}
+	changes, err := bisect.list(ctx, remote, opts, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diagnostics := []PullDiagnostic{}
+	if bisect.splits > 0 {
+		diagnostics = append(diagnostics, PullDiagnostic{
+			Path:    opts.SpaceKey,
+			Code:    "CHANGE_LIST_WINDOW_BISECTED",
+			Message: fmt.Sprintf("incremental change listing hit the server result cap; narrowed the lastmodified window %d time(s) to list every change", bisect.splits),
+		})
+	}
+	if bisect.unresolved > 0 {
+		diagnostics = append(diagnostics, PullDiagnostic{
+			Path:    opts.SpaceKey,
+			Code:    "CHANGE_LIST_CAP_UNRESOLVED",
+			Message: fmt.Sprintf("%d lastmodified window(s) could not be narrowed below the server result cap; some changes may be missing, run 'conf pull --force' for a full refresh", bisect.unresolved),
+		})
+	}
+	return changes, diagnostics, nil
+}
+
+// changeWindowMinSpan is the narrowest lastmodified window worth bisecting;
+// CQL date literals only have minute precision.
+const changeWindowMinSpan = time.Minute
+
+type changeWindowBisection struct {
+	now        func() time.Time
+	splits     int
+	unresolved int
+}
+
+func (b *changeWindowBisection) list(ctx context.Context, remote PullRemote, opts confluence.ChangeListOptions, progress Progress) ([]confluence.Change, error) {
+	changes, capped, err := listChangesWindow(ctx, remote, opts, progress)
+	if err != nil || !capped {
+		return changes, err
+	}
+
+	since := opts.Since.UTC().Truncate(changeWindowMinSpan)
+	until := opts.Until.UTC()
+	if until.IsZero() {
+		until = b.now().UTC().Truncate(changeWindowMinSpan).Add(changeWindowMinSpan)
+	}
+	if opts.Since.IsZero() || until.Sub(since) < 2*changeWindowMinSpan {
+		b.unresolved++
+		return changes, nil
+	}
+
+	mid := since.Add(until.Sub(since) / 2).Truncate(changeWindowMinSpan)
+	b.splits++
+
+	lower := opts
+	lower.Since, lower.Until, lower.Start = since, mid, 0
+	upper := opts
+	upper.Since, upper.Until, upper.Start = mid, until, 0
+
+	merged := dedupeChanges(changes)
+	for _, window := range []confluence.ChangeListOptions{lower, upper} {
+		windowChanges, err := b.list(ctx, remote, window, progress)
+		if err != nil {
+			return nil, err
+		}
+		merged = dedupeChanges(append(merged, windowChanges...))
+	}
+	return merged, nil
+}
+
+// listChangesWindow pages through one lastmodified window and reports
+// capped=true when the server stopped short of the matches it advertised.
+func listChangesWindow(ctx context.Context, remote PullRemote, opts confluence.ChangeListOptions, progress Progress) ([]confluence.Change, bool, error) {
 	result := []confluence.Change{}
 	start := opts.Start
 	iterations := 0
+	totalSize := 0
 	for {
 		if iterations >= maxPaginationIterations {
-			return nil, fmt.Errorf("pagination loop exceeded %d iterations for changes since %v", maxPaginationIterations, opts.Since)
+			return nil, false, fmt.Errorf("pagination loop exceeded %d iterations for changes since %v", maxPaginationIterations, opts.Since)
 		}
 		iterations++
 		opts.Start = start
 		changeResult, err := remote.ListChanges(ctx, opts)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		result = append(result, changeResult.Changes...)
+		if changeResult.TotalSize > totalSize {
+			totalSize = changeResult.TotalSize
+		}
 		if progress != nil {
 			progress.Add(len(changeResult.Changes))
 		}
@@ -345,9 +499,24 @@ func listAllChanges(ctx context.Context, remote PullRemote, opts confluence.Chan
 			next = start + opts.Limit
 		}
 		if next <= start {
-			break
+			return result, true, nil
 		}
 		start = next
 	}
-	return result, nil
+	return result, totalSize > len(result), nil
+}
+
+func dedupeChanges(changes []confluence.Change) []confluence.Change {
+	out := make([]confluence.Change, 0, len(changes))
+	indexByPageID := map[string]int{}
+	for _, change := range changes {
+		pageID := strings.TrimSpace(change.PageID)
+		if idx, ok := indexByPageID[pageID]; ok && pageID != "" {
+			out[idx] = mergeChangedPage(out[idx], change)
+			continue
+		}
+		indexByPageID[pageID] = len(out)
+		out = append(out, change)
+	}
+	return out
 }
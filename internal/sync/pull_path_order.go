@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// pagePathNamer turns page and folder titles into path segments for one
+// layout. With an order prefix, every name starts with the content's
+// position among its siblings.
+type pagePathNamer struct {
+	mode        fs.FilenameMode
+	orderPrefix map[string]string
+}
+
+func newPagePathNamer(layout PagePathLayout, pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder) pagePathNamer {
+	namer := pagePathNamer{mode: layout.FilenameMode}
+	if layout.OrderPrefix {
+		namer.orderPrefix = siblingOrderPrefixes(pageByID, folderByID)
+	}
+	return namer
+}
+
+func (n pagePathNamer) segment(id, title string) string {
+	return n.orderPrefix[strings.TrimSpace(id)] + fs.SanitizePathSegmentWithMode(title, n.mode)
+}
+
+func (n pagePathNamer) filename(id, title string) string {
+	return n.orderPrefix[strings.TrimSpace(id)] + fs.SanitizeMarkdownFilenameWithMode(title, n.mode)
+}
+
+// trimOrderPrefix removes the sibling position an order-prefixed layout puts
+// in front of a name ("02-Setup" -> "Setup"). Names without a prefix, or
+// with nothing after it, are returned unchanged.
+func trimOrderPrefix(name string) string {
+	digits := 0
+	for digits < len(name) && name[digits] >= '0' && name[digits] <= '9' {
+		digits++
+	}
+	if digits < 2 || digits+1 >= len(name) || name[digits] != '-' {
+		return name
+	}
+	return name[digits+1:]
+}
+
+type siblingEntry struct {
+	id       string
+	title    string
+	position int
+}
+
+// siblingOrderPrefixes numbers pages and folders from 1 within each parent,
+// ordered by their Confluence position, then title and ID. Numbers are
+// zero-padded to at least two digits and to the width of the largest number
+// in the group, so names sort the same lexically and numerically.
+func siblingOrderPrefixes(pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder) map[string]string {
+	groups := map[string][]siblingEntry{}
+	for id, page := range pageByID {
+		parentType := strings.ToLower(strings.TrimSpace(page.ParentType))
+		if parentType == "" {
+			parentType = "page"
+		}
+		parentKey := ""
+		if parentID := strings.TrimSpace(page.ParentPageID); parentID != "" {
+			parentKey = parentType + ":" + parentID
+		}
+		groups[parentKey] = append(groups[parentKey], siblingEntry{id: strings.TrimSpace(id), title: page.Title, position: page.Position})
+	}
+	for id, folder := range folderByID {
+		parentType := strings.ToLower(strings.TrimSpace(folder.ParentType))
+		if parentType == "" {
+			parentType = "folder"
+		}
+		parentKey := ""
+		if parentID := strings.TrimSpace(folder.ParentID); parentID != "" {
+			parentKey = parentType + ":" + parentID
+		}
+		groups[parentKey] = append(groups[parentKey], siblingEntry{id: strings.TrimSpace(id), title: folder.Title, position: folder.Position})
+	}
+
+	prefixes := map[string]string{}
+	for _, siblings := range groups {
+		sort.Slice(siblings, func(i, j int) bool {
+			if siblings[i].position != siblings[j].position {
+				return siblings[i].position < siblings[j].position
+			}
+			if siblings[i].title != siblings[j].title {
+				return siblings[i].title < siblings[j].title
+			}
+			return siblings[i].id < siblings[j].id
+		})
+		width := max(2, len(strconv.Itoa(len(siblings))))
+		for i, sibling := range siblings {
+			prefixes[sibling.id] = fmt.Sprintf("%0*d-", width, i+1)
+		}
+	}
+	return prefixes
+}
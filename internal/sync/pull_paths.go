@@ -12,15 +12,29 @@ import (
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
+// PagePathLayout controls how remote titles and hierarchy become local paths.
+type PagePathLayout struct {
+	// FilenameMode controls how titles become file and directory names.
+	// Empty uses fs.FilenameModeConservative.
+	FilenameMode fs.FilenameMode
+	// OrderPrefix prefixes every page and folder name with its zero-padded
+	// position among its Confluence siblings ("01-Intro.md"), so tools that
+	// sort by filename follow the remote order.
+	OrderPrefix bool
+}
+
 // PlanPagePaths builds deterministic canonical markdown paths for remote pages.
 //
 // It always recomputes the canonical pull path from the current remote
-// hierarchy, then allocates unique sanitized filenames if needed.
+// hierarchy, then allocates unique sanitized filenames if needed. Titles
+// become names according to layout; with OrderPrefix the sibling position is
+// part of the path, so reordering pages in Confluence moves their files.
 func PlanPagePaths(
 	spaceDir string,
 	previousPageIndex map[string]string,
 	pages []confluence.Page,
 	folderByID map[string]confluence.Folder,
+	layout PagePathLayout,
 ) (map[string]string, map[string]string) {
 	pageByID := map[string]confluence.Page{}
 	hasChildren := map[string]bool{}
@@ -38,6 +52,7 @@ func PlanPagePaths(
 	if folderByID == nil {
 		folderByID = map[string]confluence.Folder{}
 	}
+	namer := newPagePathNamer(layout, pageByID, folderByID)
 	for _, folder := range folderByID {
 		if strings.EqualFold(strings.TrimSpace(folder.ParentType), "page") {
 			parentID := strings.TrimSpace(folder.ParentID)
@@ -56,7 +71,7 @@ func PlanPagePaths(
 	}
 	plans := make([]pagePathPlan, 0, len(pages))
 	for _, page := range pages {
-		baseRelPath := plannedPageRelPath(page, pageByID, folderByID, hasChildren)
+		baseRelPath := plannedPageRelPath(page, pageByID, folderByID, hasChildren, namer)
 
 		plans = append(plans, pagePathPlan{
 			ID:          page.ID,
@@ -81,14 +96,14 @@ func PlanPagePaths(
 	return absByID, relByID
 }
 
-func plannedPageRelPath(page confluence.Page, pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder, hasChildren map[string]bool) string {
+func plannedPageRelPath(page confluence.Page, pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder, hasChildren map[string]bool, namer pagePathNamer) string {
 	title := strings.TrimSpace(page.Title)
 	if title == "" {
 		title = "page-" + page.ID
 	}
-	filename := fs.SanitizeMarkdownFilename(title)
+	filename := namer.filename(page.ID, title)
 
-	ancestorSegments, ok := ancestorPathSegments(strings.TrimSpace(page.ParentPageID), strings.TrimSpace(page.ParentType), pageByID, folderByID)
+	ancestorSegments, ok := ancestorPathSegments(strings.TrimSpace(page.ParentPageID), strings.TrimSpace(page.ParentType), pageByID, folderByID, namer)
 	if !ok {
 		// Fallback to flat if hierarchy is broken
 		return normalizeRelPath(filename)
@@ -97,13 +112,13 @@ func plannedPageRelPath(page confluence.Page, pageByID map[string]confluence.Pag
 	parts := append(ancestorSegments, filename)
 	if hasChildren[page.ID] {
 		// If the page has subpages, create a directory for it and place the page inside
-		dirSegment := fs.SanitizePathSegment(title)
+		dirSegment := namer.segment(page.ID, title)
 		parts = append(ancestorSegments, dirSegment, filename)
 	}
 	return normalizeRelPath(filepath.Join(parts...))
 }
 
-func ancestorPathSegments(parentID string, parentType string, pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder) ([]string, bool) {
+func ancestorPathSegments(parentID string, parentType string, pageByID map[string]confluence.Page, folderByID map[string]confluence.Folder, namer pagePathNamer) ([]string, bool) {
 	currentID := strings.TrimSpace(parentID)
 	currentType := strings.ToLower(strings.TrimSpace(parentType))
 	if currentID == "" {
@@ -156,7 +171,7 @@ func ancestorPathSegments(parentID string, parentType string, pageByID map[strin
 		}
 
 		// All ancestors (folders and pages) contribute a directory segment to their descendants.
-		segmentsReversed = append(segmentsReversed, fs.SanitizePathSegment(title))
+		segmentsReversed = append(segmentsReversed, namer.segment(currentID, title))
 
 		currentID = nextID
 		currentType = nextType
@@ -200,9 +215,9 @@ func deletedPageIDs(previousPageIndex map[string]string, remotePages map[string]
 
 // PlannedPagePathMove describes a tracked page whose planned markdown path changed.
 type PlannedPagePathMove struct {
-	PageID       string
-	PreviousPath string
-	PlannedPath  string
+	PageID       string `json:"page_id"`
+	PreviousPath string `json:"previous_path"`
+	PlannedPath  string `json:"planned_path"`
 }
 
 // PlannedPagePathMoves returns tracked pages whose planned relative markdown path changed.
@@ -356,15 +371,16 @@ func sortedStringKeys[V any](in map[string]V) []string {
 	return out
 }
 
-func buildFolderPathIndex(folderByID map[string]confluence.Folder, pageByID map[string]confluence.Page) map[string]string {
+func buildFolderPathIndex(folderByID map[string]confluence.Folder, pageByID map[string]confluence.Page, layout PagePathLayout) map[string]string {
 	if len(folderByID) == 0 {
 		return nil
 	}
 
 	folderPathIndex := make(map[string]string)
+	namer := newPagePathNamer(layout, pageByID, folderByID)
 
 	for folderID := range folderByID {
-		localPath := buildFolderLocalPath(folderID, folderByID, pageByID)
+		localPath := buildFolderLocalPath(folderID, folderByID, pageByID, namer)
 		if localPath != "" {
 			folderPathIndex[normalizeRelPath(localPath)] = folderID
 		}
@@ -376,7 +392,7 @@ func buildFolderPathIndex(folderByID map[string]confluence.Folder, pageByID map[
 	return folderPathIndex
 }
 
-func buildFolderLocalPath(folderID string, folderByID map[string]confluence.Folder, pageByID map[string]confluence.Page) string {
+func buildFolderLocalPath(folderID string, folderByID map[string]confluence.Folder, pageByID map[string]confluence.Page, namer pagePathNamer) string {
 	segments := []string{}
 
 	currentID := folderID
@@ -417,7 +433,7 @@ func buildFolderLocalPath(folderID string, folderByID map[string]confluence.Fold
 			}
 		}
 
-		segments = append(segments, fs.SanitizePathSegment(title))
+		segments = append(segments, namer.segment(currentID, title))
 
 		currentID = nextID
 		currentType = nextType
@@ -433,3 +449,26 @@ func buildFolderLocalPath(folderID string, folderByID map[string]confluence.Fold
 
 	return normalizeRelPath(filepath.Join(segments...))
 }
+
+// pinnedParentPagePaths maps the IDs of tracked pages whose frontmatter pins
+// their remote parent with parent_id or parent_path to their tracked path.
+// Their remote parent differs from their directory on purpose, so pull keeps
+// those files where they are instead of moving them to the remote hierarchy.
+func pinnedParentPagePaths(spaceDir string, pagePathIndex map[string]string) map[string]string {
+	pinned := map[string]string{}
+	for relPath, pageID := range pagePathIndex {
+		relPath = normalizeRelPath(relPath)
+		pageID = strings.TrimSpace(pageID)
+		if relPath == "" || pageID == "" {
+			continue
+		}
+		fm, err := fs.ReadFrontmatter(filepath.Join(spaceDir, filepath.FromSlash(relPath)))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(fm.ParentID) != "" || strings.TrimSpace(fm.ParentPath) != "" {
+			pinned[pageID] = relPath
+		}
+	}
+	return pinned
+}
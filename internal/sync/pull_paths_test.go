@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
 func TestPlanPagePaths_MaintainsConfluenceHierarchy(t *testing.T) {
@@ -17,7 +18,7 @@ func TestPlanPagePaths_MaintainsConfluenceHierarchy(t *testing.T) {
 		{ID: "3", Title: "Grand Child", ParentPageID: "2"},
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Root/Root.md" {
 		t.Fatalf("root path = %q, want Root/Root.md", got)
@@ -30,6 +31,25 @@ func TestPlanPagePaths_MaintainsConfluenceHierarchy(t *testing.T) {
 	}
 }
 
+func TestPlanPagePaths_AppliesFilenameModeToFilesAndDirectories(t *testing.T) {
+	spaceDir := t.TempDir()
+
+	pages := []confluence.Page{
+		{ID: "1", Title: "Руководство"},
+		{ID: "2", Title: "Café Menu", ParentPageID: "1"},
+	}
+
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeTransliterate})
+	if got := relByID["2"]; got != "Rukovodstvo/Cafe-Menu.md" {
+		t.Fatalf("transliterated path = %q, want Rukovodstvo/Cafe-Menu.md", got)
+	}
+
+	_, relByID = PlanPagePaths(spaceDir, nil, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModePreserveUnicode})
+	if got := relByID["2"]; got != "Руководство/Café Menu.md" {
+		t.Fatalf("unicode-preserving path = %q, want Руководство/Café Menu.md", got)
+	}
+}
+
 func TestPlanPagePaths_FallsBackToTopLevelWhenParentMissing(t *testing.T) {
 	spaceDir := t.TempDir()
 
@@ -37,7 +57,7 @@ func TestPlanPagePaths_FallsBackToTopLevelWhenParentMissing(t *testing.T) {
 		{ID: "2", Title: "Child", ParentPageID: "missing-parent"},
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["2"]; got != "Child.md" {
 		t.Fatalf("fallback path = %q, want Child.md", got)
@@ -55,7 +75,7 @@ func TestPlanPagePaths_UsesFolderHierarchy(t *testing.T) {
 		"folder-2": {ID: "folder-2", Title: "Onboarding", ParentID: "folder-1"},
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, nil, pages, folderByID)
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, folderByID, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Policies/Onboarding/Start-Here.md" {
 		t.Fatalf("folder-based path = %q, want Policies/Onboarding/Start-Here.md", got)
@@ -72,7 +92,7 @@ func TestPlanPagePaths_ReconcilesExistingPathToCanonicalTitle(t *testing.T) {
 		"custom-title.md": "1",
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Renamed-Page.md" {
 		t.Fatalf("canonical path = %q, want Renamed-Page.md", got)
@@ -91,7 +111,7 @@ func TestPlanPagePaths_ReconcilesShortSlugToCanonicalPath(t *testing.T) {
 		"Software-Development/Software-Development.md": "10",
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Software-Development/Cross-Space-Target-2026-03-11-0712.md" {
 		t.Fatalf("canonical child path = %q, want Software-Development/Cross-Space-Target-2026-03-11-0712.md", got)
@@ -145,7 +165,7 @@ func TestPlanPagePaths_SubtreeRootTitleRenameMovesOwnedDirectory(t *testing.T) {
 		"Original-Root/Child.md":         "2",
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil)
+	_, relByID := PlanPagePaths(spaceDir, previousPageIndex, pages, nil, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Renamed-Root/Renamed-Root.md" {
 		t.Fatalf("root path = %q, want Renamed-Root/Renamed-Root.md", got)
@@ -154,3 +174,46 @@ func TestPlanPagePaths_SubtreeRootTitleRenameMovesOwnedDirectory(t *testing.T) {
 		t.Fatalf("child path = %q, want Renamed-Root/Child.md", got)
 	}
 }
+
+func TestPlanPagePaths_OrderPrefixFollowsSiblingPosition(t *testing.T) {
+	spaceDir := t.TempDir()
+
+	pages := []confluence.Page{
+		{ID: "1", Title: "Home", Position: 0},
+		{ID: "2", Title: "Setup", ParentPageID: "1", Position: 20},
+		{ID: "3", Title: "Intro", ParentPageID: "1", Position: 10},
+		{ID: "4", Title: "Runbook", ParentPageID: "folder-1", ParentType: "folder"},
+	}
+	folderByID := map[string]confluence.Folder{
+		"folder-1": {ID: "folder-1", Title: "Ops", ParentID: "1", ParentType: "page", Position: 30},
+	}
+	layout := PagePathLayout{FilenameMode: fs.FilenameModeConservative, OrderPrefix: true}
+
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, folderByID, layout)
+	want := map[string]string{
+		"1": "01-Home/01-Home.md",
+		"3": "01-Home/01-Intro.md",
+		"2": "01-Home/02-Setup.md",
+		"4": "01-Home/03-Ops/01-Runbook.md",
+	}
+	for id, path := range want {
+		if got := relByID[id]; got != path {
+			t.Fatalf("page %s path = %q, want %q (all: %v)", id, got, path, relByID)
+		}
+	}
+	pageByID := map[string]confluence.Page{}
+	for _, page := range pages {
+		pageByID[page.ID] = page
+	}
+	if got := buildFolderPathIndex(folderByID, pageByID, layout); got["01-Home/03-Ops"] != "folder-1" {
+		t.Fatalf("folder path index = %v, want prefixed folder path", got)
+	}
+
+	// Moving Setup before Intro in Confluence renames both files.
+	pages[1].Position, pages[2].Position = 10, 20
+	_, reordered := PlanPagePaths(spaceDir, invertPathByID(relByID), pages, folderByID, layout)
+	moves := PlannedPagePathMoves(invertPathByID(relByID), reordered)
+	if reordered["2"] != "01-Home/01-Setup.md" || reordered["3"] != "01-Home/02-Intro.md" || len(moves) != 2 {
+		t.Fatalf("reordered paths = %v, moves = %+v", reordered, moves)
+	}
+}
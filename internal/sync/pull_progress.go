@@ -0,0 +1,251 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	gosync "sync"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+const (
+	pullProgressFileName  = "progress.jsonl"
+	pullProgressAssetsDir = "assets"
+)
+
+// pullProgress records what a pull has already fetched, so a run that is
+// interrupted before it completes can be resumed without fetching those pages
+// and attachments again. Records are appended as JSON lines to
+// fs.PullProgressDir; attachment bytes are kept next to them. The data only
+// applies to the same space, starting watermark and asset layout, and is
+// removed once a pull completes.
+//
+// Persisting progress is best effort: when the directory cannot be written,
+// the pull continues without it.
+type pullProgress struct {
+	dir string
+
+	mu     gosync.Mutex
+	file   *os.File
+	pages  map[string]confluence.Page
+	assets map[string]pullProgressAsset
+}
+
+// pullProgressRun identifies the pull a progress directory belongs to.
+type pullProgressRun struct {
+	SpaceKey    string `json:"space_key"`
+	Since       string `json:"since,omitempty"`
+	AssetLayout string `json:"asset_layout,omitempty"`
+}
+
+// pullProgressAsset is a downloaded attachment whose bytes are kept in the
+// progress directory.
+type pullProgressAsset struct {
+	AttachmentID string `json:"attachment_id"`
+	PageID       string `json:"page_id"`
+	Size         int64  `json:"size"`
+}
+
+// pullProgressRecord is one line of the progress file: the run header first,
+// then one fetched page or downloaded attachment per line.
+type pullProgressRecord struct {
+	Run   *pullProgressRun   `json:"run,omitempty"`
+	Page  *confluence.Page   `json:"page,omitempty"`
+	Asset *pullProgressAsset `json:"asset,omitempty"`
+}
+
+// openPullProgress loads the progress left by an interrupted run of the same
+// pull and opens the progress file for appending. Progress of a different
+// run is discarded.
+func openPullProgress(spaceDir string, run pullProgressRun) *pullProgress {
+	progress := &pullProgress{
+		dir:    fs.PullProgressDir(spaceDir),
+		pages:  map[string]confluence.Page{},
+		assets: map[string]pullProgressAsset{},
+	}
+	path := filepath.Join(progress.dir, pullProgressFileName)
+
+	validLen, matched := progress.load(path, run)
+	if !matched {
+		_ = os.RemoveAll(progress.dir)
+		progress.pages = map[string]confluence.Page{}
+		progress.assets = map[string]pullProgressAsset{}
+		validLen = 0
+	}
+
+	if err := os.MkdirAll(filepath.Join(progress.dir, pullProgressAssetsDir), 0o750); err != nil {
+		return progress
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is derived from the space directory
+	if err != nil {
+		return progress
+	}
+	// A run killed mid-write leaves a partial last line; it is dropped.
+	if err := file.Truncate(validLen); err != nil {
+		_ = file.Close()
+		return progress
+	}
+	if _, err := file.Seek(validLen, io.SeekStart); err != nil {
+		_ = file.Close()
+		return progress
+	}
+	progress.file = file
+	if validLen == 0 {
+		progress.append(pullProgressRecord{Run: &run})
+	}
+	return progress
+}
+
+// load reads the records of path and returns the length of its valid prefix.
+// matched is false when there is no progress or it belongs to another run.
+func (p *pullProgress) load(path string, run pullProgressRun) (validLen int64, matched bool) {
+	file, err := os.Open(path) //nolint:gosec // path is derived from the space directory
+	if err != nil {
+		return 0, false
+	}
+	defer func() { _ = file.Close() }()
+
+	dec := json.NewDecoder(file)
+	var header pullProgressRecord
+	if err := dec.Decode(&header); err != nil || header.Run == nil || *header.Run != run {
+		return 0, false
+	}
+	for {
+		validLen = dec.InputOffset()
+		var record pullProgressRecord
+		if err := dec.Decode(&record); err != nil {
+			return validLen, true
+		}
+		switch {
+		case record.Page != nil:
+			page := *record.Page
+			if string(page.BodyADF) == "null" {
+				page.BodyADF = nil
+			}
+			p.pages[page.ID] = page
+		case record.Asset != nil:
+			p.assets[record.Asset.AttachmentID] = *record.Asset
+		}
+	}
+}
+
+// page returns the fetched page recorded for pageID when it is at least at
+// version.
+func (p *pullProgress) page(pageID string, version int) (confluence.Page, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	page, ok := p.pages[pageID]
+	if !ok || version <= 0 || page.Version < version {
+		return confluence.Page{}, false
+	}
+	return page, true
+}
+
+// recordPage persists a fully fetched page.
+func (p *pullProgress) recordPage(page confluence.Page) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pages[page.ID] = page
+	p.append(pullProgressRecord{Page: &page})
+}
+
+// recordAsset keeps a copy of a downloaded attachment.
+func (p *pullProgress) recordAsset(attachmentID, pageID, assetPath string) {
+	p.mu.Lock()
+	if p.file == nil {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	size, err := copyFileAtomic(assetPath, p.assetPath(attachmentID))
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	asset := pullProgressAsset{AttachmentID: attachmentID, PageID: pageID, Size: size}
+	p.assets[attachmentID] = asset
+	p.append(pullProgressRecord{Asset: &asset})
+}
+
+// restoreAsset writes the kept copy of an attachment to assetPath and reports
+// whether it did.
+func (p *pullProgress) restoreAsset(attachmentID, pageID, assetPath string) bool {
+	p.mu.Lock()
+	asset, ok := p.assets[attachmentID]
+	p.mu.Unlock()
+	if !ok || asset.PageID != pageID {
+		return false
+	}
+	info, err := os.Stat(p.assetPath(attachmentID))
+	if err != nil || info.Size() != asset.Size {
+		return false
+	}
+	_, err = copyFileAtomic(p.assetPath(attachmentID), assetPath)
+	return err == nil
+}
+
+// close releases the progress file and keeps its records for the next run.
+func (p *pullProgress) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file != nil {
+		_ = p.file.Close()
+		p.file = nil
+	}
+}
+
+// remove deletes the progress of a completed pull.
+func (p *pullProgress) remove() {
+	p.close()
+	_ = os.RemoveAll(p.dir)
+}
+
+func (p *pullProgress) assetPath(attachmentID string) string {
+	return filepath.Join(p.dir, pullProgressAssetsDir, fs.SanitizePathSegment(attachmentID))
+}
+
+// append writes one record; the caller holds p.mu. A failed write stops
+// recording so a torn line is never followed by more records.
+func (p *pullProgress) append(record pullProgressRecord) {
+	if p.file == nil {
+		return
+	}
+	if err := json.NewEncoder(p.file).Encode(record); err != nil {
+		_ = p.file.Close()
+		p.file = nil
+	}
+}
+
+// copyFileAtomic copies src to dst through a temporary file in dst's
+// directory and returns the number of bytes copied.
+func copyFileAtomic(src, dst string) (int64, error) {
+	in, err := os.Open(src) //nolint:gosec // paths are controlled by pull
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = in.Close() }()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "asset-*")
+	if err != nil {
+		return 0, err
+	}
+	tmpName := tmp.Name()
+	size, copyErr := io.Copy(tmp, in)
+	closeErr := tmp.Close()
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		_ = os.Remove(tmpName) //nolint:gosec // path is controlled by pull
+		return 0, err
+	}
+	if err := os.Rename(tmpName, dst); err != nil { //nolint:gosec // paths are controlled by pull
+		_ = os.Remove(tmpName) //nolint:gosec // path is controlled by pull
+		return 0, err
+	}
+	return size, nil
+}
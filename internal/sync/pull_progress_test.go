@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestPull_ResumesInterruptedRunWithoutRefetching(t *testing.T) {
+	spaceDir := t.TempDir()
+	modified := time.Date(2026, time.February, 1, 10, 0, 0, 0, time.UTC)
+	pages := []confluence.Page{
+		{ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modified},
+		{ID: "2", SpaceID: "space-1", Title: "Child", ParentPageID: "1", Version: 2, LastModified: modified},
+	}
+	var downloadsMu gosync.Mutex
+	downloads := map[string]int{}
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: pages,
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 3, LastModified: modified, BodyADF: rawJSON(t, sampleRootADF())},
+			"2": {ID: "2", SpaceID: "space-1", Title: "Child", ParentPageID: "1", Version: 2, LastModified: modified, BodyADF: rawJSON(t, sampleChildADF())},
+		},
+		// att-2 is missing, so the first run fails after downloading att-1.
+		attachments: map[string][]byte{"att-1": []byte("png")},
+		downloadHook: func(attachmentID string) {
+			downloadsMu.Lock()
+			downloads[attachmentID]++
+			downloadsMu.Unlock()
+		},
+	}
+	opts := PullOptions{
+		SpaceKey:      "ENG",
+		SpaceDir:      spaceDir,
+		State:         fs.NewSpaceState(),
+		PullStartedAt: modified.Add(time.Hour),
+		Concurrency:   1,
+	}
+
+	if _, err := Pull(context.Background(), fake, opts); err == nil {
+		t.Fatal("expected the first pull to fail on the missing attachment")
+	}
+	if _, err := os.Stat(fs.PullProgressDir(spaceDir)); err != nil {
+		t.Fatalf("interrupted pull should keep its progress: %v", err)
+	}
+	// Simulate the cleanup of the failed run.
+	if err := os.RemoveAll(filepath.Join(spaceDir, "assets")); err != nil {
+		t.Fatalf("remove assets: %v", err)
+	}
+
+	fake.attachments["att-2"] = []byte("inline")
+	result, err := Pull(context.Background(), fake, opts)
+	if err != nil {
+		t.Fatalf("resumed Pull() error: %v", err)
+	}
+
+	if fake.getPageCallCount["1"] != 1 || fake.getPageCallCount["2"] != 1 {
+		t.Fatalf("GetPage calls = %v, want each page fetched once across both runs", fake.getPageCallCount)
+	}
+	if downloads["att-1"] != 1 || downloads["att-2"] != 2 {
+		t.Fatalf("downloads = %v, want att-1 reused and att-2 retried", downloads)
+	}
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "assets", "1", "att-1-diagram.png")) //nolint:gosec // test temp dir
+	if err != nil || string(raw) != "png" {
+		t.Fatalf("restored attachment = %q, %v", raw, err)
+	}
+	if len(result.UpdatedMarkdown) != 2 {
+		t.Fatalf("updated markdown = %v, want both pages written", result.UpdatedMarkdown)
+	}
+	resumed := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "PULL_RESUMED" {
+			resumed = strings.Contains(diag.Message, "2 page(s) and 1 attachment(s)")
+		}
+	}
+	if !resumed {
+		t.Fatalf("diagnostics = %+v, want PULL_RESUMED for 2 pages and 1 attachment", result.Diagnostics)
+	}
+	if _, err := os.Stat(fs.PullProgressDir(spaceDir)); !os.IsNotExist(err) {
+		t.Fatalf("completed pull should remove its progress, stat err = %v", err)
+	}
+}
+
+func TestPull_IgnoresProgressOfStalePageVersions(t *testing.T) {
+	spaceDir := t.TempDir()
+	modified := time.Date(2026, time.February, 1, 10, 0, 0, 0, time.UTC)
+	progress := openPullProgress(spaceDir, pullProgressRun{SpaceKey: "ENG"})
+	progress.recordPage(confluence.Page{ID: "1", Title: "Root", Version: 1, BodyADF: rawJSON(t, sampleChildADF())})
+	progress.close()
+
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified}},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Root", Version: 2, LastModified: modified, BodyADF: rawJSON(t, map[string]any{
+				"version": 1,
+				"type":    "doc",
+				"content": []any{map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "current body"}}}},
+			})},
+		},
+	}
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+	if fake.getPageCallCount["1"] != 1 {
+		t.Fatalf("GetPage calls = %v, want the newer version fetched", fake.getPageCallCount)
+	}
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Root.md")) //nolint:gosec // test temp dir
+	if err != nil || !strings.Contains(string(raw), "current body") {
+		t.Fatalf("Root.md = %q, %v; want the fetched version", raw, err)
+	}
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "PULL_RESUMED" {
+			t.Fatalf("stale progress must not be reported as resumed: %+v", diag)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// hasPathScope reports whether Include or Exclude narrows the pull.
+func (opts PullOptions) hasPathScope() bool {
+	return len(opts.Include) > 0 || len(opts.Exclude) > 0
+}
+
+// inPathScope reports whether a space-relative Markdown path matches an
+// Include glob (any path when there is none) and no Exclude glob.
+func (opts PullOptions) inPathScope(relPath string) bool {
+	if len(opts.Include) > 0 && !fs.MatchAnyRelPathGlob(opts.Include, relPath) {
+		return false
+	}
+	return !fs.MatchAnyRelPathGlob(opts.Exclude, relPath)
+}
+
+// scopePullPagePaths applies Include and Exclude to the planned page paths
+// and returns the IDs of the pages the pull must leave alone. A tracked page
+// out of scope keeps its tracked path, so it is neither rewritten, moved nor
+// deleted; an untracked one is dropped from the plan and stays unpulled.
+// Tracked pages deleted remotely whose file is out of scope are pinned to
+// their path too, with a PULL_DELETE_OUT_OF_SCOPE diagnostic each.
+func scopePullPagePaths(
+	opts PullOptions,
+	spaceDir string,
+	pagePathIndex map[string]string,
+	pageByID map[string]confluence.Page,
+	pagePathByIDAbs map[string]string,
+	pagePathByIDRel map[string]string,
+) (map[string]struct{}, []PullDiagnostic) {
+	outOfScope := map[string]struct{}{}
+	if !opts.hasPathScope() {
+		return outOfScope, nil
+	}
+
+	pin := func(pageID, relPath string) {
+		pagePathByIDRel[pageID] = relPath
+		pagePathByIDAbs[pageID] = filepath.Join(spaceDir, filepath.FromSlash(relPath))
+	}
+	for pageID, plannedPath := range pagePathByIDRel {
+		if opts.inPathScope(plannedPath) {
+			continue
+		}
+		outOfScope[pageID] = struct{}{}
+		if trackedPath, tracked := trackedPathForPageID(pagePathIndex, pageID); tracked {
+			pin(pageID, trackedPath)
+			continue
+		}
+		delete(pagePathByIDRel, pageID)
+		delete(pagePathByIDAbs, pageID)
+	}
+
+	var diagnostics []PullDiagnostic
+	for _, relPath := range sortedStringKeys(pagePathIndex) {
+		pageID := strings.TrimSpace(pagePathIndex[relPath])
+		relPath = normalizeRelPath(relPath)
+		if _, exists := pageByID[pageID]; exists || pageID == "" || opts.inPathScope(relPath) {
+			continue
+		}
+		outOfScope[pageID] = struct{}{}
+		pin(pageID, relPath)
+		diagnostics = append(diagnostics, PullDiagnostic{
+			Path:    relPath,
+			Code:    "PULL_DELETE_OUT_OF_SCOPE",
+			Message: fmt.Sprintf("page %s is no longer in the space but %s is outside the --include/--exclude scope; kept the local file", pageID, relPath),
+		})
+	}
+	return outOfScope, diagnostics
+}
+
+// keepUnlistedTrackedPages leaves the tracked pages a truncated listing did
+// not reach as they are: each keeps its tracked path and joins outOfScope,
+// so the pull neither rewrites nor deletes it.
+func keepUnlistedTrackedPages(
+	spaceDir string,
+	pagePathIndex map[string]string,
+	pageByID map[string]confluence.Page,
+	pagePathByIDAbs map[string]string,
+	pagePathByIDRel map[string]string,
+	outOfScope map[string]struct{},
+) {
+	for relPath, pageID := range pagePathIndex {
+		pageID = strings.TrimSpace(pageID)
+		if _, listed := pageByID[pageID]; listed || pageID == "" {
+			continue
+		}
+		relPath = normalizeRelPath(relPath)
+		outOfScope[pageID] = struct{}{}
+		pagePathByIDRel[pageID] = relPath
+		pagePathByIDAbs[pageID] = filepath.Join(spaceDir, filepath.FromSlash(relPath))
+	}
+}
+
+// dropOutOfScopePageIDs removes the pages scopePullPagePaths left alone.
+func dropOutOfScopePageIDs(pageIDs []string, outOfScope map[string]struct{}) []string {
+	if len(outOfScope) == 0 {
+		return pageIDs
+	}
+	kept := make([]string, 0, len(pageIDs))
+	for _, pageID := range pageIDs {
+		if _, skip := outOfScope[pageID]; !skip {
+			kept = append(kept, pageID)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,93 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestPull_IncludeLimitsWritesAndDeletesToMatchingPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	for relPath, doc := range map[string]fs.MarkdownDocument{
+		"Other.md": {Frontmatter: fs.Frontmatter{Title: "Other", ID: "3", Version: 1}, Body: "local other\n"},
+		"Gone.md":  {Frontmatter: fs.Frontmatter{Title: "Gone", ID: "4", Version: 1}, Body: "gone\n"},
+	} {
+		if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, relPath), doc); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Other.md": "3", "Gone.md": "4"}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	emptyADF := map[string]any{"version": 1, "type": "doc", "content": []any{}}
+	pages := []confluence.Page{
+		{ID: "1", SpaceID: "space-1", Title: "API", Version: 1, LastModified: modifiedAt},
+		{ID: "2", SpaceID: "space-1", Title: "Auth", ParentPageID: "1", ParentType: "page", Version: 1, LastModified: modifiedAt},
+		{ID: "3", SpaceID: "space-1", Title: "Other", Version: 2, LastModified: modifiedAt},
+	}
+	pagesByID := map[string]confluence.Page{}
+	for _, page := range pages {
+		page.BodyADF = rawJSON(t, emptyADF)
+		pagesByID[page.ID] = page
+	}
+	fake := &fakePullRemote{
+		space:     confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages:     pages,
+		pagesByID: pagesByID,
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    state,
+		Include:  []string{"API/**"},
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	for _, relPath := range []string{"API/API.md", "API/Auth.md"} {
+		if _, err := os.Stat(filepath.Join(spaceDir, filepath.FromSlash(relPath))); err != nil {
+			t.Fatalf("expected %s to be pulled: %v", relPath, err)
+		}
+	}
+	other, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Other.md"))
+	if err != nil {
+		t.Fatalf("read Other.md: %v", err)
+	}
+	if other.Frontmatter.Version != 1 || other.Body != "local other\n" {
+		t.Fatalf("Other.md outside --include was rewritten: %+v", other)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Gone.md")); err != nil {
+		t.Fatalf("Gone.md outside --include was deleted: %v", err)
+	}
+	if len(result.DeletedMarkdown) != 0 {
+		t.Fatalf("deleted markdown = %v, want none", result.DeletedMarkdown)
+	}
+	if got := result.State.PagePathIndex["Gone.md"]; got != "4" {
+		t.Fatalf("state lost out-of-scope page: %v", result.State.PagePathIndex)
+	}
+	if got := result.State.PagePathIndex["Other.md"]; got != "3" {
+		t.Fatalf("state moved out-of-scope page: %v", result.State.PagePathIndex)
+	}
+
+	found := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "PULL_DELETE_OUT_OF_SCOPE" && diag.Path == "Gone.md" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected PULL_DELETE_OUT_OF_SCOPE for Gone.md, got %+v", result.Diagnostics)
+	}
+}
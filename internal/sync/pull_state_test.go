@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -159,10 +160,10 @@ func TestListAllChanges_UsesContinuationOffsets(t *testing.T) {
 		},
 	}
 
-	changes, err := listAllChanges(context.Background(), remote, confluence.ChangeListOptions{
+	changes, _, err := listAllChanges(context.Background(), remote, confluence.ChangeListOptions{
 		SpaceKey: "ENG",
 		Limit:    25,
-	}, nil)
+	}, nil, time.Now)
 	if err != nil {
 		t.Fatalf("listAllChanges() error: %v", err)
 	}
@@ -179,6 +180,143 @@ func TestListAllChanges_UsesContinuationOffsets(t *testing.T) {
 	}
 }
 
+func cappedChangeRemote(changes []confluence.Change, resultCap int) *fakePullRemote {
+	return &fakePullRemote{
+		listChangesFunc: func(opts confluence.ChangeListOptions) (confluence.ChangeListResult, error) {
+			matches := []confluence.Change{}
+			for _, change := range changes {
+				if change.LastModified.Before(opts.Since) {
+					continue
+				}
+				if !opts.Until.IsZero() && !change.LastModified.Before(opts.Until) {
+					continue
+				}
+				matches = append(matches, change)
+			}
+			returned := matches
+			if len(returned) > resultCap {
+				returned = returned[:resultCap]
+			}
+			return confluence.ChangeListResult{Changes: returned, TotalSize: len(matches)}, nil
+		},
+	}
+}
+
+func TestListAllChanges_BisectsWindowWhenResultCapIsHit(t *testing.T) {
+	since := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	changes := make([]confluence.Change, 0, 6)
+	for i := range 6 {
+		changes = append(changes, confluence.Change{
+			PageID:       fmt.Sprintf("%d", i+1),
+			LastModified: since.Add(time.Duration(i*10) * time.Minute),
+		})
+	}
+
+	got, diags, err := listAllChanges(context.Background(), cappedChangeRemote(changes, 2), confluence.ChangeListOptions{
+		SpaceKey: "ENG",
+		Since:    since,
+		Until:    since.Add(time.Hour),
+		Limit:    25,
+	}, nil, time.Now)
+	if err != nil {
+		t.Fatalf("listAllChanges() error: %v", err)
+	}
+
+	ids := map[string]struct{}{}
+	for _, change := range got {
+		ids[change.PageID] = struct{}{}
+	}
+	if len(ids) != 6 || len(got) != 6 {
+		t.Fatalf("changes = %+v, want all 6 pages exactly once", got)
+	}
+	if len(diags) != 1 || diags[0].Code != "CHANGE_LIST_WINDOW_BISECTED" {
+		t.Fatalf("diagnostics = %+v, want CHANGE_LIST_WINDOW_BISECTED", diags)
+	}
+}
+
+func TestListAllChanges_BisectsOpenWindowUpToInjectedClock(t *testing.T) {
+	since := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	changes := make([]confluence.Change, 0, 6)
+	for i := range 6 {
+		changes = append(changes, confluence.Change{
+			PageID:       fmt.Sprintf("%d", i+1),
+			LastModified: since.Add(time.Duration(i*10) * time.Minute),
+		})
+	}
+
+	now := func() time.Time { return since.Add(time.Hour) }
+	got, _, err := listAllChanges(context.Background(), cappedChangeRemote(changes, 2), confluence.ChangeListOptions{
+		SpaceKey: "ENG",
+		Since:    since,
+		Limit:    25,
+	}, nil, now)
+	if err != nil {
+		t.Fatalf("listAllChanges() error: %v", err)
+	}
+	if len(got) != 6 {
+		t.Fatalf("changes = %+v, want all 6 pages", got)
+	}
+}
+
+func TestPull_UsesInjectedClockForHighWatermark(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Plan body</p>"},
+		},
+	}
+
+	fixedNow := time.Date(2026, time.March, 7, 8, 30, 0, 0, time.UTC)
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+		Now:      func() time.Time { return fixedNow },
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+	if got := result.State.LastPullHighWatermark; got != "2026-03-07T08:30:00Z" {
+		t.Fatalf("LastPullHighWatermark = %q, want the injected clock time", got)
+	}
+}
+
+func TestListAllChanges_ReportsCapThatCannotBeNarrowed(t *testing.T) {
+	since := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	changes := []confluence.Change{
+		{PageID: "1", LastModified: since},
+		{PageID: "2", LastModified: since.Add(10 * time.Second)},
+		{PageID: "3", LastModified: since.Add(20 * time.Second)},
+	}
+
+	_, diags, err := listAllChanges(context.Background(), cappedChangeRemote(changes, 2), confluence.ChangeListOptions{
+		SpaceKey: "ENG",
+		Since:    since,
+		Until:    since.Add(4 * time.Minute),
+		Limit:    25,
+	}, nil, time.Now)
+	if err != nil {
+		t.Fatalf("listAllChanges() error: %v", err)
+	}
+
+	codes := []string{}
+	for _, diag := range diags {
+		codes = append(codes, diag.Code)
+	}
+	if !slices.Contains(codes, "CHANGE_LIST_CAP_UNRESOLVED") {
+		t.Fatalf("diagnostic codes = %v, want CHANGE_LIST_CAP_UNRESOLVED", codes)
+	}
+}
+
 func TestPull_DraftRecovery(t *testing.T) {
 	tmpDir := t.TempDir()
 	spaceDir := filepath.Join(tmpDir, "ENG")
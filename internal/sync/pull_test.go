@@ -152,3 +152,390 @@ func findPullDiagnostic(diags []PullDiagnostic, code string) *PullDiagnostic {
 	}
 	return nil
 }
+
+func TestPull_ReportsMacroPassthroughDiagnostic(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	macroADF := map[string]any{
+		"version": 1,
+		"type":    "doc",
+		"content": []any{
+			map[string]any{
+				"type": "extension",
+				"attrs": map[string]any{
+					"extensionType": "com.atlassian.confluence.macro.core",
+					"extensionKey":  "jira",
+					"parameters":    map[string]any{"macroParams": map[string]any{"key": map[string]any{"value": "ENG-1"}}},
+				},
+			},
+		},
+	}
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Macros", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Macros", Version: 1, LastModified: modifiedAt, BodyADF: rawJSON(t, macroADF)},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	diag := findPullDiagnostic(result.Diagnostics, "MACRO_PASSTHROUGH")
+	if diag == nil {
+		t.Fatalf("expected MACRO_PASSTHROUGH diagnostic, got %+v", result.Diagnostics)
+	}
+	if diag.Path != "Macros.md" || !strings.Contains(diag.Message, "jira") {
+		t.Fatalf("unexpected macro diagnostic: %+v", *diag)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Macros.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Macros.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "```adf:extension") {
+		t.Fatalf("expected raw ADF passthrough fence, got:\n%s", string(raw))
+	}
+}
+
+func TestPull_WritesRestrictionsToFrontmatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Secret", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Secret", Version: 1, LastModified: modifiedAt, BodyADF: rawJSON(t, map[string]any{"version": 1, "type": "doc", "content": []any{}})},
+		},
+		restrictions: map[string]confluence.PageRestrictions{
+			"1": {Read: []string{"user:acct-2", "group:legal"}},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Secret.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Secret.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "restrictions:\n    read:\n        - group:legal\n        - user:acct-2\n") {
+		t.Fatalf("expected read restrictions in frontmatter, got:\n%s", raw)
+	}
+
+	var restricted *PullDiagnostic
+	for i := range result.Diagnostics {
+		if result.Diagnostics[i].Code == "RESTRICTED_PAGE" {
+			restricted = &result.Diagnostics[i]
+		}
+	}
+	if restricted == nil {
+		t.Fatalf("expected RESTRICTED_PAGE diagnostic, got %+v", result.Diagnostics)
+	}
+	if restricted.Path != "Secret.md" || !strings.Contains(restricted.Message, "read: group:legal, user:acct-2; update: anyone") {
+		t.Fatalf("RESTRICTED_PAGE diagnostic = %+v", *restricted)
+	}
+}
+
+func TestPull_ConvertsStorageBodyWhenADFIsEmpty(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Legacy", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Legacy", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Old <strong>page</strong></p>"},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Legacy.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Legacy.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "Old **page**") {
+		t.Fatalf("expected storage body converted to Markdown, got:\n%s", raw)
+	}
+
+	diag := findPullDiagnostic(result.Diagnostics, "storage_body_fallback")
+	if diag == nil || diag.Path != "Legacy.md" {
+		t.Fatalf("expected storage_body_fallback diagnostic for Legacy.md, got %+v", result.Diagnostics)
+	}
+}
+
+func TestPull_KeepsLocalPageWhenStorageFallbackFails(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	legacyPath := filepath.Join(spaceDir, "Legacy.md")
+	if err := fs.WriteMarkdownDocument(legacyPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Legacy", ID: "1", Version: 1},
+		Body:        "legacy body\n",
+	}); err != nil {
+		t.Fatalf("write Legacy.md: %v", err)
+	}
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Legacy.md": "1"}
+	state.PageETags = map[string]string{"1": `"v1"`}
+	state.LastPullHighWatermark = "2026-03-01T00:00:00Z"
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Legacy", Version: 2, LastModified: modifiedAt},
+			{ID: "2", SpaceID: "space-1", Title: "Current", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Legacy", Version: 2, LastModified: modifiedAt, ETag: `"v2"`, BodyStorageError: "status 403"},
+			"2": {ID: "2", SpaceID: "space-1", Title: "Current", Version: 1, LastModified: modifiedAt, BodyADF: []byte(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"current body"}]}]}`)},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{SpaceKey: "ENG", SpaceDir: spaceDir, State: state})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+	if len(result.UpdatedMarkdown) != 1 || result.UpdatedMarkdown[0] != "Current.md" {
+		t.Fatalf("updated markdown = %v, want only Current.md", result.UpdatedMarkdown)
+	}
+	diag := findPullDiagnostic(result.Diagnostics, "PAGE_BODY_FETCH_FAILED")
+	if diag == nil || !strings.Contains(diag.Message, "(status 403)") {
+		t.Fatalf("expected PAGE_BODY_FETCH_FAILED naming the fetch error, got %+v", result.Diagnostics)
+	}
+	doc, err := fs.ReadMarkdownDocument(legacyPath)
+	if err != nil {
+		t.Fatalf("read Legacy.md: %v", err)
+	}
+	if doc.Frontmatter.Version != 1 || doc.Body != "legacy body\n" {
+		t.Fatalf("Legacy.md was rewritten: version %d, body %q", doc.Frontmatter.Version, doc.Body)
+	}
+	if result.State.PagePathIndex["Legacy.md"] != "1" || result.State.PageETags["1"] != `"v1"` {
+		t.Fatalf("state advanced for the failed page: index %v, etags %v", result.State.PagePathIndex, result.State.PageETags)
+	}
+
+	// Once the storage body can be read, the next incremental pull fetches
+	// the page again because its local version is still behind.
+	legacy := fake.pagesByID["1"]
+	legacy.BodyStorageError = ""
+	legacy.BodyStorage = "<p>restored body</p>"
+	fake.pagesByID["1"] = legacy
+	result, err = Pull(context.Background(), fake, PullOptions{SpaceKey: "ENG", SpaceDir: spaceDir, State: result.State})
+	if err != nil {
+		t.Fatalf("second Pull() error: %v", err)
+	}
+	doc, err = fs.ReadMarkdownDocument(legacyPath)
+	if err != nil {
+		t.Fatalf("read Legacy.md: %v", err)
+	}
+	if doc.Frontmatter.Version != 2 || !strings.Contains(doc.Body, "restored body") {
+		t.Fatalf("second pull did not refetch Legacy.md: version %d, body %q", doc.Frontmatter.Version, doc.Body)
+	}
+}
+
+type commentFakePullRemote struct {
+	*fakePullRemote
+	comments map[string][]confluence.Comment
+}
+
+func (f *commentFakePullRemote) ListFooterComments(_ context.Context, pageID string) ([]confluence.Comment, error) {
+	return f.comments[pageID], nil
+}
+
+func TestPull_WritesFooterCommentsSidecarWhenEnabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	// A stale sidecar of a page that no longer has comments must go away.
+	if err := os.WriteFile(filepath.Join(spaceDir, "Quiet.comments.md"), []byte("old\n"), 0o600); err != nil {
+		t.Fatalf("write stale sidecar: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	commentADF := []byte(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Please expand the rollout section."}]}]}`)
+	fake := &commentFakePullRemote{
+		fakePullRemote: &fakePullRemote{
+			space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+			pages: []confluence.Page{
+				{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt},
+				{ID: "2", SpaceID: "space-1", Title: "Quiet", Version: 1, LastModified: modifiedAt},
+			},
+			pagesByID: map[string]confluence.Page{
+				"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Plan body</p>"},
+				"2": {ID: "2", SpaceID: "space-1", Title: "Quiet", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Quiet body</p>"},
+			},
+		},
+		comments: map[string][]confluence.Comment{
+			"1": {{ID: "c-1", PageID: "1", AuthorID: "acc-1", CreatedAt: modifiedAt, BodyADF: commentADF}},
+		},
+	}
+
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		State:    fs.NewSpaceState(),
+		Comments: true,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Plan.comments.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Plan.comments.md: %v", err)
+	}
+	for _, want := range []string{"# Comments on Plan", "## User acc-1 - 2026-03-06T12:00:00Z", "Please expand the rollout section."} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("sidecar missing %q, got:\n%s", want, raw)
+		}
+	}
+	page, err := os.ReadFile(filepath.Join(spaceDir, "Plan.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Plan.md: %v", err)
+	}
+	if strings.Contains(string(page), "rollout") {
+		t.Fatalf("comments leaked into the page body:\n%s", page)
+	}
+	if _, err := os.Stat(filepath.Join(spaceDir, "Quiet.comments.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected stale sidecar of a page without comments to be removed, stat err = %v", err)
+	}
+	for _, path := range result.UpdatedMarkdown {
+		if fs.IsCommentsSidecar(path) {
+			t.Fatalf("sidecar reported as updated page markdown: %v", result.UpdatedMarkdown)
+		}
+	}
+}
+
+func TestPull_RefreshesCommentsSidecarOfUnchangedPage(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	fake := &commentFakePullRemote{
+		fakePullRemote: &fakePullRemote{
+			space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+			pages: []confluence.Page{{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt}},
+			pagesByID: map[string]confluence.Page{
+				"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt, BodyStorage: "<p>Plan body</p>"},
+			},
+		},
+		comments: map[string][]confluence.Comment{},
+	}
+	opts := PullOptions{SpaceKey: "ENG", SpaceDir: spaceDir, State: fs.NewSpaceState(), Comments: true}
+	first, err := Pull(context.Background(), fake, opts)
+	if err != nil {
+		t.Fatalf("first Pull() error: %v", err)
+	}
+
+	// A new comment does not bump the page version.
+	fake.comments["1"] = []confluence.Comment{{
+		ID: "c-1", PageID: "1", AuthorID: "acc-1", CreatedAt: modifiedAt,
+		BodyADF: []byte(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Looks good."}]}]}`),
+	}}
+	opts.State = first.State
+	second, err := Pull(context.Background(), fake, opts)
+	if err != nil {
+		t.Fatalf("second Pull() error: %v", err)
+	}
+	if len(second.UpdatedMarkdown) != 0 {
+		t.Fatalf("unchanged page was rewritten: %v", second.UpdatedMarkdown)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(spaceDir, "Plan.comments.md")) //nolint:gosec // test path is controlled
+	if err != nil {
+		t.Fatalf("read Plan.comments.md: %v", err)
+	}
+	if !strings.Contains(string(raw), "Looks good.") {
+		t.Fatalf("sidecar missing the new comment:\n%s", raw)
+	}
+}
+
+func TestPull_WritesPageURLToFrontmatterWhenEnabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	spaceDir := filepath.Join(tmpDir, "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	webURL := "https://example.atlassian.net/wiki/spaces/ENG/pages/1/Plan"
+	fake := &fakePullRemote{
+		space: confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages: []confluence.Page{
+			{ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt},
+		},
+		pagesByID: map[string]confluence.Page{
+			"1": {ID: "1", SpaceID: "space-1", Title: "Plan", Version: 1, LastModified: modifiedAt, WebURL: webURL, BodyStorage: "<p>Plan body</p>"},
+		},
+	}
+
+	for _, enabled := range []bool{false, true} {
+		if _, err := Pull(context.Background(), fake, PullOptions{
+			SpaceKey:  "ENG",
+			SpaceDir:  spaceDir,
+			State:     fs.NewSpaceState(),
+			ForceFull: true,
+			PageURL:   enabled,
+		}); err != nil {
+			t.Fatalf("Pull(PageURL=%v) error: %v", enabled, err)
+		}
+
+		doc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Plan.md"))
+		if err != nil {
+			t.Fatalf("read Plan.md: %v", err)
+		}
+		want := ""
+		if enabled {
+			want = webURL
+		}
+		if doc.Frontmatter.URL != want {
+			t.Fatalf("PageURL=%v: frontmatter url = %q, want %q", enabled, doc.Frontmatter.URL, want)
+		}
+	}
+}
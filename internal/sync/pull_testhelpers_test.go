@@ -15,6 +15,7 @@ type fakePullRemote struct {
 	mu                gosync.Mutex
 	space             confluence.Space
 	pages             []confluence.Page
+	listPagesFunc     func(opts confluence.PageListOptions) (confluence.PageListResult, error)
 	folderByID        map[string]confluence.Folder
 	folderErr         error
 	getFolderCalls    []string
@@ -24,12 +25,14 @@ type fakePullRemote struct {
 	attachments       map[string][]byte
 	attachmentsByPage map[string][]confluence.Attachment
 	labels            map[string][]string
+	restrictions      map[string]confluence.PageRestrictions
 	users             map[string]confluence.User
 	contentStatuses   map[string]string
 	contentStatusErr  error
 	getStatusCalls    []string
 	lastChangeSince   time.Time
 	getPageHook       func(pageID string)
+	downloadHook      func(attachmentID string)
 	getPageFunc       func(pageID string) (confluence.Page, error)
 	getPageCallCount  map[string]int
 }
@@ -49,7 +52,10 @@ func (f *fakePullRemote) GetSpace(_ context.Context, _ string) (confluence.Space
 	return f.space, nil
 }
 
-func (f *fakePullRemote) ListPages(_ context.Context, _ confluence.PageListOptions) (confluence.PageListResult, error) {
+func (f *fakePullRemote) ListPages(_ context.Context, opts confluence.PageListOptions) (confluence.PageListResult, error) {
+	if f.listPagesFunc != nil {
+		return f.listPagesFunc(opts)
+	}
 	return confluence.PageListResult{Pages: f.pages}, nil
 }
 
@@ -116,6 +122,13 @@ func (f *fakePullRemote) GetLabels(_ context.Context, pageID string) ([]string,
 	return f.labels[pageID], nil
 }
 
+func (f *fakePullRemote) GetRestrictions(_ context.Context, pageID string) (confluence.PageRestrictions, error) {
+	if f.restrictions == nil {
+		return confluence.PageRestrictions{}, nil
+	}
+	return f.restrictions[pageID], nil
+}
+
 func (f *fakePullRemote) ListAttachments(_ context.Context, pageID string) ([]confluence.Attachment, error) {
 	if f.attachmentsByPage == nil {
 		return nil, nil
@@ -125,6 +138,9 @@ func (f *fakePullRemote) ListAttachments(_ context.Context, pageID string) ([]co
 }
 
 func (f *fakePullRemote) DownloadAttachment(_ context.Context, attachmentID string, pageID string, out io.Writer) error {
+	if f.downloadHook != nil {
+		f.downloadHook(attachmentID)
+	}
 	raw, ok := f.attachments[attachmentID]
 	if !ok {
 		return confluence.ErrNotFound
@@ -78,7 +78,8 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 	if opts.folderMode == tenantFolderModePageFallback {
 		folderIDByPath = map[string]string{}
 	}
-	changes := normalizePushChanges(opts.Changes)
+	changes, skippedDiags := skipFrontmatterExcludedPushChanges(spaceDir, normalizePushChanges(opts.Changes))
+	diagnostics = append(diagnostics, skippedDiags...)
 	commits := make([]PushCommitPlan, 0, len(changes))
 	opts.contentStatusMode, err = capabilities.detectPushContentStatusMode(ctx, remote, opts.SpaceDir, pages, changes)
 	if err != nil {
@@ -88,6 +89,15 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 	if err := seedPendingPageIDsForPushChanges(opts.SpaceDir, changes, pageIDByPath); err != nil {
 		return PushResult{}, fmt.Errorf("seed pending page ids: %w", err)
 	}
+	if opts.ParentByTitle {
+		seedParentPagesByTitle(changes, pageIDByPath, folderIDByPath, remotePageByID, opts.OrderPrefix, opts.FilenameMode, &diagnostics)
+	}
+	opts.newPageParentID, err = resolveNewPageParent(ctx, remote, space.ID, opts.NewPageParent, pageIDByPath)
+	if err != nil {
+		return PushResult{State: state, Diagnostics: diagnostics}, err
+	}
+	opts.createdPageIDs = map[string]struct{}{}
+	opts.assetContents = assetContentCache{}
 	if opts.contentStatusMode != tenantContentStatusModeDisabled {
 		opts.contentStateCatalog, err = buildPushContentStateCatalog(ctx, remote, opts.SpaceKey, opts.SpaceDir, changes, pageIDByPath)
 		if err != nil {
@@ -110,12 +120,14 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 		pageIDByPath,
 		pageTitleByPath,
 		folderIDByPath,
+		remotePageByID,
 		&diagnostics,
 	)
 	if err != nil {
 		return PushResult{}, err
 	}
 	pendingPrecreatedPages := clonePageMap(precreatedPages)
+	var failures []PushFailure
 
 	if opts.Progress != nil {
 		opts.Progress.SetDescription("Pushing changes")
@@ -135,10 +147,14 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 		case PushChangeDelete:
 			commit, err := pushDeletePage(ctx, remote, opts, state, attachmentIDByPath, remotePageByID, relPath, &diagnostics)
 			if err != nil {
+				if opts.ContinueOnError && isRecoverablePushFailure(err) {
+					failures = append(failures, PushFailure{Path: relPath, Type: change.Type, Err: err})
+					break
+				}
 				if !opts.DryRun {
 					cleanupPendingPrecreatedPages(ctx, remote, pendingPrecreatedPages, &diagnostics)
 				}
-				return PushResult{State: state, Commits: commits, Diagnostics: diagnostics}, err
+				return PushResult{State: state, Commits: commits, Diagnostics: diagnostics, Failures: failures}, err
 			}
 			if commit.Path != "" {
 				commits = append(commits, commit)
@@ -163,10 +179,14 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 				&diagnostics,
 			)
 			if err != nil {
+				if opts.ContinueOnError && isRecoverablePushFailure(err) {
+					failures = append(failures, PushFailure{Path: relPath, Type: change.Type, Err: err})
+					break
+				}
 				if !opts.DryRun {
 					cleanupPendingPrecreatedPages(ctx, remote, pendingPrecreatedPages, &diagnostics)
 				}
-				return PushResult{State: state, Commits: commits, Diagnostics: diagnostics}, err
+				return PushResult{State: state, Commits: commits, Diagnostics: diagnostics, Failures: failures}, err
 			}
 			if commit.Path != "" {
 				commits = append(commits, commit)
@@ -194,9 +214,25 @@ func Push(ctx context.Context, remote PushRemote, opts PushOptions) (PushResult,
 		State:       state,
 		Commits:     commits,
 		Diagnostics: diagnostics,
+		Failures:    failures,
 	}, nil
 }
 
+// isRecoverablePushFailure reports whether a per-page error can be recorded
+// as a PushFailure under ContinueOnError. Conflicts and folder fallbacks need
+// the caller to resolve them, and a cancelled context fails every later page.
+func isRecoverablePushFailure(err error) bool {
+	var conflictErr *PushConflictError
+	var fallbackErr *FolderPageFallbackRequiredError
+	switch {
+	case errors.As(err, &conflictErr), errors.As(err, &fallbackErr):
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	}
+	return true
+}
+
 func republishUntilMediaResolvable(
 	ctx context.Context,
 	remote PushRemote,
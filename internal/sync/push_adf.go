@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -11,6 +12,68 @@ import (
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
+// adfDocVersion is the only ADF document version Confluence accepts.
+const adfDocVersion = 1
+
+// normalizeADFDocRoot guarantees that adfJSON is a `doc` node with version 1
+// before it is sent to Confluence. A missing or wrong version is corrected,
+// and a bare block node or node list is wrapped in a doc. Anything that cannot
+// be turned into a doc is reported instead of being rejected opaquely by the
+// API.
+func normalizeADFDocRoot(adfJSON []byte) ([]byte, error) {
+	if len(adfJSON) == 0 {
+		return adfJSON, nil
+	}
+
+	var root any
+	if err := json.Unmarshal(adfJSON, &root); err != nil {
+		return nil, fmt.Errorf("invalid ADF document: %w", err)
+	}
+
+	var doc map[string]any
+	modified := false
+	switch node := root.(type) {
+	case []any:
+		doc = map[string]any{"type": "doc", "content": node}
+		modified = true
+	case map[string]any:
+		nodeType, _ := node["type"].(string)
+		switch {
+		case nodeType == "doc":
+			doc = node
+		case nodeType != "":
+			doc = map[string]any{"type": "doc", "content": []any{node}}
+			modified = true
+		default:
+			return nil, errors.New("invalid ADF document: root node has no type")
+		}
+	default:
+		return nil, fmt.Errorf("invalid ADF document: root must be a doc node, got %T", root)
+	}
+
+	if version, ok := doc["version"].(float64); !ok || version != adfDocVersion {
+		doc["version"] = adfDocVersion
+		modified = true
+	}
+	switch doc["content"].(type) {
+	case []any:
+	case nil:
+		doc["content"] = []any{}
+		modified = true
+	default:
+		return nil, fmt.Errorf("invalid ADF document: doc content must be a list of nodes, got %T", doc["content"])
+	}
+	if !modified {
+		return adfJSON, nil
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal ADF: %w", err)
+	}
+	return out, nil
+}
+
 // ensureADFMediaCollection post-processes media nodes with the collection and
 // attachment metadata Confluence needs to preserve uploaded attachments.
 func ensureADFMediaCollection(adfJSON []byte, pageID string, refsByPath map[string]publishedAttachmentRef) ([]byte, error) {
@@ -36,7 +99,10 @@ func ensureADFMediaCollection(adfJSON []byte, pageID string, refsByPath map[stri
 		}
 	}
 
-	modified := walkAndFixMediaNodes(root, pageID, refByID)
+	modified := groupMediaInlineParagraphs(root)
+	if walkAndFixMediaNodes(root, pageID, refByID) {
+		modified = true
+	}
 	var dateProtected bool
 	root, dateProtected = protectLiteralISODateText(root, false)
 	modified = modified || dateProtected
@@ -51,6 +117,84 @@ func ensureADFMediaCollection(adfJSON []byte, pageID string, refsByPath map[stri
 	return out, nil
 }
 
+// groupMediaInlineParagraphs turns paragraphs holding nothing but two or more
+// file attachment links (one per line in Markdown) into a mediaGroup, the
+// block Confluence uses for a row of attached files. Pull renders each
+// mediaGroup item as its own file link, so this restores the original block.
+// Embedded images keep their inline placement.
+func groupMediaInlineParagraphs(node any) bool {
+	modified := false
+	switch n := node.(type) {
+	case map[string]any:
+		if content, ok := n["content"].([]any); ok {
+			for i, item := range content {
+				if group, ok := mediaGroupFromParagraph(item); ok {
+					content[i] = group
+					modified = true
+				}
+			}
+		}
+		for _, v := range n {
+			if groupMediaInlineParagraphs(v) {
+				modified = true
+			}
+		}
+	case []any:
+		for _, item := range n {
+			if groupMediaInlineParagraphs(item) {
+				modified = true
+			}
+		}
+	}
+	return modified
+}
+
+func mediaGroupFromParagraph(node any) (map[string]any, bool) {
+	paragraph, ok := node.(map[string]any)
+	if !ok || stringValue(paragraph["type"]) != "paragraph" {
+		return nil, false
+	}
+	content, ok := paragraph["content"].([]any)
+	if !ok {
+		return nil, false
+	}
+
+	media := make([]any, 0, len(content))
+	for _, item := range content {
+		child, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		switch stringValue(child["type"]) {
+		case "mediaInline":
+			if _, hasMarks := child["marks"]; hasMarks {
+				return nil, false
+			}
+			attrs, _ := child["attrs"].(map[string]any)
+			if mediaType := stringValue(attrs["type"]); mediaType != "" && mediaType != "file" {
+				return nil, false
+			}
+			groupAttrs := make(map[string]any, len(attrs)+1)
+			for key, value := range attrs {
+				groupAttrs[key] = value
+			}
+			groupAttrs["type"] = "file"
+			media = append(media, map[string]any{"type": "media", "attrs": groupAttrs})
+		case "hardBreak":
+		case "text":
+			if strings.TrimSpace(stringValue(child["text"])) != "" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	if len(media) < 2 {
+		return nil, false
+	}
+	return map[string]any{"type": "mediaGroup", "content": media}, true
+}
+
 var literalISODatePattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
 
 const invisibleDateGuard = "\u2060"
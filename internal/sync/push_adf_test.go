@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
@@ -168,13 +171,65 @@ func TestEnsureADFMediaCollection_SplitsLiteralISODateTextNodes(t *testing.T) {
 	}
 }
 
+func TestNormalizeADFDocRoot_AddsMissingVersion(t *testing.T) {
+	out, err := normalizeADFDocRoot([]byte(`{"type":"doc","content":[{"type":"paragraph"}]}`))
+	if err != nil {
+		t.Fatalf("normalizeADFDocRoot() error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc["type"] != "doc" || doc["version"] != float64(1) {
+		t.Fatalf("normalized doc = %s, want type doc and version 1", out)
+	}
+
+	fixed, err := normalizeADFDocRoot([]byte(`{"type":"doc","version":2,"content":[]}`))
+	if err != nil {
+		t.Fatalf("normalizeADFDocRoot() wrong version error: %v", err)
+	}
+	if !strings.Contains(string(fixed), `"version":1`) {
+		t.Fatalf("expected wrong version to be corrected, got %s", fixed)
+	}
+
+	valid := []byte(`{"type":"doc","version":1,"content":[]}`)
+	unchanged, err := normalizeADFDocRoot(valid)
+	if err != nil || string(unchanged) != string(valid) {
+		t.Fatalf("valid doc should pass through unchanged, got %s (err %v)", unchanged, err)
+	}
+}
+
+func TestNormalizeADFDocRoot_WrapsOrRejectsNonDocRoot(t *testing.T) {
+	out, err := normalizeADFDocRoot([]byte(`{"type":"paragraph","content":[{"type":"text","text":"hi"}]}`))
+	if err != nil {
+		t.Fatalf("normalizeADFDocRoot() error: %v", err)
+	}
+	var doc struct {
+		Type    string           `json:"type"`
+		Version int              `json:"version"`
+		Content []map[string]any `json:"content"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.Type != "doc" || doc.Version != 1 || len(doc.Content) != 1 || doc.Content[0]["type"] != "paragraph" {
+		t.Fatalf("expected paragraph wrapped in a version 1 doc, got %s", out)
+	}
+
+	for _, raw := range []string{`{"content":[]}`, `"doc"`, `{"type":"doc","version":1,"content":"text"}`, `{`} {
+		if _, err := normalizeADFDocRoot([]byte(raw)); err == nil || !strings.Contains(err.Error(), "invalid ADF document") {
+			t.Fatalf("normalizeADFDocRoot(%s) error = %v, want invalid ADF document", raw, err)
+		}
+	}
+}
+
 func TestSyncPageMetadata_EquivalentLabelSetsDoNotChurn(t *testing.T) {
 	remote := newRollbackPushRemote()
 	remote.labelsByPage["1"] = []string{"ops", "team"}
 
 	doc := fs.MarkdownDocument{
 		Frontmatter: fs.Frontmatter{
-			Labels: []string{" team ", "OPS", "team"},
+			Labels: []string{" team ", "OPS", "team", "global:ops"},
 		},
 	}
 
@@ -323,3 +378,161 @@ func TestSyncPageMetadata_UsesNameOnlyFallbackWhenCatalogUnavailable(t *testing.
 		t.Fatalf("unexpected content status call: %+v", got)
 	}
 }
+
+func TestMediaGroup_RoundTripsEveryAttachment(t *testing.T) {
+	spaceDir := t.TempDir()
+	sourcePath := filepath.Join(spaceDir, "page.md")
+
+	attachmentPathByID := map[string]string{}
+	attachmentIndex := map[string]string{}
+	items := make([]string, 0, 5)
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("file-%d.pdf", i)
+		if i%2 == 0 {
+			name = fmt.Sprintf("shot-%d.png", i)
+		}
+		attachmentID := fmt.Sprintf("att%d", i)
+		relPath := "assets/1/" + attachmentID + "-" + name
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+			t.Fatalf("mkdir assets: %v", err)
+		}
+		if err := os.WriteFile(absPath, []byte("x"), 0o600); err != nil {
+			t.Fatalf("write asset: %v", err)
+		}
+		attachmentPathByID[attachmentID] = absPath
+		attachmentIndex[relPath] = attachmentID
+		items = append(items, fmt.Sprintf(`{"type":"media","attrs":{"type":"file","id":%q,"collection":"contentId-1","__fileName":%q}}`, attachmentID, name))
+	}
+	adf := `{"version":1,"type":"doc","content":[{"type":"mediaGroup","content":[` + strings.Join(items, ",") + `]}]}`
+
+	forward, err := converter.Forward(context.Background(), []byte(adf), converter.ForwardConfig{
+		MediaHook: NewForwardMediaHook(sourcePath, attachmentPathByID),
+	}, sourcePath)
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	for relPath := range attachmentIndex {
+		if !strings.Contains(forward.Markdown, "("+relPath+")") {
+			t.Fatalf("forward markdown missing reference to %s:\n%s", relPath, forward.Markdown)
+		}
+	}
+
+	prepared, err := PrepareMarkdownForAttachmentConversion(spaceDir, sourcePath, forward.Markdown, attachmentIndex)
+	if err != nil {
+		t.Fatalf("PrepareMarkdownForAttachmentConversion() error: %v", err)
+	}
+	reverse, err := converter.Reverse(context.Background(), []byte(prepared), converter.ReverseConfig{
+		LinkHook:  NewReverseLinkHook(spaceDir, nil, ""),
+		MediaHook: NewReverseMediaHook(spaceDir, attachmentIndex),
+	}, sourcePath)
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	out, err := ensureADFMediaCollection(reverse.ADF, "1", nil)
+	if err != nil {
+		t.Fatalf("ensureADFMediaCollection() error: %v", err)
+	}
+
+	var doc struct {
+		Content []struct {
+			Type    string `json:"type"`
+			Content []struct {
+				Type  string         `json:"type"`
+				Attrs map[string]any `json:"attrs"`
+			} `json:"content"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Type != "mediaGroup" {
+		t.Fatalf("expected a single mediaGroup block, got %s", out)
+	}
+	if len(doc.Content[0].Content) != 5 {
+		t.Fatalf("mediaGroup has %d items, want 5: %s", len(doc.Content[0].Content), out)
+	}
+	for i, media := range doc.Content[0].Content {
+		wantID := fmt.Sprintf("att%d", i+1)
+		if media.Type != "media" || media.Attrs["id"] != wantID || media.Attrs["type"] != "file" || media.Attrs["collection"] != "contentId-1" {
+			t.Fatalf("mediaGroup item %d = %s %v, want media %s", i, media.Type, media.Attrs, wantID)
+		}
+	}
+}
+
+func TestEnsureADFMediaCollection_KeepsInlineMediaWithProse(t *testing.T) {
+	adf := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"See "},{"type":"mediaInline","attrs":{"id":"att1"}},{"type":"text","text":" and "},{"type":"mediaInline","attrs":{"id":"att2"}}]}]}`
+
+	got, err := ensureADFMediaCollection([]byte(adf), "1", nil)
+	if err != nil {
+		t.Fatalf("ensureADFMediaCollection() error: %v", err)
+	}
+	if strings.Contains(string(got), "mediaGroup") {
+		t.Fatalf("paragraph with prose must stay inline: %s", got)
+	}
+}
+
+func TestMediaInline_RoundTripsAsInlineFileLink(t *testing.T) {
+	spaceDir := t.TempDir()
+	sourcePath := filepath.Join(spaceDir, "page.md")
+
+	relPath := "assets/1/att1-diagram.png"
+	absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	if err := os.WriteFile(absPath, []byte("x"), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+	attachmentIndex := map[string]string{relPath: "att1"}
+
+	// Confluence often omits the type on inline file chips.
+	adf := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"See "},{"type":"mediaInline","attrs":{"id":"att1","collection":"contentId-1"}},{"type":"text","text":" for details."}]}]}`
+
+	forward, err := converter.Forward(context.Background(), []byte(adf), converter.ForwardConfig{
+		MediaHook: NewForwardMediaHook(sourcePath, map[string]string{"att1": absPath}),
+	}, sourcePath)
+	if err != nil {
+		t.Fatalf("Forward() error: %v", err)
+	}
+	if got, want := forward.Markdown, "See [diagram.png](assets/1/att1-diagram.png) for details.\n"; got != want {
+		t.Fatalf("forward markdown = %q, want %q", got, want)
+	}
+
+	prepared, err := PrepareMarkdownForAttachmentConversion(spaceDir, sourcePath, forward.Markdown, attachmentIndex)
+	if err != nil {
+		t.Fatalf("PrepareMarkdownForAttachmentConversion() error: %v", err)
+	}
+	reverse, err := converter.Reverse(context.Background(), []byte(prepared), converter.ReverseConfig{
+		LinkHook:  NewReverseLinkHook(spaceDir, nil, ""),
+		MediaHook: NewReverseMediaHook(spaceDir, attachmentIndex),
+	}, sourcePath)
+	if err != nil {
+		t.Fatalf("Reverse() error: %v", err)
+	}
+	out, err := ensureADFMediaCollection(reverse.ADF, "1", nil)
+	if err != nil {
+		t.Fatalf("ensureADFMediaCollection() error: %v", err)
+	}
+
+	var doc struct {
+		Content []struct {
+			Type    string `json:"type"`
+			Content []struct {
+				Type  string         `json:"type"`
+				Text  string         `json:"text"`
+				Attrs map[string]any `json:"attrs"`
+			} `json:"content"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal ADF: %v", err)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Type != "paragraph" || len(doc.Content[0].Content) != 3 {
+		t.Fatalf("expected one paragraph with text, mediaInline, text: %s", out)
+	}
+	media := doc.Content[0].Content[1]
+	if media.Type != "mediaInline" || media.Attrs["id"] != "att1" || media.Attrs["type"] != "file" {
+		t.Fatalf("inline chip = %s %v, want mediaInline file att1: %s", media.Type, media.Attrs, out)
+	}
+}
@@ -0,0 +1,58 @@
+package sync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// assetContent is a local asset file as read by push.
+type assetContent struct {
+	data []byte
+	hash string
+}
+
+// assetContentCache keeps the bytes and hash of every asset read during one
+// push, keyed by absolute path, so an image shared by several pages is read
+// and hashed once.
+type assetContentCache map[string]assetContent
+
+func (c assetContentCache) read(absPath string) (assetContent, error) {
+	absPath = filepath.Clean(absPath)
+	if content, ok := c[absPath]; ok {
+		return content, nil
+	}
+	raw, err := os.ReadFile(absPath) //nolint:gosec // asset path is resolved from validated in-scope markdown references
+	if err != nil {
+		return assetContent{}, err
+	}
+	content := assetContent{data: raw, hash: assetContentHash(raw)}
+	if c != nil {
+		c[absPath] = content
+	}
+	return content, nil
+}
+
+func assetContentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// reusablePageAttachmentID returns the ID of an attachment of the page that
+// owns assetDir whose uploaded bytes hash to hash, or "" if there is none.
+// Confluence media belong to the page they are attached to, so an identical
+// file on another page is still uploaded there.
+func reusablePageAttachmentID(hashIndex, attachmentIDByPath map[string]string, assetDir, hash string) string {
+	attachmentID := strings.TrimSpace(hashIndex[hash])
+	if attachmentID == "" {
+		return ""
+	}
+	for _, relPath := range collectPageAttachmentPaths(attachmentIDByPath, assetDir) {
+		if strings.TrimSpace(attachmentIDByPath[relPath]) == attachmentID {
+			return attachmentID
+		}
+	}
+	return ""
+}
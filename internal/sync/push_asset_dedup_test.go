@@ -0,0 +1,82 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestPush_UploadsIdenticalAssetsOncePerPage(t *testing.T) {
+	spaceDir := t.TempDir()
+	assetDir := filepath.Join(spaceDir, "assets", "1")
+	if err := os.MkdirAll(assetDir, 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	for _, name := range []string{"a.png", "b.png"} {
+		if err := os.WriteFile(filepath.Join(assetDir, name), []byte("same-bytes"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "![A](assets/1/a.png)\n\n![B](assets/1/b.png)\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.pagesByID["1"] = confluence.Page{ID: "1", SpaceID: "space-1", Title: "Root", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)}
+	remote.pages = append(remote.pages, remote.pagesByID["1"])
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State:          fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1"}},
+		Changes:        []PushFileChange{{Type: PushChangeModify, Path: "root.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	if remote.uploadAttachmentCalls != 1 {
+		t.Fatalf("upload attachment calls = %d, want 1", remote.uploadAttachmentCalls)
+	}
+	first, second := result.State.AttachmentIndex["assets/1/a.png"], result.State.AttachmentIndex["assets/1/b.png"]
+	if first == "" || first != second {
+		t.Fatalf("identical assets should share one attachment, index = %v", result.State.AttachmentIndex)
+	}
+	if got := result.State.AttachmentHashIndex[assetContentHash([]byte("same-bytes"))]; got != first {
+		t.Fatalf("attachment hash index = %v, want hash mapped to %s", result.State.AttachmentHashIndex, first)
+	}
+	reused := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "ATTACHMENT_REUSED" {
+			reused = true
+		}
+	}
+	if !reused {
+		t.Fatalf("expected ATTACHMENT_REUSED diagnostic, got %+v", result.Diagnostics)
+	}
+}
+
+func TestReusablePageAttachmentID_OnlyReusesAttachmentsOfTheSamePage(t *testing.T) {
+	hash := assetContentHash([]byte("diagram"))
+	hashIndex := map[string]string{hash: "att-1"}
+	attachmentIDByPath := map[string]string{"assets/1/diagram.png": "att-1"}
+
+	if got := reusablePageAttachmentID(hashIndex, attachmentIDByPath, "assets/1", hash); got != "att-1" {
+		t.Fatalf("same page reuse = %q, want att-1", got)
+	}
+	if got := reusablePageAttachmentID(hashIndex, attachmentIDByPath, "assets/2", hash); got != "" {
+		t.Fatalf("another page's attachment must not be reused, got %q", got)
+	}
+	if got := reusablePageAttachmentID(hashIndex, attachmentIDByPath, "assets/1", assetContentHash([]byte("other"))); got != "" {
+		t.Fatalf("different content must not be reused, got %q", got)
+	}
+}
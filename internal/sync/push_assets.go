@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
@@ -104,7 +105,7 @@ type assetPathMove struct {
 }
 
 func CollectReferencedAssetPaths(spaceDir, sourcePath, body string) ([]string, error) {
-	references, err := collectLocalAssetReferences(spaceDir, sourcePath, body)
+	references, err := collectLocalAssetReferences(spaceDir, sourcePath, converter.InlineReferenceLinks(body))
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +122,7 @@ func CollectReferencedAssetPaths(spaceDir, sourcePath, body string) ([]string, e
 // into inline media spans so strict reverse conversion can preserve attachment
 // references without dropping inline context.
 func PrepareMarkdownForAttachmentConversion(spaceDir, sourcePath, body string, attachmentIndex map[string]string) (string, error) {
+	body = converter.InlineReferenceLinks(body)
 	references, err := collectLocalAssetReferences(spaceDir, sourcePath, body)
 	if err != nil {
 		return "", err
@@ -138,7 +140,12 @@ func PrepareMarkdownForAttachmentConversion(spaceDir, sourcePath, body string, a
 		}
 
 		displayName := attachmentDisplayNameForPath(reference.RelPath, attachmentID)
-		mediaType := mediaTypeForDestination(reference.RelPath)
+		// A plain link to an attachment is a file reference even when it points
+		// at an image; only ![...](...) embeds the image.
+		mediaType := "file"
+		if reference.Occurrence.kind == markdownReferenceKindImage {
+			mediaType = mediaTypeForDestination(reference.RelPath)
+		}
 		rewrite := markdownDestinationRewrite{
 			Occurrence:       reference.Occurrence,
 			ReplacementToken: formatPandocInlineMediaToken(displayName, attachmentID, mediaType),
@@ -419,17 +426,22 @@ func applyMarkdownDestinationRewrites(body string, rewrites []markdownDestinatio
 	return builder.String()
 }
 
+// migrateReferencedAssetsToPageHierarchy moves local files referenced by a
+// page into its asset directory (see PageAssetDir) and rewrites the links.
 func migrateReferencedAssetsToPageHierarchy(
-	spaceDir, sourcePath, pageID, body string,
+	spaceDir, sourcePath, assetDir, body string,
 	attachmentIDByPath map[string]string,
 	stateAttachmentIndex map[string]string,
 ) (string, []string, []assetPathMove, error) {
-	pageID = fs.SanitizePathSegment(strings.TrimSpace(pageID))
-	if pageID == "" {
+	assetDir = normalizeRelPath(assetDir)
+	if assetDir == "" {
 		return body, nil, nil, nil
 	}
 
-	references, err := collectLocalAssetReferences(spaceDir, sourcePath, body)
+	// Reference-style links are only rewritten to inline form when one of
+	// their assets actually moves.
+	inlinedBody := converter.InlineReferenceLinks(body)
+	references, err := collectLocalAssetReferences(spaceDir, sourcePath, inlinedBody)
 	if err != nil {
 		return "", nil, nil, err
 	}
@@ -444,7 +456,7 @@ func migrateReferencedAssetsToPageHierarchy(
 	rewrites := make([]markdownDestinationRewrite, 0, len(references))
 
 	for _, reference := range references {
-		targetAbsPath, targetRelPath, resolveErr := resolvePageAssetTargetPath(spaceDir, pageID, reference.AbsPath, reservedTargets)
+		targetAbsPath, targetRelPath, resolveErr := resolvePageAssetTargetPath(spaceDir, assetDir, reference.AbsPath, reservedTargets)
 		if resolveErr != nil {
 			return "", nil, nil, resolveErr
 		}
@@ -496,7 +508,7 @@ func migrateReferencedAssetsToPageHierarchy(
 
 	updatedBody := body
 	if len(rewrites) > 0 {
-		updatedBody = applyMarkdownDestinationRewrites(body, rewrites)
+		updatedBody = applyMarkdownDestinationRewrites(inlinedBody, rewrites)
 	}
 
 	moves := make([]assetPathMove, 0, len(pathMoves))
@@ -507,13 +519,13 @@ func migrateReferencedAssetsToPageHierarchy(
 	return updatedBody, sortedStringKeys(touchedPaths), moves, nil
 }
 
-func resolvePageAssetTargetPath(spaceDir, pageID, sourceAbsPath string, reservedTargets map[string]string) (string, string, error) {
+func resolvePageAssetTargetPath(spaceDir, assetDir, sourceAbsPath string, reservedTargets map[string]string) (string, string, error) {
 	filename := strings.TrimSpace(filepath.Base(sourceAbsPath))
 	if filename == "" || filename == "." {
 		filename = "attachment"
 	}
 
-	targetDir := filepath.Join(spaceDir, "assets", pageID)
+	targetDir := filepath.Join(spaceDir, filepath.FromSlash(assetDir))
 	ext := filepath.Ext(filename)
 	stem := strings.TrimSuffix(filename, ext)
 	if stem == "" {
@@ -663,10 +675,10 @@ func isExternalDestination(destination string) bool {
 	return false
 }
 
-func collectPageAttachmentPaths(index map[string]string, pageID string) []string {
+func collectPageAttachmentPaths(index map[string]string, assetDir string) []string {
 	paths := make([]string, 0)
 	for relPath := range index {
-		if attachmentBelongsToPage(relPath, pageID) {
+		if attachmentBelongsToPage(relPath, assetDir) {
 			paths = append(paths, normalizeRelPath(relPath))
 		}
 	}
@@ -695,6 +707,108 @@ func dedupeSortedPaths(paths []string) []string {
 	return normalized
 }
 
+// DefaultMaxAttachmentBytes matches the default Confluence Cloud attachment
+// size limit (100 MiB).
+const DefaultMaxAttachmentBytes int64 = 100 << 20
+
+// blockedAttachmentExtensions lists executable file types that Confluence
+// attachment security settings commonly reject.
+var blockedAttachmentExtensions = map[string]struct{}{
+	".bat": {},
+	".cmd": {},
+	".com": {},
+	".dll": {},
+	".exe": {},
+	".msi": {},
+	".scr": {},
+	".vbs": {},
+}
+
+// blockedAttachmentContentTypes lists MIME types that Confluence attachment
+// security settings commonly reject.
+var blockedAttachmentContentTypes = map[string]struct{}{
+	"application/x-msdownload":                      {},
+	"application/x-msdos-program":                   {},
+	"application/x-ms-installer":                    {},
+	"application/x-msi":                             {},
+	"application/x-dosexec":                         {},
+	"application/x-executable":                      {},
+	"application/vnd.microsoft.portable-executable": {},
+}
+
+// validateAttachmentUploads checks every asset that still needs uploading
+// against the size limit before any HTTP call is made. Oversized files fail
+// the page; content types that tenants commonly block produce a warning.
+func validateAttachmentUploads(
+	spaceDir string,
+	assetRelPaths []string,
+	attachmentIDByPath map[string]string,
+	maxBytes int64,
+	diagnostics *[]PushDiagnostic,
+) error {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxAttachmentBytes
+	}
+
+	for _, assetRelPath := range assetRelPaths {
+		if strings.TrimSpace(attachmentIDByPath[assetRelPath]) != "" {
+			continue
+		}
+
+		assetAbsPath := filepath.Join(spaceDir, filepath.FromSlash(assetRelPath))
+		info, err := os.Stat(assetAbsPath)
+		if err != nil {
+			return fmt.Errorf("stat asset %s: %w", assetRelPath, err)
+		}
+		if info.Size() > maxBytes {
+			return fmt.Errorf(
+				"attachment %s is %s, which exceeds the %s upload limit; shrink the file or raise --max-attachment-bytes",
+				assetRelPath,
+				formatAttachmentSize(info.Size()),
+				formatAttachmentSize(maxBytes),
+			)
+		}
+
+		if isBlockedAttachmentType(assetAbsPath) {
+			appendPushDiagnostic(
+				diagnostics,
+				assetRelPath,
+				"ATTACHMENT_TYPE_BLOCKED",
+				fmt.Sprintf("attachment %s (%s) has a file type that Confluence commonly blocks; the upload may be rejected", assetRelPath, formatAttachmentSize(info.Size())),
+			)
+		}
+	}
+	return nil
+}
+
+func isBlockedAttachmentType(assetAbsPath string) bool {
+	ext := strings.ToLower(filepath.Ext(assetAbsPath))
+	if _, blocked := blockedAttachmentExtensions[ext]; blocked {
+		return true
+	}
+	contentType := strings.TrimSpace(strings.SplitN(mime.TypeByExtension(ext), ";", 2)[0])
+	_, blocked := blockedAttachmentContentTypes[strings.ToLower(contentType)]
+	return blocked
+}
+
+func formatAttachmentSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB"}
+	suffix := ""
+	for _, next := range suffixes {
+		value /= unit
+		suffix = next
+		if value < unit {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
 func detectAssetContentType(filename string, raw []byte) string {
 	extType := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename)))
 	if strings.TrimSpace(extType) != "" {
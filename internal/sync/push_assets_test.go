@@ -202,6 +202,66 @@ func TestPush_MigratesLocalRelativeAssetIntoPageHierarchy(t *testing.T) {
 	}
 }
 
+func TestPush_ResolvesReferenceStyleImagesAndPageLinks(t *testing.T) {
+	spaceDir := t.TempDir()
+	mdPath := filepath.Join(spaceDir, "root.md")
+	if err := os.WriteFile(filepath.Join(spaceDir, "diagram.png"), []byte("png"), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "other.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Other", ID: "2", Version: 1},
+		Body:        "other\n",
+	}); err != nil {
+		t.Fatalf("write other markdown: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(mdPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "![Diagram][diagram]\n\nSee [the other page][other].\n\n[diagram]: ./diagram.png\n[other]: other.md\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	for _, page := range []confluence.Page{
+		{ID: "1", SpaceID: "space-1", Title: "Root", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)},
+		{ID: "2", SpaceID: "space-1", Title: "Other", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)},
+	} {
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State:          fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1", "other.md": "2"}},
+		Changes:        []PushFileChange{{Type: PushChangeModify, Path: "root.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	if got := strings.TrimSpace(result.State.AttachmentIndex["assets/1/diagram.png"]); got == "" {
+		t.Fatalf("expected reference-style image to be uploaded and tracked, index=%v", result.State.AttachmentIndex)
+	}
+	updatedDoc, err := fs.ReadMarkdownDocument(mdPath)
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	if !strings.Contains(updatedDoc.Body, "![Diagram](assets/1/diagram.png)") {
+		t.Fatalf("expected migrated image reference in body, got %q", updatedDoc.Body)
+	}
+
+	adf := string(remote.updateInputsByPageID["1"].BodyADF)
+	if !strings.Contains(adf, `"fileName":"diagram.png"`) {
+		t.Fatalf("expected reference-style image to become a media node, ADF=%s", adf)
+	}
+	if !strings.Contains(adf, "pageId=2") {
+		t.Fatalf("expected reference-style page link to resolve to page 2, ADF=%s", adf)
+	}
+}
+
 func TestPush_UploadsLocalFileLinksAsAttachments(t *testing.T) {
 	spaceDir := t.TempDir()
 	mdPath := filepath.Join(spaceDir, "root.md")
@@ -278,6 +338,113 @@ func TestPush_UploadsLocalFileLinksAsAttachments(t *testing.T) {
 	}
 }
 
+func TestPush_RejectsOversizedAttachmentBeforeUpload(t *testing.T) {
+	spaceDir := t.TempDir()
+	mdPath := filepath.Join(spaceDir, "root.md")
+	assetPath := filepath.Join(spaceDir, "assets", "1", "huge.bin")
+
+	if err := os.MkdirAll(filepath.Dir(assetPath), 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	if err := os.WriteFile(assetPath, make([]byte, 2048), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(mdPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "[Data](assets/1/huge.bin)\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.pagesByID["1"] = confluence.Page{
+		ID:      "1",
+		SpaceID: "space-1",
+		Title:   "Root",
+		Status:  "current",
+		Version: 1,
+		BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`),
+	}
+	remote.pages = append(remote.pages, remote.pagesByID["1"])
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:           "ENG",
+		SpaceDir:           spaceDir,
+		Domain:             "https://example.atlassian.net",
+		ConflictPolicy:     PushConflictPolicyCancel,
+		MaxAttachmentBytes: 1024,
+		State:              fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1"}},
+		Changes:            []PushFileChange{{Type: PushChangeModify, Path: "root.md"}},
+	})
+	if err == nil {
+		t.Fatal("expected oversized attachment to fail push")
+	}
+	if !strings.Contains(err.Error(), "assets/1/huge.bin is 2.0 KiB") || !strings.Contains(err.Error(), "1.0 KiB upload limit") {
+		t.Fatalf("error should name the file, its size and the limit, got: %v", err)
+	}
+	if remote.uploadAttachmentCalls != 0 {
+		t.Fatalf("upload attachment calls = %d, want 0", remote.uploadAttachmentCalls)
+	}
+	if remote.updatePageCalls != 0 {
+		t.Fatalf("update page calls = %d, want 0", remote.updatePageCalls)
+	}
+}
+
+func TestPush_WarnsOnCommonlyBlockedAttachmentType(t *testing.T) {
+	spaceDir := t.TempDir()
+	mdPath := filepath.Join(spaceDir, "root.md")
+	assetPath := filepath.Join(spaceDir, "assets", "1", "setup.exe")
+
+	if err := os.MkdirAll(filepath.Dir(assetPath), 0o750); err != nil {
+		t.Fatalf("mkdir assets: %v", err)
+	}
+	if err := os.WriteFile(assetPath, []byte("MZ"), 0o600); err != nil {
+		t.Fatalf("write asset: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(mdPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1},
+		Body:        "[Installer](assets/1/setup.exe)\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.pagesByID["1"] = confluence.Page{
+		ID:      "1",
+		SpaceID: "space-1",
+		Title:   "Root",
+		Status:  "current",
+		Version: 1,
+		BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`),
+	}
+	remote.pages = append(remote.pages, remote.pagesByID["1"])
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State:          fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1"}},
+		Changes:        []PushFileChange{{Type: PushChangeModify, Path: "root.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+	if remote.uploadAttachmentCalls != 1 {
+		t.Fatalf("upload attachment calls = %d, want 1", remote.uploadAttachmentCalls)
+	}
+
+	found := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "ATTACHMENT_TYPE_BLOCKED" && diag.Path == "assets/1/setup.exe" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected ATTACHMENT_TYPE_BLOCKED diagnostic, got %+v", result.Diagnostics)
+	}
+}
+
 type publishedAttachmentRefRemote struct {
 	rollbackPushRemote
 	attachments []confluence.Attachment
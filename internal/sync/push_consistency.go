@@ -0,0 +1,73 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+)
+
+// Confluence Cloud reads can lag behind writes for a few seconds. After a
+// page is created or updated, push polls GetPage until the write is visible
+// so the next operation in the same run (a child of a page just created,
+// a metadata update, attachment reconciliation) sees consistent data.
+const writeConsistencyAttempts = 6
+
+var (
+	writeConsistencyInitialDelay = 100 * time.Millisecond
+	writeConsistencyMaxDelay     = 2 * time.Second
+)
+
+// waitForPageVersion polls GetPage until pageID reports at least version.
+// A failed read is retried like a stale one. It gives up after
+// writeConsistencyAttempts reads with exponential backoff and then records a
+// WRITE_NOT_YET_VISIBLE diagnostic instead of failing: the write itself
+// succeeded. Polling is skipped for dry runs and when
+// opts.SkipConsistencyWait is set.
+func waitForPageVersion(ctx context.Context, remote PushRemote, opts *PushOptions, relPath, pageID string, version int, diagnostics *[]PushDiagnostic) error {
+	pageID = strings.TrimSpace(pageID)
+	if opts == nil || opts.DryRun || opts.SkipConsistencyWait || pageID == "" || version <= 0 {
+		return nil
+	}
+
+	delay := writeConsistencyInitialDelay
+	seenVersion := 0
+	var readErr error
+	for attempt := 1; ; attempt++ {
+		page, err := remote.GetPage(ctx, pageID)
+		readErr = nil
+		switch {
+		case err == nil:
+			if page.Version >= version {
+				return nil
+			}
+			seenVersion = page.Version
+		case errors.Is(err, confluence.ErrNotFound):
+			// A page created a moment ago may not be readable yet.
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return err
+		default:
+			readErr = err
+		}
+
+		if attempt >= writeConsistencyAttempts {
+			break
+		}
+		slog.Debug("push_write_not_yet_visible", "path", relPath, "page_id", pageID, "want_version", version, "seen_version", seenVersion, "attempt", attempt, "error", readErr)
+		if err := contextSleep(ctx, delay); err != nil {
+			return err
+		}
+		delay = min(delay*2, writeConsistencyMaxDelay)
+	}
+
+	message := fmt.Sprintf("page %s still reads as version %d after writing version %d; later steps may see stale data", pageID, seenVersion, version)
+	if readErr != nil {
+		message = fmt.Sprintf("could not read back page %s after writing version %d (%v); later steps may see stale data", pageID, version, readErr)
+	}
+	appendPushDiagnostic(diagnostics, relPath, "WRITE_NOT_YET_VISIBLE", message)
+	return nil
+}
@@ -0,0 +1,200 @@
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// laggingPushRemote serves the previous version of a page for the first
+// staleReads reads after each write, like Confluence's eventually
+// consistent read path.
+type laggingPushRemote struct {
+	*rollbackPushRemote
+	staleReads   int
+	pendingStale map[string]int
+	previous     map[string]confluence.Page
+	getPageCalls int
+}
+
+func newLaggingPushRemote(staleReads int) *laggingPushRemote {
+	return &laggingPushRemote{
+		rollbackPushRemote: newRollbackPushRemote(),
+		staleReads:         staleReads,
+		pendingStale:       map[string]int{},
+		previous:           map[string]confluence.Page{},
+	}
+}
+
+func (f *laggingPushRemote) GetPage(ctx context.Context, pageID string) (confluence.Page, error) {
+	f.getPageCalls++
+	if f.pendingStale[pageID] > 0 {
+		f.pendingStale[pageID]--
+		previous, ok := f.previous[pageID]
+		if !ok {
+			return confluence.Page{}, confluence.ErrNotFound
+		}
+		return previous, nil
+	}
+	return f.rollbackPushRemote.GetPage(ctx, pageID)
+}
+
+func (f *laggingPushRemote) CreatePage(ctx context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
+	created, err := f.rollbackPushRemote.CreatePage(ctx, input)
+	if err == nil {
+		f.pendingStale[created.ID] = f.staleReads
+	}
+	return created, err
+}
+
+func (f *laggingPushRemote) UpdatePage(ctx context.Context, pageID string, input confluence.PageUpsertInput) (confluence.Page, error) {
+	previous, hadPrevious := f.rollbackPushRemote.pagesByID[pageID]
+	updated, err := f.rollbackPushRemote.UpdatePage(ctx, pageID, input)
+	if err == nil && hadPrevious {
+		f.previous[pageID] = previous
+		f.pendingStale[pageID] = f.staleReads
+	}
+	return updated, err
+}
+
+func shortenWriteConsistencyDelays(t *testing.T) {
+	t.Helper()
+	oldInitial, oldMax := writeConsistencyInitialDelay, writeConsistencyMaxDelay
+	writeConsistencyInitialDelay, writeConsistencyMaxDelay = time.Millisecond, 2*time.Millisecond
+	t.Cleanup(func() {
+		writeConsistencyInitialDelay, writeConsistencyMaxDelay = oldInitial, oldMax
+	})
+}
+
+func TestWaitForPageVersion_PollsUntilWriteIsVisible(t *testing.T) {
+	shortenWriteConsistencyDelays(t)
+	remote := newLaggingPushRemote(2)
+	remote.pagesByID["1"] = confluence.Page{ID: "1", Version: 3}
+	remote.previous["1"] = confluence.Page{ID: "1", Version: 2}
+	remote.pendingStale["1"] = 2
+
+	var diagnostics []PushDiagnostic
+	if err := waitForPageVersion(context.Background(), remote, &PushOptions{}, "root.md", "1", 3, &diagnostics); err != nil {
+		t.Fatalf("waitForPageVersion() error: %v", err)
+	}
+	if remote.getPageCalls != 3 {
+		t.Fatalf("GetPage calls = %d, want 3", remote.getPageCalls)
+	}
+	if len(diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestWaitForPageVersion_ReportsWriteStillNotVisible(t *testing.T) {
+	shortenWriteConsistencyDelays(t)
+	remote := newLaggingPushRemote(0)
+	remote.pagesByID["1"] = confluence.Page{ID: "1", Version: 2}
+
+	var diagnostics []PushDiagnostic
+	if err := waitForPageVersion(context.Background(), remote, &PushOptions{}, "root.md", "1", 3, &diagnostics); err != nil {
+		t.Fatalf("waitForPageVersion() error: %v", err)
+	}
+	if remote.getPageCalls != writeConsistencyAttempts {
+		t.Fatalf("GetPage calls = %d, want %d", remote.getPageCalls, writeConsistencyAttempts)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Code != "WRITE_NOT_YET_VISIBLE" {
+		t.Fatalf("diagnostics = %+v, want one WRITE_NOT_YET_VISIBLE", diagnostics)
+	}
+}
+
+// failingReadPushRemote fails every GetPage after a write succeeded.
+type failingReadPushRemote struct {
+	*rollbackPushRemote
+	getPageCalls int
+}
+
+func (f *failingReadPushRemote) GetPage(context.Context, string) (confluence.Page, error) {
+	f.getPageCalls++
+	return confluence.Page{}, &confluence.APIError{StatusCode: 503, Message: "service unavailable"}
+}
+
+func TestWaitForPageVersion_ReportsReadBackErrorsInsteadOfFailing(t *testing.T) {
+	shortenWriteConsistencyDelays(t)
+	remote := &failingReadPushRemote{rollbackPushRemote: newRollbackPushRemote()}
+
+	var diagnostics []PushDiagnostic
+	if err := waitForPageVersion(context.Background(), remote, &PushOptions{}, "root.md", "1", 3, &diagnostics); err != nil {
+		t.Fatalf("waitForPageVersion() error: %v", err)
+	}
+	if remote.getPageCalls != writeConsistencyAttempts {
+		t.Fatalf("GetPage calls = %d, want %d", remote.getPageCalls, writeConsistencyAttempts)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Code != "WRITE_NOT_YET_VISIBLE" || !strings.Contains(diagnostics[0].Message, "service unavailable") {
+		t.Fatalf("diagnostics = %+v, want one WRITE_NOT_YET_VISIBLE naming the read error", diagnostics)
+	}
+}
+
+func TestPush_ReadsBackWrittenPagesUnlessSkipped(t *testing.T) {
+	shortenWriteConsistencyDelays(t)
+	newSpaceDir := func() string {
+		t.Helper()
+		spaceDir := t.TempDir()
+		for _, title := range []string{"Parent", "Child"} {
+			if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "Parent", title+".md"), fs.MarkdownDocument{
+				Frontmatter: fs.Frontmatter{Title: title},
+				Body:        title + "\n",
+			}); err != nil {
+				t.Fatalf("write %s: %v", title, err)
+			}
+		}
+		return spaceDir
+	}
+
+	changes := []PushFileChange{
+		{Type: PushChangeAdd, Path: "Parent/Parent.md"},
+		{Type: PushChangeAdd, Path: "Parent/Child.md"},
+	}
+
+	remote := newLaggingPushRemote(1)
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       newSpaceDir(),
+		Domain:         "https://example.atlassian.net",
+		State:          fs.NewSpaceState(),
+		Changes:        changes,
+		ConflictPolicy: PushConflictPolicyCancel,
+	})
+	if err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+	if len(result.Commits) != 2 {
+		t.Fatalf("commits = %d, want 2", len(result.Commits))
+	}
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "WRITE_NOT_YET_VISIBLE" {
+			t.Fatalf("unexpected diagnostic %+v", diag)
+		}
+	}
+
+	for pageID, pending := range remote.pendingStale {
+		if pending != 0 {
+			t.Fatalf("page %s was not read back after its last write", pageID)
+		}
+	}
+
+	skipping := newLaggingPushRemote(1)
+	if _, err := Push(context.Background(), skipping, PushOptions{
+		SpaceKey:            "ENG",
+		SpaceDir:            newSpaceDir(),
+		Domain:              "https://example.atlassian.net",
+		State:               fs.NewSpaceState(),
+		Changes:             changes,
+		ConflictPolicy:      PushConflictPolicyCancel,
+		SkipConsistencyWait: true,
+	}); err != nil {
+		t.Fatalf("Push() with SkipConsistencyWait error: %v", err)
+	}
+	if skipping.getPageCalls >= remote.getPageCalls {
+		t.Fatalf("GetPage calls with SkipConsistencyWait = %d, want fewer than %d", skipping.getPageCalls, remote.getPageCalls)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -50,6 +51,112 @@ func resolveParentIDFromHierarchy(relPath, pageID, fallbackParentID string, page
 	return resolvedFallback
 }
 
+// LocalParentID returns the parent a push would give the page at relPath,
+// using only local data: a parent_path or parent_id pin first, then the
+// index page or folder of the enclosing directories. An empty result means
+// the page sits at the space root. A pin push would reject is ignored.
+func LocalParentID(relPath string, fm fs.Frontmatter, pageIDByPath PageIndex, folderIDByPath map[string]string) string {
+	pageID := strings.TrimSpace(fm.ID)
+	if parentID, ok, err := resolvePinnedParentID(relPath, pageID, fm, pageIDByPath, nil, nil); err == nil && ok {
+		return parentID
+	}
+	return resolveParentIDFromHierarchy(relPath, pageID, "", pageIDByPath, folderIDByPath)
+}
+
+// resolvePinnedParentID returns the parent page pinned through the parent_path
+// or parent_id frontmatter keys. ok is false when no usable pin is set and the
+// caller should fall back to the directory-derived parent. A parent_path that
+// does not match a tracked page is an error; an unknown parent_id only warns.
+// A nil remotePageByID accepts any parent_id, for callers without remote data.
+func resolvePinnedParentID(
+	relPath, pageID string,
+	fm fs.Frontmatter,
+	pageIDByPath PageIndex,
+	remotePageByID map[string]confluence.Page,
+	diagnostics *[]PushDiagnostic,
+) (string, bool, error) {
+	pageID = strings.TrimSpace(pageID)
+
+	if parentPath := strings.TrimSpace(fm.ParentPath); parentPath != "" {
+		parentID := strings.TrimSpace(pageIDByPath[normalizeRelPath(parentPath)])
+		if parentID == "" {
+			return "", false, fmt.Errorf("parent_path %q in %s does not match a tracked page in this space", parentPath, relPath)
+		}
+		if isPendingPageID(parentID) {
+			return "", false, nil
+		}
+		if parentID == pageID {
+			return "", false, fmt.Errorf("parent_path %q in %s points at the page itself", parentPath, relPath)
+		}
+		return parentID, true, nil
+	}
+
+	parentID := strings.TrimSpace(fm.ParentID)
+	if parentID == "" {
+		return "", false, nil
+	}
+	if parentID == pageID {
+		return "", false, fmt.Errorf("parent_id %s in %s points at the page itself", parentID, relPath)
+	}
+	if _, exists := remotePageByID[parentID]; remotePageByID != nil && !exists {
+		appendPushDiagnostic(
+			diagnostics,
+			relPath,
+			"PARENT_PIN_NOT_FOUND",
+			fmt.Sprintf("parent_id %s does not match a current page in this space; using the directory-derived parent instead", parentID),
+		)
+		return "", false, nil
+	}
+	return parentID, true, nil
+}
+
+// resolveNewPageParent resolves the --parent value (a page ID or a tracked
+// Markdown path) and confirms the page exists remotely, so a typo cannot
+// publish new pages at the space root.
+func resolveNewPageParent(ctx context.Context, remote PushRemote, spaceID, raw string, pageIDByPath PageIndex) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	parentID := raw
+	if strings.HasSuffix(strings.ToLower(raw), ".md") {
+		parentID = strings.TrimSpace(pageIDByPath[normalizeRelPath(raw)])
+		if parentID == "" || isPendingPageID(parentID) {
+			return "", fmt.Errorf("--parent %q does not match a published page in this space", raw)
+		}
+	}
+
+	page, err := remote.GetPage(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, confluence.ErrNotFound) || errors.Is(err, confluence.ErrArchived) {
+			return "", fmt.Errorf("--parent %q: page %s does not exist", raw, parentID)
+		}
+		return "", fmt.Errorf("resolve --parent %q: %w", raw, err)
+	}
+	if pageSpaceID := strings.TrimSpace(page.SpaceID); pageSpaceID != "" && pageSpaceID != spaceID {
+		return "", fmt.Errorf("--parent %q: page %s belongs to a different space", raw, parentID)
+	}
+	return parentID, nil
+}
+
+// newPageParentOverride returns the --parent page for a page created in this
+// push. A directory-derived parent that was itself created in this push is
+// kept so new subtrees stay intact.
+func newPageParentOverride(opts *PushOptions, derivedParentID string) (string, bool) {
+	if opts == nil || opts.newPageParentID == "" {
+		return "", false
+	}
+	derivedParentID = strings.TrimSpace(derivedParentID)
+	if isPendingPageID(derivedParentID) {
+		return "", false
+	}
+	if _, created := opts.createdPageIDs[derivedParentID]; created {
+		return "", false
+	}
+	return opts.newPageParentID, true
+}
+
 func ensureFolderHierarchy(
 	ctx context.Context,
 	remote PushRemote,
@@ -102,9 +209,13 @@ func ensureFolderHierarchy(
 			continue
 		}
 
+		folderTitle := seg
+		if opts != nil && opts.OrderPrefix {
+			folderTitle = trimOrderPrefix(seg)
+		}
 		createInput := confluence.FolderCreateInput{
 			SpaceID: spaceID,
-			Title:   seg,
+			Title:   folderTitle,
 		}
 		if strings.TrimSpace(parentID) != "" {
 			createInput.ParentID = parentID
@@ -257,6 +368,14 @@ func normalizePushState(state fs.SpaceState) fs.SpaceState {
 	state.PagePathIndex = normalizedPageIndex
 	state.AttachmentIndex = cloneStringMap(state.AttachmentIndex)
 	state.FolderPathIndex = cloneStringMap(state.FolderPathIndex)
+	attachmentHashIndex := make(map[string]string, len(state.AttachmentHashIndex))
+	maps.Copy(attachmentHashIndex, state.AttachmentHashIndex)
+	state.AttachmentHashIndex = attachmentHashIndex
+	propertyHashes := make(map[string]map[string]string, len(state.PagePropertyHashes))
+	for pageID, hashes := range state.PagePropertyHashes {
+		propertyHashes[pageID] = maps.Clone(hashes)
+	}
+	state.PagePropertyHashes = propertyHashes
 	return state
 }
 
@@ -403,6 +522,7 @@ func precreatePendingPushPages(
 	pageIDByPath PageIndex,
 	pageTitleByPath map[string]string,
 	folderIDByPath map[string]string,
+	remotePageByID map[string]confluence.Page,
 	diagnostics *[]PushDiagnostic,
 ) (map[string]confluence.Page, error) {
 	precreated := map[string]confluence.Page{}
@@ -452,42 +572,36 @@ func precreatePendingPushPages(
 
 		fallbackParentID := strings.TrimSpace(doc.Frontmatter.ConfluenceParentPageID)
 		resolvedParentID := resolveParentIDFromHierarchy(relPath, "", fallbackParentID, pageIDByPath, folderIDByPath)
-		created, err := remote.CreatePage(ctx, confluence.PageUpsertInput{
-			SpaceID:      space.ID,
-			ParentPageID: resolvedParentID,
-			Title:        title,
-			Status:       normalizePageLifecycleState(doc.Frontmatter.State),
-			BodyADF:      []byte(`{"version":1,"type":"doc","content":[]}`),
+		if overrideParentID, ok := newPageParentOverride(opts, resolvedParentID); ok {
+			resolvedParentID = overrideParentID
+		}
+		if pinnedParentID, ok, _ := resolvePinnedParentID(relPath, "", doc.Frontmatter, pageIDByPath, remotePageByID, nil); ok {
+			resolvedParentID = pinnedParentID
+		}
+		created, acceptedTitle, err := writePageResolvingTitleConflict(ctx, remote, space.ID, opts, relPath, title, diagnostics, func(candidate string) (confluence.Page, error) {
+			return remote.CreatePage(ctx, confluence.PageUpsertInput{
+				SpaceID:      space.ID,
+				ParentPageID: resolvedParentID,
+				Title:        candidate,
+				Status:       normalizePageLifecycleState(doc.Frontmatter.State),
+				BodyADF:      opts.placeholderBody(),
+			})
 		})
 		if err != nil {
-			if isDuplicateTitleCreateError(err) {
-				conflictPage, conflictStatuses, resolved := findRemoteTitleCollision(ctx, remote, space.ID, title)
-				if resolved {
-					return nil, fmt.Errorf(
-						"create placeholder page for %s: remote page title collision for %q (id=%s status=%s title=%q); rename the new file or reconcile the conflicting remote page first",
-						relPath,
-						title,
-						conflictPage.ID,
-						conflictPage.Status,
-						conflictPage.Title,
-					)
-				}
-				return nil, fmt.Errorf(
-					"create placeholder page for %s: remote page title collision for %q, but the conflicting page was not discoverable through current/draft/archived title lookups (checked: %s); inspect the space for hidden or permission-restricted pages before retrying",
-					relPath,
-					title,
-					strings.Join(conflictStatuses, ", "),
-				)
-			}
-			if err != nil {
-				return nil, fmt.Errorf("create placeholder page for %s: %w", relPath, err)
-			}
+			return nil, fmt.Errorf("create placeholder page for %s: %w", relPath, err)
 		}
+		pageTitleByPath[relPath] = acceptedTitle
 
 		createdID := strings.TrimSpace(created.ID)
 		if createdID == "" {
 			return nil, fmt.Errorf("create placeholder page for %s returned empty page ID", relPath)
 		}
+		if opts.createdPageIDs != nil {
+			opts.createdPageIDs[createdID] = struct{}{}
+		}
+		if err := waitForPageVersion(ctx, remote, opts, relPath, createdID, created.Version, diagnostics); err != nil {
+			return nil, err
+		}
 
 		pageIDByPath[relPath] = createdID
 		precreated[relPath] = created
@@ -496,14 +610,6 @@ func precreatePendingPushPages(
 	return precreated, nil
 }
 
-func isDuplicateTitleCreateError(err error) bool {
-	if err == nil {
-		return false
-	}
-	lower := strings.ToLower(err.Error())
-	return strings.Contains(lower, "title already exists") || strings.Contains(lower, "already exists with the same title") || strings.Contains(lower, "a page with this title already exists")
-}
-
 func findRemoteTitleCollision(ctx context.Context, remote PushRemote, spaceID, title string) (confluence.Page, []string, bool) {
 	statuses := []string{"current", "draft", "archived"}
 	for _, status := range statuses {
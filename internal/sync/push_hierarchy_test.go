@@ -2,9 +2,14 @@ package sync
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
 func TestResolveParentIDFromHierarchy_PrefersIndexPageOverFolder(t *testing.T) {
@@ -233,3 +238,448 @@ func TestCollapseFolderParentIfIndexPage_ReparentsChildren(t *testing.T) {
 		t.Fatalf("expected FOLDER_COLLAPSED diagnostic")
 	}
 }
+
+func TestResolvePinnedParentID_ParentPathResolvesTrackedPage(t *testing.T) {
+	pageIndex := PageIndex{"Team/Overview.md": "page-overview"}
+
+	got, ok, err := resolvePinnedParentID("Guides/Intro.md", "page-intro", fs.Frontmatter{ParentPath: "./Team/Overview.md"}, pageIndex, nil, nil)
+	if err != nil {
+		t.Fatalf("resolvePinnedParentID() error: %v", err)
+	}
+	if !ok || got != "page-overview" {
+		t.Fatalf("pinned parent = %q (ok=%v), want page-overview", got, ok)
+	}
+}
+
+func TestResolvePinnedParentID_UnknownParentPathFails(t *testing.T) {
+	_, _, err := resolvePinnedParentID("Guides/Intro.md", "page-intro", fs.Frontmatter{ParentPath: "Missing.md"}, PageIndex{}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), `parent_path "Missing.md"`) {
+		t.Fatalf("expected unknown parent_path error, got %v", err)
+	}
+}
+
+func TestResolvePinnedParentID_UnknownParentIDWarnsAndFallsBack(t *testing.T) {
+	diagnostics := []PushDiagnostic{}
+	remotePages := map[string]confluence.Page{"page-known": {ID: "page-known"}}
+
+	got, ok, err := resolvePinnedParentID("Guides/Intro.md", "page-intro", fs.Frontmatter{ParentID: "page-missing"}, PageIndex{}, remotePages, &diagnostics)
+	if err != nil {
+		t.Fatalf("resolvePinnedParentID() error: %v", err)
+	}
+	if ok || got != "" {
+		t.Fatalf("expected fallback for unknown parent_id, got %q (ok=%v)", got, ok)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Code != "PARENT_PIN_NOT_FOUND" {
+		t.Fatalf("expected PARENT_PIN_NOT_FOUND diagnostic, got %+v", diagnostics)
+	}
+
+	got, ok, err = resolvePinnedParentID("Guides/Intro.md", "page-intro", fs.Frontmatter{ParentID: "page-known"}, PageIndex{}, remotePages, &diagnostics)
+	if err != nil || !ok || got != "page-known" {
+		t.Fatalf("expected known parent_id to be pinned, got %q (ok=%v, err=%v)", got, ok, err)
+	}
+}
+
+func TestLocalParentID_FollowsPushPinRules(t *testing.T) {
+	pageIndex := PageIndex{
+		"Team/Overview.md": "page-overview",
+		"Guides/Guides.md": "page-guides",
+		"Draft.md":         "pending-page-draft",
+	}
+
+	tests := []struct {
+		name string
+		fm   fs.Frontmatter
+		want string
+	}{
+		{name: "parent_path pin", fm: fs.Frontmatter{ID: "page-intro", ParentPath: "./Team/Overview.md"}, want: "page-overview"},
+		{name: "parent_id pin without remote data", fm: fs.Frontmatter{ID: "page-intro", ParentID: "page-elsewhere"}, want: "page-elsewhere"},
+		{name: "unknown parent_path falls back", fm: fs.Frontmatter{ID: "page-intro", ParentPath: "Missing.md"}, want: "page-guides"},
+		{name: "unpublished parent_path falls back", fm: fs.Frontmatter{ID: "page-intro", ParentPath: "Draft.md"}, want: "page-guides"},
+		{name: "self pin falls back", fm: fs.Frontmatter{ID: "page-intro", ParentID: "page-intro"}, want: "page-guides"},
+	}
+	for _, tt := range tests {
+		if got := LocalParentID("Guides/Intro.md", tt.fm, pageIndex, nil); got != tt.want {
+			t.Errorf("%s: LocalParentID() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPush_ParentPathOverridesDirectoryParent(t *testing.T) {
+	spaceDir := t.TempDir()
+	writePinnedParentDoc := func(relPath string, fm fs.Frontmatter) {
+		t.Helper()
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", relPath, err)
+		}
+		if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fm, Body: "content\n"}); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+	writePinnedParentDoc("Guides/Guides.md", fs.Frontmatter{Title: "Guides", ID: "10", Version: 1})
+	writePinnedParentDoc("Team/Team.md", fs.Frontmatter{Title: "Team", ID: "20", Version: 1})
+	writePinnedParentDoc("Guides/Intro.md", fs.Frontmatter{Title: "Intro", ID: "30", Version: 1, ParentPath: "Team/Team.md"})
+
+	remote := newRollbackPushRemote()
+	for _, page := range []confluence.Page{
+		{ID: "10", SpaceID: "space-1", Title: "Guides", Status: "current", Version: 1},
+		{ID: "20", SpaceID: "space-1", Title: "Team", Status: "current", Version: 1},
+		{ID: "30", SpaceID: "space-1", Title: "Intro", Status: "current", Version: 1, ParentPageID: "10"},
+	} {
+		page.BodyADF = []byte(`{"version":1,"type":"doc","content":[]}`)
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State: fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{
+			"Guides/Guides.md": "10",
+			"Team/Team.md":     "20",
+			"Guides/Intro.md":  "30",
+		}},
+		Changes: []PushFileChange{{Type: PushChangeModify, Path: "Guides/Intro.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	payload, ok := remote.updateInputsByPageID["30"]
+	if !ok {
+		t.Fatal("expected update payload for page 30")
+	}
+	if payload.ParentPageID != "20" {
+		t.Fatalf("update parent = %q, want pinned parent 20", payload.ParentPageID)
+	}
+}
+
+func TestPull_KeepsParentPinAndLocalPathOfPinnedPage(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	for _, doc := range []struct {
+		path string
+		fm   fs.Frontmatter
+	}{
+		{"Handbook.md", fs.Frontmatter{Title: "Handbook", ID: "1", Version: 1}},
+		{"Plan.md", fs.Frontmatter{Title: "Plan", ID: "2", Version: 1, ParentPath: "Handbook.md"}},
+	} {
+		if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, doc.path), fs.MarkdownDocument{Frontmatter: doc.fm, Body: "local\n"}); err != nil {
+			t.Fatalf("write %s: %v", doc.path, err)
+		}
+	}
+
+	// The pin was pushed, so Confluence now nests Plan under Handbook.
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	emptyADF := rawJSON(t, map[string]any{"version": 1, "type": "doc", "content": []any{}})
+	handbook := confluence.Page{ID: "1", SpaceID: "space-1", Title: "Handbook", Version: 1, LastModified: modifiedAt}
+	plan := confluence.Page{ID: "2", SpaceID: "space-1", Title: "Plan", ParentPageID: "1", ParentType: "page", Version: 2, LastModified: modifiedAt}
+	fullHandbook, fullPlan := handbook, plan
+	fullHandbook.BodyADF, fullPlan.BodyADF = emptyADF, emptyADF
+	fake := &fakePullRemote{
+		space:     confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages:     []confluence.Page{handbook, plan},
+		pagesByID: map[string]confluence.Page{"1": fullHandbook, "2": fullPlan},
+	}
+
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Handbook.md": "1", "Plan.md": "2"}
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:  "ENG",
+		SpaceDir:  spaceDir,
+		State:     state,
+		ForceFull: true,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	if got := result.State.PagePathIndex["Plan.md"]; got != "2" {
+		t.Fatalf("pinned page should stay at Plan.md, index = %v", result.State.PagePathIndex)
+	}
+	doc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Plan.md"))
+	if err != nil {
+		t.Fatalf("read Plan.md: %v", err)
+	}
+	if doc.Frontmatter.Version != 2 || doc.Frontmatter.ParentPath != "Handbook.md" {
+		t.Fatalf("pulled Plan.md frontmatter = %+v, want version 2 with parent_path kept", doc.Frontmatter)
+	}
+}
+
+func TestPush_NewPageParentNestsNewRootPages(t *testing.T) {
+	spaceDir := t.TempDir()
+	for relPath, fm := range map[string]fs.Frontmatter{
+		"Existing.md":      {Title: "Existing", ID: "10", Version: 1},
+		"New.md":           {Title: "New"},
+		"Guide/Guide.md":   {Title: "Guide"},
+		"Guide/Chapter.md": {Title: "Chapter"},
+	} {
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", relPath, err)
+		}
+		if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fm, Body: "content\n"}); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+
+	remote := newRollbackPushRemote()
+	for _, page := range []confluence.Page{
+		{ID: "10", SpaceID: "space-1", Title: "Existing", Status: "current", Version: 1},
+		{ID: "99", SpaceID: "space-1", Title: "Landing", Status: "current", Version: 1},
+	} {
+		page.BodyADF = []byte(`{"version":1,"type":"doc","content":[]}`)
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		NewPageParent:  "99",
+		State:          fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"Existing.md": "10"}},
+		Changes: []PushFileChange{
+			{Type: PushChangeModify, Path: "Existing.md"},
+			{Type: PushChangeAdd, Path: "New.md"},
+			{Type: PushChangeAdd, Path: "Guide/Guide.md"},
+			{Type: PushChangeAdd, Path: "Guide/Chapter.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	if got := remote.updateInputsByPageID["10"].ParentPageID; got != "" {
+		t.Fatalf("existing page parent = %q, want unchanged root", got)
+	}
+	newID := result.State.PagePathIndex["New.md"]
+	guideID := result.State.PagePathIndex["Guide/Guide.md"]
+	chapterID := result.State.PagePathIndex["Guide/Chapter.md"]
+	if got := remote.updateInputsByPageID[newID].ParentPageID; got != "99" {
+		t.Fatalf("new root page parent = %q, want 99", got)
+	}
+	if got := remote.updateInputsByPageID[guideID].ParentPageID; got != "99" {
+		t.Fatalf("new section page parent = %q, want 99", got)
+	}
+	if got := remote.updateInputsByPageID[chapterID].ParentPageID; got != guideID {
+		t.Fatalf("new child page parent = %q, want new section %q", got, guideID)
+	}
+
+	// The next push no longer passes --parent and sees New.md as an existing
+	// page; the parent_id written on creation keeps it under page 99.
+	doc, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "New.md"))
+	if err != nil {
+		t.Fatalf("read New.md: %v", err)
+	}
+	if doc.Frontmatter.ParentID != "99" {
+		t.Fatalf("New.md parent_id = %q, want 99", doc.Frontmatter.ParentID)
+	}
+	if chapter, err := fs.ReadMarkdownDocument(filepath.Join(spaceDir, "Guide", "Chapter.md")); err != nil || chapter.Frontmatter.ParentID != "" {
+		t.Fatalf("Guide/Chapter.md parent_id = %q (err %v), want none for a directory-derived parent", chapter.Frontmatter.ParentID, err)
+	}
+	doc.Body = "edited\n"
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "New.md"), doc); err != nil {
+		t.Fatalf("write New.md: %v", err)
+	}
+	if _, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State:          result.State,
+		Changes:        []PushFileChange{{Type: PushChangeModify, Path: "New.md"}},
+	}); err != nil {
+		t.Fatalf("second Push() unexpected error: %v", err)
+	}
+	if got := remote.updateInputsByPageID[newID].ParentPageID; got != "99" {
+		t.Fatalf("new root page parent after second push = %q, want 99", got)
+	}
+}
+
+func TestPush_NewPageParentMustExist(t *testing.T) {
+	spaceDir := t.TempDir()
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "New.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "New"},
+		Body:        "content\n",
+	}); err != nil {
+		t.Fatalf("write New.md: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		NewPageParent:  "404",
+		State:          fs.SpaceState{SpaceKey: "ENG"},
+		Changes:        []PushFileChange{{Type: PushChangeAdd, Path: "New.md"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `--parent "404"`) {
+		t.Fatalf("Push() error = %v, want missing --parent error", err)
+	}
+	if remote.createPageCalls != 0 {
+		t.Fatalf("create page calls = %d, want 0", remote.createPageCalls)
+	}
+}
+
+func TestPush_ParentByTitleUsesUnpulledRemoteParent(t *testing.T) {
+	spaceDir := t.TempDir()
+	for _, relPath := range []string{"Runbooks/Deploy.md", "Notes/Idea.md"} {
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", relPath, err)
+		}
+		title := strings.TrimSuffix(filepath.Base(relPath), ".md")
+		if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fs.Frontmatter{Title: title}, Body: "content\n"}); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+
+	remote := newRollbackPushRemote()
+	for _, page := range []confluence.Page{
+		{ID: "10", SpaceID: "space-1", Title: "Runbooks", Status: "current", Version: 1},
+		{ID: "20", SpaceID: "space-1", Title: "Notes", Status: "current", Version: 1},
+		{ID: "21", SpaceID: "space-1", Title: "notes", Status: "current", Version: 1},
+	} {
+		page.BodyADF = []byte(`{"version":1,"type":"doc","content":[]}`)
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		ParentByTitle:  true,
+		State:          fs.SpaceState{SpaceKey: "ENG"},
+		Changes: []PushFileChange{
+			{Type: PushChangeAdd, Path: "Runbooks/Deploy.md"},
+			{Type: PushChangeAdd, Path: "Notes/Idea.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	deployID := result.State.PagePathIndex["Runbooks/Deploy.md"]
+	if got := remote.updateInputsByPageID[deployID].ParentPageID; got != "10" {
+		t.Fatalf("new page parent = %q, want remote page 10 matched by title", got)
+	}
+	if remote.createFolderCalls != 1 {
+		t.Fatalf("create folder calls = %d, want 1 (only for the ambiguous Notes directory)", remote.createFolderCalls)
+	}
+	if _, tracked := result.State.PagePathIndex["Runbooks/Runbooks.md"]; tracked {
+		t.Fatal("unpulled parent page must not be tracked as a local file")
+	}
+
+	codes := map[string]string{}
+	for _, diag := range result.Diagnostics {
+		codes[diag.Code] = diag.Path
+	}
+	if codes["PARENT_RESOLVED_BY_TITLE"] != "Runbooks/Deploy.md" {
+		t.Fatalf("expected PARENT_RESOLVED_BY_TITLE for Runbooks/Deploy.md, got %+v", result.Diagnostics)
+	}
+	if codes["PARENT_TITLE_AMBIGUOUS"] != "Notes/Idea.md" {
+		t.Fatalf("expected PARENT_TITLE_AMBIGUOUS for Notes/Idea.md, got %+v", result.Diagnostics)
+	}
+}
+
+func TestPush_OrderPrefixIsDroppedFromFolderAndParentTitles(t *testing.T) {
+	spaceDir := t.TempDir()
+	for _, relPath := range []string{"01-Runbooks/01-Deploy.md", "02-Notes/01-Idea.md"} {
+		absPath := filepath.Join(spaceDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+			t.Fatalf("mkdir %s: %v", relPath, err)
+		}
+		if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fs.Frontmatter{Title: "Page"}, Body: "content\n"}); err != nil {
+			t.Fatalf("write %s: %v", relPath, err)
+		}
+	}
+
+	remote := newRollbackPushRemote()
+	runbooks := confluence.Page{ID: "10", SpaceID: "space-1", Title: "Runbooks", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)}
+	remote.pagesByID[runbooks.ID] = runbooks
+	remote.pages = append(remote.pages, runbooks)
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		ParentByTitle:  true,
+		OrderPrefix:    true,
+		State:          fs.SpaceState{SpaceKey: "ENG"},
+		Changes: []PushFileChange{
+			{Type: PushChangeAdd, Path: "01-Runbooks/01-Deploy.md"},
+			{Type: PushChangeAdd, Path: "02-Notes/01-Idea.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	deployID := result.State.PagePathIndex["01-Runbooks/01-Deploy.md"]
+	if got := remote.updateInputsByPageID[deployID].ParentPageID; got != "10" {
+		t.Fatalf("new page parent = %q, want remote page 10 matched by the unprefixed title", got)
+	}
+	if len(remote.folders) != 1 || remote.folders[0].Title != "Notes" {
+		t.Fatalf("created folders = %+v, want one folder titled Notes", remote.folders)
+	}
+}
+
+func TestPush_ParentByTitleMatchesDirectoryNamedWithSpaceFilenameMode(t *testing.T) {
+	spaceDir := t.TempDir()
+	absPath := filepath.Join(spaceDir, "Zazolc-Notes", "Idea.md")
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{Frontmatter: fs.Frontmatter{Title: "Idea"}, Body: "content\n"}); err != nil {
+		t.Fatalf("write Idea.md: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	notes := confluence.Page{ID: "10", SpaceID: "space-1", Title: "Zażółć Notes", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)}
+	remote.pagesByID[notes.ID] = notes
+	remote.pages = append(remote.pages, notes)
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		ParentByTitle:  true,
+		FilenameMode:   fs.FilenameModeTransliterate,
+		State:          fs.SpaceState{SpaceKey: "ENG"},
+		Changes:        []PushFileChange{{Type: PushChangeAdd, Path: "Zazolc-Notes/Idea.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	ideaID := result.State.PagePathIndex["Zazolc-Notes/Idea.md"]
+	if got := remote.updateInputsByPageID[ideaID].ParentPageID; got != "10" {
+		t.Fatalf("new page parent = %q, want remote page 10 matched by its transliterated title", got)
+	}
+}
+
+func TestTrimOrderPrefix(t *testing.T) {
+	for name, want := range map[string]string{
+		"02-Setup":   "Setup",
+		"100-Guides": "Guides",
+		"2-Setup":    "2-Setup",
+		"02-":        "02-",
+		"Setup":      "Setup",
+	} {
+		if got := trimOrderPrefix(name); got != want {
+			t.Errorf("trimOrderPrefix(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
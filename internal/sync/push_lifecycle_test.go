@@ -453,3 +453,137 @@ func TestPushConflictError_Error(t *testing.T) {
 		t.Errorf("PushConflictError.Error() = %q, want %q", got, want)
 	}
 }
+
+func TestPush_NewPageTitleConflictFailsWithActionableError(t *testing.T) {
+	spaceDir := t.TempDir()
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "new.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Runbook"},
+		Body:        "content\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.enforceUniqueTitles = true
+	remote.pages = []confluence.Page{{ID: "77", SpaceID: "space-1", Title: "Runbook", Status: "current"}}
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		State:          fs.SpaceState{SpaceKey: "ENG"},
+		ConflictPolicy: PushConflictPolicyCancel,
+		Changes:        []PushFileChange{{Type: PushChangeAdd, Path: "new.md"}},
+	})
+	if err == nil {
+		t.Fatal("expected title conflict error")
+	}
+	for _, want := range []string{`"Runbook"`, "id=77", "--on-title-conflict=suffix"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+	if strings.Contains(err.Error(), "INVALID_REQUEST_PARAMETER") {
+		t.Fatalf("error should not surface the raw API response: %v", err)
+	}
+}
+
+func TestPush_NewPageTitleConflictSuffixPolicyAppendsSuffix(t *testing.T) {
+	spaceDir := t.TempDir()
+	mdPath := filepath.Join(spaceDir, "new.md")
+	if err := fs.WriteMarkdownDocument(mdPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Runbook"},
+		Body:        "content\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.enforceUniqueTitles = true
+	remote.pages = []confluence.Page{
+		{ID: "77", SpaceID: "space-1", Title: "Runbook", Status: "current"},
+		{ID: "78", SpaceID: "space-1", Title: "Runbook (2)", Status: "current"},
+	}
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:            "ENG",
+		SpaceDir:            spaceDir,
+		Domain:              "https://example.atlassian.net",
+		State:               fs.SpaceState{SpaceKey: "ENG"},
+		ConflictPolicy:      PushConflictPolicyCancel,
+		TitleConflictPolicy: PushTitleConflictSuffix,
+		Changes:             []PushFileChange{{Type: PushChangeAdd, Path: "new.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+
+	pageID := result.State.PagePathIndex["new.md"]
+	if got := remote.pagesByID[pageID].Title; got != "Runbook (3)" {
+		t.Fatalf("remote title = %q, want %q", got, "Runbook (3)")
+	}
+	doc, err := fs.ReadMarkdownDocument(mdPath)
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	if doc.Frontmatter.Title != "Runbook (3)" {
+		t.Fatalf("frontmatter title = %q, want %q", doc.Frontmatter.Title, "Runbook (3)")
+	}
+	found := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "TITLE_CONFLICT_SUFFIXED" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected TITLE_CONFLICT_SUFFIXED diagnostic, got %+v", result.Diagnostics)
+	}
+}
+
+func TestPush_ExistingPageTitleConflictFailsUnderSuffixPolicy(t *testing.T) {
+	spaceDir := t.TempDir()
+	mdPath := filepath.Join(spaceDir, "guide.md")
+	if err := fs.WriteMarkdownDocument(mdPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Runbook", ID: "10", Version: 1},
+		Body:        "content\n",
+	}); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.enforceUniqueTitles = true
+	for _, page := range []confluence.Page{
+		{ID: "10", SpaceID: "space-1", Title: "Guide", Status: "current", Version: 1},
+		{ID: "77", SpaceID: "space-1", Title: "Runbook", Status: "current", Version: 1},
+	} {
+		page.BodyADF = []byte(`{"version":1,"type":"doc","content":[]}`)
+		remote.pagesByID[page.ID] = page
+		remote.pages = append(remote.pages, page)
+	}
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:            "ENG",
+		SpaceDir:            spaceDir,
+		Domain:              "https://example.atlassian.net",
+		State:               fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"guide.md": "10"}},
+		ConflictPolicy:      PushConflictPolicyCancel,
+		TitleConflictPolicy: PushTitleConflictSuffix,
+		Changes:             []PushFileChange{{Type: PushChangeModify, Path: "guide.md"}},
+	})
+	if err == nil {
+		t.Fatal("expected title conflict error for an existing page")
+	}
+	if !strings.Contains(err.Error(), "id=77") || strings.Contains(err.Error(), "--on-title-conflict=suffix") {
+		t.Fatalf("error = %v, want the conflicting page without a suffix hint", err)
+	}
+	if got := remote.pagesByID["10"].Title; got != "Guide" {
+		t.Fatalf("remote title = %q, want it unchanged", got)
+	}
+	doc, err := fs.ReadMarkdownDocument(mdPath)
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	if doc.Frontmatter.Title != "Runbook" {
+		t.Fatalf("frontmatter title = %q, want it unchanged", doc.Frontmatter.Title)
+	}
+}
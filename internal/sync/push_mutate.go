@@ -98,7 +98,7 @@ func pushDeletePage(
 		}
 	}
 
-	stalePaths := collectPageAttachmentPaths(state.AttachmentIndex, pageID)
+	stalePaths := collectPageAttachmentPaths(state.AttachmentIndex, PageAssetDir(state.AssetLayout, pageID, relPath))
 	for _, assetPath := range stalePaths {
 		attachmentID := state.AttachmentIndex[assetPath]
 		if strings.TrimSpace(attachmentID) != "" {
@@ -181,7 +181,7 @@ func deleteLocalAssetFile(spaceDir, relPath string) error {
 	if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
 		return err
 	}
-	_ = removeEmptyParentDirs(filepath.Dir(absPath), filepath.Join(spaceDir, "assets"))
+	_ = removeEmptyAssetDirs(spaceDir, absPath)
 	return nil
 }
 
@@ -216,6 +216,10 @@ func pushUpsertPage(
 	trackContentStatus := shouldSyncContentStatus(isExistingPage, doc)
 	dirPath := normalizeRelPath(filepath.ToSlash(filepath.Dir(filepath.FromSlash(relPath))))
 	title := resolveLocalTitle(doc, relPath)
+	if hasPrecreated && strings.TrimSpace(precreatedPage.Title) != "" {
+		// Keep a title suffixed during pre-creation (--on-title-conflict=suffix).
+		title = strings.TrimSpace(precreatedPage.Title)
+	}
 	pageTitleByPath[normalizedRelPath] = title
 
 	if pageID == "" && !hasPrecreated {
@@ -265,7 +269,12 @@ func pushUpsertPage(
 			slog.Info("push_rollback_skipped", "path", relPath, "reason", "dry_run")
 			return PushCommitPlan{}, opErr
 		}
-		if rollbackErr := rollback.rollback(ctx, remote); rollbackErr != nil {
+		// An interrupted push (Ctrl-C or --timeout) still has to undo its
+		// partial remote changes, so rollback gets a context of its own.
+		rollbackCtx, cancelRollback := context.WithTimeout(context.WithoutCancel(ctx), pushRollbackTimeout)
+		rollbackErr := rollback.rollback(rollbackCtx, remote)
+		cancelRollback()
+		if rollbackErr != nil {
 			return PushCommitPlan{}, errors.Join(opErr, fmt.Errorf("rollback for %s: %w", relPath, rollbackErr))
 		}
 		return PushCommitPlan{}, opErr
@@ -337,7 +346,7 @@ func pushUpsertPage(
 		migratedBody, migratedPaths, migratedMoves, migrateErr := migrateReferencedAssetsToPageHierarchy(
 			opts.SpaceDir,
 			absPath,
-			assetOwnerPageID,
+			PageAssetDir(state.AssetLayout, assetOwnerPageID, relPath),
 			doc.Body,
 			attachmentIDByPath,
 			state.AttachmentIndex,
@@ -370,6 +379,13 @@ func pushUpsertPage(
 		}
 		return PushCommitPlan{}, preflightErr
 	}
+	if err := validateAttachmentUploads(opts.SpaceDir, referencedAssetPaths, attachmentIDByPath, opts.MaxAttachmentBytes, diagnostics); err != nil {
+		preflightErr := fmt.Errorf("validate attachments for %s: %w", relPath, err)
+		if hasPrecreated {
+			return failWithRollback(preflightErr)
+		}
+		return PushCommitPlan{}, preflightErr
+	}
 	preparedBody, err := PrepareMarkdownForAttachmentConversion(opts.SpaceDir, absPath, doc.Body, strictAttachmentIndex)
 	if err != nil {
 		preflightErr := fmt.Errorf("prepare attachment conversion for %s: %w", relPath, err)
@@ -416,16 +432,26 @@ func pushUpsertPage(
 			}
 
 			resolvedParentID := resolveParentIDFromHierarchy(relPath, "", fallbackParentID, pageIDByPath, folderIDByPath)
-			created, createErr := remote.CreatePage(ctx, confluence.PageUpsertInput{
-				SpaceID:      space.ID,
-				ParentPageID: resolvedParentID,
-				Title:        title,
-				Status:       targetState,
-				BodyADF:      []byte(`{"version":1,"type":"doc","content":[]}`),
+			if overrideParentID, ok := newPageParentOverride(opts, resolvedParentID); ok {
+				resolvedParentID = overrideParentID
+			}
+			if pinnedParentID, ok, _ := resolvePinnedParentID(relPath, "", doc.Frontmatter, pageIDByPath, remotePageByID, nil); ok {
+				resolvedParentID = pinnedParentID
+			}
+			created, acceptedTitle, createErr := writePageResolvingTitleConflict(ctx, remote, space.ID, opts, relPath, title, diagnostics, func(candidate string) (confluence.Page, error) {
+				return remote.CreatePage(ctx, confluence.PageUpsertInput{
+					SpaceID:      space.ID,
+					ParentPageID: resolvedParentID,
+					Title:        candidate,
+					Status:       targetState,
+					BodyADF:      opts.placeholderBody(),
+				})
 			})
 			if createErr != nil {
 				return failWithRollback(fmt.Errorf("create placeholder page for %s: %w", relPath, createErr))
 			}
+			title = acceptedTitle
+			pageTitleByPath[normalizedRelPath] = title
 
 			pageID = strings.TrimSpace(created.ID)
 			if pageID == "" {
@@ -433,6 +459,12 @@ func pushUpsertPage(
 			}
 
 			rollback.trackCreatedPage(pageID, targetState)
+			if opts.createdPageIDs != nil {
+				opts.createdPageIDs[pageID] = struct{}{}
+			}
+			if err := waitForPageVersion(ctx, remote, opts, relPath, pageID, created.Version, diagnostics); err != nil {
+				return failWithRollback(err)
+			}
 			localVersion = created.Version
 			remotePage = created
 			remotePageByID[pageID] = created
@@ -453,16 +485,30 @@ func pushUpsertPage(
 		}
 
 		assetAbsPath := filepath.Join(opts.SpaceDir, filepath.FromSlash(assetRelPath))
-		raw, err := os.ReadFile(assetAbsPath) //nolint:gosec // asset path is resolved from validated in-scope markdown references
+		content, err := opts.assetContents.read(assetAbsPath)
 		if err != nil {
 			return PushCommitPlan{}, fmt.Errorf("read asset %s: %w", assetRelPath, err)
 		}
 
+		if reusedID := reusablePageAttachmentID(state.AttachmentHashIndex, attachmentIDByPath, PageAssetDir(state.AssetLayout, pageID, relPath), content.hash); reusedID != "" {
+			attachmentIDByPath[assetRelPath] = reusedID
+			state.AttachmentIndex[assetRelPath] = reusedID
+			appendPushDiagnostic(
+				diagnostics,
+				assetRelPath,
+				"ATTACHMENT_REUSED",
+				fmt.Sprintf("reused attachment %s with identical content instead of uploading %s again", reusedID, assetRelPath),
+			)
+			referencedIDs[reusedID] = struct{}{}
+			touchedAssets = append(touchedAssets, assetRelPath)
+			continue
+		}
+
 		uploaded, err := remote.UploadAttachment(ctx, confluence.AttachmentUploadInput{
 			PageID:      pageID,
 			Filename:    filepath.Base(assetAbsPath),
-			ContentType: detectAssetContentType(assetAbsPath, raw),
-			Data:        raw,
+			ContentType: detectAssetContentType(assetAbsPath, content.data),
+			Data:        content.data,
 		})
 		if err != nil {
 			return failWithRollback(fmt.Errorf("upload asset %s: %w", assetRelPath, err))
@@ -476,6 +522,9 @@ func pushUpsertPage(
 		attachmentIDByPath[assetRelPath] = uploadedID
 		uploadedAttachmentsByPath[assetRelPath] = uploaded
 		state.AttachmentIndex[assetRelPath] = uploadedID
+		if state.AttachmentHashIndex != nil {
+			state.AttachmentHashIndex[content.hash] = uploadedID
+		}
 		rollback.trackUploadedAttachment(pageID, uploadedID, assetRelPath)
 		appendPushDiagnostic(
 			diagnostics,
@@ -487,7 +536,7 @@ func pushUpsertPage(
 		touchedAssets = append(touchedAssets, assetRelPath)
 	}
 
-	stalePaths := collectPageAttachmentPaths(state.AttachmentIndex, pageID)
+	stalePaths := collectPageAttachmentPaths(state.AttachmentIndex, PageAssetDir(state.AssetLayout, pageID, relPath))
 	for _, stalePath := range stalePaths {
 		attachmentID := strings.TrimSpace(state.AttachmentIndex[stalePath])
 		if attachmentID == "" {
@@ -545,34 +594,69 @@ func pushUpsertPage(
 
 	mediaHook = NewReverseMediaHook(opts.SpaceDir, publishedMediaIDByPath)
 	reverse, err := converter.Reverse(ctx, []byte(preparedBody), converter.ReverseConfig{
-		LinkHook:  linkHook,
-		MediaHook: mediaHook,
-		Strict:    true,
+		LinkHook:     linkHook,
+		MediaHook:    mediaHook,
+		Strict:       true,
+		AddLeadingH1: opts.StripTitleHeading && converter.HasLeadingTitleHeading(remotePage.BodyADF, remotePage.Title),
+		Title:        title,
+		PreviousADF:  remotePage.BodyADF,
 	}, absPath)
 	if err != nil {
 		return failWithRollback(fmt.Errorf("strict conversion failed for %s after attachment mapping: %w", relPath, err))
 	}
+	for _, warning := range reverse.Warnings {
+		switch warning.Type {
+		case converter.WarningUnsupportedColor:
+			appendPushDiagnostic(diagnostics, relPath, "UNSUPPORTED_COLOR", warning.Message)
+		case converter.WarningHTMLBlockPassthrough:
+			appendPushDiagnostic(diagnostics, relPath, "HTML_BLOCK_PASSTHROUGH", warning.Message)
+		case converter.WarningHTMLCommentDropped:
+			appendPushDiagnostic(diagnostics, relPath, "HTML_COMMENT_DROPPED", warning.Message)
+		}
+	}
 
 	resolvedParentID := resolveParentIDFromHierarchy(relPath, pageID, fallbackParentID, pageIDByPath, folderIDByPath)
+	overrideParentID, hasParentOverride := "", false
+	if !isExistingPage {
+		if overrideParentID, hasParentOverride = newPageParentOverride(opts, resolvedParentID); hasParentOverride {
+			resolvedParentID = overrideParentID
+		}
+	}
+	pinnedParentID, hasPinnedParent, err := resolvePinnedParentID(relPath, pageID, doc.Frontmatter, pageIDByPath, remotePageByID, diagnostics)
+	if err != nil {
+		return failWithRollback(err)
+	}
+	if hasPinnedParent {
+		resolvedParentID = pinnedParentID
+	} else if hasParentOverride {
+		// Later pushes treat the page as existing and derive its parent from
+		// its directory, so the --parent choice is pinned in frontmatter.
+		doc.Frontmatter.ParentID = overrideParentID
+	}
 	nextVersion := localVersion + 1
 	if policy == PushConflictPolicyForce && remotePage.Version >= nextVersion {
 		nextVersion = remotePage.Version + 1
 	}
 
-	finalADF, err := ensureADFMediaCollection(reverse.ADF, pageID, publishedAttachmentRefs)
+	finalADF, err := normalizeADFDocRoot(reverse.ADF)
+	if err != nil {
+		return failWithRollback(fmt.Errorf("validate ADF for %s: %w", relPath, err))
+	}
+	finalADF, err = ensureADFMediaCollection(finalADF, pageID, publishedAttachmentRefs)
 	if err != nil {
 		return failWithRollback(fmt.Errorf("post-process ADF for %s: %w", relPath, err))
 	}
 
 	updateInput := confluence.PageUpsertInput{
-		SpaceID:      space.ID,
-		ParentPageID: resolvedParentID,
-		Title:        title,
-		Status:       targetState,
-		Version:      nextVersion,
-		BodyADF:      finalADF,
-	}
-	updatedPage, err := remote.UpdatePage(ctx, pageID, updateInput)
+		SpaceID:        space.ID,
+		ParentPageID:   resolvedParentID,
+		Title:          title,
+		Status:         targetState,
+		Version:        nextVersion,
+		VersionMessage: opts.versionMessage(relPath),
+		BodyADF:        finalADF,
+	}
+	updatedPage, err := updatePageFailingOnTitleConflict(ctx, remote, space.ID, relPath, pageID, updateInput)
 	if err != nil && isExistingPage && errors.Is(err, confluence.ErrNotFound) {
 		refreshedPage, refreshErr := remote.GetPage(ctx, pageID)
 		if refreshErr != nil {
@@ -650,6 +734,9 @@ func pushUpsertPage(
 	if err != nil {
 		return failWithRollback(fmt.Errorf("update page %s: %w", pageID, err))
 	}
+	if err := waitForPageVersion(ctx, remote, opts, relPath, pageID, updatedPage.Version, diagnostics); err != nil {
+		return failWithRollback(err)
+	}
 	if len(referencedAssetPaths) > 0 {
 		reconciledPage, reconcileErr := republishUntilMediaResolvable(
 			ctx,
@@ -681,6 +768,12 @@ func pushUpsertPage(
 	if err := syncPageMetadata(ctx, remote, pageID, doc, isExistingPage, capabilities, opts.contentStateCatalog, diagnostics); err != nil {
 		return failWithRollback(fmt.Errorf("sync metadata for %s: %w", relPath, err))
 	}
+	if err := syncPageRestrictions(ctx, remote, relPath, pageID, doc.Frontmatter.Restrictions, diagnostics); err != nil {
+		return failWithRollback(fmt.Errorf("sync restrictions for %s: %w", relPath, err))
+	}
+	if err := syncPageProperties(ctx, remote, relPath, pageID, doc.Frontmatter, state.PagePropertyHashes, diagnostics); err != nil {
+		return failWithRollback(fmt.Errorf("sync content properties for %s: %w", relPath, err))
+	}
 	if !opts.DryRun {
 		refreshedPage, err := remote.GetPage(ctx, pageID)
 		if err != nil {
@@ -692,6 +785,10 @@ func pushUpsertPage(
 
 	doc.Frontmatter.Title = title
 	doc.Frontmatter.Version = updatedPage.Version
+	if doc.Frontmatter.URL != "" && strings.TrimSpace(updatedPage.WebURL) != "" {
+		// Keep a pulled `url` current after a retitle; it is never sent.
+		doc.Frontmatter.URL = strings.TrimSpace(updatedPage.WebURL)
+	}
 	if !opts.DryRun {
 		if err := fs.WriteMarkdownDocument(absPath, doc); err != nil {
 			return failWithRollback(fmt.Errorf("write markdown %s: %w", relPath, err))
@@ -11,9 +11,33 @@ import (
 	"strings"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/converter"
 	"github.com/rgonek/confluence-markdown-sync/internal/fs"
 )
 
+// emptyPageADF is an ADF document without content.
+const emptyPageADF = `{"version":1,"type":"doc","content":[]}`
+
+// placeholderBody returns the body for pages created ahead of their content,
+// so a push that fails before the real update leaves no misleading text.
+func (opts *PushOptions) placeholderBody() json.RawMessage {
+	if opts == nil || len(opts.PlaceholderBodyADF) == 0 {
+		return json.RawMessage(emptyPageADF)
+	}
+	return append(json.RawMessage(nil), opts.PlaceholderBodyADF...)
+}
+
+// versionMessage returns the edit comment for the update of relPath.
+func (opts *PushOptions) versionMessage(relPath string) string {
+	if opts == nil {
+		return ""
+	}
+	if message := strings.TrimSpace(opts.VersionMessage); message != "" {
+		return message
+	}
+	return strings.TrimSpace(opts.VersionMessageByPath[normalizeRelPath(relPath)])
+}
+
 func snapshotPageContent(page confluence.Page) pushContentSnapshot {
 	clonedBody := append(json.RawMessage(nil), page.BodyADF...)
 	return pushContentSnapshot{
@@ -55,7 +79,7 @@ func restorePageContentSnapshot(ctx context.Context, remote PushRemote, pageID s
 
 	body := append(json.RawMessage(nil), snapshot.BodyADF...)
 	if len(body) == 0 {
-		body = []byte(`{"version":1,"type":"doc","content":[]}`)
+		body = []byte(emptyPageADF)
 	}
 
 	nextVersion := headPage.Version + 1
@@ -195,20 +219,15 @@ func deleteOptionsForPageLifecycle(pageStatus string, preferPermanent bool) conf
 	return confluence.PageDeleteOptions{}
 }
 
+// resolveLocalTitle returns the Confluence page title for a local document.
+// Precedence is strict: frontmatter title, then the first H1 heading, then
+// the file name. A non-empty frontmatter title always wins.
 func resolveLocalTitle(doc fs.MarkdownDocument, relPath string) string {
-	title := strings.TrimSpace(doc.Frontmatter.Title)
-	if title != "" {
+	if title := strings.TrimSpace(doc.Frontmatter.Title); title != "" {
 		return title
 	}
-
-	for _, line := range strings.Split(doc.Body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "# ") {
-			title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
-			if title != "" {
-				return title
-			}
-		}
+	if title := converter.MarkdownH1Title(doc.Body); title != "" {
+		return title
 	}
 
 	base := filepath.Base(relPath)
@@ -227,7 +246,7 @@ func buildLocalPageTitleIndex(spaceDir string) (map[string]string, error) {
 			}
 			return nil
 		}
-		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") || fs.IsPageSidecar(d.Name()) {
 			return nil
 		}
 
@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestResolveLocalTitle_Precedence(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  fs.MarkdownDocument
+		want string
+	}{
+		{
+			name: "frontmatter title wins over H1 and file name",
+			doc:  fs.MarkdownDocument{Frontmatter: fs.Frontmatter{Title: "API Reference Guide"}, Body: "# API\n"},
+			want: "API Reference Guide",
+		},
+		{
+			name: "H1 used when frontmatter title is empty",
+			doc:  fs.MarkdownDocument{Body: "Intro\n\n# Heading Title\n"},
+			want: "Heading Title",
+		},
+		{
+			name: "H1 inside fenced code is ignored",
+			doc:  fs.MarkdownDocument{Body: "```bash\n# install deps\n```\n"},
+			want: "api",
+		},
+		{
+			name: "file name is the last resort",
+			doc:  fs.MarkdownDocument{Body: "## Not an H1\n"},
+			want: "api",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolveLocalTitle(tc.doc, "docs/api.md"); got != tc.want {
+				t.Fatalf("resolveLocalTitle() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPush_FrontmatterTitleIsPublishedAndKept(t *testing.T) {
+	spaceDir := t.TempDir()
+	absPath := filepath.Join(spaceDir, "api.md")
+	if err := fs.WriteMarkdownDocument(absPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "API Reference Guide", ID: "10", Version: 1},
+		Body:        "# API\n\ncontent\n",
+	}); err != nil {
+		t.Fatalf("write api.md: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	page := confluence.Page{ID: "10", SpaceID: "space-1", Title: "API Reference Guide", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)}
+	remote.pagesByID[page.ID] = page
+	remote.pages = append(remote.pages, page)
+
+	_, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:       "ENG",
+		SpaceDir:       spaceDir,
+		Domain:         "https://example.atlassian.net",
+		ConflictPolicy: PushConflictPolicyCancel,
+		State:          fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"api.md": "10"}},
+		Changes:        []PushFileChange{{Type: PushChangeModify, Path: "api.md"}},
+	})
+	if err != nil {
+		t.Fatalf("Push() unexpected error: %v", err)
+	}
+
+	if got := remote.updateInputsByPageID["10"].Title; got != "API Reference Guide" {
+		t.Fatalf("published title = %q, want frontmatter title", got)
+	}
+	doc, err := fs.ReadMarkdownDocument(absPath)
+	if err != nil {
+		t.Fatalf("read api.md: %v", err)
+	}
+	if doc.Frontmatter.Title != "API Reference Guide" {
+		t.Fatalf("frontmatter title after push = %q, want unchanged", doc.Frontmatter.Title)
+	}
+}
+
+func TestPushOptionsPlaceholderBody(t *testing.T) {
+	var nilOpts *PushOptions
+	if got := string(nilOpts.placeholderBody()); got != emptyPageADF {
+		t.Fatalf("nil options placeholder = %s, want %s", got, emptyPageADF)
+	}
+	if got := string((&PushOptions{}).placeholderBody()); got != emptyPageADF {
+		t.Fatalf("default placeholder = %s, want %s", got, emptyPageADF)
+	}
+
+	custom := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Syncing…"}]}]}`
+	opts := &PushOptions{PlaceholderBodyADF: []byte(custom)}
+	if got := string(opts.placeholderBody()); got != custom {
+		t.Fatalf("configured placeholder = %s, want %s", got, custom)
+	}
+}
+
+func TestPushOptionsVersionMessage_FlagOverridesCommitSubject(t *testing.T) {
+	opts := &PushOptions{VersionMessageByPath: map[string]string{"Guides/Setup.md": "Document the proxy"}}
+	if got := opts.versionMessage("./Guides/Setup.md"); got != "Document the proxy" {
+		t.Fatalf("versionMessage() = %q, want per-path commit subject", got)
+	}
+	if got := opts.versionMessage("Other.md"); got != "" {
+		t.Fatalf("versionMessage() = %q, want empty for a path without a subject", got)
+	}
+	opts.VersionMessage = "Quarterly review"
+	if got := opts.versionMessage("Guides/Setup.md"); got != "Quarterly review" {
+		t.Fatalf("versionMessage() = %q, want explicit message", got)
+	}
+}
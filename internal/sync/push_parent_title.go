@@ -0,0 +1,117 @@
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// seedParentPagesByTitle resolves the enclosing directories of new pages
+// that have neither a local index page (<dir>/<dir>.md) nor a tracked
+// folder against the titles of remote pages. A unique match is recorded in
+// pageIDByPath under the index path a pull would write for that page, so
+// the usual hierarchy resolution parents new pages under it instead of
+// creating a folder. Ambiguous matches are reported and left unresolved.
+func seedParentPagesByTitle(
+	changes []PushFileChange,
+	pageIDByPath PageIndex,
+	folderIDByPath map[string]string,
+	remotePageByID map[string]confluence.Page,
+	orderPrefix bool,
+	filenameMode fs.FilenameMode,
+	diagnostics *[]PushDiagnostic,
+) {
+	dirs := map[string]string{}
+	for _, change := range changes {
+		if change.Type != PushChangeAdd && change.Type != PushChangeModify {
+			continue
+		}
+		relPath := normalizeRelPath(change.Path)
+		if !isPendingPageID(pageIDByPath[relPath]) {
+			continue
+		}
+		dirPath := normalizeRelPath(filepath.ToSlash(filepath.Dir(filepath.FromSlash(relPath))))
+		for dirPath != "" && dirPath != "." {
+			if _, seen := dirs[dirPath]; !seen {
+				dirs[dirPath] = relPath
+			}
+			dirPath = normalizeRelPath(filepath.ToSlash(filepath.Dir(filepath.FromSlash(dirPath))))
+		}
+	}
+
+	// Resolve outer directories first so an ambiguous inner match can be
+	// narrowed to the pages under the outer directory's parent.
+	for _, dirPath := range sortedStringKeys(dirs) {
+		indexPath := indexPagePathForDir(dirPath)
+		if indexPath == "" || strings.TrimSpace(pageIDByPath[indexPath]) != "" {
+			continue
+		}
+		if strings.TrimSpace(folderIDByPath[dirPath]) != "" {
+			continue
+		}
+
+		dirName := filepath.Base(filepath.FromSlash(dirPath))
+		if orderPrefix {
+			dirName = trimOrderPrefix(dirName)
+		}
+		candidates := remotePagesTitled(remotePageByID, dirName, filenameMode)
+		if len(candidates) > 1 {
+			outerParentID := resolveParentIDFromHierarchy(indexPath, "", "", pageIDByPath, folderIDByPath)
+			var narrowed []confluence.Page
+			for _, candidate := range candidates {
+				if strings.TrimSpace(candidate.ParentPageID) == outerParentID {
+					narrowed = append(narrowed, candidate)
+				}
+			}
+			if len(narrowed) == 1 {
+				candidates = narrowed
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			pageIDByPath[indexPath] = candidates[0].ID
+			appendPushDiagnostic(
+				diagnostics,
+				dirs[dirPath],
+				"PARENT_RESOLVED_BY_TITLE",
+				fmt.Sprintf("directory %q has no local parent page; using remote page %q (id=%s) with a matching title", dirPath, candidates[0].Title, candidates[0].ID),
+			)
+		default:
+			ids := make([]string, 0, len(candidates))
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.ID)
+			}
+			appendPushDiagnostic(
+				diagnostics,
+				dirs[dirPath],
+				"PARENT_TITLE_AMBIGUOUS",
+				fmt.Sprintf("directory %q matches %d remote pages by title (ids %s); pull the intended parent or pin it with parent_id", dirPath, len(candidates), strings.Join(ids, ", ")),
+			)
+		}
+	}
+}
+
+// remotePagesTitled returns the remote pages whose title, or the path
+// segment pull would derive from it, equals dirName ignoring case.
+func remotePagesTitled(remotePageByID map[string]confluence.Page, dirName string, filenameMode fs.FilenameMode) []confluence.Page {
+	dirName = strings.TrimSpace(dirName)
+	var matches []confluence.Page
+	for _, page := range remotePageByID {
+		title := strings.TrimSpace(page.Title)
+		if title == "" {
+			continue
+		}
+		if strings.EqualFold(title, dirName) || strings.EqualFold(fs.SanitizePathSegmentWithMode(title, filenameMode), dirName) {
+			matches = append(matches, page)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
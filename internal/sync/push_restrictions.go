@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// FrontmatterRestrictions converts remote page restrictions to the
+// `restrictions` frontmatter key. Unrestricted pages yield nil so the key is
+// omitted.
+func FrontmatterRestrictions(restrictions confluence.PageRestrictions) *fs.Restrictions {
+	if len(restrictions.Read) == 0 && len(restrictions.Update) == 0 {
+		return nil
+	}
+	return &fs.Restrictions{
+		Read:   fs.NormalizeRestrictionSubjects(restrictions.Read),
+		Update: fs.NormalizeRestrictionSubjects(restrictions.Update),
+	}
+}
+
+// syncPageRestrictions applies the `restrictions` frontmatter key to a page.
+// Pages without the key keep their remote restrictions. A token that may not
+// read or change restrictions produces a RESTRICTIONS_PERMISSION_DENIED
+// diagnostic instead of failing the push.
+func syncPageRestrictions(ctx context.Context, remote PushRemote, relPath, pageID string, local *fs.Restrictions, diagnostics *[]PushDiagnostic) error {
+	if local == nil {
+		return nil
+	}
+	target := confluence.PageRestrictions{
+		Read:   fs.NormalizeRestrictionSubjects(local.Read),
+		Update: fs.NormalizeRestrictionSubjects(local.Update),
+	}
+
+	current, err := remote.GetRestrictions(ctx, pageID)
+	if err != nil {
+		if isRestrictionPermissionError(err) {
+			appendRestrictionPermissionDiagnostic(diagnostics, relPath, "read", err)
+			return nil
+		}
+		return fmt.Errorf("get restrictions: %w", err)
+	}
+	current.Read = fs.NormalizeRestrictionSubjects(current.Read)
+	current.Update = fs.NormalizeRestrictionSubjects(current.Update)
+	if reflect.DeepEqual(current, target) {
+		return nil
+	}
+
+	if err := remote.SetRestrictions(ctx, pageID, target); err != nil {
+		if isRestrictionPermissionError(err) {
+			appendRestrictionPermissionDiagnostic(diagnostics, relPath, "update", err)
+			return nil
+		}
+		return fmt.Errorf("set restrictions: %w", err)
+	}
+	return nil
+}
+
+func isRestrictionPermissionError(err error) bool {
+	var apiErr *confluence.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
+
+func appendRestrictionPermissionDiagnostic(diagnostics *[]PushDiagnostic, relPath, action string, err error) {
+	appendPushDiagnostic(
+		diagnostics,
+		relPath,
+		"RESTRICTIONS_PERMISSION_DENIED",
+		fmt.Sprintf("could not %s page restrictions (%v); the page content was pushed but its restrictions were left unchanged — the API token's user needs permission to edit restrictions", action, err),
+	)
+}
+
+// describeRestrictionSubjects lists restriction subjects for a diagnostic;
+// an empty list means the operation is not restricted.
+func describeRestrictionSubjects(subjects []string) string {
+	if len(subjects) == 0 {
+		return "anyone"
+	}
+	return strings.Join(subjects, ", ")
+}
@@ -0,0 +1,66 @@
+package sync
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestSyncPageRestrictions_LeavesRemoteUntouchedWithoutKey(t *testing.T) {
+	remote := newRollbackPushRemote()
+	remote.restrictionsByPage["1"] = confluence.PageRestrictions{Read: []string{"group:legal"}}
+
+	if err := syncPageRestrictions(context.Background(), remote, "Secret.md", "1", nil, nil); err != nil {
+		t.Fatalf("syncPageRestrictions() error: %v", err)
+	}
+	if len(remote.setRestrictionsCalls) != 0 {
+		t.Fatalf("set restrictions calls = %v, want none", remote.setRestrictionsCalls)
+	}
+}
+
+func TestSyncPageRestrictions_AppliesChangesOnlyWhenDifferent(t *testing.T) {
+	remote := newRollbackPushRemote()
+	remote.restrictionsByPage["1"] = confluence.PageRestrictions{Read: []string{"group:legal"}}
+
+	local := &fs.Restrictions{Read: []string{"group:legal"}}
+	if err := syncPageRestrictions(context.Background(), remote, "Secret.md", "1", local, nil); err != nil {
+		t.Fatalf("syncPageRestrictions() error: %v", err)
+	}
+	if len(remote.setRestrictionsCalls) != 0 {
+		t.Fatalf("equal restrictions should not be rewritten, got calls %v", remote.setRestrictionsCalls)
+	}
+
+	local = &fs.Restrictions{Read: []string{"group:legal"}, Update: []string{"user:acct-1"}}
+	if err := syncPageRestrictions(context.Background(), remote, "Secret.md", "1", local, nil); err != nil {
+		t.Fatalf("syncPageRestrictions() error: %v", err)
+	}
+	want := confluence.PageRestrictions{Read: []string{"group:legal"}, Update: []string{"user:acct-1"}}
+	if !reflect.DeepEqual(remote.restrictionsByPage["1"], want) {
+		t.Fatalf("remote restrictions = %+v, want %+v", remote.restrictionsByPage["1"], want)
+	}
+
+	if err := syncPageRestrictions(context.Background(), remote, "Secret.md", "1", &fs.Restrictions{}, nil); err != nil {
+		t.Fatalf("syncPageRestrictions() error: %v", err)
+	}
+	if got := remote.restrictionsByPage["1"]; len(got.Read) != 0 || len(got.Update) != 0 {
+		t.Fatalf("empty restrictions key should clear remote restrictions, got %+v", got)
+	}
+}
+
+func TestSyncPageRestrictions_PermissionDeniedIsDiagnostic(t *testing.T) {
+	remote := newRollbackPushRemote()
+	remote.setRestrictionsErr = &confluence.APIError{StatusCode: http.StatusForbidden, Method: http.MethodPut, URL: "/restriction", Message: "forbidden"}
+
+	var diagnostics []PushDiagnostic
+	local := &fs.Restrictions{Read: []string{"group:legal"}}
+	if err := syncPageRestrictions(context.Background(), remote, "Secret.md", "1", local, &diagnostics); err != nil {
+		t.Fatalf("syncPageRestrictions() should not fail on a permission error: %v", err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Code != "RESTRICTIONS_PERMISSION_DENIED" || diagnostics[0].Path != "Secret.md" {
+		t.Fatalf("unexpected diagnostics: %+v", diagnostics)
+	}
+}
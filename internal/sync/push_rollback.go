@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
 )
@@ -81,6 +82,9 @@ func (r *pushRollbackTracker) clearMetadataSnapshot() {
 	r.metadataRestoreReq = false
 }
 
+// pushRollbackTimeout bounds the remote calls that undo a failed page push.
+const pushRollbackTimeout = 2 * time.Minute
+
 func (r *pushRollbackTracker) rollback(ctx context.Context, remote PushRemote) error {
 	var rollbackErr error
 
@@ -2,6 +2,8 @@ package sync
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -93,6 +95,74 @@ func TestPush_RollbackDeletesCreatedPageAndAttachmentsOnUpdateFailure(t *testing
 	}
 }
 
+func TestPush_ContinueOnErrorRecordsFailureAndPushesRemainingPages(t *testing.T) {
+	spaceDir := t.TempDir()
+	for _, name := range []string{"Bad", "Good"} {
+		if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, strings.ToLower(name)+".md"), fs.MarkdownDocument{
+			Frontmatter: fs.Frontmatter{Title: name},
+			Body:        name + " body\n",
+		}); err != nil {
+			t.Fatalf("write markdown: %v", err)
+		}
+	}
+
+	remote := newRollbackPushRemote()
+	remote.failUpdateTitle = "Bad"
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey:        "ENG",
+		SpaceDir:        spaceDir,
+		Domain:          "https://example.atlassian.net",
+		State:           fs.SpaceState{SpaceKey: "ENG"},
+		ConflictPolicy:  PushConflictPolicyCancel,
+		ContinueOnError: true,
+		Changes: []PushFileChange{
+			{Type: PushChangeAdd, Path: "bad.md"},
+			{Type: PushChangeAdd, Path: "good.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+
+	if len(result.Failures) != 1 {
+		t.Fatalf("failures = %+v, want one", result.Failures)
+	}
+	failure := result.Failures[0]
+	if failure.Path != "bad.md" || failure.Type != PushChangeAdd || failure.Err == nil {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if !strings.Contains(failure.Err.Error(), "simulated update failure") {
+		t.Fatalf("failure reason = %v", failure.Err)
+	}
+
+	if len(result.Commits) != 1 || result.Commits[0].Path != "good.md" {
+		t.Fatalf("commits = %+v, want only good.md", result.Commits)
+	}
+	if _, tracked := result.State.PagePathIndex["bad.md"]; tracked {
+		t.Fatalf("failed page must not be tracked in state: %+v", result.State.PagePathIndex)
+	}
+	if result.State.PagePathIndex["good.md"] == "" {
+		t.Fatalf("pushed page missing from state: %+v", result.State.PagePathIndex)
+	}
+	if len(remote.deletePageCalls) != 1 {
+		t.Fatalf("delete page calls = %v, want the failed page rolled back", remote.deletePageCalls)
+	}
+}
+
+func TestPush_ContinueOnErrorStillStopsOnConflict(t *testing.T) {
+	err := &PushConflictError{Path: "a.md", PageID: "1"}
+	if isRecoverablePushFailure(err) {
+		t.Fatal("conflicts must stop the push")
+	}
+	if isRecoverablePushFailure(fmt.Errorf("update: %w", context.Canceled)) {
+		t.Fatal("cancellation must stop the push")
+	}
+	if !isRecoverablePushFailure(errors.New("update page: boom")) {
+		t.Fatal("plain page errors should be recoverable")
+	}
+}
+
 func TestPush_RollbackRestoresMetadataOnSyncFailure(t *testing.T) {
 	spaceDir := t.TempDir()
 	mdPath := filepath.Join(spaceDir, "root.md")
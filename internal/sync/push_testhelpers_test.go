@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
@@ -80,6 +81,22 @@ func (f *fakeFolderPushRemote) RemoveLabel(_ context.Context, pageID string, lab
 	return nil
 }
 
+func (f *fakeFolderPushRemote) GetRestrictions(_ context.Context, pageID string) (confluence.PageRestrictions, error) {
+	return confluence.PageRestrictions{}, nil
+}
+
+func (f *fakeFolderPushRemote) SetRestrictions(_ context.Context, pageID string, restrictions confluence.PageRestrictions) error {
+	return nil
+}
+
+func (f *fakeFolderPushRemote) GetContentProperties(_ context.Context, _ string) ([]confluence.ContentProperty, error) {
+	return nil, nil
+}
+
+func (f *fakeFolderPushRemote) SetContentProperty(_ context.Context, _ string, property confluence.ContentProperty) (confluence.ContentProperty, error) {
+	return property, nil
+}
+
 func (f *fakeFolderPushRemote) CreatePage(_ context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
 	return confluence.Page{}, nil
 }
@@ -153,6 +170,11 @@ type rollbackPushRemote struct {
 	pagesByID                 map[string]confluence.Page
 	contentStatuses           map[string]string
 	labelsByPage              map[string][]string
+	restrictionsByPage        map[string]confluence.PageRestrictions
+	setRestrictionsCalls      []string
+	setRestrictionsErr        error
+	propertiesByPage          map[string][]confluence.ContentProperty
+	setPropertyCalls          []confluence.ContentProperty
 	folders                   []confluence.Folder
 	attachmentsByPage         map[string][]confluence.Attachment
 	nextPageID                int
@@ -179,7 +201,9 @@ type rollbackPushRemote struct {
 	createFolderErr           error
 	getContentStatusErr       error
 	failUpdate                bool
+	failUpdateTitle           string
 	failCreatePageErr         error
+	enforceUniqueTitles       bool
 	failAddLabels             bool
 	failSetContentStatus      bool
 	failDeleteContentStatus   bool
@@ -203,6 +227,7 @@ func newRollbackPushRemote() *rollbackPushRemote {
 		pagesByID:             map[string]confluence.Page{},
 		contentStatuses:       map[string]string{},
 		labelsByPage:          map[string][]string{},
+		restrictionsByPage:    map[string]confluence.PageRestrictions{},
 		attachmentsByPage:     map[string][]confluence.Attachment{},
 		updateInputsByPageID:  map[string]confluence.PageUpsertInput{},
 		availableStatesByPage: map[string][]confluence.ContentState{},
@@ -332,11 +357,36 @@ func (f *rollbackPushRemote) RemoveLabel(_ context.Context, pageID string, label
 	return nil
 }
 
+func (f *rollbackPushRemote) GetRestrictions(_ context.Context, pageID string) (confluence.PageRestrictions, error) {
+	return f.restrictionsByPage[pageID], nil
+}
+
+func (f *rollbackPushRemote) SetRestrictions(_ context.Context, pageID string, restrictions confluence.PageRestrictions) error {
+	f.setRestrictionsCalls = append(f.setRestrictionsCalls, pageID)
+	if f.setRestrictionsErr != nil {
+		return f.setRestrictionsErr
+	}
+	f.restrictionsByPage[pageID] = restrictions
+	return nil
+}
+
+func (f *rollbackPushRemote) GetContentProperties(_ context.Context, pageID string) ([]confluence.ContentProperty, error) {
+	return f.propertiesByPage[pageID], nil
+}
+
+func (f *rollbackPushRemote) SetContentProperty(_ context.Context, _ string, property confluence.ContentProperty) (confluence.ContentProperty, error) {
+	f.setPropertyCalls = append(f.setPropertyCalls, property)
+	return property, nil
+}
+
 func (f *rollbackPushRemote) CreatePage(_ context.Context, input confluence.PageUpsertInput) (confluence.Page, error) {
 	f.createPageCalls++
 	if f.failCreatePageErr != nil {
 		return confluence.Page{}, f.failCreatePageErr
 	}
+	if err := f.checkUniqueTitle("", input.Title); err != nil {
+		return confluence.Page{}, err
+	}
 	id := fmt.Sprintf("new-page-%d", f.nextPageID)
 	f.nextPageID++
 	page := confluence.Page{
@@ -358,9 +408,12 @@ func (f *rollbackPushRemote) UpdatePage(_ context.Context, pageID string, input
 	f.updatePageCalls++
 	f.updateInputsByPageID[pageID] = input
 	f.updateCallInputs = append(f.updateCallInputs, input)
-	if f.failUpdate {
+	if f.failUpdate || (f.failUpdateTitle != "" && input.Title == f.failUpdateTitle) {
 		return confluence.Page{}, errors.New("simulated update failure")
 	}
+	if err := f.checkUniqueTitle(pageID, input.Title); err != nil {
+		return confluence.Page{}, err
+	}
 	if strings.TrimSpace(f.rejectParentID) != "" && strings.TrimSpace(input.ParentPageID) == strings.TrimSpace(f.rejectParentID) {
 		err := f.rejectParentErr
 		if err == nil {
@@ -387,6 +440,24 @@ func (f *rollbackPushRemote) UpdatePage(_ context.Context, pageID string, input
 	return updated, nil
 }
 
+func (f *rollbackPushRemote) checkUniqueTitle(pageID, title string) error {
+	if !f.enforceUniqueTitles {
+		return nil
+	}
+	for _, page := range f.pages {
+		if page.ID != pageID && strings.EqualFold(page.Title, title) {
+			return &confluence.APIError{
+				StatusCode: http.StatusBadRequest,
+				Method:     "POST",
+				URL:        "/wiki/api/v2/pages",
+				Message:    "a page with this title already exists in the space — choose a unique title",
+				Body:       `{"errors":[{"status":400,"code":"INVALID_REQUEST_PARAMETER","title":"A page with this title already exists: A page already exists with the same TITLE in this space"}]}`,
+			}
+		}
+	}
+	return nil
+}
+
 func (f *rollbackPushRemote) ArchivePages(_ context.Context, _ []string) (confluence.ArchiveResult, error) {
 	if f.archivePagesErr != nil {
 		return confluence.ArchiveResult{}, f.archivePagesErr
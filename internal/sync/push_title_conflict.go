@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+)
+
+// maxTitleConflictSuffix bounds the " (n)" suffixes tried under
+// PushTitleConflictSuffix before push gives up.
+const maxTitleConflictSuffix = 20
+
+// isTitleConflictError reports whether Confluence rejected a page write
+// because the title is already used by another page in the space.
+func isTitleConflictError(err error) bool {
+	if err == nil {
+		return false
+	}
+	text := err.Error()
+	var apiErr *confluence.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest, http.StatusConflict:
+		default:
+			return false
+		}
+		text = apiErr.Message + " " + apiErr.Body
+	}
+	lower := strings.ToLower(text)
+	return strings.Contains(lower, "title_already_exists") ||
+		strings.Contains(lower, "title already exists") ||
+		strings.Contains(lower, "already exists with the same title") ||
+		strings.Contains(lower, "a page with this title already exists")
+}
+
+// writePageResolvingTitleConflict runs write with title. When Confluence
+// reports the title as taken, it either fails with an error naming the
+// conflicting page or, under PushTitleConflictSuffix, retries with
+// "title (2)", "title (3)", ... and returns the title that was accepted.
+// Only page creates go through here; see updatePageFailingOnTitleConflict.
+func writePageResolvingTitleConflict(
+	ctx context.Context,
+	remote PushRemote,
+	spaceID string,
+	opts *PushOptions,
+	relPath string,
+	title string,
+	diagnostics *[]PushDiagnostic,
+	write func(title string) (confluence.Page, error),
+) (confluence.Page, string, error) {
+	page, err := write(title)
+	if err == nil || !isTitleConflictError(err) {
+		return page, title, err
+	}
+	if opts.TitleConflictPolicy != PushTitleConflictSuffix {
+		return confluence.Page{}, title, titleConflictError(ctx, remote, spaceID, relPath, title, true)
+	}
+
+	for n := 2; n <= maxTitleConflictSuffix; n++ {
+		candidate := fmt.Sprintf("%s (%d)", title, n)
+		page, err = write(candidate)
+		if err == nil {
+			appendPushDiagnostic(
+				diagnostics,
+				relPath,
+				"TITLE_CONFLICT_SUFFIXED",
+				fmt.Sprintf("title %q is already used in the space; published as %q and updated frontmatter title", title, candidate),
+			)
+			return page, candidate, nil
+		}
+		if !isTitleConflictError(err) {
+			return confluence.Page{}, title, err
+		}
+	}
+	return confluence.Page{}, title, fmt.Errorf(
+		"title %q and suffixes (2) through (%d) are all used in the space; rename the page (frontmatter title or first H1)",
+		title,
+		maxTitleConflictSuffix,
+	)
+}
+
+// updatePageFailingOnTitleConflict updates an existing page. A taken title
+// always fails with an error naming the conflicting page: suffixing would
+// silently rename a page that already exists.
+func updatePageFailingOnTitleConflict(ctx context.Context, remote PushRemote, spaceID, relPath, pageID string, input confluence.PageUpsertInput) (confluence.Page, error) {
+	page, err := remote.UpdatePage(ctx, pageID, input)
+	if err != nil && isTitleConflictError(err) {
+		return confluence.Page{}, titleConflictError(ctx, remote, spaceID, relPath, input.Title, false)
+	}
+	return page, err
+}
+
+// titleConflictError names the remote page holding title when it can be
+// found, so the user knows what to rename or reconcile. suffixable says
+// whether --on-title-conflict=suffix would resolve the conflict.
+func titleConflictError(ctx context.Context, remote PushRemote, spaceID, relPath, title string, suffixable bool) error {
+	hint := "rename the page (frontmatter title or first H1) or reconcile the conflicting remote page"
+	lookupHint := "inspect the space for hidden or permission-restricted pages"
+	if suffixable {
+		hint = "rename the page (frontmatter title or first H1), reconcile the conflicting remote page, or rerun with --on-title-conflict=suffix"
+		lookupHint += ", or rerun with --on-title-conflict=suffix"
+	}
+	conflictPage, conflictStatuses, resolved := findRemoteTitleCollision(ctx, remote, spaceID, title)
+	if resolved {
+		return fmt.Errorf(
+			"remote page title collision for %s: %q is already used by page id=%s status=%s title=%q; %s",
+			relPath,
+			title,
+			conflictPage.ID,
+			conflictPage.Status,
+			conflictPage.Title,
+			hint,
+		)
+	}
+	return fmt.Errorf(
+		"remote page title collision for %s: %q is already used in the space, but the conflicting page was not discoverable through current/draft/archived title lookups (checked: %s); %s",
+		relPath,
+		title,
+		strings.Join(conflictStatuses, ", "),
+		lookupHint,
+	)
+}
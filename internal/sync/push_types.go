@@ -27,6 +27,10 @@ type PushRemote interface {
 	GetLabels(ctx context.Context, pageID string) ([]string, error)
 	AddLabels(ctx context.Context, pageID string, labels []string) error
 	RemoveLabel(ctx context.Context, pageID string, labelName string) error
+	GetRestrictions(ctx context.Context, pageID string) (confluence.PageRestrictions, error)
+	SetRestrictions(ctx context.Context, pageID string, restrictions confluence.PageRestrictions) error
+	GetContentProperties(ctx context.Context, pageID string) ([]confluence.ContentProperty, error)
+	SetContentProperty(ctx context.Context, pageID string, property confluence.ContentProperty) (confluence.ContentProperty, error)
 	CreatePage(ctx context.Context, input confluence.PageUpsertInput) (confluence.Page, error)
 	UpdatePage(ctx context.Context, pageID string, input confluence.PageUpsertInput) (confluence.Page, error)
 	ArchivePages(ctx context.Context, pageIDs []string) (confluence.ArchiveResult, error)
@@ -50,6 +54,15 @@ const (
 	PushConflictPolicyCancel    PushConflictPolicy = "cancel"
 )
 
+// PushTitleConflictPolicy controls what push does when Confluence rejects a
+// page title because another page in the space already uses it.
+type PushTitleConflictPolicy string
+
+const (
+	PushTitleConflictFail   PushTitleConflictPolicy = "fail"
+	PushTitleConflictSuffix PushTitleConflictPolicy = "suffix"
+)
+
 // PushChangeType is the git-derived file change type for push planning.
 type PushChangeType string
 
@@ -81,11 +94,52 @@ type PushOptions struct {
 	DryRun              bool
 	ArchiveTimeout      time.Duration
 	ArchivePollInterval time.Duration
-	Progress            Progress
-	folderListTracker   *folderListFallbackTracker
-	folderMode          tenantFolderMode
-	contentStatusMode   tenantContentStatusMode
-	contentStateCatalog pushContentStateCatalog
+	MaxAttachmentBytes  int64
+	// NewPageParent is a page ID or tracked Markdown path used as the parent
+	// of pages created by this push.
+	NewPageParent string
+	// TitleConflictPolicy decides between failing and retrying with a
+	// " (2)", " (3)", ... title suffix when a title is already taken.
+	TitleConflictPolicy PushTitleConflictPolicy
+	// PlaceholderBodyADF is the body of pages created before their converted
+	// content is written. Empty uses an empty ADF document.
+	PlaceholderBodyADF json.RawMessage
+	// ContinueOnError records per-page failures in PushResult.Failures and
+	// keeps pushing the remaining changes instead of stopping at the first
+	// one. Conflicts, folder fallbacks and cancellation still stop the run.
+	ContinueOnError bool
+	// SkipConsistencyWait skips reading pages back after they are created or
+	// updated. Pushes are faster, but later steps of the same push may act
+	// on stale data while Confluence catches up.
+	SkipConsistencyWait bool
+	// ParentByTitle lets a new page whose directory has no local parent page
+	// use the remote page titled like the directory as its parent, instead
+	// of creating a folder. Ambiguous titles are reported and not used.
+	ParentByTitle bool
+	// StripTitleHeading puts back the leading title H1 that pull leaves out
+	// of Markdown bodies (see PullOptions.StripTitleHeading), on pages whose
+	// current body starts with it. The H1 takes the pushed title.
+	StripTitleHeading bool
+	// OrderPrefix marks a space laid out with sibling order prefixes (see
+	// PagePathLayout): the prefix is dropped from directory names before they
+	// become folder titles or are matched against remote page titles.
+	OrderPrefix bool
+	// FilenameMode is the space's filename mode, used to match directory
+	// names against the path segments pull derives from remote page titles.
+	FilenameMode fs.FilenameMode
+	// VersionMessage is the Confluence edit comment for every page this push
+	// updates. When empty, VersionMessageByPath supplies a per-page comment
+	// keyed by space-relative path.
+	VersionMessage       string
+	VersionMessageByPath map[string]string
+	Progress             Progress
+	newPageParentID      string
+	createdPageIDs       map[string]struct{}
+	assetContents        assetContentCache
+	folderListTracker    *folderListFallbackTracker
+	folderMode           tenantFolderMode
+	contentStatusMode    tenantContentStatusMode
+	contentStateCatalog  pushContentStateCatalog
 }
 
 // PushCommitPlan describes local paths and metadata for one push commit.
@@ -107,11 +161,20 @@ type PushDiagnostic struct {
 	Message string
 }
 
+// PushFailure is one change that failed during a push run with
+// ContinueOnError set. The page was rolled back and has no commit plan.
+type PushFailure struct {
+	Path string
+	Type PushChangeType
+	Err  error
+}
+
 // PushResult captures outputs of push orchestration.
 type PushResult struct {
 	State       fs.SpaceState
 	Commits     []PushCommitPlan
 	Diagnostics []PushDiagnostic
+	Failures    []PushFailure
 }
 
 type pushMetadataSnapshot struct {
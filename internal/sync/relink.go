@@ -231,6 +231,12 @@ func encodeMarkdownPath(path string) string {
 }
 
 func collectLinkDestinationOccurrences(content []byte) []linkDestinationOccurrence {
+	return collectDestinationOccurrences(content, false)
+}
+
+// collectDestinationOccurrences finds inline link destinations outside code,
+// and image destinations as well when includeImages is set.
+func collectDestinationOccurrences(content []byte, includeImages bool) []linkDestinationOccurrence {
 	occurrences := make([]linkDestinationOccurrence, 0)
 
 	inFence := false
@@ -284,7 +290,7 @@ func collectLinkDestinationOccurrences(content []byte) []linkDestinationOccurren
 			continue
 		}
 
-		if content[i] == '[' && (i == 0 || content[i-1] != '!') {
+		if content[i] == '[' && (includeImages || i == 0 || content[i-1] != '!') {
 			if occurrence, next, ok := parseInlineLinkOccurrence(content, i); ok {
 				occurrences = append(occurrences, occurrence)
 				i = next
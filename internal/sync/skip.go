@@ -0,0 +1,73 @@
+package sync
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+// IsSyncSkipped reports whether the markdown file at absPath opts out of
+// sync with `cms_skip: true`. Unreadable files are not skipped so the
+// regular validation reports them.
+func IsSyncSkipped(absPath string) bool {
+	fm, err := fs.ReadFrontmatter(absPath)
+	return err == nil && fm.Skip
+}
+
+// SyncSkippedPaths returns the tracked markdown paths in spaceDir whose
+// frontmatter has `cms_skip: true`.
+func SyncSkippedPaths(spaceDir string, pagePathIndex map[string]string) map[string]struct{} {
+	skipped := map[string]struct{}{}
+	for relPath := range pagePathIndex {
+		relPath = normalizeRelPath(relPath)
+		if relPath == "" {
+			continue
+		}
+		if IsSyncSkipped(filepath.Join(spaceDir, filepath.FromSlash(relPath))) {
+			skipped[relPath] = struct{}{}
+		}
+	}
+	return skipped
+}
+
+// skipFrontmatterExcludedPushChanges drops added or modified files marked
+// `cms_skip: true` before any remote mutation, reporting each as skipped.
+// Deletions are kept: a deleted file has no frontmatter to opt out with.
+func skipFrontmatterExcludedPushChanges(spaceDir string, changes []PushFileChange) ([]PushFileChange, []PushDiagnostic) {
+	kept := make([]PushFileChange, 0, len(changes))
+	var diagnostics []PushDiagnostic
+	for _, change := range changes {
+		if change.Type != PushChangeDelete && IsSyncSkipped(filepath.Join(spaceDir, filepath.FromSlash(change.Path))) {
+			diagnostics = append(diagnostics, PushDiagnostic{
+				Path:    change.Path,
+				Code:    "SYNC_SKIPPED",
+				Message: "cms_skip: true in frontmatter; local changes were not pushed",
+			})
+			continue
+		}
+		kept = append(kept, change)
+	}
+	return kept, diagnostics
+}
+
+// heldPullPageIDs maps the IDs of tracked pages whose local copy opts out of
+// sync to their tracked path. Pull keeps those files where they are and does
+// not overwrite them, like a held local edit.
+func heldPullPageIDs(spaceDir string, pagePathIndex map[string]string, skippedPaths map[string]struct{}) map[string]string {
+	if skippedPaths == nil {
+		skippedPaths = SyncSkippedPaths(spaceDir, pagePathIndex)
+	}
+	held := map[string]string{}
+	for relPath, pageID := range pagePathIndex {
+		relPath = normalizeRelPath(relPath)
+		pageID = strings.TrimSpace(pageID)
+		if pageID == "" {
+			continue
+		}
+		if _, ok := skippedPaths[relPath]; ok {
+			held[pageID] = relPath
+		}
+	}
+	return held
+}
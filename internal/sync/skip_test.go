@@ -0,0 +1,121 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgonek/confluence-markdown-sync/internal/confluence"
+	"github.com/rgonek/confluence-markdown-sync/internal/fs"
+)
+
+func TestPush_SkipsFilesMarkedCmsSkip(t *testing.T) {
+	spaceDir := t.TempDir()
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "root.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, Skip: true},
+		Body:        "work in progress\n",
+	}); err != nil {
+		t.Fatalf("write root.md: %v", err)
+	}
+	if err := fs.WriteMarkdownDocument(filepath.Join(spaceDir, "draft.md"), fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Draft", Skip: true},
+		Body:        "not ready\n",
+	}); err != nil {
+		t.Fatalf("write draft.md: %v", err)
+	}
+
+	remote := newRollbackPushRemote()
+	remote.pagesByID["1"] = confluence.Page{ID: "1", SpaceID: "space-1", Title: "Root", Status: "current", Version: 1, BodyADF: []byte(`{"version":1,"type":"doc","content":[]}`)}
+	remote.pages = append(remote.pages, remote.pagesByID["1"])
+
+	result, err := Push(context.Background(), remote, PushOptions{
+		SpaceKey: "ENG",
+		SpaceDir: spaceDir,
+		Domain:   "https://example.atlassian.net",
+		State:    fs.SpaceState{SpaceKey: "ENG", PagePathIndex: map[string]string{"root.md": "1"}},
+		Changes: []PushFileChange{
+			{Type: PushChangeModify, Path: "root.md"},
+			{Type: PushChangeAdd, Path: "draft.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Push() error: %v", err)
+	}
+	if remote.updatePageCalls != 0 || remote.createPageCalls != 0 {
+		t.Fatalf("skipped files must not be pushed: updates=%d creates=%d", remote.updatePageCalls, remote.createPageCalls)
+	}
+	if len(result.Commits) != 0 {
+		t.Fatalf("commits = %+v, want none", result.Commits)
+	}
+	skipped := map[string]bool{}
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "SYNC_SKIPPED" {
+			skipped[diag.Path] = true
+		}
+	}
+	if !skipped["root.md"] || !skipped["draft.md"] {
+		t.Fatalf("expected SYNC_SKIPPED for both files, got %+v", result.Diagnostics)
+	}
+}
+
+func TestPull_KeepsLocalCopyOfFileMarkedCmsSkip(t *testing.T) {
+	spaceDir := filepath.Join(t.TempDir(), "ENG")
+	if err := os.MkdirAll(spaceDir, 0o750); err != nil {
+		t.Fatalf("mkdir space: %v", err)
+	}
+	localPath := filepath.Join(spaceDir, "Root.md")
+	if err := fs.WriteMarkdownDocument(localPath, fs.MarkdownDocument{
+		Frontmatter: fs.Frontmatter{Title: "Root", ID: "1", Version: 1, Skip: true},
+		Body:        "local work in progress\n",
+	}); err != nil {
+		t.Fatalf("write Root.md: %v", err)
+	}
+
+	modifiedAt := time.Date(2026, time.March, 6, 12, 0, 0, 0, time.UTC)
+	page := confluence.Page{ID: "1", SpaceID: "space-1", Title: "Renamed Root", Version: 2, LastModified: modifiedAt}
+	fullPage := page
+	fullPage.BodyADF = rawJSON(t, map[string]any{"version": 1, "type": "doc", "content": []any{}})
+	fake := &fakePullRemote{
+		space:     confluence.Space{ID: "space-1", Key: "ENG", Name: "Engineering"},
+		pages:     []confluence.Page{page},
+		pagesByID: map[string]confluence.Page{"1": fullPage},
+	}
+
+	state := fs.NewSpaceState()
+	state.PagePathIndex = map[string]string{"Root.md": "1"}
+	result, err := Pull(context.Background(), fake, PullOptions{
+		SpaceKey:  "ENG",
+		SpaceDir:  spaceDir,
+		State:     state,
+		ForceFull: true,
+	})
+	if err != nil {
+		t.Fatalf("Pull() error: %v", err)
+	}
+
+	doc, err := fs.ReadMarkdownDocument(localPath)
+	if err != nil {
+		t.Fatalf("read Root.md: %v", err)
+	}
+	if doc.Body != "local work in progress\n" || doc.Frontmatter.Version != 1 {
+		t.Fatalf("held file was overwritten: version=%d body=%q", doc.Frontmatter.Version, doc.Body)
+	}
+	if len(result.UpdatedMarkdown) != 0 || len(result.DeletedMarkdown) != 0 {
+		t.Fatalf("updated=%v deleted=%v, want the held file untouched", result.UpdatedMarkdown, result.DeletedMarkdown)
+	}
+	if got := result.State.PagePathIndex["Root.md"]; got != "1" {
+		t.Fatalf("held page should stay tracked at its path, index = %v", result.State.PagePathIndex)
+	}
+	found := false
+	for _, diag := range result.Diagnostics {
+		if diag.Code == "SYNC_SKIPPED" && diag.Path == "Root.md" && strings.Contains(diag.Message, "version 2") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected SYNC_SKIPPED diagnostic, got %+v", result.Diagnostics)
+	}
+}
@@ -24,7 +24,7 @@ func TestPlanPagePaths_TreatsParentTypesCaseInsensitively(t *testing.T) {
 		"folder-1": {ID: "folder-1", Title: "Section", ParentID: "1", ParentType: "PAGE"},
 	}
 
-	_, relByID := PlanPagePaths(spaceDir, nil, pages, folderByID)
+	_, relByID := PlanPagePaths(spaceDir, nil, pages, folderByID, PagePathLayout{FilenameMode: fs.FilenameModeConservative})
 
 	if got := relByID["1"]; got != "Root/Root.md" {
 		t.Fatalf("root path = %q, want Root/Root.md", got)